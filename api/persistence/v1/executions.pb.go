@@ -83,6 +83,15 @@ type ShardInfo struct {
 	ReplicationDlqAckLevel       map[string]int64      `protobuf:"bytes,13,rep,name=replication_dlq_ack_level,json=replicationDlqAckLevel,proto3" json:"replication_dlq_ack_level,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 	VisibilityAckLevel           int64                 `protobuf:"varint,14,opt,name=visibility_ack_level,json=visibilityAckLevel,proto3" json:"visibility_ack_level,omitempty"`
 	TieredStorageAckLevel        int64                 `protobuf:"varint,15,opt,name=tiered_storage_ack_level,json=tieredStorageAckLevel,proto3" json:"tiered_storage_ack_level,omitempty"`
+	// ack levels of registered task categories by category ID, the fire time in unix nanos for scheduled
+	// categories and the task ID for immediate categories
+	QueueAckLevels map[int32]int64 `protobuf:"bytes,16,rep,name=queue_ack_levels,json=queueAckLevels,proto3" json:"queue_ack_levels,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// in progress namespace failovers by failover ID, persisted so that they are resumed after a shard reload
+	TransferFailoverLevels map[string]*TransferFailoverLevel `protobuf:"bytes,17,rep,name=transfer_failover_levels,json=transferFailoverLevels,proto3" json:"transfer_failover_levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TimerFailoverLevels    map[string]*TimerFailoverLevel    `protobuf:"bytes,18,rep,name=timer_failover_levels,json=timerFailoverLevels,proto3" json:"timer_failover_levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// ack levels of namespaces with outstanding tasks by category ID, namespaces which are not included have
+	// acked all tasks read by the queue
+	NamespaceAckLevels map[int32]*NamespaceAckLevels `protobuf:"bytes,19,rep,name=namespace_ack_levels,json=namespaceAckLevels,proto3" json:"namespace_ack_levels,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *ShardInfo) Reset()      { *m = ShardInfo{} }
@@ -222,6 +231,228 @@ func (m *ShardInfo) GetTieredStorageAckLevel() int64 {
 	return 0
 }
 
+func (m *ShardInfo) GetQueueAckLevels() map[int32]int64 {
+	if m != nil {
+		return m.QueueAckLevels
+	}
+	return nil
+}
+
+func (m *ShardInfo) GetTransferFailoverLevels() map[string]*TransferFailoverLevel {
+	if m != nil {
+		return m.TransferFailoverLevels
+	}
+	return nil
+}
+
+func (m *ShardInfo) GetTimerFailoverLevels() map[string]*TimerFailoverLevel {
+	if m != nil {
+		return m.TimerFailoverLevels
+	}
+	return nil
+}
+
+func (m *ShardInfo) GetNamespaceAckLevels() map[int32]*NamespaceAckLevels {
+	if m != nil {
+		return m.NamespaceAckLevels
+	}
+	return nil
+}
+
+type NamespaceAckLevels struct {
+	// ack levels by namespace ID, encoded as in queue_ack_levels
+	AckLevels map[string]int64 `protobuf:"bytes,1,rep,name=ack_levels,json=ackLevels,proto3" json:"ack_levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *NamespaceAckLevels) Reset()      { *m = NamespaceAckLevels{} }
+func (*NamespaceAckLevels) ProtoMessage() {}
+func (*NamespaceAckLevels) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67a714d0e7ba9f37, []int{1}
+}
+func (m *NamespaceAckLevels) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NamespaceAckLevels) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NamespaceAckLevels.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NamespaceAckLevels) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceAckLevels.Merge(m, src)
+}
+func (m *NamespaceAckLevels) XXX_Size() int {
+	return m.Size()
+}
+func (m *NamespaceAckLevels) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceAckLevels.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceAckLevels proto.InternalMessageInfo
+
+func (m *NamespaceAckLevels) GetAckLevels() map[string]int64 {
+	if m != nil {
+		return m.AckLevels
+	}
+	return nil
+}
+
+type TransferFailoverLevel struct {
+	StartTime    *time.Time `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time,omitempty"`
+	MinLevel     int64      `protobuf:"varint,2,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	CurrentLevel int64      `protobuf:"varint,3,opt,name=current_level,json=currentLevel,proto3" json:"current_level,omitempty"`
+	MaxLevel     int64      `protobuf:"varint,4,opt,name=max_level,json=maxLevel,proto3" json:"max_level,omitempty"`
+	NamespaceIds []string   `protobuf:"bytes,5,rep,name=namespace_ids,json=namespaceIds,proto3" json:"namespace_ids,omitempty"`
+}
+
+func (m *TransferFailoverLevel) Reset()      { *m = TransferFailoverLevel{} }
+func (*TransferFailoverLevel) ProtoMessage() {}
+func (*TransferFailoverLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67a714d0e7ba9f37, []int{2}
+}
+func (m *TransferFailoverLevel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferFailoverLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferFailoverLevel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransferFailoverLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferFailoverLevel.Merge(m, src)
+}
+func (m *TransferFailoverLevel) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferFailoverLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferFailoverLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferFailoverLevel proto.InternalMessageInfo
+
+func (m *TransferFailoverLevel) GetStartTime() *time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *TransferFailoverLevel) GetMinLevel() int64 {
+	if m != nil {
+		return m.MinLevel
+	}
+	return 0
+}
+
+func (m *TransferFailoverLevel) GetCurrentLevel() int64 {
+	if m != nil {
+		return m.CurrentLevel
+	}
+	return 0
+}
+
+func (m *TransferFailoverLevel) GetMaxLevel() int64 {
+	if m != nil {
+		return m.MaxLevel
+	}
+	return 0
+}
+
+func (m *TransferFailoverLevel) GetNamespaceIds() []string {
+	if m != nil {
+		return m.NamespaceIds
+	}
+	return nil
+}
+
+type TimerFailoverLevel struct {
+	StartTime    *time.Time `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time,omitempty"`
+	MinLevel     *time.Time `protobuf:"bytes,2,opt,name=min_level,json=minLevel,proto3,stdtime" json:"min_level,omitempty"`
+	CurrentLevel *time.Time `protobuf:"bytes,3,opt,name=current_level,json=currentLevel,proto3,stdtime" json:"current_level,omitempty"`
+	MaxLevel     *time.Time `protobuf:"bytes,4,opt,name=max_level,json=maxLevel,proto3,stdtime" json:"max_level,omitempty"`
+	NamespaceIds []string   `protobuf:"bytes,5,rep,name=namespace_ids,json=namespaceIds,proto3" json:"namespace_ids,omitempty"`
+}
+
+func (m *TimerFailoverLevel) Reset()      { *m = TimerFailoverLevel{} }
+func (*TimerFailoverLevel) ProtoMessage() {}
+func (*TimerFailoverLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67a714d0e7ba9f37, []int{3}
+}
+func (m *TimerFailoverLevel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimerFailoverLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimerFailoverLevel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TimerFailoverLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimerFailoverLevel.Merge(m, src)
+}
+func (m *TimerFailoverLevel) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimerFailoverLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimerFailoverLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimerFailoverLevel proto.InternalMessageInfo
+
+func (m *TimerFailoverLevel) GetStartTime() *time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *TimerFailoverLevel) GetMinLevel() *time.Time {
+	if m != nil {
+		return m.MinLevel
+	}
+	return nil
+}
+
+func (m *TimerFailoverLevel) GetCurrentLevel() *time.Time {
+	if m != nil {
+		return m.CurrentLevel
+	}
+	return nil
+}
+
+func (m *TimerFailoverLevel) GetMaxLevel() *time.Time {
+	if m != nil {
+		return m.MaxLevel
+	}
+	return nil
+}
+
+func (m *TimerFailoverLevel) GetNamespaceIds() []string {
+	if m != nil {
+		return m.NamespaceIds
+	}
+	return nil
+}
+
 // execution column
 type WorkflowExecutionInfo struct {
 	NamespaceId                       string         `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
@@ -281,12 +512,14 @@ type WorkflowExecutionInfo struct {
 	ExecutionTime        *time.Time `protobuf:"bytes,60,opt,name=execution_time,json=executionTime,proto3,stdtime" json:"execution_time,omitempty"`
 	// If continued-as-new, or retried, or cron, holds the new run id.
 	NewExecutionRunId string `protobuf:"bytes,61,opt,name=new_execution_run_id,json=newExecutionRunId,proto3" json:"new_execution_run_id,omitempty"`
+	// Number of consecutive workflow task failures and timeouts, reset when a workflow task completes.
+	WorkflowTaskConsecutiveFailures int32 `protobuf:"varint,62,opt,name=workflow_task_consecutive_failures,json=workflowTaskConsecutiveFailures,proto3" json:"workflow_task_consecutive_failures,omitempty"`
 }
 
 func (m *WorkflowExecutionInfo) Reset()      { *m = WorkflowExecutionInfo{} }
 func (*WorkflowExecutionInfo) ProtoMessage() {}
 func (*WorkflowExecutionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{1}
+	return fileDescriptor_67a714d0e7ba9f37, []int{4}
 }
 func (m *WorkflowExecutionInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -687,6 +920,13 @@ func (m *WorkflowExecutionInfo) GetNewExecutionRunId() string {
 	return ""
 }
 
+func (m *WorkflowExecutionInfo) GetWorkflowTaskConsecutiveFailures() int32 {
+	if m != nil {
+		return m.WorkflowTaskConsecutiveFailures
+	}
+	return 0
+}
+
 type ExecutionStats struct {
 	HistorySize int64 `protobuf:"varint,1,opt,name=history_size,json=historySize,proto3" json:"history_size,omitempty"`
 }
@@ -694,7 +934,7 @@ type ExecutionStats struct {
 func (m *ExecutionStats) Reset()      { *m = ExecutionStats{} }
 func (*ExecutionStats) ProtoMessage() {}
 func (*ExecutionStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{2}
+	return fileDescriptor_67a714d0e7ba9f37, []int{5}
 }
 func (m *ExecutionStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -741,7 +981,7 @@ type WorkflowExecutionState struct {
 func (m *WorkflowExecutionState) Reset()      { *m = WorkflowExecutionState{} }
 func (*WorkflowExecutionState) ProtoMessage() {}
 func (*WorkflowExecutionState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{3}
+	return fileDescriptor_67a714d0e7ba9f37, []int{6}
 }
 func (m *WorkflowExecutionState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -818,7 +1058,7 @@ type TransferTaskInfo struct {
 func (m *TransferTaskInfo) Reset()      { *m = TransferTaskInfo{} }
 func (*TransferTaskInfo) ProtoMessage() {}
 func (*TransferTaskInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{4}
+	return fileDescriptor_67a714d0e7ba9f37, []int{7}
 }
 func (m *TransferTaskInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -957,7 +1197,7 @@ type ReplicationTaskInfo struct {
 func (m *ReplicationTaskInfo) Reset()      { *m = ReplicationTaskInfo{} }
 func (*ReplicationTaskInfo) ProtoMessage() {}
 func (*ReplicationTaskInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{5}
+	return fileDescriptor_67a714d0e7ba9f37, []int{8}
 }
 func (m *ReplicationTaskInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1084,7 +1324,7 @@ type VisibilityTaskInfo struct {
 func (m *VisibilityTaskInfo) Reset()      { *m = VisibilityTaskInfo{} }
 func (*VisibilityTaskInfo) ProtoMessage() {}
 func (*VisibilityTaskInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{6}
+	return fileDescriptor_67a714d0e7ba9f37, []int{9}
 }
 func (m *VisibilityTaskInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1176,7 +1416,7 @@ type TieredStorageTaskInfo struct {
 func (m *TieredStorageTaskInfo) Reset()      { *m = TieredStorageTaskInfo{} }
 func (*TieredStorageTaskInfo) ProtoMessage() {}
 func (*TieredStorageTaskInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{7}
+	return fileDescriptor_67a714d0e7ba9f37, []int{10}
 }
 func (m *TieredStorageTaskInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1272,7 +1512,7 @@ type TimerTaskInfo struct {
 func (m *TimerTaskInfo) Reset()      { *m = TimerTaskInfo{} }
 func (*TimerTaskInfo) ProtoMessage() {}
 func (*TimerTaskInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{8}
+	return fileDescriptor_67a714d0e7ba9f37, []int{11}
 }
 func (m *TimerTaskInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1421,7 +1661,7 @@ type ActivityInfo struct {
 func (m *ActivityInfo) Reset()      { *m = ActivityInfo{} }
 func (*ActivityInfo) ProtoMessage() {}
 func (*ActivityInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{9}
+	return fileDescriptor_67a714d0e7ba9f37, []int{12}
 }
 func (m *ActivityInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1673,7 +1913,7 @@ type TimerInfo struct {
 func (m *TimerInfo) Reset()      { *m = TimerInfo{} }
 func (*TimerInfo) ProtoMessage() {}
 func (*TimerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{10}
+	return fileDescriptor_67a714d0e7ba9f37, []int{13}
 }
 func (m *TimerInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1754,7 +1994,7 @@ type ChildExecutionInfo struct {
 func (m *ChildExecutionInfo) Reset()      { *m = ChildExecutionInfo{} }
 func (*ChildExecutionInfo) ProtoMessage() {}
 func (*ChildExecutionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{11}
+	return fileDescriptor_67a714d0e7ba9f37, []int{14}
 }
 func (m *ChildExecutionInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1864,7 +2104,7 @@ type RequestCancelInfo struct {
 func (m *RequestCancelInfo) Reset()      { *m = RequestCancelInfo{} }
 func (*RequestCancelInfo) ProtoMessage() {}
 func (*RequestCancelInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{12}
+	return fileDescriptor_67a714d0e7ba9f37, []int{15}
 }
 func (m *RequestCancelInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1936,7 +2176,7 @@ type SignalInfo struct {
 func (m *SignalInfo) Reset()      { *m = SignalInfo{} }
 func (*SignalInfo) ProtoMessage() {}
 func (*SignalInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{13}
+	return fileDescriptor_67a714d0e7ba9f37, []int{16}
 }
 func (m *SignalInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2031,7 +2271,7 @@ type Checksum struct {
 func (m *Checksum) Reset()      { *m = Checksum{} }
 func (*Checksum) ProtoMessage() {}
 func (*Checksum) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67a714d0e7ba9f37, []int{14}
+	return fileDescriptor_67a714d0e7ba9f37, []int{17}
 }
 func (m *Checksum) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2086,7 +2326,15 @@ func init() {
 	proto.RegisterMapType((map[string]int64)(nil), "temporal.server.api.persistence.v1.ShardInfo.ClusterReplicationLevelEntry")
 	proto.RegisterMapType((map[string]*time.Time)(nil), "temporal.server.api.persistence.v1.ShardInfo.ClusterTimerAckLevelEntry")
 	proto.RegisterMapType((map[string]int64)(nil), "temporal.server.api.persistence.v1.ShardInfo.ClusterTransferAckLevelEntry")
+	proto.RegisterMapType((map[int32]*NamespaceAckLevels)(nil), "temporal.server.api.persistence.v1.ShardInfo.NamespaceAckLevelsEntry")
+	proto.RegisterMapType((map[int32]int64)(nil), "temporal.server.api.persistence.v1.ShardInfo.QueueAckLevelsEntry")
 	proto.RegisterMapType((map[string]int64)(nil), "temporal.server.api.persistence.v1.ShardInfo.ReplicationDlqAckLevelEntry")
+	proto.RegisterMapType((map[string]*TimerFailoverLevel)(nil), "temporal.server.api.persistence.v1.ShardInfo.TimerFailoverLevelsEntry")
+	proto.RegisterMapType((map[string]*TransferFailoverLevel)(nil), "temporal.server.api.persistence.v1.ShardInfo.TransferFailoverLevelsEntry")
+	proto.RegisterType((*NamespaceAckLevels)(nil), "temporal.server.api.persistence.v1.NamespaceAckLevels")
+	proto.RegisterMapType((map[string]int64)(nil), "temporal.server.api.persistence.v1.NamespaceAckLevels.AckLevelsEntry")
+	proto.RegisterType((*TransferFailoverLevel)(nil), "temporal.server.api.persistence.v1.TransferFailoverLevel")
+	proto.RegisterType((*TimerFailoverLevel)(nil), "temporal.server.api.persistence.v1.TimerFailoverLevel")
 	proto.RegisterType((*WorkflowExecutionInfo)(nil), "temporal.server.api.persistence.v1.WorkflowExecutionInfo")
 	proto.RegisterMapType((map[string]*v11.Payload)(nil), "temporal.server.api.persistence.v1.WorkflowExecutionInfo.MemoEntry")
 	proto.RegisterMapType((map[string]*v11.Payload)(nil), "temporal.server.api.persistence.v1.WorkflowExecutionInfo.SearchAttributesEntry")
@@ -2110,211 +2358,231 @@ func init() {
 }
 
 var fileDescriptor_67a714d0e7ba9f37 = []byte{
-	// 3255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3a, 0xcf, 0x73, 0xdb, 0xc6,
-	0xd5, 0xa6, 0x45, 0x89, 0xe4, 0xa3, 0x44, 0x41, 0xd0, 0x2f, 0x48, 0x96, 0x29, 0x99, 0xb1, 0x13,
-	0x39, 0x71, 0x28, 0x5b, 0x76, 0x7e, 0xe7, 0x9b, 0x6f, 0x6c, 0xd9, 0x4e, 0xc8, 0x49, 0x1c, 0x07,
-	0x52, 0xe2, 0x4c, 0xbe, 0xc9, 0x70, 0x20, 0x60, 0x25, 0xe1, 0x13, 0x08, 0xd0, 0xc0, 0x92, 0x32,
-	0x33, 0xdf, 0x21, 0x87, 0x6f, 0xda, 0x43, 0x7b, 0xc8, 0xad, 0xbd, 0xf6, 0xd6, 0x73, 0x67, 0x72,
-	0xee, 0xa1, 0x97, 0x1e, 0x33, 0x3d, 0xe5, 0xd2, 0x69, 0xa3, 0x5c, 0x7a, 0x6b, 0xfe, 0x84, 0xce,
-	0xbe, 0xdd, 0x05, 0x17, 0x20, 0x24, 0x53, 0x6e, 0x7c, 0xc8, 0x4c, 0x6f, 0xc0, 0xbe, 0x1f, 0x78,
-	0xef, 0xed, 0xdb, 0xf7, 0x6b, 0x01, 0x37, 0x29, 0x69, 0x77, 0x82, 0xd0, 0xf2, 0x36, 0x22, 0x12,
-	0xf6, 0x48, 0xb8, 0x61, 0x75, 0xdc, 0x8d, 0x0e, 0x09, 0x23, 0x37, 0xa2, 0xc4, 0xb7, 0xc9, 0x46,
-	0xef, 0xc6, 0x06, 0x79, 0x42, 0xec, 0x2e, 0x75, 0x03, 0x3f, 0xaa, 0x77, 0xc2, 0x80, 0x06, 0x7a,
-	0x4d, 0x12, 0xd5, 0x39, 0x51, 0xdd, 0xea, 0xb8, 0x75, 0x85, 0xa8, 0xde, 0xbb, 0xb1, 0x5c, 0xdd,
-	0x0f, 0x82, 0x7d, 0x8f, 0x6c, 0x20, 0xc5, 0x6e, 0x77, 0x6f, 0xc3, 0xe9, 0x86, 0x16, 0x63, 0xc2,
-	0x79, 0x2c, 0xaf, 0xa6, 0xe1, 0xd4, 0x6d, 0x93, 0x88, 0x5a, 0xed, 0x8e, 0x40, 0xb8, 0xe4, 0x90,
-	0x0e, 0xf1, 0x1d, 0xe2, 0xdb, 0x2e, 0x89, 0x36, 0xf6, 0x83, 0xfd, 0x00, 0xd7, 0xf1, 0x49, 0xa0,
-	0x5c, 0x8e, 0x85, 0x67, 0x52, 0xdb, 0x41, 0xbb, 0x1d, 0xf8, 0x4c, 0xe0, 0x36, 0x89, 0x22, 0x6b,
-	0x9f, 0x64, 0x62, 0x11, 0xbf, 0xdb, 0x8e, 0x18, 0xd2, 0x51, 0x10, 0x1e, 0xee, 0x79, 0xc1, 0x91,
-	0xc0, 0xba, 0x92, 0xc0, 0xda, 0xb3, 0x5c, 0xaf, 0x1b, 0x92, 0x61, 0x66, 0x49, 0xb4, 0x03, 0x37,
-	0xa2, 0x41, 0xd8, 0x1f, 0x46, 0x7b, 0x31, 0x81, 0x26, 0x3f, 0x35, 0x8c, 0x77, 0x35, 0xcb, 0xfc,
-	0xb1, 0x88, 0x5c, 0x23, 0x81, 0xfa, 0xca, 0xa9, 0xa8, 0x29, 0x6d, 0x5e, 0x3a, 0x15, 0x99, 0x5a,
-	0xd1, 0xa1, 0x40, 0xbc, 0x96, 0x85, 0x78, 0x92, 0x5a, 0xb5, 0xdf, 0x94, 0xa1, 0xb4, 0x7d, 0x60,
-	0x85, 0x4e, 0xc3, 0xdf, 0x0b, 0xf4, 0x25, 0x28, 0x46, 0xec, 0xa5, 0xe5, 0x3a, 0x46, 0x6e, 0x2d,
-	0xb7, 0x3e, 0x6e, 0x16, 0xf0, 0xbd, 0xe1, 0x30, 0x50, 0x68, 0xf9, 0xfb, 0x84, 0x81, 0xce, 0xaf,
-	0xe5, 0xd6, 0xc7, 0xcc, 0x02, 0xbe, 0x37, 0x1c, 0x7d, 0x0e, 0xc6, 0x83, 0x23, 0x9f, 0x84, 0xc6,
-	0xd8, 0x5a, 0x6e, 0xbd, 0x64, 0xf2, 0x17, 0x7d, 0x13, 0xe6, 0x43, 0xd2, 0xf1, 0x5c, 0x1b, 0x7d,
-	0xa4, 0x65, 0xd9, 0x87, 0x2d, 0x8f, 0xf4, 0x88, 0x67, 0xe4, 0x91, 0x7a, 0x56, 0x01, 0xde, 0xb6,
-	0x0f, 0x3f, 0x60, 0x20, 0xfd, 0x1a, 0xe8, 0x34, 0xb4, 0xfc, 0x68, 0x8f, 0x84, 0x0a, 0xc1, 0x38,
-	0x12, 0x68, 0x12, 0xa2, 0x62, 0x47, 0x34, 0xf0, 0x88, 0xdf, 0x8a, 0x5c, 0xdf, 0x26, 0xad, 0x90,
-	0xf8, 0xe4, 0xc8, 0x98, 0x40, 0xb9, 0x35, 0x0e, 0xd9, 0x66, 0x00, 0x93, 0xad, 0xeb, 0xb7, 0xa1,
-	0xdc, 0xed, 0x38, 0x16, 0x25, 0x2d, 0xe6, 0x97, 0x46, 0x61, 0x2d, 0xb7, 0x5e, 0xde, 0x5c, 0xae,
-	0x73, 0xa7, 0xad, 0x4b, 0xa7, 0xad, 0xef, 0x48, 0xa7, 0xbd, 0x93, 0xff, 0xfa, 0x6f, 0xab, 0x39,
-	0x13, 0x38, 0x11, 0x5b, 0xd6, 0x3f, 0x86, 0x39, 0x46, 0xab, 0xc8, 0xc6, 0x79, 0x15, 0x47, 0xe4,
-	0x35, 0x83, 0xd4, 0x52, 0x7e, 0x64, 0x79, 0x17, 0xaa, 0xbe, 0xd5, 0x26, 0x51, 0xc7, 0xb2, 0x49,
-	0xcb, 0x0f, 0xa8, 0xbb, 0x27, 0x0d, 0xd6, 0x63, 0xa7, 0x2f, 0xf0, 0x8d, 0x12, 0x6a, 0xbf, 0x12,
-	0x63, 0x3d, 0x50, 0x90, 0x3e, 0xe5, 0x38, 0xfa, 0x2f, 0x73, 0xb0, 0x6c, 0x7b, 0xdd, 0x88, 0x92,
-	0xb0, 0x95, 0x61, 0x40, 0x58, 0x1b, 0x5b, 0x2f, 0x6f, 0x36, 0xeb, 0x4f, 0x3f, 0xe4, 0xf5, 0xd8,
-	0x17, 0xea, 0x5b, 0x9c, 0xdf, 0x4e, 0xca, 0xea, 0xf7, 0x7c, 0x1a, 0xf6, 0xcd, 0x45, 0x3b, 0x1b,
-	0xaa, 0xff, 0x7f, 0x0e, 0x16, 0x63, 0x49, 0x92, 0xb6, 0x32, 0xca, 0x28, 0xc6, 0x7b, 0xcf, 0x26,
-	0x86, 0x6a, 0x39, 0x94, 0x41, 0xd8, 0x74, 0xce, 0xce, 0x40, 0xd0, 0x7f, 0x91, 0x83, 0x25, 0x29,
-	0x86, 0xea, 0x85, 0x5c, 0x90, 0xc9, 0x7f, 0xc3, 0x1e, 0xe6, 0x80, 0x5b, 0x86, 0x3d, 0xd2, 0x50,
-	0x66, 0x8f, 0x25, 0x55, 0x00, 0xc7, 0x7b, 0xac, 0x58, 0x64, 0x0a, 0x05, 0x69, 0x9c, 0x4d, 0x10,
-	0xe5, 0x1b, 0x77, 0xbd, 0xc7, 0xc9, 0x7d, 0x59, 0x08, 0x33, 0x81, 0xfa, 0x75, 0x98, 0xeb, 0xb9,
-	0x91, 0xbb, 0xeb, 0x7a, 0x2e, 0xed, 0x2b, 0x02, 0x54, 0xd0, 0xb9, 0xf4, 0x01, 0x2c, 0xa6, 0x78,
-	0x03, 0x0c, 0xea, 0x92, 0x90, 0x38, 0x2d, 0x16, 0x39, 0xac, 0x7d, 0xa2, 0x50, 0x4d, 0x23, 0xd5,
-	0x3c, 0x87, 0x6f, 0x73, 0xb0, 0x24, 0x5c, 0x6e, 0xc2, 0xca, 0x69, 0xae, 0xa3, 0x6b, 0x30, 0x76,
-	0x48, 0xfa, 0x18, 0x5e, 0x4a, 0x26, 0x7b, 0x64, 0xf1, 0xa3, 0x67, 0x79, 0x5d, 0x22, 0xe2, 0x0a,
-	0x7f, 0x79, 0xfb, 0xfc, 0x9b, 0xb9, 0x65, 0x1b, 0x96, 0x4e, 0xdc, 0xff, 0x0c, 0x46, 0xd7, 0x55,
-	0x46, 0xa7, 0x1e, 0x48, 0xf5, 0x23, 0x03, 0x81, 0x33, 0xf7, 0xf6, 0x4c, 0x02, 0x37, 0xe0, 0xc2,
-	0x29, 0xdb, 0x73, 0x16, 0x56, 0xb5, 0xbf, 0xac, 0xc0, 0xfc, 0x23, 0x91, 0x03, 0xee, 0xc9, 0x7c,
-	0x8d, 0x51, 0xfa, 0x12, 0x4c, 0x0e, 0x62, 0x86, 0x88, 0xd4, 0x25, 0xb3, 0x1c, 0xaf, 0x35, 0x1c,
-	0x7d, 0x15, 0xca, 0x32, 0x7f, 0xc8, 0x80, 0x5d, 0x32, 0x41, 0x2e, 0x35, 0x1c, 0xbd, 0x0e, 0xb3,
-	0x1d, 0x2b, 0x24, 0x3e, 0x6d, 0x25, 0x58, 0xf1, 0x08, 0x3e, 0xc3, 0x41, 0x0f, 0x14, 0x86, 0xd7,
-	0x40, 0x17, 0xf8, 0x2a, 0xdf, 0x3c, 0xa2, 0x6b, 0x1c, 0xf2, 0x68, 0xc0, 0xbd, 0x06, 0x53, 0x02,
-	0x3b, 0xec, 0xfa, 0x0c, 0x71, 0x9c, 0x8b, 0xc8, 0x17, 0xcd, 0xae, 0xdf, 0x70, 0x98, 0x16, 0xae,
-	0xef, 0x52, 0xd7, 0xa2, 0x04, 0xf3, 0xcd, 0x04, 0x1a, 0xa0, 0x1c, 0xaf, 0x35, 0x1c, 0xfd, 0x2d,
-	0x58, 0xb2, 0x83, 0x76, 0xc7, 0x23, 0x78, 0x74, 0x48, 0x8f, 0x31, 0xdc, 0xb5, 0xa8, 0x7d, 0xc0,
-	0xf0, 0x0b, 0x88, 0xbf, 0x30, 0x40, 0xb8, 0xc7, 0xe0, 0x77, 0x18, 0xb8, 0xe1, 0xe8, 0x17, 0x01,
-	0x58, 0x4e, 0x6c, 0x3d, 0xee, 0x92, 0x2e, 0xc1, 0x18, 0x5a, 0x32, 0x4b, 0x6c, 0xe5, 0x63, 0xb6,
-	0xc0, 0xd4, 0x89, 0xf5, 0xa0, 0xfd, 0x0e, 0x41, 0x2b, 0x18, 0xc0, 0xd5, 0x91, 0x90, 0x9d, 0x7e,
-	0x87, 0x30, 0x1b, 0xe8, 0x5f, 0xc0, 0x72, 0x8c, 0x1d, 0x97, 0x4e, 0x18, 0xde, 0x82, 0x2e, 0x35,
-	0xca, 0xe8, 0x6c, 0x4b, 0x43, 0xce, 0x76, 0x57, 0x94, 0x47, 0x77, 0xf2, 0xbf, 0x65, 0x81, 0xca,
-	0x38, 0x4a, 0x6f, 0xe6, 0x0e, 0x67, 0xc0, 0xd2, 0x4a, 0xcc, 0x9e, 0xd9, 0x4b, 0x32, 0x9e, 0x1c,
-	0x8d, 0x71, 0xac, 0x89, 0xd9, 0x8d, 0x59, 0xee, 0xc2, 0x45, 0x87, 0xec, 0x59, 0x5d, 0x4f, 0xd9,
-	0x2f, 0xb4, 0x87, 0xe4, 0x3d, 0x35, 0x1a, 0xef, 0x65, 0xc1, 0x45, 0xee, 0xed, 0x8e, 0x15, 0x1d,
-	0xca, 0x6f, 0xbc, 0x00, 0x53, 0x11, 0xb5, 0x42, 0x1a, 0x67, 0x2a, 0x1e, 0x4c, 0x26, 0x71, 0x51,
-	0x66, 0xa6, 0x57, 0x40, 0xf7, 0xac, 0x88, 0x8a, 0xcd, 0x43, 0x11, 0x5c, 0xc7, 0x98, 0x41, 0xcc,
-	0x69, 0x06, 0xc1, 0x5d, 0x63, 0x6c, 0x1b, 0x8e, 0xfe, 0x2a, 0xcc, 0x22, 0xf2, 0x9e, 0x1b, 0xc6,
-	0x24, 0xae, 0x63, 0xe8, 0x3c, 0xff, 0x33, 0xd0, 0x7d, 0x06, 0x41, 0x92, 0x86, 0xa3, 0xbf, 0x0b,
-	0x17, 0x10, 0x3d, 0xa9, 0x21, 0x97, 0xc9, 0x75, 0x8c, 0x59, 0x24, 0x5b, 0x64, 0x28, 0xaa, 0xf8,
-	0xdb, 0x0c, 0xde, 0x70, 0xf4, 0xff, 0x06, 0xe0, 0xa8, 0x98, 0xc2, 0xe7, 0x46, 0x4c, 0xe1, 0x25,
-	0xa4, 0xc1, 0xd4, 0xdd, 0x04, 0x14, 0xa9, 0xa5, 0x56, 0x15, 0xf3, 0x23, 0xb2, 0xa9, 0x30, 0xca,
-	0x4f, 0x06, 0x95, 0xc5, 0x26, 0xcc, 0x27, 0xb5, 0x90, 0x36, 0x5d, 0xe0, 0xc5, 0xd2, 0x91, 0xa2,
-	0x80, 0x34, 0xed, 0x5b, 0xb0, 0x94, 0xd2, 0xdc, 0x3e, 0x20, 0x4e, 0xd7, 0xc3, 0x83, 0xbc, 0xc8,
-	0x4f, 0x87, 0x4a, 0xb7, 0x2d, 0xc0, 0x0d, 0x87, 0x05, 0xf7, 0x0c, 0xa3, 0xf1, 0x73, 0x68, 0xf0,
-	0xe0, 0x7e, 0x94, 0x36, 0x19, 0x9e, 0xc8, 0xed, 0xb4, 0x9c, 0xd2, 0x9f, 0x96, 0x46, 0xf3, 0xa7,
-	0x84, 0x22, 0xd2, 0x91, 0x86, 0x94, 0xb7, 0x28, 0x4b, 0x88, 0xd4, 0x58, 0xc6, 0x52, 0x2e, 0x41,
-	0x73, 0x9b, 0x83, 0x12, 0x47, 0x32, 0xa1, 0x01, 0x6e, 0xc3, 0x85, 0x11, 0xb7, 0x61, 0x31, 0x43,
-	0x4b, 0xdc, 0x0f, 0x0b, 0x56, 0xb2, 0x6d, 0x2b, 0x3e, 0xb0, 0x32, 0xe2, 0x07, 0x96, 0xb2, 0x36,
-	0x80, 0x7f, 0xe2, 0x2a, 0x68, 0xb6, 0xe5, 0xdb, 0xc4, 0x6b, 0x85, 0xe4, 0x71, 0x97, 0x44, 0x94,
-	0x38, 0xc6, 0xc5, 0xb5, 0xdc, 0x7a, 0xd1, 0x9c, 0xe6, 0xeb, 0xa6, 0x5c, 0xd6, 0x43, 0xb8, 0x92,
-	0x94, 0x26, 0x08, 0xdd, 0x7d, 0xd7, 0xb7, 0xbc, 0xb4, 0x58, 0xd5, 0x11, 0xc5, 0xba, 0xa4, 0x8a,
-	0xf5, 0x91, 0x60, 0x96, 0x14, 0x6f, 0xc8, 0x45, 0x84, 0x94, 0xcc, 0x45, 0x56, 0x31, 0x4e, 0x26,
-	0x5c, 0x44, 0x08, 0xdb, 0x70, 0xf4, 0x97, 0x61, 0x26, 0xa9, 0x17, 0xa3, 0x58, 0x43, 0x8a, 0xa4,
-	0x62, 0x1c, 0x37, 0xa2, 0xae, 0x7d, 0xd8, 0x6f, 0x29, 0xc1, 0xfa, 0x12, 0xc7, 0xe5, 0x80, 0x9d,
-	0x38, 0x64, 0xef, 0xc3, 0x9a, 0xc0, 0x8d, 0xfd, 0x9c, 0x06, 0xad, 0xc1, 0x11, 0x66, 0x5e, 0x58,
-	0x1b, 0xcd, 0x0b, 0x57, 0x38, 0x23, 0xa9, 0xf0, 0x4e, 0xb0, 0x2d, 0x0f, 0x35, 0x73, 0x47, 0x03,
-	0x0a, 0xd2, 0x01, 0x5f, 0xe0, 0x3d, 0x90, 0x78, 0xd5, 0x3f, 0x81, 0x85, 0x90, 0xd0, 0xb0, 0xdf,
-	0xe2, 0x49, 0xca, 0x6b, 0xb9, 0x3e, 0x25, 0x61, 0xcf, 0xf2, 0x8c, 0xcb, 0xa3, 0x7d, 0x78, 0x0e,
-	0xc9, 0x1b, 0x9c, 0xba, 0x21, 0x88, 0x07, 0x6c, 0xdb, 0xd6, 0x13, 0xb7, 0xdd, 0x6d, 0x0f, 0xd8,
-	0x5e, 0x39, 0x0b, 0xdb, 0x0f, 0x39, 0x75, 0xcc, 0xf6, 0x56, 0x9a, 0xad, 0x50, 0x23, 0x32, 0x5e,
-	0x44, 0xb5, 0x12, 0x54, 0xe2, 0x5c, 0x45, 0xfa, 0xdb, 0xac, 0x5e, 0x65, 0x54, 0xbb, 0x96, 0x7d,
-	0x18, 0xec, 0xed, 0xb5, 0xec, 0x80, 0xec, 0xed, 0xb9, 0xb6, 0x4b, 0x7c, 0x6a, 0xbc, 0xb4, 0x96,
-	0x5b, 0xcf, 0x99, 0x8b, 0x88, 0x70, 0x87, 0xc3, 0xb7, 0x06, 0x60, 0xbd, 0x0d, 0xb5, 0x8c, 0x3c,
-	0x49, 0x9e, 0x74, 0x5c, 0x2e, 0x2e, 0x77, 0xd2, 0xf5, 0x11, 0x9d, 0x74, 0x75, 0x28, 0x61, 0xde,
-	0x8b, 0x39, 0x89, 0xde, 0x69, 0x95, 0x8b, 0xea, 0x07, 0x7e, 0x0b, 0x9f, 0xac, 0x5d, 0x8f, 0xb4,
-	0x48, 0x18, 0x06, 0x21, 0x66, 0xf5, 0xc8, 0xb8, 0xba, 0x36, 0xb6, 0x5e, 0x32, 0x2f, 0x20, 0xf0,
-	0x41, 0xe0, 0x9b, 0x12, 0xe9, 0x1e, 0xc3, 0x61, 0xf9, 0x3d, 0xd2, 0xd7, 0x41, 0x3b, 0xb0, 0x22,
-	0x4e, 0xdf, 0xea, 0x04, 0x9e, 0x6b, 0xf7, 0x8d, 0x97, 0xf1, 0x1c, 0x56, 0x0e, 0xac, 0x08, 0x29,
-	0x1e, 0xe2, 0x2a, 0x4b, 0x78, 0x76, 0x18, 0xf8, 0xb1, 0xff, 0x19, 0xaf, 0xa0, 0xa7, 0x4e, 0xb2,
-	0x45, 0xe9, 0x4b, 0xac, 0xac, 0x89, 0xdc, 0x7d, 0x76, 0x36, 0xed, 0xa0, 0xeb, 0x53, 0xa3, 0xce,
-	0xcb, 0x1a, 0xbe, 0xb6, 0xc5, 0x96, 0xf4, 0x2b, 0x30, 0x29, 0xfa, 0xf1, 0x56, 0xe4, 0x7e, 0x49,
-	0x8c, 0x0d, 0x86, 0x72, 0xe7, 0xbc, 0x91, 0x33, 0xcb, 0x62, 0x7d, 0xdb, 0xfd, 0x92, 0x75, 0x9b,
-	0x33, 0x56, 0x97, 0x06, 0xad, 0x90, 0x44, 0x84, 0xb6, 0x3a, 0x81, 0xeb, 0xd3, 0xc8, 0xb8, 0x89,
-	0xc6, 0xbb, 0x32, 0xe8, 0x18, 0x58, 0xab, 0x10, 0x8f, 0x0a, 0x7a, 0x37, 0xea, 0x26, 0xc3, 0x7e,
-	0x88, 0xc8, 0xe6, 0x34, 0xa3, 0x57, 0x16, 0xf4, 0xff, 0x83, 0x99, 0x88, 0x58, 0xa1, 0x7d, 0xc0,
-	0x7c, 0x21, 0x74, 0x77, 0xbb, 0x94, 0x44, 0xc6, 0x2d, 0x6c, 0x42, 0x3e, 0x1a, 0xa5, 0x09, 0xc9,
-	0xac, 0x47, 0xeb, 0xdb, 0xc8, 0xf2, 0x76, 0xcc, 0x91, 0xb7, 0x22, 0x5a, 0x94, 0x5a, 0xd6, 0x1f,
-	0x41, 0xbe, 0x4d, 0xda, 0x81, 0xf1, 0x1a, 0x7e, 0x70, 0xeb, 0xd9, 0x3f, 0xf8, 0x21, 0x69, 0x07,
-	0xfc, 0x23, 0xc8, 0x50, 0xff, 0x02, 0x66, 0x44, 0xbe, 0x6c, 0x71, 0x03, 0xba, 0x24, 0x32, 0x5e,
-	0x47, 0x4b, 0x5d, 0xcf, 0xfc, 0x8a, 0x30, 0x33, 0xfb, 0x82, 0xc8, 0xa6, 0xef, 0x4b, 0x3a, 0x53,
-	0xeb, 0xa5, 0x56, 0xf4, 0x9b, 0xb0, 0x20, 0x2a, 0x92, 0xd8, 0xa7, 0x45, 0x59, 0xfb, 0x06, 0x3a,
-	0xc0, 0x2c, 0x42, 0x63, 0x11, 0x79, 0x79, 0xfb, 0x3f, 0x30, 0x3d, 0x40, 0x8f, 0xa8, 0x45, 0x23,
-	0xe3, 0x4d, 0x94, 0x68, 0x73, 0x14, 0xbd, 0x63, 0x66, 0xdb, 0x8c, 0xd2, 0xac, 0x90, 0xc4, 0x7b,
-	0x22, 0x3d, 0x31, 0x51, 0xd2, 0x47, 0xec, 0xad, 0xb3, 0xa6, 0x27, 0xb3, 0x9b, 0x3e, 0x5c, 0xb7,
-	0x60, 0x71, 0xa8, 0x16, 0xa3, 0x4f, 0x50, 0xeb, 0xb7, 0x79, 0x4d, 0x92, 0xac, 0xc7, 0x76, 0x9e,
-	0x30, 0xad, 0x6f, 0xc1, 0x02, 0xd3, 0x95, 0xf0, 0x29, 0x84, 0x8b, 0x12, 0xf1, 0x73, 0xf0, 0x0e,
-	0x12, 0xcd, 0x21, 0x74, 0x27, 0x06, 0xf2, 0x03, 0xf1, 0x1e, 0x54, 0x92, 0x65, 0xb5, 0xf1, 0xee,
-	0x88, 0x0a, 0x4c, 0x11, 0xb5, 0x98, 0xd6, 0x37, 0x60, 0xce, 0x27, 0x47, 0xc3, 0xfb, 0xf4, 0x5f,
-	0xbc, 0xad, 0xf1, 0xc9, 0x51, 0x72, 0x97, 0x96, 0x1d, 0x98, 0xcf, 0xf4, 0xde, 0x8c, 0x4e, 0xed,
-	0xb5, 0x64, 0x73, 0xb9, 0x9a, 0x3c, 0x82, 0x62, 0xb0, 0xd7, 0xbb, 0x51, 0x7f, 0x68, 0xf5, 0xbd,
-	0xc0, 0x72, 0xd4, 0xae, 0xf0, 0x33, 0x28, 0xc5, 0x2e, 0xfb, 0x93, 0x72, 0x6e, 0xe6, 0x8b, 0x45,
-	0xad, 0xd4, 0xcc, 0x17, 0xa7, 0x35, 0xad, 0x99, 0x2f, 0x6a, 0xda, 0x4c, 0x33, 0x5f, 0xbc, 0xa6,
-	0xbd, 0xda, 0xcc, 0x17, 0x5f, 0xd5, 0xea, 0xcd, 0x7c, 0xf1, 0xba, 0x76, 0xa3, 0x99, 0x2f, 0xde,
-	0xd0, 0x36, 0x9b, 0xf9, 0xe2, 0xa6, 0x76, 0xb3, 0x76, 0x13, 0x2a, 0x49, 0xd7, 0x62, 0xf1, 0x2a,
-	0x11, 0x8c, 0x72, 0x3c, 0x5e, 0x29, 0x81, 0xa8, 0xf6, 0xcf, 0x1c, 0x2c, 0x0c, 0x1d, 0x44, 0x46,
-	0x4d, 0x30, 0xd9, 0x87, 0x84, 0x6d, 0xb8, 0x92, 0xec, 0x73, 0x22, 0xd9, 0x23, 0x60, 0x90, 0xec,
-	0xe7, 0x61, 0x42, 0x6c, 0x07, 0x6f, 0x47, 0xc7, 0x43, 0x3c, 0x28, 0x4d, 0x18, 0x47, 0xa7, 0xc0,
-	0xde, 0xb3, 0xb2, 0x79, 0x2b, 0xf3, 0x78, 0xe0, 0xa0, 0x33, 0x33, 0x20, 0xa0, 0x1c, 0x26, 0x67,
-	0xa1, 0xdf, 0x87, 0x09, 0xf6, 0xd0, 0x8d, 0xb0, 0x33, 0xad, 0x6c, 0xd6, 0x93, 0xa6, 0x3c, 0x9d,
-	0x4b, 0x37, 0x32, 0x05, 0x75, 0xed, 0x9b, 0x3c, 0x68, 0x72, 0x7a, 0x81, 0xbd, 0xc9, 0x4f, 0xd5,
-	0x76, 0x0f, 0x6c, 0x30, 0xa6, 0xda, 0x60, 0x0b, 0x4a, 0xbc, 0x9a, 0xee, 0x77, 0x88, 0x10, 0xfd,
-	0xc5, 0xd3, 0xed, 0x80, 0xf5, 0x73, 0xbf, 0x43, 0xcc, 0x22, 0x15, 0x4f, 0xac, 0xa5, 0xa7, 0x56,
-	0xb8, 0x4f, 0x52, 0x2d, 0x3d, 0x6f, 0xbd, 0x67, 0x38, 0x28, 0xd5, 0xd2, 0x0b, 0x7c, 0x55, 0xe6,
-	0x09, 0xde, 0x03, 0x73, 0x48, 0xb2, 0xa5, 0x17, 0xd8, 0x42, 0x81, 0x02, 0x57, 0x9f, 0x2f, 0xf2,
-	0x98, 0x97, 0x6c, 0xba, 0x8b, 0xe9, 0xa6, 0xfb, 0x1d, 0x58, 0x16, 0x2c, 0xec, 0x03, 0xd7, 0x73,
-	0x06, 0x9f, 0x0d, 0x7c, 0xaf, 0x8f, 0x3d, 0x7a, 0xd1, 0x5c, 0xe4, 0x18, 0x5b, 0x0c, 0x41, 0x7e,
-	0xfd, 0x23, 0xdf, 0xeb, 0x33, 0xd3, 0xaa, 0xfd, 0x0d, 0xa0, 0x9b, 0x42, 0x34, 0xe8, 0x69, 0x0c,
-	0x28, 0xc8, 0xa6, 0xa9, 0xcc, 0xe7, 0xd3, 0xe2, 0x55, 0x5f, 0x84, 0x82, 0x6c, 0x3c, 0x27, 0x11,
-	0x32, 0x41, 0x79, 0xbf, 0xd9, 0x80, 0x69, 0x65, 0x2a, 0x86, 0x81, 0x67, 0x6a, 0xd4, 0x06, 0x6e,
-	0x40, 0xc8, 0x40, 0xcd, 0x7c, 0xb1, 0xa2, 0x4d, 0xd7, 0x7e, 0x9d, 0x87, 0x59, 0x65, 0xfe, 0xf3,
-	0xb3, 0x71, 0x1d, 0xc5, 0x76, 0xe3, 0x49, 0xdb, 0x5d, 0x86, 0x4a, 0xaa, 0x1b, 0xe7, 0x73, 0x9a,
-	0xc9, 0x3d, 0xb5, 0x13, 0xaf, 0xc1, 0x94, 0x4f, 0x9e, 0x28, 0x48, 0x7c, 0x38, 0x53, 0x66, 0x8b,
-	0x12, 0x87, 0x15, 0x46, 0x71, 0xb7, 0xe2, 0x3a, 0xe8, 0x1e, 0xac, 0x30, 0x92, 0x6b, 0x1c, 0x65,
-	0x37, 0xb4, 0x7c, 0xfb, 0xa0, 0x45, 0x83, 0x43, 0xc2, 0xf7, 0x71, 0xd2, 0x2c, 0xf3, 0xb5, 0x1d,
-	0xb6, 0x24, 0x23, 0x3c, 0xb3, 0x44, 0x02, 0x75, 0x0a, 0x51, 0x59, 0x84, 0x37, 0xbb, 0xfe, 0x1d,
-	0x85, 0x40, 0xd9, 0xfc, 0xe9, 0xa7, 0x6d, 0xbe, 0xf6, 0xcc, 0x9b, 0x5f, 0xd2, 0xa0, 0x99, 0x2f,
-	0x82, 0x56, 0x6e, 0xe6, 0x8b, 0x93, 0xda, 0x94, 0x70, 0x87, 0x3f, 0x9c, 0x07, 0xfd, 0xd3, 0x01,
-	0xea, 0xcf, 0xdf, 0x1b, 0x14, 0x63, 0x4e, 0x3c, 0xcd, 0x98, 0x85, 0x67, 0x33, 0x66, 0xed, 0x9b,
-	0xf3, 0x30, 0xbf, 0xa3, 0x4e, 0x96, 0xff, 0x63, 0xb7, 0x91, 0xec, 0xf6, 0xbb, 0x3c, 0x4c, 0xe1,
-	0x94, 0xfc, 0x67, 0x63, 0xaf, 0x7b, 0x30, 0x29, 0x1a, 0x77, 0xce, 0x67, 0x1c, 0xf9, 0xd4, 0x4e,
-	0xc8, 0xd9, 0xa2, 0x3d, 0x47, 0x1e, 0x65, 0x3a, 0x78, 0xd1, 0x89, 0x32, 0x3e, 0x92, 0x4d, 0x2b,
-	0xf2, 0x9b, 0x40, 0x7e, 0x37, 0x46, 0x2b, 0x28, 0x44, 0x3b, 0x8b, 0xec, 0xe3, 0x89, 0x93, 0xb2,
-	0xa8, 0xee, 0x6e, 0x21, 0xb9, 0xbb, 0x57, 0x41, 0x8b, 0x53, 0x93, 0x9c, 0x1c, 0x14, 0xb1, 0xc5,
-	0x9e, 0x96, 0xeb, 0x72, 0x6c, 0xb5, 0x04, 0xc5, 0x38, 0x46, 0xf2, 0x8b, 0xbd, 0x02, 0x11, 0xf1,
-	0x51, 0xf1, 0x11, 0x78, 0x9a, 0x8f, 0x94, 0x9f, 0xd1, 0x47, 0x7e, 0x55, 0x81, 0xc9, 0xdb, 0x36,
-	0x75, 0x7b, 0x2e, 0xed, 0xa3, 0x8b, 0x28, 0x4a, 0xe5, 0x92, 0x4a, 0xbd, 0x01, 0xc6, 0x20, 0x5c,
-	0xa7, 0x46, 0xef, 0xfc, 0xae, 0x62, 0x3e, 0x86, 0x27, 0x26, 0xef, 0xef, 0x41, 0x25, 0x35, 0x95,
-	0xca, 0x8f, 0x5a, 0xcc, 0x47, 0x89, 0x09, 0xd4, 0x45, 0x31, 0xa0, 0xe5, 0xe9, 0x82, 0x9f, 0xa8,
-	0x52, 0x14, 0x8f, 0x22, 0xb7, 0x60, 0x32, 0x31, 0xf3, 0x1b, 0xf5, 0xdc, 0x94, 0x23, 0x65, 0xce,
-	0xb7, 0x0a, 0x65, 0x4b, 0xd8, 0x43, 0xe6, 0xa4, 0x92, 0x09, 0x72, 0x89, 0x97, 0x34, 0x4a, 0x65,
-	0x2b, 0xee, 0x11, 0xc2, 0xb8, 0xa6, 0xfd, 0x1c, 0x96, 0x4e, 0x9e, 0x46, 0xc1, 0x68, 0xd3, 0x9b,
-	0x85, 0x28, 0x7b, 0x0e, 0x95, 0xe2, 0x6d, 0x7b, 0x41, 0x44, 0xce, 0x7a, 0xe9, 0xa0, 0xf0, 0xde,
-	0x62, 0xf4, 0x92, 0xf7, 0x0e, 0xf6, 0x69, 0x4c, 0xd6, 0x34, 0xe3, 0x11, 0x2f, 0x1d, 0x66, 0xf9,
-	0x1c, 0x3c, 0xc9, 0xf5, 0x03, 0x98, 0x39, 0x20, 0x56, 0x48, 0x77, 0x89, 0x45, 0xcf, 0x7a, 0xd3,
-	0xa0, 0xc5, 0x94, 0x92, 0x5b, 0xd6, 0x80, 0xb4, 0x92, 0x3d, 0x20, 0xcd, 0x9c, 0x39, 0xf2, 0x74,
-	0x9f, 0x35, 0x73, 0xe4, 0x17, 0xd3, 0x72, 0x6c, 0xcc, 0xda, 0x05, 0x8d, 0x1f, 0x57, 0x2a, 0xe3,
-	0x27, 0xef, 0x07, 0xd4, 0x51, 0xe0, 0x4c, 0x72, 0x14, 0x98, 0x2c, 0x75, 0xf5, 0x74, 0xa9, 0xcb,
-	0x42, 0x42, 0xec, 0xbb, 0xc4, 0xa7, 0x2e, 0xed, 0xe3, 0x7d, 0x04, 0xce, 0x35, 0x85, 0x07, 0xf3,
-	0xe5, 0xcc, 0xf9, 0xd3, 0x5c, 0xe6, 0xfc, 0xe9, 0xe4, 0xf1, 0xe3, 0xfc, 0xf3, 0x19, 0x3f, 0x2e,
-	0x3c, 0x9f, 0xf1, 0xe3, 0xe2, 0x29, 0xe3, 0xc7, 0x1d, 0x98, 0xe7, 0x54, 0xe9, 0x91, 0x86, 0x31,
-	0xe2, 0xf1, 0x9e, 0x45, 0xf2, 0xd4, 0x30, 0xe3, 0xd4, 0xa1, 0xe6, 0xd2, 0xe9, 0x43, 0xcd, 0x11,
-	0xa6, 0x8c, 0xcb, 0x4f, 0x9f, 0x32, 0x3e, 0x00, 0x9d, 0x73, 0xe1, 0x43, 0x15, 0xfe, 0x33, 0x92,
-	0xb8, 0xa7, 0x58, 0x4b, 0x66, 0x3c, 0x01, 0x64, 0xc9, 0xe9, 0x3e, 0x7f, 0x34, 0x35, 0xa4, 0xfd,
-	0xc0, 0x8a, 0xa8, 0x58, 0x61, 0xbd, 0x94, 0xc2, 0x8f, 0xe5, 0x2b, 0x12, 0x0e, 0x5c, 0x6d, 0x05,
-	0x5d, 0x6d, 0x31, 0xa6, 0x7a, 0x84, 0xf0, 0xd8, 0xe5, 0xd2, 0x85, 0xc1, 0xc5, 0xcc, 0xc2, 0x40,
-	0x6d, 0xb7, 0xaa, 0x43, 0xed, 0xd6, 0xa7, 0xb0, 0x80, 0x9f, 0x1e, 0x1c, 0x78, 0x87, 0x50, 0xcb,
-	0xf5, 0x22, 0xbc, 0x1d, 0x18, 0x52, 0x6a, 0x68, 0x8a, 0x11, 0x99, 0x73, 0x8c, 0xfe, 0x7d, 0x49,
-	0x7e, 0x97, 0x53, 0xeb, 0x5f, 0xc0, 0x72, 0x8a, 0xaf, 0x7a, 0xbf, 0xb6, 0x36, 0xea, 0xc5, 0x4e,
-	0x82, 0xf7, 0xe0, 0xa2, 0xad, 0x99, 0x2f, 0x8e, 0x69, 0xf9, 0x66, 0xbe, 0x38, 0xa1, 0x15, 0x6a,
-	0x7f, 0xca, 0x41, 0x09, 0x2b, 0xa6, 0xa7, 0xa4, 0xc2, 0x64, 0x22, 0x3a, 0x9f, 0x4e, 0x44, 0xb7,
-	0xa1, 0x8c, 0xce, 0x2a, 0x72, 0xf3, 0xd8, 0xa8, 0x3f, 0x16, 0x71, 0x22, 0x99, 0x86, 0xd4, 0x68,
-	0xc4, 0xff, 0x90, 0xc2, 0x00, 0x23, 0x02, 0xd1, 0x12, 0x14, 0x79, 0xd0, 0x8a, 0x1b, 0xfa, 0x02,
-	0xbe, 0x37, 0x9c, 0xda, 0x5f, 0xc7, 0x40, 0xc7, 0x76, 0x39, 0xf9, 0x93, 0xc0, 0xa9, 0x99, 0x7d,
-	0x70, 0xf1, 0x9e, 0x9d, 0xd9, 0x63, 0x78, 0xfa, 0x4e, 0x5d, 0xb1, 0xc3, 0x58, 0xda, 0x0e, 0x75,
-	0x98, 0x95, 0x60, 0xb5, 0xa6, 0x14, 0xf3, 0x07, 0x01, 0x52, 0x26, 0x0a, 0x97, 0xa1, 0x22, 0xf1,
-	0x45, 0x89, 0xc9, 0x67, 0x0f, 0x32, 0xad, 0xf3, 0x99, 0x42, 0xe6, 0x84, 0xa9, 0x98, 0x3d, 0x61,
-	0x5a, 0x81, 0x52, 0xec, 0xc3, 0x32, 0x57, 0xc7, 0x0b, 0x67, 0xbc, 0xf3, 0xff, 0x2c, 0xfe, 0x41,
-	0x82, 0xe7, 0x47, 0x11, 0x99, 0xcb, 0x58, 0x53, 0xae, 0x9f, 0x50, 0xa3, 0x3e, 0x44, 0x0a, 0xcc,
-	0x89, 0x3c, 0x66, 0xcb, 0x5f, 0x29, 0x94, 0xa5, 0xa1, 0x1f, 0x1f, 0x26, 0x87, 0x7e, 0x7c, 0x68,
-	0xe6, 0x8b, 0x79, 0x6d, 0xbc, 0x99, 0x2f, 0x16, 0xb4, 0x62, 0xed, 0x9b, 0x1c, 0xcc, 0x08, 0x15,
-	0xb7, 0x30, 0x95, 0x3d, 0xaf, 0xed, 0xcd, 0x4c, 0xa2, 0x63, 0xd9, 0x17, 0x77, 0x69, 0x1d, 0xf2,
-	0x43, 0x3a, 0xd4, 0xfe, 0x78, 0x1e, 0x60, 0x1b, 0x6f, 0x3d, 0x9e, 0xa3, 0x3f, 0x0e, 0x49, 0xaa,
-	0xd4, 0x66, 0x3a, 0xe4, 0x71, 0x87, 0xf9, 0x4f, 0x2a, 0xf8, 0xac, 0xbf, 0x0e, 0xe3, 0xae, 0xdf,
-	0xe9, 0x52, 0xf4, 0xca, 0x51, 0x82, 0x14, 0x47, 0x67, 0xd2, 0xdb, 0x81, 0x4f, 0xc3, 0xc0, 0x13,
-	0x4e, 0x2a, 0x5f, 0x87, 0x2c, 0x51, 0x18, 0xfe, 0x8d, 0xe5, 0x75, 0x98, 0x38, 0x20, 0x96, 0x43,
-	0x42, 0xf1, 0xa3, 0x60, 0xf5, 0xa4, 0xaf, 0xbe, 0x8f, 0x58, 0xa6, 0xc0, 0xae, 0x7d, 0x95, 0x83,
-	0xe2, 0xd6, 0x01, 0xb1, 0x0f, 0xa3, 0x6e, 0x3b, 0x6d, 0xbf, 0xf1, 0x81, 0xfd, 0xee, 0xc2, 0xc4,
-	0x9e, 0x67, 0xf5, 0x82, 0x10, 0xad, 0x55, 0xd9, 0xbc, 0x76, 0x7a, 0xc3, 0x23, 0x39, 0xde, 0x47,
-	0x1a, 0x53, 0xd0, 0x0e, 0x7e, 0x44, 0x1a, 0xc3, 0x49, 0x0a, 0x7f, 0xb9, 0xf3, 0xbf, 0xdf, 0x7e,
-	0x5f, 0x3d, 0xf7, 0xdd, 0xf7, 0xd5, 0x73, 0x3f, 0x7e, 0x5f, 0xcd, 0x7d, 0x75, 0x5c, 0xcd, 0xfd,
-	0xfe, 0xb8, 0x9a, 0xfb, 0xf3, 0x71, 0x35, 0xf7, 0xed, 0x71, 0x35, 0xf7, 0xf7, 0xe3, 0x6a, 0xee,
-	0x1f, 0xc7, 0xd5, 0x73, 0x3f, 0x1e, 0x57, 0x73, 0x5f, 0xff, 0x50, 0x3d, 0xf7, 0xed, 0x0f, 0xd5,
-	0x73, 0xdf, 0xfd, 0x50, 0x3d, 0xf7, 0xf9, 0xad, 0xfd, 0x60, 0x20, 0x83, 0x1b, 0x9c, 0xfc, 0x23,
-	0xf2, 0x3b, 0xca, 0xeb, 0xee, 0x04, 0x86, 0xca, 0x9b, 0xff, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x14,
-	0x74, 0xc7, 0x5d, 0xc1, 0x2c, 0x00, 0x00,
+	// 3575 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3b, 0xcd, 0x73, 0xdb, 0xc6,
+	0x77, 0x86, 0x45, 0x49, 0xe4, 0x23, 0x45, 0x41, 0xd0, 0x17, 0x24, 0xdb, 0x94, 0xcc, 0xd8, 0x89,
+	0x9c, 0x38, 0x94, 0x2d, 0x3b, 0x4e, 0x9c, 0x8f, 0x76, 0x64, 0x59, 0x4e, 0xc8, 0x3a, 0xb6, 0x03,
+	0x29, 0x71, 0x26, 0x9d, 0x0c, 0x07, 0x02, 0x56, 0x12, 0x2a, 0x10, 0xa0, 0x01, 0x90, 0x12, 0x33,
+	0x6d, 0x27, 0x33, 0xcd, 0xb4, 0x87, 0xf6, 0x90, 0x63, 0x6f, 0x9d, 0xde, 0x7a, 0xee, 0x4c, 0x66,
+	0x7a, 0xeb, 0xa1, 0x97, 0x1c, 0x73, 0xcc, 0xa1, 0x9d, 0x36, 0xce, 0xa5, 0xb7, 0x5f, 0xfe, 0x84,
+	0xdf, 0xec, 0xdb, 0x05, 0xb0, 0x00, 0x21, 0x09, 0x72, 0xe2, 0x43, 0x66, 0x7e, 0x37, 0xe2, 0x7d,
+	0xed, 0x7b, 0x6f, 0xdf, 0xee, 0xfb, 0x00, 0x08, 0xb7, 0x02, 0xd2, 0xe9, 0xba, 0x9e, 0x6e, 0xaf,
+	0xfa, 0xc4, 0xeb, 0x13, 0x6f, 0x55, 0xef, 0x5a, 0xab, 0x5d, 0xe2, 0xf9, 0x96, 0x1f, 0x10, 0xc7,
+	0x20, 0xab, 0xfd, 0x9b, 0xab, 0xe4, 0x88, 0x18, 0xbd, 0xc0, 0x72, 0x1d, 0xbf, 0xd1, 0xf5, 0xdc,
+	0xc0, 0x55, 0xea, 0x21, 0x53, 0x83, 0x31, 0x35, 0xf4, 0xae, 0xd5, 0x10, 0x98, 0x1a, 0xfd, 0x9b,
+	0x8b, 0xb5, 0x3d, 0xd7, 0xdd, 0xb3, 0xc9, 0x2a, 0x72, 0xec, 0xf4, 0x76, 0x57, 0xcd, 0x9e, 0xa7,
+	0x53, 0x21, 0x4c, 0xc6, 0xe2, 0x52, 0x1a, 0x1f, 0x58, 0x1d, 0xe2, 0x07, 0x7a, 0xa7, 0xcb, 0x09,
+	0x2e, 0x9b, 0xa4, 0x4b, 0x1c, 0x93, 0x38, 0x86, 0x45, 0xfc, 0xd5, 0x3d, 0x77, 0xcf, 0x45, 0x38,
+	0xfe, 0xe2, 0x24, 0x57, 0x22, 0xe5, 0xa9, 0xd6, 0x86, 0xdb, 0xe9, 0xb8, 0x0e, 0x55, 0xb8, 0x43,
+	0x7c, 0x5f, 0xdf, 0x23, 0x99, 0x54, 0xc4, 0xe9, 0x75, 0x7c, 0x4a, 0x74, 0xe8, 0x7a, 0x07, 0xbb,
+	0xb6, 0x7b, 0xc8, 0xa9, 0xae, 0x26, 0xa8, 0x76, 0x75, 0xcb, 0xee, 0x79, 0x64, 0x58, 0x58, 0x92,
+	0x6c, 0xdf, 0xf2, 0x03, 0xd7, 0x1b, 0x0c, 0x93, 0xbd, 0x9a, 0x20, 0x0b, 0x97, 0x1a, 0xa6, 0xbb,
+	0x96, 0xe5, 0xfe, 0x48, 0x45, 0x66, 0x11, 0x27, 0x7d, 0xe3, 0x44, 0xd2, 0x94, 0x35, 0xaf, 0x9d,
+	0x48, 0x1c, 0xe8, 0xfe, 0x01, 0x27, 0xbc, 0x9e, 0x45, 0x78, 0x9c, 0x59, 0xf5, 0xef, 0x15, 0x28,
+	0x6d, 0xed, 0xeb, 0x9e, 0xd9, 0x74, 0x76, 0x5d, 0x65, 0x01, 0x8a, 0x3e, 0x7d, 0x68, 0x5b, 0xa6,
+	0x2a, 0x2d, 0x4b, 0x2b, 0xa3, 0xda, 0x38, 0x3e, 0x37, 0x4d, 0x8a, 0xf2, 0x74, 0x67, 0x8f, 0x50,
+	0xd4, 0xf9, 0x65, 0x69, 0x65, 0x44, 0x1b, 0xc7, 0xe7, 0xa6, 0xa9, 0xcc, 0xc0, 0xa8, 0x7b, 0xe8,
+	0x10, 0x4f, 0x1d, 0x59, 0x96, 0x56, 0x4a, 0x1a, 0x7b, 0x50, 0xd6, 0x60, 0xd6, 0x23, 0x5d, 0xdb,
+	0x32, 0x30, 0x46, 0xda, 0xba, 0x71, 0xd0, 0xb6, 0x49, 0x9f, 0xd8, 0x6a, 0x01, 0xb9, 0xa7, 0x05,
+	0xe4, 0xba, 0x71, 0xf0, 0x90, 0xa2, 0x94, 0xeb, 0xa0, 0x04, 0x9e, 0xee, 0xf8, 0xbb, 0xc4, 0x13,
+	0x18, 0x46, 0x91, 0x41, 0x0e, 0x31, 0x22, 0xb5, 0x1f, 0xb8, 0x36, 0x71, 0xda, 0xbe, 0xe5, 0x18,
+	0xa4, 0xed, 0x11, 0x87, 0x1c, 0xaa, 0x63, 0xa8, 0xb7, 0xcc, 0x30, 0x5b, 0x14, 0xa1, 0x51, 0xb8,
+	0xb2, 0x0e, 0xe5, 0x5e, 0xd7, 0xd4, 0x03, 0xd2, 0xa6, 0x71, 0xa9, 0x8e, 0x2f, 0x4b, 0x2b, 0xe5,
+	0xb5, 0xc5, 0x06, 0x0b, 0xda, 0x46, 0x18, 0xb4, 0x8d, 0xed, 0x30, 0x68, 0xef, 0x15, 0xbe, 0xfd,
+	0xdf, 0x25, 0x49, 0x03, 0xc6, 0x44, 0xc1, 0xca, 0x27, 0x30, 0x43, 0x79, 0x05, 0xdd, 0x98, 0xac,
+	0x62, 0x4e, 0x59, 0x53, 0xc8, 0x1d, 0xea, 0x8f, 0x22, 0xef, 0x43, 0xcd, 0xd1, 0x3b, 0xc4, 0xef,
+	0xea, 0x06, 0x69, 0x3b, 0x6e, 0x60, 0xed, 0x86, 0x0e, 0xeb, 0xd3, 0xd3, 0xe7, 0x3a, 0x6a, 0x09,
+	0xad, 0xbf, 0x18, 0x51, 0x3d, 0x12, 0x88, 0x3e, 0x63, 0x34, 0xca, 0x3f, 0x48, 0xb0, 0x68, 0xd8,
+	0x3d, 0x3f, 0x20, 0x5e, 0x3b, 0xc3, 0x81, 0xb0, 0x3c, 0xb2, 0x52, 0x5e, 0x6b, 0x35, 0x4e, 0x3f,
+	0xe4, 0x8d, 0x28, 0x16, 0x1a, 0x1b, 0x4c, 0xde, 0x76, 0xca, 0xeb, 0x9b, 0x4e, 0xe0, 0x0d, 0xb4,
+	0x79, 0x23, 0x1b, 0xab, 0x7c, 0x23, 0xc1, 0x7c, 0xa4, 0x49, 0xd2, 0x57, 0x6a, 0x19, 0xd5, 0xf8,
+	0xf0, 0xc5, 0xd4, 0xb0, 0x3a, 0x29, 0x1d, 0xb8, 0x4f, 0x67, 0x8c, 0x0c, 0x02, 0xe5, 0xef, 0x25,
+	0x58, 0x08, 0xd5, 0x10, 0xa3, 0x90, 0x29, 0x52, 0xf9, 0x15, 0xfe, 0xd0, 0x62, 0x69, 0x19, 0xfe,
+	0x48, 0x63, 0xa9, 0x3f, 0x16, 0x44, 0x05, 0x4c, 0xfb, 0x99, 0xe0, 0x91, 0x09, 0x54, 0xa4, 0x79,
+	0x36, 0x45, 0x84, 0x35, 0xee, 0xdb, 0xcf, 0x92, 0xfb, 0x32, 0xe7, 0x65, 0x22, 0x95, 0x1b, 0x30,
+	0xd3, 0xb7, 0x7c, 0x6b, 0xc7, 0xb2, 0xad, 0x60, 0x20, 0x28, 0x50, 0xc5, 0xe0, 0x52, 0x62, 0x5c,
+	0xc4, 0xf1, 0x36, 0xa8, 0x81, 0x45, 0x3c, 0x62, 0xb6, 0xe9, 0xcd, 0xa1, 0xef, 0x11, 0x81, 0x6b,
+	0x12, 0xb9, 0x66, 0x19, 0x7e, 0x8b, 0xa1, 0x23, 0xc6, 0x03, 0x90, 0x9f, 0xf5, 0x48, 0x4f, 0xa0,
+	0xf7, 0x55, 0x19, 0xed, 0x5c, 0x3f, 0x9b, 0x9d, 0x9f, 0x50, 0x29, 0xa1, 0x58, 0x9f, 0xd9, 0x57,
+	0x7d, 0x96, 0x00, 0x2a, 0x7f, 0x27, 0x81, 0x1a, 0x05, 0x3c, 0xbd, 0xe2, 0xdd, 0x3e, 0xf1, 0xc2,
+	0x55, 0xa7, 0x5e, 0xc4, 0xbb, 0x61, 0x44, 0x3f, 0xe0, 0xc2, 0xc4, 0xd5, 0xe7, 0x82, 0x4c, 0xa4,
+	0xf2, 0x15, 0xcc, 0xb2, 0x58, 0x4f, 0x6b, 0xa0, 0xa0, 0x06, 0x0f, 0xce, 0xa8, 0x81, 0xd5, 0x49,
+	0xaf, 0xc0, 0x96, 0x9f, 0x0e, 0x86, 0x31, 0xca, 0x21, 0xcc, 0xc4, 0x17, 0x88, 0xe0, 0xf2, 0x69,
+	0x5c, 0x7a, 0xf3, 0x6c, 0x4b, 0x3f, 0x0a, 0x25, 0xa5, 0xdc, 0xae, 0x38, 0x43, 0x88, 0xc5, 0x16,
+	0x5c, 0x3c, 0xe9, 0x8a, 0x50, 0x64, 0x18, 0x39, 0x20, 0x03, 0x4c, 0x23, 0x25, 0x8d, 0xfe, 0xa4,
+	0x79, 0xa2, 0xaf, 0xdb, 0x3d, 0xc2, 0xf3, 0x07, 0x7b, 0x78, 0xf7, 0xfc, 0x3b, 0xd2, 0xa2, 0x01,
+	0x0b, 0xc7, 0x9e, 0xf3, 0x0c, 0x41, 0x37, 0x44, 0x41, 0x27, 0x5e, 0xbc, 0xe2, 0x22, 0xb1, 0xc2,
+	0x99, 0x67, 0xf8, 0x4c, 0x0a, 0x37, 0xe1, 0xc2, 0x09, 0xc7, 0xf0, 0x4c, 0xa2, 0xd6, 0x61, 0x3a,
+	0x23, 0xd2, 0x45, 0x11, 0xa3, 0xa7, 0x89, 0xf8, 0x46, 0x82, 0x0b, 0x27, 0xc4, 0x6d, 0x86, 0x3a,
+	0x8f, 0x93, 0x1e, 0xbc, 0x9b, 0x27, 0x4c, 0x32, 0x57, 0x10, 0xd5, 0xf8, 0x5b, 0x50, 0x8f, 0x8b,
+	0xdd, 0x0c, 0x15, 0x1e, 0x26, 0x55, 0xb8, 0x93, 0x4b, 0x05, 0xab, 0x73, 0xc2, 0xfa, 0x7f, 0x03,
+	0xf3, 0xc7, 0x04, 0x70, 0x86, 0x37, 0x5f, 0x64, 0xf9, 0x61, 0xe9, 0xc2, 0xf2, 0xf5, 0xff, 0x90,
+	0x40, 0x19, 0xa6, 0x50, 0x4c, 0x00, 0xe1, 0x58, 0x4a, 0xf9, 0x8f, 0xe5, 0xb0, 0xac, 0x46, 0xea,
+	0x58, 0x96, 0xf4, 0xe8, 0x34, 0xbe, 0x0f, 0xd5, 0xe3, 0x4d, 0x3e, 0x2d, 0x06, 0xeb, 0xff, 0x2d,
+	0xc1, 0x6c, 0xe6, 0xf6, 0x2a, 0x7f, 0x0e, 0xe0, 0x07, 0xba, 0x17, 0xb0, 0x42, 0x47, 0xca, 0x59,
+	0xe8, 0x94, 0x90, 0x87, 0x42, 0x95, 0x0b, 0x50, 0xea, 0x58, 0x61, 0xe2, 0x65, 0x0b, 0x17, 0x3b,
+	0x16, 0xcf, 0x8e, 0xaf, 0xc0, 0x84, 0xd1, 0xf3, 0x3c, 0xe2, 0x04, 0x9c, 0x60, 0x04, 0x09, 0x2a,
+	0x1c, 0xc8, 0x88, 0xa8, 0x04, 0xfd, 0x28, 0x51, 0x3c, 0x16, 0x3b, 0xfa, 0x51, 0x24, 0x21, 0xbe,
+	0xfe, 0x2c, 0xd3, 0x57, 0x47, 0x97, 0x47, 0x56, 0x4a, 0x5a, 0x25, 0x02, 0x36, 0x4d, 0xbf, 0xfe,
+	0x9f, 0xe7, 0x41, 0x19, 0x0e, 0x9d, 0x5f, 0x6f, 0xdb, 0x07, 0x69, 0xdb, 0xf2, 0xf0, 0xc7, 0xd6,
+	0x6f, 0x66, 0x59, 0x9f, 0x47, 0x44, 0xd2, 0x3f, 0x1f, 0xa4, 0xfd, 0x93, 0x4f, 0x8b, 0x33, 0x79,
+	0xf0, 0x5f, 0x2e, 0xc1, 0xec, 0x53, 0xde, 0x90, 0x6c, 0x86, 0xcd, 0x23, 0xb6, 0x0c, 0x97, 0xa1,
+	0x22, 0xb2, 0xf3, 0x78, 0x2b, 0x0b, 0xdc, 0xca, 0x12, 0x94, 0xc3, 0x66, 0x26, 0xec, 0x1e, 0x4a,
+	0x1a, 0x84, 0xa0, 0xa6, 0xa9, 0x34, 0x60, 0xba, 0xab, 0xa3, 0x1f, 0x12, 0xa2, 0x58, 0x3b, 0x31,
+	0xc5, 0x50, 0x8f, 0x04, 0x81, 0xd7, 0x41, 0xe1, 0xf4, 0xa2, 0xdc, 0x02, 0x92, 0xcb, 0x0c, 0xf3,
+	0x34, 0x96, 0x5e, 0x87, 0x09, 0x4e, 0xed, 0xf5, 0x1c, 0x4a, 0x38, 0xca, 0x54, 0x64, 0x40, 0xad,
+	0xe7, 0x34, 0x4d, 0x6a, 0x85, 0xe5, 0x58, 0x81, 0xa5, 0x07, 0x04, 0x9b, 0x9f, 0x31, 0x0c, 0xb3,
+	0x72, 0x04, 0x6b, 0x9a, 0xca, 0x5d, 0x58, 0x30, 0xdc, 0x4e, 0xd7, 0x26, 0x58, 0xc7, 0x91, 0x3e,
+	0x15, 0xb8, 0xa3, 0x07, 0xc6, 0x3e, 0xa5, 0x1f, 0x47, 0xfa, 0xb9, 0x98, 0x60, 0x93, 0xe2, 0xef,
+	0x51, 0x74, 0xd3, 0x54, 0x2e, 0x01, 0xd0, 0x06, 0xad, 0x8d, 0xc5, 0x0b, 0x16, 0xf4, 0x25, 0xad,
+	0x44, 0x21, 0x78, 0xf1, 0x53, 0x73, 0x22, 0x3b, 0x82, 0x41, 0x97, 0xa0, 0x17, 0x54, 0x60, 0xe6,
+	0x84, 0x98, 0xed, 0x41, 0x97, 0x50, 0x1f, 0x28, 0x5f, 0xc2, 0x62, 0x44, 0x1d, 0xf5, 0xf1, 0x18,
+	0xc2, 0x6e, 0x2f, 0x50, 0xcb, 0xb8, 0xff, 0x0b, 0x43, 0xfb, 0x7f, 0x9f, 0xf7, 0xea, 0xf7, 0x0a,
+	0xff, 0x4c, 0xb7, 0x5f, 0x3d, 0x4c, 0x6f, 0xe6, 0x36, 0x13, 0x40, 0x7b, 0x9c, 0x48, 0xbc, 0xd7,
+	0x8b, 0x05, 0x57, 0xf2, 0x09, 0x8e, 0x2c, 0xd1, 0x7a, 0x91, 0xc8, 0x1d, 0xb8, 0x64, 0x92, 0x5d,
+	0xbd, 0x67, 0x0b, 0xfb, 0x85, 0xfe, 0x08, 0x65, 0x4f, 0xe4, 0x93, 0xbd, 0xc8, 0xa5, 0x84, 0x7b,
+	0xbb, 0xad, 0xfb, 0x07, 0xe1, 0x1a, 0xaf, 0xc0, 0x04, 0x3b, 0xcb, 0x61, 0xdb, 0xc4, 0x2a, 0xdb,
+	0x0a, 0x02, 0xc3, 0x36, 0xe9, 0x0d, 0x50, 0x6c, 0xdd, 0x0f, 0xf8, 0xe6, 0xa1, 0x0a, 0x96, 0xa9,
+	0x4e, 0x21, 0xe5, 0x24, 0xc5, 0xe0, 0xae, 0x51, 0xb1, 0x4d, 0x53, 0x79, 0x13, 0xa6, 0x91, 0x78,
+	0xd7, 0xf2, 0x22, 0x16, 0xcb, 0x54, 0x15, 0xd6, 0x8c, 0x52, 0xd4, 0x03, 0xcb, 0xe3, 0x2c, 0x4d,
+	0x53, 0x79, 0x1f, 0x2e, 0x20, 0x79, 0xd2, 0x42, 0xa6, 0x93, 0x65, 0xaa, 0xd3, 0xc8, 0x36, 0x4f,
+	0x49, 0x44, 0xf5, 0xb7, 0x28, 0xbe, 0x69, 0xa6, 0xae, 0xa2, 0x99, 0xb3, 0x5f, 0x45, 0x2d, 0x40,
+	0x95, 0xda, 0x62, 0x8b, 0x3b, 0x9b, 0x53, 0x4c, 0x95, 0x72, 0x7e, 0x1a, 0xb7, 0xb9, 0x6b, 0x30,
+	0x9b, 0xb4, 0x22, 0xf4, 0xe9, 0x1c, 0xeb, 0xdc, 0x0f, 0x05, 0x03, 0x42, 0xd7, 0xde, 0x85, 0x85,
+	0x94, 0xe5, 0xc6, 0x3e, 0x31, 0x7b, 0x36, 0x1e, 0xe4, 0x79, 0x76, 0x3a, 0x44, 0xbe, 0x2d, 0x8e,
+	0x6e, 0x9a, 0xb4, 0xd3, 0xc8, 0x70, 0x1a, 0x3b, 0x87, 0x2a, 0xeb, 0x34, 0x0e, 0xd3, 0x2e, 0xc3,
+	0x13, 0xb9, 0x95, 0xd6, 0x33, 0x8c, 0xa7, 0x85, 0x7c, 0xf1, 0x94, 0x30, 0x24, 0x0c, 0xa4, 0x21,
+	0xe3, 0xf5, 0x80, 0xe6, 0xea, 0x40, 0x5d, 0xc4, 0xda, 0x21, 0xc1, 0xb3, 0xce, 0x50, 0x89, 0x23,
+	0x99, 0xb0, 0x00, 0xb7, 0xe1, 0x42, 0xce, 0x6d, 0x98, 0xcf, 0xb0, 0x12, 0xf7, 0x43, 0x87, 0x8b,
+	0xd9, 0xbe, 0xe5, 0x0b, 0x5c, 0xcc, 0xb9, 0xc0, 0x42, 0xd6, 0x06, 0xb0, 0x25, 0xae, 0x81, 0x6c,
+	0xe8, 0x8e, 0x41, 0xec, 0xb6, 0x47, 0x9e, 0xf5, 0x88, 0x1f, 0x10, 0x53, 0xbd, 0xb4, 0x2c, 0xad,
+	0x14, 0xb5, 0x49, 0x06, 0xd7, 0x42, 0xb0, 0xe2, 0xc1, 0xd5, 0xa4, 0x36, 0xae, 0x67, 0xed, 0x59,
+	0x8e, 0x6e, 0xa7, 0xd5, 0xaa, 0xe5, 0x54, 0xeb, 0xb2, 0xa8, 0xd6, 0x63, 0x2e, 0x2c, 0xa9, 0xde,
+	0x50, 0x88, 0x70, 0x2d, 0x69, 0x88, 0x2c, 0xe1, 0x3d, 0x99, 0x08, 0x11, 0xae, 0x6c, 0xd3, 0x54,
+	0x5e, 0x87, 0xa9, 0xa4, 0x5d, 0x94, 0x63, 0x19, 0x39, 0x92, 0x86, 0x31, 0x5a, 0x3f, 0xb0, 0x8c,
+	0x83, 0x41, 0x5b, 0xb8, 0xac, 0x2f, 0x33, 0x5a, 0x86, 0xd8, 0x8e, 0xae, 0xec, 0x3d, 0x58, 0xe6,
+	0xb4, 0x51, 0x9c, 0x07, 0x6e, 0x3b, 0x3e, 0xc2, 0x34, 0x0a, 0xeb, 0xf9, 0xa2, 0xf0, 0x22, 0x13,
+	0x14, 0x1a, 0xbc, 0xed, 0x6e, 0x85, 0x87, 0x9a, 0x86, 0xa3, 0x0a, 0xe3, 0x61, 0x00, 0xbe, 0xc2,
+	0x06, 0x72, 0xfc, 0x51, 0xf9, 0x14, 0xe6, 0x3c, 0x12, 0x78, 0x83, 0x36, 0x4b, 0x52, 0x76, 0xdb,
+	0x72, 0x02, 0xe2, 0xf5, 0x75, 0x5b, 0xbd, 0x92, 0x6f, 0xe1, 0x19, 0x64, 0x6f, 0x32, 0xee, 0x26,
+	0x67, 0x8e, 0xc5, 0x76, 0xf4, 0x23, 0xab, 0xd3, 0xeb, 0xc4, 0x62, 0xaf, 0x9e, 0x45, 0xec, 0xc7,
+	0x8c, 0x3b, 0x12, 0x7b, 0x3b, 0x2d, 0x96, 0x9b, 0xe1, 0xab, 0xaf, 0xa2, 0x59, 0x09, 0x2e, 0x7e,
+	0xae, 0x7c, 0xe5, 0x5d, 0x58, 0x60, 0x5c, 0x3b, 0xba, 0x71, 0xe0, 0xee, 0xee, 0xb6, 0x0d, 0x97,
+	0xec, 0xee, 0x5a, 0x86, 0x45, 0x9c, 0x40, 0x7d, 0x6d, 0x59, 0x5a, 0x91, 0xb4, 0x79, 0x24, 0xb8,
+	0xc7, 0xf0, 0x1b, 0x31, 0x5a, 0xe9, 0x40, 0x3d, 0x23, 0x4f, 0x92, 0xa3, 0xae, 0xc5, 0xd4, 0x65,
+	0x41, 0xba, 0x92, 0x33, 0x48, 0x97, 0x86, 0x12, 0xe6, 0x66, 0x24, 0x89, 0x0f, 0xf2, 0x96, 0x98,
+	0xaa, 0x8e, 0xeb, 0xb4, 0xf1, 0x97, 0xbe, 0x63, 0x93, 0x36, 0xf1, 0x3c, 0xd7, 0xc3, 0xac, 0xee,
+	0xab, 0xd7, 0xb0, 0xb0, 0xba, 0x80, 0xc8, 0x47, 0xae, 0xa3, 0x85, 0x44, 0x9b, 0x94, 0x86, 0xe6,
+	0x77, 0x5f, 0x59, 0x01, 0x79, 0x5f, 0xf7, 0x19, 0x7f, 0xbb, 0xeb, 0xda, 0x96, 0x31, 0x50, 0x5f,
+	0xc7, 0x73, 0x58, 0xdd, 0xd7, 0x7d, 0xe4, 0x78, 0x82, 0x50, 0x2c, 0x9d, 0x3d, 0xd7, 0x89, 0xe2,
+	0x4f, 0x7d, 0x03, 0x23, 0xb5, 0x42, 0x81, 0x61, 0x2c, 0xd1, 0xb2, 0xc6, 0xb7, 0xf6, 0xe8, 0xd9,
+	0x34, 0xdc, 0x9e, 0x13, 0xa8, 0x0d, 0x56, 0xd6, 0x30, 0xd8, 0x06, 0x05, 0x29, 0x57, 0xa1, 0xc2,
+	0x87, 0xc3, 0x6d, 0xdf, 0xfa, 0x8a, 0xa8, 0xab, 0x94, 0xe4, 0xde, 0x79, 0x55, 0xd2, 0xca, 0x1c,
+	0xbe, 0x65, 0x7d, 0x45, 0x47, 0x9f, 0x53, 0x7a, 0x2f, 0x70, 0xdb, 0x1e, 0xf1, 0x49, 0xd0, 0xee,
+	0xba, 0x96, 0x13, 0xf8, 0xea, 0x2d, 0x74, 0xde, 0xd5, 0xb8, 0x99, 0xa1, 0x5d, 0x4c, 0x34, 0xb7,
+	0xee, 0xdf, 0x6c, 0x68, 0x94, 0xfa, 0x09, 0x12, 0x6b, 0x93, 0x94, 0x5f, 0x00, 0x28, 0x7f, 0x0d,
+	0x53, 0x3e, 0xd1, 0x3d, 0x63, 0x9f, 0xc6, 0x82, 0x67, 0xed, 0xf4, 0x02, 0xe2, 0xab, 0xb7, 0xb1,
+	0x3f, 0x7a, 0x9c, 0xa7, 0x3f, 0xca, 0xac, 0x47, 0x1b, 0x5b, 0x28, 0x72, 0x3d, 0x92, 0xc8, 0x3a,
+	0x25, 0xd9, 0x4f, 0x81, 0x95, 0xa7, 0x50, 0xe8, 0x90, 0x8e, 0xab, 0xbe, 0x85, 0x0b, 0x6e, 0xbc,
+	0xf8, 0x82, 0x1f, 0x93, 0x8e, 0xcb, 0x16, 0x41, 0x81, 0xca, 0x97, 0x30, 0xc5, 0xf3, 0x65, 0x9b,
+	0x39, 0xd0, 0x22, 0xbe, 0x7a, 0x07, 0x3d, 0x75, 0x23, 0x73, 0x15, 0xee, 0x66, 0xba, 0x02, 0xcf,
+	0xa6, 0x1f, 0x85, 0x7c, 0x9a, 0xdc, 0x4f, 0x41, 0x94, 0x5b, 0x30, 0xc7, 0x2b, 0x92, 0x28, 0xa6,
+	0x79, 0x59, 0xfb, 0x36, 0x06, 0xc0, 0x34, 0x62, 0x23, 0x15, 0x59, 0x79, 0xfb, 0x97, 0x30, 0x19,
+	0x93, 0xfb, 0x81, 0x1e, 0xf8, 0xea, 0x3b, 0xa8, 0xd1, 0x5a, 0x1e, 0xbb, 0x23, 0x61, 0x5b, 0x94,
+	0x53, 0xab, 0x92, 0xc4, 0x73, 0x22, 0x3d, 0x79, 0xbd, 0xe1, 0x23, 0x76, 0xf7, 0xac, 0xe9, 0x49,
+	0xeb, 0xa5, 0x0f, 0xd7, 0x6d, 0x98, 0x1f, 0xaa, 0xc5, 0x82, 0x23, 0xb4, 0xfa, 0x5d, 0x56, 0x93,
+	0x24, 0xeb, 0xb1, 0xed, 0x23, 0x6a, 0xf5, 0x6d, 0x98, 0xa3, 0xb6, 0x12, 0x36, 0x12, 0xb7, 0x50,
+	0x23, 0x76, 0x0e, 0xde, 0x43, 0xa6, 0x19, 0xc4, 0x6e, 0x47, 0x48, 0x76, 0x20, 0x3e, 0x84, 0x6a,
+	0xb2, 0xac, 0x56, 0xdf, 0xcf, 0x69, 0xc0, 0x04, 0x11, 0x8b, 0x69, 0x65, 0x15, 0x66, 0x1c, 0x72,
+	0x38, 0xbc, 0x4f, 0x1f, 0xb0, 0xb6, 0xc6, 0x21, 0x87, 0xa9, 0x5d, 0xfa, 0x0b, 0xe1, 0xc6, 0xc2,
+	0x14, 0x64, 0xb8, 0x8e, 0x8f, 0x14, 0x7d, 0xd2, 0xe6, 0xef, 0xaf, 0x7c, 0xf5, 0xcf, 0xf0, 0xbe,
+	0x5c, 0x12, 0xf3, 0xdd, 0x46, 0x4c, 0xf7, 0x80, 0x93, 0x2d, 0x9a, 0x30, 0x9b, 0x79, 0x14, 0x32,
+	0xe6, 0x02, 0x6f, 0x25, 0x47, 0x21, 0x4b, 0xc9, 0xf3, 0xcc, 0x5f, 0x59, 0xf5, 0x6f, 0x36, 0x9e,
+	0xe8, 0x03, 0xdb, 0xd5, 0x4d, 0x71, 0xe4, 0xf2, 0x39, 0x94, 0xa2, 0xf8, 0xff, 0x4d, 0x25, 0xb7,
+	0x0a, 0xc5, 0xa2, 0x5c, 0x6a, 0x15, 0x8a, 0x93, 0xb2, 0xdc, 0x2a, 0x14, 0x65, 0x79, 0xaa, 0x55,
+	0x28, 0x5e, 0x97, 0xdf, 0x6c, 0x15, 0x8a, 0x6f, 0xca, 0x8d, 0x56, 0xa1, 0x78, 0x43, 0xbe, 0xd9,
+	0x2a, 0x14, 0x6f, 0xca, 0x6b, 0xad, 0x42, 0x71, 0x4d, 0xbe, 0x55, 0xbf, 0x05, 0xd5, 0x64, 0x9c,
+	0xd2, 0xcb, 0x2f, 0x71, 0xb3, 0x49, 0xec, 0xf2, 0x13, 0x6e, 0xb5, 0xfa, 0x1f, 0x24, 0x98, 0x1b,
+	0x3a, 0xd5, 0x94, 0x9b, 0x60, 0xe5, 0xe0, 0x11, 0x1a, 0x3d, 0x42, 0xe5, 0x20, 0xf1, 0xca, 0x01,
+	0x11, 0x71, 0xe5, 0x30, 0x0b, 0x63, 0x7c, 0x6f, 0x59, 0x6f, 0x3b, 0xea, 0xe1, 0x7e, 0xb6, 0x60,
+	0x14, 0x23, 0x0c, 0x1b, 0xd9, 0xea, 0xda, 0xed, 0xcc, 0xb3, 0x86, 0xaf, 0xf0, 0x32, 0x6f, 0x17,
+	0xd4, 0x43, 0x63, 0x22, 0x94, 0x07, 0x30, 0x46, 0x7f, 0xf4, 0x7c, 0x6c, 0x73, 0xab, 0x6b, 0x8d,
+	0xa4, 0x2b, 0x4f, 0x96, 0xd2, 0xf3, 0x35, 0xce, 0x5d, 0xff, 0xae, 0x00, 0x72, 0x38, 0xe9, 0xc1,
+	0x46, 0xe7, 0xb7, 0xea, 0xe1, 0x63, 0x1f, 0x8c, 0x88, 0x3e, 0xd8, 0x80, 0x12, 0x2b, 0xcd, 0x07,
+	0x5d, 0xc2, 0x55, 0x7f, 0xf5, 0x64, 0x3f, 0x60, 0x31, 0x3e, 0xe8, 0x12, 0xad, 0x18, 0xf0, 0x5f,
+	0x74, 0x3e, 0x10, 0xe8, 0xde, 0x1e, 0x49, 0xcd, 0x07, 0x58, 0x1f, 0x3f, 0xc5, 0x50, 0xa9, 0xf9,
+	0x00, 0xa7, 0x17, 0x75, 0x1e, 0x63, 0x0d, 0x35, 0xc3, 0x24, 0xe7, 0x03, 0x9c, 0x9a, 0x1b, 0x30,
+	0xce, 0xcc, 0x67, 0x40, 0x76, 0x34, 0x93, 0x1d, 0x7c, 0x31, 0xdd, 0xc1, 0xbf, 0x07, 0x8b, 0x5c,
+	0x84, 0xb1, 0x6f, 0xd9, 0x66, 0xbc, 0xac, 0xeb, 0xd8, 0x03, 0x6c, 0xf8, 0x8b, 0xda, 0x3c, 0xa3,
+	0xd8, 0xa0, 0x04, 0xe1, 0xea, 0x8f, 0x1d, 0x7b, 0x40, 0x5d, 0x2b, 0x36, 0x4b, 0x80, 0x61, 0x0a,
+	0x7e, 0xdc, 0x20, 0xa9, 0x30, 0x1e, 0x76, 0x60, 0x65, 0x44, 0x86, 0x8f, 0xca, 0x3c, 0x8c, 0x87,
+	0x5d, 0x6c, 0x05, 0x31, 0x63, 0x01, 0x6b, 0x5e, 0x9b, 0x30, 0x29, 0xbc, 0xef, 0xc1, 0x5b, 0x6c,
+	0x22, 0x6f, 0x37, 0x18, 0x33, 0x52, 0x54, 0xab, 0x50, 0xac, 0xca, 0x93, 0xf5, 0x7f, 0x2a, 0xc0,
+	0xb4, 0x30, 0xf1, 0xfe, 0xdd, 0x84, 0x8e, 0xe0, 0xbb, 0xd1, 0xa4, 0xef, 0xae, 0x40, 0x35, 0xd5,
+	0xda, 0xb3, 0xa1, 0x4f, 0x65, 0x57, 0x6c, 0xeb, 0xeb, 0x30, 0xe1, 0x90, 0x23, 0x81, 0x88, 0x4d,
+	0x7a, 0xca, 0x14, 0x18, 0xd2, 0xd0, 0x2a, 0x2b, 0x6a, 0x7d, 0x2c, 0x53, 0x2d, 0xf2, 0x2a, 0x2b,
+	0x84, 0x31, 0x92, 0x1d, 0x4f, 0x77, 0x8c, 0xfd, 0x76, 0xe0, 0x1e, 0x10, 0xb6, 0x8f, 0x15, 0xad,
+	0xcc, 0x60, 0xdb, 0x14, 0x14, 0xa6, 0x0b, 0xea, 0x89, 0x04, 0xe9, 0x04, 0x92, 0xd2, 0x74, 0xa1,
+	0xf5, 0x9c, 0x7b, 0x02, 0x83, 0xb0, 0xf9, 0x93, 0xa7, 0x6d, 0xbe, 0xfc, 0xc2, 0x9b, 0x5f, 0x92,
+	0xa1, 0x55, 0x28, 0x82, 0x5c, 0x6e, 0x15, 0x8a, 0x15, 0x79, 0x82, 0x87, 0xc3, 0xbf, 0x9f, 0x07,
+	0xe5, 0xb3, 0x98, 0xf4, 0xf7, 0x1f, 0x0d, 0x82, 0x33, 0xc7, 0x4e, 0x73, 0xe6, 0xf8, 0x8b, 0x39,
+	0xb3, 0xfe, 0xdd, 0x79, 0x98, 0xdd, 0x16, 0xdf, 0x99, 0xfe, 0xc9, 0x6f, 0xb9, 0xfc, 0xf6, 0xaf,
+	0x05, 0x98, 0xa0, 0x3f, 0x7e, 0x3f, 0x09, 0x6b, 0x13, 0x2a, 0x7c, 0x0a, 0xc0, 0xe4, 0x8c, 0xa2,
+	0x9c, 0xfa, 0x31, 0x39, 0x9b, 0xf7, 0xfa, 0x28, 0xa3, 0x1c, 0xc4, 0x0f, 0x0a, 0x11, 0x66, 0x51,
+	0x61, 0x07, 0x8c, 0xf2, 0xc6, 0x50, 0xde, 0xcd, 0x7c, 0x05, 0x05, 0xef, 0x8d, 0x51, 0xfc, 0xf4,
+	0xe1, 0x30, 0x50, 0xdc, 0xdd, 0xf1, 0xe4, 0xee, 0x5e, 0x03, 0x39, 0x4a, 0x4d, 0xe1, 0x18, 0xa2,
+	0x88, 0xf5, 0xe7, 0x64, 0x08, 0x0f, 0x67, 0x60, 0x0b, 0x50, 0x8c, 0xee, 0x48, 0xf6, 0xc9, 0xca,
+	0x38, 0xe1, 0xf7, 0xa3, 0x10, 0x23, 0x70, 0x5a, 0x8c, 0x94, 0x5f, 0x30, 0x46, 0xfe, 0xb1, 0x0a,
+	0x95, 0x75, 0x23, 0xb0, 0xfa, 0x56, 0x30, 0xc0, 0x10, 0x11, 0x8c, 0x92, 0x92, 0x46, 0xbd, 0x0d,
+	0x6a, 0x7c, 0x5d, 0xa7, 0xe6, 0xf8, 0xec, 0x05, 0xd5, 0x6c, 0x84, 0x4f, 0x8c, 0xf1, 0x3f, 0x84,
+	0x6a, 0x6a, 0xc4, 0x95, 0xf7, 0x6d, 0xcb, 0x84, 0x9f, 0x18, 0x67, 0x5d, 0xe2, 0xd3, 0x5e, 0x96,
+	0x2e, 0xd8, 0x89, 0x2a, 0xf9, 0xd1, 0x5c, 0x73, 0x03, 0x2a, 0x89, 0x01, 0x62, 0xde, 0x73, 0x53,
+	0xf6, 0x85, 0xa1, 0xe1, 0x12, 0x94, 0x75, 0xee, 0x8f, 0x30, 0x27, 0x95, 0x34, 0x08, 0x41, 0xac,
+	0xa4, 0x11, 0x2a, 0x5b, 0xfe, 0x52, 0xc2, 0x8b, 0x6a, 0xda, 0x2f, 0x60, 0xe1, 0xf8, 0xd1, 0x16,
+	0xe4, 0x1b, 0x05, 0xcd, 0xf9, 0xd9, 0x43, 0xad, 0x94, 0x6c, 0xc3, 0x76, 0x7d, 0x72, 0xd6, 0x37,
+	0x18, 0x82, 0xec, 0x0d, 0xca, 0x1f, 0xca, 0xde, 0x86, 0x39, 0xae, 0x6b, 0x5a, 0x70, 0xce, 0x37,
+	0x18, 0xd3, 0xc8, 0x9e, 0x92, 0xfa, 0x10, 0xa6, 0xf6, 0x89, 0xee, 0x05, 0x3b, 0x44, 0x0f, 0xce,
+	0xfa, 0xda, 0x42, 0x8e, 0x38, 0x43, 0x69, 0x59, 0xd3, 0xd6, 0x6a, 0xf6, 0xb4, 0x35, 0x73, 0x80,
+	0xc9, 0xd2, 0x7d, 0xd6, 0x00, 0x93, 0x7d, 0x86, 0x12, 0xce, 0xa0, 0x69, 0xbb, 0x20, 0xb3, 0xe3,
+	0x1a, 0x84, 0xf7, 0x27, 0xeb, 0x07, 0xc4, 0xb9, 0xe2, 0x54, 0x72, 0xae, 0x98, 0x2c, 0x75, 0x95,
+	0x74, 0xa9, 0x4b, 0xaf, 0x84, 0x28, 0x76, 0x89, 0x13, 0x58, 0xc1, 0x40, 0x9d, 0x0e, 0x87, 0xa4,
+	0x3c, 0x82, 0x19, 0x38, 0x73, 0x98, 0x35, 0x93, 0x39, 0xcc, 0x3a, 0x7e, 0x96, 0x39, 0xfb, 0x72,
+	0x66, 0x99, 0x73, 0x2f, 0x67, 0x96, 0x39, 0x7f, 0xc2, 0x2c, 0x73, 0x1b, 0x66, 0x19, 0x57, 0x7a,
+	0x3e, 0xa2, 0xe6, 0x3c, 0xde, 0xd3, 0xc8, 0x9e, 0x9a, 0x8c, 0x9c, 0x38, 0x21, 0x5d, 0x38, 0x79,
+	0x42, 0x9a, 0x63, 0x64, 0xb9, 0x78, 0xfa, 0xc8, 0xf2, 0x11, 0x28, 0x4c, 0x0a, 0x9b, 0xd0, 0xb0,
+	0xf9, 0x03, 0x7f, 0xe9, 0xb1, 0x9c, 0xcc, 0x78, 0x1c, 0x49, 0x93, 0x13, 0x9f, 0x53, 0x68, 0x32,
+	0xf2, 0x3e, 0xa4, 0xd3, 0x1b, 0x06, 0xa1, 0xbd, 0x94, 0x20, 0x8f, 0xe6, 0x2b, 0xe2, 0xc5, 0xa1,
+	0x76, 0x11, 0x43, 0x6d, 0x3e, 0xe2, 0x7a, 0x8a, 0xf8, 0x28, 0xe4, 0xd2, 0x85, 0xc1, 0xa5, 0xcc,
+	0xc2, 0x40, 0x6c, 0xb7, 0x6a, 0x43, 0xed, 0xd6, 0x67, 0x30, 0x87, 0x4b, 0xc7, 0x07, 0xde, 0x24,
+	0x81, 0x6e, 0xd9, 0xbe, 0xba, 0x94, 0x65, 0xd4, 0xd0, 0x14, 0xc3, 0xd7, 0x66, 0x28, 0xff, 0x47,
+	0x21, 0xfb, 0x7d, 0xc6, 0x4d, 0xdf, 0x12, 0xa5, 0xe4, 0x8a, 0x2f, 0xeb, 0x96, 0xf3, 0xbe, 0x25,
+	0x4a, 0xc8, 0x8e, 0xdf, 0xda, 0xb5, 0x0a, 0xc5, 0x11, 0xb9, 0xd0, 0x2a, 0x14, 0xc7, 0xe4, 0xf1,
+	0xfa, 0x7f, 0x49, 0x50, 0xa2, 0x40, 0xef, 0x94, 0x54, 0x98, 0x4c, 0x44, 0xe7, 0xd3, 0x89, 0x68,
+	0x1d, 0xca, 0x18, 0xac, 0x3c, 0x37, 0xe7, 0xfd, 0x3c, 0x01, 0x18, 0x53, 0x98, 0x86, 0xc4, 0xdb,
+	0x88, 0x7d, 0xbe, 0x01, 0x41, 0x7c, 0x11, 0x2d, 0x40, 0x91, 0x5d, 0x5a, 0x51, 0x43, 0x3f, 0x8e,
+	0xcf, 0x4d, 0xb3, 0xfe, 0x3f, 0x23, 0xa0, 0x60, 0xbb, 0x9c, 0xfc, 0xe2, 0xe0, 0xc4, 0xcc, 0x1e,
+	0xbf, 0xc5, 0xcf, 0xce, 0xec, 0x11, 0x3e, 0xfd, 0x82, 0x5e, 0xf0, 0xc3, 0x48, 0xda, 0x0f, 0x0d,
+	0x98, 0x0e, 0xd1, 0x62, 0x4d, 0xc9, 0xe7, 0x0f, 0x1c, 0x25, 0x4c, 0x14, 0xae, 0x40, 0x35, 0xa4,
+	0xe7, 0x25, 0x26, 0x9b, 0x3d, 0x84, 0x69, 0x9d, 0xcd, 0x14, 0x32, 0x27, 0x4c, 0xc5, 0xec, 0x09,
+	0xd3, 0x45, 0x28, 0x45, 0x31, 0x1c, 0xe6, 0xea, 0x08, 0x70, 0xc6, 0x0f, 0x08, 0x3e, 0x8f, 0xbe,
+	0xb6, 0x60, 0xf9, 0x91, 0xdf, 0xcc, 0x65, 0xac, 0x29, 0x57, 0x8e, 0xa9, 0x51, 0x9f, 0x20, 0x07,
+	0xe6, 0x44, 0x76, 0x67, 0x87, 0xdf, 0x65, 0x08, 0xa0, 0xa1, 0xaf, 0x28, 0x2a, 0x43, 0x5f, 0x51,
+	0xb4, 0x0a, 0xc5, 0x82, 0x3c, 0xda, 0x2a, 0x14, 0xc7, 0xe5, 0x62, 0xfd, 0x3b, 0x09, 0xa6, 0xb8,
+	0x89, 0x1b, 0x98, 0xca, 0x5e, 0xd6, 0xf6, 0x66, 0x26, 0xd1, 0x91, 0xec, 0xb7, 0x80, 0x69, 0x1b,
+	0x0a, 0x43, 0x36, 0xd0, 0xcf, 0x89, 0x60, 0x0b, 0x5f, 0xa1, 0xbc, 0xc4, 0x78, 0x1c, 0xd2, 0x54,
+	0xa8, 0xcd, 0x14, 0x28, 0xe0, 0x0e, 0xb3, 0x2f, 0x5e, 0xf0, 0xb7, 0x72, 0x07, 0x46, 0x2d, 0xa7,
+	0xdb, 0x0b, 0xd4, 0xd1, 0x9c, 0x97, 0x14, 0x23, 0xa7, 0xda, 0x1b, 0xae, 0x13, 0x78, 0xae, 0xcd,
+	0x83, 0x34, 0x7c, 0x1c, 0xf2, 0xc4, 0xf8, 0xf0, 0x37, 0x31, 0x77, 0x60, 0x6c, 0x9f, 0xe8, 0x26,
+	0xf1, 0xf8, 0x27, 0xf0, 0xb5, 0xe3, 0x56, 0xfd, 0x08, 0xa9, 0x34, 0x4e, 0x5d, 0xff, 0x5a, 0x82,
+	0xe2, 0xc6, 0x3e, 0x31, 0x0e, 0xfc, 0x5e, 0x27, 0xed, 0xbf, 0xd1, 0xd8, 0x7f, 0xf7, 0x61, 0x6c,
+	0xd7, 0xd6, 0xfb, 0xae, 0x87, 0xde, 0xaa, 0xae, 0x5d, 0x3f, 0xb9, 0xe1, 0x09, 0x25, 0x3e, 0x40,
+	0x1e, 0x8d, 0xf3, 0xc6, 0x9f, 0xbd, 0x8d, 0xe0, 0x24, 0x85, 0x3d, 0xdc, 0xfb, 0xab, 0x1f, 0x7e,
+	0xaa, 0x9d, 0xfb, 0xf1, 0xa7, 0xda, 0xb9, 0x5f, 0x7e, 0xaa, 0x49, 0x5f, 0x3f, 0xaf, 0x49, 0xff,
+	0xf6, 0xbc, 0x26, 0x7d, 0xff, 0xbc, 0x26, 0xfd, 0xf0, 0xbc, 0x26, 0xfd, 0xdf, 0xf3, 0x9a, 0xf4,
+	0xff, 0xcf, 0x6b, 0xe7, 0x7e, 0x79, 0x5e, 0x93, 0xbe, 0xfd, 0xb9, 0x76, 0xee, 0x87, 0x9f, 0x6b,
+	0xe7, 0x7e, 0xfc, 0xb9, 0x76, 0xee, 0x8b, 0xdb, 0x7b, 0x6e, 0xac, 0x83, 0xe5, 0x1e, 0xff, 0x17,
+	0x9b, 0xf7, 0x84, 0xc7, 0x9d, 0x31, 0xbc, 0x2a, 0x6f, 0xfd, 0x31, 0x00, 0x00, 0xff, 0xff, 0x7c,
+	0xa9, 0x9b, 0x4e, 0x9b, 0x33, 0x00, 0x00,
 }
 
 func (this *ShardInfo) Equal(that interface{}) bool {
@@ -2409,16 +2677,48 @@ func (this *ShardInfo) Equal(that interface{}) bool {
 	if this.TieredStorageAckLevel != that1.TieredStorageAckLevel {
 		return false
 	}
+	if len(this.QueueAckLevels) != len(that1.QueueAckLevels) {
+		return false
+	}
+	for i := range this.QueueAckLevels {
+		if this.QueueAckLevels[i] != that1.QueueAckLevels[i] {
+			return false
+		}
+	}
+	if len(this.TransferFailoverLevels) != len(that1.TransferFailoverLevels) {
+		return false
+	}
+	for i := range this.TransferFailoverLevels {
+		if !this.TransferFailoverLevels[i].Equal(that1.TransferFailoverLevels[i]) {
+			return false
+		}
+	}
+	if len(this.TimerFailoverLevels) != len(that1.TimerFailoverLevels) {
+		return false
+	}
+	for i := range this.TimerFailoverLevels {
+		if !this.TimerFailoverLevels[i].Equal(that1.TimerFailoverLevels[i]) {
+			return false
+		}
+	}
+	if len(this.NamespaceAckLevels) != len(that1.NamespaceAckLevels) {
+		return false
+	}
+	for i := range this.NamespaceAckLevels {
+		if !this.NamespaceAckLevels[i].Equal(that1.NamespaceAckLevels[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *WorkflowExecutionInfo) Equal(that interface{}) bool {
+func (this *NamespaceAckLevels) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*WorkflowExecutionInfo)
+	that1, ok := that.(*NamespaceAckLevels)
 	if !ok {
-		that2, ok := that.(WorkflowExecutionInfo)
+		that2, ok := that.(NamespaceAckLevels)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2430,53 +2730,184 @@ func (this *WorkflowExecutionInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.NamespaceId != that1.NamespaceId {
+	if len(this.AckLevels) != len(that1.AckLevels) {
 		return false
 	}
-	if this.WorkflowId != that1.WorkflowId {
-		return false
+	for i := range this.AckLevels {
+		if this.AckLevels[i] != that1.AckLevels[i] {
+			return false
+		}
 	}
-	if this.ParentNamespaceId != that1.ParentNamespaceId {
-		return false
+	return true
+}
+func (this *TransferFailoverLevel) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.ParentWorkflowId != that1.ParentWorkflowId {
+
+	that1, ok := that.(*TransferFailoverLevel)
+	if !ok {
+		that2, ok := that.(TransferFailoverLevel)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if this.ParentRunId != that1.ParentRunId {
+	if that1.StartTime == nil {
+		if this.StartTime != nil {
+			return false
+		}
+	} else if !this.StartTime.Equal(*that1.StartTime) {
 		return false
 	}
-	if this.InitiatedId != that1.InitiatedId {
+	if this.MinLevel != that1.MinLevel {
 		return false
 	}
-	if this.CompletionEventBatchId != that1.CompletionEventBatchId {
+	if this.CurrentLevel != that1.CurrentLevel {
 		return false
 	}
-	if this.TaskQueue != that1.TaskQueue {
+	if this.MaxLevel != that1.MaxLevel {
 		return false
 	}
-	if this.WorkflowTypeName != that1.WorkflowTypeName {
+	if len(this.NamespaceIds) != len(that1.NamespaceIds) {
 		return false
 	}
-	if this.WorkflowExecutionTimeout != nil && that1.WorkflowExecutionTimeout != nil {
-		if *this.WorkflowExecutionTimeout != *that1.WorkflowExecutionTimeout {
+	for i := range this.NamespaceIds {
+		if this.NamespaceIds[i] != that1.NamespaceIds[i] {
 			return false
 		}
-	} else if this.WorkflowExecutionTimeout != nil {
-		return false
-	} else if that1.WorkflowExecutionTimeout != nil {
-		return false
 	}
-	if this.WorkflowRunTimeout != nil && that1.WorkflowRunTimeout != nil {
-		if *this.WorkflowRunTimeout != *that1.WorkflowRunTimeout {
+	return true
+}
+func (this *TimerFailoverLevel) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TimerFailoverLevel)
+	if !ok {
+		that2, ok := that.(TimerFailoverLevel)
+		if ok {
+			that1 = &that2
+		} else {
 			return false
 		}
-	} else if this.WorkflowRunTimeout != nil {
-		return false
-	} else if that1.WorkflowRunTimeout != nil {
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if this.DefaultWorkflowTaskTimeout != nil && that1.DefaultWorkflowTaskTimeout != nil {
-		if *this.DefaultWorkflowTaskTimeout != *that1.DefaultWorkflowTaskTimeout {
+	if that1.StartTime == nil {
+		if this.StartTime != nil {
+			return false
+		}
+	} else if !this.StartTime.Equal(*that1.StartTime) {
+		return false
+	}
+	if that1.MinLevel == nil {
+		if this.MinLevel != nil {
+			return false
+		}
+	} else if !this.MinLevel.Equal(*that1.MinLevel) {
+		return false
+	}
+	if that1.CurrentLevel == nil {
+		if this.CurrentLevel != nil {
+			return false
+		}
+	} else if !this.CurrentLevel.Equal(*that1.CurrentLevel) {
+		return false
+	}
+	if that1.MaxLevel == nil {
+		if this.MaxLevel != nil {
+			return false
+		}
+	} else if !this.MaxLevel.Equal(*that1.MaxLevel) {
+		return false
+	}
+	if len(this.NamespaceIds) != len(that1.NamespaceIds) {
+		return false
+	}
+	for i := range this.NamespaceIds {
+		if this.NamespaceIds[i] != that1.NamespaceIds[i] {
+			return false
+		}
+	}
+	return true
+}
+func (this *WorkflowExecutionInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*WorkflowExecutionInfo)
+	if !ok {
+		that2, ok := that.(WorkflowExecutionInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.NamespaceId != that1.NamespaceId {
+		return false
+	}
+	if this.WorkflowId != that1.WorkflowId {
+		return false
+	}
+	if this.ParentNamespaceId != that1.ParentNamespaceId {
+		return false
+	}
+	if this.ParentWorkflowId != that1.ParentWorkflowId {
+		return false
+	}
+	if this.ParentRunId != that1.ParentRunId {
+		return false
+	}
+	if this.InitiatedId != that1.InitiatedId {
+		return false
+	}
+	if this.CompletionEventBatchId != that1.CompletionEventBatchId {
+		return false
+	}
+	if this.TaskQueue != that1.TaskQueue {
+		return false
+	}
+	if this.WorkflowTypeName != that1.WorkflowTypeName {
+		return false
+	}
+	if this.WorkflowExecutionTimeout != nil && that1.WorkflowExecutionTimeout != nil {
+		if *this.WorkflowExecutionTimeout != *that1.WorkflowExecutionTimeout {
+			return false
+		}
+	} else if this.WorkflowExecutionTimeout != nil {
+		return false
+	} else if that1.WorkflowExecutionTimeout != nil {
+		return false
+	}
+	if this.WorkflowRunTimeout != nil && that1.WorkflowRunTimeout != nil {
+		if *this.WorkflowRunTimeout != *that1.WorkflowRunTimeout {
+			return false
+		}
+	} else if this.WorkflowRunTimeout != nil {
+		return false
+	} else if that1.WorkflowRunTimeout != nil {
+		return false
+	}
+	if this.DefaultWorkflowTaskTimeout != nil && that1.DefaultWorkflowTaskTimeout != nil {
+		if *this.DefaultWorkflowTaskTimeout != *that1.DefaultWorkflowTaskTimeout {
 			return false
 		}
 	} else if this.DefaultWorkflowTaskTimeout != nil {
@@ -2678,6 +3109,9 @@ func (this *WorkflowExecutionInfo) Equal(that interface{}) bool {
 	if this.NewExecutionRunId != that1.NewExecutionRunId {
 		return false
 	}
+	if this.WorkflowTaskConsecutiveFailures != that1.WorkflowTaskConsecutiveFailures {
+		return false
+	}
 	return true
 }
 func (this *ExecutionStats) Equal(that interface{}) bool {
@@ -3383,7 +3817,7 @@ func (this *ShardInfo) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 19)
+	s := make([]string, 0, 23)
 	s = append(s, "&persistence.ShardInfo{")
 	s = append(s, "ShardId: "+fmt.Sprintf("%#v", this.ShardId)+",\n")
 	s = append(s, "RangeId: "+fmt.Sprintf("%#v", this.RangeId)+",\n")
@@ -3448,6 +3882,108 @@ func (this *ShardInfo) GoString() string {
 	}
 	s = append(s, "VisibilityAckLevel: "+fmt.Sprintf("%#v", this.VisibilityAckLevel)+",\n")
 	s = append(s, "TieredStorageAckLevel: "+fmt.Sprintf("%#v", this.TieredStorageAckLevel)+",\n")
+	keysForQueueAckLevels := make([]int32, 0, len(this.QueueAckLevels))
+	for k, _ := range this.QueueAckLevels {
+		keysForQueueAckLevels = append(keysForQueueAckLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Int32s(keysForQueueAckLevels)
+	mapStringForQueueAckLevels := "map[int32]int64{"
+	for _, k := range keysForQueueAckLevels {
+		mapStringForQueueAckLevels += fmt.Sprintf("%#v: %#v,", k, this.QueueAckLevels[k])
+	}
+	mapStringForQueueAckLevels += "}"
+	if this.QueueAckLevels != nil {
+		s = append(s, "QueueAckLevels: "+mapStringForQueueAckLevels+",\n")
+	}
+	keysForTransferFailoverLevels := make([]string, 0, len(this.TransferFailoverLevels))
+	for k, _ := range this.TransferFailoverLevels {
+		keysForTransferFailoverLevels = append(keysForTransferFailoverLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForTransferFailoverLevels)
+	mapStringForTransferFailoverLevels := "map[string]*TransferFailoverLevel{"
+	for _, k := range keysForTransferFailoverLevels {
+		mapStringForTransferFailoverLevels += fmt.Sprintf("%#v: %#v,", k, this.TransferFailoverLevels[k])
+	}
+	mapStringForTransferFailoverLevels += "}"
+	if this.TransferFailoverLevels != nil {
+		s = append(s, "TransferFailoverLevels: "+mapStringForTransferFailoverLevels+",\n")
+	}
+	keysForTimerFailoverLevels := make([]string, 0, len(this.TimerFailoverLevels))
+	for k, _ := range this.TimerFailoverLevels {
+		keysForTimerFailoverLevels = append(keysForTimerFailoverLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForTimerFailoverLevels)
+	mapStringForTimerFailoverLevels := "map[string]*TimerFailoverLevel{"
+	for _, k := range keysForTimerFailoverLevels {
+		mapStringForTimerFailoverLevels += fmt.Sprintf("%#v: %#v,", k, this.TimerFailoverLevels[k])
+	}
+	mapStringForTimerFailoverLevels += "}"
+	if this.TimerFailoverLevels != nil {
+		s = append(s, "TimerFailoverLevels: "+mapStringForTimerFailoverLevels+",\n")
+	}
+	keysForNamespaceAckLevels := make([]int32, 0, len(this.NamespaceAckLevels))
+	for k, _ := range this.NamespaceAckLevels {
+		keysForNamespaceAckLevels = append(keysForNamespaceAckLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Int32s(keysForNamespaceAckLevels)
+	mapStringForNamespaceAckLevels := "map[int32]*NamespaceAckLevels{"
+	for _, k := range keysForNamespaceAckLevels {
+		mapStringForNamespaceAckLevels += fmt.Sprintf("%#v: %#v,", k, this.NamespaceAckLevels[k])
+	}
+	mapStringForNamespaceAckLevels += "}"
+	if this.NamespaceAckLevels != nil {
+		s = append(s, "NamespaceAckLevels: "+mapStringForNamespaceAckLevels+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NamespaceAckLevels) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&persistence.NamespaceAckLevels{")
+	keysForAckLevels := make([]string, 0, len(this.AckLevels))
+	for k, _ := range this.AckLevels {
+		keysForAckLevels = append(keysForAckLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForAckLevels)
+	mapStringForAckLevels := "map[string]int64{"
+	for _, k := range keysForAckLevels {
+		mapStringForAckLevels += fmt.Sprintf("%#v: %#v,", k, this.AckLevels[k])
+	}
+	mapStringForAckLevels += "}"
+	if this.AckLevels != nil {
+		s = append(s, "AckLevels: "+mapStringForAckLevels+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransferFailoverLevel) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&persistence.TransferFailoverLevel{")
+	s = append(s, "StartTime: "+fmt.Sprintf("%#v", this.StartTime)+",\n")
+	s = append(s, "MinLevel: "+fmt.Sprintf("%#v", this.MinLevel)+",\n")
+	s = append(s, "CurrentLevel: "+fmt.Sprintf("%#v", this.CurrentLevel)+",\n")
+	s = append(s, "MaxLevel: "+fmt.Sprintf("%#v", this.MaxLevel)+",\n")
+	s = append(s, "NamespaceIds: "+fmt.Sprintf("%#v", this.NamespaceIds)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TimerFailoverLevel) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&persistence.TimerFailoverLevel{")
+	s = append(s, "StartTime: "+fmt.Sprintf("%#v", this.StartTime)+",\n")
+	s = append(s, "MinLevel: "+fmt.Sprintf("%#v", this.MinLevel)+",\n")
+	s = append(s, "CurrentLevel: "+fmt.Sprintf("%#v", this.CurrentLevel)+",\n")
+	s = append(s, "MaxLevel: "+fmt.Sprintf("%#v", this.MaxLevel)+",\n")
+	s = append(s, "NamespaceIds: "+fmt.Sprintf("%#v", this.NamespaceIds)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -3455,7 +3991,7 @@ func (this *WorkflowExecutionInfo) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 57)
+	s := make([]string, 0, 58)
 	s = append(s, "&persistence.WorkflowExecutionInfo{")
 	s = append(s, "NamespaceId: "+fmt.Sprintf("%#v", this.NamespaceId)+",\n")
 	s = append(s, "WorkflowId: "+fmt.Sprintf("%#v", this.WorkflowId)+",\n")
@@ -3540,6 +4076,7 @@ func (this *WorkflowExecutionInfo) GoString() string {
 	s = append(s, "StateTransitionCount: "+fmt.Sprintf("%#v", this.StateTransitionCount)+",\n")
 	s = append(s, "ExecutionTime: "+fmt.Sprintf("%#v", this.ExecutionTime)+",\n")
 	s = append(s, "NewExecutionRunId: "+fmt.Sprintf("%#v", this.NewExecutionRunId)+",\n")
+	s = append(s, "WorkflowTaskConsecutiveFailures: "+fmt.Sprintf("%#v", this.WorkflowTaskConsecutiveFailures)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -3811,6 +4348,105 @@ func (m *ShardInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NamespaceAckLevels) > 0 {
+		for k := range m.NamespaceAckLevels {
+			v := m.NamespaceAckLevels[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintExecutions(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i = encodeVarintExecutions(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintExecutions(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x9a
+		}
+	}
+	if len(m.TimerFailoverLevels) > 0 {
+		for k := range m.TimerFailoverLevels {
+			v := m.TimerFailoverLevels[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintExecutions(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintExecutions(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintExecutions(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if len(m.TransferFailoverLevels) > 0 {
+		for k := range m.TransferFailoverLevels {
+			v := m.TransferFailoverLevels[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintExecutions(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintExecutions(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintExecutions(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if len(m.QueueAckLevels) > 0 {
+		for k := range m.QueueAckLevels {
+			v := m.QueueAckLevels[k]
+			baseI := i
+			i = encodeVarintExecutions(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i = encodeVarintExecutions(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintExecutions(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
 	if m.TieredStorageAckLevel != 0 {
 		i = encodeVarintExecutions(dAtA, i, uint64(m.TieredStorageAckLevel))
 		i--
@@ -3860,12 +4496,12 @@ func (m *ShardInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			v := m.ClusterTimerAckLevel[k]
 			baseI := i
 			if v != nil {
-				n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo((*v), dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime((*v)):])
-				if err1 != nil {
-					return 0, err1
+				n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo((*v), dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime((*v)):])
+				if err4 != nil {
+					return 0, err4
 				}
-				i -= n1
-				i = encodeVarintExecutions(dAtA, i, uint64(n1))
+				i -= n4
+				i = encodeVarintExecutions(dAtA, i, uint64(n4))
 				i--
 				dAtA[i] = 0x12
 			}
@@ -3902,22 +4538,22 @@ func (m *ShardInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x48
 	}
 	if m.TimerAckLevelTime != nil {
-		n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.TimerAckLevelTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.TimerAckLevelTime):])
-		if err2 != nil {
-			return 0, err2
+		n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.TimerAckLevelTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.TimerAckLevelTime):])
+		if err5 != nil {
+			return 0, err5
 		}
-		i -= n2
-		i = encodeVarintExecutions(dAtA, i, uint64(n2))
+		i -= n5
+		i = encodeVarintExecutions(dAtA, i, uint64(n5))
 		i--
 		dAtA[i] = 0x42
 	}
 	if m.UpdateTime != nil {
-		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.UpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.UpdateTime):])
-		if err3 != nil {
-			return 0, err3
+		n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.UpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.UpdateTime):])
+		if err6 != nil {
+			return 0, err6
 		}
-		i -= n3
-		i = encodeVarintExecutions(dAtA, i, uint64(n3))
+		i -= n6
+		i = encodeVarintExecutions(dAtA, i, uint64(n6))
 		i--
 		dAtA[i] = 0x3a
 	}
@@ -3956,7 +4592,7 @@ func (m *ShardInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *WorkflowExecutionInfo) Marshal() (dAtA []byte, err error) {
+func (m *NamespaceAckLevels) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3966,36 +4602,212 @@ func (m *WorkflowExecutionInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WorkflowExecutionInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *NamespaceAckLevels) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NamespaceAckLevels) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.NewExecutionRunId) > 0 {
-		i -= len(m.NewExecutionRunId)
-		copy(dAtA[i:], m.NewExecutionRunId)
-		i = encodeVarintExecutions(dAtA, i, uint64(len(m.NewExecutionRunId)))
-		i--
-		dAtA[i] = 0x3
-		i--
-		dAtA[i] = 0xea
+	if len(m.AckLevels) > 0 {
+		for k := range m.AckLevels {
+			v := m.AckLevels[k]
+			baseI := i
+			i = encodeVarintExecutions(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintExecutions(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintExecutions(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	if m.ExecutionTime != nil {
-		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ExecutionTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ExecutionTime):])
-		if err4 != nil {
-			return 0, err4
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferFailoverLevel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferFailoverLevel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferFailoverLevel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NamespaceIds) > 0 {
+		for iNdEx := len(m.NamespaceIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.NamespaceIds[iNdEx])
+			copy(dAtA[i:], m.NamespaceIds[iNdEx])
+			i = encodeVarintExecutions(dAtA, i, uint64(len(m.NamespaceIds[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
 		}
-		i -= n4
-		i = encodeVarintExecutions(dAtA, i, uint64(n4))
+	}
+	if m.MaxLevel != 0 {
+		i = encodeVarintExecutions(dAtA, i, uint64(m.MaxLevel))
 		i--
-		dAtA[i] = 0x3
+		dAtA[i] = 0x20
+	}
+	if m.CurrentLevel != 0 {
+		i = encodeVarintExecutions(dAtA, i, uint64(m.CurrentLevel))
 		i--
-		dAtA[i] = 0xe2
+		dAtA[i] = 0x18
+	}
+	if m.MinLevel != 0 {
+		i = encodeVarintExecutions(dAtA, i, uint64(m.MinLevel))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTime != nil {
+		n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartTime):])
+		if err7 != nil {
+			return 0, err7
+		}
+		i -= n7
+		i = encodeVarintExecutions(dAtA, i, uint64(n7))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimerFailoverLevel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimerFailoverLevel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimerFailoverLevel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NamespaceIds) > 0 {
+		for iNdEx := len(m.NamespaceIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.NamespaceIds[iNdEx])
+			copy(dAtA[i:], m.NamespaceIds[iNdEx])
+			i = encodeVarintExecutions(dAtA, i, uint64(len(m.NamespaceIds[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.MaxLevel != nil {
+		n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.MaxLevel, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.MaxLevel):])
+		if err8 != nil {
+			return 0, err8
+		}
+		i -= n8
+		i = encodeVarintExecutions(dAtA, i, uint64(n8))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.CurrentLevel != nil {
+		n9, err9 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.CurrentLevel, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.CurrentLevel):])
+		if err9 != nil {
+			return 0, err9
+		}
+		i -= n9
+		i = encodeVarintExecutions(dAtA, i, uint64(n9))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.MinLevel != nil {
+		n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.MinLevel, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.MinLevel):])
+		if err10 != nil {
+			return 0, err10
+		}
+		i -= n10
+		i = encodeVarintExecutions(dAtA, i, uint64(n10))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.StartTime != nil {
+		n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartTime):])
+		if err11 != nil {
+			return 0, err11
+		}
+		i -= n11
+		i = encodeVarintExecutions(dAtA, i, uint64(n11))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WorkflowExecutionInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WorkflowExecutionInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.WorkflowTaskConsecutiveFailures != 0 {
+		i = encodeVarintExecutions(dAtA, i, uint64(m.WorkflowTaskConsecutiveFailures))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xf0
+	}
+	if len(m.NewExecutionRunId) > 0 {
+		i -= len(m.NewExecutionRunId)
+		copy(dAtA[i:], m.NewExecutionRunId)
+		i = encodeVarintExecutions(dAtA, i, uint64(len(m.NewExecutionRunId)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xea
+	}
+	if m.ExecutionTime != nil {
+		n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ExecutionTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ExecutionTime):])
+		if err12 != nil {
+			return 0, err12
+		}
+		i -= n12
+		i = encodeVarintExecutions(dAtA, i, uint64(n12))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe2
 	}
 	if m.StateTransitionCount != 0 {
 		i = encodeVarintExecutions(dAtA, i, uint64(m.StateTransitionCount))
@@ -4012,12 +4824,12 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xd0
 	}
 	if m.WorkflowRunExpirationTime != nil {
-		n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowRunExpirationTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowRunExpirationTime):])
-		if err5 != nil {
-			return 0, err5
+		n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowRunExpirationTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowRunExpirationTime):])
+		if err13 != nil {
+			return 0, err13
 		}
-		i -= n5
-		i = encodeVarintExecutions(dAtA, i, uint64(n5))
+		i -= n13
+		i = encodeVarintExecutions(dAtA, i, uint64(n13))
 		i--
 		dAtA[i] = 0x3
 		i--
@@ -4177,12 +4989,12 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		}
 	}
 	if m.WorkflowExecutionExpirationTime != nil {
-		n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowExecutionExpirationTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowExecutionExpirationTime):])
-		if err11 != nil {
-			return 0, err11
+		n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowExecutionExpirationTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowExecutionExpirationTime):])
+		if err19 != nil {
+			return 0, err19
 		}
-		i -= n11
-		i = encodeVarintExecutions(dAtA, i, uint64(n11))
+		i -= n19
+		i = encodeVarintExecutions(dAtA, i, uint64(n19))
 		i--
 		dAtA[i] = 0x2
 		i--
@@ -4204,24 +5016,24 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xb0
 	}
 	if m.RetryMaximumInterval != nil {
-		n12, err12 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryMaximumInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryMaximumInterval):])
-		if err12 != nil {
-			return 0, err12
+		n20, err20 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryMaximumInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryMaximumInterval):])
+		if err20 != nil {
+			return 0, err20
 		}
-		i -= n12
-		i = encodeVarintExecutions(dAtA, i, uint64(n12))
+		i -= n20
+		i = encodeVarintExecutions(dAtA, i, uint64(n20))
 		i--
 		dAtA[i] = 0x2
 		i--
 		dAtA[i] = 0xaa
 	}
 	if m.RetryInitialInterval != nil {
-		n13, err13 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryInitialInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryInitialInterval):])
-		if err13 != nil {
-			return 0, err13
+		n21, err21 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryInitialInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryInitialInterval):])
+		if err21 != nil {
+			return 0, err21
 		}
-		i -= n13
-		i = encodeVarintExecutions(dAtA, i, uint64(n13))
+		i -= n21
+		i = encodeVarintExecutions(dAtA, i, uint64(n21))
 		i--
 		dAtA[i] = 0x2
 		i--
@@ -4235,12 +5047,12 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x98
 	}
 	if m.StickyScheduleToStartTimeout != nil {
-		n14, err14 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.StickyScheduleToStartTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.StickyScheduleToStartTimeout):])
-		if err14 != nil {
-			return 0, err14
+		n22, err22 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.StickyScheduleToStartTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.StickyScheduleToStartTimeout):])
+		if err22 != nil {
+			return 0, err22
 		}
-		i -= n14
-		i = encodeVarintExecutions(dAtA, i, uint64(n14))
+		i -= n22
+		i = encodeVarintExecutions(dAtA, i, uint64(n22))
 		i--
 		dAtA[i] = 0x2
 		i--
@@ -4274,12 +5086,12 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xfa
 	}
 	if m.WorkflowTaskOriginalScheduledTime != nil {
-		n15, err15 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowTaskOriginalScheduledTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowTaskOriginalScheduledTime):])
-		if err15 != nil {
-			return 0, err15
+		n23, err23 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowTaskOriginalScheduledTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowTaskOriginalScheduledTime):])
+		if err23 != nil {
+			return 0, err23
 		}
-		i -= n15
-		i = encodeVarintExecutions(dAtA, i, uint64(n15))
+		i -= n23
+		i = encodeVarintExecutions(dAtA, i, uint64(n23))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -4298,24 +5110,24 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xe8
 	}
 	if m.WorkflowTaskScheduledTime != nil {
-		n16, err16 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowTaskScheduledTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowTaskScheduledTime):])
-		if err16 != nil {
-			return 0, err16
+		n24, err24 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowTaskScheduledTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowTaskScheduledTime):])
+		if err24 != nil {
+			return 0, err24
 		}
-		i -= n16
-		i = encodeVarintExecutions(dAtA, i, uint64(n16))
+		i -= n24
+		i = encodeVarintExecutions(dAtA, i, uint64(n24))
 		i--
 		dAtA[i] = 0x1
 		i--
 		dAtA[i] = 0xe2
 	}
 	if m.WorkflowTaskStartedTime != nil {
-		n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowTaskStartedTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowTaskStartedTime):])
-		if err17 != nil {
-			return 0, err17
+		n25, err25 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.WorkflowTaskStartedTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.WorkflowTaskStartedTime):])
+		if err25 != nil {
+			return 0, err25
 		}
-		i -= n17
-		i = encodeVarintExecutions(dAtA, i, uint64(n17))
+		i -= n25
+		i = encodeVarintExecutions(dAtA, i, uint64(n25))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -4329,12 +5141,12 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xd0
 	}
 	if m.WorkflowTaskTimeout != nil {
-		n18, err18 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.WorkflowTaskTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.WorkflowTaskTimeout):])
-		if err18 != nil {
-			return 0, err18
+		n26, err26 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.WorkflowTaskTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.WorkflowTaskTimeout):])
+		if err26 != nil {
+			return 0, err26
 		}
-		i -= n18
-		i = encodeVarintExecutions(dAtA, i, uint64(n18))
+		i -= n26
+		i = encodeVarintExecutions(dAtA, i, uint64(n26))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -4362,24 +5174,24 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xb0
 	}
 	if m.LastUpdateTime != nil {
-		n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastUpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastUpdateTime):])
-		if err19 != nil {
-			return 0, err19
+		n27, err27 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastUpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastUpdateTime):])
+		if err27 != nil {
+			return 0, err27
 		}
-		i -= n19
-		i = encodeVarintExecutions(dAtA, i, uint64(n19))
+		i -= n27
+		i = encodeVarintExecutions(dAtA, i, uint64(n27))
 		i--
 		dAtA[i] = 0x1
 		i--
 		dAtA[i] = 0xaa
 	}
 	if m.StartTime != nil {
-		n20, err20 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartTime):])
-		if err20 != nil {
-			return 0, err20
+		n28, err28 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.StartTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartTime):])
+		if err28 != nil {
+			return 0, err28
 		}
-		i -= n20
-		i = encodeVarintExecutions(dAtA, i, uint64(n20))
+		i -= n28
+		i = encodeVarintExecutions(dAtA, i, uint64(n28))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -4412,32 +5224,32 @@ func (m *WorkflowExecutionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x70
 	}
 	if m.DefaultWorkflowTaskTimeout != nil {
-		n21, err21 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.DefaultWorkflowTaskTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.DefaultWorkflowTaskTimeout):])
-		if err21 != nil {
-			return 0, err21
+		n29, err29 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.DefaultWorkflowTaskTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.DefaultWorkflowTaskTimeout):])
+		if err29 != nil {
+			return 0, err29
 		}
-		i -= n21
-		i = encodeVarintExecutions(dAtA, i, uint64(n21))
+		i -= n29
+		i = encodeVarintExecutions(dAtA, i, uint64(n29))
 		i--
 		dAtA[i] = 0x6a
 	}
 	if m.WorkflowRunTimeout != nil {
-		n22, err22 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.WorkflowRunTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.WorkflowRunTimeout):])
-		if err22 != nil {
-			return 0, err22
+		n30, err30 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.WorkflowRunTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.WorkflowRunTimeout):])
+		if err30 != nil {
+			return 0, err30
 		}
-		i -= n22
-		i = encodeVarintExecutions(dAtA, i, uint64(n22))
+		i -= n30
+		i = encodeVarintExecutions(dAtA, i, uint64(n30))
 		i--
 		dAtA[i] = 0x62
 	}
 	if m.WorkflowExecutionTimeout != nil {
-		n23, err23 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.WorkflowExecutionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.WorkflowExecutionTimeout):])
-		if err23 != nil {
-			return 0, err23
+		n31, err31 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.WorkflowExecutionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.WorkflowExecutionTimeout):])
+		if err31 != nil {
+			return 0, err31
 		}
-		i -= n23
-		i = encodeVarintExecutions(dAtA, i, uint64(n23))
+		i -= n31
+		i = encodeVarintExecutions(dAtA, i, uint64(n31))
 		i--
 		dAtA[i] = 0x5a
 	}
@@ -4599,12 +5411,12 @@ func (m *TransferTaskInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.VisibilityTime != nil {
-		n24, err24 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
-		if err24 != nil {
-			return 0, err24
+		n32, err32 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
+		if err32 != nil {
+			return 0, err32
 		}
-		i -= n24
-		i = encodeVarintExecutions(dAtA, i, uint64(n24))
+		i -= n32
+		i = encodeVarintExecutions(dAtA, i, uint64(n32))
 		i--
 		dAtA[i] = 0x6a
 	}
@@ -4711,12 +5523,12 @@ func (m *ReplicationTaskInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.VisibilityTime != nil {
-		n25, err25 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
-		if err25 != nil {
-			return 0, err25
+		n33, err33 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
+		if err33 != nil {
+			return 0, err33
 		}
-		i -= n25
-		i = encodeVarintExecutions(dAtA, i, uint64(n25))
+		i -= n33
+		i = encodeVarintExecutions(dAtA, i, uint64(n33))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -4811,12 +5623,12 @@ func (m *VisibilityTaskInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.VisibilityTime != nil {
-		n26, err26 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
-		if err26 != nil {
-			return 0, err26
+		n34, err34 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
+		if err34 != nil {
+			return 0, err34
 		}
-		i -= n26
-		i = encodeVarintExecutions(dAtA, i, uint64(n26))
+		i -= n34
+		i = encodeVarintExecutions(dAtA, i, uint64(n34))
 		i--
 		dAtA[i] = 0x3a
 	}
@@ -4880,12 +5692,12 @@ func (m *TieredStorageTaskInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.VisibilityTime != nil {
-		n27, err27 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
-		if err27 != nil {
-			return 0, err27
+		n35, err35 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
+		if err35 != nil {
+			return 0, err35
 		}
-		i -= n27
-		i = encodeVarintExecutions(dAtA, i, uint64(n27))
+		i -= n35
+		i = encodeVarintExecutions(dAtA, i, uint64(n35))
 		i--
 		dAtA[i] = 0x3a
 	}
@@ -4949,12 +5761,12 @@ func (m *TimerTaskInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.VisibilityTime != nil {
-		n28, err28 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
-		if err28 != nil {
-			return 0, err28
+		n36, err36 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.VisibilityTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.VisibilityTime):])
+		if err36 != nil {
+			return 0, err36
 		}
-		i -= n28
-		i = encodeVarintExecutions(dAtA, i, uint64(n28))
+		i -= n36
+		i = encodeVarintExecutions(dAtA, i, uint64(n36))
 		i--
 		dAtA[i] = 0x5a
 	}
@@ -5038,12 +5850,12 @@ func (m *ActivityInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if m.LastHeartbeatUpdateTime != nil {
-		n29, err29 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastHeartbeatUpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastHeartbeatUpdateTime):])
-		if err29 != nil {
-			return 0, err29
+		n37, err37 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastHeartbeatUpdateTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastHeartbeatUpdateTime):])
+		if err37 != nil {
+			return 0, err37
 		}
-		i -= n29
-		i = encodeVarintExecutions(dAtA, i, uint64(n29))
+		i -= n37
+		i = encodeVarintExecutions(dAtA, i, uint64(n37))
 		i--
 		dAtA[i] = 0x2
 		i--
@@ -5122,12 +5934,12 @@ func (m *ActivityInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xc9
 	}
 	if m.RetryExpirationTime != nil {
-		n32, err32 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.RetryExpirationTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.RetryExpirationTime):])
-		if err32 != nil {
-			return 0, err32
+		n40, err40 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.RetryExpirationTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.RetryExpirationTime):])
+		if err40 != nil {
+			return 0, err40
 		}
-		i -= n32
-		i = encodeVarintExecutions(dAtA, i, uint64(n32))
+		i -= n40
+		i = encodeVarintExecutions(dAtA, i, uint64(n40))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -5141,24 +5953,24 @@ func (m *ActivityInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0xb8
 	}
 	if m.RetryMaximumInterval != nil {
-		n33, err33 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryMaximumInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryMaximumInterval):])
-		if err33 != nil {
-			return 0, err33
+		n41, err41 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryMaximumInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryMaximumInterval):])
+		if err41 != nil {
+			return 0, err41
 		}
-		i -= n33
-		i = encodeVarintExecutions(dAtA, i, uint64(n33))
+		i -= n41
+		i = encodeVarintExecutions(dAtA, i, uint64(n41))
 		i--
 		dAtA[i] = 0x1
 		i--
 		dAtA[i] = 0xb2
 	}
 	if m.RetryInitialInterval != nil {
-		n34, err34 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryInitialInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryInitialInterval):])
-		if err34 != nil {
-			return 0, err34
+		n42, err42 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.RetryInitialInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.RetryInitialInterval):])
+		if err42 != nil {
+			return 0, err42
 		}
-		i -= n34
-		i = encodeVarintExecutions(dAtA, i, uint64(n34))
+		i -= n42
+		i = encodeVarintExecutions(dAtA, i, uint64(n42))
 		i--
 		dAtA[i] = 0x1
 		i--
@@ -5224,42 +6036,42 @@ func (m *ActivityInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x70
 	}
 	if m.HeartbeatTimeout != nil {
-		n35, err35 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.HeartbeatTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatTimeout):])
-		if err35 != nil {
-			return 0, err35
+		n43, err43 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.HeartbeatTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatTimeout):])
+		if err43 != nil {
+			return 0, err43
 		}
-		i -= n35
-		i = encodeVarintExecutions(dAtA, i, uint64(n35))
+		i -= n43
+		i = encodeVarintExecutions(dAtA, i, uint64(n43))
 		i--
 		dAtA[i] = 0x6a
 	}
 	if m.StartToCloseTimeout != nil {
-		n36, err36 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.StartToCloseTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.StartToCloseTimeout):])
-		if err36 != nil {
-			return 0, err36
+		n44, err44 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.StartToCloseTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.StartToCloseTimeout):])
+		if err44 != nil {
+			return 0, err44
 		}
-		i -= n36
-		i = encodeVarintExecutions(dAtA, i, uint64(n36))
+		i -= n44
+		i = encodeVarintExecutions(dAtA, i, uint64(n44))
 		i--
 		dAtA[i] = 0x62
 	}
 	if m.ScheduleToCloseTimeout != nil {
-		n37, err37 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ScheduleToCloseTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ScheduleToCloseTimeout):])
-		if err37 != nil {
-			return 0, err37
+		n45, err45 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ScheduleToCloseTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ScheduleToCloseTimeout):])
+		if err45 != nil {
+			return 0, err45
 		}
-		i -= n37
-		i = encodeVarintExecutions(dAtA, i, uint64(n37))
+		i -= n45
+		i = encodeVarintExecutions(dAtA, i, uint64(n45))
 		i--
 		dAtA[i] = 0x5a
 	}
 	if m.ScheduleToStartTimeout != nil {
-		n38, err38 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ScheduleToStartTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ScheduleToStartTimeout):])
-		if err38 != nil {
-			return 0, err38
+		n46, err46 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ScheduleToStartTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ScheduleToStartTimeout):])
+		if err46 != nil {
+			return 0, err46
 		}
-		i -= n38
-		i = encodeVarintExecutions(dAtA, i, uint64(n38))
+		i -= n46
+		i = encodeVarintExecutions(dAtA, i, uint64(n46))
 		i--
 		dAtA[i] = 0x52
 	}
@@ -5278,12 +6090,12 @@ func (m *ActivityInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x42
 	}
 	if m.StartedTime != nil {
-		n39, err39 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.StartedTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartedTime):])
-		if err39 != nil {
-			return 0, err39
+		n47, err47 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.StartedTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartedTime):])
+		if err47 != nil {
+			return 0, err47
 		}
-		i -= n39
-		i = encodeVarintExecutions(dAtA, i, uint64(n39))
+		i -= n47
+		i = encodeVarintExecutions(dAtA, i, uint64(n47))
 		i--
 		dAtA[i] = 0x3a
 	}
@@ -5293,12 +6105,12 @@ func (m *ActivityInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x28
 	}
 	if m.ScheduledTime != nil {
-		n40, err40 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ScheduledTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ScheduledTime):])
-		if err40 != nil {
-			return 0, err40
+		n48, err48 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ScheduledTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ScheduledTime):])
+		if err48 != nil {
+			return 0, err48
 		}
-		i -= n40
-		i = encodeVarintExecutions(dAtA, i, uint64(n40))
+		i -= n48
+		i = encodeVarintExecutions(dAtA, i, uint64(n48))
 		i--
 		dAtA[i] = 0x22
 	}
@@ -5348,12 +6160,12 @@ func (m *TimerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x20
 	}
 	if m.ExpiryTime != nil {
-		n41, err41 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ExpiryTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ExpiryTime):])
-		if err41 != nil {
-			return 0, err41
+		n49, err49 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.ExpiryTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.ExpiryTime):])
+		if err49 != nil {
+			return 0, err49
 		}
-		i -= n41
-		i = encodeVarintExecutions(dAtA, i, uint64(n41))
+		i -= n49
+		i = encodeVarintExecutions(dAtA, i, uint64(n49))
 		i--
 		dAtA[i] = 0x1a
 	}
@@ -5711,6 +6523,129 @@ func (m *ShardInfo) Size() (n int) {
 	if m.TieredStorageAckLevel != 0 {
 		n += 1 + sovExecutions(uint64(m.TieredStorageAckLevel))
 	}
+	if len(m.QueueAckLevels) > 0 {
+		for k, v := range m.QueueAckLevels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + sovExecutions(uint64(k)) + 1 + sovExecutions(uint64(v))
+			n += mapEntrySize + 2 + sovExecutions(uint64(mapEntrySize))
+		}
+	}
+	if len(m.TransferFailoverLevels) > 0 {
+		for k, v := range m.TransferFailoverLevels {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovExecutions(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovExecutions(uint64(len(k))) + l
+			n += mapEntrySize + 2 + sovExecutions(uint64(mapEntrySize))
+		}
+	}
+	if len(m.TimerFailoverLevels) > 0 {
+		for k, v := range m.TimerFailoverLevels {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovExecutions(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovExecutions(uint64(len(k))) + l
+			n += mapEntrySize + 2 + sovExecutions(uint64(mapEntrySize))
+		}
+	}
+	if len(m.NamespaceAckLevels) > 0 {
+		for k, v := range m.NamespaceAckLevels {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovExecutions(uint64(l))
+			}
+			mapEntrySize := 1 + sovExecutions(uint64(k)) + l
+			n += mapEntrySize + 2 + sovExecutions(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *NamespaceAckLevels) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AckLevels) > 0 {
+		for k, v := range m.AckLevels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovExecutions(uint64(len(k))) + 1 + sovExecutions(uint64(v))
+			n += mapEntrySize + 1 + sovExecutions(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *TransferFailoverLevel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartTime)
+		n += 1 + l + sovExecutions(uint64(l))
+	}
+	if m.MinLevel != 0 {
+		n += 1 + sovExecutions(uint64(m.MinLevel))
+	}
+	if m.CurrentLevel != 0 {
+		n += 1 + sovExecutions(uint64(m.CurrentLevel))
+	}
+	if m.MaxLevel != 0 {
+		n += 1 + sovExecutions(uint64(m.MaxLevel))
+	}
+	if len(m.NamespaceIds) > 0 {
+		for _, s := range m.NamespaceIds {
+			l = len(s)
+			n += 1 + l + sovExecutions(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TimerFailoverLevel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTime != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.StartTime)
+		n += 1 + l + sovExecutions(uint64(l))
+	}
+	if m.MinLevel != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.MinLevel)
+		n += 1 + l + sovExecutions(uint64(l))
+	}
+	if m.CurrentLevel != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.CurrentLevel)
+		n += 1 + l + sovExecutions(uint64(l))
+	}
+	if m.MaxLevel != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.MaxLevel)
+		n += 1 + l + sovExecutions(uint64(l))
+	}
+	if len(m.NamespaceIds) > 0 {
+		for _, s := range m.NamespaceIds {
+			l = len(s)
+			n += 1 + l + sovExecutions(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -5933,6 +6868,9 @@ func (m *WorkflowExecutionInfo) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovExecutions(uint64(l))
 	}
+	if m.WorkflowTaskConsecutiveFailures != 0 {
+		n += 2 + sovExecutions(uint64(m.WorkflowTaskConsecutiveFailures))
+	}
 	return n
 }
 
@@ -6511,6 +7449,46 @@ func (this *ShardInfo) String() string {
 		mapStringForReplicationDlqAckLevel += fmt.Sprintf("%v: %v,", k, this.ReplicationDlqAckLevel[k])
 	}
 	mapStringForReplicationDlqAckLevel += "}"
+	keysForQueueAckLevels := make([]int32, 0, len(this.QueueAckLevels))
+	for k, _ := range this.QueueAckLevels {
+		keysForQueueAckLevels = append(keysForQueueAckLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Int32s(keysForQueueAckLevels)
+	mapStringForQueueAckLevels := "map[int32]int64{"
+	for _, k := range keysForQueueAckLevels {
+		mapStringForQueueAckLevels += fmt.Sprintf("%v: %v,", k, this.QueueAckLevels[k])
+	}
+	mapStringForQueueAckLevels += "}"
+	keysForTransferFailoverLevels := make([]string, 0, len(this.TransferFailoverLevels))
+	for k, _ := range this.TransferFailoverLevels {
+		keysForTransferFailoverLevels = append(keysForTransferFailoverLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForTransferFailoverLevels)
+	mapStringForTransferFailoverLevels := "map[string]*TransferFailoverLevel{"
+	for _, k := range keysForTransferFailoverLevels {
+		mapStringForTransferFailoverLevels += fmt.Sprintf("%v: %v,", k, this.TransferFailoverLevels[k])
+	}
+	mapStringForTransferFailoverLevels += "}"
+	keysForTimerFailoverLevels := make([]string, 0, len(this.TimerFailoverLevels))
+	for k, _ := range this.TimerFailoverLevels {
+		keysForTimerFailoverLevels = append(keysForTimerFailoverLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForTimerFailoverLevels)
+	mapStringForTimerFailoverLevels := "map[string]*TimerFailoverLevel{"
+	for _, k := range keysForTimerFailoverLevels {
+		mapStringForTimerFailoverLevels += fmt.Sprintf("%v: %v,", k, this.TimerFailoverLevels[k])
+	}
+	mapStringForTimerFailoverLevels += "}"
+	keysForNamespaceAckLevels := make([]int32, 0, len(this.NamespaceAckLevels))
+	for k, _ := range this.NamespaceAckLevels {
+		keysForNamespaceAckLevels = append(keysForNamespaceAckLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Int32s(keysForNamespaceAckLevels)
+	mapStringForNamespaceAckLevels := "map[int32]*NamespaceAckLevels{"
+	for _, k := range keysForNamespaceAckLevels {
+		mapStringForNamespaceAckLevels += fmt.Sprintf("%v: %v,", k, this.NamespaceAckLevels[k])
+	}
+	mapStringForNamespaceAckLevels += "}"
 	s := strings.Join([]string{`&ShardInfo{`,
 		`ShardId:` + fmt.Sprintf("%v", this.ShardId) + `,`,
 		`RangeId:` + fmt.Sprintf("%v", this.RangeId) + `,`,
@@ -6527,6 +7505,58 @@ func (this *ShardInfo) String() string {
 		`ReplicationDlqAckLevel:` + mapStringForReplicationDlqAckLevel + `,`,
 		`VisibilityAckLevel:` + fmt.Sprintf("%v", this.VisibilityAckLevel) + `,`,
 		`TieredStorageAckLevel:` + fmt.Sprintf("%v", this.TieredStorageAckLevel) + `,`,
+		`QueueAckLevels:` + mapStringForQueueAckLevels + `,`,
+		`TransferFailoverLevels:` + mapStringForTransferFailoverLevels + `,`,
+		`TimerFailoverLevels:` + mapStringForTimerFailoverLevels + `,`,
+		`NamespaceAckLevels:` + mapStringForNamespaceAckLevels + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NamespaceAckLevels) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForAckLevels := make([]string, 0, len(this.AckLevels))
+	for k, _ := range this.AckLevels {
+		keysForAckLevels = append(keysForAckLevels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForAckLevels)
+	mapStringForAckLevels := "map[string]int64{"
+	for _, k := range keysForAckLevels {
+		mapStringForAckLevels += fmt.Sprintf("%v: %v,", k, this.AckLevels[k])
+	}
+	mapStringForAckLevels += "}"
+	s := strings.Join([]string{`&NamespaceAckLevels{`,
+		`AckLevels:` + mapStringForAckLevels + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TransferFailoverLevel) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TransferFailoverLevel{`,
+		`StartTime:` + strings.Replace(fmt.Sprintf("%v", this.StartTime), "Timestamp", "types.Timestamp", 1) + `,`,
+		`MinLevel:` + fmt.Sprintf("%v", this.MinLevel) + `,`,
+		`CurrentLevel:` + fmt.Sprintf("%v", this.CurrentLevel) + `,`,
+		`MaxLevel:` + fmt.Sprintf("%v", this.MaxLevel) + `,`,
+		`NamespaceIds:` + fmt.Sprintf("%v", this.NamespaceIds) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TimerFailoverLevel) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TimerFailoverLevel{`,
+		`StartTime:` + strings.Replace(fmt.Sprintf("%v", this.StartTime), "Timestamp", "types.Timestamp", 1) + `,`,
+		`MinLevel:` + strings.Replace(fmt.Sprintf("%v", this.MinLevel), "Timestamp", "types.Timestamp", 1) + `,`,
+		`CurrentLevel:` + strings.Replace(fmt.Sprintf("%v", this.CurrentLevel), "Timestamp", "types.Timestamp", 1) + `,`,
+		`MaxLevel:` + strings.Replace(fmt.Sprintf("%v", this.MaxLevel), "Timestamp", "types.Timestamp", 1) + `,`,
+		`NamespaceIds:` + fmt.Sprintf("%v", this.NamespaceIds) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -6609,6 +7639,7 @@ func (this *WorkflowExecutionInfo) String() string {
 		`StateTransitionCount:` + fmt.Sprintf("%v", this.StateTransitionCount) + `,`,
 		`ExecutionTime:` + strings.Replace(fmt.Sprintf("%v", this.ExecutionTime), "Timestamp", "types.Timestamp", 1) + `,`,
 		`NewExecutionRunId:` + fmt.Sprintf("%v", this.NewExecutionRunId) + `,`,
+		`WorkflowTaskConsecutiveFailures:` + fmt.Sprintf("%v", this.WorkflowTaskConsecutiveFailures) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -7339,11 +8370,1180 @@ func (m *ShardInfo) Unmarshal(dAtA []byte) error {
 					iNdEx += skippy
 				}
 			}
-			m.ClusterTimerAckLevel[mapkey] = mapvalue
+			m.ClusterTimerAckLevel[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterReplicationLevel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ClusterReplicationLevel == nil {
+				m.ClusterReplicationLevel = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExecutions
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipExecutions(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ClusterReplicationLevel[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationDlqAckLevel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReplicationDlqAckLevel == nil {
+				m.ReplicationDlqAckLevel = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExecutions
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipExecutions(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReplicationDlqAckLevel[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VisibilityAckLevel", wireType)
+			}
+			m.VisibilityAckLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VisibilityAckLevel |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TieredStorageAckLevel", wireType)
+			}
+			m.TieredStorageAckLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TieredStorageAckLevel |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueAckLevels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.QueueAckLevels == nil {
+				m.QueueAckLevels = make(map[int32]int64)
+			}
+			var mapkey int32
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExecutions
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipExecutions(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.QueueAckLevels[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferFailoverLevels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferFailoverLevels == nil {
+				m.TransferFailoverLevels = make(map[string]*TransferFailoverLevel)
+			}
+			var mapkey string
+			var mapvalue *TransferFailoverLevel
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExecutions
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &TransferFailoverLevel{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipExecutions(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.TransferFailoverLevels[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimerFailoverLevels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TimerFailoverLevels == nil {
+				m.TimerFailoverLevels = make(map[string]*TimerFailoverLevel)
+			}
+			var mapkey string
+			var mapvalue *TimerFailoverLevel
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExecutions
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &TimerFailoverLevel{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipExecutions(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.TimerFailoverLevels[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceAckLevels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NamespaceAckLevels == nil {
+				m.NamespaceAckLevels = make(map[int32]*NamespaceAckLevels)
+			}
+			var mapkey int32
+			var mapvalue *NamespaceAckLevels
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExecutions
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &NamespaceAckLevels{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipExecutions(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.NamespaceAckLevels[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExecutions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamespaceAckLevels) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExecutions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceAckLevels: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceAckLevels: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckLevels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AckLevels == nil {
+				m.AckLevels = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExecutions
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExecutions
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipExecutions(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthExecutions
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.AckLevels[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExecutions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferFailoverLevel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExecutions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferFailoverLevel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferFailoverLevel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StartTime == nil {
+				m.StartTime = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLevel", wireType)
+			}
+			m.MinLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinLevel |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentLevel", wireType)
+			}
+			m.CurrentLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CurrentLevel |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLevel", wireType)
+			}
+			m.MaxLevel = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxLevel |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceIds = append(m.NamespaceIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExecutions(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimerFailoverLevel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExecutions
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimerFailoverLevel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimerFailoverLevel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StartTime == nil {
+				m.StartTime = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 12:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterReplicationLevel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLevel", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7370,93 +9570,16 @@ func (m *ShardInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ClusterReplicationLevel == nil {
-				m.ClusterReplicationLevel = make(map[string]int64)
+			if m.MinLevel == nil {
+				m.MinLevel = new(time.Time)
 			}
-			var mapkey string
-			var mapvalue int64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowExecutions
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowExecutions
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthExecutions
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthExecutions
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowExecutions
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= int64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipExecutions(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthExecutions
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.MinLevel, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.ClusterReplicationLevel[mapkey] = mapvalue
 			iNdEx = postIndex
-		case 13:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationDlqAckLevel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentLevel", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7483,95 +9606,18 @@ func (m *ShardInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ReplicationDlqAckLevel == nil {
-				m.ReplicationDlqAckLevel = make(map[string]int64)
+			if m.CurrentLevel == nil {
+				m.CurrentLevel = new(time.Time)
 			}
-			var mapkey string
-			var mapvalue int64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowExecutions
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowExecutions
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthExecutions
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthExecutions
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowExecutions
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= int64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipExecutions(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthExecutions
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.CurrentLevel, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.ReplicationDlqAckLevel[mapkey] = mapvalue
 			iNdEx = postIndex
-		case 14:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VisibilityAckLevel", wireType)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLevel", wireType)
 			}
-			m.VisibilityAckLevel = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowExecutions
@@ -7581,16 +9627,33 @@ func (m *ShardInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.VisibilityAckLevel |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 15:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TieredStorageAckLevel", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthExecutions
 			}
-			m.TieredStorageAckLevel = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxLevel == nil {
+				m.MaxLevel = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.MaxLevel, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceIds", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowExecutions
@@ -7600,11 +9663,24 @@ func (m *ShardInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TieredStorageAckLevel |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExecutions
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceIds = append(m.NamespaceIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExecutions(dAtA[iNdEx:])
@@ -9367,6 +11443,25 @@ func (m *WorkflowExecutionInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.NewExecutionRunId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 62:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkflowTaskConsecutiveFailures", wireType)
+			}
+			m.WorkflowTaskConsecutiveFailures = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutions
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WorkflowTaskConsecutiveFailures |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExecutions(dAtA[iNdEx:])
@@ -38,8 +38,10 @@ import (
 	"go.temporal.io/server/client/history"
 	"go.temporal.io/server/client/matching"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/membership"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
@@ -139,13 +141,28 @@ func (cf *rpcClientFactory) NewHistoryClientWithTimeout(timeout time.Duration) (
 		return nil, err
 	}
 
+	shardPins := cf.dynConfig.GetMapProperty(dynamicconfig.HistoryShardPins, nil)
+	resolver = membership.NewPinnedServiceResolver(resolver, func() map[string]interface{} { return shardPins() })
+
 	keyResolver := newServiceKeyResolver(resolver)
 	clientProvider := func(clientKey string) (interface{}, error) {
 		connection := cf.rpcFactory.CreateInternodeGRPCConnection(clientKey)
-		return historyservice.NewHistoryServiceClient(connection), nil
+		return history.NewHostClient(clientKey, historyservice.NewHistoryServiceClient(connection)), nil
+	}
+	reportUnreachable := func(address string) {
+		if err := cf.monitor.ReportUnreachable(common.HistoryServiceName, address); err != nil {
+			cf.logger.Warn("Failed to report unreachable history host", tag.Address(address), tag.Error(err))
+		}
 	}
 	clientCache := common.NewClientCache(keyResolver, clientProvider)
-	client := history.NewClient(cf.numberOfHistoryShards, timeout, clientCache, cf.logger)
+	shardHandoffHedgeDelay := cf.dynConfig.GetDurationProperty(dynamicconfig.HistoryClientShardHandoffHedgeDelay, 100*time.Millisecond)
+	shardPartitions := common.NewShardPartitions(
+		cf.numberOfHistoryShards,
+		cf.dynConfig.GetMapProperty(dynamicconfig.NamespaceShardPartitions, nil),
+		clock.NewRealTimeSource(),
+		cf.logger,
+	)
+	client := history.NewClient(cf.numberOfHistoryShards, shardPartitions, timeout, clientCache, reportUnreachable, shardHandoffHedgeDelay, cf.logger)
 	if cf.metricsClient != nil {
 		client = history.NewMetricClient(client, cf.metricsClient)
 	}
@@ -37,6 +37,7 @@ import (
 	replicationspb "go.temporal.io/server/api/replication/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	serviceerrors "go.temporal.io/server/common/serviceerror"
@@ -47,29 +48,76 @@ var _ historyservice.HistoryServiceClient = (*clientImpl)(nil)
 const (
 	// DefaultTimeout is the default timeout used to make calls
 	DefaultTimeout = time.Second * 30
+
+	// maxShardHandoffHedges bounds the number of hedged attempts of a request while its shard is moving
+	maxShardHandoffHedges = 3
 )
 
-type clientImpl struct {
-	numberOfShards  int32
-	tokenSerializer common.TaskTokenSerializer
-	timeout         time.Duration
-	clients         common.ClientCache
-	logger          log.Logger
-}
+type (
+	clientImpl struct {
+		numberOfShards         int32
+		shardPartitions        *common.ShardPartitions
+		tokenSerializer        common.TaskTokenSerializer
+		timeout                time.Duration
+		clients                common.ClientCache
+		reportUnreachable      UnreachableHostReporter
+		shardHandoffHedgeDelay dynamicconfig.DurationPropertyFn
+		logger                 log.Logger
+	}
+
+	// UnreachableHostReporter is notified of history hosts the client failed to reach
+	UnreachableHostReporter func(address string)
+
+	// hostClient is a history service client connected to a single history host
+	hostClient struct {
+		historyservice.HistoryServiceClient
+		address string
+	}
+
+	// shardClient is the client of the history host presumed to own the shard
+	shardClient struct {
+		historyservice.HistoryServiceClient
+		shardID int32
+	}
+
+	// attemptResult is the outcome of one of the hedged attempts of a request
+	attemptResult struct {
+		response interface{}
+		err      error
+	}
+)
 
 // NewClient creates a new history service gRPC client
 func NewClient(
 	numberOfShards int32,
+	shardPartitions *common.ShardPartitions,
 	timeout time.Duration,
 	clients common.ClientCache,
+	reportUnreachable UnreachableHostReporter,
+	shardHandoffHedgeDelay dynamicconfig.DurationPropertyFn,
 	logger log.Logger,
 ) historyservice.HistoryServiceClient {
 	return &clientImpl{
-		numberOfShards:  numberOfShards,
-		tokenSerializer: common.NewProtoTaskTokenSerializer(),
-		timeout:         timeout,
-		clients:         clients,
-		logger:          logger,
+		numberOfShards:         numberOfShards,
+		shardPartitions:        shardPartitions,
+		tokenSerializer:        common.NewProtoTaskTokenSerializer(),
+		timeout:                timeout,
+		clients:                clients,
+		reportUnreachable:      reportUnreachable,
+		shardHandoffHedgeDelay: shardHandoffHedgeDelay,
+		logger:                 logger,
+	}
+}
+
+// NewHostClient wraps the client connected to the history host at the given address,
+// allowing connection failures to be attributed to the host
+func NewHostClient(
+	address string,
+	client historyservice.HistoryServiceClient,
+) historyservice.HistoryServiceClient {
+	return &hostClient{
+		HistoryServiceClient: client,
+		address:              address,
 	}
 }
 
@@ -82,19 +130,16 @@ func (c *clientImpl) StartWorkflowExecution(
 		return nil, err
 	}
 
-	var response *historyservice.StartWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.StartWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.StartWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.StartWorkflowExecutionResponse), nil
 }
 
 func (c *clientImpl) GetMutableState(
@@ -106,19 +151,16 @@ func (c *clientImpl) GetMutableState(
 		return nil, err
 	}
 
-	var response *historyservice.GetMutableStateResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.GetMutableState(ctx, request, opts...)
-		return err
+		return client.GetMutableState(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.GetMutableStateResponse), nil
 }
 
 func (c *clientImpl) PollMutableState(
@@ -130,19 +172,16 @@ func (c *clientImpl) PollMutableState(
 		return nil, err
 	}
 
-	var response *historyservice.PollMutableStateResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.PollMutableState(ctx, request, opts...)
-		return err
+		return client.PollMutableState(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.PollMutableStateResponse), nil
 }
 
 func (c *clientImpl) DescribeHistoryHost(
@@ -168,19 +207,16 @@ func (c *clientImpl) DescribeHistoryHost(
 		return nil, err
 	}
 
-	var response *historyservice.DescribeHistoryHostResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.DescribeHistoryHost(ctx, request, opts...)
-		return err
+		return client.DescribeHistoryHost(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.DescribeHistoryHostResponse), nil
 }
 
 func (c *clientImpl) RemoveTask(
@@ -195,20 +231,16 @@ func (c *clientImpl) RemoveTask(
 			return nil, err
 		}
 	}
-	var response *historyservice.RemoveTaskResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RemoveTask(ctx, request, opts...)
-		return err
+		return client.RemoveTask(ctx, request, opts...)
 	}
-
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RemoveTaskResponse), nil
 }
 
 func (c *clientImpl) CloseShard(
@@ -224,20 +256,16 @@ func (c *clientImpl) CloseShard(
 			return nil, err
 		}
 	}
-	var response *historyservice.CloseShardResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.CloseShard(ctx, request, opts...)
-		return err
+		return client.CloseShard(ctx, request, opts...)
 	}
-
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.CloseShardResponse), nil
 }
 
 func (c *clientImpl) GetShard(
@@ -253,20 +281,16 @@ func (c *clientImpl) GetShard(
 			return nil, err
 		}
 	}
-	var response *historyservice.GetShardResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.GetShard(ctx, request, opts...)
-		return err
+		return client.GetShard(ctx, request, opts...)
 	}
-
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.GetShardResponse), nil
 }
 
 func (c *clientImpl) DescribeMutableState(
@@ -278,19 +302,16 @@ func (c *clientImpl) DescribeMutableState(
 		return nil, err
 	}
 
-	var response *historyservice.DescribeMutableStateResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.DescribeMutableState(ctx, request, opts...)
-		return err
+		return client.DescribeMutableState(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.DescribeMutableStateResponse), nil
 }
 
 func (c *clientImpl) ResetStickyTaskQueue(
@@ -302,19 +323,16 @@ func (c *clientImpl) ResetStickyTaskQueue(
 		return nil, err
 	}
 
-	var response *historyservice.ResetStickyTaskQueueResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.ResetStickyTaskQueue(ctx, request, opts...)
-		return err
+		return client.ResetStickyTaskQueue(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.ResetStickyTaskQueueResponse), nil
 }
 
 func (c *clientImpl) DescribeWorkflowExecution(
@@ -326,19 +344,16 @@ func (c *clientImpl) DescribeWorkflowExecution(
 		return nil, err
 	}
 
-	var response *historyservice.DescribeWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.DescribeWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.DescribeWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.DescribeWorkflowExecutionResponse), nil
 }
 
 func (c *clientImpl) RecordWorkflowTaskStarted(
@@ -350,19 +365,16 @@ func (c *clientImpl) RecordWorkflowTaskStarted(
 		return nil, err
 	}
 
-	var response *historyservice.RecordWorkflowTaskStartedResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RecordWorkflowTaskStarted(ctx, request, opts...)
-		return err
+		return client.RecordWorkflowTaskStarted(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RecordWorkflowTaskStartedResponse), nil
 }
 
 func (c *clientImpl) RecordActivityTaskStarted(
@@ -374,19 +386,16 @@ func (c *clientImpl) RecordActivityTaskStarted(
 		return nil, err
 	}
 
-	var response *historyservice.RecordActivityTaskStartedResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RecordActivityTaskStarted(ctx, request, opts...)
-		return err
+		return client.RecordActivityTaskStarted(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RecordActivityTaskStartedResponse), nil
 }
 
 func (c *clientImpl) RespondWorkflowTaskCompleted(
@@ -402,15 +411,16 @@ func (c *clientImpl) RespondWorkflowTaskCompleted(
 		return nil, err
 	}
 
-	var response *historyservice.RespondWorkflowTaskCompletedResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RespondWorkflowTaskCompleted(ctx, request, opts...)
-		return err
+		return client.RespondWorkflowTaskCompleted(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
-	return response, err
+	response, err := c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response.(*historyservice.RespondWorkflowTaskCompletedResponse), nil
 }
 
 func (c *clientImpl) RespondWorkflowTaskFailed(
@@ -426,19 +436,16 @@ func (c *clientImpl) RespondWorkflowTaskFailed(
 		return nil, err
 	}
 
-	var response *historyservice.RespondWorkflowTaskFailedResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RespondWorkflowTaskFailed(ctx, request, opts...)
-		return err
+		return client.RespondWorkflowTaskFailed(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RespondWorkflowTaskFailedResponse), nil
 
 }
 
@@ -455,19 +462,16 @@ func (c *clientImpl) RespondActivityTaskCompleted(
 		return nil, err
 	}
 
-	var response *historyservice.RespondActivityTaskCompletedResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RespondActivityTaskCompleted(ctx, request, opts...)
-		return err
+		return client.RespondActivityTaskCompleted(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RespondActivityTaskCompletedResponse), nil
 
 }
 
@@ -484,19 +488,16 @@ func (c *clientImpl) RespondActivityTaskFailed(
 		return nil, err
 	}
 
-	var response *historyservice.RespondActivityTaskFailedResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RespondActivityTaskFailed(ctx, request, opts...)
-		return err
+		return client.RespondActivityTaskFailed(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RespondActivityTaskFailedResponse), nil
 
 }
 
@@ -513,19 +514,16 @@ func (c *clientImpl) RespondActivityTaskCanceled(
 		return nil, err
 	}
 
-	var response *historyservice.RespondActivityTaskCanceledResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RespondActivityTaskCanceled(ctx, request, opts...)
-		return err
+		return client.RespondActivityTaskCanceled(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RespondActivityTaskCanceledResponse), nil
 
 }
 
@@ -542,19 +540,16 @@ func (c *clientImpl) RecordActivityTaskHeartbeat(
 		return nil, err
 	}
 
-	var response *historyservice.RecordActivityTaskHeartbeatResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RecordActivityTaskHeartbeat(ctx, request, opts...)
-		return err
+		return client.RecordActivityTaskHeartbeat(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RecordActivityTaskHeartbeatResponse), nil
 }
 
 func (c *clientImpl) RequestCancelWorkflowExecution(
@@ -566,19 +561,16 @@ func (c *clientImpl) RequestCancelWorkflowExecution(
 		return nil, err
 	}
 
-	var response *historyservice.RequestCancelWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RequestCancelWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.RequestCancelWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RequestCancelWorkflowExecutionResponse), nil
 }
 
 func (c *clientImpl) SignalWorkflowExecution(
@@ -590,19 +582,16 @@ func (c *clientImpl) SignalWorkflowExecution(
 		return nil, err
 	}
 
-	var response *historyservice.SignalWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.SignalWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.SignalWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.SignalWorkflowExecutionResponse), nil
 
 }
 
@@ -615,19 +604,16 @@ func (c *clientImpl) SignalWithStartWorkflowExecution(
 		return nil, err
 	}
 
-	var response *historyservice.SignalWithStartWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.SignalWithStartWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.SignalWithStartWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, err
+	return response.(*historyservice.SignalWithStartWorkflowExecutionResponse), nil
 }
 
 func (c *clientImpl) RemoveSignalMutableState(
@@ -638,19 +624,16 @@ func (c *clientImpl) RemoveSignalMutableState(
 	if err != nil {
 		return nil, err
 	}
-	var response *historyservice.RemoveSignalMutableStateResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RemoveSignalMutableState(ctx, request, opts...)
-		return err
+		return client.RemoveSignalMutableState(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, err
+	return response.(*historyservice.RemoveSignalMutableStateResponse), nil
 }
 
 func (c *clientImpl) TerminateWorkflowExecution(
@@ -662,19 +645,16 @@ func (c *clientImpl) TerminateWorkflowExecution(
 		return nil, err
 	}
 
-	var response *historyservice.TerminateWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.TerminateWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.TerminateWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.TerminateWorkflowExecutionResponse), nil
 
 }
 
@@ -687,18 +667,16 @@ func (c *clientImpl) ResetWorkflowExecution(
 		return nil, err
 	}
 
-	var response *historyservice.ResetWorkflowExecutionResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.ResetWorkflowExecution(ctx, request, opts...)
-		return err
+		return client.ResetWorkflowExecution(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, err
+	return response.(*historyservice.ResetWorkflowExecutionResponse), nil
 }
 
 func (c *clientImpl) ScheduleWorkflowTask(
@@ -710,19 +688,16 @@ func (c *clientImpl) ScheduleWorkflowTask(
 		return nil, err
 	}
 
-	var response *historyservice.ScheduleWorkflowTaskResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.ScheduleWorkflowTask(ctx, request, opts...)
-		return err
+		return client.ScheduleWorkflowTask(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.ScheduleWorkflowTaskResponse), nil
 
 }
 
@@ -735,19 +710,16 @@ func (c *clientImpl) RecordChildExecutionCompleted(
 		return nil, err
 	}
 
-	var response *historyservice.RecordChildExecutionCompletedResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RecordChildExecutionCompleted(ctx, request, opts...)
-		return err
+		return client.RecordChildExecutionCompleted(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RecordChildExecutionCompletedResponse), nil
 
 }
 
@@ -760,19 +732,16 @@ func (c *clientImpl) ReplicateEventsV2(
 		return nil, err
 	}
 
-	var response *historyservice.ReplicateEventsV2Response
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.ReplicateEventsV2(ctx, request, opts...)
-		return err
+		return client.ReplicateEventsV2(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.ReplicateEventsV2Response), nil
 
 }
 
@@ -787,19 +756,16 @@ func (c *clientImpl) SyncShardStatus(
 		return nil, err
 	}
 
-	var response *historyservice.SyncShardStatusResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.SyncShardStatus(ctx, request, opts...)
-		return err
+		return client.SyncShardStatus(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.SyncShardStatusResponse), nil
 
 }
 
@@ -813,19 +779,16 @@ func (c *clientImpl) SyncActivity(
 		return nil, err
 	}
 
-	var response *historyservice.SyncActivityResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.SyncActivity(ctx, request, opts...)
-		return err
+		return client.SyncActivity(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.SyncActivityResponse), nil
 
 }
 
@@ -839,19 +802,16 @@ func (c *clientImpl) QueryWorkflow(
 		return nil, err
 	}
 
-	var response *historyservice.QueryWorkflowResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.QueryWorkflow(ctx, request, opts...)
-		return err
+		return client.QueryWorkflow(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.QueryWorkflowResponse), nil
 }
 
 func (c *clientImpl) GetReplicationMessages(
@@ -867,6 +827,7 @@ func (c *clientImpl) GetReplicationMessages(
 			return nil, err
 		}
 
+		client = hostOf(client)
 		if _, ok := requestsByClient[client]; !ok {
 			requestsByClient[client] = &historyservice.GetReplicationMessagesRequest{
 				ClusterName: request.ClusterName,
@@ -951,19 +912,16 @@ func (c *clientImpl) ReapplyEvents(
 		return nil, err
 	}
 
-	var response *historyservice.ReapplyEventsResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.ReapplyEvents(ctx, request, opts...)
-		return err
+		return client.ReapplyEvents(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.ReapplyEventsResponse), nil
 
 }
 
@@ -1012,19 +970,16 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	opts ...grpc.CallOption,
 ) (*historyservice.RefreshWorkflowTasksResponse, error) {
 	client, err := c.getClientForWorkflowID(request.NamespaceId, request.GetRequest().GetExecution().GetWorkflowId())
-	var response *historyservice.RefreshWorkflowTasksResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.RefreshWorkflowTasks(ctx, request, opts...)
-		return err
+		return client.RefreshWorkflowTasks(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.RefreshWorkflowTasksResponse), nil
 }
 
 func (c *clientImpl) GenerateLastHistoryReplicationTasks(
@@ -1033,19 +988,16 @@ func (c *clientImpl) GenerateLastHistoryReplicationTasks(
 	opts ...grpc.CallOption,
 ) (*historyservice.GenerateLastHistoryReplicationTasksResponse, error) {
 	client, err := c.getClientForWorkflowID(request.NamespaceId, request.GetExecution().GetWorkflowId())
-	var response *historyservice.GenerateLastHistoryReplicationTasksResponse
-	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
-		var err error
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error) {
 		ctx, cancel := c.createContext(ctx)
 		defer cancel()
-		response, err = client.GenerateLastHistoryReplicationTasks(ctx, request, opts...)
-		return err
+		return client.GenerateLastHistoryReplicationTasks(ctx, request, opts...)
 	}
-	err = c.executeWithRedirect(ctx, client, op)
+	response, err := c.executeWithRedirect(ctx, client, op)
 	if err != nil {
 		return nil, err
 	}
-	return response, nil
+	return response.(*historyservice.GenerateLastHistoryReplicationTasksResponse), nil
 }
 
 func (c *clientImpl) GetReplicationStatus(
@@ -1099,7 +1051,7 @@ func (c *clientImpl) createContext(parent context.Context) (context.Context, con
 }
 
 func (c *clientImpl) getClientForWorkflowID(namespaceID, workflowID string) (historyservice.HistoryServiceClient, error) {
-	key := common.WorkflowIDToHistoryShard(namespaceID, workflowID, c.numberOfShards)
+	key := c.shardPartitions.GetShardID(namespaceID, workflowID)
 	return c.getClientForShardID(key)
 }
 
@@ -1111,13 +1063,92 @@ func (c *clientImpl) getClientForShardID(shardID int32) (historyservice.HistoryS
 	if err != nil {
 		return nil, err
 	}
-	return client.(historyservice.HistoryServiceClient), nil
+	return &shardClient{
+		HistoryServiceClient: client.(historyservice.HistoryServiceClient),
+		shardID:              shardID,
+	}, nil
 }
 
+// executeWithRedirect runs op against the history host owning the shard of the client, following
+// shard ownership redirects. While the shard is moving between hosts, op is hedged to the presumed
+// new owner after a short delay and the first conclusive result is returned.
 func (c *clientImpl) executeWithRedirect(ctx context.Context,
 	client historyservice.HistoryServiceClient,
-	op func(ctx context.Context, client historyservice.HistoryServiceClient) error) error {
+	op func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error)) (interface{}, error) {
+
+	sc, ok := client.(*shardClient)
+	if !ok || c.shardHandoffHedgeDelay == nil {
+		return c.executeAttempt(ctx, client, op)
+	}
+	delay := c.shardHandoffHedgeDelay()
+	if delay <= 0 {
+		return c.executeAttempt(ctx, client, op)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan attemptResult, maxShardHandoffHedges+1)
+	attempt := func(client historyservice.HistoryServiceClient) {
+		go func() {
+			response, err := c.executeAttempt(ctx, client, op)
+			results <- attemptResult{response: response, err: err}
+		}()
+	}
+
+	attempt(client)
+	inFlight := 1
+	hedges := 0
+	lastHost := hostOf(client)
+	var lastResult attemptResult
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	done := ctx.Done()
+	for {
+		select {
+		case result := <-results:
+			inFlight--
+			if _, ok := result.err.(*serviceerrors.ShardUnavailable); !ok {
+				return result.response, result.err
+			}
+			lastResult = result
+			if inFlight == 0 && hedges == maxShardHandoffHedges {
+				return lastResult.response, lastResult.err
+			}
+
+		case <-timer.C:
+			owner, err := c.getClientForShardID(sc.shardID)
+			if err != nil {
+				c.logger.Warn("Failed to resolve history shard owner", tag.ShardID(sc.shardID), tag.Error(err))
+			} else if inFlight == 0 || hostOf(owner) != lastHost {
+				// there is no point in sending the request twice to the same host
+				hedges++
+				inFlight++
+				lastHost = hostOf(owner)
+				attempt(owner)
+			}
+			if hedges < maxShardHandoffHedges {
+				timer.Reset(delay)
+			} else if inFlight == 0 {
+				return lastResult.response, lastResult.err
+			}
+
+		case <-done:
+			if inFlight == 0 {
+				return lastResult.response, lastResult.err
+			}
+			// in flight attempts return shortly with the context error
+			done = nil
+		}
+	}
+}
+
+func (c *clientImpl) executeAttempt(ctx context.Context,
+	client historyservice.HistoryServiceClient,
+	op func(ctx context.Context, client historyservice.HistoryServiceClient) (interface{}, error)) (interface{}, error) {
 
+	var response interface{}
 	var err error
 redirectLoop:
 	for {
@@ -1125,13 +1156,14 @@ redirectLoop:
 		if err != nil {
 			break redirectLoop
 		}
-		err = op(ctx, client)
+		response, err = op(ctx, client)
 		if err != nil {
+			c.reportIfUnreachable(client, err)
 			if s, ok := err.(*serviceerrors.ShardOwnershipLost); ok {
 				// TODO: consider emitting a metric for number of redirects
 				ret, err := c.clients.GetClientForClientKey(s.OwnerHost)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				client = ret.(historyservice.HistoryServiceClient)
 				continue redirectLoop
@@ -1139,5 +1171,31 @@ redirectLoop:
 		}
 		break redirectLoop
 	}
-	return err
+	return response, err
+}
+
+// hostOf returns the client of the history host behind the given client
+func hostOf(client historyservice.HistoryServiceClient) historyservice.HistoryServiceClient {
+	if sc, ok := client.(*shardClient); ok {
+		return sc.HistoryServiceClient
+	}
+	return client
+}
+
+func (c *clientImpl) reportIfUnreachable(
+	client historyservice.HistoryServiceClient,
+	err error,
+) {
+	if c.reportUnreachable == nil {
+		return
+	}
+	if _, ok := err.(*serviceerror.Unavailable); !ok {
+		return
+	}
+	if sc, ok := client.(*shardClient); ok {
+		client = sc.HistoryServiceClient
+	}
+	if hc, ok := client.(*hostClient); ok {
+		c.reportUnreachable(hc.address)
+	}
 }
@@ -0,0 +1,167 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc"
+
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	serviceerrors "go.temporal.io/server/common/serviceerror"
+)
+
+type (
+	clientSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller *gomock.Controller
+		hostA      *historyservicemock.MockHistoryServiceClient
+		hostB      *historyservicemock.MockHistoryServiceClient
+		resolver   *testKeyResolver
+		client     historyservice.HistoryServiceClient
+	}
+
+	testKeyResolver struct {
+		sync.Mutex
+		owner string
+	}
+)
+
+func TestClientSuite(t *testing.T) {
+	s := new(clientSuite)
+	suite.Run(t, s)
+}
+
+func (s *clientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.hostA = historyservicemock.NewMockHistoryServiceClient(s.controller)
+	s.hostB = historyservicemock.NewMockHistoryServiceClient(s.controller)
+	s.resolver = &testKeyResolver{owner: "A"}
+	hosts := map[string]historyservice.HistoryServiceClient{
+		"A": s.hostA,
+		"B": s.hostB,
+	}
+	clients := common.NewClientCache(s.resolver, func(address string) (interface{}, error) {
+		return hosts[address], nil
+	})
+	s.client = NewClient(
+		1,
+		common.NewShardPartitions(1, dynamicconfig.GetMapPropertyFn(nil), clock.NewRealTimeSource(), log.NewNoopLogger()),
+		DefaultTimeout,
+		clients,
+		nil,
+		dynamicconfig.GetDurationPropertyFn(10*time.Millisecond),
+		log.NewNoopLogger(),
+	)
+}
+
+func (s *clientSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *clientSuite) TestShardHandoff_HedgeToNewOwner() {
+	s.hostA.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *historyservice.GetMutableStateRequest, _ ...grpc.CallOption) (*historyservice.GetMutableStateResponse, error) {
+			// the shard moves while the request is stuck on the old owner
+			s.resolver.setOwner("B")
+			<-ctx.Done()
+			return nil, serviceerror.NewCanceled(ctx.Err().Error())
+		})
+	response := &historyservice.GetMutableStateResponse{NextEventId: 5}
+	s.hostB.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(response, nil)
+
+	resp, err := s.client.GetMutableState(context.Background(), s.newGetMutableStateRequest())
+	s.NoError(err)
+	s.Equal(response, resp)
+}
+
+func (s *clientSuite) TestShardHandoff_RetrySameOwner() {
+	response := &historyservice.GetMutableStateResponse{NextEventId: 5}
+	gomock.InOrder(
+		s.hostA.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(nil, serviceerrors.NewShardUnavailable("shard closed")),
+		s.hostA.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(response, nil),
+	)
+
+	resp, err := s.client.GetMutableState(context.Background(), s.newGetMutableStateRequest())
+	s.NoError(err)
+	s.Equal(response, resp)
+}
+
+func (s *clientSuite) TestShardHandoff_GiveUp() {
+	s.hostA.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(nil, serviceerrors.NewShardUnavailable("shard closed")).Times(maxShardHandoffHedges + 1)
+
+	_, err := s.client.GetMutableState(context.Background(), s.newGetMutableStateRequest())
+	s.IsType(&serviceerrors.ShardUnavailable{}, err)
+}
+
+func (s *clientSuite) TestOtherError_NotHedged() {
+	s.hostA.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewUnavailable("shard closed"))
+
+	_, err := s.client.GetMutableState(context.Background(), s.newGetMutableStateRequest())
+	s.IsType(&serviceerror.Unavailable{}, err)
+}
+
+func (s *clientSuite) newGetMutableStateRequest() *historyservice.GetMutableStateRequest {
+	return &historyservice.GetMutableStateRequest{
+		NamespaceId: "namespace-id",
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: "workflow-id",
+			RunId:      "run-id",
+		},
+	}
+}
+
+func (r *testKeyResolver) setOwner(owner string) {
+	r.Lock()
+	defer r.Unlock()
+	r.owner = owner
+}
+
+func (r *testKeyResolver) Lookup(_ string) (string, error) {
+	r.Lock()
+	defer r.Unlock()
+	return r.owner, nil
+}
+
+func (r *testKeyResolver) GetAllAddresses() ([]string, error) {
+	return []string{"A", "B"}, nil
+}
@@ -41,6 +41,7 @@ import (
 	_ "go.temporal.io/server/common/persistence/sql/sqlplugin/mysql"      // needed to load mysql plugin
 	_ "go.temporal.io/server/common/persistence/sql/sqlplugin/postgresql" // needed to load postgresql plugin
 	"go.temporal.io/server/temporal"
+	"go.temporal.io/server/tools/migrate"
 )
 
 // main entry point for the temporal server
@@ -171,6 +172,33 @@ func buildCLI() *cli.App {
 				return cli.Exit("All services are stopped.", 0)
 			},
 		},
+		{
+			Name:      "migrate",
+			Usage:     "Apply the schema migrations embedded in this binary to the configured datastores",
+			ArgsUsage: " ",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "log the pending schema updates without applying them",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				env := c.String("env")
+				zone := c.String("zone")
+				configDir := path.Join(c.String("root"), c.String("config"))
+
+				cfg, err := config.LoadConfig(env, configDir, zone)
+				if err != nil {
+					return cli.Exit(fmt.Sprintf("Unable to load configuration: %v.", err), 1)
+				}
+
+				logger := log.NewZapLogger(log.BuildZapLogger(cfg.Log))
+				if err := migrate.Migrate(&cfg.Persistence, c.Bool("dry-run"), logger); err != nil {
+					return cli.Exit(fmt.Sprintf("Unable to migrate schema. Error: %v", err), 1)
+				}
+				return nil
+			},
+		},
 	}
 	return app
 }
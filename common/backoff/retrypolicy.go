@@ -37,6 +37,7 @@ const (
 	noMaximumAttempts               = 0
 
 	defaultBackoffCoefficient = 2.0
+	defaultJitterCoefficient  = 0.2
 	defaultMaximumInterval    = 10 * time.Second
 	defaultExpirationInterval = time.Minute
 	defaultMaximumAttempts    = noMaximumAttempts
@@ -69,6 +70,7 @@ type (
 		maximumInterval    time.Duration
 		expirationInterval time.Duration
 		maximumAttempts    int
+		jitterCoefficient  float64
 	}
 
 	// TwoPhaseRetryPolicy implements a policy that first use one policy to get next delay,
@@ -100,6 +102,7 @@ func NewExponentialRetryPolicy(initialInterval time.Duration) *ExponentialRetryP
 		maximumInterval:    defaultMaximumInterval,
 		expirationInterval: defaultExpirationInterval,
 		maximumAttempts:    defaultMaximumAttempts,
+		jitterCoefficient:  defaultJitterCoefficient,
 	}
 
 	return p
@@ -144,6 +147,11 @@ func (p *ExponentialRetryPolicy) SetMaximumAttempts(maximumAttempts int) {
 	p.maximumAttempts = maximumAttempts
 }
 
+// SetJitterCoefficient sets the fraction of each delay which is randomized, in [0, 1]
+func (p *ExponentialRetryPolicy) SetJitterCoefficient(jitterCoefficient float64) {
+	p.jitterCoefficient = math.Max(0, math.Min(1, jitterCoefficient))
+}
+
 // ComputeNextDelay returns the next delay interval.  This is used by Retrier to delay calling the operation again
 func (p *ExponentialRetryPolicy) ComputeNextDelay(elapsedTime time.Duration, numAttempts int) time.Duration {
 	// Check to see if we ran out of maximum number of attempts
@@ -177,12 +185,12 @@ func (p *ExponentialRetryPolicy) ComputeNextDelay(elapsedTime time.Duration, num
 	}
 
 	// add jitter to avoid global synchronization
-	jitterPortion := int(0.2 * nextInterval)
+	jitterPortion := int(p.jitterCoefficient * nextInterval)
 	// Prevent overflow
 	if jitterPortion < 1 {
 		jitterPortion = 1
 	}
-	nextInterval = nextInterval*0.8 + float64(rand.Intn(jitterPortion))
+	nextInterval = nextInterval*(1-p.jitterCoefficient) + float64(rand.Intn(jitterPortion))
 
 	return time.Duration(nextInterval)
 }
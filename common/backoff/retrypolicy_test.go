@@ -114,6 +114,25 @@ func (s *RetryPolicySuite) TestBackoffCoefficient() {
 	}
 }
 
+func (s *RetryPolicySuite) TestJitterCoefficient() {
+	policy := createPolicy(2 * time.Second)
+	policy.SetBackoffCoefficient(1.0)
+	policy.SetJitterCoefficient(0)
+
+	r, _ := createRetrier(policy)
+	for i := 0; i < 10; i++ {
+		s.Equal(2*time.Second, r.NextBackOff())
+	}
+
+	policy.SetJitterCoefficient(0.5)
+	r, _ = createRetrier(policy)
+	for i := 0; i < 10; i++ {
+		next := r.NextBackOff()
+		s.True(next >= time.Second, "NextBackoff too low")
+		s.True(next < 2*time.Second, "NextBackoff too high")
+	}
+}
+
 func (s *RetryPolicySuite) TestExpirationInterval() {
 	policy := createPolicy(2 * time.Second)
 	policy.SetExpirationInterval(5 * time.Minute)
@@ -67,6 +67,11 @@ type Options struct {
 	// Pin prevents in-use objects from getting evicted.
 	Pin bool
 
+	// Protected is an optional function to protect entries from eviction by bulk traffic.
+	// It is evaluated once when an entry is inserted. Protected entries are evicted only
+	// when there is no unprotected entry that can be evicted instead.
+	Protected func(key interface{}) bool
+
 	// RemovedFunc is an optional function called when an element
 	// is scheduled for deletion
 	RemovedFunc RemovedFunc
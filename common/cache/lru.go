@@ -41,11 +41,14 @@ type (
 	lru struct {
 		mut      sync.Mutex
 		byAccess *list.List
-		byKey    map[interface{}]*list.Element
-		maxSize  int
-		ttl      time.Duration
-		pin      bool
-		rmFunc   RemovedFunc
+		// protectedByAccess holds the protected entries so eviction never has to walk past them
+		protectedByAccess *list.List
+		byKey             map[interface{}]*list.Element
+		maxSize           int
+		ttl               time.Duration
+		pin               bool
+		rmFunc            RemovedFunc
+		protected         func(key interface{}) bool
 	}
 
 	iteratorImpl struct {
@@ -59,6 +62,7 @@ type (
 		createTime time.Time
 		value      interface{}
 		refCount   int
+		protected  bool
 	}
 )
 
@@ -79,7 +83,7 @@ func (it *iteratorImpl) Next() Entry {
 	}
 
 	entry := it.nextItem.Value.(*entryImpl)
-	it.nextItem = it.nextItem.Next()
+	it.nextItem = it.next(it.nextItem)
 	// make a copy of the entry so there will be no concurrent access to this entry
 	entry = &entryImpl{
 		key:        entry.key,
@@ -94,7 +98,7 @@ func (it *iteratorImpl) prepareNext() {
 	for it.nextItem != nil {
 		entry := it.nextItem.Value.(*entryImpl)
 		if it.lru.isEntryExpired(entry, it.createTime) {
-			nextItem := it.nextItem.Next()
+			nextItem := it.next(it.nextItem)
 			it.lru.deleteInternal(it.nextItem)
 			it.nextItem = nextItem
 		} else {
@@ -103,6 +107,18 @@ func (it *iteratorImpl) prepareNext() {
 	}
 }
 
+// next returns the element after the given one, continuing with the protected entries once the
+// unprotected ones are exhausted
+func (it *iteratorImpl) next(element *list.Element) *list.Element {
+	if next := element.Next(); next != nil {
+		return next
+	}
+	if element.Value.(*entryImpl).protected {
+		return nil
+	}
+	return it.lru.protectedByAccess.Front()
+}
+
 // Iterator returns an iterator to the map. This map
 // does not use re-entrant locks, so access or modification
 // to the map during iteration can cause a dead lock.
@@ -113,6 +129,9 @@ func (c *lru) Iterator() Iterator {
 		createTime: time.Now().UTC(),
 		nextItem:   c.byAccess.Front(),
 	}
+	if iterator.nextItem == nil {
+		iterator.nextItem = c.protectedByAccess.Front()
+	}
 	iterator.prepareNext()
 	return iterator
 }
@@ -136,12 +155,14 @@ func New(maxSize int, opts *Options) Cache {
 	}
 
 	return &lru{
-		byAccess: list.New(),
-		byKey:    make(map[interface{}]*list.Element, opts.InitialCapacity),
-		ttl:      opts.TTL,
-		maxSize:  maxSize,
-		pin:      opts.Pin,
-		rmFunc:   opts.RemovedFunc,
+		byAccess:          list.New(),
+		protectedByAccess: list.New(),
+		byKey:             make(map[interface{}]*list.Element, opts.InitialCapacity),
+		ttl:               opts.TTL,
+		maxSize:           maxSize,
+		pin:               opts.Pin,
+		rmFunc:            opts.RemovedFunc,
+		protected:         opts.Protected,
 	}
 }
 
@@ -183,7 +204,7 @@ func (c *lru) Get(key interface{}) interface{} {
 	if c.pin {
 		entry.refCount++
 	}
-	c.byAccess.MoveToFront(element)
+	c.listOf(entry).MoveToFront(element)
 	return entry.value
 }
 
@@ -273,7 +294,7 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 				}
 			}
 
-			c.byAccess.MoveToFront(elt)
+			c.listOf(entry).MoveToFront(elt)
 			if c.pin {
 				entry.refCount++
 			}
@@ -285,6 +306,9 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 		key:   key,
 		value: value,
 	}
+	if c.protected != nil {
+		entry.protected = c.protected(key)
+	}
 
 	if c.pin {
 		entry.refCount++
@@ -294,25 +318,48 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 		entry.createTime = time.Now().UTC()
 	}
 
-	c.byKey[key] = c.byAccess.PushFront(entry)
+	inserted := c.listOf(entry).PushFront(entry)
+	c.byKey[key] = inserted
 	if len(c.byKey) > c.maxSize {
-		oldest := c.byAccess.Back().Value.(*entryImpl)
+		victim := c.evictionCandidate(inserted)
+		oldest := victim.Value.(*entryImpl)
 
 		if oldest.refCount > 0 {
 			// Cache is full with pinned elements
 			// revert the insert and return
-			c.deleteInternal(c.byAccess.Front())
+			c.deleteInternal(inserted)
 			return nil, ErrCacheFull
 		}
 
-		c.deleteInternal(c.byAccess.Back())
+		c.deleteInternal(victim)
 	}
 
 	return nil, nil
 }
 
+// evictionCandidate returns the least recently used unprotected element, or the least recently
+// used protected element if there is none. The most recently inserted element is never a candidate
+// unless it is the only one.
+func (c *lru) evictionCandidate(inserted *list.Element) *list.Element {
+	if back := c.byAccess.Back(); back != nil && back != inserted {
+		return back
+	}
+	if back := c.protectedByAccess.Back(); back != nil && back != inserted {
+		return back
+	}
+	return inserted
+}
+
+func (c *lru) listOf(entry *entryImpl) *list.List {
+	if entry.protected {
+		return c.protectedByAccess
+	}
+	return c.byAccess
+}
+
 func (c *lru) deleteInternal(element *list.Element) {
-	entry := c.byAccess.Remove(element).(*entryImpl)
+	entry := element.Value.(*entryImpl)
+	c.listOf(entry).Remove(element)
 	if c.rmFunc != nil {
 		go c.rmFunc(entry.value)
 	}
@@ -25,6 +25,7 @@
 package cache
 
 import (
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -71,6 +72,41 @@ func TestLRU(t *testing.T) {
 	assert.Nil(t, cache.Get("A"))
 }
 
+func TestLRUWithProtected(t *testing.T) {
+	cache := New(3, &Options{
+		Protected: func(key interface{}) bool {
+			return strings.HasPrefix(key.(string), "pinned")
+		},
+	})
+
+	cache.Put("pinned-A", "Foo")
+	cache.Put("pinned-B", "Bar")
+	cache.Put("C", "Cid")
+	cache.Put("D", "Delt")
+	// C is the only unprotected entry that can be evicted
+	assert.Nil(t, cache.Get("C"))
+	assert.Equal(t, "Foo", cache.Get("pinned-A"))
+	assert.Equal(t, "Bar", cache.Get("pinned-B"))
+	assert.Equal(t, "Delt", cache.Get("D"))
+
+	// most recently inserted entry is kept, protected entries are evicted in lru order
+	cache.Delete("D")
+	cache.Put("pinned-C", "Cid")
+	cache.Put("E", "Epsi")
+	assert.Equal(t, "Epsi", cache.Get("E"))
+	assert.Nil(t, cache.Get("pinned-A"))
+	assert.Equal(t, 3, cache.Size())
+
+	// the iterator covers both protected and unprotected entries
+	it := cache.Iterator()
+	var keys []string
+	for it.HasNext() {
+		keys = append(keys, it.Next().Key().(string))
+	}
+	it.Close()
+	assert.ElementsMatch(t, []string{"E", "pinned-B", "pinned-C"}, keys)
+}
+
 func TestGenerics(t *testing.T) {
 	key := keyType{
 		dummyString: "some random key",
@@ -214,6 +214,28 @@ type (
 		// This is generally used when BindOnIP would be the same across several nodes (ie: 0.0.0.0)
 		// and for nat traversal scenarios. Check net.ParseIP for supported syntax, only IPv4 is supported.
 		BroadcastAddress string `yaml:"broadcastAddress"`
+		// UnreachableHostCheck is the config for accelerated failure detection of hosts reported
+		// as unreachable by RPC clients, nil disables the accelerated failure detection
+		UnreachableHostCheck *UnreachableHostCheck `yaml:"unreachableHostCheck"`
+	}
+
+	// UnreachableHostCheck contains the config for health checking hosts which RPC clients failed to connect to.
+	// A host failing all probes is reported to its peers via membership. Once a peer's own check confirmed it,
+	// the host is excluded from the membership rings of all hosts, so e.g. its history shards get reassigned
+	// before gossip based failure detection catches up. It has to be enabled on the peers to take effect.
+	UnreachableHostCheck struct {
+		// Attempts is the number of consecutive failed probes before a host is considered dead
+		Attempts int `yaml:"attempts"`
+		// ProbeInterval is the wait time between two probes of the same host
+		ProbeInterval time.Duration `yaml:"probeInterval"`
+		// ProbeTimeout is the connect timeout of a single probe
+		ProbeTimeout time.Duration `yaml:"probeTimeout"`
+		// ExclusionDuration is how long a dead host is kept out of the local membership ring
+		ExclusionDuration time.Duration `yaml:"exclusionDuration"`
+		// Cooldown is the min interval between two checks of the same host, to avoid flapping
+		Cooldown time.Duration `yaml:"cooldown"`
+		// MaxExcludedFraction is the max fraction of a service's hosts which can be excluded at the same time
+		MaxExcludedFraction float64 `yaml:"maxExcludedFraction"`
 	}
 
 	// Persistence contains the configuration for data store / persistence layer
@@ -231,6 +253,10 @@ type (
 		DataStores map[string]DataStore `yaml:"datastores"`
 		// TransactionSizeLimit is the largest allowed transaction size
 		TransactionSizeLimit dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
+		// EnableHistoryEventBatchChecksum enables checksum generation for persisted history event batches
+		EnableHistoryEventBatchChecksum dynamicconfig.BoolPropertyFn `yaml:"-" json:"-"`
+		// NamespaceMetricsLimit is the max number of namespaces tagged on execution persistence metrics
+		NamespaceMetricsLimit dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -282,6 +308,11 @@ type (
 		// Default defines the consistency level for ALL stores.
 		// Defaults to LOCAL_QUORUM and LOCAL_SERIAL if not set
 		Default *CassandraConsistencySettings `yaml:"default"`
+		// TaskRead overrides the consistency level used by history task queue range scans (defaults to Default if not set).
+		// Levels weaker than a quorum require AllowEventualTaskReads, as a scan which misses a task may move the ack level past it.
+		TaskRead *CassandraConsistencySettings `yaml:"taskRead"`
+		// AllowEventualTaskReads permits TaskRead to use a non quorum consistency level.
+		AllowEventualTaskReads bool `yaml:"allowEventualTaskReads"`
 	}
 
 	// CassandraConsistencySettings sets the default consistency level for regular & serial queries to Cassandra.
@@ -318,6 +349,11 @@ type (
 		// This is used for in a sharded sql database such as Vitess for heavy task workloads to minimize scatter gather.
 		// The default value for this param is 1, and should not be configured without a thorough understanding of what this does.
 		TaskScanPartitions int `yaml:"taskScanPartitions"`
+		// EXPERIMENTAL - TaskQueueSubShards is the number of sub-shards the tasks of a single task queue partition are
+		// hashed across, to spread range reads and acks of heavily used task queues over multiple rows.
+		// The default value for this param is 1. Changing it requires the task backlogs to be drained first,
+		// as existing tasks are only found under the sub-shard layout they were written with.
+		TaskQueueSubShards int `yaml:"taskQueueSubShards"`
 		// TLS is the configuration for TLS connections
 		TLS *auth.TLS `yaml:"tls"`
 	}
@@ -121,6 +121,9 @@ func (ds *DataStore) Validate() error {
 	if ds.SQL != nil && ds.SQL.TaskScanPartitions == 0 {
 		ds.SQL.TaskScanPartitions = 1
 	}
+	if ds.SQL != nil && ds.SQL.TaskQueueSubShards == 0 {
+		ds.SQL.TaskQueueSubShards = 1
+	}
 	if ds.Cassandra != nil {
 		if err := ds.Cassandra.validate(); err != nil {
 			return err
@@ -143,6 +146,14 @@ func (c *CassandraStoreConsistency) GetSerialConsistency() gocql.SerialConsisten
 	return res
 }
 
+// GetTaskReadConsistency returns the gosql.Consistency setting used for history task queue range scans
+func (c *CassandraStoreConsistency) GetTaskReadConsistency() gocql.Consistency {
+	if c == nil || c.TaskRead == nil || c.TaskRead.Consistency == "" {
+		return c.GetConsistency()
+	}
+	return gocql.ParseConsistency(c.TaskRead.Consistency)
+}
+
 func (c *CassandraStoreConsistency) getConsistencySettings() *CassandraConsistencySettings {
 	return ensureStoreConsistencyNotNil(c).Default
 }
@@ -184,9 +195,38 @@ func (c *CassandraStoreConsistency) validate() error {
 		}
 	}
 
+	return c.validateTaskRead()
+}
+
+func (c *CassandraStoreConsistency) validateTaskRead() error {
+	if c.TaskRead == nil {
+		return nil
+	}
+
+	if c.TaskRead.SerialConsistency != "" {
+		return errors.New("bad cassandra task read consistency: serial consistency is not applicable to task reads")
+	}
+
+	consistency := c.GetTaskReadConsistency()
+	if consistency == gocql.Any {
+		return fmt.Errorf("bad cassandra task read consistency: %v is not applicable to reads", consistency)
+	}
+	if !c.AllowEventualTaskReads && !isQuorumConsistency(consistency) && c.GetConsistency() != gocql.All {
+		return fmt.Errorf("bad cassandra task read consistency: %v may miss tasks written with %v, set allowEventualTaskReads to permit it",
+			consistency, c.GetConsistency())
+	}
 	return nil
 }
 
+func isQuorumConsistency(c gocql.Consistency) bool {
+	switch c {
+	case gocql.Quorum, gocql.LocalQuorum, gocql.EachQuorum, gocql.All:
+		return true
+	default:
+		return false
+	}
+}
+
 func (c *CassandraConsistencySettings) validate() error {
 	if c == nil {
 		return nil
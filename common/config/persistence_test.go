@@ -121,6 +121,51 @@ func TestCassandraStoreConsistency_GetSerialConsistency(t *testing.T) {
 	}
 }
 
+func TestCassandraStoreConsistency_GetTaskReadConsistency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input *CassandraStoreConsistency
+		want  gocql.Consistency
+	}{
+		{
+			name:  "Nil Consistency Settings",
+			input: nil,
+			want:  gocql.LocalQuorum,
+		},
+		{
+			name: "Default Override",
+			input: &CassandraStoreConsistency{
+				Default: &CassandraConsistencySettings{
+					Consistency: "All",
+				},
+			},
+			want: gocql.All,
+		},
+		{
+			name: "Task Read Override",
+			input: &CassandraStoreConsistency{
+				Default: &CassandraConsistencySettings{
+					Consistency: "All",
+				},
+				TaskRead: &CassandraConsistencySettings{
+					Consistency: "local_one",
+				},
+			},
+			want: gocql.LocalOne,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.input
+			if got := c.GetTaskReadConsistency(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CassandraStoreConsistency.GetTaskReadConsistency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCassandraConsistencySettings_validate(t *testing.T) {
 	t.Parallel()
 
@@ -220,6 +265,65 @@ func TestCassandraStoreConsistency_validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "quorum task read settings",
+			settings: &CassandraStoreConsistency{
+				TaskRead: &CassandraConsistencySettings{
+					Consistency: "local_quorum",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "eventual task read settings without opt in",
+			settings: &CassandraStoreConsistency{
+				TaskRead: &CassandraConsistencySettings{
+					Consistency: "local_one",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "eventual task read settings with opt in",
+			settings: &CassandraStoreConsistency{
+				TaskRead: &CassandraConsistencySettings{
+					Consistency: "local_one",
+				},
+				AllowEventualTaskReads: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "eventual task read settings with all writes",
+			settings: &CassandraStoreConsistency{
+				Default: &CassandraConsistencySettings{
+					Consistency: "all",
+				},
+				TaskRead: &CassandraConsistencySettings{
+					Consistency: "one",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "any task read settings",
+			settings: &CassandraStoreConsistency{
+				TaskRead: &CassandraConsistencySettings{
+					Consistency: "any",
+				},
+				AllowEventualTaskReads: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "serial task read settings",
+			settings: &CassandraStoreConsistency{
+				TaskRead: &CassandraConsistencySettings{
+					SerialConsistency: "serial",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -35,3 +35,14 @@ type DefaultRetrySettings struct {
 	BackoffCoefficient         float64
 	MaximumAttempts            int32
 }
+
+// RetryPolicySettings are the settings of an exponential retry policy used by
+// the server for its own operations, e.g. retrying persistence calls
+type RetryPolicySettings struct {
+	InitialInterval    time.Duration
+	MaximumInterval    time.Duration
+	ExpirationInterval time.Duration
+	MaximumAttempts    int
+	// JitterCoefficient is the fraction of each delay which is randomized, 0 keeps the default of the policy
+	JitterCoefficient float64
+}
@@ -95,6 +95,9 @@ type IntPropertyFnWithTaskQueueInfoFilters func(namespace string, taskQueue stri
 // IntPropertyFnWithShardIDFilter is a wrapper to get int property from dynamic config with shardID as filter
 type IntPropertyFnWithShardIDFilter func(shardID int32) int
 
+// IntPropertyFnWithWorkflowTypeFilter is a wrapper to get int property from dynamic config with two filters: namespace, workflowType
+type IntPropertyFnWithWorkflowTypeFilter func(namespace string, workflowType string) int
+
 // FloatPropertyFn is a wrapper to get float property from dynamic config
 type FloatPropertyFn func(opts ...FilterOption) float64
 
@@ -122,6 +125,9 @@ type DurationPropertyFnWithTaskQueueInfoFilters func(namespace string, taskQueue
 // DurationPropertyFnWithShardIDFilter is a wrapper to get duration property from dynamic config with shardID as filter
 type DurationPropertyFnWithShardIDFilter func(shardID int32) time.Duration
 
+// DurationPropertyFnWithWorkflowTypeFilter is a wrapper to get duration property from dynamic config with two filters: namespace, workflowType
+type DurationPropertyFnWithWorkflowTypeFilter func(namespace string, workflowType string) time.Duration
+
 // BoolPropertyFn is a wrapper to get bool property from dynamic config
 type BoolPropertyFn func(opts ...FilterOption) bool
 
@@ -146,6 +152,9 @@ type BoolPropertyFnWithNamespaceIDFilter func(id string) bool
 // BoolPropertyFnWithTaskQueueInfoFilters is a wrapper to get bool property from dynamic config with three filters: namespace, taskQueue, taskType
 type BoolPropertyFnWithTaskQueueInfoFilters func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) bool
 
+// BoolPropertyFnWithShardIDFilter is a wrapper to get bool property from dynamic config with shardID as filter
+type BoolPropertyFnWithShardIDFilter func(shardID int32) bool
+
 // GetProperty gets a interface property and returns defaultValue if property is not found
 func (c *Collection) GetProperty(key Key, defaultValue interface{}) PropertyFn {
 	return func() interface{} {
@@ -222,6 +231,38 @@ func (c *Collection) GetIntPropertyFilteredByTaskQueueInfo(key Key, defaultValue
 	}
 }
 
+// GetIntPropertyFilteredByWorkflowType gets property with namespace and workflowType as filters and asserts that it's an integer.
+// Values constrained by namespace only are used for workflow types without a specific value.
+func (c *Collection) GetIntPropertyFilteredByWorkflowType(key Key, defaultValue int) IntPropertyFnWithWorkflowTypeFilter {
+	return func(namespace string, workflowType string) int {
+		val := defaultValue
+		var err error
+
+		filterMaps := []map[Filter]interface{}{
+			getFilterMap(NamespaceFilter(namespace), WorkflowTypeFilter(workflowType)),
+			getFilterMap(NamespaceFilter(namespace)),
+		}
+
+		for _, filterMap := range filterMaps {
+			val, err = c.client.GetIntValue(
+				key,
+				filterMap,
+				defaultValue,
+			)
+			if err != nil {
+				c.logError(key, err)
+			}
+
+			if val != defaultValue {
+				break
+			}
+		}
+
+		c.logValue(key, val, defaultValue, intCompareEquals)
+		return val
+	}
+}
+
 // GetIntPropertyFilteredByShardID gets property with shardID as filter and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByShardID(key Key, defaultValue int) IntPropertyFnWithShardIDFilter {
 	return func(shardID int32) int {
@@ -376,6 +417,38 @@ func (c *Collection) GetDurationPropertyFilteredByTaskQueueInfo(key Key, default
 	}
 }
 
+// GetDurationPropertyFilteredByWorkflowType gets property with namespace and workflowType as filters and asserts that it's a duration.
+// Values constrained by namespace only are used for workflow types without a specific value.
+func (c *Collection) GetDurationPropertyFilteredByWorkflowType(key Key, defaultValue time.Duration) DurationPropertyFnWithWorkflowTypeFilter {
+	return func(namespace string, workflowType string) time.Duration {
+		val := defaultValue
+		var err error
+
+		filterMaps := []map[Filter]interface{}{
+			getFilterMap(NamespaceFilter(namespace), WorkflowTypeFilter(workflowType)),
+			getFilterMap(NamespaceFilter(namespace)),
+		}
+
+		for _, filterMap := range filterMaps {
+			val, err = c.client.GetDurationValue(
+				key,
+				filterMap,
+				defaultValue,
+			)
+			if err != nil {
+				c.logError(key, err)
+			}
+
+			if val != defaultValue {
+				break
+			}
+		}
+
+		c.logValue(key, val, defaultValue, durationCompareEquals)
+		return val
+	}
+}
+
 // GetDurationPropertyFilteredByShardID gets property with shardID id as filter and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByShardID(key Key, defaultValue time.Duration) DurationPropertyFnWithShardIDFilter {
 	return func(shardID int32) time.Duration {
@@ -476,6 +549,22 @@ func (c *Collection) GetBoolPropertyFnWithNamespaceIDFilter(key Key, defaultValu
 	}
 }
 
+// GetBoolPropertyFilteredByShardID gets property with shardID as filter and asserts that it's a bool
+func (c *Collection) GetBoolPropertyFilteredByShardID(key Key, defaultValue bool) BoolPropertyFnWithShardIDFilter {
+	return func(shardID int32) bool {
+		val, err := c.client.GetBoolValue(
+			key,
+			getFilterMap(ShardIDFilter(shardID)),
+			defaultValue,
+		)
+		if err != nil {
+			c.logError(key, err)
+		}
+		c.logValue(key, val, defaultValue, boolCompareEquals)
+		return val
+	}
+}
+
 // GetBoolPropertyFilteredByTaskQueueInfo gets property with taskQueueInfo as filters and asserts that it's an bool
 func (c *Collection) GetBoolPropertyFilteredByTaskQueueInfo(key Key, defaultValue bool) BoolPropertyFnWithTaskQueueInfoFilters {
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) bool {
@@ -47,6 +47,11 @@ func GetIntPropertyFilteredByTaskQueueInfo(value int) func(namespace string, tas
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) int { return value }
 }
 
+// GetIntPropertyFilteredByWorkflowType returns value as IntPropertyFnWithWorkflowTypeFilter
+func GetIntPropertyFilteredByWorkflowType(value int) func(namespace string, workflowType string) int {
+	return func(namespace string, workflowType string) int { return value }
+}
+
 // GetFloatPropertyFn returns value as FloatPropertyFn
 func GetFloatPropertyFn(value float64) func(opts ...FilterOption) float64 {
 	return func(...FilterOption) float64 { return value }
@@ -62,6 +67,11 @@ func GetBoolPropertyFnFilteredByNamespace(value bool) func(namespace string) boo
 	return func(namespace string) bool { return value }
 }
 
+// GetBoolPropertyFnFilteredByShardID returns value as BoolPropertyFnWithShardIDFilter
+func GetBoolPropertyFnFilteredByShardID(value bool) func(shardID int32) bool {
+	return func(shardID int32) bool { return value }
+}
+
 // GetDurationPropertyFnFilteredByNamespace returns value as DurationPropertyFnFilteredByNamespace
 func GetDurationPropertyFnFilteredByNamespace(value time.Duration) func(namespace string) time.Duration {
 	return func(namespace string) time.Duration { return value }
@@ -77,11 +87,21 @@ func GetDurationPropertyFnFilteredByTaskQueueInfo(value time.Duration) func(name
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) time.Duration { return value }
 }
 
+// GetDurationPropertyFnFilteredByWorkflowType returns value as DurationPropertyFnWithWorkflowTypeFilter
+func GetDurationPropertyFnFilteredByWorkflowType(value time.Duration) func(namespace string, workflowType string) time.Duration {
+	return func(namespace string, workflowType string) time.Duration { return value }
+}
+
 // GetStringPropertyFn returns value as StringPropertyFn
 func GetStringPropertyFn(value string) func(opts ...FilterOption) string {
 	return func(...FilterOption) string { return value }
 }
 
+// GetStringPropertyFnFilteredByNamespace returns value as StringPropertyFnWithNamespaceFilter
+func GetStringPropertyFnFilteredByNamespace(value string) func(namespace string) string {
+	return func(namespace string) string { return value }
+}
+
 // GetMapPropertyFn returns value as MapPropertyFn
 func GetMapPropertyFn(value map[string]interface{}) func(opts ...FilterOption) map[string]interface{} {
 	return func(...FilterOption) map[string]interface{} { return value }
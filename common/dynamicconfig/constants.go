@@ -81,6 +81,8 @@ var Keys = map[Key]string{
 	EnableReadFromVisibilityArchival:       "system.enableReadFromVisibilityArchival",
 	EnableNamespaceNotActiveAutoForwarding: "system.enableNamespaceNotActiveAutoForwarding",
 	TransactionSizeLimit:                   "system.transactionSizeLimit",
+	EnableHistoryEventBatchChecksum:        "system.enableHistoryEventBatchChecksum",
+	PersistenceNamespaceMetricsLimit:       "system.persistenceNamespaceMetricsLimit",
 	DisallowQuery:                          "system.disallowQuery",
 	EnableBatcher:                          "worker.enableBatcher",
 	EnableParentClosePolicyWorker:          "system.enableParentClosePolicyWorker",
@@ -88,6 +90,11 @@ var Keys = map[Key]string{
 	EnablePriorityTaskProcessor:            "system.enablePriorityTaskProcessor",
 	EnableAuthorization:                    "system.enableAuthorization",
 	EnableCrossNamespaceCommands:           "system.enableCrossNamespaceCommands",
+	CrossNamespaceCommandTargets:           "system.crossNamespaceCommandTargets",
+	EnableGlobalCrossNamespaceCommands:     "system.enableGlobalCrossNamespaceCommands",
+	HistoryClientShardHandoffHedgeDelay:    "system.historyClientShardHandoffHedgeDelay",
+	HistoryShardPins:                       "system.historyShardPins",
+	NamespaceShardPartitions:               "system.namespaceShardPartitions",
 
 	// size limit
 	BlobSizeLimitError:     "limit.blobSize.error",
@@ -100,6 +107,15 @@ var Keys = map[Key]string{
 	HistoryCountLimitWarn:  "limit.historyCount.warn",
 	MaxIDLengthLimit:       "limit.maxIDLength",
 
+	MutableStateSizeLimitError:          "limit.mutableStateSize.error",
+	MutableStateSizeLimitWarn:           "limit.mutableStateSize.warn",
+	NumPendingActivitiesLimitError:      "limit.numPendingActivities.error",
+	NumPendingActivitiesLimitWarn:       "limit.numPendingActivities.warn",
+	NumPendingChildExecutionsLimitError: "limit.numPendingChildExecutions.error",
+	NumPendingChildExecutionsLimitWarn:  "limit.numPendingChildExecutions.warn",
+	NumPendingSignalsLimitError:         "limit.numPendingSignals.error",
+	NumPendingSignalsLimitWarn:          "limit.numPendingSignals.warn",
+
 	// frontend settings
 	FrontendPersistenceMaxQPS:             "frontend.persistenceMaxQPS",
 	FrontendPersistenceGlobalMaxQPS:       "frontend.persistenceGlobalMaxQPS",
@@ -113,6 +129,12 @@ var Keys = map[Key]string{
 	FrontendMaxNamespaceCountPerInstance:  "frontend.namespaceCount",
 	FrontendGlobalNamespaceRPS:            "frontend.globalNamespacerps",
 	FrontendShutdownDrainDuration:         "frontend.shutdownDrainDuration",
+	FrontendMaintenanceMode:               "frontend.maintenanceMode",
+	FrontendMaintenanceModeRetryAfter:     "frontend.maintenanceModeRetryAfter",
+	FrontendMaxHistoryLongPollsPerCaller:  "frontend.maxHistoryLongPollsPerCaller",
+	FrontendHistoryLongPollRetryAfter:     "frontend.historyLongPollRetryAfter",
+	FrontendRecordWorkerPrincipal:         "frontend.recordWorkerPrincipal",
+	FrontendPerIdentityTaskMetrics:        "frontend.perIdentityTaskMetrics",
 	DisableListVisibilityByFilter:         "frontend.disableListVisibilityByFilter",
 	FrontendThrottledLogRPS:               "frontend.throttledLogRPS",
 	EnableClientVersionCheck:              "frontend.enableClientVersionCheck",
@@ -154,31 +176,54 @@ var Keys = map[Key]string{
 	MatchingForwarderMaxOutstandingTasks:    "matching.forwarderMaxOutstandingTasks",
 	MatchingForwarderMaxRatePerSecond:       "matching.forwarderMaxRatePerSecond",
 	MatchingForwarderMaxChildrenPerNode:     "matching.forwarderMaxChildrenPerNode",
+	MatchingForwarderPollLocalWait:          "matching.forwarderPollLocalWait",
+	MatchingForwarderPollLatencyBudget:      "matching.forwarderPollLatencyBudget",
 	MatchingShutdownDrainDuration:           "matching.shutdownDrainDuration",
+	MatchingQueueBacklogMetricsInterval:     "matching.queueBacklogMetricsInterval",
+	MatchingEnableBacklogTrimming:           "matching.enableBacklogTrimming",
+	MatchingBacklogTrimInterval:             "matching.backlogTrimInterval",
+	MatchingBacklogTrimMinTaskAge:           "matching.backlogTrimMinTaskAge",
+	MatchingBacklogTrimBatchSize:            "matching.backlogTrimBatchSize",
+	MatchingBacklogTrimSampleSize:           "matching.backlogTrimSampleSize",
+	MatchingStickyPollerUnavailableWindow:   "matching.stickyPollerUnavailableWindow",
+	MatchingEnableNamespaceFairShare:        "matching.enableNamespaceFairShare",
+	MatchingNamespaceFairShareWeight:        "matching.namespaceFairShareWeight",
+	MatchingFairShareStarvationThreshold:    "matching.fairShareStarvationThreshold",
 
 	// history settings
 	HistoryRPS:                                           "history.rps",
 	HistoryPersistenceMaxQPS:                             "history.persistenceMaxQPS",
 	HistoryPersistenceGlobalMaxQPS:                       "history.persistenceGlobalMaxQPS",
 	HistoryLongPollExpirationInterval:                    "history.longPollExpirationInterval",
+	HistoryMaxLongPollsPerExecution:                      "history.maxLongPollsPerExecution",
 	HistoryCacheInitialSize:                              "history.cacheInitialSize",
 	HistoryMaxAutoResetPoints:                            "history.historyMaxAutoResetPoints",
+	HistoryMaxCompactedResetPoints:                       "history.maxCompactedResetPoints",
 	HistoryCacheMaxSize:                                  "history.cacheMaxSize",
 	HistoryCacheTTL:                                      "history.cacheTTL",
+	HistoryCachePinnedNamespace:                          "history.cachePinnedNamespace",
 	HistoryShutdownDrainDuration:                         "history.shutdownDrainDuration",
 	EventsCacheInitialSize:                               "history.eventsCacheInitialSize",
 	EventsCacheMaxSize:                                   "history.eventsCacheMaxSize",
 	EventsCacheTTL:                                       "history.eventsCacheTTL",
 	AcquireShardInterval:                                 "history.acquireShardInterval",
 	AcquireShardConcurrency:                              "history.acquireShardConcurrency",
+	EnableShardOwnershipHints:                            "history.enableShardOwnershipHints",
+	HistoryQueueBacklogMetricsInterval:                   "history.queueBacklogMetricsInterval",
 	StandbyClusterDelay:                                  "history.standbyClusterDelay",
 	StandbyTaskMissingEventsResendDelay:                  "history.standbyTaskMissingEventsResendDelay",
 	StandbyTaskMissingEventsDiscardDelay:                 "history.standbyTaskMissingEventsDiscardDelay",
+	StandbyReadMaxStaleness:                              "history.standbyReadMaxStaleness",
+	EmitLimitWarnings:                                    "history.emitLimitWarnings",
+	ReapplyEventTypes:                                    "history.reapplyEventTypes",
+	VisibilityRecordRetention:                            "history.visibilityRecordRetention",
+	ActivityHeartbeatCoalesceInterval:                    "history.activityHeartbeatCoalesceInterval",
 	TaskProcessRPS:                                       "history.taskProcessRPS",
 	TaskSchedulerType:                                    "history.taskSchedulerType",
 	TaskSchedulerWorkerCount:                             "history.taskSchedulerWorkerCount",
 	TaskSchedulerQueueSize:                               "history.taskSchedulerQueueSize",
 	TaskSchedulerRoundRobinWeights:                       "history.taskSchedulerRoundRobinWeight",
+	TaskProcessorThrottleErrorRate:                       "history.taskProcessorThrottleErrorRate",
 	TimerTaskBatchSize:                                   "history.timerTaskBatchSize",
 	TimerTaskWorkerCount:                                 "history.timerTaskWorkerCount",
 	TimerTaskMaxRetryCount:                               "history.timerTaskMaxRetryCount",
@@ -234,6 +279,8 @@ var Keys = map[Key]string{
 	VisibilityProcessorMaxRedispatchQueueSize:              "history.visibilityProcessorMaxRedispatchQueueSize",
 	VisibilityProcessorEnablePriorityTaskProcessor:         "history.visibilityProcessorEnablePriorityTaskProcessor",
 	VisibilityProcessorVisibilityArchivalTimeLimit:         "history.visibilityProcessorVisibilityArchivalTimeLimit",
+	VisibilityProcessorMemoSizeLimit:                       "history.visibilityProcessorMemoSizeLimit",
+	VisibilityActivityProgressInterval:                     "history.visibilityActivityProgressInterval",
 
 	TieredStorageTaskBatchSize:                                "history.tieredStorageTaskBatchSize",
 	TieredStorageProcessorFailoverMaxPollRPS:                  "history.tieredStorageProcessorFailoverMaxPollRPS",
@@ -270,6 +317,31 @@ var Keys = map[Key]string{
 	MaximumSignalsPerExecution:                             "history.maximumSignalsPerExecution",
 	ShardUpdateMinInterval:                                 "history.shardUpdateMinInterval",
 	ShardSyncMinInterval:                                   "history.shardSyncMinInterval",
+	ShardDrainTimeout:                                      "history.shardDrainTimeout",
+	ShardPersistenceMaxQPS:                                 "history.shardPersistenceMaxQPS",
+	ShardPersistenceNamespaceMaxQPS:                        "history.shardPersistenceNamespaceMaxQPS",
+	ShardPersistenceRetryPolicy:                            "history.shardPersistenceRetryPolicy",
+	ShardAcquisitionRetryPolicy:                            "history.shardAcquisitionRetryPolicy",
+	ShardAcquisitionMaxConcurrency:                         "history.shardAcquisitionMaxConcurrency",
+	ShardAcquisitionPacing:                                 "history.shardAcquisitionPacing",
+	ShardWarmUpMaxExecutions:                               "history.shardWarmUpMaxExecutions",
+	ShardWarmUpTimeout:                                     "history.shardWarmUpTimeout",
+	RangePreallocationThreshold:                            "history.rangePreallocationThreshold",
+	RangeRenewalAlertRate:                                  "history.rangeRenewalAlertRate",
+	ShardIdleUnloadTimeout:                                 "history.shardIdleUnloadTimeout",
+	ShardReadOnly:                                          "history.shardReadOnly",
+	ShardClockSkewLimit:                                    "history.shardClockSkewLimit",
+	ShardClockSkewBlockTimerAllocation:                     "history.shardClockSkewBlockTimerAllocation",
+	ShardLockSlowThreshold:                                 "history.shardLockSlowThreshold",
+	ShardOwnershipHeartbeatInterval:                        "history.shardOwnershipHeartbeatInterval",
+	ShardOwnershipAssertionRate:                            "history.shardOwnershipAssertionRate",
+	ShardPruneRemovedClusters:                              "history.shardPruneRemovedClusters",
+	ShardLogDedupInterval:                                  "history.shardLogDedupInterval",
+	ShardReadValidateRangeID:                               "history.shardReadValidateRangeID",
+	ShardUpdateDirtyUpdatesThreshold:                       "history.shardUpdateDirtyUpdatesThreshold",
+	ShardUpdateProgressAtRiskThreshold:                     "history.shardUpdateProgressAtRiskThreshold",
+	ShardUpdateFastInterval:                                "history.shardUpdateFastInterval",
+	ShardUpdateIdleInterval:                                "history.shardUpdateIdleInterval",
 	ShardSyncTimerJitterCoefficient:                        "history.shardSyncMinInterval",
 	DefaultEventEncoding:                                   "history.defaultEventEncoding",
 	EnableParentClosePolicy:                                "history.enableParentClosePolicy",
@@ -298,7 +370,11 @@ var Keys = map[Key]string{
 	ReplicationTaskProcessorStartWaitJitterCoefficient:     "history.ReplicationTaskProcessorStartWaitJitterCoefficient",
 	ReplicationTaskProcessorHostQPS:                        "history.ReplicationTaskProcessorHostQPS",
 	ReplicationTaskProcessorShardQPS:                       "history.ReplicationTaskProcessorShardQPS",
+	ReplicationTaskReorderBufferWindow:                     "history.ReplicationTaskReorderBufferWindow",
+	ReplicationTaskReorderBufferMaxTasksPerExecution:       "history.ReplicationTaskReorderBufferMaxTasksPerExecution",
+	ReplicationTaskReorderBufferMaxTasks:                   "history.ReplicationTaskReorderBufferMaxTasks",
 	MaxBufferedQueryCount:                                  "history.MaxBufferedQueryCount",
+	WorkflowTaskQuarantineThreshold:                        "history.workflowTaskQuarantineThreshold",
 	MutableStateChecksumGenProbability:                     "history.mutableStateChecksumGenProbability",
 	MutableStateChecksumVerifyProbability:                  "history.mutableStateChecksumVerifyProbability",
 	MutableStateChecksumInvalidateBefore:                   "history.mutableStateChecksumInvalidateBefore",
@@ -308,6 +384,7 @@ var Keys = map[Key]string{
 	SkipReapplicationByNamespaceID:                         "history.SkipReapplicationByNamespaceID",
 	DefaultActivityRetryPolicy:                             "history.defaultActivityRetryPolicy",
 	DefaultWorkflowRetryPolicy:                             "history.defaultWorkflowRetryPolicy",
+	CapActivityTimeoutsByRunDeadline:                       "history.capActivityTimeoutsByRunDeadline",
 
 	// worker settings
 	WorkerPersistenceMaxQPS:       "worker.persistenceMaxQPS",
@@ -336,18 +413,21 @@ var Keys = map[Key]string{
 	WorkerBatcherMaxConcurrentWorkflowTaskExecutionSize: "worker.BatcherMaxConcurrentWorkflowTaskExecutionSize",
 	WorkerBatcherMaxConcurrentActivityTaskPollers:       "worker.BatcherMaxConcurrentActivityTaskPollers",
 	WorkerBatcherMaxConcurrentWorkflowTaskPollers:       "worker.BatcherMaxConcurrentWorkflowTaskPollers",
+	WorkerBatcherBlastRadiusGuardFraction:               "worker.BatcherBlastRadiusGuardFraction",
+	WorkerBatcherNamespaceRPS:                           "worker.BatcherNamespaceRPS",
 
 	WorkerParentCloseMaxConcurrentActivityExecutionSize:     "worker.ParentCloseMaxConcurrentActivityExecutionSize",
 	WorkerParentCloseMaxConcurrentWorkflowTaskExecutionSize: "worker.ParentCloseMaxConcurrentWorkflowTaskExecutionSize",
 	WorkerParentCloseMaxConcurrentActivityTaskPollers:       "worker.ParentCloseMaxConcurrentActivityTaskPollers",
 	WorkerParentCloseMaxConcurrentWorkflowTaskPollers:       "worker.ParentCloseMaxConcurrentWorkflowTaskPollers",
 
-	WorkerTimeLimitPerArchivalIteration: "worker.TimeLimitPerArchivalIteration",
-	WorkerThrottledLogRPS:               "worker.throttledLogRPS",
-	ScannerPersistenceMaxQPS:            "worker.scannerPersistenceMaxQPS",
-	TaskQueueScannerEnabled:             "worker.taskQueueScannerEnabled",
-	HistoryScannerEnabled:               "worker.historyScannerEnabled",
-	ExecutionsScannerEnabled:            "worker.executionsScannerEnabled",
+	WorkerTimeLimitPerArchivalIteration:               "worker.TimeLimitPerArchivalIteration",
+	WorkerThrottledLogRPS:                             "worker.throttledLogRPS",
+	ScannerPersistenceMaxQPS:                          "worker.scannerPersistenceMaxQPS",
+	TaskQueueScannerEnabled:                           "worker.taskQueueScannerEnabled",
+	HistoryScannerEnabled:                             "worker.historyScannerEnabled",
+	ExecutionsScannerEnabled:                          "worker.executionsScannerEnabled",
+	ExecutionsScannerHistoryChecksumValidationEnabled: "worker.executionsScannerHistoryChecksumValidationEnabled",
 
 	EnableRingpopTLS: "system.enableRingpopTLS",
 }
@@ -411,6 +491,12 @@ const (
 	EnableNamespaceNotActiveAutoForwarding
 	// TransactionSizeLimit is the largest allowed transaction size to persistence
 	TransactionSizeLimit
+	// EnableHistoryEventBatchChecksum is the key for generating a checksum for every persisted history event batch
+	EnableHistoryEventBatchChecksum
+	// PersistenceNamespaceMetricsLimit is the max number of distinct namespaces per host for which execution
+	// persistence metrics are tagged with namespace ID, calls of other namespaces are attributed to a shared
+	// overflow value. 0 disables per namespace persistence metrics.
+	PersistenceNamespaceMetricsLimit
 	// DisallowQuery is the key to disallow query for a namespace
 	DisallowQuery
 	// EnablePriorityTaskProcessor is the key for enabling priority task processor
@@ -419,6 +505,24 @@ const (
 	EnableAuthorization
 	// EnableCrossNamespaceCommands is the key to enable commands for external namespaces
 	EnableCrossNamespaceCommands
+	// CrossNamespaceCommandTargets is the comma separated list of namespaces in which workflows of a namespace may
+	// start child workflows or signal and cancel workflows. Empty allows all namespaces
+	CrossNamespaceCommandTargets
+	// EnableGlobalCrossNamespaceCommands allows cross namespace commands between global namespaces replicated to
+	// more than one cluster, as long as both have the same clusters and the same active cluster
+	EnableGlobalCrossNamespaceCommands
+	// HistoryClientShardHandoffHedgeDelay is the delay before the history client hedges a request whose shard is
+	// moving between hosts to the presumed new owner, 0 disables hedging
+	HistoryClientShardHandoffHedgeDelay
+	// HistoryShardPins pins history shards to hosts regardless of the membership ring. It's a map from shard ID
+	// to either the address of a history host, or a map with the host Address and the time Until which the pin
+	// applies, in RFC3339 format. The shard moves once its current owner closes it, e.g. through admin CloseShard.
+	HistoryShardPins
+	// NamespaceShardPartitions constrains namespaces to a subset of history shards. It's a map from namespace ID
+	// to a list of shard IDs and shard ranges, e.g. [1, 2, "10-20"], or a single range string. Workflows are
+	// routed by their ID, so a partition is applied as it's added, but a changed or removed partition only takes
+	// effect on restart. Change it once tctl admin shard rebalance_plan --wait returns, and restart all services.
+	NamespaceShardPartitions
 	// BlobSizeLimitError is the per event blob size limit
 	BlobSizeLimitError
 	// BlobSizeLimitWarn is the per event blob size limit for warning
@@ -435,6 +539,27 @@ const (
 	HistoryCountLimitError
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	HistoryCountLimitWarn
+	// MutableStateSizeLimitError is the per workflow execution mutable state size limit, executions above it are
+	// terminated
+	MutableStateSizeLimitError
+	// MutableStateSizeLimitWarn is the per workflow execution mutable state size limit for warning
+	MutableStateSizeLimitWarn
+	// NumPendingActivitiesLimitError is the per workflow execution limit of pending activities, commands
+	// scheduling more are rejected. 0 disables the limit
+	NumPendingActivitiesLimitError
+	// NumPendingActivitiesLimitWarn is the per workflow execution limit of pending activities for warning
+	NumPendingActivitiesLimitWarn
+	// NumPendingChildExecutionsLimitError is the per workflow execution limit of pending child workflows, commands
+	// starting more are rejected. 0 disables the limit
+	NumPendingChildExecutionsLimitError
+	// NumPendingChildExecutionsLimitWarn is the per workflow execution limit of pending child workflows for warning
+	NumPendingChildExecutionsLimitWarn
+	// NumPendingSignalsLimitError is the per workflow execution limit of pending signals to external workflows,
+	// commands signaling more are rejected. 0 disables the limit
+	NumPendingSignalsLimitError
+	// NumPendingSignalsLimitWarn is the per workflow execution limit of pending signals to external workflows for
+	// warning
+	NumPendingSignalsLimitWarn
 
 	// MaxIDLengthLimit is the length limit for various IDs, including: Namespace, TaskQueue, WorkflowID, ActivityID, TimerID,
 	// WorkflowType, ActivityType, SignalName, MarkerName, ErrorReason/FailureReason/CancelCause, Identity, RequestID
@@ -468,6 +593,24 @@ const (
 	FrontendThrottledLogRPS
 	// FrontendShutdownDrainDuration is the duration of traffic drain during shutdown
 	FrontendShutdownDrainDuration
+	// FrontendMaintenanceMode puts the cluster in maintenance mode: frontends reject non-critical
+	// workflow service APIs with a retryable error, while task completions and heartbeats are still served
+	FrontendMaintenanceMode
+	// FrontendMaintenanceModeRetryAfter is the retry-after hint returned to clients during maintenance mode
+	FrontendMaintenanceModeRetryAfter
+	// FrontendMaxHistoryLongPollsPerCaller is the max number of concurrent GetWorkflowExecutionHistory long polls
+	// of a caller in a namespace on a frontend host, 0 means unlimited
+	FrontendMaxHistoryLongPollsPerCaller
+	// FrontendHistoryLongPollRetryAfter is the retry-after hint returned to clients whose history long polls are
+	// rejected for exceeding the per caller or per execution limit
+	FrontendHistoryLongPollRetryAfter
+	// FrontendRecordWorkerPrincipal appends the subject of the auth claims of a worker to the identity recorded in
+	// the events of the workflow and activity tasks it completes, fails or cancels
+	FrontendRecordWorkerPrincipal
+	// FrontendPerIdentityTaskMetrics emits the count of workflow and activity task completions tagged with
+	// the subject of the worker's auth claims, or with the worker identity if it has none. The identity tag may have
+	// high cardinality when workers aren't authenticated
+	FrontendPerIdentityTaskMetrics
 	// EnableClientVersionCheck enables client version check for frontend
 	EnableClientVersionCheck
 
@@ -560,8 +703,36 @@ const (
 	MatchingForwarderMaxRatePerSecond
 	// MatchingForwarderMaxChildrenPerNode is the max number of children per node in the task queue partition tree
 	MatchingForwarderMaxChildrenPerNode
+	// MatchingForwarderPollLocalWait is the time a poll waits for a local task before it is forwarded to the parent partition
+	MatchingForwarderPollLocalWait
+	// MatchingForwarderPollLatencyBudget is the part of poll timeout reserved for the response of a forwarded poll,
+	// polls are not forwarded when less than this is left
+	MatchingForwarderPollLatencyBudget
 	// MatchingShutdownDrainDuration is the duration of traffic drain during shutdown
 	MatchingShutdownDrainDuration
+	// MatchingQueueBacklogMetricsInterval is the interval at which per-host task queue backlog and processing rate gauges are emitted
+	MatchingQueueBacklogMetricsInterval
+	// MatchingEnableBacklogTrimming enables background trimming of stale tasks from the task queue backlog
+	MatchingEnableBacklogTrimming
+	// MatchingBacklogTrimInterval is the interval between backlog trimming passes
+	MatchingBacklogTrimInterval
+	// MatchingBacklogTrimMinTaskAge is the minimum age of a backlog task before it is considered for trimming
+	MatchingBacklogTrimMinTaskAge
+	// MatchingBacklogTrimBatchSize is the max number of backlog tasks read per trimming pass
+	MatchingBacklogTrimBatchSize
+	// MatchingBacklogTrimSampleSize is the max number of backlog tasks validated against history per trimming pass
+	MatchingBacklogTrimSampleSize
+	// MatchingStickyPollerUnavailableWindow is the time since the last poll of a sticky task queue after which
+	// queries dispatched to it fail right away instead of waiting for a poller, 0 disables the check
+	MatchingStickyPollerUnavailableWindow
+	// MatchingEnableNamespaceFairShare shares MatchingRPS among the namespaces with recent add and poll requests
+	// by weight, so a single namespace can't use up the RPS of a matching host
+	MatchingEnableNamespaceFairShare
+	// MatchingNamespaceFairShareWeight is the weight of the share of MatchingRPS of a namespace
+	MatchingNamespaceFairShareWeight
+	// MatchingFairShareStarvationThreshold is how long a namespace may go without any request allowed by its
+	// fair share before it's reported as starved, 0 disables starvation detection
+	MatchingFairShareStarvationThreshold
 
 	// key for history
 
@@ -573,12 +744,18 @@ const (
 	HistoryPersistenceGlobalMaxQPS
 	// HistoryLongPollExpirationInterval is the long poll expiration interval in the history service
 	HistoryLongPollExpirationInterval
+	// HistoryMaxLongPollsPerExecution is the max number of concurrent mutable state long polls on a workflow
+	// execution, 0 means unlimited
+	HistoryMaxLongPollsPerExecution
 	// HistoryCacheInitialSize is initial size of history cache
 	HistoryCacheInitialSize
 	// HistoryCacheMaxSize is max size of history cache
 	HistoryCacheMaxSize
 	// HistoryCacheTTL is TTL of history cache
 	HistoryCacheTTL
+	// HistoryCachePinnedNamespace marks namespaces (by namespace ID) whose history and events cache entries
+	// are evicted only after entries of other namespaces
+	HistoryCachePinnedNamespace
 	// HistoryShutdownDrainDuration is the duration of traffic drain during shutdown
 	HistoryShutdownDrainDuration
 	// EventsCacheInitialSize is initial size of events cache
@@ -591,6 +768,10 @@ const (
 	AcquireShardInterval
 	// AcquireShardConcurrency is number of goroutines that can be used to acquire shards in the shard controller.
 	AcquireShardConcurrency
+	// EnableShardOwnershipHints enables publishing of owned shards on shutdown, so other hosts can acquire the busiest shards first
+	EnableShardOwnershipHints
+	// HistoryQueueBacklogMetricsInterval is the interval at which per-host queue backlog and processing rate gauges are emitted
+	HistoryQueueBacklogMetricsInterval
 	// StandbyClusterDelay is the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay
 	// StandbyTaskMissingEventsResendDelay is the amount of time standby cluster's will wait (if events are missing)
@@ -599,6 +780,29 @@ const (
 	// StandbyTaskMissingEventsDiscardDelay is the amount of time standby cluster's will wait (if events are missing)
 	// before discarding the task
 	StandbyTaskMissingEventsDiscardDelay
+	// StandbyReadMaxStaleness is the max replication lag under which API reads (PollMutableState and
+	// DescribeWorkflowExecution) of a standby namespace are served from this cluster, otherwise they are rejected so
+	// that frontend can forward them to the active cluster. Internal reads are not gated.
+	// Zero means reads are always served from this cluster.
+	StandbyReadMaxStaleness
+	// EmitLimitWarnings attaches a warning to workflow task completion responses, in the temporal-limit-warnings
+	// gRPC trailer, for each limit the execution is past the warn limit of, so SDKs can surface them before the
+	// execution hits the error limit
+	EmitLimitWarnings
+	// ReapplyEventTypes is the comma separated list of event types, e.g. WorkflowExecutionSignaled, reapplied onto
+	// the current run of a workflow when it is reset or its branch loses a conflict.
+	// Only signal events can be reapplied.
+	ReapplyEventTypes
+	// VisibilityRecordRetention is how long the visibility record of a workflow execution, with its final state,
+	// is kept after the execution is deleted by retention. Zero deletes it along with the execution.
+	// The retained deletions are persisted as timer tasks that older servers can't read, which stalls their timer
+	// queue. Before rolling back to a version without this setting, set it to zero and wait until the deletions
+	// scheduled so far have fired, i.e. for the largest retention configured.
+	VisibilityRecordRetention
+	// ActivityHeartbeatCoalesceInterval is how often heartbeats of an activity with unchanged details are written.
+	// Heartbeats in between are only held in the cached mutable state and are lost if it's evicted before the next
+	// write. The interval is capped at half the heartbeat timeout. Zero writes every heartbeat.
+	ActivityHeartbeatCoalesceInterval
 	// TaskProcessRPS is the task processing rate per second for each namespace
 	TaskProcessRPS
 	// TaskSchedulerType is the task scheduler type for priority task processor
@@ -609,9 +813,12 @@ const (
 	TaskSchedulerQueueSize
 	// TaskSchedulerRoundRobinWeights is the priority weight for weighted round robin task scheduler
 	TaskSchedulerRoundRobinWeights
+	// TaskProcessorThrottleErrorRate is the rate of task attempts failing with persistence errors above which the
+	// timer, transfer, visibility and tiered storage processors of a shard lower their worker count, 0 disables it
+	TaskProcessorThrottleErrorRate
 	// TimerTaskBatchSize is batch size for timer processor to process tasks
 	TimerTaskBatchSize
-	// TimerTaskWorkerCount is number of task workers for timer processor
+	// TimerTaskWorkerCount is number of task workers for timer processor, it can be overridden per shard
 	TimerTaskWorkerCount
 	// TimerTaskMaxRetryCount is max retry count for timer processor
 	TimerTaskMaxRetryCount
@@ -655,7 +862,7 @@ const (
 	TransferProcessorFailoverMaxPollRPS
 	// TransferProcessorMaxPollRPS is max poll rate per second for transferQueueProcessor
 	TransferProcessorMaxPollRPS
-	// TransferTaskWorkerCount is number of worker for transferQueueProcessor
+	// TransferTaskWorkerCount is number of worker for transferQueueProcessor, it can be overridden per shard
 	TransferTaskWorkerCount
 	// TransferTaskMaxRetryCount is max times of retry for transferQueueProcessor
 	TransferTaskMaxRetryCount
@@ -690,7 +897,7 @@ const (
 	VisibilityProcessorFailoverMaxPollRPS
 	// VisibilityProcessorMaxPollRPS is max poll rate per second for visibilityQueueProcessor
 	VisibilityProcessorMaxPollRPS
-	// VisibilityTaskWorkerCount is number of worker for visibilityQueueProcessor
+	// VisibilityTaskWorkerCount is number of worker for visibilityQueueProcessor, it can be overridden per shard
 	VisibilityTaskWorkerCount
 	// VisibilityTaskMaxRetryCount is max times of retry for visibilityQueueProcessor
 	VisibilityTaskMaxRetryCount
@@ -718,6 +925,12 @@ const (
 	VisibilityProcessorEnablePriorityTaskProcessor
 	// VisibilityProcessorVisibilityArchivalTimeLimit is the upper time limit for archiving visibility records
 	VisibilityProcessorVisibilityArchivalTimeLimit
+	// VisibilityProcessorMemoSizeLimit is the max size of the memo written to a visibility record, the largest memo
+	// fields are dropped from the record until it fits, 0 means unlimited
+	VisibilityProcessorMemoSizeLimit
+	// VisibilityActivityProgressInterval is how often heartbeats reporting activity progress update the visibility
+	// record of the workflow, which lists the progress of its pending activities in its memo. 0 disables it
+	VisibilityActivityProgressInterval
 
 	// TieredStorageTaskBatchSize is batch size for TieredStorageQueueProcessor
 	TieredStorageTaskBatchSize
@@ -725,7 +938,7 @@ const (
 	TieredStorageProcessorFailoverMaxPollRPS
 	// TieredStorageProcessorMaxPollRPS is max poll rate per second for TieredStorageQueueProcessor
 	TieredStorageProcessorMaxPollRPS
-	// TieredStorageTaskWorkerCount is number of worker for TieredStorageQueueProcessor
+	// TieredStorageTaskWorkerCount is number of worker for TieredStorageQueueProcessor, it can be overridden per shard
 	TieredStorageTaskWorkerCount
 	// TieredStorageTaskMaxRetryCount is max times of retry for TieredStorageQueueProcessor
 	TieredStorageTaskMaxRetryCount
@@ -788,6 +1001,81 @@ const (
 	ShardUpdateMinInterval
 	// ShardSyncMinInterval is the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval
+	// ShardDrainTimeout is the max time an unloading shard waits for queue processors to finish outstanding tasks
+	ShardDrainTimeout
+	// ShardPersistenceMaxQPS is the max qps of workflow writes a shard issues to persistence, 0 means unlimited
+	ShardPersistenceMaxQPS
+	// ShardPersistenceNamespaceMaxQPS is the max qps of workflow writes a shard issues to persistence for a namespace,
+	// 0 means unlimited
+	ShardPersistenceNamespaceMaxQPS
+	// ShardPersistenceRetryPolicy is the retry policy of persistence operations of shards and workflow transactions,
+	// a map which may set InitialInterval, MaximumInterval, ExpirationInterval, MaximumAttempts and JitterCoefficient
+	ShardPersistenceRetryPolicy
+	// ShardAcquisitionRetryPolicy is the retry policy of acquiring a shard, a map which may set InitialInterval,
+	// MaximumInterval, ExpirationInterval, MaximumAttempts and JitterCoefficient
+	ShardAcquisitionRetryPolicy
+	// ShardAcquisitionMaxConcurrency is the max number of shards a history host acquires at the same time,
+	// 0 means unlimited
+	ShardAcquisitionMaxConcurrency
+	// ShardAcquisitionPacing is the min time between the starts of two shard acquisitions on a history host,
+	// 0 disables pacing
+	ShardAcquisitionPacing
+	// ShardWarmUpMaxExecutions is the max number of executions with pending tasks a newly acquired shard loads into
+	// the mutable state and events caches before serving requests, 0 disables the warm-up
+	ShardWarmUpMaxExecutions
+	// ShardWarmUpTimeout is the max time a newly acquired shard spends warming up its caches
+	ShardWarmUpTimeout
+	// RangePreallocationThreshold is the fraction of the task IDs of a shard range allocated before the next range
+	// is acquired in the background, values outside of (0, 1) disable it
+	RangePreallocationThreshold
+	// RangeRenewalAlertRate is the number of range renewals of a shard per minute above which the shard logs a
+	// warning and emits shard_range_renewal_rate_exceeded, suggesting that RangeSizeBits is too small for the
+	// load of the shard. 0 disables it
+	RangeRenewalAlertRate
+	// ShardIdleUnloadTimeout is the time without API requests or task writes after which a shard with no pending
+	// tasks is unloaded until it gets traffic again or its next timer is due, 0 disables unloading idle shards.
+	// Shards are never unloaded while remote clusters replicate to them.
+	ShardIdleUnloadTimeout
+	// ShardReadOnly rejects the writes of a shard while reads keep working: workflow writes, ack level updates,
+	// shard info flushes, range renewals and task range completion. Only acquiring the shard still renews its
+	// range. Set it without a shard filter to freeze writes of the whole cluster
+	ShardReadOnly
+	// ShardClockSkewLimit is how far the time reported by a remote cluster may be ahead of the local clock before
+	// the shard reports the clock skew, 0 disables skew detection
+	ShardClockSkewLimit
+	// ShardClockSkewBlockTimerAllocation rejects timer task writes for a remote cluster whose clock skew exceeds
+	// ShardClockSkewLimit
+	ShardClockSkewBlockTimerAllocation
+	// ShardLockSlowThreshold is the shard lock wait or hold time above which the lock operation is logged, 0 disables it
+	ShardLockSlowThreshold
+	// ShardOwnershipHeartbeatInterval is the max time an acquired shard goes without persisting shard info, so an
+	// idle shard still detects that it was stolen. 0 disables the heartbeat
+	ShardOwnershipHeartbeatInterval
+	// ShardOwnershipAssertionRate is the fraction of workflow writes before which the shard reads its persisted
+	// range ID to verify that no other host acquired it. The first write after a transient persistence error is
+	// always verified if it's above 0. 0 disables the assertion
+	ShardOwnershipAssertionRate
+	// ShardPruneRemovedClusters deletes the ack levels of clusters which are not in the cluster metadata from
+	// shard info when a shard is loaded
+	ShardPruneRemovedClusters
+	// ShardLogDedupInterval is the interval within which identical warn and error messages of the shard throttled
+	// logger are logged once and summarized with the count of suppressed messages, 0 disables the deduplication
+	ShardLogDedupInterval
+	// ShardReadValidateRangeID rejects a workflow read with ErrShardStatusUnknown if the range ID of the shard
+	// changed while the read was in flight, instead of returning mutable state which may be stale
+	ShardReadValidateRangeID
+	// ShardUpdateDirtyUpdatesThreshold is the number of shard info updates since the last flush at which shard
+	// info is flushed sooner than ShardUpdateMinInterval, 0 disables it
+	ShardUpdateDirtyUpdatesThreshold
+	// ShardUpdateProgressAtRiskThreshold is the number of acked task IDs not yet persisted at which shard info is
+	// flushed sooner than ShardUpdateMinInterval, 0 disables it
+	ShardUpdateProgressAtRiskThreshold
+	// ShardUpdateFastInterval is the shortest interval between shard info flushes of a shard above the dirty
+	// updates or progress at risk thresholds
+	ShardUpdateFastInterval
+	// ShardUpdateIdleInterval is the interval between shard info flushes of a shard far below the dirty updates
+	// and progress at risk thresholds. It only applies if it's longer than ShardUpdateMinInterval
+	ShardUpdateIdleInterval
 	// ShardSyncTimerJitterCoefficient is the sync shard jitter coefficient
 	ShardSyncTimerJitterCoefficient
 	// DefaultEventEncoding is the encoding type for history events
@@ -802,9 +1090,15 @@ const (
 	// DefaultWorkflowRetryPolicy represents the out-of-box retry policy for unset fields
 	// where the user has set an explicit RetryPolicy, but not specified all the fields
 	DefaultWorkflowRetryPolicy
+	// CapActivityTimeoutsByRunDeadline caps the timeouts of activities at the time left until the workflow run times
+	// out when they are scheduled, instead of at the whole run timeout
+	CapActivityTimeoutsByRunDeadline
 
 	// HistoryMaxAutoResetPoints is the key for max number of auto reset points stored in mutableState
 	HistoryMaxAutoResetPoints
+	// HistoryMaxCompactedResetPoints is the key for max number of expired or excess auto reset points
+	// dropped from mutableState per workflow task completion, 0 disables the compaction
+	HistoryMaxCompactedResetPoints
 
 	// EnableParentClosePolicy whether to  ParentClosePolicy
 	EnableParentClosePolicy
@@ -879,6 +1173,8 @@ const (
 	HistoryScannerEnabled
 	// ExecutionsScannerEnabled indicates if executions scanner should be started as part of worker.Scanner
 	ExecutionsScannerEnabled
+	// ExecutionsScannerHistoryChecksumValidationEnabled indicates if executions scanner should verify history event batch checksums
+	ExecutionsScannerHistoryChecksumValidationEnabled
 	// WorkerBatcherMaxConcurrentActivityExecutionSize indicates worker batcher max concurrent activity execution size
 	WorkerBatcherMaxConcurrentActivityExecutionSize
 	// WorkerBatcherMaxConcurrentWorkflowTaskExecutionSize indicates worker batcher max concurrent workflow execution size
@@ -887,6 +1183,12 @@ const (
 	WorkerBatcherMaxConcurrentActivityTaskPollers
 	// WorkerBatcherMaxConcurrentWorkflowTaskPollers indicates worker batcher max concurrent workflow pollers
 	WorkerBatcherMaxConcurrentWorkflowTaskPollers
+	// WorkerBatcherBlastRadiusGuardFraction is the fraction of open workflows of a namespace a terminate or cancel
+	// batch can affect without explicit confirmation, 0 (default) disables the guard
+	WorkerBatcherBlastRadiusGuardFraction
+	// WorkerBatcherNamespaceRPS is the rate of operations shared by all batches of a namespace processed on one worker
+	// host, on top of the RPS of each batch. 0 disables the limit
+	WorkerBatcherNamespaceRPS
 	// EnableBatcher decides whether start batcher in our worker
 	EnableBatcher
 	// WorkerParentCloseMaxConcurrentActivityExecutionSize indicates worker parent close worker max concurrent activity execution size
@@ -934,8 +1236,19 @@ const (
 	ReplicationTaskProcessorHostQPS
 	// ReplicationTaskProcessorShardQPS is the qps of task processing rate limiter on shard level
 	ReplicationTaskProcessorShardQPS
+	// ReplicationTaskReorderBufferWindow is how long an out of order history replication task is buffered
+	// waiting for the missing events before falling back to resend, 0 disables the buffer
+	ReplicationTaskReorderBufferWindow
+	// ReplicationTaskReorderBufferMaxTasksPerExecution is the max number of buffered out of order replication tasks per execution
+	ReplicationTaskReorderBufferMaxTasksPerExecution
+	// ReplicationTaskReorderBufferMaxTasks is the max number of buffered out of order replication tasks per shard and source cluster
+	ReplicationTaskReorderBufferMaxTasks
 	// MaxBufferedQueryCount indicates max buffer query count
 	MaxBufferedQueryCount
+	// WorkflowTaskQuarantineThreshold is the number of consecutive workflow task failures and timeouts after which
+	// an execution whose workflow task crashed the worker, e.g. with a non-deterministic error, or timed out is
+	// quarantined, 0 disables quarantining
+	WorkflowTaskQuarantineThreshold
 	// MutableStateChecksumGenProbability is the probability [0-100] that checksum will be generated for mutable state
 	MutableStateChecksumGenProbability
 	// MutableStateChecksumVerifyProbability is the probability [0-100] that checksum will be verified for mutable state
@@ -959,7 +1272,7 @@ const (
 type Filter int
 
 func (f Filter) String() string {
-	if f <= unknownFilter || f > TaskType {
+	if f <= unknownFilter || f >= lastFilterTypeForTest {
 		return filters[unknownFilter]
 	}
 	return filters[f]
@@ -972,6 +1285,7 @@ var filters = []string{
 	"taskQueueName",
 	"taskType",
 	"shardID",
+	"workflowType",
 }
 
 const (
@@ -986,6 +1300,8 @@ const (
 	TaskType
 	// ShardID is the shard id
 	ShardID
+	// WorkflowType is the workflow type name
+	WorkflowType
 
 	// lastFilterTypeForTest must be the last one in this const group for testing purpose
 	lastFilterTypeForTest
@@ -1030,3 +1346,10 @@ func ShardIDFilter(shardID int32) FilterOption {
 		filterMap[ShardID] = shardID
 	}
 }
+
+// WorkflowTypeFilter filters by workflow type name
+func WorkflowTypeFilter(name string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[WorkflowType] = name
+	}
+}
@@ -77,6 +77,13 @@ func ForTaskType(t int) MutationConstraint {
 	}
 }
 
+// ForWorkflowType builds an exact-match workflow type MutationConstraint.
+func ForWorkflowType(t string) MutationConstraint {
+	return func(m map[string]interface{}) {
+		m[WorkflowType.String()] = t
+	}
+}
+
 // Set assigns the supplied value along with optional constraints to the
 // indicated key. Any other additional value (possibly with other constraints)
 // is overwritten.
@@ -30,6 +30,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	dconf "go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
 )
 
 func TestMutations(t *testing.T) {
@@ -103,6 +104,18 @@ func TestMulti(t *testing.T) {
 	require.Equal(t, i, 1)
 }
 
+func TestWorkflowTypeConstrainedMutation(t *testing.T) {
+	c := dconf.NewMutableEphemeralClient()
+	c.Add(dconf.HistorySizeLimitError, 1024, dconf.ForNamespace("nsfoo"))
+	c.Add(dconf.HistorySizeLimitError, 2048, dconf.ForNamespace("nsfoo"), dconf.ForWorkflowType("wtfoo"))
+
+	limit := dconf.NewCollection(c, log.NewNoopLogger()).
+		GetIntPropertyFilteredByWorkflowType(dconf.HistorySizeLimitError, 100)
+	require.Equal(t, 2048, limit("nsfoo", "wtfoo"))
+	require.Equal(t, 1024, limit("nsfoo", "wtbar"))
+	require.Equal(t, 100, limit("nsbar", "wtfoo"))
+}
+
 func composeFilters(fs ...dconf.FilterOption) map[dconf.Filter]interface{} {
 	out := map[dconf.Filter]interface{}{}
 	for _, f := range fs {
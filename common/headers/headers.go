@@ -26,6 +26,10 @@ package headers
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/metadata"
 )
@@ -36,6 +40,26 @@ const (
 	SupportedServerVersionsHeaderName = "supported-server-versions"
 	SupportedFeaturesHeaderName       = "supported-features"
 	SupportedFeaturesHeaderDelim      = ","
+
+	// ReadStalenessHeaderName is the response header carrying staleness in milliseconds
+	// of a workflow read served from a standby namespace replica
+	ReadStalenessHeaderName = "read-staleness-ms"
+
+	// RequestStartTimeHeaderName is the internal request header carrying the time in unix nanoseconds
+	// the frontend received the request, it's used to break down latency by service hop
+	RequestStartTimeHeaderName = "request-start-time"
+
+	// OperatorIdentityHeaderName is the internal request header carrying the identity of the caller which
+	// terminated, canceled or reset a workflow execution, for history to record
+	OperatorIdentityHeaderName = "operator-identity"
+
+	// ResetReapplyExcludeEventIDsHeaderName is the request header carrying the comma separated IDs of the events
+	// in the run being reset which aren't reapplied to the new run, e.g. a signal which caused the failure
+	ResetReapplyExcludeEventIDsHeaderName = "reset-reapply-exclude-event-ids"
+
+	// LimitWarningsTrailerName is the response trailer carrying a warning for each limit a workflow execution is
+	// approaching, e.g. history size or the number of pending activities
+	LimitWarningsTrailerName = "temporal-limit-warnings"
 )
 
 var (
@@ -45,6 +69,7 @@ var (
 		ClientVersionHeaderName,
 		SupportedServerVersionsHeaderName,
 		SupportedFeaturesHeaderName,
+		ResetReapplyExcludeEventIDsHeaderName,
 	}
 
 	internalVersionHeaders = metadata.New(map[string]string{
@@ -77,8 +102,8 @@ func GetValues(ctx context.Context, headerNames ...string) []string {
 	return headerValues
 }
 
-// Propagate propagates version headers from incoming context to outgoing context.
-// It copies all version headers to outgoing context only if they are exist in incoming context
+// Propagate propagates version headers and request options such as the reset reapply exclusions from incoming
+// context to outgoing context. It copies all these headers to outgoing context only if they are exist in incoming context
 // and doesn't exist in outgoing context already.
 func Propagate(ctx context.Context) context.Context {
 	if mdIncoming, ok := metadata.FromIncomingContext(ctx); ok {
@@ -126,6 +151,71 @@ func SetVersionsForTests(ctx context.Context, clientVersion, clientName, support
 	}))
 }
 
+// SetRequestStartTime sets the time the frontend received a request on the outgoing context.
+func SetRequestStartTime(ctx context.Context, startTime time.Time) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestStartTimeHeaderName, strconv.FormatInt(startTime.UnixNano(), 10))
+}
+
+// GetRequestStartTime returns the time the frontend received the incoming request, if it's set.
+func GetRequestStartTime(ctx context.Context) (time.Time, bool) {
+	value := GetValues(ctx, RequestStartTimeHeaderName)[0]
+	if value == "" {
+		return time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos).UTC(), true
+}
+
+// SetOperatorIdentity sets the identity of the operator of a workflow execution operation on the outgoing context.
+func SetOperatorIdentity(ctx context.Context, identity string) context.Context {
+	if identity == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, OperatorIdentityHeaderName, identity)
+}
+
+// GetOperatorIdentity returns the identity of the operator of the incoming request, or defaultIdentity if it's not set.
+func GetOperatorIdentity(ctx context.Context, defaultIdentity string) string {
+	if identity := GetValues(ctx, OperatorIdentityHeaderName)[0]; identity != "" {
+		return identity
+	}
+	return defaultIdentity
+}
+
+// SetResetReapplyExcludeEventIDs sets the IDs of the events not to reapply when resetting a workflow execution on
+// the outgoing context.
+func SetResetReapplyExcludeEventIDs(ctx context.Context, eventIDs []int64) context.Context {
+	if len(eventIDs) == 0 {
+		return ctx
+	}
+	values := make([]string, len(eventIDs))
+	for i, eventID := range eventIDs {
+		values[i] = strconv.FormatInt(eventID, 10)
+	}
+	return metadata.AppendToOutgoingContext(ctx, ResetReapplyExcludeEventIDsHeaderName, strings.Join(values, ","))
+}
+
+// GetResetReapplyExcludeEventIDs returns the IDs of the events not to reapply when resetting a workflow execution
+// set on the incoming request, or an error if the header isn't a comma separated list of event IDs.
+func GetResetReapplyExcludeEventIDs(ctx context.Context) (map[int64]struct{}, error) {
+	value := GetValues(ctx, ResetReapplyExcludeEventIDsHeaderName)[0]
+	if value == "" {
+		return nil, nil
+	}
+	eventIDs := make(map[int64]struct{})
+	for _, eventID := range strings.Split(value, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(eventID), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event ID %q in %v header", eventID, ResetReapplyExcludeEventIDsHeaderName)
+		}
+		eventIDs[id] = struct{}{}
+	}
+	return eventIDs, nil
+}
+
 func getSingleHeaderValue(md metadata.MD, headerName string) string {
 	values := md.Get(headerName)
 	if len(values) == 0 {
@@ -27,6 +27,7 @@ package headers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -151,3 +152,52 @@ func (s *HeadersSuite) TestPropagate_EmptyIncomingContext() {
 	s.Equal("<21.04.16", md.Get(SupportedServerVersionsHeaderName)[0])
 	s.Equal("28.08.14", md.Get(ClientNameHeaderName)[0])
 }
+
+func (s *HeadersSuite) TestRequestStartTime() {
+	_, ok := GetRequestStartTime(context.Background())
+	s.False(ok)
+
+	startTime := time.Now().UTC()
+	ctx := SetRequestStartTime(context.Background(), startTime)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	s.True(ok)
+
+	// as received by the downstream service
+	actual, ok := GetRequestStartTime(metadata.NewIncomingContext(context.Background(), md))
+	s.True(ok)
+	s.True(startTime.Equal(actual))
+
+	_, ok = GetRequestStartTime(metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestStartTimeHeaderName, "invalid")))
+	s.False(ok)
+}
+
+func (s *HeadersSuite) TestOperatorIdentity() {
+	s.Equal("default", GetOperatorIdentity(context.Background(), "default"))
+
+	ctx := SetOperatorIdentity(context.Background(), "operator@example.com")
+	md, ok := metadata.FromOutgoingContext(ctx)
+	s.True(ok)
+
+	// as received by the downstream service
+	s.Equal("operator@example.com", GetOperatorIdentity(metadata.NewIncomingContext(context.Background(), md), "default"))
+
+	s.Equal(context.Background(), SetOperatorIdentity(context.Background(), ""))
+}
+
+func (s *HeadersSuite) TestResetReapplyExcludeEventIDs() {
+	eventIDs, err := GetResetReapplyExcludeEventIDs(context.Background())
+	s.NoError(err)
+	s.Empty(eventIDs)
+
+	ctx := SetResetReapplyExcludeEventIDs(context.Background(), []int64{5, 12})
+	md, ok := metadata.FromOutgoingContext(ctx)
+	s.True(ok)
+
+	// as received by the downstream service
+	eventIDs, err = GetResetReapplyExcludeEventIDs(metadata.NewIncomingContext(context.Background(), md))
+	s.NoError(err)
+	s.Equal(map[int64]struct{}{5: {}, 12: {}}, eventIDs)
+
+	_, err = GetResetReapplyExcludeEventIDs(metadata.NewIncomingContext(context.Background(), metadata.Pairs(ResetReapplyExcludeEventIDsHeaderName, "5,x")))
+	s.Error(err)
+}
@@ -0,0 +1,80 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package log
+
+import (
+	"context"
+
+	"go.temporal.io/server/common/log/tag"
+)
+
+type contextTagsKey struct{}
+
+// ContextWithTags returns a copy of ctx carrying tags in addition to any tags already attached to ctx.
+// A tag replaces a tag with the same key already attached to ctx, e.g. once the current run of a
+// workflow is resolved its run ID replaces the (empty) run ID of the request.
+// Loggers obtained through WithContext prepend these tags to every log entry.
+func ContextWithTags(ctx context.Context, tags ...tag.Tag) context.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	existing := TagsFromContext(ctx)
+	merged := make([]tag.Tag, 0, len(existing)+len(tags))
+	for _, t := range existing {
+		if !containsKey(tags, t.Key()) {
+			merged = append(merged, t)
+		}
+	}
+	merged = append(merged, tags...)
+	return context.WithValue(ctx, contextTagsKey{}, merged)
+}
+
+// TagsFromContext returns tags attached to ctx with ContextWithTags.
+func TagsFromContext(ctx context.Context) []tag.Tag {
+	if ctx == nil {
+		return nil
+	}
+	tags, _ := ctx.Value(contextTagsKey{}).([]tag.Tag)
+	return tags
+}
+
+// WithContext returns Logger instance that prepend every log entry with tags attached to ctx.
+// If ctx carries no tags logger is returned as is.
+func WithContext(ctx context.Context, logger Logger) Logger {
+	tags := TagsFromContext(ctx)
+	if len(tags) == 0 {
+		return logger
+	}
+	return With(logger, tags...)
+}
+
+func containsKey(tags []tag.Tag, key string) bool {
+	for _, t := range tags {
+		if t.Key() == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/log/tag"
+)
+
+func TestContextWithTags_Appends(t *testing.T) {
+	ctx := ContextWithTags(context.Background(), tag.WorkflowID("wid"))
+	child := ContextWithTags(ctx, tag.WorkflowRunID("rid"))
+
+	require.Equal(t, []tag.Tag{tag.WorkflowID("wid")}, TagsFromContext(ctx))
+	require.Equal(t, []tag.Tag{tag.WorkflowID("wid"), tag.WorkflowRunID("rid")}, TagsFromContext(child))
+}
+
+func TestContextWithTags_Replaces(t *testing.T) {
+	ctx := ContextWithTags(context.Background(), tag.WorkflowID("wid"), tag.WorkflowRunID("rid"))
+	child := ContextWithTags(ctx, tag.WorkflowRunID("current-rid"))
+
+	require.Equal(t, []tag.Tag{tag.WorkflowID("wid"), tag.WorkflowRunID("current-rid")}, TagsFromContext(child))
+}
+
+func TestWithContext_NoTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := NewMockLogger(ctrl)
+	require.Equal(t, Logger(logger), WithContext(context.Background(), logger))
+}
+
+func TestWithContext_PrependsTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := NewMockLogger(ctrl)
+	ctx := ContextWithTags(context.Background(), tag.WorkflowID("wid"), tag.ShardID(1))
+	logger.EXPECT().Info("msg", tag.WorkflowID("wid"), tag.ShardID(1), tag.WorkflowNamespaceID("nid"))
+
+	WithContext(ctx, logger).Info("msg", tag.WorkflowNamespaceID("nid"))
+}
@@ -0,0 +1,163 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.temporal.io/server/common/log/tag"
+)
+
+const extraSkipForDedupLogger = 3
+
+type (
+	dedupLogger struct {
+		logger   Logger
+		interval func() time.Duration
+		state    *dedupState
+		// tags set with With, which are part of the deduplication key
+		tags string
+	}
+
+	// dedupState is shared by a dedup logger and all loggers derived from it with With
+	dedupState struct {
+		sync.Mutex
+		// suppressed counts the messages suppressed in the current interval, keyed by level, message and tags.
+		// A key is present for as long as its interval is open.
+		suppressed map[dedupKey]int
+	}
+
+	dedupKey struct {
+		level string
+		msg   string
+		tags  string
+	}
+)
+
+var _ Logger = (*dedupLogger)(nil)
+
+// NewDedupLogger returns a logger which emits a warn or error message at most once per interval. Identical
+// messages logged within the interval with the same tags, including errors, are counted and summarized in one
+// "Suppressed repeated log messages" entry with these tags at the end of the interval. Debug and info messages
+// are not deduplicated. An interval of 0 or less disables deduplication.
+func NewDedupLogger(logger Logger, interval func() time.Duration) *dedupLogger {
+	if sl, ok := logger.(SkipLogger); ok {
+		logger = sl.Skip(extraSkipForDedupLogger)
+	}
+	return &dedupLogger{
+		logger:   logger,
+		interval: interval,
+		state: &dedupState{
+			suppressed: make(map[dedupKey]int),
+		},
+	}
+}
+
+func (dl *dedupLogger) Debug(msg string, tags ...tag.Tag) {
+	dl.logger.Debug(msg, tags...)
+}
+
+func (dl *dedupLogger) Info(msg string, tags ...tag.Tag) {
+	dl.logger.Info(msg, tags...)
+}
+
+func (dl *dedupLogger) Warn(msg string, tags ...tag.Tag) {
+	dl.dedup(dl.key("warn", msg, tags), tags, func() {
+		dl.logger.Warn(msg, tags...)
+	}, dl.logger.Warn)
+}
+
+func (dl *dedupLogger) Error(msg string, tags ...tag.Tag) {
+	dl.dedup(dl.key("error", msg, tags), tags, func() {
+		dl.logger.Error(msg, tags...)
+	}, dl.logger.Error)
+}
+
+func (dl *dedupLogger) Fatal(msg string, tags ...tag.Tag) {
+	dl.logger.Fatal(msg, tags...)
+}
+
+// With returns a logger with the specified key-value pairs set, which shares the deduplication state with
+// this logger
+func (dl *dedupLogger) With(tags ...tag.Tag) Logger {
+	return &dedupLogger{
+		logger:   With(dl.logger, tags...),
+		interval: dl.interval,
+		state:    dl.state,
+		tags:     dl.tags + formatTags(tags),
+	}
+}
+
+func (dl *dedupLogger) key(level string, msg string, tags []tag.Tag) dedupKey {
+	return dedupKey{
+		level: level,
+		msg:   msg,
+		tags:  dl.tags + formatTags(tags),
+	}
+}
+
+func formatTags(tags []tag.Tag) string {
+	var sb strings.Builder
+	for _, t := range tags {
+		fmt.Fprintf(&sb, "%s=%v;", t.Key(), t.Value())
+	}
+	return sb.String()
+}
+
+func (dl *dedupLogger) dedup(key dedupKey, tags []tag.Tag, emit func(), summarize func(msg string, tags ...tag.Tag)) {
+	interval := dl.interval()
+	if interval <= 0 {
+		emit()
+		return
+	}
+
+	dl.state.Lock()
+	if count, ok := dl.state.suppressed[key]; ok {
+		dl.state.suppressed[key] = count + 1
+		dl.state.Unlock()
+		return
+	}
+	dl.state.suppressed[key] = 0
+	dl.state.Unlock()
+
+	time.AfterFunc(interval, func() {
+		dl.state.Lock()
+		count := dl.state.suppressed[key]
+		delete(dl.state.suppressed, key)
+		dl.state.Unlock()
+
+		if count > 0 {
+			summarize("Suppressed repeated log messages", append([]tag.Tag{
+				tag.SuppressedMessage(key.msg),
+				tag.SuppressedCount(count),
+				tag.Interval(interval),
+			}, tags...)...)
+		}
+	})
+	emit()
+}
@@ -0,0 +1,100 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package log
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"go.temporal.io/server/common/log/tag"
+)
+
+func TestDedupLogger_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := NewMockLogger(ctrl)
+	logger.EXPECT().Error("msg").Times(3)
+
+	dl := NewDedupLogger(logger, func() time.Duration { return 0 })
+	for i := 0; i < 3; i++ {
+		dl.Error("msg")
+	}
+}
+
+func TestDedupLogger_SummarizesSuppressed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interval := 20 * time.Millisecond
+	err := errors.New("store unavailable")
+	logger := NewMockLogger(ctrl)
+	logger.EXPECT().Error("msg", tag.Error(err))
+	logger.EXPECT().Warn("other msg")
+	logger.EXPECT().Info("info msg").Times(2)
+	summaryCh := make(chan struct{})
+	logger.EXPECT().Error("Suppressed repeated log messages",
+		tag.SuppressedMessage("msg"),
+		tag.SuppressedCount(2),
+		tag.Interval(interval),
+		tag.Error(err),
+	).Do(func(msg string, tags ...tag.Tag) { close(summaryCh) })
+
+	dl := NewDedupLogger(logger, func() time.Duration { return interval })
+	dl.Error("msg", tag.Error(err))
+	dl.Error("msg", tag.Error(err))
+	dl.Error("msg", tag.Error(err))
+	dl.Warn("other msg")
+	dl.Info("info msg")
+	dl.Info("info msg")
+
+	select {
+	case <-summaryCh:
+	case <-time.After(time.Second):
+		t.Fatal("suppressed messages were not summarized")
+	}
+}
+
+func TestDedupLogger_KeyIncludesTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := NewMockLogger(ctrl)
+	logger.EXPECT().Error("msg")
+	logger.EXPECT().Error("msg", tag.Error(errors.New("store unavailable")))
+	logger.EXPECT().Error("msg", tag.Error(errors.New("condition failed")))
+	logger.EXPECT().Error("msg", tag.ShardID(1))
+	logger.EXPECT().Error("msg", tag.ShardID(2))
+
+	dl := NewDedupLogger(logger, func() time.Duration { return time.Minute })
+	dl.Error("msg")
+	dl.Error("msg", tag.Error(errors.New("store unavailable")))
+	dl.Error("msg", tag.Error(errors.New("condition failed")))
+	dl.With(tag.ShardID(1)).Error("msg")
+	dl.With(tag.ShardID(2)).Error("msg")
+}
@@ -315,6 +315,16 @@ func WorkflowEventCount(eventCount int) ZapTag {
 	return NewInt("wf-event-count", eventCount)
 }
 
+// WorkflowMutableStateSize returns tag for MutableStateSize
+func WorkflowMutableStateSize(mutableStateSize int) ZapTag {
+	return NewInt("wf-mutable-state-size", mutableStateSize)
+}
+
+// WorkflowPendingCount returns tag for the number of pending activities, child workflows or signals
+func WorkflowPendingCount(pendingCount int) ZapTag {
+	return NewInt("wf-pending-count", pendingCount)
+}
+
 ///////////////////  System tags defined here:  ///////////////////
 // Tags with pre-define values
 
@@ -873,3 +883,18 @@ func TLSCertFiles(filePaths []string) ZapTag {
 func Timeout(timeoutValue string) ZapTag {
 	return NewStringTag("timeout", timeoutValue)
 }
+
+// SuppressedMessage returns tag for the message of suppressed repeated log entries
+func SuppressedMessage(msg string) ZapTag {
+	return NewStringTag("suppressed-message", msg)
+}
+
+// SuppressedCount returns tag for the number of suppressed repeated log entries
+func SuppressedCount(count int) ZapTag {
+	return NewInt("suppressed-count", count)
+}
+
+// Interval returns tag for Interval
+func Interval(interval time.Duration) ZapTag {
+	return NewDurationTag("interval", interval)
+}
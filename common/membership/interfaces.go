@@ -81,6 +81,15 @@ type (
 		// GetMemberCount returns the number of reachable members
 		// currently in this node's membership list for the given role
 		GetMemberCount(role string) (int, error)
+		// ReportUnreachable reports that the given host of the given service could not be reached.
+		// If enabled, the host gets health checked on an accelerated schedule. When the check fails, the
+		// host is reported to its peers, which check it on their own. Once a peer confirmed it, every
+		// member removes the host from the service ring without waiting for the gossip failure detection,
+		// and listeners of the ring, e.g. the history shard controller, get notified.
+		ReportUnreachable(service string, address string) error
+		// SetLabel sets a label on this member. Labels are gossiped to the other members of the ring,
+		// which get them back in the HostInfo of ChangedEvent, including when this member leaves the ring.
+		SetLabel(key string, value string) error
 	}
 
 	// ServiceResolver provides membership information for a specific temporal service.
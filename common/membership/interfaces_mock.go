@@ -159,6 +159,34 @@ func (mr *MockMonitorMockRecorder) RemoveListener(service, name interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveListener", reflect.TypeOf((*MockMonitor)(nil).RemoveListener), service, name)
 }
 
+// ReportUnreachable mocks base method.
+func (m *MockMonitor) ReportUnreachable(service, address string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportUnreachable", service, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportUnreachable indicates an expected call of ReportUnreachable.
+func (mr *MockMonitorMockRecorder) ReportUnreachable(service, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportUnreachable", reflect.TypeOf((*MockMonitor)(nil).ReportUnreachable), service, address)
+}
+
+// SetLabel mocks base method.
+func (m *MockMonitor) SetLabel(key, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLabel", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLabel indicates an expected call of SetLabel.
+func (mr *MockMonitorMockRecorder) SetLabel(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabel", reflect.TypeOf((*MockMonitor)(nil).SetLabel), key, value)
+}
+
 // Start mocks base method.
 func (m *MockMonitor) Start() {
 	m.ctrl.T.Helper()
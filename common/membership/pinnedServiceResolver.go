@@ -0,0 +1,104 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"time"
+)
+
+type (
+	// pinnedServiceResolver overrides the owner of pinned keys found by the ring, so that operators
+	// can move a key, e.g. a history shard, to a specific host for a while
+	pinnedServiceResolver struct {
+		ServiceResolver
+
+		pins func() map[string]interface{}
+		now  func() time.Time
+	}
+)
+
+const (
+	pinAddressKey = "Address"
+	pinUntilKey   = "Until"
+)
+
+var _ ServiceResolver = (*pinnedServiceResolver)(nil)
+
+// NewPinnedServiceResolver returns a resolver which looks up keys in pins before the ring. A pin is either
+// the address of a host, or a map with the Address of the host and the time Until which the pin applies,
+// in RFC3339 format. Pins to hosts which are not members of the ring are ignored.
+func NewPinnedServiceResolver(
+	resolver ServiceResolver,
+	pins func() map[string]interface{},
+) ServiceResolver {
+	return &pinnedServiceResolver{
+		ServiceResolver: resolver,
+		pins:            pins,
+		now:             time.Now,
+	}
+}
+
+func (r *pinnedServiceResolver) Lookup(
+	key string,
+) (*HostInfo, error) {
+
+	if address, ok := r.pinnedAddress(key); ok {
+		for _, member := range r.Members() {
+			if member.GetAddress() == address {
+				return member, nil
+			}
+		}
+	}
+	return r.ServiceResolver.Lookup(key)
+}
+
+func (r *pinnedServiceResolver) pinnedAddress(
+	key string,
+) (string, bool) {
+
+	switch pin := r.pins()[key].(type) {
+	case string:
+		return pin, pin != ""
+	case map[string]interface{}:
+		address, _ := pin[pinAddressKey].(string)
+		switch until := pin[pinUntilKey].(type) {
+		case nil:
+		case time.Time:
+			if !r.now().Before(until) {
+				return "", false
+			}
+		case string:
+			untilTime, err := time.Parse(time.RFC3339, until)
+			if err != nil || !r.now().Before(untilTime) {
+				return "", false
+			}
+		default:
+			return "", false
+		}
+		return address, address != ""
+	default:
+		return "", false
+	}
+}
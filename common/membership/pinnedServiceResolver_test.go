@@ -0,0 +1,66 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinnedServiceResolver_Lookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	ringOwner := NewHostInfo("10.0.0.1:7234", nil)
+	pinnedHost := NewHostInfo("10.0.0.2:7234", nil)
+	ring := NewMockServiceResolver(ctrl)
+	ring.EXPECT().Lookup(gomock.Any()).Return(ringOwner, nil).AnyTimes()
+	ring.EXPECT().Members().Return([]*HostInfo{ringOwner, pinnedHost}).AnyTimes()
+
+	pins := map[string]interface{}{
+		"1": pinnedHost.GetAddress(),
+		"2": map[string]interface{}{pinAddressKey: pinnedHost.GetAddress(), pinUntilKey: now.Add(time.Hour).Format(time.RFC3339)},
+		"3": map[string]interface{}{pinAddressKey: pinnedHost.GetAddress(), pinUntilKey: now.Add(-time.Hour).Format(time.RFC3339)},
+		"4": "10.0.0.3:7234",
+	}
+	resolver := NewPinnedServiceResolver(ring, func() map[string]interface{} { return pins }).(*pinnedServiceResolver)
+	resolver.now = func() time.Time { return now }
+
+	for key, expected := range map[string]*HostInfo{
+		"1": pinnedHost,
+		"2": pinnedHost,
+		"3": ringOwner, // pin expired
+		"4": ringOwner, // not a member
+		"5": ringOwner, // not pinned
+	} {
+		host, err := resolver.Lookup(key)
+		require.NoError(t, err)
+		require.Equal(t, expected.GetAddress(), host.GetAddress(), key)
+	}
+}
@@ -38,6 +38,8 @@ import (
 	"go.temporal.io/server/common/primitives"
 
 	"github.com/pborman/uuid"
+	"github.com/temporalio/ringpop-go/events"
+	rpmembership "github.com/temporalio/ringpop-go/membership"
 
 	"go.temporal.io/server/common/persistence"
 
@@ -64,6 +66,9 @@ type ringpopMonitor struct {
 	metadataManager           persistence.ClusterMetadataManager
 	broadcastHostPortResolver func() (string, error)
 	hostID                    uuid.UUID
+
+	unreachableHostCheckConfig *UnreachableHostCheckConfig
+	unreachableHostChecker     *unreachableHostChecker
 }
 
 var _ Monitor = (*ringpopMonitor)(nil)
@@ -76,22 +81,27 @@ func NewRingpopMonitor(
 	logger log.Logger,
 	metadataManager persistence.ClusterMetadataManager,
 	broadcastHostPortResolver func() (string, error),
+	unreachableHostCheckConfig *UnreachableHostCheckConfig,
 ) Monitor {
 
 	rpo := &ringpopMonitor{
-		broadcastHostPortResolver: broadcastHostPortResolver,
-		metadataManager:           metadataManager,
-		status:                    common.DaemonStatusInitialized,
-		serviceName:               serviceName,
-		services:                  services,
-		rp:                        rp,
-		logger:                    logger,
-		rings:                     make(map[string]*ringpopServiceResolver),
-		hostID:                    uuid.NewUUID(),
+		broadcastHostPortResolver:  broadcastHostPortResolver,
+		metadataManager:            metadataManager,
+		status:                     common.DaemonStatusInitialized,
+		serviceName:                serviceName,
+		services:                   services,
+		rp:                         rp,
+		logger:                     logger,
+		rings:                      make(map[string]*ringpopServiceResolver),
+		hostID:                     uuid.NewUUID(),
+		unreachableHostCheckConfig: unreachableHostCheckConfig,
 	}
 	for service, port := range services {
 		rpo.rings[service] = newRingpopServiceResolver(service, port, rp, logger)
 	}
+	if unreachableHostCheckConfig != nil {
+		rpo.unreachableHostChecker = newUnreachableHostChecker(*unreachableHostCheckConfig, logger)
+	}
 	return rpo
 }
 
@@ -137,6 +147,10 @@ func (rpo *ringpopMonitor) Start() {
 	for _, ring := range rpo.rings {
 		ring.Start()
 	}
+
+	if rpo.unreachableHostChecker != nil {
+		rpo.rp.AddListener(rpo)
+	}
 }
 
 func ServiceNameToServiceTypeEnum(name string) (persistence.ServiceType, error) {
@@ -293,6 +307,11 @@ func (rpo *ringpopMonitor) Stop() {
 		return
 	}
 
+	if rpo.unreachableHostChecker != nil {
+		rpo.rp.RemoveListener(rpo)
+		rpo.unreachableHostChecker.stop()
+	}
+
 	for _, ring := range rpo.rings {
 		ring.Stop()
 	}
@@ -375,6 +394,109 @@ func (rpo *ringpopMonitor) GetMemberCount(service string) (int, error) {
 	return ring.MemberCount(), nil
 }
 
+func (rpo *ringpopMonitor) ReportUnreachable(service string, address string) error {
+	ring, found := rpo.rings[service]
+	if !found {
+		return ErrUnknownService
+	}
+	if rpo.unreachableHostChecker == nil {
+		return nil
+	}
+
+	rpo.unreachableHostChecker.check(address, func(address string) {
+		rpo.publishUnreachableHost(address)
+		// peers of the host take over its work, e.g. history hosts its shards, so the host is only removed
+		// from the ring once a peer confirmed it, other services would route requests to hosts not owning them
+		if service == rpo.serviceName {
+			rpo.excludeUnreachableHost(ring, address)
+		}
+	})
+	return nil
+}
+
+// HandleEvent handles unreachable hosts reported by other members via their labels
+func (rpo *ringpopMonitor) HandleEvent(
+	event events.Event,
+) {
+	e, ok := event.(rpmembership.ChangeEvent)
+	if !ok {
+		return
+	}
+
+	for _, change := range e.Changes {
+		if change.After == nil {
+			continue
+		}
+		value, ok := change.After.Label(unreachableHostsLabel)
+		if !ok {
+			continue
+		}
+		reporterService, _ := change.After.Label(RoleKey)
+		for _, address := range parseUnreachableHostsLabel(value) {
+			rpo.handleUnreachableHostReport(reporterService, address)
+		}
+	}
+}
+
+func (rpo *ringpopMonitor) handleUnreachableHostReport(
+	reporterService string,
+	address string,
+) {
+	for service, ring := range rpo.rings {
+		if !ring.hasMember(address) {
+			continue
+		}
+		switch {
+		case service == reporterService:
+			// a peer of the host confirmed it's unreachable
+			rpo.excludeUnreachableHost(ring, address)
+		case service == rpo.serviceName:
+			// this host is a peer of the reported host, it confirms the report with its own health check
+			_ = rpo.ReportUnreachable(service, address)
+		}
+	}
+}
+
+func (rpo *ringpopMonitor) publishUnreachableHost(
+	address string,
+) {
+	duration := rpo.unreachableHostCheckConfig.ExclusionDuration
+	value := rpo.unreachableHostChecker.report(address, time.Now().UTC().Add(duration))
+	if err := rpo.SetLabel(unreachableHostsLabel, value); err != nil {
+		rpo.logger.Warn("Unable to publish unreachable host", tag.Address(address), tag.Error(err))
+		return
+	}
+
+	// withdraw the report once it expired, so that peers don't act on it after the host came back
+	time.AfterFunc(duration, func() {
+		if atomic.LoadInt32(&rpo.status) != common.DaemonStatusStarted {
+			return
+		}
+		if err := rpo.SetLabel(unreachableHostsLabel, rpo.unreachableHostChecker.reportedHostsLabel()); err != nil {
+			rpo.logger.Warn("Unable to withdraw unreachable host", tag.Address(address), tag.Error(err))
+		}
+	})
+}
+
+func (rpo *ringpopMonitor) excludeUnreachableHost(
+	ring *ringpopServiceResolver,
+	address string,
+) {
+	ring.excludeUnreachableHost(
+		address,
+		rpo.unreachableHostCheckConfig.ExclusionDuration,
+		rpo.unreachableHostCheckConfig.MaxExcludedFraction,
+	)
+}
+
+func (rpo *ringpopMonitor) SetLabel(key string, value string) error {
+	labels, err := rpo.rp.Labels()
+	if err != nil {
+		return err
+	}
+	return labels.Set(key, value)
+}
+
 func replaceServicePort(address string, servicePort int) (string, error) {
 	host, _, err := net.SplitHostPort(address)
 	if err != nil {
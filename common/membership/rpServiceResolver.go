@@ -38,6 +38,7 @@ import (
 	"github.com/dgryski/go-farm"
 	"github.com/temporalio/ringpop-go/events"
 	"github.com/temporalio/ringpop-go/hashring"
+	rpmembership "github.com/temporalio/ringpop-go/membership"
 	"github.com/temporalio/ringpop-go/swim"
 
 	"go.temporal.io/server/common"
@@ -55,9 +56,16 @@ const (
 	// the service can be accessed.
 	RolePort = "servicePort"
 
+	// LabelValueMaxSize is the max size of a label value set via Monitor.SetLabel
+	LabelValueMaxSize = 4096
+
 	minRefreshInternal     = time.Second * 4
 	defaultRefreshInterval = time.Second * 10
 	replicaPoints          = 100
+
+	// departedMemberLabelsTTL is how long labels of a member which left the ring are kept
+	// waiting for the ring changed event which reports the removal of that member
+	departedMemberLabelsTTL = time.Minute
 )
 
 type ringpopServiceResolver struct {
@@ -72,12 +80,21 @@ type ringpopServiceResolver struct {
 
 	ringValue atomic.Value // this stores the current hashring
 
-	refreshLock     sync.Mutex
-	lastRefreshTime time.Time
-	membersMap      map[string]struct{} // for de-duping change notifications
+	refreshLock      sync.Mutex
+	lastRefreshTime  time.Time
+	membersMap       map[string]struct{}  // for de-duping change notifications
+	unreachableHosts map[string]time.Time // hosts excluded from the ring until the given time
 
 	listenerLock sync.RWMutex
 	listeners    map[string]chan<- *ChangedEvent
+
+	departedLock    sync.Mutex
+	departedMembers map[string]departedMember // last known labels of members which left the ring
+}
+
+type departedMember struct {
+	labels       map[string]string
+	departedTime time.Time
 }
 
 var _ ServiceResolver = (*ringpopServiceResolver)(nil)
@@ -99,6 +116,9 @@ func newRingpopServiceResolver(
 		logger:      log.With(logger, tag.ComponentServiceResolver, tag.Service(service)),
 		membersMap:  make(map[string]struct{}),
 		listeners:   make(map[string]chan<- *ChangedEvent),
+
+		unreachableHosts: make(map[string]time.Time),
+		departedMembers:  make(map[string]departedMember),
 	}
 	resolver.ringValue.Store(newHashRing())
 	return resolver
@@ -213,9 +233,8 @@ func (r *ringpopServiceResolver) HandleEvent(
 	event events.Event,
 ) {
 
-	// We only care about RingChangedEvent
-	e, ok := event.(events.RingChangedEvent)
-	if ok {
+	switch e := event.(type) {
+	case events.RingChangedEvent:
 		r.logger.Info("Received a ring changed event")
 		// Note that we receive events asynchronously, possibly out of order.
 		// We cannot rely on the content of the event, rather we load everything
@@ -224,7 +243,69 @@ func (r *ringpopServiceResolver) HandleEvent(
 			r.logger.Error("error refreshing ring when receiving a ring changed event", tag.Error(err))
 		}
 		r.emitEvent(e)
+	case rpmembership.ChangeEvent:
+		// Membership change event is the last place where labels of a member which left the ring are available.
+		// Keep them, so they can be reported to listeners along with the ring changed event.
+		r.recordDepartedMembers(e)
+	}
+}
+
+func (r *ringpopServiceResolver) recordDepartedMembers(
+	event rpmembership.ChangeEvent,
+) {
+	now := time.Now().UTC()
+
+	r.departedLock.Lock()
+	defer r.departedLock.Unlock()
+
+	for address, member := range r.departedMembers {
+		if now.Sub(member.departedTime) > departedMemberLabelsTTL {
+			delete(r.departedMembers, address)
+		}
+	}
+
+	for _, change := range event.Changes {
+		if change.Before == nil || change.After != nil {
+			continue
+		}
+		member, ok := change.Before.(swim.Member)
+		if !ok {
+			continue
+		}
+		if role, _ := member.Label(RoleKey); role != r.service {
+			continue
+		}
+		labels := make(map[string]string, len(member.Labels))
+		for key, value := range member.Labels {
+			labels[key] = value
+		}
+		r.departedMembers[member.Address] = departedMember{
+			labels:       labels,
+			departedTime: now,
+		}
+	}
+}
+
+// departedMemberLabels returns last known labels of the given member which left the ring
+func (r *ringpopServiceResolver) departedMemberLabels(
+	address string,
+) map[string]string {
+	labels := r.getLabelsMap()
+
+	r.departedLock.Lock()
+	defer r.departedLock.Unlock()
+
+	member, ok := r.departedMembers[address]
+	if !ok {
+		return labels
+	}
+	delete(r.departedMembers, address)
+	for key, value := range member.labels {
+		if _, ok := labels[key]; !ok {
+			labels[key] = value
+		}
 	}
+	return labels
 }
 
 func (r *ringpopServiceResolver) refresh() error {
@@ -248,6 +329,7 @@ func (r *ringpopServiceResolver) refreshNoLock() error {
 	if err != nil {
 		return err
 	}
+	addrs = r.filterUnreachableHostsNoLock(addrs)
 
 	newMembersMap, changed := r.compareMembers(addrs)
 	if !changed {
@@ -267,6 +349,74 @@ func (r *ringpopServiceResolver) refreshNoLock() error {
 	return nil
 }
 
+// excludeUnreachableHost removes the given host from the ring for the given duration,
+// ahead of ringpop detecting the failure. The host is not excluded if it is not a member
+// or if the fraction of excluded hosts would exceed maxExcludedFraction, which protects
+// against the local host being partitioned from the rest of the cluster.
+func (r *ringpopServiceResolver) excludeUnreachableHost(
+	address string,
+	duration time.Duration,
+	maxExcludedFraction float64,
+) {
+	r.refreshLock.Lock()
+	defer r.refreshLock.Unlock()
+
+	if _, ok := r.membersMap[address]; !ok {
+		return
+	}
+	now := time.Now().UTC()
+	excluded := 0
+	for _, until := range r.unreachableHosts {
+		if until.After(now) {
+			excluded++
+		}
+	}
+	total := len(r.membersMap) + excluded
+	if float64(excluded+1) > float64(total)*maxExcludedFraction {
+		r.logger.Warn("Not excluding unreachable host, too many hosts excluded", tag.Address(address))
+		return
+	}
+
+	r.unreachableHosts[address] = now.Add(duration)
+	if err := r.refreshNoLock(); err != nil {
+		r.logger.Error("error refreshing ring when excluding unreachable host", tag.Error(err))
+		return
+	}
+	r.logger.Info("Excluded unreachable host from ring", tag.Address(address))
+	r.notifyListeners(&ChangedEvent{
+		HostsRemoved: []*HostInfo{NewHostInfo(address, r.getLabelsMap())},
+	})
+}
+
+func (r *ringpopServiceResolver) hasMember(address string) bool {
+	r.refreshLock.Lock()
+	defer r.refreshLock.Unlock()
+
+	_, ok := r.membersMap[address]
+	return ok
+}
+
+func (r *ringpopServiceResolver) filterUnreachableHostsNoLock(addrs []string) []string {
+	if len(r.unreachableHosts) == 0 {
+		return addrs
+	}
+
+	now := time.Now().UTC()
+	for addr, until := range r.unreachableHosts {
+		if !until.After(now) {
+			delete(r.unreachableHosts, addr)
+		}
+	}
+
+	reachable := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := r.unreachableHosts[addr]; !ok {
+			reachable = append(reachable, addr)
+		}
+	}
+	return reachable
+}
+
 func (r *ringpopServiceResolver) getReachableMembers() ([]string, error) {
 	members, err := r.rp.GetReachableMemberObjects(swim.MemberWithLabelAndValue(RoleKey, r.service))
 	if err != nil {
@@ -311,13 +461,18 @@ func (r *ringpopServiceResolver) emitEvent(
 		event.HostsAdded = append(event.HostsAdded, NewHostInfo(addr, r.getLabelsMap()))
 	}
 	for _, addr := range rpEvent.ServersRemoved {
-		event.HostsRemoved = append(event.HostsRemoved, NewHostInfo(addr, r.getLabelsMap()))
+		event.HostsRemoved = append(event.HostsRemoved, NewHostInfo(addr, r.departedMemberLabels(addr)))
 	}
 	for _, addr := range rpEvent.ServersUpdated {
 		event.HostsUpdated = append(event.HostsUpdated, NewHostInfo(addr, r.getLabelsMap()))
 	}
 
-	// Notify listeners
+	r.notifyListeners(event)
+}
+
+func (r *ringpopServiceResolver) notifyListeners(
+	event *ChangedEvent,
+) {
 	r.listenerLock.RLock()
 	defer r.listenerLock.RUnlock()
 
@@ -159,6 +159,7 @@ func NewTestRingpopCluster(
 			logger,
 			mockMgr,
 			resolver,
+			nil,
 		)
 		cluster.rings[i].Start()
 	}
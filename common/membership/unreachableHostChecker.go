@@ -0,0 +1,181 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+const (
+	// unreachableHostsLabel lists the addresses of hosts which failed the accelerated health check of this member.
+	// Peers of a reported host check it on their own, and only their confirmation removes it from the rings.
+	unreachableHostsLabel = "unreachableHosts"
+)
+
+type (
+	// UnreachableHostCheckConfig is the config for accelerated health checks of hosts
+	// which were reported as unreachable by RPC clients
+	UnreachableHostCheckConfig struct {
+		// Attempts is the number of consecutive failed probes before a host is considered dead
+		Attempts int
+		// ProbeInterval is the wait time between two probes of the same host
+		ProbeInterval time.Duration
+		// ProbeTimeout is the connect timeout of a single probe
+		ProbeTimeout time.Duration
+		// ExclusionDuration is how long a dead host is kept out of the local ring,
+		// ringpop failure detection is expected to have caught up by then
+		ExclusionDuration time.Duration
+		// Cooldown is the min interval between two checks of the same host
+		Cooldown time.Duration
+		// MaxExcludedFraction is the max fraction of a service's hosts that can be excluded at the same time
+		MaxExcludedFraction float64
+	}
+
+	unreachableHostChecker struct {
+		config UnreachableHostCheckConfig
+		probe  func(address string, timeout time.Duration) error
+		logger log.Logger
+
+		shutdownCh chan struct{}
+
+		sync.Mutex
+		lastCheckTime map[string]time.Time
+		reportedHosts map[string]time.Time // hosts published in unreachableHostsLabel until the given time
+	}
+)
+
+func newUnreachableHostChecker(
+	config UnreachableHostCheckConfig,
+	logger log.Logger,
+) *unreachableHostChecker {
+	return &unreachableHostChecker{
+		config:        config,
+		probe:         probeTCP,
+		logger:        logger,
+		shutdownCh:    make(chan struct{}),
+		lastCheckTime: make(map[string]time.Time),
+		reportedHosts: make(map[string]time.Time),
+	}
+}
+
+// check probes the given host in background and invokes onDead if
+// none of the probes succeeds, hosts checked within the cooldown are skipped
+func (c *unreachableHostChecker) check(
+	address string,
+	onDead func(address string),
+) {
+	now := time.Now().UTC()
+
+	c.Lock()
+	for addr, lastCheckTime := range c.lastCheckTime {
+		if now.Sub(lastCheckTime) >= c.config.Cooldown {
+			delete(c.lastCheckTime, addr)
+		}
+	}
+	if _, ok := c.lastCheckTime[address]; ok {
+		c.Unlock()
+		return
+	}
+	c.lastCheckTime[address] = now
+	c.Unlock()
+
+	go func() {
+		for attempt := 0; attempt < c.config.Attempts; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-c.shutdownCh:
+					return
+				case <-time.After(c.config.ProbeInterval):
+				}
+			}
+			if err := c.probe(address, c.config.ProbeTimeout); err == nil {
+				return
+			}
+		}
+
+		c.logger.Warn("Host failed accelerated health check", tag.Address(address))
+		onDead(address)
+	}()
+}
+
+func (c *unreachableHostChecker) stop() {
+	close(c.shutdownCh)
+}
+
+// report adds the given dead host to the reported hosts until the given time,
+// and returns the new value of unreachableHostsLabel
+func (c *unreachableHostChecker) report(
+	address string,
+	until time.Time,
+) string {
+	c.Lock()
+	defer c.Unlock()
+
+	c.reportedHosts[address] = until
+	return c.reportedHostsLabelLocked(time.Now().UTC())
+}
+
+// reportedHostsLabel drops expired reports and returns the new value of unreachableHostsLabel
+func (c *unreachableHostChecker) reportedHostsLabel() string {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.reportedHostsLabelLocked(time.Now().UTC())
+}
+
+func (c *unreachableHostChecker) reportedHostsLabelLocked(now time.Time) string {
+	addresses := make([]string, 0, len(c.reportedHosts))
+	for address, until := range c.reportedHosts {
+		if !until.After(now) {
+			delete(c.reportedHosts, address)
+			continue
+		}
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return strings.Join(addresses, ",")
+}
+
+func parseUnreachableHostsLabel(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func probeTCP(address string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
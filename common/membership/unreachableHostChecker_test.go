@@ -0,0 +1,202 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/temporalio/ringpop-go"
+	"github.com/uber/tchannel-go"
+
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/primitives"
+)
+
+type unreachableHostCheckerSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	checker *unreachableHostChecker
+}
+
+func TestUnreachableHostCheckerSuite(t *testing.T) {
+	suite.Run(t, new(unreachableHostCheckerSuite))
+}
+
+func (s *unreachableHostCheckerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.checker = newUnreachableHostChecker(UnreachableHostCheckConfig{
+		Attempts:      3,
+		ProbeInterval: time.Millisecond,
+		ProbeTimeout:  time.Millisecond,
+		Cooldown:      time.Minute,
+	}, log.NewNoopLogger())
+}
+
+func (s *unreachableHostCheckerSuite) TearDownTest() {
+	s.checker.stop()
+}
+
+func (s *unreachableHostCheckerSuite) TestCheck_Dead() {
+	var probes int32
+	s.checker.probe = func(address string, timeout time.Duration) error {
+		atomic.AddInt32(&probes, 1)
+		return errors.New("connection refused")
+	}
+
+	deadCh := make(chan string, 1)
+	s.checker.check("127.0.0.1:7234", func(address string) { deadCh <- address })
+
+	select {
+	case address := <-deadCh:
+		s.Equal("127.0.0.1:7234", address)
+		s.Equal(int32(3), atomic.LoadInt32(&probes))
+	case <-time.After(time.Second):
+		s.Fail("timed out waiting for host to be reported dead")
+	}
+}
+
+func (s *unreachableHostCheckerSuite) TestCheck_Alive() {
+	var probes int32
+	s.checker.probe = func(address string, timeout time.Duration) error {
+		if atomic.AddInt32(&probes, 1) < 2 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	deadCh := make(chan string, 1)
+	s.checker.check("127.0.0.1:7234", func(address string) { deadCh <- address })
+
+	select {
+	case <-deadCh:
+		s.Fail("host should not be reported dead")
+	case <-time.After(100 * time.Millisecond):
+		s.Equal(int32(2), atomic.LoadInt32(&probes))
+	}
+}
+
+func (s *unreachableHostCheckerSuite) TestCheck_Cooldown() {
+	var probes int32
+	s.checker.probe = func(address string, timeout time.Duration) error {
+		atomic.AddInt32(&probes, 1)
+		return nil
+	}
+
+	s.checker.check("127.0.0.1:7234", func(address string) {})
+	s.checker.check("127.0.0.1:7234", func(address string) {})
+	time.Sleep(100 * time.Millisecond)
+	s.Equal(int32(1), atomic.LoadInt32(&probes))
+}
+
+func (s *unreachableHostCheckerSuite) TestReport() {
+	now := time.Now().UTC()
+	s.Equal("127.0.0.2:7234", s.checker.report("127.0.0.2:7234", now.Add(time.Minute)))
+	s.Equal("127.0.0.1:7234,127.0.0.2:7234", s.checker.report("127.0.0.1:7234", now.Add(time.Minute)))
+
+	// expired reports are withdrawn
+	s.checker.reportedHosts["127.0.0.2:7234"] = now
+	s.Equal("127.0.0.1:7234", s.checker.reportedHostsLabel())
+	s.Equal([]string{"127.0.0.1:7234"}, parseUnreachableHostsLabel(s.checker.reportedHostsLabel()))
+	s.Empty(parseUnreachableHostsLabel(""))
+}
+
+func (s *unreachableHostCheckerSuite) TestHandleUnreachableHostReport() {
+	channel, err := tchannel.NewChannel("unreachable-host-test", nil)
+	s.NoError(err)
+	defer channel.Close()
+	rp, err := ringpop.New("unreachable-host-test", ringpop.Channel(channel))
+	s.NoError(err)
+	logger := log.NewNoopLogger()
+	rpWrapper := NewRingPop(rp, time.Second, logger)
+
+	newMonitor := func(serviceName string) (*ringpopMonitor, *ringpopServiceResolver) {
+		historyRing := newRingpopServiceResolver(primitives.HistoryService, 7234, rpWrapper, logger)
+		historyRing.membersMap = map[string]struct{}{
+			"127.0.0.1:7234": {},
+			"127.0.0.2:7234": {},
+			"127.0.0.3:7234": {},
+		}
+		monitor := &ringpopMonitor{
+			serviceName: serviceName,
+			rp:          rpWrapper,
+			logger:      logger,
+			rings: map[string]*ringpopServiceResolver{
+				primitives.HistoryService:  historyRing,
+				primitives.FrontendService: newRingpopServiceResolver(primitives.FrontendService, 7233, rpWrapper, logger),
+			},
+			unreachableHostCheckConfig: &UnreachableHostCheckConfig{
+				ExclusionDuration:   time.Minute,
+				MaxExcludedFraction: 0.5,
+			},
+			unreachableHostChecker: s.checker,
+		}
+		return monitor, historyRing
+	}
+	probeCh := make(chan string, 3)
+	s.checker.config.Attempts = 1
+	s.checker.probe = func(address string, timeout time.Duration) error {
+		probeCh <- address
+		return errors.New("connection refused")
+	}
+
+	// a frontend report doesn't remove the host from the ring of another frontend, as history hosts still own its shards
+	frontend, frontendHistoryRing := newMonitor(primitives.FrontendService)
+	frontend.handleUnreachableHostReport(primitives.FrontendService, "127.0.0.1:7234")
+	s.Empty(frontendHistoryRing.unreachableHosts)
+	s.Empty(probeCh)
+
+	// history hosts check the host reported by a frontend on their own and exclude it when the check fails
+	history, historyRing := newMonitor(primitives.HistoryService)
+	history.handleUnreachableHostReport(primitives.FrontendService, "127.0.0.1:7234")
+	select {
+	case address := <-probeCh:
+		s.Equal("127.0.0.1:7234", address)
+	case <-time.After(time.Second):
+		s.Fail("reported host was not checked")
+	}
+	s.Eventually(func() bool {
+		historyRing.refreshLock.Lock()
+		defer historyRing.refreshLock.Unlock()
+		_, ok := historyRing.unreachableHosts["127.0.0.1:7234"]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	s.Equal("127.0.0.1:7234", s.checker.reportedHostsLabel())
+
+	// once confirmed by a history host, the host is removed from the ring of frontends without another check
+	frontend.handleUnreachableHostReport(primitives.HistoryService, "127.0.0.1:7234")
+	s.Contains(frontendHistoryRing.unreachableHosts, "127.0.0.1:7234")
+	s.Empty(probeCh)
+
+	// hosts which are no members of a ring are ignored
+	history.handleUnreachableHostReport(primitives.FrontendService, "127.0.0.9:7234")
+	s.Empty(probeCh)
+}
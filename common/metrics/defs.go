@@ -76,6 +76,9 @@ const (
 // Values used for metrics propagation
 const (
 	HistoryWorkflowExecutionCacheLatency = "history_workflow_execution_cache_latency"
+	HistoryActionUnitsStateTransitions   = "history_action_units_state_transitions"
+	HistoryActionUnitsBytesWritten       = "history_action_units_bytes_written"
+	HistoryActionUnitsTasksGenerated     = "history_action_units_tasks_generated"
 )
 
 // Common tags for all services
@@ -84,11 +87,15 @@ const (
 	ServiceRoleTagName    = "service_role"
 	StatsTypeTagName      = "stats_type"
 	CacheTypeTagName      = "cache_type"
+	CacheTierTagName      = "cache_tier"
 	FailureTagName        = "failure"
 	TaskTypeTagName       = "task_type"
 	QueueTypeTagName      = "queue_type"
 	visibilityTypeTagName = "visibility_type"
 	httpStatusTagName     = "http_status"
+	StartStageTagName     = "start_stage"
+	LockOperationTagName  = "lock_operation"
+	WorkerIdentityTagName = "worker_identity"
 )
 
 // This package should hold all the metrics and tags for temporal
@@ -106,8 +113,19 @@ const (
 	MutableStateCacheTypeTagValue = "mutablestate"
 	EventsCacheTypeTagValue       = "events"
 
+	PinnedCacheTierTagValue  = "pinned"
+	DefaultCacheTierTagValue = "default"
+
 	standardVisibilityTagValue = "standard_visibility"
 	advancedVisibilityTagValue = "advanced_visibility"
+
+	// StartWorkflowStageLatency stages, the first three are measured from the time the frontend received
+	// the request, the last two from the workflow execution time
+	FrontendStartStageTagValue                 = "frontend"
+	HistoryReceivedStartStageTagValue          = "history_received"
+	HistoryPersistedStartStageTagValue         = "history_persisted"
+	WorkflowTaskDispatchedStartStageTagValue   = "workflow_task_dispatched"
+	FirstWorkflowTaskStartedStartStageTagValue = "first_workflow_task_started"
 )
 
 // Common service base metrics
@@ -1027,6 +1045,8 @@ const (
 	TimerActiveTaskWorkflowBackoffTimerScope
 	// TimerActiveTaskDeleteHistoryEventScope is the scope used by metric emitted by timer queue processor for processing history event cleanup
 	TimerActiveTaskDeleteHistoryEventScope
+	// TimerActiveTaskDeleteVisibilityScope is the scope used by metric emitted by timer queue processor for processing delayed visibility record cleanup
+	TimerActiveTaskDeleteVisibilityScope
 	// TimerStandbyTaskActivityTimeoutScope is the scope used by metric emitted by timer queue processor for processing activity timeouts
 	TimerStandbyTaskActivityTimeoutScope
 	// TimerStandbyTaskWorkflowTaskTimeoutScope is the scope used by metric emitted by timer queue processor for processing workflow task timeouts
@@ -1039,6 +1059,8 @@ const (
 	TimerStandbyTaskActivityRetryTimerScope
 	// TimerStandbyTaskDeleteHistoryEventScope is the scope used by metric emitted by timer queue processor for processing history event cleanup
 	TimerStandbyTaskDeleteHistoryEventScope
+	// TimerStandbyTaskDeleteVisibilityScope is the scope used by metric emitted by timer queue processor for processing delayed visibility record cleanup
+	TimerStandbyTaskDeleteVisibilityScope
 	// TimerStandbyTaskWorkflowBackoffTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerStandbyTaskWorkflowBackoffTimerScope
 	// HistoryEventNotificationScope is the scope used by shard history event nitification
@@ -1625,6 +1647,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerActiveTaskActivityRetryTimerScope:    {operation: "TimerActiveTaskActivityRetryTimer"},
 		TimerActiveTaskWorkflowBackoffTimerScope:  {operation: "TimerActiveTaskWorkflowBackoffTimer"},
 		TimerActiveTaskDeleteHistoryEventScope:    {operation: "TimerActiveTaskDeleteHistoryEvent"},
+		TimerActiveTaskDeleteVisibilityScope:      {operation: "TimerActiveTaskDeleteVisibility"},
 		TimerStandbyTaskActivityTimeoutScope:      {operation: "TimerStandbyTaskActivityTimeout"},
 		TimerStandbyTaskWorkflowTaskTimeoutScope:  {operation: "TimerStandbyTaskWorkflowTaskTimeout"},
 		TimerStandbyTaskUserTimerScope:            {operation: "TimerStandbyTaskUserTimer"},
@@ -1632,6 +1655,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerStandbyTaskActivityRetryTimerScope:   {operation: "TimerStandbyTaskActivityRetryTimer"},
 		TimerStandbyTaskWorkflowBackoffTimerScope: {operation: "TimerStandbyTaskWorkflowBackoffTimer"},
 		TimerStandbyTaskDeleteHistoryEventScope:   {operation: "TimerStandbyTaskDeleteHistoryEvent"},
+		TimerStandbyTaskDeleteVisibilityScope:     {operation: "TimerStandbyTaskDeleteVisibility"},
 		HistoryEventNotificationScope:             {operation: "HistoryEventNotification"},
 		ReplicatorQueueProcessorScope:             {operation: "ReplicatorQueueProcessor"},
 		ReplicatorTaskHistoryScope:                {operation: "ReplicatorTaskHistory"},
@@ -1711,6 +1735,9 @@ const (
 	ServiceLatency
 	ServiceLatencyNoUserLatency
 	ServiceLatencyUserLatency
+	ServiceActionUnitsStateTransitions
+	ServiceActionUnitsBytesWritten
+	ServiceActionUnitsTasksGenerated
 	ServiceErrInvalidArgumentCounter
 	ServiceErrNamespaceNotActiveCounter
 	ServiceErrResourceExhaustedCounter
@@ -1742,6 +1769,22 @@ const (
 	PersistenceErrEntityNotExistsCounter
 	PersistenceErrNamespaceAlreadyExistsCounter
 	PersistenceErrBadRequestCounter
+	PersistenceErrHistoryChecksumMismatchCounter
+
+	PersistenceRequestsPerNamespace
+	PersistenceFailuresPerNamespace
+	PersistenceLatencyPerNamespace
+
+	QueueBacklogGauge
+	QueueProcessingRateGauge
+	QueueTaskIDBacklogGauge
+	QueueTaskIDProcessingRateGauge
+	QueueTimerLagGauge
+	QueueTimerProcessingRateGauge
+
+	LongPollLimitExceededCounter
+
+	TaskCompletionsPerIdentityCounter
 
 	ClientRequests
 	ClientFailures
@@ -1765,6 +1808,7 @@ const (
 	LockRequests
 	LockFailures
 	LockLatency
+	LockHoldLatency
 
 	ArchivalConfigFailures
 
@@ -1860,6 +1904,8 @@ const (
 
 	NoopImplementationIsUsed
 
+	StartWorkflowStageLatency
+
 	NumCommonMetrics // Needs to be last on this list for iota numbering
 )
 
@@ -1872,7 +1918,11 @@ const (
 	TaskAttemptTimer
 	TaskStandbyRetryCounter
 	TaskNotActiveCounter
+	TaskRangeStolenCounter
+	TaskRangeRenewTimeoutCounter
 	TaskLimitExceededCounter
+	TaskProcessorWorkerCount
+	TaskProcessorThrottledCounter
 	TaskBatchCompleteCounter
 	TaskProcessingLatency
 	TaskNoUserProcessingLatency
@@ -1882,6 +1932,7 @@ const (
 	TaskNoUserQueueLatency
 	TaskRedispatchQueuePendingTasksTimer
 	TaskScheduleToStartLatency
+	TaskScheduleToMatchingLatency
 
 	TransferTaskMissingEventCounter
 
@@ -1907,9 +1958,13 @@ const (
 	EmptyCompletionCommandsCounter
 	MultipleCompletionCommandsCounter
 	FailedWorkflowTasksCounter
+	WorkflowQuarantinedCounter
+	VisibilityMemoTruncatedCounter
 	StaleMutableStateCounter
+	ActivityHeartbeatCoalescedCounter
 	AutoResetPointsLimitExceededCounter
 	AutoResetPointCorruptionCounter
+	CompactedResetPointsCounter
 	ConcurrencyUpdateFailureCounter
 	ServiceErrTaskAlreadyStartedCounter
 	ServiceErrShardOwnershipLostCounter
@@ -1920,10 +1975,22 @@ const (
 	NewTimerNotifyCounter
 	AcquireShardsCounter
 	AcquireShardsLatency
+	ShardAcquisitionQueueDepthGauge
 	ShardContextClosedCounter
 	ShardContextCreatedCounter
 	ShardContextRemovedCounter
+	ShardContextIdleUnloadedCounter
+	ShardInfoClusterClockDriftGauge
+	ShardClockSkewExceededCounter
+	ShardOwnershipHeartbeatCounter
+	ShardInfoFlushSkippedProgressAtRisk
+	ShardInfoFlushDirtyUpdates
+	ShardRangeExhaustedCounter
+	ShardRangeRenewalRateExceededCounter
+	ShardOwnershipAssertionFailedCounter
 	ShardContextAcquisitionLatency
+	ShardContextWarmUpLatency
+	ShardContextWarmUpExecutions
 	ShardInfoReplicationPendingTasksTimer
 	ShardInfoTransferActivePendingTasksTimer
 	ShardInfoTransferStandbyPendingTasksTimer
@@ -1932,6 +1999,8 @@ const (
 	ShardInfoReplicationLagTimer
 	ShardInfoTransferLagTimer
 	ShardInfoTimerLagTimer
+	ShardInfoNamespaceTransferLagTimer
+	ShardInfoNamespaceTimerLagTimer
 	ShardInfoTransferDiffTimer
 	ShardInfoTimerDiffTimer
 	ShardInfoTransferFailoverInProgressTimer
@@ -1964,6 +2033,7 @@ const (
 	CacheFailures
 	CacheLatency
 	CacheMissCounter
+	CachePinnedBytes
 	AcquireLockFailedCounter
 	WorkflowContextCleared
 	MutableStateSize
@@ -2009,6 +2079,11 @@ const (
 	ReplicationTasksFetched
 	ReplicationTasksReturned
 	ReplicationTasksAppliedLatency
+	ReplicationTasksBuffered
+	ReplicationTasksBufferApplied
+	ReplicationTasksBufferExpired
+	ReplicationTasksResent
+	ReplicationTasksSkipped
 	ReplicationDLQFailed
 	ReplicationDLQMaxLevelGauge
 	ReplicationDLQAckLevelGauge
@@ -2060,15 +2135,22 @@ const (
 	PollSuccessPerTaskQueueCounter = iota + NumHistoryMetrics
 	PollTimeoutPerTaskQueueCounter
 	PollSuccessWithSyncPerTaskQueueCounter
+	PollSuccessLocalWaitPerTaskQueueCounter
+	PollSuccessForwardedPerTaskQueueCounter
 	LeaseRequestPerTaskQueueCounter
 	LeaseFailurePerTaskQueueCounter
 	ConditionFailedErrorPerTaskQueueCounter
 	RespondQueryTaskFailedPerTaskQueueCounter
+	StickyPollerUnavailablePerTaskQueueCounter
 	SyncThrottlePerTaskQueueCounter
 	BufferThrottlePerTaskQueueCounter
+	FairShareThrottlePerTaskQueueCounter
+	NamespaceFairShareStarvedCounter
 	SyncMatchLatencyPerTaskQueue
 	AsyncMatchLatencyPerTaskQueue
+	DispatchLatencyPerTaskQueue
 	ExpiredTasksPerTaskQueueCounter
+	BacklogTrimmedTasksPerTaskQueueCounter
 	ForwardedPerTaskQueueCounter
 	ForwardTaskCallsPerTaskQueue
 	ForwardTaskErrorsPerTaskQueue
@@ -2135,6 +2217,7 @@ const (
 	ExecutorTasksDroppedCount
 	BatcherProcessorSuccess
 	BatcherProcessorFailures
+	BatcherBlastRadiusGuardRefused
 	HistoryScavengerSuccessCount
 	HistoryScavengerErrorCount
 	HistoryScavengerSkipCount
@@ -2164,6 +2247,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ServiceLatency:                                      {metricName: "service_latency", metricType: Timer},
 		ServiceLatencyNoUserLatency:                         {metricName: "service_latency_nouserlatency", metricType: Timer},
 		ServiceLatencyUserLatency:                           {metricName: "service_latency_userlatency", metricType: Timer},
+		ServiceActionUnitsStateTransitions:                  {metricName: "service_action_units_state_transitions", metricType: Counter},
+		ServiceActionUnitsBytesWritten:                      {metricName: "service_action_units_bytes_written", metricType: Counter},
+		ServiceActionUnitsTasksGenerated:                    {metricName: "service_action_units_tasks_generated", metricType: Counter},
 		ServiceErrInvalidArgumentCounter:                    {metricName: "service_errors_invalid_argument", metricType: Counter},
 		ServiceErrNamespaceNotActiveCounter:                 {metricName: "service_errors_namespace_not_active", metricType: Counter},
 		ServiceErrResourceExhaustedCounter:                  {metricName: "service_errors_resource_exhausted", metricType: Counter},
@@ -2194,6 +2280,18 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrEntityNotExistsCounter:                {metricName: "persistence_errors_entity_not_exists", metricType: Counter},
 		PersistenceErrNamespaceAlreadyExistsCounter:         {metricName: "persistence_errors_namespace_already_exists", metricType: Counter},
 		PersistenceErrBadRequestCounter:                     {metricName: "persistence_errors_bad_request", metricType: Counter},
+		PersistenceErrHistoryChecksumMismatchCounter:        {metricName: "persistence_errors_history_checksum_mismatch", metricType: Counter},
+		PersistenceRequestsPerNamespace:                     {metricName: "persistence_requests_per_ns", metricType: Counter},
+		PersistenceFailuresPerNamespace:                     {metricName: "persistence_errors_per_ns", metricType: Counter},
+		PersistenceLatencyPerNamespace:                      {metricName: "persistence_latency_per_ns", metricType: Timer},
+		QueueBacklogGauge:                                   {metricName: "queue_backlog", metricType: Gauge},
+		QueueProcessingRateGauge:                            {metricName: "queue_processing_rate", metricType: Gauge},
+		QueueTaskIDBacklogGauge:                             {metricName: "queue_task_id_backlog", metricType: Gauge},
+		QueueTaskIDProcessingRateGauge:                      {metricName: "queue_task_id_processing_rate", metricType: Gauge},
+		QueueTimerLagGauge:                                  {metricName: "queue_timer_lag_seconds", metricType: Gauge},
+		QueueTimerProcessingRateGauge:                       {metricName: "queue_timer_processing_rate", metricType: Gauge},
+		LongPollLimitExceededCounter:                        {metricName: "long_poll_limit_exceeded", metricType: Counter},
+		TaskCompletionsPerIdentityCounter:                   {metricName: "task_completions_per_identity", metricType: Counter},
 		ClientRequests:                                      {metricName: "client_requests", metricType: Counter},
 		ClientFailures:                                      {metricName: "client_errors", metricType: Counter},
 		ClientLatency:                                       {metricName: "client_latency", metricType: Timer},
@@ -2211,6 +2309,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		LockRequests:                                        {metricName: "lock_requests", metricType: Counter},
 		LockFailures:                                        {metricName: "lock_failures", metricType: Counter},
 		LockLatency:                                         {metricName: "lock_latency", metricType: Timer},
+		LockHoldLatency:                                     {metricName: "lock_hold_latency", metricType: Timer},
 		ArchivalConfigFailures:                              {metricName: "archivalconfig_failures", metricType: Counter},
 
 		VisibilityPersistenceRequests:          {metricName: "visibility_persistence_requests", metricType: Counter},
@@ -2334,6 +2433,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ElasticsearchDocumentGenerateFailuresCount: {metricName: "elasticsearch_document_generate_failures_counter", metricType: Counter},
 
 		NoopImplementationIsUsed: {metricName: "noop_implementation_is_used", metricType: Counter},
+
+		StartWorkflowStageLatency: {metricName: "start_workflow_stage_latency", metricType: Timer},
 	},
 	History: {
 		TaskRequests: {metricName: "task_requests", metricType: Counter},
@@ -2342,14 +2443,20 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskUserLatency:   {metricName: "task_latency_userlatency", metricType: Timer},   // from task generated to task complete
 		TaskNoUserLatency: {metricName: "task_latency_nouserlatency", metricType: Timer}, // from task generated to task complete
 
-		TaskAttemptTimer:         {metricName: "task_attempt", metricType: Timer},
-		TaskFailures:             {metricName: "task_errors", metricType: Counter},
-		TaskDiscarded:            {metricName: "task_errors_discarded", metricType: Counter},
-		TaskStandbyRetryCounter:  {metricName: "task_errors_standby_retry_counter", metricType: Counter},
-		TaskNotActiveCounter:     {metricName: "task_errors_not_active_counter", metricType: Counter},
-		TaskLimitExceededCounter: {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
+		TaskAttemptTimer:             {metricName: "task_attempt", metricType: Timer},
+		TaskFailures:                 {metricName: "task_errors", metricType: Counter},
+		TaskDiscarded:                {metricName: "task_errors_discarded", metricType: Counter},
+		TaskStandbyRetryCounter:      {metricName: "task_errors_standby_retry_counter", metricType: Counter},
+		TaskNotActiveCounter:         {metricName: "task_errors_not_active_counter", metricType: Counter},
+		TaskRangeStolenCounter:       {metricName: "task_errors_range_stolen_counter", metricType: Counter},
+		TaskRangeRenewTimeoutCounter: {metricName: "task_errors_range_renew_timeout_counter", metricType: Counter},
+		TaskLimitExceededCounter:     {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
+
+		TaskProcessorWorkerCount:      {metricName: "task_processor_worker_count", metricType: Gauge},
+		TaskProcessorThrottledCounter: {metricName: "task_processor_throttled", metricType: Counter},
 
-		TaskScheduleToStartLatency: {metricName: "task_schedule_to_start_latency", metricType: Timer},
+		TaskScheduleToStartLatency:    {metricName: "task_schedule_to_start_latency", metricType: Timer},
+		TaskScheduleToMatchingLatency: {metricName: "task_schedule_to_matching_latency", metricType: Timer},
 
 		TaskProcessingLatency:       {metricName: "task_latency_processing", metricType: Timer},               // per-attempt
 		TaskNoUserProcessingLatency: {metricName: "task_latency_processing_nouserlatency", metricType: Timer}, // per-attempt
@@ -2381,9 +2488,13 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		EmptyCompletionCommandsCounter:                    {metricName: "empty_completion_commands", metricType: Counter},
 		MultipleCompletionCommandsCounter:                 {metricName: "multiple_completion_commands", metricType: Counter},
 		FailedWorkflowTasksCounter:                        {metricName: "failed_workflow_tasks", metricType: Counter},
+		WorkflowQuarantinedCounter:                        {metricName: "workflow_quarantined", metricType: Counter},
+		VisibilityMemoTruncatedCounter:                    {metricName: "visibility_memo_truncated", metricType: Counter},
 		StaleMutableStateCounter:                          {metricName: "stale_mutable_state", metricType: Counter},
+		ActivityHeartbeatCoalescedCounter:                 {metricName: "activity_heartbeat_coalesced", metricType: Counter},
 		AutoResetPointsLimitExceededCounter:               {metricName: "auto_reset_points_exceed_limit", metricType: Counter},
 		AutoResetPointCorruptionCounter:                   {metricName: "auto_reset_point_corruption", metricType: Counter},
+		CompactedResetPointsCounter:                       {metricName: "compacted_reset_points", metricType: Counter},
 		ConcurrencyUpdateFailureCounter:                   {metricName: "concurrency_update_failure", metricType: Counter},
 		ServiceErrShardOwnershipLostCounter:               {metricName: "service_errors_shard_ownership_lost", metricType: Counter},
 		ServiceErrTaskAlreadyStartedCounter:               {metricName: "service_errors_task_already_started", metricType: Counter},
@@ -2394,10 +2505,22 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		NewTimerNotifyCounter:                             {metricName: "new_timer_notifications", metricType: Counter},
 		AcquireShardsCounter:                              {metricName: "acquire_shards_count", metricType: Counter},
 		AcquireShardsLatency:                              {metricName: "acquire_shards_latency", metricType: Timer},
+		ShardAcquisitionQueueDepthGauge:                   {metricName: "shard_acquisition_queue_depth", metricType: Gauge},
 		ShardContextClosedCounter:                         {metricName: "shard_closed_count", metricType: Counter},
 		ShardContextCreatedCounter:                        {metricName: "sharditem_created_count", metricType: Counter},
 		ShardContextRemovedCounter:                        {metricName: "sharditem_removed_count", metricType: Counter},
+		ShardContextIdleUnloadedCounter:                   {metricName: "sharditem_idle_unloaded_count", metricType: Counter},
+		ShardInfoClusterClockDriftGauge:                   {metricName: "shardinfo_cluster_clock_drift_ms", metricType: Gauge},
+		ShardClockSkewExceededCounter:                     {metricName: "shard_clock_skew_exceeded", metricType: Counter},
+		ShardOwnershipHeartbeatCounter:                    {metricName: "shard_ownership_heartbeat", metricType: Counter},
+		ShardInfoFlushSkippedProgressAtRisk:               {metricName: "shardinfo_flush_skipped_progress_at_risk", metricType: Timer},
+		ShardInfoFlushDirtyUpdates:                        {metricName: "shardinfo_flush_dirty_updates", metricType: Timer},
+		ShardRangeExhaustedCounter:                        {metricName: "shard_range_exhausted", metricType: Counter},
+		ShardRangeRenewalRateExceededCounter:              {metricName: "shard_range_renewal_rate_exceeded", metricType: Counter},
+		ShardOwnershipAssertionFailedCounter:              {metricName: "shard_ownership_assertion_failed", metricType: Counter},
 		ShardContextAcquisitionLatency:                    {metricName: "sharditem_acquisition_latency", metricType: Timer},
+		ShardContextWarmUpLatency:                         {metricName: "sharditem_warm_up_latency", metricType: Timer},
+		ShardContextWarmUpExecutions:                      {metricName: "sharditem_warm_up_executions", metricType: Timer},
 		ShardInfoReplicationPendingTasksTimer:             {metricName: "shardinfo_replication_pending_task", metricType: Timer},
 		ShardInfoTransferActivePendingTasksTimer:          {metricName: "shardinfo_transfer_active_pending_task", metricType: Timer},
 		ShardInfoTransferStandbyPendingTasksTimer:         {metricName: "shardinfo_transfer_standby_pending_task", metricType: Timer},
@@ -2406,6 +2529,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ShardInfoReplicationLagTimer:                      {metricName: "shardinfo_replication_lag", metricType: Timer},
 		ShardInfoTransferLagTimer:                         {metricName: "shardinfo_transfer_lag", metricType: Timer},
 		ShardInfoTimerLagTimer:                            {metricName: "shardinfo_timer_lag", metricType: Timer},
+		ShardInfoNamespaceTransferLagTimer:                {metricName: "shardinfo_namespace_transfer_lag", metricType: Timer},
+		ShardInfoNamespaceTimerLagTimer:                   {metricName: "shardinfo_namespace_timer_lag", metricType: Timer},
 		ShardInfoTransferDiffTimer:                        {metricName: "shardinfo_transfer_diff", metricType: Timer},
 		ShardInfoTimerDiffTimer:                           {metricName: "shardinfo_timer_diff", metricType: Timer},
 		ShardInfoTransferFailoverInProgressTimer:          {metricName: "shardinfo_transfer_failover_in_progress", metricType: Timer},
@@ -2438,6 +2563,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CacheFailures:                                     {metricName: "cache_errors", metricType: Counter},
 		CacheLatency:                                      {metricName: "cache_latency", metricType: Timer},
 		CacheMissCounter:                                  {metricName: "cache_miss", metricType: Counter},
+		CachePinnedBytes:                                  {metricName: "cache_pinned_bytes", metricType: Gauge},
 		AcquireLockFailedCounter:                          {metricName: "acquire_lock_failed", metricType: Counter},
 		WorkflowContextCleared:                            {metricName: "workflow_context_cleared", metricType: Counter},
 		MutableStateSize:                                  {metricName: "mutable_state_size", metricType: Timer},
@@ -2483,6 +2609,11 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationTasksFetched:                           {metricName: "replication_tasks_fetched", metricType: Timer},
 		ReplicationTasksReturned:                          {metricName: "replication_tasks_returned", metricType: Timer},
 		ReplicationTasksAppliedLatency:                    {metricName: "replication_tasks_applied_latency", metricType: Timer},
+		ReplicationTasksBuffered:                          {metricName: "replication_tasks_buffered", metricType: Counter},
+		ReplicationTasksBufferApplied:                     {metricName: "replication_tasks_buffer_applied", metricType: Counter},
+		ReplicationTasksBufferExpired:                     {metricName: "replication_tasks_buffer_expired", metricType: Counter},
+		ReplicationTasksResent:                            {metricName: "replication_tasks_resent", metricType: Counter},
+		ReplicationTasksSkipped:                           {metricName: "replication_tasks_skipped", metricType: Counter},
 		ReplicationDLQFailed:                              {metricName: "replication_dlq_enqueue_failed", metricType: Counter},
 		ReplicationDLQMaxLevelGauge:                       {metricName: "replication_dlq_max_level", metricType: Gauge},
 		ReplicationDLQAckLevelGauge:                       {metricName: "replication_dlq_ack_level", metricType: Gauge},
@@ -2524,33 +2655,40 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ElasticsearchBulkProcessorDeadlock:         {metricName: "elasticsearch_bulk_processor_deadlock"},
 	},
 	Matching: {
-		PollSuccessPerTaskQueueCounter:            {metricName: "poll_success_per_tl", metricRollupName: "poll_success"},
-		PollTimeoutPerTaskQueueCounter:            {metricName: "poll_timeouts_per_tl", metricRollupName: "poll_timeouts"},
-		PollSuccessWithSyncPerTaskQueueCounter:    {metricName: "poll_success_sync_per_tl", metricRollupName: "poll_success_sync"},
-		LeaseRequestPerTaskQueueCounter:           {metricName: "lease_requests_per_tl", metricRollupName: "lease_requests"},
-		LeaseFailurePerTaskQueueCounter:           {metricName: "lease_failures_per_tl", metricRollupName: "lease_failures"},
-		ConditionFailedErrorPerTaskQueueCounter:   {metricName: "condition_failed_errors_per_tl", metricRollupName: "condition_failed_errors"},
-		RespondQueryTaskFailedPerTaskQueueCounter: {metricName: "respond_query_failed_per_tl", metricRollupName: "respond_query_failed"},
-		SyncThrottlePerTaskQueueCounter:           {metricName: "sync_throttle_count_per_tl", metricRollupName: "sync_throttle_count"},
-		BufferThrottlePerTaskQueueCounter:         {metricName: "buffer_throttle_count_per_tl", metricRollupName: "buffer_throttle_count"},
-		ExpiredTasksPerTaskQueueCounter:           {metricName: "tasks_expired_per_tl", metricRollupName: "tasks_expired"},
-		ForwardedPerTaskQueueCounter:              {metricName: "forwarded_per_tl"},
-		ForwardTaskCallsPerTaskQueue:              {metricName: "forward_task_calls_per_tl", metricRollupName: "forward_task_calls"},
-		ForwardTaskErrorsPerTaskQueue:             {metricName: "forward_task_errors_per_tl", metricRollupName: "forward_task_errors"},
-		ForwardQueryCallsPerTaskQueue:             {metricName: "forward_query_calls_per_tl", metricRollupName: "forward_query_calls"},
-		ForwardQueryErrorsPerTaskQueue:            {metricName: "forward_query_errors_per_tl", metricRollupName: "forward_query_errors"},
-		ForwardPollCallsPerTaskQueue:              {metricName: "forward_poll_calls_per_tl", metricRollupName: "forward_poll_calls"},
-		ForwardPollErrorsPerTaskQueue:             {metricName: "forward_poll_errors_per_tl", metricRollupName: "forward_poll_errors"},
-		SyncMatchLatencyPerTaskQueue:              {metricName: "syncmatch_latency_per_tl", metricRollupName: "syncmatch_latency", metricType: Timer},
-		AsyncMatchLatencyPerTaskQueue:             {metricName: "asyncmatch_latency_per_tl", metricRollupName: "asyncmatch_latency", metricType: Timer},
-		ForwardTaskLatencyPerTaskQueue:            {metricName: "forward_task_latency_per_tl", metricRollupName: "forward_task_latency"},
-		ForwardQueryLatencyPerTaskQueue:           {metricName: "forward_query_latency_per_tl", metricRollupName: "forward_query_latency"},
-		ForwardPollLatencyPerTaskQueue:            {metricName: "forward_poll_latency_per_tl", metricRollupName: "forward_poll_latency"},
-		LocalToLocalMatchPerTaskQueueCounter:      {metricName: "local_to_local_matches_per_tl", metricRollupName: "local_to_local_matches"},
-		LocalToRemoteMatchPerTaskQueueCounter:     {metricName: "local_to_remote_matches_per_tl", metricRollupName: "local_to_remote_matches"},
-		RemoteToLocalMatchPerTaskQueueCounter:     {metricName: "remote_to_local_matches_per_tl", metricRollupName: "remote_to_local_matches"},
-		RemoteToRemoteMatchPerTaskQueueCounter:    {metricName: "remote_to_remote_matches_per_tl", metricRollupName: "remote_to_remote_matches"},
-		TaskQueueGauge:                            {metricName: "loaded_task_queue_count", metricType: Gauge},
+		PollSuccessPerTaskQueueCounter:             {metricName: "poll_success_per_tl", metricRollupName: "poll_success"},
+		PollTimeoutPerTaskQueueCounter:             {metricName: "poll_timeouts_per_tl", metricRollupName: "poll_timeouts"},
+		PollSuccessWithSyncPerTaskQueueCounter:     {metricName: "poll_success_sync_per_tl", metricRollupName: "poll_success_sync"},
+		PollSuccessLocalWaitPerTaskQueueCounter:    {metricName: "poll_success_local_wait_per_tl", metricRollupName: "poll_success_local_wait"},
+		PollSuccessForwardedPerTaskQueueCounter:    {metricName: "poll_success_forwarded_per_tl", metricRollupName: "poll_success_forwarded"},
+		LeaseRequestPerTaskQueueCounter:            {metricName: "lease_requests_per_tl", metricRollupName: "lease_requests"},
+		LeaseFailurePerTaskQueueCounter:            {metricName: "lease_failures_per_tl", metricRollupName: "lease_failures"},
+		ConditionFailedErrorPerTaskQueueCounter:    {metricName: "condition_failed_errors_per_tl", metricRollupName: "condition_failed_errors"},
+		RespondQueryTaskFailedPerTaskQueueCounter:  {metricName: "respond_query_failed_per_tl", metricRollupName: "respond_query_failed"},
+		StickyPollerUnavailablePerTaskQueueCounter: {metricName: "sticky_poller_unavailable_per_tl", metricRollupName: "sticky_poller_unavailable"},
+		SyncThrottlePerTaskQueueCounter:            {metricName: "sync_throttle_count_per_tl", metricRollupName: "sync_throttle_count"},
+		BufferThrottlePerTaskQueueCounter:          {metricName: "buffer_throttle_count_per_tl", metricRollupName: "buffer_throttle_count"},
+		FairShareThrottlePerTaskQueueCounter:       {metricName: "fair_share_throttle_count_per_tl", metricRollupName: "fair_share_throttle_count"},
+		NamespaceFairShareStarvedCounter:           {metricName: "namespace_fair_share_starved", metricType: Counter},
+		ExpiredTasksPerTaskQueueCounter:            {metricName: "tasks_expired_per_tl", metricRollupName: "tasks_expired"},
+		BacklogTrimmedTasksPerTaskQueueCounter:     {metricName: "tasks_backlog_trimmed_per_tl", metricRollupName: "tasks_backlog_trimmed"},
+		ForwardedPerTaskQueueCounter:               {metricName: "forwarded_per_tl"},
+		ForwardTaskCallsPerTaskQueue:               {metricName: "forward_task_calls_per_tl", metricRollupName: "forward_task_calls"},
+		ForwardTaskErrorsPerTaskQueue:              {metricName: "forward_task_errors_per_tl", metricRollupName: "forward_task_errors"},
+		ForwardQueryCallsPerTaskQueue:              {metricName: "forward_query_calls_per_tl", metricRollupName: "forward_query_calls"},
+		ForwardQueryErrorsPerTaskQueue:             {metricName: "forward_query_errors_per_tl", metricRollupName: "forward_query_errors"},
+		ForwardPollCallsPerTaskQueue:               {metricName: "forward_poll_calls_per_tl", metricRollupName: "forward_poll_calls"},
+		ForwardPollErrorsPerTaskQueue:              {metricName: "forward_poll_errors_per_tl", metricRollupName: "forward_poll_errors"},
+		SyncMatchLatencyPerTaskQueue:               {metricName: "syncmatch_latency_per_tl", metricRollupName: "syncmatch_latency", metricType: Timer},
+		AsyncMatchLatencyPerTaskQueue:              {metricName: "asyncmatch_latency_per_tl", metricRollupName: "asyncmatch_latency", metricType: Timer},
+		DispatchLatencyPerTaskQueue:                {metricName: "dispatch_latency_per_tl", metricRollupName: "dispatch_latency", metricType: Timer},
+		ForwardTaskLatencyPerTaskQueue:             {metricName: "forward_task_latency_per_tl", metricRollupName: "forward_task_latency"},
+		ForwardQueryLatencyPerTaskQueue:            {metricName: "forward_query_latency_per_tl", metricRollupName: "forward_query_latency"},
+		ForwardPollLatencyPerTaskQueue:             {metricName: "forward_poll_latency_per_tl", metricRollupName: "forward_poll_latency"},
+		LocalToLocalMatchPerTaskQueueCounter:       {metricName: "local_to_local_matches_per_tl", metricRollupName: "local_to_local_matches"},
+		LocalToRemoteMatchPerTaskQueueCounter:      {metricName: "local_to_remote_matches_per_tl", metricRollupName: "local_to_remote_matches"},
+		RemoteToLocalMatchPerTaskQueueCounter:      {metricName: "remote_to_local_matches_per_tl", metricRollupName: "remote_to_local_matches"},
+		RemoteToRemoteMatchPerTaskQueueCounter:     {metricName: "remote_to_remote_matches_per_tl", metricRollupName: "remote_to_remote_matches"},
+		TaskQueueGauge:                             {metricName: "loaded_task_queue_count", metricType: Gauge},
 	},
 	Worker: {
 		ReplicatorMessages:                            {metricName: "replicator_messages"},
@@ -2598,6 +2736,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ExecutorTasksDroppedCount:                     {metricName: "executor_dropped", metricType: Counter},
 		BatcherProcessorSuccess:                       {metricName: "batcher_processor_requests", metricType: Counter},
 		BatcherProcessorFailures:                      {metricName: "batcher_processor_errors", metricType: Counter},
+		BatcherBlastRadiusGuardRefused:                {metricName: "batcher_blast_radius_guard_refused", metricType: Counter},
 		HistoryScavengerSuccessCount:                  {metricName: "scavenger_success", metricType: Counter},
 		HistoryScavengerErrorCount:                    {metricName: "scavenger_errors", metricType: Counter},
 		HistoryScavengerSkipCount:                     {metricName: "scavenger_skips", metricType: Counter},
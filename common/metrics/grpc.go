@@ -26,6 +26,7 @@ package metrics
 
 import (
 	"context"
+	"strconv"
 	"sync"
 
 	metricspb "go.temporal.io/server/api/metrics/v1"
@@ -51,6 +52,13 @@ var (
 	// If trailer key has such a suffix, value will be base64 encoded.
 	metricsTrailerKey = "metrics-trailer-bin"
 	metricsCtxKey     = metricsContextKey{}
+
+	// actionUnitsTrailerKeys maps the cost counters to the plain text trailer keys returned to API callers
+	actionUnitsTrailerKeys = map[string]string{
+		HistoryActionUnitsStateTransitions: "temporal-action-units-state-transitions",
+		HistoryActionUnitsBytesWritten:     "temporal-action-units-bytes-written",
+		HistoryActionUnitsTasksGenerated:   "temporal-action-units-tasks-generated",
+	}
 )
 
 // NewServerMetricsContextInjectorInterceptor returns grpc server interceptor that adds metrics context to golang
@@ -147,6 +155,41 @@ func NewServerMetricsTrailerPropagatorInterceptor(logger log.Logger) grpc.UnaryS
 	}
 }
 
+// NewServerActionUnitsTrailerInterceptor returns grpc server interceptor that returns the action units spent on
+// the request in gRPC trailer, so callers can account and budget their usage.
+func NewServerActionUnitsTrailerInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		// we want to return original handler response, so don't override err
+		resp, err := handler(ctx, req)
+
+		select {
+		case <-ctx.Done():
+			return resp, err
+		default:
+		}
+
+		md := metadata.MD{}
+		for counterName, trailerKey := range actionUnitsTrailerKeys {
+			if val, ok := ContextCounterGet(ctx, counterName); ok && val > 0 {
+				md.Set(trailerKey, strconv.FormatInt(val, 10))
+			}
+		}
+		if md.Len() == 0 {
+			return resp, err
+		}
+
+		if trailerErr := grpc.SetTrailer(ctx, md); trailerErr != nil {
+			logger.Error("unable to add action units to gRPC trailer", tag.Error(trailerErr))
+		}
+		return resp, err
+	}
+}
+
 // getMetricsContext extracts metrics context from golang context.
 func getMetricsContext(ctx context.Context) *metricsContext {
 	metricsCtx := ctx.Value(metricsCtxKey)
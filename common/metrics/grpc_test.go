@@ -188,6 +188,29 @@ func (s *grpcSuite) TestContextCounterAddNoMetricsContext() {
 	ContextCounterAdd(context.Background(), testCounterName, 3)
 }
 
+func (s *grpcSuite) TestActionUnitsTrailer() {
+	logger := log.NewMockLogger(s.controller)
+	ctx := AddMetricsContext(context.Background())
+	ssts := newMockServerTransportStream()
+	ctx = grpc.NewContextWithServerTransportStream(ctx, ssts)
+
+	res, err := NewServerActionUnitsTrailerInterceptor(logger)(
+		ctx, nil, nil,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			ContextCounterAdd(ctx, HistoryActionUnitsStateTransitions, 1)
+			ContextCounterAdd(ctx, HistoryActionUnitsBytesWritten, 2048)
+			return 10, nil
+		},
+	)
+
+	s.Nil(err)
+	s.Equal(10, res)
+	s.Equal(1, len(ssts.trailers))
+	s.Equal([]string{"1"}, ssts.trailers[0].Get("temporal-action-units-state-transitions"))
+	s.Equal([]string{"2048"}, ssts.trailers[0].Get("temporal-action-units-bytes-written"))
+	s.Empty(ssts.trailers[0].Get("temporal-action-units-tasks-generated"))
+}
+
 func newMockServerTransportStream() *mockServerTransportStream {
 	return &mockServerTransportStream{trailers: []*metadata.MD{}}
 }
@@ -39,6 +39,7 @@ const (
 
 	instance      = "instance"
 	namespace     = "namespace"
+	namespaceID   = "namespace_id"
 	targetCluster = "target_cluster"
 	taskQueue     = "taskqueue"
 	workflowType  = "workflowType"
@@ -101,6 +102,18 @@ func NamespaceUnknownTag() Tag {
 	return namespaceUnknownTag
 }
 
+// NamespaceIDTag returns a new namespace ID tag. If a blank namespace ID is provided then
+// this converts that to an unknown namespace ID.
+func NamespaceIDTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return &tagImpl{
+		key:   namespaceID,
+		value: value,
+	}
+}
+
 var taskQueueUnknownTag = &tagImpl{key: taskQueue, value: unknownValue}
 
 // TaskQueueUnknownTag returns a new taskqueue:unknown tag-value
@@ -177,6 +190,14 @@ func FailureTag(value string) Tag {
 	return &tagImpl{key: FailureTagName, value: value}
 }
 
+// CacheTierTag returns a new cache tier tag, pinned or default
+func CacheTierTag(pinned bool) Tag {
+	if pinned {
+		return &tagImpl{key: CacheTierTagName, value: PinnedCacheTierTagValue}
+	}
+	return &tagImpl{key: CacheTierTagName, value: DefaultCacheTierTagValue}
+}
+
 func TaskTypeTag(value string) Tag {
 	if len(value) == 0 {
 		value = unknownValue
@@ -185,6 +206,13 @@ func TaskTypeTag(value string) Tag {
 
 }
 
+func LockOperationTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return &tagImpl{key: LockOperationTagName, value: value}
+}
+
 func QueueTypeTag(value string) Tag {
 	if len(value) == 0 {
 		value = unknownValue
@@ -210,6 +238,19 @@ func AdvancedVisibilityTypeTag() Tag {
 	return advancedVisibilityTypeTag
 }
 
+// WorkerIdentityTag returns a new tag of the identity a worker completed a task as.
+func WorkerIdentityTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return &tagImpl{key: WorkerIdentityTagName, value: value}
+}
+
+// StartStageTag returns a new StartWorkflowStageLatency stage tag.
+func StartStageTag(value string) Tag {
+	return &tagImpl{key: StartStageTagName, value: value}
+}
+
 // HttpStatusTag returns a new httpStatusTag.
 func HttpStatusTag(value int) Tag {
 	return &tagImpl{key: httpStatusTagName, value: strconv.Itoa(value)}
@@ -61,6 +61,27 @@ func (d *AttrValidatorImpl) validateNamespaceConfig(config *persistencespb.Names
 	return nil
 }
 
+func (d *AttrValidatorImpl) validateDataResidency(
+	info *persistencespb.NamespaceInfo,
+	config *persistencespb.NamespaceConfig,
+	replicationConfig *persistencespb.NamespaceReplicationConfig,
+) error {
+
+	residency := NewDataResidency(info.Data)
+	for _, clusterName := range replicationConfig.Clusters {
+		if !residency.AllowsCluster(clusterName) {
+			return serviceerror.NewInvalidArgument(fmt.Sprintf("Cluster %v is not allowed by namespace data residency constraints", clusterName))
+		}
+	}
+	if config.HistoryArchivalState == enumspb.ARCHIVAL_STATE_ENABLED && !residency.AllowsArchivalURI(config.HistoryArchivalUri) {
+		return errArchivalURINotAllowed
+	}
+	if config.VisibilityArchivalState == enumspb.ARCHIVAL_STATE_ENABLED && !residency.AllowsArchivalURI(config.VisibilityArchivalUri) {
+		return errArchivalURINotAllowed
+	}
+	return nil
+}
+
 func (d *AttrValidatorImpl) validateNamespaceReplicationConfigForLocalNamespace(
 	replicationConfig *persistencespb.NamespaceReplicationConfig,
 ) error {
@@ -29,6 +29,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
 
 	persistencespb "go.temporal.io/server/api/persistence/v1"
@@ -230,3 +231,37 @@ func (s *attrValidatorSuite) TestValidateNamespaceReplicationConfigClustersDoesN
 	)
 	s.IsType(&serviceerror.InvalidArgument{}, err)
 }
+
+func (s *attrValidatorSuite) TestValidateDataResidency() {
+	info := &persistencespb.NamespaceInfo{
+		Data: map[string]string{
+			AllowedClustersDataKey:          cluster.TestCurrentClusterName,
+			AllowedArchivalLocationsDataKey: "file:///eu/",
+		},
+	}
+	replicationConfig := &persistencespb.NamespaceReplicationConfig{
+		ActiveClusterName: cluster.TestCurrentClusterName,
+		Clusters:          []string{cluster.TestCurrentClusterName},
+	}
+	config := &persistencespb.NamespaceConfig{
+		HistoryArchivalState: enumspb.ARCHIVAL_STATE_ENABLED,
+		HistoryArchivalUri:   "file:///eu/history",
+	}
+
+	err := s.validator.validateDataResidency(info, config, replicationConfig)
+	s.NoError(err)
+
+	replicationConfig.Clusters = []string{cluster.TestCurrentClusterName, cluster.TestAlternativeClusterName}
+	err = s.validator.validateDataResidency(info, config, replicationConfig)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+
+	replicationConfig.Clusters = []string{cluster.TestCurrentClusterName}
+	config.VisibilityArchivalState = enumspb.ARCHIVAL_STATE_ENABLED
+	config.VisibilityArchivalUri = "file:///us/visibility"
+	err = s.validator.validateDataResidency(info, config, replicationConfig)
+	s.Equal(errArchivalURINotAllowed, err)
+
+	config.VisibilityArchivalState = enumspb.ARCHIVAL_STATE_DISABLED
+	err = s.validator.validateDataResidency(info, config, replicationConfig)
+	s.NoError(err)
+}
@@ -36,4 +36,5 @@ var (
 	errCannotDoNamespaceFailoverAndUpdate = serviceerror.NewInvalidArgument("Cannot set active cluster to current cluster when other parameters are set.")
 	errInvalidRetentionPeriod             = serviceerror.NewInvalidArgument("A valid retention period is not set on request.")
 	errInvalidArchivalConfig              = serviceerror.NewInvalidArgument("Invalid to enable archival without specifying a uri.")
+	errArchivalURINotAllowed              = serviceerror.NewInvalidArgument("Archival uri is not allowed by namespace data residency constraints.")
 )
@@ -231,6 +231,9 @@ func (d *HandlerImpl) RegisterNamespace(
 	if err := d.namespaceAttrValidator.validateNamespaceConfig(config); err != nil {
 		return nil, err
 	}
+	if err := d.namespaceAttrValidator.validateDataResidency(info, config, replicationConfig); err != nil {
+		return nil, err
+	}
 	if isGlobalNamespace {
 		if err := d.namespaceAttrValidator.validateNamespaceReplicationConfigForGlobalNamespace(
 			replicationConfig,
@@ -519,6 +522,9 @@ func (d *HandlerImpl) UpdateNamespace(
 	if err := d.namespaceAttrValidator.validateNamespaceConfig(config); err != nil {
 		return nil, err
 	}
+	if err := d.namespaceAttrValidator.validateDataResidency(info, config, replicationConfig); err != nil {
+		return nil, err
+	}
 	if isGlobalNamespace {
 		if err := d.namespaceAttrValidator.validateNamespaceReplicationConfigForGlobalNamespace(
 			replicationConfig,
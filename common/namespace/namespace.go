@@ -173,6 +173,12 @@ func (ns *Namespace) ClusterNames() []string {
 	return out
 }
 
+// DataResidency observes the storage location constraints declared for this
+// namespace.
+func (ns *Namespace) DataResidency() DataResidency {
+	return NewDataResidency(ns.info.Data)
+}
+
 // ConfigVersion return the namespace config version
 func (ns *Namespace) ConfigVersion() int64 {
 	return ns.configVersion
@@ -131,3 +131,21 @@ func Test_GetRetentionDays(t *testing.T) {
 		})
 	}
 }
+
+func TestDataResidency(t *testing.T) {
+	base := base(t)
+
+	unrestricted := base.DataResidency()
+	require.True(t, unrestricted.AllowsCluster("foo"))
+	require.True(t, unrestricted.AllowsArchivalURI("s3://any-bucket/history"))
+
+	restricted := base.Clone(
+		namespace.WithData(namespace.AllowedClustersDataKey, "foo, baz"),
+		namespace.WithData(namespace.AllowedArchivalLocationsDataKey, "s3://eu-bucket/"),
+	).DataResidency()
+	require.True(t, restricted.AllowsCluster("foo"))
+	require.True(t, restricted.AllowsCluster("baz"))
+	require.False(t, restricted.AllowsCluster("bar"))
+	require.True(t, restricted.AllowsArchivalURI("s3://eu-bucket/history"))
+	require.False(t, restricted.AllowsArchivalURI("s3://us-bucket/history"))
+}
@@ -0,0 +1,97 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"strings"
+)
+
+const (
+	// AllowedClustersDataKey is the namespace data key holding a comma separated
+	// list of clusters the namespace may be replicated to. Operators map their
+	// regions to clusters, so this pins the namespace to a set of regions.
+	// An absent or empty value means the namespace is unrestricted.
+	AllowedClustersDataKey = "temporal.residency.allowedClusters"
+
+	// AllowedArchivalLocationsDataKey is the namespace data key holding a comma
+	// separated list of URI prefixes (e.g. "s3://eu-bucket/") that history and
+	// visibility archival URIs must start with. An absent or empty value means
+	// the namespace is unrestricted.
+	AllowedArchivalLocationsDataKey = "temporal.residency.allowedArchivalLocations"
+)
+
+type (
+	// DataResidency holds the storage location constraints declared on a
+	// namespace through its data.
+	DataResidency struct {
+		allowedClusters          map[string]struct{}
+		allowedArchivalLocations []string
+	}
+)
+
+// NewDataResidency parses the data residency constraints out of namespace data.
+func NewDataResidency(data map[string]string) DataResidency {
+	var residency DataResidency
+	if clusters := splitResidencyList(data[AllowedClustersDataKey]); len(clusters) != 0 {
+		residency.allowedClusters = make(map[string]struct{}, len(clusters))
+		for _, clusterName := range clusters {
+			residency.allowedClusters[clusterName] = struct{}{}
+		}
+	}
+	residency.allowedArchivalLocations = splitResidencyList(data[AllowedArchivalLocationsDataKey])
+	return residency
+}
+
+// AllowsCluster returns whether namespace data may be stored in the given cluster.
+func (r DataResidency) AllowsCluster(clusterName string) bool {
+	if r.allowedClusters == nil {
+		return true
+	}
+	_, ok := r.allowedClusters[clusterName]
+	return ok
+}
+
+// AllowsArchivalURI returns whether namespace data may be archived to the given URI.
+func (r DataResidency) AllowsArchivalURI(uri string) bool {
+	if len(r.allowedArchivalLocations) == 0 {
+		return true
+	}
+	for _, location := range r.allowedArchivalLocations {
+		if strings.HasPrefix(uri, location) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitResidencyList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -98,7 +98,15 @@ func (f *Factory) NewClusterMetadataStore() (p.ClusterMetadataStore, error) {
 
 // NewExecutionStore returns a new ExecutionStore.
 func (f *Factory) NewExecutionStore() (p.ExecutionStore, error) {
-	return NewExecutionStore(f.session, f.logger), nil
+	store := NewExecutionStore(f.session, f.logger)
+	if f.cfg.Consistency != nil && f.cfg.Consistency.TaskRead != nil {
+		consistency, err := gocql.ConvertFromGocqlConsistency(f.cfg.Consistency.GetTaskReadConsistency())
+		if err != nil {
+			return nil, err
+		}
+		store.TaskReadConsistency = &consistency
+	}
+	return store, nil
 }
 
 // NewQueue returns a new queue backed by cassandra
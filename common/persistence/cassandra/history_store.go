@@ -41,10 +41,10 @@ import (
 const (
 	// below are templates for history_node table
 	v2templateUpsertHistoryNode = `INSERT INTO history_node (` +
-		`tree_id, branch_id, node_id, prev_txn_id, txn_id, data, data_encoding) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?) `
+		`tree_id, branch_id, node_id, prev_txn_id, txn_id, data, data_encoding, checksum, checksum_encoding) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) `
 
-	v2templateReadHistoryNode = `SELECT node_id, prev_txn_id, txn_id, data, data_encoding FROM history_node ` +
+	v2templateReadHistoryNode = `SELECT node_id, prev_txn_id, txn_id, data, data_encoding, checksum, checksum_encoding FROM history_node ` +
 		`WHERE tree_id = ? AND branch_id = ? AND node_id >= ? AND node_id < ? `
 
 	v2templateReadHistoryNodeMetadata = `SELECT node_id, prev_txn_id, txn_id FROM history_node ` +
@@ -100,6 +100,8 @@ func (h *HistoryStore) AppendHistoryNodes(
 			node.TransactionID,
 			node.Events.Data,
 			node.Events.EncodingType.String(),
+			node.Checksum.GetData(),
+			node.Checksum.GetEncodingType().String(),
 		)
 		if err := query.Exec(); err != nil {
 			return gocql.ConvertError("AppendHistoryNodes", err)
@@ -123,6 +125,8 @@ func (h *HistoryStore) AppendHistoryNodes(
 		node.TransactionID,
 		node.Events.Data,
 		node.Events.EncodingType.String(),
+		node.Checksum.GetData(),
+		node.Checksum.GetEncodingType().String(),
 	)
 	if err := h.Session.ExecuteBatch(batch); err != nil {
 		return gocql.ConvertError("AppendHistoryNodes", err)
@@ -423,10 +427,17 @@ func convertHistoryNode(
 		data = message["data"].([]byte)
 		dataEncoding = message["data_encoding"].(string)
 	}
+	var checksum []byte
+	var checksumEncoding string
+	if _, ok := message["checksum"]; ok {
+		checksum, _ = message["checksum"].([]byte)
+		checksumEncoding, _ = message["checksum_encoding"].(string)
+	}
 	return p.InternalHistoryNode{
 		NodeID:            nodeID,
 		PrevTransactionID: prevTxnID,
 		TransactionID:     txnID,
 		Events:            p.NewDataBlob(data, dataEncoding),
+		Checksum:          p.NewDataBlob(checksum, checksumEncoding),
 	}
 }
@@ -904,7 +904,22 @@ func (d *MutableStateStore) assertNotCurrentExecution(
 func (d *MutableStateStore) DeleteWorkflowExecution(
 	request *p.DeleteWorkflowExecutionRequest,
 ) error {
-	query := d.Session.Query(templateDeleteWorkflowExecutionMutableStateQuery,
+	if request.RangeID == 0 {
+		query := d.Session.Query(templateDeleteWorkflowExecutionMutableStateQuery,
+			request.ShardID,
+			rowTypeExecution,
+			request.NamespaceID,
+			request.WorkflowID,
+			request.RunID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID)
+
+		err := query.Exec()
+		return gocql.ConvertError("DeleteWorkflowExecution", err)
+	}
+
+	batch := d.Session.NewBatch(gocql.LoggedBatch)
+	batch.Query(templateDeleteWorkflowExecutionMutableStateQuery,
 		request.ShardID,
 		rowTypeExecution,
 		request.NamespaceID,
@@ -912,15 +927,60 @@ func (d *MutableStateStore) DeleteWorkflowExecution(
 		request.RunID,
 		defaultVisibilityTimestamp,
 		rowTypeExecutionTaskID)
+	batch.Query(templateUpdateLeaseQuery,
+		request.RangeID,
+		request.ShardID,
+		rowTypeShard,
+		rowTypeShardNamespaceID,
+		rowTypeShardWorkflowID,
+		rowTypeShardRunID,
+		defaultVisibilityTimestamp,
+		rowTypeShardTaskID,
+		request.RangeID,
+	)
 
-	err := query.Exec()
-	return gocql.ConvertError("DeleteWorkflowExecution", err)
+	record := make(map[string]interface{})
+	applied, iter, err := d.Session.MapExecuteBatchCAS(batch, record)
+	if err != nil {
+		return gocql.ConvertError("DeleteWorkflowExecution", err)
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	if !applied {
+		return convertErrors(
+			record,
+			iter,
+			request.ShardID,
+			request.RangeID,
+			"",
+			nil,
+		)
+	}
+	return nil
 }
 
 func (d *MutableStateStore) DeleteCurrentWorkflowExecution(
 	request *p.DeleteCurrentWorkflowExecutionRequest,
 ) error {
-	query := d.Session.Query(templateDeleteWorkflowExecutionCurrentRowQuery,
+	if request.RangeID == 0 {
+		query := d.Session.Query(templateDeleteWorkflowExecutionCurrentRowQuery,
+			request.ShardID,
+			rowTypeExecution,
+			request.NamespaceID,
+			request.WorkflowID,
+			permanentRunID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID,
+			request.RunID)
+
+		err := query.Exec()
+		return gocql.ConvertError("DeleteWorkflowCurrentRow", err)
+	}
+
+	batch := d.Session.NewBatch(gocql.LoggedBatch)
+	batch.Query(templateDeleteWorkflowExecutionCurrentRowQuery,
 		request.ShardID,
 		rowTypeExecution,
 		request.NamespaceID,
@@ -929,9 +989,42 @@ func (d *MutableStateStore) DeleteCurrentWorkflowExecution(
 		defaultVisibilityTimestamp,
 		rowTypeExecutionTaskID,
 		request.RunID)
+	batch.Query(templateUpdateLeaseQuery,
+		request.RangeID,
+		request.ShardID,
+		rowTypeShard,
+		rowTypeShardNamespaceID,
+		rowTypeShardWorkflowID,
+		rowTypeShardRunID,
+		defaultVisibilityTimestamp,
+		rowTypeShardTaskID,
+		request.RangeID,
+	)
 
-	err := query.Exec()
-	return gocql.ConvertError("DeleteWorkflowCurrentRow", err)
+	record := make(map[string]interface{})
+	applied, iter, err := d.Session.MapExecuteBatchCAS(batch, record)
+	if err != nil {
+		return gocql.ConvertError("DeleteWorkflowCurrentRow", err)
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	if !applied {
+		err := convertErrors(
+			record,
+			iter,
+			request.ShardID,
+			request.RangeID,
+			request.RunID,
+			nil,
+		)
+		if _, ok := err.(*p.ShardOwnershipLostError); ok {
+			return err
+		}
+		// current record points to another run or is already gone, nothing to delete
+	}
+	return nil
 }
 
 func (d *MutableStateStore) GetCurrentExecution(
@@ -340,6 +340,8 @@ type (
 	MutableStateTaskStore struct {
 		Session gocql.Session
 		Logger  log.Logger
+		// TaskReadConsistency overrides the session consistency for task queue range scans when set
+		TaskReadConsistency *gocql.Consistency
 	}
 )
 
@@ -353,6 +355,15 @@ func NewMutableStateTaskStore(
 	}
 }
 
+func (d *MutableStateTaskStore) withTaskReadConsistency(
+	query gocql.Query,
+) gocql.Query {
+	if d.TaskReadConsistency == nil {
+		return query
+	}
+	return query.Consistency(*d.TaskReadConsistency)
+}
+
 func (d *MutableStateTaskStore) AddTasks(
 	request *p.InternalAddTasksRequest,
 ) error {
@@ -430,7 +441,8 @@ func (d *MutableStateTaskStore) GetTransferTasks(
 	request *p.GetTransferTasksRequest,
 ) (*p.InternalGetTransferTasksResponse, error) {
 
-	// Reading transfer tasks need to be quorum level consistent, otherwise we could lose task
+	// Reading transfer tasks need to be quorum level consistent, otherwise we could lose task,
+	// unless the operator explicitly opted into a weaker task read consistency
 	query := d.Session.Query(templateGetTransferTasksQuery,
 		request.ShardID,
 		rowTypeTransferTask,
@@ -441,7 +453,7 @@ func (d *MutableStateTaskStore) GetTransferTasks(
 		request.ReadLevel,
 		request.MaxReadLevel,
 	)
-	iter := query.PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
+	iter := d.withTaskReadConsistency(query).PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
 
 	response := &p.InternalGetTransferTasksResponse{}
 	var data []byte
@@ -537,7 +549,7 @@ func (d *MutableStateTaskStore) GetTimerTasks(
 		minTimestamp,
 		maxTimestamp,
 	)
-	iter := query.PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
+	iter := d.withTaskReadConsistency(query).PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
 
 	response := &p.InternalGetTimerTasksResponse{}
 	var data []byte
@@ -635,7 +647,7 @@ func (d *MutableStateTaskStore) GetReplicationTasks(
 		request.MaxTaskID,
 	).PageSize(request.BatchSize).PageState(request.NextPageToken)
 
-	return d.populateGetReplicationTasksResponse(query, "GetReplicationTasks")
+	return d.populateGetReplicationTasksResponse(d.withTaskReadConsistency(query), "GetReplicationTasks")
 }
 
 func (d *MutableStateTaskStore) CompleteReplicationTask(
@@ -792,7 +804,7 @@ func (d *MutableStateTaskStore) GetVisibilityTasks(
 		request.ReadLevel,
 		request.MaxReadLevel,
 	)
-	iter := query.PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
+	iter := d.withTaskReadConsistency(query).PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
 
 	response := &p.InternalGetVisibilityTasksResponse{}
 	var data []byte
@@ -886,7 +898,7 @@ func (d *MutableStateTaskStore) GetTieredStorageTasks(
 		request.MinTaskID,
 		request.MaxTaskID,
 	)
-	iter := query.PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
+	iter := d.withTaskReadConsistency(query).PageSize(request.BatchSize).PageState(request.NextPageToken).Iter()
 
 	response := &p.InternalGetTieredStorageTasksResponse{}
 	var data []byte
@@ -313,10 +313,18 @@ func (q *QueueStore) updateAckLevel(
 ) error {
 
 	// TODO: remove this once cluster_ack_level is removed from DB
-	metadataStruct, err := serialization.QueueMetadataFromBlob(metadata.Blob.Data, metadata.Blob.EncodingType.String())
+	// encrypted metadata is only kept in the data column, the ack levels would be in plain text otherwise
+	clusterAckLevels := map[string]int64{}
+	if !serialization.IsEncryptedBlob(metadata.Blob.Data) {
+		metadataStruct, err := serialization.QueueMetadataFromBlob(metadata.Blob.Data, metadata.Blob.EncodingType.String())
+		if err != nil {
+			return err
+		}
+		clusterAckLevels = metadataStruct.ClusterAckLevels
+	}
 
 	query := q.session.Query(templateUpdateQueueMetadataQuery,
-		metadataStruct.ClusterAckLevels,
+		clusterAckLevels,
 		metadata.Blob.Data,
 		metadata.Blob.EncodingType.String(),
 		metadata.Version+1, // always increase version number on update
@@ -384,8 +392,11 @@ func convertQueueMetadata(
 	metadata := &persistence.InternalQueueMetadata{
 		Version: message["version"].(int64),
 	}
+	// the data column is written together with cluster_ack_level, but only data has the ack levels of
+	// encrypted metadata
+	data, _ := message["data"].([]byte)
 	_, ok := message["cluster_ack_level"]
-	if ok {
+	if ok && len(data) == 0 {
 		clusterAckLevel := message["cluster_ack_level"].(map[string]int64)
 		// TODO: remove this once we remove cluster_ack_level from DB.
 		blob, err := serialization.QueueMetadataToBlob(&persistencespb.QueueMetadata{ClusterAckLevels: clusterAckLevel})
@@ -394,7 +405,6 @@ func convertQueueMetadata(
 		}
 		metadata.Blob = &blob
 	} else {
-		data := message["data"].([]byte)
 		encoding := message["data_encoding"].(string)
 
 		metadata.Blob = persistence.NewDataBlob(data, encoding)
@@ -33,6 +33,7 @@ import (
 	"go.temporal.io/server/common/metrics"
 	p "go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/cassandra"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/sql"
 	"go.temporal.io/server/common/quotas"
 	"go.temporal.io/server/common/resolver"
@@ -97,6 +98,7 @@ type (
 		sync.RWMutex
 		config                   *config.Persistence
 		abstractDataStoreFactory AbstractDataStoreFactory
+		serializer               serialization.Serializer
 		faultInjection           *FaultInjectionDataStoreFactory
 		metricsClient            metrics.Client
 		logger                   log.Logger
@@ -139,11 +141,12 @@ func NewFactory(
 	r resolver.ServiceResolver,
 	persistenceMaxQPS dynamicconfig.IntPropertyFn,
 	abstractDataStoreFactory AbstractDataStoreFactory,
+	encryptionProvider serialization.EncryptionProvider,
 	clusterName string,
 	metricsClient metrics.Client,
 	logger log.Logger,
 ) Factory {
-	return NewFactoryImpl(cfg, r, persistenceMaxQPS, abstractDataStoreFactory, clusterName, metricsClient, logger)
+	return NewFactoryImpl(cfg, r, persistenceMaxQPS, abstractDataStoreFactory, encryptionProvider, clusterName, metricsClient, logger)
 }
 
 // Initializes and returns FactoryImpl
@@ -152,13 +155,19 @@ func NewFactoryImpl(
 	r resolver.ServiceResolver,
 	persistenceMaxQPS dynamicconfig.IntPropertyFn,
 	abstractDataStoreFactory AbstractDataStoreFactory,
+	encryptionProvider serialization.EncryptionProvider,
 	clusterName string,
 	metricsClient metrics.Client,
 	logger log.Logger,
 ) *factoryImpl {
+	serializer := serialization.NewSerializer()
+	if encryptionProvider != nil {
+		serializer = serialization.NewEncryptingSerializer(serializer, encryptionProvider)
+	}
 	factory := &factoryImpl{
 		config:                   cfg,
 		abstractDataStoreFactory: abstractDataStoreFactory,
+		serializer:               serializer,
 		metricsClient:            metricsClient,
 		logger:                   logger,
 		clusterName:              clusterName,
@@ -189,7 +198,7 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	ds := f.datastores[storeTypeShard]
 	shardStore, err := ds.factory.NewShardStore()
-	result := p.NewShardManager(shardStore)
+	result := p.NewShardManager(shardStore, f.serializer)
 	if err != nil {
 		return nil, err
 	}
@@ -250,12 +259,12 @@ func (f *factoryImpl) NewExecutionManager() (p.ExecutionManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewExecutionManager(store, f.logger, f.config.TransactionSizeLimit)
+	result := p.NewExecutionManager(store, f.serializer, f.logger, f.config.TransactionSizeLimit, f.config.EnableHistoryEventBatchChecksum)
 	if ds.ratelimit != nil {
 		result = p.NewExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
 	if f.metricsClient != nil {
-		result = p.NewExecutionPersistenceMetricsClient(result, f.metricsClient, f.logger)
+		result = p.NewExecutionPersistenceMetricsClient(result, f.metricsClient, f.logger, f.config.NamespaceMetricsLimit)
 	}
 	return result, nil
 }
@@ -273,7 +282,7 @@ func (f *factoryImpl) NewNamespaceReplicationQueue() (p.NamespaceReplicationQueu
 		result = p.NewQueuePersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
 
-	return p.NewNamespaceReplicationQueue(result, f.clusterName, f.metricsClient, f.logger)
+	return p.NewNamespaceReplicationQueue(result, f.serializer, f.clusterName, f.metricsClient, f.logger)
 }
 
 // Close closes this factory
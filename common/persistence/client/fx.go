@@ -34,6 +34,7 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/resolver"
 )
 
@@ -66,6 +67,7 @@ func NewFactoryImplProvider(
 	r resolver.ServiceResolver,
 	persistenceMaxQPS PersistenceMaxQps,
 	abstractDataStoreFactory AbstractDataStoreFactory,
+	encryptionProvider serialization.EncryptionProvider,
 	clusterName ClusterName,
 	metricsClient metrics.Client,
 	logger log.Logger,
@@ -74,6 +76,7 @@ func NewFactoryImplProvider(
 		r,
 		dynamicconfig.IntPropertyFn(persistenceMaxQPS),
 		abstractDataStoreFactory,
+		encryptionProvider,
 		string(clusterName),
 		metricsClient,
 		logger)
@@ -159,11 +159,12 @@ type (
 		Msg string
 	}
 
-	// ShardInfoWithFailover describes a shard
-	ShardInfoWithFailover struct {
-		*persistencespb.ShardInfo
-		TransferFailoverLevels map[string]TransferFailoverLevel // uuid -> TransferFailoverLevel
-		TimerFailoverLevels    map[string]TimerFailoverLevel    // uuid -> TimerFailoverLevel
+	// HistoryEventBatchChecksumError is returned when a persisted history event batch
+	// does not match the checksum stored alongside it
+	HistoryEventBatchChecksumError struct {
+		Msg           string
+		NodeID        int64
+		TransactionID int64
 	}
 
 	// TransferFailoverLevel contains corresponding start / end level
@@ -227,8 +228,9 @@ type (
 
 	// CreateWorkflowExecutionRequest is used to write a new workflow execution
 	CreateWorkflowExecutionRequest struct {
-		ShardID int32
-		RangeID int64
+		ShardID     int32
+		RangeID     int64
+		NamespaceID string
 
 		Mode CreateWorkflowMode
 
@@ -288,8 +290,9 @@ type (
 
 	// UpdateWorkflowExecutionRequest is used to update a workflow execution
 	UpdateWorkflowExecutionRequest struct {
-		ShardID int32
-		RangeID int64
+		ShardID     int32
+		RangeID     int64
+		NamespaceID string
 
 		Mode UpdateWorkflowMode
 
@@ -307,8 +310,9 @@ type (
 
 	// ConflictResolveWorkflowExecutionRequest is used to reset workflow execution state for a single run
 	ConflictResolveWorkflowExecutionRequest struct {
-		ShardID int32
-		RangeID int64
+		ShardID     int32
+		RangeID     int64
+		NamespaceID string
 
 		Mode ConflictResolveWorkflowMode
 
@@ -406,6 +410,9 @@ type (
 		NamespaceID string
 		WorkflowID  string
 		RunID       string
+		// RangeID fences the delete, it fails with ShardOwnershipLostError if the shard range ID changed.
+		// Zero skips the check, for callers that don't own the shard.
+		RangeID int64
 	}
 
 	// DeleteCurrentWorkflowExecutionRequest is used to delete the current workflow execution
@@ -414,6 +421,9 @@ type (
 		NamespaceID string
 		WorkflowID  string
 		RunID       string
+		// RangeID fences the delete, it fails with ShardOwnershipLostError if the shard range ID changed.
+		// Zero skips the check, for callers that don't own the shard.
+		RangeID int64
 	}
 
 	// GetTransferTaskRequest is the request for GetTransferTask
@@ -838,6 +848,12 @@ type (
 		PrevTransactionID int64
 		// requested TransactionID for this write operation. For the same eventID, the node with larger TransactionID always wins
 		TransactionID int64
+		// RangeID fences the append, it fails with ShardOwnershipLostError if the shard range ID changed.
+		// Zero skips the check, for callers that don't own the shard. Only SQL stores check it, Cassandra
+		// can't condition the history node write on the shard row which lives in another table.
+		RangeID int64
+		// NamespaceID attributes the append to a namespace in persistence metrics, it may be empty
+		NamespaceID string
 	}
 
 	// AppendHistoryNodesResponse is a response to AppendHistoryNodesRequest
@@ -1252,6 +1268,10 @@ func (e *TransactionSizeLimitError) Error() string {
 	return e.Msg
 }
 
+func (e *HistoryEventBatchChecksumError) Error() string {
+	return e.Msg
+}
+
 // UnixMilliseconds returns t as a Unix time, the number of milliseconds elapsed since January 1, 1970 UTC.
 // It should be used for all CQL timestamp.
 func UnixMilliseconds(t time.Time) int64 {
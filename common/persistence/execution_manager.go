@@ -48,6 +48,7 @@ type (
 		logger                log.Logger
 		pagingTokenSerializer *jsonHistoryTokenSerializer
 		transactionSizeLimit  dynamicconfig.IntPropertyFn
+		enableChecksum        dynamicconfig.BoolPropertyFn
 	}
 )
 
@@ -56,16 +57,23 @@ var _ ExecutionManager = (*executionManagerImpl)(nil)
 // NewExecutionManager returns new ExecutionManager
 func NewExecutionManager(
 	persistence ExecutionStore,
+	serializer serialization.Serializer,
 	logger log.Logger,
 	transactionSizeLimit dynamicconfig.IntPropertyFn,
+	enableChecksum dynamicconfig.BoolPropertyFn,
 ) ExecutionManager {
 
+	if enableChecksum == nil {
+		enableChecksum = dynamicconfig.GetBoolPropertyFn(false)
+	}
+
 	return &executionManagerImpl{
-		serializer:            serialization.NewSerializer(),
+		serializer:            serializer,
 		persistence:           persistence,
 		logger:                logger,
 		pagingTokenSerializer: newJSONHistoryTokenSerializer(),
 		transactionSizeLimit:  transactionSizeLimit,
+		enableChecksum:        enableChecksum,
 	}
 }
 
@@ -0,0 +1,111 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/serialization"
+)
+
+type (
+	historyChecksumSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestHistoryChecksumSuite(t *testing.T) {
+	s := new(historyChecksumSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyChecksumSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *historyChecksumSuite) newExecutionManager(enableChecksum bool) *executionManagerImpl {
+	return NewExecutionManager(
+		nil,
+		serialization.NewSerializer(),
+		log.NewNoopLogger(),
+		dynamicconfig.GetIntPropertyFn(4*1024*1024),
+		dynamicconfig.GetBoolPropertyFn(enableChecksum),
+	).(*executionManagerImpl)
+}
+
+func (s *historyChecksumSuite) TestVerify_Match() {
+	m := s.newExecutionManager(true)
+	events := &commonpb.DataBlob{Data: []byte("history events"), EncodingType: enumspb.ENCODING_TYPE_PROTO3}
+
+	checksumBlob, err := m.generateHistoryNodeChecksum(events)
+	s.NoError(err)
+	s.NotEmpty(checksumBlob.Data)
+
+	s.NoError(m.verifyHistoryNodeChecksum(InternalHistoryNode{
+		NodeID:   1,
+		Events:   events,
+		Checksum: checksumBlob,
+	}))
+}
+
+func (s *historyChecksumSuite) TestVerify_Mismatch() {
+	m := s.newExecutionManager(true)
+	events := &commonpb.DataBlob{Data: []byte("history events"), EncodingType: enumspb.ENCODING_TYPE_PROTO3}
+
+	checksumBlob, err := m.generateHistoryNodeChecksum(events)
+	s.NoError(err)
+
+	err = m.verifyHistoryNodeChecksum(InternalHistoryNode{
+		NodeID:        5,
+		TransactionID: 123,
+		Events:        &commonpb.DataBlob{Data: []byte("history evento"), EncodingType: enumspb.ENCODING_TYPE_PROTO3},
+		Checksum:      checksumBlob,
+	})
+	s.IsType(&HistoryEventBatchChecksumError{}, err)
+	s.Equal(int64(5), err.(*HistoryEventBatchChecksumError).NodeID)
+	s.Equal(int64(123), err.(*HistoryEventBatchChecksumError).TransactionID)
+}
+
+func (s *historyChecksumSuite) TestVerify_NoChecksum() {
+	m := s.newExecutionManager(false)
+	events := &commonpb.DataBlob{Data: []byte("history events"), EncodingType: enumspb.ENCODING_TYPE_PROTO3}
+
+	checksumBlob, err := m.generateHistoryNodeChecksum(events)
+	s.NoError(err)
+	s.Empty(checksumBlob.Data)
+
+	s.NoError(m.verifyHistoryNodeChecksum(InternalHistoryNode{
+		NodeID: 1,
+		Events: events,
+	}))
+}
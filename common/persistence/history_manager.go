@@ -35,6 +35,7 @@ import (
 
 	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/checksum"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/primitives/timestamp"
@@ -46,6 +47,14 @@ const (
 
 	// TrimHistoryBranch will only dump metadata, relatively cheap
 	trimHistoryBranchPageSize = 1000
+
+	historyNodeChecksumPayloadV1 = int32(1)
+)
+
+type (
+	// historyEventsPayload adapts an already serialized history events blob to proto.Marshaler,
+	// so the checksum is computed over the exact bytes persisted
+	historyEventsPayload []byte
 )
 
 var _ ExecutionManager = (*executionManagerImpl)(nil)
@@ -382,6 +391,11 @@ func (m *executionManagerImpl) serializeAppendHistoryNodesRequest(
 		}
 	}
 
+	checksumBlob, err := m.generateHistoryNodeChecksum(blob)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &InternalAppendHistoryNodesRequest{
 		IsNewBranch: request.IsNewBranch,
 		Info:        request.Info,
@@ -389,10 +403,12 @@ func (m *executionManagerImpl) serializeAppendHistoryNodesRequest(
 		Node: InternalHistoryNode{
 			NodeID:            nodeID,
 			Events:            blob,
+			Checksum:          checksumBlob,
 			PrevTransactionID: request.PrevTransactionID,
 			TransactionID:     request.TransactionID,
 		},
 		ShardID: request.ShardID,
+		RangeID: request.RangeID,
 	}
 
 	if req.IsNewBranch {
@@ -637,6 +653,9 @@ func (m *executionManagerImpl) readRawHistoryBranchAndFilter(
 	if len(nodes) > 0 {
 		dataBlobs = make([]*commonpb.DataBlob, len(nodes))
 		for index, node := range nodes {
+			if err := m.verifyHistoryNodeChecksum(node); err != nil {
+				return nil, nil, 0, err
+			}
 			dataBlobs[index] = node.Events
 			dataSize += len(node.Events.Data)
 		}
@@ -743,6 +762,55 @@ func (m *executionManagerImpl) filterHistoryNodes(
 	return result, nil
 }
 
+func (m *executionManagerImpl) generateHistoryNodeChecksum(
+	events *commonpb.DataBlob,
+) (*commonpb.DataBlob, error) {
+
+	if !m.enableChecksum() {
+		return m.serializer.ChecksumToBlob(nil, enumspb.ENCODING_TYPE_PROTO3)
+	}
+
+	csum, err := checksum.GenerateCRC32(historyEventsPayload(events.Data), historyNodeChecksumPayloadV1)
+	if err != nil {
+		return nil, err
+	}
+	return m.serializer.ChecksumToBlob(csum, enumspb.ENCODING_TYPE_PROTO3)
+}
+
+// verifyHistoryNodeChecksum verifies the events of given node against its persisted checksum,
+// nodes written without checksum are always considered valid
+func (m *executionManagerImpl) verifyHistoryNodeChecksum(
+	node InternalHistoryNode,
+) error {
+
+	if node.Checksum == nil || node.Events == nil {
+		return nil
+	}
+	csum, err := m.serializer.ChecksumFromBlob(node.Checksum)
+	if err != nil {
+		return err
+	}
+	if csum == nil {
+		return nil
+	}
+	if csum.Version != historyNodeChecksumPayloadV1 {
+		return fmt.Errorf("invalid history node checksum payload version %v", csum.Version)
+	}
+
+	if err := checksum.Verify(historyEventsPayload(node.Events.Data), csum); err != nil {
+		m.logger.Error("Corrupted history event batch, checksum mismatch",
+			tag.WorkflowFirstEventID(node.NodeID),
+			tag.NewInt64("transaction-id", node.TransactionID),
+			tag.Error(err))
+		return &HistoryEventBatchChecksumError{
+			Msg:           fmt.Sprintf("corrupted history event batch, node ID %v transaction ID %v: %v", node.NodeID, node.TransactionID, err),
+			NodeID:        node.NodeID,
+			TransactionID: node.TransactionID,
+		}
+	}
+	return nil
+}
+
 func (m *executionManagerImpl) deserializeToken(
 	token []byte,
 	defaultLastEventID int64,
@@ -772,3 +840,8 @@ func (m *executionManagerImpl) serializeToken(
 
 	return m.pagingTokenSerializer.Serialize(pagingToken)
 }
+
+// Marshal returns the underlying serialized history events
+func (p historyEventsPayload) Marshal() ([]byte, error) {
+	return p, nil
+}
@@ -84,3 +84,29 @@ func mustConvertSerialConsistency(c SerialConsistency) gocql.SerialConsistency {
 		panic(fmt.Sprintf("Unknown gocql SerialConsistency level: %v", c))
 	}
 }
+
+// ConvertFromGocqlConsistency converts a gocql.Consistency to the corresponding Consistency level
+func ConvertFromGocqlConsistency(c gocql.Consistency) (Consistency, error) {
+	switch c {
+	case gocql.Any:
+		return Any, nil
+	case gocql.One:
+		return One, nil
+	case gocql.Two:
+		return Two, nil
+	case gocql.Three:
+		return Three, nil
+	case gocql.Quorum:
+		return Quorum, nil
+	case gocql.All:
+		return All, nil
+	case gocql.LocalQuorum:
+		return LocalQuorum, nil
+	case gocql.EachQuorum:
+		return EachQuorum, nil
+	case gocql.LocalOne:
+		return LocalOne, nil
+	default:
+		return 0, fmt.Errorf("unknown gocql Consistency level: %v", c)
+	}
+}
@@ -468,6 +468,8 @@ type (
 		PrevTransactionID int64
 		// The events to be appended
 		Events *commonpb.DataBlob
+		// Checksum of the events blob, optional
+		Checksum *commonpb.DataBlob // persistencespb.Checksum
 	}
 
 	// InternalAppendHistoryNodesRequest is used to append a batch of history nodes
@@ -484,6 +486,8 @@ type (
 		Node InternalHistoryNode
 		// Used in sharded data stores to identify which shard to use
 		ShardID int32
+		// Fences the append if not zero, only checked by SQL stores
+		RangeID int64
 	}
 
 	// InternalGetWorkflowExecutionResponse is the response to GetworkflowExecution for Persistence Interface
@@ -25,9 +25,12 @@
 package persistence
 
 import (
+	"sync"
+
 	commonpb "go.temporal.io/api/common/v1"
 	"go.temporal.io/api/serviceerror"
 
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
@@ -46,7 +49,16 @@ type (
 
 	executionPersistenceClient struct {
 		metricEmitter
-		persistence ExecutionManager
+		persistence   ExecutionManager
+		namespaceTags *namespaceTagLimiter
+	}
+
+	// namespaceTagLimiter guards the cardinality of namespace tagged persistence metrics. The first
+	// namespaces seen, up to the limit, are tagged with their ID, all others share an overflow value.
+	namespaceTagLimiter struct {
+		sync.RWMutex
+		limit      dynamicconfig.IntPropertyFn
+		namespaces map[string]struct{}
 	}
 
 	taskPersistenceClient struct {
@@ -70,6 +82,10 @@ type (
 	}
 )
 
+const (
+	namespaceTagOverflowValue = "_other_"
+)
+
 var _ ShardManager = (*shardPersistenceClient)(nil)
 var _ ExecutionManager = (*executionPersistenceClient)(nil)
 var _ TaskManager = (*taskPersistenceClient)(nil)
@@ -88,14 +104,21 @@ func NewShardPersistenceMetricsClient(persistence ShardManager, metricClient met
 	}
 }
 
-// NewExecutionPersistenceMetricsClient creates a client to manage executions
-func NewExecutionPersistenceMetricsClient(persistence ExecutionManager, metricClient metrics.Client, logger log.Logger) ExecutionManager {
+// NewExecutionPersistenceMetricsClient creates a client to manage executions. Calls carrying a namespace ID
+// additionally emit per namespace metrics, for at most namespaceMetricsLimit distinct namespaces.
+func NewExecutionPersistenceMetricsClient(
+	persistence ExecutionManager,
+	metricClient metrics.Client,
+	logger log.Logger,
+	namespaceMetricsLimit dynamicconfig.IntPropertyFn,
+) ExecutionManager {
 	return &executionPersistenceClient{
 		metricEmitter: metricEmitter{
 			metricClient: metricClient,
 			logger:       logger,
 		},
-		persistence: persistence,
+		persistence:   persistence,
+		namespaceTags: newNamespaceTagLimiter(namespaceMetricsLimit),
 	}
 }
 
@@ -186,13 +209,18 @@ func (p *executionPersistenceClient) GetName() string {
 
 func (p *executionPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceCreateWorkflowExecutionScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	response, err := p.persistence.CreateWorkflowExecution(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateWorkflowExecutionScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return response, err
@@ -200,13 +228,18 @@ func (p *executionPersistenceClient) CreateWorkflowExecution(request *CreateWork
 
 func (p *executionPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceGetWorkflowExecutionScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	response, err := p.persistence.GetWorkflowExecution(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetWorkflowExecutionScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return response, err
@@ -214,13 +247,18 @@ func (p *executionPersistenceClient) GetWorkflowExecution(request *GetWorkflowEx
 
 func (p *executionPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceUpdateWorkflowExecutionScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	resp, err := p.persistence.UpdateWorkflowExecution(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateWorkflowExecutionScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return resp, err
@@ -228,13 +266,18 @@ func (p *executionPersistenceClient) UpdateWorkflowExecution(request *UpdateWork
 
 func (p *executionPersistenceClient) ConflictResolveWorkflowExecution(request *ConflictResolveWorkflowExecutionRequest) (*ConflictResolveWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceConflictResolveWorkflowExecutionScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceConflictResolveWorkflowExecutionScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceConflictResolveWorkflowExecutionScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	response, err := p.persistence.ConflictResolveWorkflowExecution(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceConflictResolveWorkflowExecutionScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return response, err
@@ -242,13 +285,18 @@ func (p *executionPersistenceClient) ConflictResolveWorkflowExecution(request *C
 
 func (p *executionPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceDeleteWorkflowExecutionScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	err := p.persistence.DeleteWorkflowExecution(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteWorkflowExecutionScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return err
@@ -256,13 +304,18 @@ func (p *executionPersistenceClient) DeleteWorkflowExecution(request *DeleteWork
 
 func (p *executionPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	err := p.persistence.DeleteCurrentWorkflowExecution(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return err
@@ -270,13 +323,18 @@ func (p *executionPersistenceClient) DeleteCurrentWorkflowExecution(request *Del
 
 func (p *executionPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceGetCurrentExecutionScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	response, err := p.persistence.GetCurrentExecution(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetCurrentExecutionScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return response, err
@@ -298,13 +356,18 @@ func (p *executionPersistenceClient) ListConcreteExecutions(request *ListConcret
 
 func (p *executionPersistenceClient) AddTasks(request *AddTasksRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceAddTasksScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceAddTasksScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 
 	sw := p.metricClient.StartTimer(metrics.PersistenceAddTasksScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	err := p.persistence.AddTasks(request)
 	sw.Stop()
+	nsSw.Stop()
 
 	if err != nil {
-		p.updateErrorMetric(metrics.PersistenceGetTransferTaskScope, err)
+		p.updateErrorMetric(metrics.PersistenceAddTasksScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 
 	return err
@@ -658,6 +721,14 @@ func (p *executionPersistenceClient) Close() {
 	p.persistence.Close()
 }
 
+func (p *executionPersistenceClient) namespaceScope(scope int, namespaceID string) metrics.Scope {
+	tag, ok := p.namespaceTags.tag(namespaceID)
+	if !ok {
+		return metrics.NoopScope(metrics.Common)
+	}
+	return p.metricClient.Scope(scope, tag)
+}
+
 func (p *taskPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
@@ -878,11 +949,16 @@ func (p *metadataPersistenceClient) Close() {
 // AppendHistoryNodes add a node to history node table
 func (p *executionPersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceAppendHistoryNodesScope, metrics.PersistenceRequests)
+	nsScope := p.namespaceScope(metrics.PersistenceAppendHistoryNodesScope, request.NamespaceID)
+	nsScope.IncCounter(metrics.PersistenceRequestsPerNamespace)
 	sw := p.metricClient.StartTimer(metrics.PersistenceAppendHistoryNodesScope, metrics.PersistenceLatency)
+	nsSw := nsScope.StartTimer(metrics.PersistenceLatencyPerNamespace)
 	resp, err := p.persistence.AppendHistoryNodes(request)
 	sw.Stop()
+	nsSw.Stop()
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceAppendHistoryNodesScope, err)
+		nsScope.IncCounter(metrics.PersistenceFailuresPerNamespace)
 	}
 	return resp, err
 }
@@ -1310,6 +1386,10 @@ func (p *metricEmitter) updateErrorMetric(scope int, err error) {
 	case *TimeoutError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrTimeoutCounter)
 		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	case *HistoryEventBatchChecksumError:
+		p.logger.Error("History event batch checksum mismatch.", tag.Error(err), tag.MetricScope(scope))
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrHistoryChecksumMismatchCounter)
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
 
 	case *serviceerror.InvalidArgument:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrBadRequestCounter)
@@ -1331,3 +1411,43 @@ func (p *metricEmitter) updateErrorMetric(scope int, err error) {
 		}
 	}
 }
+
+func newNamespaceTagLimiter(limit dynamicconfig.IntPropertyFn) *namespaceTagLimiter {
+	return &namespaceTagLimiter{
+		limit:      limit,
+		namespaces: make(map[string]struct{}),
+	}
+}
+
+// tag returns the namespace tag to use for the given namespace ID, or false if
+// per namespace metrics are disabled.
+func (l *namespaceTagLimiter) tag(namespaceID string) (metrics.Tag, bool) {
+	if l.limit == nil {
+		return nil, false
+	}
+	limit := l.limit()
+	if limit <= 0 {
+		return nil, false
+	}
+
+	l.RLock()
+	_, ok := l.namespaces[namespaceID]
+	size := len(l.namespaces)
+	l.RUnlock()
+	if ok {
+		return metrics.NamespaceIDTag(namespaceID), true
+	}
+	if size >= limit {
+		return metrics.NamespaceIDTag(namespaceTagOverflowValue), true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := l.namespaces[namespaceID]; !ok {
+		if len(l.namespaces) >= limit {
+			return metrics.NamespaceIDTag(namespaceTagOverflowValue), true
+		}
+		l.namespaces[namespaceID] = struct{}{}
+	}
+	return metrics.NamespaceIDTag(namespaceID), true
+}
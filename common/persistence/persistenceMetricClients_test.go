@@ -0,0 +1,76 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"go.temporal.io/server/common/dynamicconfig"
+)
+
+type (
+	namespaceTagLimiterSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestNamespaceTagLimiterSuite(t *testing.T) {
+	s := new(namespaceTagLimiterSuite)
+	suite.Run(t, s)
+}
+
+func (s *namespaceTagLimiterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *namespaceTagLimiterSuite) TestTag_Disabled() {
+	_, ok := newNamespaceTagLimiter(nil).tag("ns-1")
+	s.False(ok)
+
+	_, ok = newNamespaceTagLimiter(dynamicconfig.GetIntPropertyFn(0)).tag("ns-1")
+	s.False(ok)
+}
+
+func (s *namespaceTagLimiterSuite) TestTag_Limit() {
+	limiter := newNamespaceTagLimiter(dynamicconfig.GetIntPropertyFn(2))
+
+	for _, namespaceID := range []string{"ns-1", "ns-2", "ns-1"} {
+		tag, ok := limiter.tag(namespaceID)
+		s.True(ok)
+		s.Equal(namespaceID, tag.Value())
+	}
+
+	tag, ok := limiter.tag("ns-3")
+	s.True(ok)
+	s.Equal(namespaceTagOverflowValue, tag.Value())
+
+	tag, ok = limiter.tag("ns-2")
+	s.True(ok)
+	s.Equal("ns-2", tag.Value())
+}
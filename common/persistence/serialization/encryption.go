@@ -0,0 +1,297 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package serialization
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/service/history/tasks"
+)
+
+type (
+	// EncryptionProvider encrypts the shard info, history task and queue metadata blobs written by the serializer
+	// returned by NewEncryptingSerializer. Every blob is tagged with the ID of the key it was encrypted with, so keys can be
+	// rotated while blobs encrypted with previous keys are still read.
+	EncryptionProvider interface {
+		// CurrentKeyID returns the ID of the key new blobs are encrypted with
+		CurrentKeyID() string
+		Encrypt(keyID string, plaintext []byte) ([]byte, error)
+		Decrypt(keyID string, ciphertext []byte) ([]byte, error)
+	}
+
+	encryptingSerializer struct {
+		Serializer
+		provider EncryptionProvider
+	}
+
+	aesGCMEncryptionProvider struct {
+		currentKeyID string
+		ciphers      map[string]cipher.AEAD
+	}
+)
+
+// encryptedBlobPrefix starts the envelope of an encrypted blob, followed by the length of the key ID, the key ID
+// and the ciphertext. Protobuf encoded data never starts with a 0 byte, so blobs persisted before the encryption
+// was enabled are still read as they are.
+var encryptedBlobPrefix = []byte{0, 't', 'e', 'n', 'c', 1}
+
+// NewEncryptingSerializer returns a Serializer which encrypts shard info, history task and queue metadata blobs
+// with provider.
+func NewEncryptingSerializer(serializer Serializer, provider EncryptionProvider) Serializer {
+	return &encryptingSerializer{
+		Serializer: serializer,
+		provider:   provider,
+	}
+}
+
+func (s *encryptingSerializer) ShardInfoToBlob(info *persistencespb.ShardInfo, encodingType enumspb.EncodingType) (*commonpb.DataBlob, error) {
+	blob, err := s.Serializer.ShardInfoToBlob(info, encodingType)
+	if err != nil {
+		return nil, err
+	}
+	return s.encrypt(blob)
+}
+
+func (s *encryptingSerializer) ShardInfoFromBlob(data *commonpb.DataBlob, clusterName string) (*persistencespb.ShardInfo, error) {
+	blob, err := s.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.Serializer.ShardInfoFromBlob(blob, clusterName)
+}
+
+func (s *encryptingSerializer) QueueMetadataToBlob(metadata *persistencespb.QueueMetadata, encodingType enumspb.EncodingType) (*commonpb.DataBlob, error) {
+	blob, err := s.Serializer.QueueMetadataToBlob(metadata, encodingType)
+	if err != nil {
+		return nil, err
+	}
+	return s.encrypt(blob)
+}
+
+func (s *encryptingSerializer) QueueMetadataFromBlob(data *commonpb.DataBlob) (*persistencespb.QueueMetadata, error) {
+	blob, err := s.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.Serializer.QueueMetadataFromBlob(blob)
+}
+
+func (s *encryptingSerializer) SerializeTransferTasks(taskSlice []tasks.Task) (map[tasks.Key]commonpb.DataBlob, error) {
+	return s.encryptTasks(s.Serializer.SerializeTransferTasks(taskSlice))
+}
+
+func (s *encryptingSerializer) DeserializeTransferTasks(blobSlice []commonpb.DataBlob) ([]tasks.Task, error) {
+	blobSlice, err := s.decryptTasks(blobSlice)
+	if err != nil {
+		return nil, err
+	}
+	return s.Serializer.DeserializeTransferTasks(blobSlice)
+}
+
+func (s *encryptingSerializer) SerializeTimerTasks(taskSlice []tasks.Task) (map[tasks.Key]commonpb.DataBlob, error) {
+	return s.encryptTasks(s.Serializer.SerializeTimerTasks(taskSlice))
+}
+
+func (s *encryptingSerializer) DeserializeTimerTasks(blobSlice []commonpb.DataBlob) ([]tasks.Task, error) {
+	blobSlice, err := s.decryptTasks(blobSlice)
+	if err != nil {
+		return nil, err
+	}
+	return s.Serializer.DeserializeTimerTasks(blobSlice)
+}
+
+func (s *encryptingSerializer) SerializeVisibilityTasks(taskSlice []tasks.Task) (map[tasks.Key]commonpb.DataBlob, error) {
+	return s.encryptTasks(s.Serializer.SerializeVisibilityTasks(taskSlice))
+}
+
+func (s *encryptingSerializer) DeserializeVisibilityTasks(blobSlice []commonpb.DataBlob) ([]tasks.Task, error) {
+	blobSlice, err := s.decryptTasks(blobSlice)
+	if err != nil {
+		return nil, err
+	}
+	return s.Serializer.DeserializeVisibilityTasks(blobSlice)
+}
+
+func (s *encryptingSerializer) SerializeTieredStorageTasks(taskSlice []tasks.Task) (map[tasks.Key]commonpb.DataBlob, error) {
+	return s.encryptTasks(s.Serializer.SerializeTieredStorageTasks(taskSlice))
+}
+
+func (s *encryptingSerializer) DeserializeTieredStorageTasks(blobSlice []commonpb.DataBlob) ([]tasks.Task, error) {
+	blobSlice, err := s.decryptTasks(blobSlice)
+	if err != nil {
+		return nil, err
+	}
+	return s.Serializer.DeserializeTieredStorageTasks(blobSlice)
+}
+
+func (s *encryptingSerializer) SerializeReplicationTasks(taskSlice []tasks.Task) (map[tasks.Key]commonpb.DataBlob, error) {
+	return s.encryptTasks(s.Serializer.SerializeReplicationTasks(taskSlice))
+}
+
+func (s *encryptingSerializer) DeserializeReplicationTasks(blobSlice []commonpb.DataBlob) ([]tasks.Task, error) {
+	blobSlice, err := s.decryptTasks(blobSlice)
+	if err != nil {
+		return nil, err
+	}
+	return s.Serializer.DeserializeReplicationTasks(blobSlice)
+}
+
+func (s *encryptingSerializer) encryptTasks(
+	blobs map[tasks.Key]commonpb.DataBlob,
+	err error,
+) (map[tasks.Key]commonpb.DataBlob, error) {
+	if err != nil {
+		return nil, err
+	}
+	for key, blob := range blobs {
+		blob := blob
+		encrypted, err := s.encrypt(&blob)
+		if err != nil {
+			return nil, err
+		}
+		blobs[key] = *encrypted
+	}
+	return blobs, nil
+}
+
+func (s *encryptingSerializer) decryptTasks(blobSlice []commonpb.DataBlob) ([]commonpb.DataBlob, error) {
+	result := make([]commonpb.DataBlob, len(blobSlice))
+	for i := range blobSlice {
+		decrypted, err := s.decrypt(&blobSlice[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *decrypted
+	}
+	return result, nil
+}
+
+func (s *encryptingSerializer) encrypt(blob *commonpb.DataBlob) (*commonpb.DataBlob, error) {
+	keyID := s.provider.CurrentKeyID()
+	if len(keyID) > 255 {
+		return nil, NewSerializationError(fmt.Sprintf("encryption key ID is longer than 255 bytes: %v", keyID))
+	}
+	ciphertext, err := s.provider.Encrypt(keyID, blob.Data)
+	if err != nil {
+		return nil, NewSerializationError(fmt.Sprintf("unable to encrypt blob with key %v: %v", keyID, err))
+	}
+
+	data := make([]byte, 0, len(encryptedBlobPrefix)+1+len(keyID)+len(ciphertext))
+	data = append(data, encryptedBlobPrefix...)
+	data = append(data, byte(len(keyID)))
+	data = append(data, keyID...)
+	data = append(data, ciphertext...)
+	return &commonpb.DataBlob{
+		EncodingType: blob.EncodingType,
+		Data:         data,
+	}, nil
+}
+
+func (s *encryptingSerializer) decrypt(blob *commonpb.DataBlob) (*commonpb.DataBlob, error) {
+	if blob == nil || !IsEncryptedBlob(blob.Data) {
+		return blob, nil
+	}
+
+	data := blob.Data[len(encryptedBlobPrefix):]
+	if len(data) == 0 || len(data) < 1+int(data[0]) {
+		return nil, NewDeserializationError("encrypted blob is truncated")
+	}
+	keyID := string(data[1 : 1+int(data[0])])
+	plaintext, err := s.provider.Decrypt(keyID, data[1+int(data[0]):])
+	if err != nil {
+		return nil, NewDeserializationError(fmt.Sprintf("unable to decrypt blob with key %v: %v", keyID, err))
+	}
+	return &commonpb.DataBlob{
+		EncodingType: blob.EncodingType,
+		Data:         plaintext,
+	}, nil
+}
+
+// IsEncryptedBlob returns whether data was encrypted by the serializer returned by NewEncryptingSerializer
+func IsEncryptedBlob(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedBlobPrefix)
+}
+
+// NewAESGCMEncryptionProvider returns an EncryptionProvider which encrypts blobs with AES-GCM using the key of
+// currentKeyID, and decrypts blobs with any of keys. Keys must be 16, 24 or 32 bytes long. To rotate keys, add the
+// new key and make it current, and remove the previous key once no blob encrypted with it is persisted anymore.
+func NewAESGCMEncryptionProvider(keys map[string][]byte, currentKeyID string) (EncryptionProvider, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("current encryption key %v not found", currentKeyID)
+	}
+	ciphers := make(map[string]cipher.AEAD, len(keys))
+	for keyID, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %v: %w", keyID, err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %v: %w", keyID, err)
+		}
+		ciphers[keyID] = gcm
+	}
+	return &aesGCMEncryptionProvider{
+		currentKeyID: currentKeyID,
+		ciphers:      ciphers,
+	}, nil
+}
+
+func (p *aesGCMEncryptionProvider) CurrentKeyID() string {
+	return p.currentKeyID
+}
+
+func (p *aesGCMEncryptionProvider) Encrypt(keyID string, plaintext []byte) ([]byte, error) {
+	gcm, ok := p.ciphers[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %v", keyID)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (p *aesGCMEncryptionProvider) Decrypt(keyID string, ciphertext []byte) ([]byte, error) {
+	gcm, ok := p.ciphers[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %v", keyID)
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is shorter than the nonce")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
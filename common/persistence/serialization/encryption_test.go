@@ -0,0 +1,120 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package serialization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/service/history/tasks"
+)
+
+func newTestEncryptionProvider(t *testing.T, currentKeyID string) EncryptionProvider {
+	provider, err := NewAESGCMEncryptionProvider(map[string][]byte{
+		"key-1": []byte("0123456789abcdef"),
+		"key-2": []byte("fedcba9876543210"),
+	}, currentKeyID)
+	require.NoError(t, err)
+	return provider
+}
+
+func TestEncryptingSerializer_ShardInfo(t *testing.T) {
+	plain := NewSerializer()
+	serializer := NewEncryptingSerializer(plain, newTestEncryptionProvider(t, "key-1"))
+	info := &persistencespb.ShardInfo{ShardId: 1, RangeId: 10, Owner: "host"}
+
+	blob, err := serializer.ShardInfoToBlob(info, enumspb.ENCODING_TYPE_PROTO3)
+	require.NoError(t, err)
+	_, err = plain.ShardInfoFromBlob(blob, "cluster")
+	require.Error(t, err)
+
+	decoded, err := serializer.ShardInfoFromBlob(blob, "cluster")
+	require.NoError(t, err)
+	require.Equal(t, info.Owner, decoded.Owner)
+	require.Equal(t, info.RangeId, decoded.RangeId)
+
+	// blobs persisted before the encryption was enabled are still read
+	plainBlob, err := plain.ShardInfoToBlob(info, enumspb.ENCODING_TYPE_PROTO3)
+	require.NoError(t, err)
+	decoded, err = serializer.ShardInfoFromBlob(plainBlob, "cluster")
+	require.NoError(t, err)
+	require.Equal(t, info.RangeId, decoded.RangeId)
+}
+
+func TestEncryptingSerializer_KeyRotation(t *testing.T) {
+	oldSerializer := NewEncryptingSerializer(NewSerializer(), newTestEncryptionProvider(t, "key-1"))
+	newSerializer := NewEncryptingSerializer(NewSerializer(), newTestEncryptionProvider(t, "key-2"))
+	task := &tasks.ActivityTask{
+		WorkflowKey:         definition.NewWorkflowKey("namespace-id", "workflow-id", "run-id"),
+		TaskID:              123,
+		TargetNamespaceID:   "namespace-id",
+		TaskQueue:           "task-queue",
+		ScheduleID:          5,
+		VisibilityTimestamp: time.Unix(0, 0).UTC(),
+	}
+
+	blobs, err := oldSerializer.SerializeTransferTasks([]tasks.Task{task})
+	require.NoError(t, err)
+	blobSlice := make([]commonpb.DataBlob, 0, len(blobs))
+	for _, blob := range blobs {
+		blobSlice = append(blobSlice, blob)
+	}
+
+	decoded, err := newSerializer.DeserializeTransferTasks(blobSlice)
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	require.Equal(t, task.TaskID, decoded[0].GetTaskID())
+
+	withoutOldKey, err := NewAESGCMEncryptionProvider(map[string][]byte{"key-2": []byte("fedcba9876543210")}, "key-2")
+	require.NoError(t, err)
+	_, err = NewEncryptingSerializer(NewSerializer(), withoutOldKey).DeserializeTransferTasks(blobSlice)
+	require.Error(t, err)
+}
+
+func TestEncryptingSerializer_QueueMetadata(t *testing.T) {
+	plain := NewSerializer()
+	serializer := NewEncryptingSerializer(plain, newTestEncryptionProvider(t, "key-1"))
+	metadata := &persistencespb.QueueMetadata{ClusterAckLevels: map[string]int64{"cluster": 10}}
+
+	blob, err := serializer.QueueMetadataToBlob(metadata, enumspb.ENCODING_TYPE_PROTO3)
+	require.NoError(t, err)
+	require.True(t, IsEncryptedBlob(blob.Data))
+	_, err = plain.QueueMetadataFromBlob(blob)
+	require.Error(t, err)
+
+	decoded, err := serializer.QueueMetadataFromBlob(blob)
+	require.NoError(t, err)
+	require.Equal(t, metadata.ClusterAckLevels, decoded.ClusterAckLevels)
+
+	plainBlob, err := plain.QueueMetadataToBlob(metadata, enumspb.ENCODING_TYPE_PROTO3)
+	require.NoError(t, err)
+	require.False(t, IsEncryptedBlob(plainBlob.Data))
+}
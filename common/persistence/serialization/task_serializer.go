@@ -137,6 +137,8 @@ func (s *TaskSerializer) SerializeTimerTasks(
 			timerTask = s.TimerWorkflowRunToProto(task)
 		case *tasks.DeleteHistoryEventTask:
 			timerTask = s.TimerWorkflowCleanupTaskToProto(task)
+		case *tasks.DeleteExecutionVisibilityTimerTask:
+			timerTask = s.TimerDeleteVisibilityTaskToProto(task)
 		default:
 			return nil, serviceerror.NewInternal(fmt.Sprintf("Unknown timer task type: %v", task))
 		}
@@ -175,6 +177,10 @@ func (s *TaskSerializer) DeserializeTimerTasks(
 			timer = s.timerWorkflowRunFromProto(timerTask)
 		case enumsspb.TASK_TYPE_DELETE_HISTORY_EVENT:
 			timer = s.timerWorkflowCleanupTaskFromProto(timerTask)
+		case enumsspb.TASK_TYPE_VISIBILITY_DELETE_EXECUTION:
+			// in the timer queue this type is a retained visibility deletion, see VisibilityRecordRetention for
+			// the rollback constraint, until the task type enum gets a dedicated value
+			timer = s.timerDeleteVisibilityTaskFromProto(timerTask)
 		default:
 			return nil, serviceerror.NewInternal(fmt.Sprintf("Unknown timer task type: %v", timerTask.TaskType))
 		}
@@ -835,6 +841,39 @@ func (s *TaskSerializer) timerWorkflowCleanupTaskFromProto(
 	}
 }
 
+func (s *TaskSerializer) TimerDeleteVisibilityTaskToProto(
+	deleteVisibilityTimer *tasks.DeleteExecutionVisibilityTimerTask,
+) *persistencespb.TimerTaskInfo {
+	return &persistencespb.TimerTaskInfo{
+		NamespaceId:         deleteVisibilityTimer.WorkflowKey.NamespaceID,
+		WorkflowId:          deleteVisibilityTimer.WorkflowKey.WorkflowID,
+		RunId:               deleteVisibilityTimer.WorkflowKey.RunID,
+		TaskType:            enumsspb.TASK_TYPE_VISIBILITY_DELETE_EXECUTION,
+		TimeoutType:         enumspb.TIMEOUT_TYPE_UNSPECIFIED,
+		WorkflowBackoffType: enumsspb.WORKFLOW_BACKOFF_TYPE_UNSPECIFIED,
+		Version:             deleteVisibilityTimer.Version,
+		ScheduleAttempt:     0,
+		EventId:             0,
+		TaskId:              deleteVisibilityTimer.TaskID,
+		VisibilityTime:      &deleteVisibilityTimer.VisibilityTimestamp,
+	}
+}
+
+func (s *TaskSerializer) timerDeleteVisibilityTaskFromProto(
+	deleteVisibilityTimer *persistencespb.TimerTaskInfo,
+) *tasks.DeleteExecutionVisibilityTimerTask {
+	return &tasks.DeleteExecutionVisibilityTimerTask{
+		WorkflowKey: definition.NewWorkflowKey(
+			deleteVisibilityTimer.NamespaceId,
+			deleteVisibilityTimer.WorkflowId,
+			deleteVisibilityTimer.RunId,
+		),
+		VisibilityTimestamp: *deleteVisibilityTimer.VisibilityTime,
+		TaskID:              deleteVisibilityTimer.TaskId,
+		Version:             deleteVisibilityTimer.Version,
+	}
+}
+
 func (s *TaskSerializer) VisibilityStartTaskToProto(
 	startVisibilityTask *tasks.StartExecutionVisibilityTask,
 ) *persistencespb.VisibilityTaskInfo {
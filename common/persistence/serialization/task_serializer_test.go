@@ -259,6 +259,17 @@ func (s *taskSerializerSuite) TestTimerWorkflowCleanupTask() {
 	s.assertEqualTimerTasks(workflowCleanupTimer)
 }
 
+func (s *taskSerializerSuite) TestTimerDeleteVisibilityTask() {
+	deleteVisibilityTimer := &tasks.DeleteExecutionVisibilityTimerTask{
+		WorkflowKey:         s.workflowKey,
+		VisibilityTimestamp: time.Unix(0, rand.Int63()).UTC(),
+		TaskID:              rand.Int63(),
+		Version:             rand.Int63(),
+	}
+
+	s.assertEqualTimerTasks(deleteVisibilityTimer)
+}
+
 func (s *taskSerializerSuite) TestVisibilityStartTask() {
 	visibilityStart := &tasks.StartExecutionVisibilityTask{
 		WorkflowKey:         s.workflowKey,
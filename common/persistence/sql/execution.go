@@ -630,12 +630,19 @@ func (m *sqlExecutionStore) DeleteWorkflowExecution(
 	defer cancel()
 	namespaceID := primitives.MustParseUUID(request.NamespaceID)
 	runID := primitives.MustParseUUID(request.RunID)
-	_, err := m.Db.DeleteFromExecutions(ctx, sqlplugin.ExecutionsFilter{
+	filter := sqlplugin.ExecutionsFilter{
 		ShardID:     request.ShardID,
 		NamespaceID: namespaceID,
 		WorkflowID:  request.WorkflowID,
 		RunID:       runID,
-	})
+	}
+	if request.RangeID != 0 {
+		return m.txExecuteShardLocked(ctx, "DeleteWorkflowExecution", request.ShardID, request.RangeID, func(tx sqlplugin.Tx) error {
+			_, err := tx.DeleteFromExecutions(ctx, filter)
+			return err
+		})
+	}
+	_, err := m.Db.DeleteFromExecutions(ctx, filter)
 	return err
 }
 
@@ -650,12 +657,19 @@ func (m *sqlExecutionStore) DeleteCurrentWorkflowExecution(
 	defer cancel()
 	namespaceID := primitives.MustParseUUID(request.NamespaceID)
 	runID := primitives.MustParseUUID(request.RunID)
-	_, err := m.Db.DeleteFromCurrentExecutions(ctx, sqlplugin.CurrentExecutionsFilter{
+	filter := sqlplugin.CurrentExecutionsFilter{
 		ShardID:     request.ShardID,
 		NamespaceID: namespaceID,
 		WorkflowID:  request.WorkflowID,
 		RunID:       runID,
-	})
+	}
+	if request.RangeID != 0 {
+		return m.txExecuteShardLocked(ctx, "DeleteCurrentWorkflowExecution", request.ShardID, request.RangeID, func(tx sqlplugin.Tx) error {
+			_, err := tx.DeleteFromCurrentExecutions(ctx, filter)
+			return err
+		})
+	}
+	_, err := m.Db.DeleteFromCurrentExecutions(ctx, filter)
 	return err
 }
 
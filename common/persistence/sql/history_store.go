@@ -61,14 +61,26 @@ func (m *sqlExecutionStore) AppendHistoryNodes(
 	}
 
 	nodeRow := &sqlplugin.HistoryNodeRow{
-		TreeID:       treeIDBytes,
-		BranchID:     branchIDBytes,
-		NodeID:       node.NodeID,
-		PrevTxnID:    node.PrevTransactionID,
-		TxnID:        node.TransactionID,
-		Data:         node.Events.Data,
-		DataEncoding: node.Events.EncodingType.String(),
-		ShardID:      request.ShardID,
+		TreeID:           treeIDBytes,
+		BranchID:         branchIDBytes,
+		NodeID:           node.NodeID,
+		PrevTxnID:        node.PrevTransactionID,
+		TxnID:            node.TransactionID,
+		Data:             node.Events.Data,
+		DataEncoding:     node.Events.EncodingType.String(),
+		Checksum:         node.Checksum.GetData(),
+		ChecksumEncoding: node.Checksum.GetEncodingType().String(),
+		ShardID:          request.ShardID,
+	}
+
+	if !request.IsNewBranch && request.RangeID != 0 {
+		return m.txExecuteShardLocked(ctx, "AppendHistoryNodes", request.ShardID, request.RangeID, func(tx sqlplugin.Tx) error {
+			_, err := tx.InsertIntoHistoryNode(ctx, nodeRow)
+			if err != nil && m.Db.IsDupEntryError(err) {
+				return &p.ConditionFailedError{Msg: fmt.Sprintf("AppendHistoryNodes: row already exist: %v", err)}
+			}
+			return err
+		})
 	}
 
 	if !request.IsNewBranch {
@@ -91,7 +103,7 @@ func (m *sqlExecutionStore) AppendHistoryNodes(
 		DataEncoding: treeInfoBlob.EncodingType.String(),
 	}
 
-	return m.txExecute(ctx, "AppendHistoryNodes", func(tx sqlplugin.Tx) error {
+	appendNodeAndTree := func(tx sqlplugin.Tx) error {
 		result, err := tx.InsertIntoHistoryNode(ctx, nodeRow)
 		if err != nil {
 			return err
@@ -116,7 +128,11 @@ func (m *sqlExecutionStore) AppendHistoryNodes(
 			return fmt.Errorf("expected 1 or 2 rows to be affected for tree table as we allow upserts, got %v", rowsAffected)
 		}
 		return nil
-	})
+	}
+	if request.RangeID != 0 {
+		return m.txExecuteShardLocked(ctx, "AppendHistoryNodes", request.ShardID, request.RangeID, appendNodeAndTree)
+	}
+	return m.txExecute(ctx, "AppendHistoryNodes", appendNodeAndTree)
 }
 
 func (m *sqlExecutionStore) DeleteHistoryNodes(
@@ -218,6 +234,7 @@ func (m *sqlExecutionStore) ReadHistoryBranch(
 			PrevTransactionID: row.PrevTxnID,
 			TransactionID:     row.TxnID,
 			Events:            p.NewDataBlob(row.Data, row.DataEncoding),
+			Checksum:          p.NewDataBlob(row.Checksum, row.ChecksumEncoding),
 		})
 	}
 
@@ -34,14 +34,16 @@ import (
 type (
 	// HistoryNodeRow represents a row in history_node table
 	HistoryNodeRow struct {
-		ShardID      int32
-		TreeID       primitives.UUID
-		BranchID     primitives.UUID
-		NodeID       int64
-		PrevTxnID    int64
-		TxnID        int64
-		Data         []byte
-		DataEncoding string
+		ShardID          int32
+		TreeID           primitives.UUID
+		BranchID         primitives.UUID
+		NodeID           int64
+		PrevTxnID        int64
+		TxnID            int64
+		Data             []byte
+		DataEncoding     string
+		Checksum         []byte
+		ChecksumEncoding string
 	}
 
 	// HistoryNodeSelectFilter contains the column names within history_node table that
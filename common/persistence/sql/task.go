@@ -28,8 +28,10 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/binary"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/dgryski/go-farm"
 	commonpb "go.temporal.io/api/common/v1"
@@ -51,6 +53,7 @@ type (
 	sqlTaskManager struct {
 		SqlStore
 		taskScanPartitions uint32
+		taskQueueSubShards uint32
 	}
 )
 
@@ -63,11 +66,16 @@ var (
 func newTaskPersistence(
 	db sqlplugin.DB,
 	taskScanPartitions int,
+	taskQueueSubShards int,
 	logger log.Logger,
 ) (persistence.TaskStore, error) {
+	if taskQueueSubShards < 1 {
+		taskQueueSubShards = 1
+	}
 	return &sqlTaskManager{
 		SqlStore:           NewSqlStore(db, logger),
 		taskScanPartitions: uint32(taskScanPartitions),
+		taskQueueSubShards: uint32(taskQueueSubShards),
 	}, nil
 }
 
@@ -392,7 +400,7 @@ func (m *sqlTaskManager) CreateTasks(
 	tasksRows := make([]sqlplugin.TasksRow, len(request.Tasks))
 	for i, v := range request.Tasks {
 		tasksRows[i] = sqlplugin.TasksRow{
-			RangeHash:    tqHash,
+			RangeHash:    m.taskRangeHash(tqId, tqHash, v.TaskId),
 			TaskQueueID:  tqId,
 			TaskID:       v.TaskId,
 			Data:         v.Task.Data,
@@ -430,16 +438,21 @@ func (m *sqlTaskManager) GetTasks(
 	}
 
 	tqId, tqHash := m.taskQueueIdAndHash(nidBytes, request.TaskQueue, request.TaskType)
-	rows, err := m.Db.SelectFromTasks(ctx, sqlplugin.TasksFilter{
-		RangeHash:   tqHash,
-		TaskQueueID: tqId,
-		MinTaskID:   &request.ReadLevel,
-		MaxTaskID:   request.MaxReadLevel,
-		PageSize:    &request.BatchSize,
-	})
-	if err != nil {
-		return nil, serviceerror.NewUnavailable(fmt.Sprintf("GetTasks operation failed. Failed to get rows. Error: %v", err))
+	var rows []sqlplugin.TasksRow
+	for subShard := uint32(0); subShard < m.taskQueueSubShards; subShard++ {
+		subShardRows, err := m.Db.SelectFromTasks(ctx, sqlplugin.TasksFilter{
+			RangeHash:   subShardRangeHash(tqId, tqHash, subShard),
+			TaskQueueID: tqId,
+			MinTaskID:   &request.ReadLevel,
+			MaxTaskID:   request.MaxReadLevel,
+			PageSize:    &request.BatchSize,
+		})
+		if err != nil {
+			return nil, serviceerror.NewUnavailable(fmt.Sprintf("GetTasks operation failed. Failed to get rows. Error: %v", err))
+		}
+		rows = append(rows, subShardRows...)
 	}
+	rows = mergeTasksRows(rows, request.BatchSize)
 
 	var tasks = make([]*commonpb.DataBlob, len(rows))
 	for i, v := range rows {
@@ -462,7 +475,7 @@ func (m *sqlTaskManager) CompleteTask(
 	taskID := request.TaskID
 	tqId, tqHash := m.taskQueueIdAndHash(nidBytes, request.TaskQueue.Name, request.TaskQueue.TaskType)
 	_, err = m.Db.DeleteFromTasks(ctx, sqlplugin.TasksFilter{
-		RangeHash:   tqHash,
+		RangeHash:   m.taskRangeHash(tqId, tqHash, taskID),
 		TaskQueueID: tqId,
 		TaskID:      &taskID})
 	if err != nil && err != sql.ErrNoRows {
@@ -481,20 +494,69 @@ func (m *sqlTaskManager) CompleteTasksLessThan(
 		return 0, serviceerror.NewUnavailable(err.Error())
 	}
 	tqId, tqHash := m.taskQueueIdAndHash(nidBytes, request.TaskQueueName, request.TaskType)
-	result, err := m.Db.DeleteFromTasks(ctx, sqlplugin.TasksFilter{
-		RangeHash:            tqHash,
-		TaskQueueID:          tqId,
-		TaskIDLessThanEquals: &request.TaskID,
-		Limit:                &request.Limit,
-	})
-	if err != nil {
-		return 0, serviceerror.NewUnavailable(err.Error())
+	// the limit applies to the task queue, sub-shards only get what the previous ones left
+	var totalRows int64
+	remaining := request.Limit
+	for subShard := uint32(0); subShard < m.taskQueueSubShards && remaining > 0; subShard++ {
+		limit := remaining
+		result, err := m.Db.DeleteFromTasks(ctx, sqlplugin.TasksFilter{
+			RangeHash:            subShardRangeHash(tqId, tqHash, subShard),
+			TaskQueueID:          tqId,
+			TaskIDLessThanEquals: &request.TaskID,
+			Limit:                &limit,
+		})
+		if err != nil {
+			return 0, serviceerror.NewUnavailable(err.Error())
+		}
+		nRows, err := result.RowsAffected()
+		if err != nil {
+			return 0, serviceerror.NewUnavailable(fmt.Sprintf("rowsAffected returned error: %v", err))
+		}
+		totalRows += nRows
+		remaining -= int(nRows)
 	}
-	nRows, err := result.RowsAffected()
-	if err != nil {
-		return 0, serviceerror.NewUnavailable(fmt.Sprintf("rowsAffected returned error: %v", err))
+	return int(totalRows), nil
+}
+
+// Returns the range hash of the sub-shard the given task is stored in
+func (m *sqlTaskManager) taskRangeHash(
+	tqId []byte,
+	tqHash uint32,
+	taskID int64,
+) uint32 {
+	return subShardRangeHash(tqId, tqHash, uint32(uint64(taskID)%uint64(m.taskQueueSubShards)))
+}
+
+// subShardRangeHash returns the range hash for a sub-shard of a task queue. Sub-shard 0 uses
+// the task queue hash itself, so that a single sub-shard maps to the original task layout.
+func subShardRangeHash(
+	tqId []byte,
+	tqHash uint32,
+	subShard uint32,
+) uint32 {
+	if subShard == 0 {
+		return tqHash
+	}
+	idBytes := make([]byte, len(tqId)+4)
+	copy(idBytes, tqId)
+	binary.BigEndian.PutUint32(idBytes[len(tqId):], subShard)
+	return farm.Fingerprint32(idBytes)
+}
+
+// mergeTasksRows merges the rows read from all sub-shards of a task queue into task ID
+// order and returns the first pageSize of them. Each sub-shard returns its lowest task
+// IDs, so the merged page is the same as reading from a single sub-shard.
+func mergeTasksRows(
+	rows []sqlplugin.TasksRow,
+	pageSize int,
+) []sqlplugin.TasksRow {
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].TaskID < rows[j].TaskID
+	})
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
 	}
-	return int(nRows), nil
+	return rows
 }
 
 // Returns uint32 hash for a particular TaskQueue/Task given a Namespace, Name and TaskQueueType
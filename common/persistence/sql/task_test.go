@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+	"go.temporal.io/server/common/primitives"
+)
+
+// tasksDB counts the tasks of each range hash, only DeleteFromTasks is implemented
+type tasksDB struct {
+	sqlplugin.DB
+	tasks map[uint32]int
+}
+
+func (db *tasksDB) DeleteFromTasks(_ context.Context, filter sqlplugin.TasksFilter) (sql.Result, error) {
+	deleted := db.tasks[filter.RangeHash]
+	if deleted > *filter.Limit {
+		deleted = *filter.Limit
+	}
+	db.tasks[filter.RangeHash] -= deleted
+	return driver.RowsAffected(deleted), nil
+}
+
+func TestCompleteTasksLessThan_LimitAcrossSubShards(t *testing.T) {
+	const subShards = 4
+	db := &tasksDB{tasks: make(map[uint32]int)}
+	store, err := newTaskPersistence(db, 1, subShards, log.NewNoopLogger())
+	require.NoError(t, err)
+	taskManager := store.(*sqlTaskManager)
+
+	namespaceID := uuid.New()
+	tqID, tqHash := taskManager.taskQueueIdAndHash(primitives.MustParseUUID(namespaceID), "task-queue", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	for subShard := uint32(0); subShard < subShards; subShard++ {
+		db.tasks[subShardRangeHash(tqID, tqHash, subShard)] = 3
+	}
+
+	request := &persistence.CompleteTasksLessThanRequest{
+		NamespaceID:   namespaceID,
+		TaskQueueName: "task-queue",
+		TaskType:      enumspb.TASK_QUEUE_TYPE_ACTIVITY,
+		TaskID:        100,
+		Limit:         5,
+	}
+	deleted, err := taskManager.CompleteTasksLessThan(request)
+	require.NoError(t, err)
+	require.Equal(t, 5, deleted)
+
+	deleted, err = taskManager.CompleteTasksLessThan(request)
+	require.NoError(t, err)
+	require.Equal(t, 5, deleted)
+
+	deleted, err = taskManager.CompleteTasksLessThan(request)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+}
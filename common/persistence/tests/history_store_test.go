@@ -38,6 +38,7 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	p "go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/primitives/timestamp"
 )
 
@@ -72,8 +73,10 @@ func newHistoryEventsSuite(
 		Assertions: require.New(t),
 		store: p.NewExecutionManager(
 			store,
+			serialization.NewSerializer(),
 			logger,
 			dynamicconfig.GetIntPropertyFn(4*1024*1024),
+			dynamicconfig.GetBoolPropertyFn(true),
 		),
 		logger: logger,
 	}
@@ -29,6 +29,7 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/persistence/visibility/store"
 	"go.temporal.io/server/common/persistence/visibility/store/cassandra"
@@ -44,6 +45,7 @@ func NewManager(
 	persistenceResolver resolver.ServiceResolver,
 
 	defaultIndexName string,
+	namespaceIndexNames map[string]string,
 	esClient esclient.Client,
 	esProcessorConfig *elasticsearch.ProcessorConfig,
 	searchAttributesProvider searchattribute.Provider,
@@ -73,6 +75,7 @@ func NewManager(
 
 	advVisibilityManager, err := NewAdvancedManager(
 		defaultIndexName,
+		namespaceIndexNames,
 		esClient,
 		esProcessorConfig,
 		searchAttributesProvider,
@@ -138,6 +141,7 @@ func NewStandardManager(
 
 func NewAdvancedManager(
 	defaultIndexName string,
+	namespaceIndexNames map[string]string,
 	esClient esclient.Client,
 	esProcessorConfig *elasticsearch.ProcessorConfig,
 	searchAttributesProvider searchattribute.Provider,
@@ -149,23 +153,51 @@ func NewAdvancedManager(
 	metricsClient metrics.Client,
 	logger log.Logger,
 ) (manager.VisibilityManager, error) {
-	advVisibilityStore := newAdvancedVisibilityStore(
-		defaultIndexName,
-		esClient,
-		esProcessorConfig,
-		searchAttributesProvider,
-		searchAttributesMapper,
-		metricsClient,
-		logger)
+	if esClient == nil {
+		return nil, nil
+	}
 
-	return newVisibilityManager(
-		advVisibilityStore,
-		advancedVisibilityPersistenceMaxReadQPS,
-		advancedVisibilityPersistenceMaxWriteQPS,
-		metricsClient,
-		metrics.AdvancedVisibilityTypeTag(),
-		logger,
-	), nil
+	var (
+		esProcessor           elasticsearch.Processor
+		esProcessorAckTimeout dynamicconfig.DurationPropertyFn
+	)
+	if esProcessorConfig != nil {
+		esProcessor = elasticsearch.NewProcessor(esProcessorConfig, esClient, logger, metricsClient)
+		esProcessor.Start()
+		esProcessorAckTimeout = esProcessorConfig.ESProcessorAckTimeout
+	}
+
+	newIndexManager := func(indexName string, searchAttributesProvider searchattribute.Provider) manager.VisibilityManager {
+		advVisibilityStore := elasticsearch.NewVisibilityStore(
+			esClient,
+			indexName,
+			searchAttributesProvider,
+			searchAttributesMapper,
+			esProcessor,
+			esProcessorAckTimeout,
+			metricsClient)
+
+		return newVisibilityManager(
+			advVisibilityStore,
+			advancedVisibilityPersistenceMaxReadQPS,
+			advancedVisibilityPersistenceMaxWriteQPS,
+			metricsClient,
+			metrics.AdvancedVisibilityTypeTag(),
+			logger,
+		)
+	}
+
+	defaultManager := newIndexManager(defaultIndexName, searchAttributesProvider)
+	if len(namespaceIndexNames) == 0 {
+		return defaultManager, nil
+	}
+
+	// Each dedicated index has its own search attributes in cluster metadata, which are kept in sync with its mapping.
+	namespaceManagers := make(map[namespace.Name]manager.VisibilityManager, len(namespaceIndexNames))
+	for namespaceName, indexName := range namespaceIndexNames {
+		namespaceManagers[namespace.Name(namespaceName)] = newIndexManager(indexName, searchAttributesProvider)
+	}
+	return NewVisibilityManagerRouted(defaultManager, namespaceManagers), nil
 }
 
 func newVisibilityManager(
@@ -231,36 +263,3 @@ func newStandardVisibilityStore(
 
 	return store, nil
 }
-
-func newAdvancedVisibilityStore(
-	defaultIndexName string,
-	esClient esclient.Client,
-	esProcessorConfig *elasticsearch.ProcessorConfig,
-	searchAttributesProvider searchattribute.Provider,
-	searchAttributesMapper searchattribute.Mapper,
-	metricsClient metrics.Client,
-	logger log.Logger,
-) store.VisibilityStore {
-	if esClient == nil {
-		return nil
-	}
-
-	var (
-		esProcessor           elasticsearch.Processor
-		esProcessorAckTimeout dynamicconfig.DurationPropertyFn
-	)
-	if esProcessorConfig != nil {
-		esProcessor = elasticsearch.NewProcessor(esProcessorConfig, esClient, logger, metricsClient)
-		esProcessor.Start()
-		esProcessorAckTimeout = esProcessorConfig.ESProcessorAckTimeout
-	}
-	s := elasticsearch.NewVisibilityStore(
-		esClient,
-		defaultIndexName,
-		searchAttributesProvider,
-		searchAttributesMapper,
-		esProcessor,
-		esProcessorAckTimeout,
-		metricsClient)
-	return s
-}
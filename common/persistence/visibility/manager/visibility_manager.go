@@ -165,6 +165,7 @@ type (
 	// VisibilityDeleteWorkflowExecutionRequest contains the request params for DeleteWorkflowExecution call
 	VisibilityDeleteWorkflowExecutionRequest struct {
 		NamespaceID namespace.ID
+		Namespace   namespace.Name // namespace.Name is not persisted.
 		RunID       string
 		WorkflowID  string
 		TaskID      int64
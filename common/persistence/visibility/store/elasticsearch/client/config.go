@@ -27,6 +27,7 @@ package client
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"time"
 )
 
@@ -43,6 +44,7 @@ type (
 		Username                     string                    `yaml:"username"`
 		Password                     string                    `yaml:"password"`
 		Indices                      map[string]string         `yaml:"indices"` //nolint:govet
+		NamespaceIndices             map[string]string         `yaml:"namespaceIndices"`
 		LogLevel                     string                    `yaml:"logLevel"`
 		AWSRequestSigning            ESAWSRequestSigningConfig `yaml:"aws-request-signing"`
 		CloseIdleConnectionsInterval time.Duration             `yaml:"closeIdleConnectionsInterval"`
@@ -86,6 +88,33 @@ func (cfg *Config) GetVisibilityIndex() string {
 	return cfg.Indices[VisibilityAppName]
 }
 
+// GetNamespaceVisibilityIndices return map of namespace name to dedicated visibility index name.
+// Namespaces which are not in the map use default visibility index.
+func (cfg *Config) GetNamespaceVisibilityIndices() map[string]string {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.NamespaceIndices
+}
+
+// GetNamespaceVisibilityIndexNames return sorted names of dedicated visibility indices.
+// Index which is dedicated to more than one namespace is returned once.
+func (cfg *Config) GetNamespaceVisibilityIndexNames() []string {
+	if cfg == nil || len(cfg.NamespaceIndices) == 0 {
+		return nil
+	}
+	indexNames := make(map[string]struct{}, len(cfg.NamespaceIndices))
+	for _, indexName := range cfg.NamespaceIndices {
+		indexNames[indexName] = struct{}{}
+	}
+	result := make([]string, 0, len(indexNames))
+	for indexName := range indexNames {
+		result = append(result, indexName)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (cfg *Config) Validate(storeName string) error {
 	if cfg == nil {
 		return fmt.Errorf("persistence config: advanced visibility datastore %q: must provide config for \"elasticsearch\"", storeName)
@@ -98,5 +127,13 @@ func (cfg *Config) Validate(storeName string) error {
 	if cfg.Indices[VisibilityAppName] == "" {
 		return fmt.Errorf("persistence config: advanced visibility datastore %q indices configuration: missing %q key", storeName, VisibilityAppName)
 	}
+	for namespaceName, indexName := range cfg.NamespaceIndices {
+		if indexName == "" {
+			return fmt.Errorf("persistence config: advanced visibility datastore %q namespaceIndices configuration: missing index for namespace %q", storeName, namespaceName)
+		}
+		if indexName == cfg.Indices[VisibilityAppName] {
+			return fmt.Errorf("persistence config: advanced visibility datastore %q namespaceIndices configuration: index for namespace %q must be different from %q index", storeName, namespaceName, VisibilityAppName)
+		}
+	}
 	return nil
 }
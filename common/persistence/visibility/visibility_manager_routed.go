@@ -0,0 +1,126 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+)
+
+type (
+	// visibilityManagerRouted routes requests of namespaces which have dedicated visibility index
+	// to the manager of that index. All other requests go to the default manager.
+	visibilityManagerRouted struct {
+		defaultManager    manager.VisibilityManager
+		namespaceManagers map[namespace.Name]manager.VisibilityManager
+	}
+)
+
+var _ manager.VisibilityManager = (*visibilityManagerRouted)(nil)
+
+// NewVisibilityManagerRouted create a visibility manager that routes requests to per namespace managers.
+func NewVisibilityManagerRouted(
+	defaultManager manager.VisibilityManager,
+	namespaceManagers map[namespace.Name]manager.VisibilityManager,
+) *visibilityManagerRouted {
+	return &visibilityManagerRouted{
+		defaultManager:    defaultManager,
+		namespaceManagers: namespaceManagers,
+	}
+}
+
+func (v *visibilityManagerRouted) Close() {
+	v.defaultManager.Close()
+	for _, m := range v.namespaceManagers {
+		m.Close()
+	}
+}
+
+func (v *visibilityManagerRouted) GetName() string {
+	return v.defaultManager.GetName()
+}
+
+func (v *visibilityManagerRouted) RecordWorkflowExecutionStarted(request *manager.RecordWorkflowExecutionStartedRequest) error {
+	return v.route(request.Namespace).RecordWorkflowExecutionStarted(request)
+}
+
+func (v *visibilityManagerRouted) RecordWorkflowExecutionClosed(request *manager.RecordWorkflowExecutionClosedRequest) error {
+	return v.route(request.Namespace).RecordWorkflowExecutionClosed(request)
+}
+
+func (v *visibilityManagerRouted) UpsertWorkflowExecution(request *manager.UpsertWorkflowExecutionRequest) error {
+	return v.route(request.Namespace).UpsertWorkflowExecution(request)
+}
+
+func (v *visibilityManagerRouted) DeleteWorkflowExecution(request *manager.VisibilityDeleteWorkflowExecutionRequest) error {
+	return v.route(request.Namespace).DeleteWorkflowExecution(request)
+}
+
+func (v *visibilityManagerRouted) ListOpenWorkflowExecutions(request *manager.ListWorkflowExecutionsRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListOpenWorkflowExecutions(request)
+}
+
+func (v *visibilityManagerRouted) ListClosedWorkflowExecutions(request *manager.ListWorkflowExecutionsRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListClosedWorkflowExecutions(request)
+}
+
+func (v *visibilityManagerRouted) ListOpenWorkflowExecutionsByType(request *manager.ListWorkflowExecutionsByTypeRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListOpenWorkflowExecutionsByType(request)
+}
+
+func (v *visibilityManagerRouted) ListClosedWorkflowExecutionsByType(request *manager.ListWorkflowExecutionsByTypeRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListClosedWorkflowExecutionsByType(request)
+}
+
+func (v *visibilityManagerRouted) ListOpenWorkflowExecutionsByWorkflowID(request *manager.ListWorkflowExecutionsByWorkflowIDRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListOpenWorkflowExecutionsByWorkflowID(request)
+}
+
+func (v *visibilityManagerRouted) ListClosedWorkflowExecutionsByWorkflowID(request *manager.ListWorkflowExecutionsByWorkflowIDRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListClosedWorkflowExecutionsByWorkflowID(request)
+}
+
+func (v *visibilityManagerRouted) ListClosedWorkflowExecutionsByStatus(request *manager.ListClosedWorkflowExecutionsByStatusRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListClosedWorkflowExecutionsByStatus(request)
+}
+
+func (v *visibilityManagerRouted) ListWorkflowExecutions(request *manager.ListWorkflowExecutionsRequestV2) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ListWorkflowExecutions(request)
+}
+
+func (v *visibilityManagerRouted) ScanWorkflowExecutions(request *manager.ListWorkflowExecutionsRequestV2) (*manager.ListWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).ScanWorkflowExecutions(request)
+}
+
+func (v *visibilityManagerRouted) CountWorkflowExecutions(request *manager.CountWorkflowExecutionsRequest) (*manager.CountWorkflowExecutionsResponse, error) {
+	return v.route(request.Namespace).CountWorkflowExecutions(request)
+}
+
+func (v *visibilityManagerRouted) route(namespace namespace.Name) manager.VisibilityManager {
+	if m, ok := v.namespaceManagers[namespace]; ok {
+		return m
+	}
+	return v.defaultManager
+}
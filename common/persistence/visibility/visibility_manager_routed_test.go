@@ -0,0 +1,69 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+)
+
+func TestVisibilityManagerRouted(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dedicatedNamespace := namespace.Name("dedicated-namespace")
+	defaultManager := manager.NewMockVisibilityManager(controller)
+	dedicatedManager := manager.NewMockVisibilityManager(controller)
+	routedManager := NewVisibilityManagerRouted(defaultManager, map[namespace.Name]manager.VisibilityManager{
+		dedicatedNamespace: dedicatedManager,
+	})
+
+	dedicatedRequest := &manager.RecordWorkflowExecutionStartedRequest{
+		VisibilityRequestBase: &manager.VisibilityRequestBase{Namespace: dedicatedNamespace},
+	}
+	dedicatedManager.EXPECT().RecordWorkflowExecutionStarted(dedicatedRequest).Return(nil)
+	require.NoError(t, routedManager.RecordWorkflowExecutionStarted(dedicatedRequest))
+
+	defaultRequest := &manager.RecordWorkflowExecutionStartedRequest{
+		VisibilityRequestBase: &manager.VisibilityRequestBase{Namespace: testNamespace},
+	}
+	defaultManager.EXPECT().RecordWorkflowExecutionStarted(defaultRequest).Return(nil)
+	require.NoError(t, routedManager.RecordWorkflowExecutionStarted(defaultRequest))
+
+	countRequest := &manager.CountWorkflowExecutionsRequest{Namespace: dedicatedNamespace}
+	dedicatedManager.EXPECT().CountWorkflowExecutions(countRequest).Return(&manager.CountWorkflowExecutionsResponse{Count: 1}, nil)
+	resp, err := routedManager.CountWorkflowExecutions(countRequest)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.Count)
+
+	deleteRequest := &manager.VisibilityDeleteWorkflowExecutionRequest{}
+	defaultManager.EXPECT().DeleteWorkflowExecution(deleteRequest).Return(nil)
+	require.NoError(t, routedManager.DeleteWorkflowExecution(deleteRequest))
+}
@@ -0,0 +1,219 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// fairShareRefreshInterval is how often the shares of the active callers are recomputed
+	fairShareRefreshInterval = time.Second
+	// fairShareActiveWindow is how long a caller keeps its share after its last request
+	fairShareActiveWindow = 10 * time.Second
+)
+
+type (
+	// WeightFn returns the weight of the share of the rate of a caller
+	WeightFn func(caller string) float64
+
+	// StarvationFn is called when no request of a caller was allowed for longer than the starvation threshold
+	StarvationFn func(caller string, starvedFor time.Duration)
+
+	// FairRateLimiterImpl shares the rate among the callers which made requests recently, in proportion to
+	// their weights, so a single caller can't use up the whole rate while others are waiting. Idle callers
+	// don't hold on to their share, and a single active caller gets the whole rate.
+	FairRateLimiterImpl struct {
+		rateFn              RateFn
+		weightFn            WeightFn
+		starvationThreshold func() time.Duration
+		starvationFn        StarvationFn
+
+		sync.Mutex
+		lastRefresh time.Time
+		callers     map[string]*fairShareCaller
+	}
+
+	fairShareCaller struct {
+		rateLimiter *RateLimiterImpl
+		lastRequest time.Time
+		lastAllowed time.Time
+		starved     bool
+	}
+)
+
+var _ RequestRateLimiter = (*FairRateLimiterImpl)(nil)
+
+func NewFairRateLimiter(
+	rateFn RateFn,
+	weightFn WeightFn,
+	starvationThreshold func() time.Duration,
+	starvationFn StarvationFn,
+) *FairRateLimiterImpl {
+	return &FairRateLimiterImpl{
+		rateFn:              rateFn,
+		weightFn:            weightFn,
+		starvationThreshold: starvationThreshold,
+		starvationFn:        starvationFn,
+
+		callers: make(map[string]*fairShareCaller),
+	}
+}
+
+// Allow attempts to allow a request to go through. The method returns
+// immediately with a true or false indicating if the request can make
+// progress
+func (r *FairRateLimiterImpl) Allow(
+	now time.Time,
+	request Request,
+) bool {
+	r.Lock()
+	caller := r.getOrInitCallerLocked(now, request.Caller)
+	allowed := caller.rateLimiter.AllowN(now, request.Token)
+	if allowed {
+		caller.lastAllowed = now
+		caller.starved = false
+		r.Unlock()
+		return true
+	}
+
+	starvedFor := now.Sub(caller.lastAllowed)
+	threshold := r.starvationThreshold()
+	if caller.starved || threshold <= 0 || starvedFor < threshold {
+		r.Unlock()
+		return false
+	}
+	caller.starved = true
+	r.Unlock()
+
+	if r.starvationFn != nil {
+		r.starvationFn(request.Caller, starvedFor)
+	}
+	return false
+}
+
+// Reserve returns a Reservation that indicates how long the caller
+// must wait before event happen.
+func (r *FairRateLimiterImpl) Reserve(
+	now time.Time,
+	request Request,
+) Reservation {
+	r.Lock()
+	defer r.Unlock()
+
+	caller := r.getOrInitCallerLocked(now, request.Caller)
+	return caller.rateLimiter.ReserveN(now, request.Token)
+}
+
+// Wait waits till the deadline for a rate limit token to allow the request
+// to go through.
+func (r *FairRateLimiterImpl) Wait(
+	ctx context.Context,
+	request Request,
+) error {
+	r.Lock()
+	caller := r.getOrInitCallerLocked(time.Now().UTC(), request.Caller)
+	r.Unlock()
+
+	return caller.rateLimiter.WaitN(ctx, request.Token)
+}
+
+// Rate returns the current rate of caller, for tests and diagnostics
+func (r *FairRateLimiterImpl) Rate(
+	caller string,
+) float64 {
+	r.Lock()
+	defer r.Unlock()
+
+	c, ok := r.callers[caller]
+	if !ok {
+		return 0
+	}
+	return c.rateLimiter.Rate()
+}
+
+func (r *FairRateLimiterImpl) getOrInitCallerLocked(
+	now time.Time,
+	callerName string,
+) *fairShareCaller {
+	caller, ok := r.callers[callerName]
+	if !ok {
+		rate := r.rateFn()
+		caller = &fairShareCaller{
+			rateLimiter: NewRateLimiter(rate, fairShareBurst(rate)),
+			lastAllowed: now,
+		}
+		r.callers[callerName] = caller
+		// a new caller takes its share from the others right away
+		r.lastRefresh = time.Time{}
+	}
+	caller.lastRequest = now
+
+	if now.Sub(r.lastRefresh) >= fairShareRefreshInterval {
+		r.refreshSharesLocked(now)
+	}
+	return caller
+}
+
+// refreshSharesLocked removes the callers without recent requests, and divides the rate among the rest by weight
+func (r *FairRateLimiterImpl) refreshSharesLocked(
+	now time.Time,
+) {
+	r.lastRefresh = now
+
+	weights := make(map[string]float64, len(r.callers))
+	totalWeight := float64(0)
+	for name, caller := range r.callers {
+		if now.Sub(caller.lastRequest) > fairShareActiveWindow {
+			delete(r.callers, name)
+			continue
+		}
+		weight := r.weightFn(name)
+		if weight <= 0 {
+			weight = 0
+		}
+		weights[name] = weight
+		totalWeight += weight
+	}
+
+	rate := r.rateFn()
+	for name, weight := range weights {
+		share := rate
+		if totalWeight > 0 {
+			share = rate * weight / totalWeight
+		}
+		r.callers[name].rateLimiter.SetRateBurst(share, fairShareBurst(share))
+	}
+}
+
+func fairShareBurst(rate float64) int {
+	burst := int(rate * defaultIncomingRateBurstRatio)
+	if burst < 1 {
+		burst = 1
+	}
+	return burst
+}
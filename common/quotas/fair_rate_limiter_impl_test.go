@@ -0,0 +1,99 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	fairRateLimiterSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestFairRateLimiterSuite(t *testing.T) {
+	s := new(fairRateLimiterSuite)
+	suite.Run(t, s)
+}
+
+func (s *fairRateLimiterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *fairRateLimiterSuite) TestShares() {
+	weights := map[string]float64{"a": 3, "b": 1}
+	rateLimiter := NewFairRateLimiter(
+		func() float64 { return 100 },
+		func(caller string) float64 { return weights[caller] },
+		func() time.Duration { return 0 },
+		nil,
+	)
+
+	now := time.Now().UTC()
+	rateLimiter.Allow(now, NewRequest("api", 1, "a"))
+	s.Equal(float64(100), rateLimiter.Rate("a"))
+
+	rateLimiter.Allow(now, NewRequest("api", 1, "b"))
+	s.Equal(float64(75), rateLimiter.Rate("a"))
+	s.Equal(float64(25), rateLimiter.Rate("b"))
+
+	// b stops sending requests, and a gets the whole rate back
+	now = now.Add(fairShareActiveWindow + fairShareRefreshInterval)
+	rateLimiter.Allow(now, NewRequest("api", 1, "a"))
+	s.Equal(float64(100), rateLimiter.Rate("a"))
+	s.Equal(float64(0), rateLimiter.Rate("b"))
+}
+
+func (s *fairRateLimiterSuite) TestStarvation() {
+	var starvedCallers []string
+	rateLimiter := NewFairRateLimiter(
+		func() float64 { return 1 },
+		func(caller string) float64 { return 1 },
+		func() time.Duration { return time.Second },
+		func(caller string, starvedFor time.Duration) { starvedCallers = append(starvedCallers, caller) },
+	)
+
+	now := time.Now().UTC()
+	s.True(rateLimiter.Allow(now, NewRequest("api", 2, "a")))
+	s.False(rateLimiter.Allow(now, NewRequest("api", 2, "a")))
+	s.Empty(starvedCallers)
+
+	// tokens for a single token request are refilled by now, but not for 5 tokens
+	now = now.Add(2 * time.Second)
+	s.False(rateLimiter.Allow(now, NewRequest("api", 5, "a")))
+	s.False(rateLimiter.Allow(now, NewRequest("api", 5, "a")))
+	s.Equal([]string{"a"}, starvedCallers)
+
+	s.True(rateLimiter.Allow(now, NewRequest("api", 1, "a")))
+	now = now.Add(2 * time.Second)
+	s.False(rateLimiter.Allow(now, NewRequest("api", 5, "a")))
+	s.Equal([]string{"a", "a"}, starvedCallers)
+}
@@ -0,0 +1,75 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"sync"
+)
+
+type (
+	// KeyedConcurrencyLimiter limits the number of concurrent holders per key, e.g. long polls per
+	// workflow execution or per caller. The zero value is ready to use.
+	KeyedConcurrencyLimiter struct {
+		sync.Mutex
+		holders map[string]int
+	}
+)
+
+// TryAcquire takes a slot for the key and returns true if fewer than limit slots are held for it.
+// A limit of 0 or less is unlimited. Every successful TryAcquire must be followed by a Release.
+func (l *KeyedConcurrencyLimiter) TryAcquire(key string, limit int) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.holders == nil {
+		l.holders = make(map[string]int)
+	}
+	count := l.holders[key]
+	if limit > 0 && count >= limit {
+		return false
+	}
+	l.holders[key] = count + 1
+	return true
+}
+
+// Release returns a slot of the key taken by TryAcquire
+func (l *KeyedConcurrencyLimiter) Release(key string) {
+	l.Lock()
+	defer l.Unlock()
+
+	if count := l.holders[key]; count > 1 {
+		l.holders[key] = count - 1
+	} else {
+		delete(l.holders, key)
+	}
+}
+
+// Count returns the number of slots held for the key
+func (l *KeyedConcurrencyLimiter) Count(key string) int {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.holders[key]
+}
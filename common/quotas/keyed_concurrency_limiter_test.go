@@ -0,0 +1,59 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedConcurrencyLimiter(t *testing.T) {
+	var limiter KeyedConcurrencyLimiter
+
+	require.True(t, limiter.TryAcquire("a", 2))
+	require.True(t, limiter.TryAcquire("a", 2))
+	require.False(t, limiter.TryAcquire("a", 2))
+	require.True(t, limiter.TryAcquire("b", 2))
+	require.Equal(t, 2, limiter.Count("a"))
+
+	limiter.Release("a")
+	require.True(t, limiter.TryAcquire("a", 2))
+
+	limiter.Release("a")
+	limiter.Release("a")
+	limiter.Release("b")
+	require.Equal(t, 0, limiter.Count("a"))
+	require.Empty(t, limiter.holders)
+}
+
+func TestKeyedConcurrencyLimiter_Unlimited(t *testing.T) {
+	var limiter KeyedConcurrencyLimiter
+
+	for i := 0; i < 100; i++ {
+		require.True(t, limiter.TryAcquire("a", 0))
+	}
+	require.Equal(t, 100, limiter.Count("a"))
+}
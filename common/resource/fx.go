@@ -348,6 +348,7 @@ func NewFromDI(
 	ringpopChannel *tchannel.Channel,
 	runtimeMetricsReporter *metrics.RuntimeMetricsReporter,
 	rpcFactory common.RPCFactory,
+	dynamicCollection *dynamicconfig.Collection,
 ) (Resource, error) {
 
 	frontendServiceResolver, err := membershipMonitor.GetResolver(common.FrontendServiceName)
@@ -364,6 +365,11 @@ func NewFromDI(
 	if err != nil {
 		return nil, err
 	}
+	historyShardPins := dynamicCollection.GetMapProperty(dynamicconfig.HistoryShardPins, nil)
+	historyServiceResolver = membership.NewPinnedServiceResolver(
+		historyServiceResolver,
+		func() map[string]interface{} { return historyShardPins() },
+	)
 
 	workerServiceResolver, err := membershipMonitor.GetResolver(common.WorkerServiceName)
 	if err != nil {
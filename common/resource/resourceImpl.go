@@ -187,6 +187,7 @@ func New(
 			return persistenceMaxQPS()
 		},
 		datastoreFactory,
+		nil,
 		params.ClusterMetadataConfig.CurrentClusterName,
 		params.MetricsClient,
 		logger,
@@ -247,6 +248,11 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	historyShardPins := dynamicCollection.GetMapProperty(dynamicconfig.HistoryShardPins, nil)
+	historyServiceResolver = membership.NewPinnedServiceResolver(
+		historyServiceResolver,
+		func() map[string]interface{} { return historyShardPins() },
+	)
 
 	workerServiceResolver, err := membershipMonitor.GetResolver(common.WorkerServiceName)
 	if err != nil {
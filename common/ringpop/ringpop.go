@@ -41,6 +41,13 @@ import (
 
 const (
 	defaultMaxJoinDuration = 10 * time.Second
+
+	defaultUnreachableHostCheckAttempts            = 3
+	defaultUnreachableHostCheckProbeInterval       = time.Second
+	defaultUnreachableHostCheckProbeTimeout        = time.Second
+	defaultUnreachableHostCheckExclusionDuration   = 30 * time.Second
+	defaultUnreachableHostCheckCooldown            = 30 * time.Second
+	defaultUnreachableHostCheckMaxExcludedFraction = 0.2
 )
 
 // RingpopFactory implements the RingpopFactory interface
@@ -132,11 +139,47 @@ func (factory *RingpopFactory) createMembership() (membership.Monitor, error) {
 	}
 
 	membershipMonitor := membership.NewRingpopMonitor(factory.serviceName,
-		factory.servicePortMap, rp, factory.logger, factory.metadataManager, factory.broadcastAddressResolver)
+		factory.servicePortMap, rp, factory.logger, factory.metadataManager, factory.broadcastAddressResolver,
+		factory.unreachableHostCheckConfig())
 
 	return membershipMonitor, nil
 }
 
+func (factory *RingpopFactory) unreachableHostCheckConfig() *membership.UnreachableHostCheckConfig {
+	cfg := factory.config.UnreachableHostCheck
+	if cfg == nil {
+		return nil
+	}
+
+	result := &membership.UnreachableHostCheckConfig{
+		Attempts:            cfg.Attempts,
+		ProbeInterval:       cfg.ProbeInterval,
+		ProbeTimeout:        cfg.ProbeTimeout,
+		ExclusionDuration:   cfg.ExclusionDuration,
+		Cooldown:            cfg.Cooldown,
+		MaxExcludedFraction: cfg.MaxExcludedFraction,
+	}
+	if result.Attempts <= 0 {
+		result.Attempts = defaultUnreachableHostCheckAttempts
+	}
+	if result.ProbeInterval <= 0 {
+		result.ProbeInterval = defaultUnreachableHostCheckProbeInterval
+	}
+	if result.ProbeTimeout <= 0 {
+		result.ProbeTimeout = defaultUnreachableHostCheckProbeTimeout
+	}
+	if result.ExclusionDuration <= 0 {
+		result.ExclusionDuration = defaultUnreachableHostCheckExclusionDuration
+	}
+	if result.Cooldown <= 0 {
+		result.Cooldown = defaultUnreachableHostCheckCooldown
+	}
+	if result.MaxExcludedFraction <= 0 {
+		result.MaxExcludedFraction = defaultUnreachableHostCheckMaxExcludedFraction
+	}
+	return result
+}
+
 func (factory *RingpopFactory) getRingpop() (*membership.RingPop, error) {
 	if factory.ringPop != nil {
 		return factory.ringPop, nil
@@ -155,7 +198,12 @@ func (factory *RingpopFactory) broadcastAddressResolver() (string, error) {
 }
 
 func (factory *RingpopFactory) createRingpop() (*membership.RingPop, error) {
-	rp, err := ringpop.New("temporal", ringpop.Channel(factory.channel), ringpop.AddressResolverFunc(factory.broadcastAddressResolver))
+	rp, err := ringpop.New(
+		"temporal",
+		ringpop.Channel(factory.channel),
+		ringpop.AddressResolverFunc(factory.broadcastAddressResolver),
+		ringpop.LabelLimitValueSize(membership.LabelValueMaxSize),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,95 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.temporal.io/server/common/dynamicconfig"
+)
+
+const (
+	// RetryAfterHeaderName is the response header carrying the number of seconds
+	// a client should wait before retrying a request rejected during maintenance
+	RetryAfterHeaderName = "retry-after"
+)
+
+type (
+	// MaintenanceModeInterceptor rejects requests of the given service with a retryable
+	// error while maintenance mode is enabled, except for the allowed APIs.
+	MaintenanceModeInterceptor struct {
+		serviceName string
+		enabled     dynamicconfig.BoolPropertyFn
+		retryAfter  dynamicconfig.DurationPropertyFn
+		allowedAPIs map[string]struct{}
+	}
+)
+
+var _ grpc.UnaryServerInterceptor = (*MaintenanceModeInterceptor)(nil).Intercept
+
+func NewMaintenanceModeInterceptor(
+	serviceName string,
+	enabled dynamicconfig.BoolPropertyFn,
+	retryAfter dynamicconfig.DurationPropertyFn,
+	allowedAPIs map[string]struct{},
+) *MaintenanceModeInterceptor {
+	return &MaintenanceModeInterceptor{
+		serviceName: serviceName,
+		enabled:     enabled,
+		retryAfter:  retryAfter,
+		allowedAPIs: allowedAPIs,
+	}
+}
+
+func (i *MaintenanceModeInterceptor) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !i.enabled() {
+		return handler(ctx, req)
+	}
+
+	serviceName, methodName := splitMethodName(info.FullMethod)
+	if serviceName != i.serviceName {
+		return handler(ctx, req)
+	}
+	if _, ok := i.allowedAPIs[methodName]; ok {
+		return handler(ctx, req)
+	}
+
+	retryAfter := i.retryAfter()
+	// best effort, the hint is also part of the error message
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeaderName, strconv.FormatInt(int64(retryAfter/time.Second), 10)))
+	return nil, serviceerror.NewUnavailable(fmt.Sprintf("cluster is under maintenance, retry after %v", retryAfter))
+}
@@ -0,0 +1,77 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc"
+
+	"go.temporal.io/server/common/dynamicconfig"
+)
+
+func TestMaintenanceModeInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	newInterceptor := func(enabled bool) *MaintenanceModeInterceptor {
+		return NewMaintenanceModeInterceptor(
+			"temporal.api.workflowservice.v1.WorkflowService",
+			dynamicconfig.GetBoolPropertyFn(enabled),
+			dynamicconfig.GetDurationPropertyFn(time.Minute),
+			map[string]struct{}{"RespondWorkflowTaskCompleted": {}},
+		)
+	}
+
+	testCases := []struct {
+		enabled    bool
+		fullMethod string
+		allowed    bool
+	}{
+		{false, "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution", true},
+		{true, "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution", false},
+		{true, "/temporal.api.workflowservice.v1.WorkflowService/RespondWorkflowTaskCompleted", true},
+		{true, "/temporal.server.api.adminservice.v1.AdminService/DescribeCluster", true},
+	}
+	for _, tc := range testCases {
+		resp, err := newInterceptor(tc.enabled).Intercept(
+			context.Background(),
+			nil,
+			&grpc.UnaryServerInfo{FullMethod: tc.fullMethod},
+			handler,
+		)
+		if tc.allowed {
+			require.NoError(t, err, tc.fullMethod)
+			require.Equal(t, "ok", resp)
+		} else {
+			require.IsType(t, &serviceerror.Unavailable{}, err, tc.fullMethod)
+			require.Nil(t, resp)
+		}
+	}
+}
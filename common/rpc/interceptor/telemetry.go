@@ -112,6 +112,7 @@ func (ti *TelemetryInterceptor) Intercept(
 		timerNoUserLatency.Subtract(userLatencyDuration)
 		metricsScope.RecordTimer(metrics.ServiceLatencyUserLatency, userLatencyDuration)
 	}
+	ti.emitActionUnits(ctx, metricsScope)
 
 	if err != nil {
 		ti.handleError(metricsScope, logTags, err)
@@ -121,6 +122,23 @@ func (ti *TelemetryInterceptor) Intercept(
 	return resp, nil
 }
 
+// emitActionUnits reports the cost accumulated by history while serving the request, including the cost
+// propagated back from downstream history calls
+func (ti *TelemetryInterceptor) emitActionUnits(
+	ctx context.Context,
+	scope metrics.Scope,
+) {
+	if val, ok := metrics.ContextCounterGet(ctx, metrics.HistoryActionUnitsStateTransitions); ok && val > 0 {
+		scope.AddCounter(metrics.ServiceActionUnitsStateTransitions, val)
+	}
+	if val, ok := metrics.ContextCounterGet(ctx, metrics.HistoryActionUnitsBytesWritten); ok && val > 0 {
+		scope.AddCounter(metrics.ServiceActionUnitsBytesWritten, val)
+	}
+	if val, ok := metrics.ContextCounterGet(ctx, metrics.HistoryActionUnitsTasksGenerated); ok && val > 0 {
+		scope.AddCounter(metrics.ServiceActionUnitsTasksGenerated, val)
+	}
+}
+
 func (ti *TelemetryInterceptor) metricsScopeLogTags(
 	req interface{},
 	methodName string,
@@ -182,7 +200,7 @@ func (ti *TelemetryInterceptor) handleError(
 	case *serviceerror.DataLoss:
 		scope.IncCounter(metrics.ServiceFailures)
 		ti.logger.Error("unavailable error, data loss", append(logTags, tag.Error(err))...)
-	case *serviceerror.Unavailable:
+	case *serviceerror.Unavailable, *serviceerrors.ShardUnavailable:
 		scope.IncCounter(metrics.ServiceFailures)
 		ti.logger.Error("unavailable error", append(logTags, tag.Error(err))...)
 	default:
@@ -44,10 +44,15 @@ const (
 	ExecutionDuration    = "ExecutionDuration"
 	StateTransitionCount = "StateTransitionCount"
 
-	TemporalChangeVersion = "TemporalChangeVersion"
-	BinaryChecksums       = "BinaryChecksums"
-	BatcherNamespace      = "BatcherNamespace"
-	BatcherUser           = "BatcherUser"
+	TemporalChangeVersion    = "TemporalChangeVersion"
+	BinaryChecksums          = "BinaryChecksums"
+	BatcherNamespace         = "BatcherNamespace"
+	BatcherUser              = "BatcherUser"
+	TemporalOperatorIdentity = "TemporalOperatorIdentity"
+	TemporalQuarantineReason = "TemporalQuarantineReason"
+	TemporalFailureSource    = "TemporalFailureSource"
+	TemporalFailureType      = "TemporalFailureType"
+	TemporalTimeoutType      = "TemporalTimeoutType"
 
 	MemoEncoding      = "MemoEncoding"
 	Memo              = "Memo"
@@ -74,10 +79,15 @@ var (
 
 	// predefined are internal search attributes which are passed and stored in SearchAttributes object together with custom search attributes.
 	predefined = map[string]enumspb.IndexedValueType{
-		TemporalChangeVersion: enumspb.INDEXED_VALUE_TYPE_KEYWORD,
-		BinaryChecksums:       enumspb.INDEXED_VALUE_TYPE_KEYWORD,
-		BatcherNamespace:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
-		BatcherUser:           enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		TemporalChangeVersion:    enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		BinaryChecksums:          enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		BatcherNamespace:         enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		BatcherUser:              enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		TemporalOperatorIdentity: enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		TemporalQuarantineReason: enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		TemporalFailureSource:    enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		TemporalFailureType:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		TemporalTimeoutType:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
 	}
 
 	// reserved are internal field names that can't be used as search attribute names.
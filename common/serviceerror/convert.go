@@ -25,6 +25,7 @@
 package serviceerror
 
 import (
+	"github.com/gogo/googleapis/google/rpc"
 	"github.com/gogo/status"
 	"go.temporal.io/api/serviceerror"
 	"google.golang.org/grpc/codes"
@@ -58,6 +59,13 @@ func FromStatus(st *status.Status) error {
 		case *errordetails.RetryReplicationFailure:
 			return newRetryReplication(st, errDetails)
 		}
+	case codes.Unavailable:
+		switch errDetails := errDetails.(type) {
+		case *rpc.ErrorInfo:
+			if errDetails.GetReason() == shardUnavailableReason {
+				return newShardUnavailable(st)
+			}
+		}
 	}
 
 	return serviceerror.FromStatus(st)
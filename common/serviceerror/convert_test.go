@@ -48,3 +48,19 @@ func TestFromToStatus(t *testing.T) {
 	assert.Equal(t, err.Message, solErr.Message)
 	assert.Equal(t, err.OwnerHost, solErr.OwnerHost)
 }
+
+func TestFromToStatus_ShardUnavailable(t *testing.T) {
+	err := NewShardUnavailable("shard closed")
+
+	st := serviceerror.ToStatus(err)
+	err1 := FromStatus(st)
+	var suErr *ShardUnavailable
+	if !errors.As(err1, &suErr) {
+		assert.Fail(t, "Returned error is not of type *ShardUnavailable")
+	}
+	assert.Equal(t, "shard closed", suErr.Message)
+
+	// other unavailable errors are left alone
+	err1 = FromStatus(serviceerror.ToStatus(serviceerror.NewUnavailable("shard closed")))
+	assert.IsType(t, &serviceerror.Unavailable{}, err1)
+}
@@ -0,0 +1,233 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgryski/go-farm"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+const (
+	// shardPartitionsRefreshInterval is how often ShardPartitions reads and parses the partitions again
+	shardPartitionsRefreshInterval = 10 * time.Second
+)
+
+type (
+	// ShardPartitions routes workflows to the shards of their namespace's partition, see
+	// WorkflowIDToHistoryShardInPartition. The partitions are parsed once per shardPartitionsRefreshInterval
+	// instead of on every call.
+	//
+	// Executions stay on the shard they started on, so partitions are meant for new namespaces: a partition added
+	// for a namespace is applied right away, while a partition which is changed or removed keeps routing as
+	// before until the process restarts, and the change is logged. Add a partition to a namespace with executions,
+	// or change one, only once tctl admin shard rebalance_plan has no execution left to move, and restart all
+	// services for a change.
+	ShardPartitions struct {
+		numberOfShards int32
+		partitionsFn   dynamicconfig.MapPropertyFn
+		timeSource     clock.TimeSource
+		logger         log.Logger
+
+		sync.RWMutex
+		partitions  map[string][]int32 // namespace ID -> shard IDs
+		raw         map[string]interface{}
+		refreshTime time.Time
+	}
+)
+
+// NewShardPartitions creates a ShardPartitions of the partitions given by partitionsFn, usually the
+// NamespaceShardPartitions dynamic config
+func NewShardPartitions(
+	numberOfShards int32,
+	partitionsFn dynamicconfig.MapPropertyFn,
+	timeSource clock.TimeSource,
+	logger log.Logger,
+) *ShardPartitions {
+	p := &ShardPartitions{
+		numberOfShards: numberOfShards,
+		partitionsFn:   partitionsFn,
+		timeSource:     timeSource,
+		logger:         logger,
+		partitions:     make(map[string][]int32),
+	}
+	p.refreshLocked(timeSource.Now())
+	return p
+}
+
+// GetShardID maps namespaceID-workflowID pair to a shardID within the partition of the namespace
+func (p *ShardPartitions) GetShardID(
+	namespaceID string,
+	workflowID string,
+) int32 {
+	shardIDs := p.getPartition(namespaceID)
+	if len(shardIDs) == 0 {
+		return WorkflowIDToHistoryShard(namespaceID, workflowID, p.numberOfShards)
+	}
+	return workflowIDToShardOf(namespaceID, workflowID, shardIDs)
+}
+
+func (p *ShardPartitions) getPartition(namespaceID string) []int32 {
+	now := p.timeSource.Now()
+	p.RLock()
+	shardIDs := p.partitions[namespaceID]
+	refresh := now.Sub(p.refreshTime) >= shardPartitionsRefreshInterval
+	p.RUnlock()
+	if !refresh {
+		return shardIDs
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if now.Sub(p.refreshTime) >= shardPartitionsRefreshInterval {
+		p.refreshLocked(now)
+	}
+	return p.partitions[namespaceID]
+}
+
+func (p *ShardPartitions) refreshLocked(now time.Time) {
+	p.refreshTime = now
+	raw := p.partitionsFn()
+	if reflect.DeepEqual(raw, p.raw) {
+		return
+	}
+	p.raw = raw
+
+	for namespaceID, partition := range raw {
+		if _, ok := p.partitions[namespaceID]; ok {
+			continue
+		}
+		if shardIDs := ParseShardPartition(partition, p.numberOfShards); len(shardIDs) > 0 {
+			p.partitions[namespaceID] = shardIDs
+		}
+	}
+	for namespaceID, shardIDs := range p.partitions {
+		if current := ParseShardPartition(raw[namespaceID], p.numberOfShards); !reflect.DeepEqual(current, shardIDs) {
+			p.logger.Error("Ignored change of namespace shard partition, it would strand the executions of the namespace on their shards. Restart to apply it.",
+				tag.WorkflowNamespaceID(namespaceID),
+				tag.Value(current),
+			)
+		}
+	}
+}
+
+// WorkflowIDToHistoryShardInPartition maps namespaceID-workflowID pair to a shardID within the partition of the
+// namespace, i.e. the subset of shards the namespace is constrained to. Partitions map namespace IDs to either a
+// list of shard IDs and shard ranges, e.g. [1, 2, "10-20"], or a single range string. Namespaces without a
+// partition, or whose partition has no valid shard, are spread across all shards by WorkflowIDToHistoryShard.
+func WorkflowIDToHistoryShardInPartition(
+	namespaceID string,
+	workflowID string,
+	numberOfShards int32,
+	partitions map[string]interface{},
+) int32 {
+	shardIDs := ParseShardPartition(partitions[namespaceID], numberOfShards)
+	if len(shardIDs) == 0 {
+		return WorkflowIDToHistoryShard(namespaceID, workflowID, numberOfShards)
+	}
+	return workflowIDToShardOf(namespaceID, workflowID, shardIDs)
+}
+
+func workflowIDToShardOf(
+	namespaceID string,
+	workflowID string,
+	shardIDs []int32,
+) int32 {
+	idBytes := []byte(namespaceID + "_" + workflowID)
+	hash := farm.Fingerprint32(idBytes)
+	return shardIDs[hash%uint32(len(shardIDs))]
+}
+
+// ParseShardPartition returns the sorted, distinct shard IDs of a partition, see WorkflowIDToHistoryShardInPartition.
+// Shard IDs outside of [1, numberOfShards] and malformed entries are ignored.
+func ParseShardPartition(
+	partition interface{},
+	numberOfShards int32,
+) []int32 {
+	shards := make(map[int32]struct{})
+	var add func(entry interface{})
+	add = func(entry interface{}) {
+		var first, last int64
+		switch entry := entry.(type) {
+		case int:
+			first, last = int64(entry), int64(entry)
+		case int32:
+			first, last = int64(entry), int64(entry)
+		case int64:
+			first, last = entry, entry
+		case float64:
+			first, last = int64(entry), int64(entry)
+		case string:
+			var err error
+			bounds := strings.SplitN(strings.TrimSpace(entry), "-", 2)
+			if first, err = strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 32); err != nil {
+				return
+			}
+			last = first
+			if len(bounds) == 2 {
+				if last, err = strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 32); err != nil {
+					return
+				}
+			}
+		case []interface{}:
+			for _, e := range entry {
+				add(e)
+			}
+			return
+		default:
+			return
+		}
+		if first < 1 {
+			first = 1
+		}
+		if last > int64(numberOfShards) {
+			last = int64(numberOfShards)
+		}
+		for shardID := first; shardID <= last; shardID++ {
+			shards[int32(shardID)] = struct{}{}
+		}
+	}
+	add(partition)
+
+	if len(shards) == 0 {
+		return nil
+	}
+	shardIDs := make([]int32, 0, len(shards))
+	for shardID := range shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Slice(shardIDs, func(i, j int) bool { return shardIDs[i] < shardIDs[j] })
+	return shardIDs
+}
@@ -0,0 +1,104 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+)
+
+func TestParseShardPartition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		partition interface{}
+		want      []int32
+	}{
+		{name: "nil", partition: nil, want: nil},
+		{name: "range", partition: "3-5", want: []int32{3, 4, 5}},
+		{name: "mixed list", partition: []interface{}{7, float64(1), "3-4", " 4 - 5 "}, want: []int32{1, 3, 4, 5, 7}},
+		{name: "out of bounds", partition: []interface{}{0, "6-20", 100}, want: []int32{6, 7, 8}},
+		{name: "malformed", partition: []interface{}{"a-b", true, "-1"}, want: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ParseShardPartition(tc.partition, 8))
+		})
+	}
+}
+
+func TestWorkflowIDToHistoryShardInPartition(t *testing.T) {
+	partitions := map[string]interface{}{"partitioned": "5-8"}
+	for i := 0; i < 100; i++ {
+		workflowID := "workflow-" + strconv.Itoa(i)
+
+		shardID := WorkflowIDToHistoryShardInPartition("partitioned", workflowID, 16, partitions)
+		assert.True(t, shardID >= 5 && shardID <= 8, "shard %v outside of partition", shardID)
+
+		assert.Equal(t,
+			WorkflowIDToHistoryShard("other", workflowID, 16),
+			WorkflowIDToHistoryShardInPartition("other", workflowID, 16, partitions),
+		)
+	}
+}
+
+func TestShardPartitions(t *testing.T) {
+	reads := 0
+	partitions := map[string]interface{}{"partitioned": "5-8"}
+	partitionsFn := func(opts ...dynamicconfig.FilterOption) map[string]interface{} {
+		reads++
+		return partitions
+	}
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	shardPartitions := NewShardPartitions(16, partitionsFn, timeSource, log.NewNoopLogger())
+	assertInPartition := func(namespaceID string, first int32, last int32) {
+		for i := 0; i < 100; i++ {
+			shardID := shardPartitions.GetShardID(namespaceID, "workflow-"+strconv.Itoa(i))
+			assert.True(t, shardID >= first && shardID <= last, "shard %v outside of partition", shardID)
+		}
+	}
+
+	assertInPartition("partitioned", 5, 8)
+	assert.Equal(t, 1, reads)
+
+	// changed partitions keep routing as before, added ones apply on refresh
+	partitions = map[string]interface{}{"partitioned": "1-2", "added": "9-10"}
+	timeSource.Update(timeSource.Now().Add(shardPartitionsRefreshInterval))
+	assertInPartition("partitioned", 5, 8)
+	assertInPartition("added", 9, 10)
+	assert.Equal(t, 2, reads)
+
+	partitions = map[string]interface{}{}
+	timeSource.Update(timeSource.Now().Add(shardPartitionsRefreshInterval))
+	assertInPartition("partitioned", 5, 8)
+	assertInPartition("added", 9, 10)
+	assert.Equal(t, 3, reads)
+}
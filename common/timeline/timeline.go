@@ -0,0 +1,222 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package timeline
+
+import (
+	"sort"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+type (
+	// OperationType is the kind of logical operation an Operation represents.
+	OperationType string
+
+	// OperationStatus is the last known state of an Operation.
+	OperationStatus string
+
+	// Operation is a group of history events describing a single logical step of a workflow
+	// execution, e.g. an activity together with its retries or a timer from start to fire.
+	Operation struct {
+		Type OperationType
+		// ID is the activity ID, timer ID or child workflow ID. Empty for workflow tasks.
+		ID string
+		// Name is the activity or child workflow type name.
+		Name string
+		// FirstEventID is the ID of the event that started the operation.
+		FirstEventID int64
+		// LastEventID is the ID of the last event seen for the operation.
+		LastEventID int64
+		StartTime   time.Time
+		// EndTime is zero while the operation is still open.
+		EndTime time.Time
+		// Duration is measured up to EndTime, or up to the time the timeline was built for
+		// operations that are still open.
+		Duration time.Duration
+		// Attempts is the number of attempts reported by the server for activities.
+		Attempts int32
+		Status   OperationStatus
+		// Failure is the message of the failure that closed the operation, or of the last
+		// failed attempt for an activity that was retried.
+		Failure string
+	}
+)
+
+const (
+	OperationTypeWorkflowTask  OperationType = "WorkflowTask"
+	OperationTypeActivity      OperationType = "Activity"
+	OperationTypeTimer         OperationType = "Timer"
+	OperationTypeChildWorkflow OperationType = "ChildWorkflow"
+)
+
+const (
+	OperationStatusScheduled  OperationStatus = "Scheduled"
+	OperationStatusRunning    OperationStatus = "Running"
+	OperationStatusCompleted  OperationStatus = "Completed"
+	OperationStatusFailed     OperationStatus = "Failed"
+	OperationStatusTimedOut   OperationStatus = "TimedOut"
+	OperationStatusCanceled   OperationStatus = "Canceled"
+	OperationStatusTerminated OperationStatus = "Terminated"
+	OperationStatusFired      OperationStatus = "Fired"
+)
+
+// Build groups history events into logical operations ordered by the event that started them.
+// Operations that are still open have their duration computed relative to now.
+func Build(events []*historypb.HistoryEvent, now time.Time) []*Operation {
+	var operations []*Operation
+	// keyed by the ID of the event that started the operation
+	open := make(map[int64]*Operation)
+
+	start := func(event *historypb.HistoryEvent, operationType OperationType, id string, name string) *Operation {
+		op := &Operation{
+			Type:         operationType,
+			ID:           id,
+			Name:         name,
+			FirstEventID: event.GetEventId(),
+			LastEventID:  event.GetEventId(),
+			StartTime:    timestamp.TimeValue(event.GetEventTime()),
+			Status:       OperationStatusScheduled,
+		}
+		operations = append(operations, op)
+		open[event.GetEventId()] = op
+		return op
+	}
+	update := func(event *historypb.HistoryEvent, firstEventID int64, status OperationStatus) *Operation {
+		op, ok := open[firstEventID]
+		if !ok {
+			return nil
+		}
+		op.LastEventID = event.GetEventId()
+		op.Status = status
+		if status != OperationStatusRunning && status != OperationStatusScheduled {
+			op.EndTime = timestamp.TimeValue(event.GetEventTime())
+			delete(open, firstEventID)
+		}
+		return op
+	}
+	closeWithFailure := func(event *historypb.HistoryEvent, firstEventID int64, status OperationStatus, failure *failurepb.Failure) {
+		if op := update(event, firstEventID, status); op != nil && failure != nil {
+			op.Failure = failure.GetMessage()
+		}
+	}
+
+	for _, event := range events {
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED:
+			start(event, OperationTypeWorkflowTask, "", "")
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED:
+			update(event, event.GetWorkflowTaskStartedEventAttributes().GetScheduledEventId(), OperationStatusRunning)
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED:
+			update(event, event.GetWorkflowTaskCompletedEventAttributes().GetScheduledEventId(), OperationStatusCompleted)
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED:
+			attributes := event.GetWorkflowTaskFailedEventAttributes()
+			closeWithFailure(event, attributes.GetScheduledEventId(), OperationStatusFailed, attributes.GetFailure())
+		case enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT:
+			update(event, event.GetWorkflowTaskTimedOutEventAttributes().GetScheduledEventId(), OperationStatusTimedOut)
+
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			attributes := event.GetActivityTaskScheduledEventAttributes()
+			start(event, OperationTypeActivity, attributes.GetActivityId(), attributes.GetActivityType().GetName())
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+			attributes := event.GetActivityTaskStartedEventAttributes()
+			if op := update(event, attributes.GetScheduledEventId(), OperationStatusRunning); op != nil {
+				op.Attempts = attributes.GetAttempt()
+				if attributes.GetLastFailure() != nil {
+					op.Failure = attributes.GetLastFailure().GetMessage()
+				}
+			}
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			update(event, event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId(), OperationStatusCompleted)
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			attributes := event.GetActivityTaskFailedEventAttributes()
+			closeWithFailure(event, attributes.GetScheduledEventId(), OperationStatusFailed, attributes.GetFailure())
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			attributes := event.GetActivityTaskTimedOutEventAttributes()
+			closeWithFailure(event, attributes.GetScheduledEventId(), OperationStatusTimedOut, attributes.GetFailure())
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+			update(event, event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(), OperationStatusCanceled)
+
+		case enumspb.EVENT_TYPE_TIMER_STARTED:
+			attributes := event.GetTimerStartedEventAttributes()
+			op := start(event, OperationTypeTimer, attributes.GetTimerId(), "")
+			op.Status = OperationStatusRunning
+		case enumspb.EVENT_TYPE_TIMER_FIRED:
+			update(event, event.GetTimerFiredEventAttributes().GetStartedEventId(), OperationStatusFired)
+		case enumspb.EVENT_TYPE_TIMER_CANCELED:
+			update(event, event.GetTimerCanceledEventAttributes().GetStartedEventId(), OperationStatusCanceled)
+
+		case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+			attributes := event.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+			start(event, OperationTypeChildWorkflow, attributes.GetWorkflowId(), attributes.GetWorkflowType().GetName())
+		case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+			attributes := event.GetStartChildWorkflowExecutionFailedEventAttributes()
+			if op := update(event, attributes.GetInitiatedEventId(), OperationStatusFailed); op != nil {
+				op.Failure = attributes.GetCause().String()
+			}
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+			update(event, event.GetChildWorkflowExecutionStartedEventAttributes().GetInitiatedEventId(), OperationStatusRunning)
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+			update(event, event.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventId(), OperationStatusCompleted)
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+			attributes := event.GetChildWorkflowExecutionFailedEventAttributes()
+			closeWithFailure(event, attributes.GetInitiatedEventId(), OperationStatusFailed, attributes.GetFailure())
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:
+			update(event, event.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventId(), OperationStatusTimedOut)
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+			update(event, event.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventId(), OperationStatusCanceled)
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:
+			update(event, event.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId(), OperationStatusTerminated)
+		}
+	}
+
+	for _, op := range operations {
+		end := op.EndTime
+		if end.IsZero() {
+			end = now
+		}
+		if end.After(op.StartTime) {
+			op.Duration = end.Sub(op.StartTime)
+		}
+	}
+	return operations
+}
+
+// Longest returns up to n operations with the largest duration, longest first.
+func Longest(operations []*Operation, n int) []*Operation {
+	sorted := make([]*Operation, len(operations))
+	copy(sorted, operations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+	if n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
@@ -0,0 +1,141 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+func TestBuild(t *testing.T) {
+	assert := assert.New(t)
+
+	base := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) *time.Time {
+		return timestamp.TimePtr(base.Add(time.Duration(seconds) * time.Second))
+	}
+	events := []*historypb.HistoryEvent{
+		{
+			EventId:   1,
+			EventTime: at(0),
+			EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+			Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+				ActivityId:   "activity-1",
+				ActivityType: &commonpb.ActivityType{Name: "download"},
+			}},
+		},
+		{
+			EventId:   2,
+			EventTime: at(1),
+			EventType: enumspb.EVENT_TYPE_TIMER_STARTED,
+			Attributes: &historypb.HistoryEvent_TimerStartedEventAttributes{TimerStartedEventAttributes: &historypb.TimerStartedEventAttributes{
+				TimerId: "timer-1",
+			}},
+		},
+		{
+			EventId:   3,
+			EventTime: at(30),
+			EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED,
+			Attributes: &historypb.HistoryEvent_ActivityTaskStartedEventAttributes{ActivityTaskStartedEventAttributes: &historypb.ActivityTaskStartedEventAttributes{
+				ScheduledEventId: 1,
+				Attempt:          3,
+				LastFailure:      &failurepb.Failure{Message: "connection reset"},
+			}},
+		},
+		{
+			EventId:   4,
+			EventTime: at(40),
+			EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+			Attributes: &historypb.HistoryEvent_ActivityTaskFailedEventAttributes{ActivityTaskFailedEventAttributes: &historypb.ActivityTaskFailedEventAttributes{
+				ScheduledEventId: 1,
+				StartedEventId:   3,
+				Failure:          &failurepb.Failure{Message: "disk full"},
+			}},
+		},
+		{
+			EventId:   5,
+			EventTime: at(50),
+			EventType: enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED,
+			Attributes: &historypb.HistoryEvent_StartChildWorkflowExecutionInitiatedEventAttributes{StartChildWorkflowExecutionInitiatedEventAttributes: &historypb.StartChildWorkflowExecutionInitiatedEventAttributes{
+				WorkflowId:   "child-1",
+				WorkflowType: &commonpb.WorkflowType{Name: "child-type"},
+			}},
+		},
+		{
+			EventId:   6,
+			EventTime: at(51),
+			EventType: enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED,
+			Attributes: &historypb.HistoryEvent_ChildWorkflowExecutionStartedEventAttributes{ChildWorkflowExecutionStartedEventAttributes: &historypb.ChildWorkflowExecutionStartedEventAttributes{
+				InitiatedEventId: 5,
+			}},
+		},
+		{
+			EventId:   7,
+			EventTime: at(61),
+			EventType: enumspb.EVENT_TYPE_TIMER_FIRED,
+			Attributes: &historypb.HistoryEvent_TimerFiredEventAttributes{TimerFiredEventAttributes: &historypb.TimerFiredEventAttributes{
+				TimerId:        "timer-1",
+				StartedEventId: 2,
+			}},
+		},
+	}
+
+	operations := Build(events, base.Add(100*time.Second))
+	assert.Len(operations, 3)
+
+	activity := operations[0]
+	assert.Equal(OperationTypeActivity, activity.Type)
+	assert.Equal("activity-1", activity.ID)
+	assert.Equal("download", activity.Name)
+	assert.Equal(int64(1), activity.FirstEventID)
+	assert.Equal(int64(4), activity.LastEventID)
+	assert.Equal(int32(3), activity.Attempts)
+	assert.Equal(OperationStatusFailed, activity.Status)
+	assert.Equal("disk full", activity.Failure)
+	assert.Equal(40*time.Second, activity.Duration)
+
+	timer := operations[1]
+	assert.Equal(OperationTypeTimer, timer.Type)
+	assert.Equal(OperationStatusFired, timer.Status)
+	assert.Equal(60*time.Second, timer.Duration)
+
+	child := operations[2]
+	assert.Equal(OperationTypeChildWorkflow, child.Type)
+	assert.Equal("child-type", child.Name)
+	assert.Equal(OperationStatusRunning, child.Status)
+	assert.True(child.EndTime.IsZero())
+	assert.Equal(50*time.Second, child.Duration)
+
+	longest := Longest(operations, 2)
+	assert.Equal([]*Operation{timer, child}, longest)
+}
@@ -92,6 +92,10 @@ const (
 	maximumIntervalCoefficientConfigKey = "MaximumIntervalCoefficient"
 	backoffCoefficientConfigKey         = "BackoffCoefficient"
 	maximumAttemptsConfigKey            = "MaximumAttempts"
+	initialIntervalConfigKey            = "InitialInterval"
+	maximumIntervalConfigKey            = "MaximumInterval"
+	expirationIntervalConfigKey         = "ExpirationInterval"
+	jitterCoefficientConfigKey          = "JitterCoefficient"
 
 	contextExpireThreshold = 10 * time.Millisecond
 
@@ -105,6 +109,8 @@ const (
 	FailureReasonHeartbeatExceedsLimit = "Heartbeat details exceed size limit."
 	// FailureReasonSizeExceedsLimit is reason to fail workflow when history size or count exceed limit
 	FailureReasonSizeExceedsLimit = "Workflow history size / count exceeds limit."
+	// FailureReasonMutableStateSizeExceedsLimit is reason to terminate workflow when mutable state size exceeds limit
+	FailureReasonMutableStateSizeExceedsLimit = "Workflow mutable state size exceeds limit."
 	// FailureReasonTransactionSizeExceedsLimit is the failureReason for when transaction cannot be committed because it exceeds size limit
 	FailureReasonTransactionSizeExceedsLimit = "Transaction size exceeds limit."
 )
@@ -147,6 +153,28 @@ func CreatePersistenceRetryPolicy() backoff.RetryPolicy {
 	return policy
 }
 
+// GetPersistenceRetryPolicySettings returns the settings of the default persistence retry policy
+func GetPersistenceRetryPolicySettings() RetryPolicySettings {
+	return RetryPolicySettings{
+		InitialInterval:    retryPersistenceOperationInitialInterval,
+		MaximumInterval:    retryPersistenceOperationMaxInterval,
+		ExpirationInterval: retryPersistenceOperationExpirationInterval,
+	}
+}
+
+// CreateRetryPolicy creates an exponential retry policy from settings
+func CreateRetryPolicy(settings RetryPolicySettings) backoff.RetryPolicy {
+	policy := backoff.NewExponentialRetryPolicy(settings.InitialInterval)
+	policy.SetMaximumInterval(settings.MaximumInterval)
+	policy.SetExpirationInterval(settings.ExpirationInterval)
+	policy.SetMaximumAttempts(settings.MaximumAttempts)
+	if settings.JitterCoefficient > 0 {
+		policy.SetJitterCoefficient(settings.JitterCoefficient)
+	}
+
+	return policy
+}
+
 // CreateHistoryServiceRetryPolicy creates a retry policy for calls to history service
 func CreateHistoryServiceRetryPolicy() backoff.RetryPolicy {
 	policy := backoff.NewExponentialRetryPolicy(historyServiceOperationInitialInterval)
@@ -218,6 +246,7 @@ func IsServiceTransientError(err error) bool {
 	case *serviceerror.NotFound,
 		*serviceerror.InvalidArgument,
 		*serviceerror.NamespaceNotActive,
+		*serviceerror.PermissionDenied,
 		*serviceerror.WorkflowExecutionAlreadyStarted:
 		return false
 	}
@@ -253,6 +282,7 @@ func IsWhitelistServiceTransientError(err error) bool {
 	case *serviceerror.Internal,
 		*serviceerror.ResourceExhausted,
 		*serviceerrors.ShardOwnershipLost,
+		*serviceerrors.ShardUnavailable,
 		*serviceerror.Unavailable:
 		return true
 	}
@@ -515,6 +545,40 @@ func FromConfigToDefaultRetrySettings(options map[string]interface{}) DefaultRet
 	return defaultSettings
 }
 
+// FromConfigToRetryPolicySettings overrides defaultSettings with the options set in dynamic config. Intervals are
+// durations such as "500ms", options which are missing or invalid keep their default value.
+func FromConfigToRetryPolicySettings(options map[string]interface{}, defaultSettings RetryPolicySettings) RetryPolicySettings {
+	settings := defaultSettings
+	getDuration := func(key string, defaultValue time.Duration) time.Duration {
+		value, ok := options[key].(string)
+		if !ok {
+			return defaultValue
+		}
+		d, err := timestamp.ParseDurationDefaultDays(value)
+		if err != nil || d < 0 {
+			return defaultValue
+		}
+		return d
+	}
+
+	settings.InitialInterval = getDuration(initialIntervalConfigKey, defaultSettings.InitialInterval)
+	settings.MaximumInterval = getDuration(maximumIntervalConfigKey, defaultSettings.MaximumInterval)
+	settings.ExpirationInterval = getDuration(expirationIntervalConfigKey, defaultSettings.ExpirationInterval)
+
+	if attempts, ok := options[maximumAttemptsConfigKey]; ok {
+		settings.MaximumAttempts = number.NewNumber(
+			attempts,
+		).GetIntOrDefault(defaultSettings.MaximumAttempts)
+	}
+	if jitter, ok := options[jitterCoefficientConfigKey]; ok {
+		settings.JitterCoefficient = number.NewNumber(
+			jitter,
+		).GetFloatOrDefault(defaultSettings.JitterCoefficient)
+	}
+
+	return settings
+}
+
 // CreateHistoryStartWorkflowRequest create a start workflow request for history
 func CreateHistoryStartWorkflowRequest(
 	namespaceID string,
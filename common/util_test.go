@@ -231,6 +231,28 @@ func Test_FromConfigToRetryPolicy(t *testing.T) {
 	assert.Equal(t, int32(5), defaultSettings.MaximumAttempts)
 }
 
+func Test_FromConfigToRetryPolicySettings(t *testing.T) {
+	defaultSettings := GetPersistenceRetryPolicySettings()
+
+	settings := FromConfigToRetryPolicySettings(map[string]interface{}{
+		initialIntervalConfigKey:    "100ms",
+		expirationIntervalConfigKey: "2m",
+		maximumAttemptsConfigKey:    10,
+		jitterCoefficientConfigKey:  0.5,
+	}, defaultSettings)
+	assert.Equal(t, 100*time.Millisecond, settings.InitialInterval)
+	assert.Equal(t, defaultSettings.MaximumInterval, settings.MaximumInterval)
+	assert.Equal(t, 2*time.Minute, settings.ExpirationInterval)
+	assert.Equal(t, 10, settings.MaximumAttempts)
+	assert.Equal(t, 0.5, settings.JitterCoefficient)
+
+	settings = FromConfigToRetryPolicySettings(map[string]interface{}{
+		initialIntervalConfigKey: "invalid",
+		maximumIntervalConfigKey: 5,
+	}, defaultSettings)
+	assert.Equal(t, defaultSettings, settings)
+}
+
 func TestIsContextDeadlineExceededErr(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
 	defer cancel()
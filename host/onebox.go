@@ -60,11 +60,14 @@ import (
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/resolver"
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/common/rpc"
 	"go.temporal.io/server/service/frontend"
 	"go.temporal.io/server/service/history"
+	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/workflow"
 	"go.temporal.io/server/service/matching"
 	"go.temporal.io/server/service/worker"
 	"go.temporal.io/server/service/worker/archiver"
@@ -412,6 +415,7 @@ func (c *temporalImpl) startFrontend(hosts map[string][]string, startWG *sync.Wa
 		fx.Provide(func() searchattribute.Mapper { return nil }),
 		fx.Provide(func() resolver.ServiceResolver { return resolver.NewNoopResolver() }),
 		fx.Provide(func() persistenceClient.AbstractDataStoreFactory { return nil }),
+		fx.Provide(func() serialization.EncryptionProvider { return nil }),
 		fx.Provide(func() dynamicconfig.Client { return newIntegrationConfigClient(dynamicconfig.NewNoopClient()) }),
 		fx.Provide(func() log.Logger { return c.logger }),
 		fx.Provide(func() *esclient.Config { return c.esConfig }),
@@ -507,10 +511,13 @@ func (c *temporalImpl) startHistory(
 			fx.Provide(func() searchattribute.Mapper { return nil }),
 			fx.Provide(func() resolver.ServiceResolver { return resolver.NewNoopResolver() }),
 			fx.Provide(func() persistenceClient.AbstractDataStoreFactory { return nil }),
+			fx.Provide(func() serialization.EncryptionProvider { return nil }),
 			fx.Provide(func() dynamicconfig.Client { return integrationClient }),
 			fx.Provide(func() log.Logger { return c.logger }),
 			fx.Provide(func() *esclient.Config { return c.esConfig }),
 			fx.Provide(func() esclient.Client { return c.esClient }),
+			fx.Provide(func() shard.LifecycleObservers { return nil }),
+			fx.Provide(func() workflow.EventsReapplyPolicy { return nil }),
 			history.Module,
 			fx.Populate(&historyService),
 			fx.NopLogger)
@@ -580,6 +587,7 @@ func (c *temporalImpl) startMatching(hosts map[string][]string, startWG *sync.Wa
 		fx.Provide(func() searchattribute.Mapper { return nil }),
 		fx.Provide(func() resolver.ServiceResolver { return resolver.NewNoopResolver() }),
 		fx.Provide(func() persistenceClient.AbstractDataStoreFactory { return nil }),
+		fx.Provide(func() serialization.EncryptionProvider { return nil }),
 		fx.Provide(func() dynamicconfig.Client { return newIntegrationConfigClient(dynamicconfig.NewNoopClient()) }),
 		fx.Provide(func() log.Logger { return c.logger }),
 		matching.Module,
@@ -87,3 +87,11 @@ func (s *simpleMonitor) GetReachableMembers() ([]string, error) {
 func (s *simpleMonitor) GetMemberCount(service string) (int, error) {
 	return 0, nil
 }
+
+func (s *simpleMonitor) ReportUnreachable(service string, address string) error {
+	return nil
+}
+
+func (s *simpleMonitor) SetLabel(key string, value string) error {
+	return nil
+}
@@ -27,7 +27,7 @@ package cassandra
 // NOTE: whenever there is a new database schema update, plz update the following versions
 
 // Version is the Cassandra database release version
-const Version = "1.6"
+const Version = "1.7"
 
 // VisibilityVersion is the Cassandra visibility database release version
 const VisibilityVersion = "1.0"
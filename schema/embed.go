@@ -0,0 +1,40 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package schema
+
+import (
+	"embed"
+)
+
+// ElasticsearchIndexTemplatePath is the path in Assets of the current Elasticsearch index template of an Elasticsearch
+// version, e.g. v7. The templates are embedded from the versioned directory, because go:embed rejects the symlinks
+// which point to them.
+const ElasticsearchIndexTemplatePath = "elasticsearch/visibility/versioned/v1/index_template_%s.json"
+
+// Assets contains the versioned schema directories and the Elasticsearch index templates,
+// so that the server binary can migrate the schema it was built against.
+//
+//go:embed cassandra/*/versioned mysql/v57/*/versioned postgresql/v96/*/versioned elasticsearch/visibility/versioned/v1/index_template_*.json
+var Assets embed.FS
@@ -0,0 +1,2 @@
+ALTER TABLE history_node ADD checksum MEDIUMBLOB;
+ALTER TABLE history_node ADD checksum_encoding VARCHAR(16) NOT NULL DEFAULT '';
@@ -227,6 +227,8 @@ CREATE TABLE history_node (
   prev_txn_id    BIGINT NOT NULL DEFAULT 0,
   data           BYTEA NOT NULL,
   data_encoding  VARCHAR(16) NOT NULL,
+  checksum       BYTEA,
+  checksum_encoding VARCHAR(16) NOT NULL DEFAULT '',
   PRIMARY KEY (shard_id, tree_id, branch_id, node_id, txn_id)
 );
 
@@ -328,6 +328,7 @@ func (adh *AdminHandler) AddSearchAttributes(ctx context.Context, request *admin
 	wfParams := addsearchattributes.WorkflowParams{
 		CustomAttributesToAdd: request.GetSearchAttributes(),
 		IndexName:             indexName,
+		NamespaceIndexNames:   adh.namespaceIndexNames(indexName),
 		SkipSchemaUpdate:      request.GetSkipSchemaUpdate(),
 	}
 
@@ -401,9 +402,37 @@ func (adh *AdminHandler) RemoveSearchAttributes(_ context.Context, request *admi
 		return nil, adh.error(serviceerror.NewUnavailable(fmt.Sprintf(errUnableToSaveSearchAttributesMessage, err)), scope)
 	}
 
+	// Dedicated namespace indices follow default index.
+	for _, namespaceIndexName := range adh.namespaceIndexNames(indexName) {
+		namespaceIndexSearchAttributes, err := adh.Resource.GetSearchAttributesProvider().GetSearchAttributes(namespaceIndexName, true)
+		if err != nil {
+			return nil, adh.error(serviceerror.NewUnavailable(fmt.Sprintf(errUnableToGetSearchAttributesMessage, err)), scope)
+		}
+		newNamespaceIndexSearchAttributes := map[string]enumspb.IndexedValueType{}
+		for saName, saType := range namespaceIndexSearchAttributes.Custom() {
+			newNamespaceIndexSearchAttributes[saName] = saType
+		}
+		for _, saName := range request.GetSearchAttributes() {
+			delete(newNamespaceIndexSearchAttributes, saName)
+		}
+		err = adh.Resource.GetSearchAttributesManager().SaveSearchAttributes(namespaceIndexName, newNamespaceIndexSearchAttributes)
+		if err != nil {
+			return nil, adh.error(serviceerror.NewUnavailable(fmt.Sprintf(errUnableToSaveSearchAttributesMessage, err)), scope)
+		}
+	}
+
 	return &adminservice.RemoveSearchAttributesResponse{}, nil
 }
 
+// namespaceIndexNames returns dedicated namespace indices which follow search attributes of indexName.
+// Only default visibility index has followers.
+func (adh *AdminHandler) namespaceIndexNames(indexName string) []string {
+	if indexName == "" || indexName != adh.ESConfig.GetVisibilityIndex() {
+		return nil
+	}
+	return adh.ESConfig.GetNamespaceVisibilityIndexNames()
+}
+
 func (adh *AdminHandler) GetSearchAttributes(ctx context.Context, request *adminservice.GetSearchAttributesRequest) (_ *adminservice.GetSearchAttributesResponse, retError error) {
 	defer log.CapturePanic(adh.GetLogger(), &retError)
 
@@ -481,7 +510,7 @@ func (adh *AdminHandler) DescribeMutableState(ctx context.Context, request *admi
 
 	namespaceID, err := adh.GetNamespaceRegistry().GetNamespaceID(namespace.Name(request.GetNamespace()))
 
-	shardID := common.WorkflowIDToHistoryShard(namespaceID.String(), request.Execution.WorkflowId, adh.numberOfHistoryShards)
+	shardID := adh.config.NamespaceShardPartitions.GetShardID(namespaceID.String(), request.Execution.WorkflowId)
 	shardIDStr := convert.Int32ToString(shardID)
 
 	historyHost, err := adh.GetMembershipMonitor().Lookup(common.HistoryServiceName, shardIDStr)
@@ -871,11 +900,7 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, r
 		}, nil
 	}
 	pageSize := int(request.GetMaximumPageSize())
-	shardID := common.WorkflowIDToHistoryShard(
-		namespaceID.String(),
-		execution.GetWorkflowId(),
-		adh.numberOfHistoryShards,
-	)
+	shardID := adh.config.NamespaceShardPartitions.GetShardID(namespaceID.String(), execution.GetWorkflowId())
 	rawHistoryResponse, err := adh.GetExecutionManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken: targetVersionHistory.GetBranchToken(),
 		// GetWorkflowExecutionRawHistoryV2 is exclusive exclusive.
@@ -1766,6 +1791,8 @@ func getTaskType(task tasks.Task) (enumsspb.TaskType, error) {
 		taskType = enumsspb.TASK_TYPE_WORKFLOW_RUN_TIMEOUT
 	case *tasks.DeleteHistoryEventTask:
 		taskType = enumsspb.TASK_TYPE_DELETE_HISTORY_EVENT
+	case *tasks.DeleteExecutionVisibilityTimerTask:
+		taskType = enumsspb.TASK_TYPE_VISIBILITY_DELETE_EXECUTION
 	default:
 		return 0, serviceerror.NewInternal(fmt.Sprintf("Unknown timer task type: %v", task))
 	}
@@ -52,7 +52,10 @@ import (
 	"go.temporal.io/server/api/historyservicemock/v1"
 	clientmocks "go.temporal.io/server/client"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
@@ -61,6 +64,7 @@ import (
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/service/worker/addsearchattributes"
 )
 
 type (
@@ -116,7 +120,14 @@ func (s *adminHandlerSuite) SetupTest() {
 			NumHistoryShards: 1,
 		},
 	}
-	config := &Config{}
+	config := &Config{
+		NamespaceShardPartitions: common.NewShardPartitions(
+			1,
+			dynamicconfig.GetMapPropertyFn(nil),
+			clock.NewRealTimeSource(),
+			log.NewNoopLogger(),
+		),
+	}
 	s.handler = NewAdminHandler(s.mockResource, params, config, s.mockProducer, nil, s.mockResource.ESClient, s.mockVisibilityMgr)
 	s.handler.Start()
 }
@@ -542,6 +553,26 @@ func (s *adminHandlerSuite) Test_AddSearchAttributes() {
 	})
 	s.NoError(err)
 	s.NotNil(resp)
+
+	// Dedicated namespace indices get search attributes added to default index.
+	handler.ESConfig.NamespaceIndices = map[string]string{
+		"namespace-1": "random-namespace-index-name",
+		"namespace-2": "random-namespace-index-name",
+	}
+	resp, err = handler.AddSearchAttributes(ctx, &adminservice.AddSearchAttributesRequest{
+		SearchAttributes: map[string]enumspb.IndexedValueType{
+			"CustomAttr2": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		},
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+	s.mockResource.SDKClient.AssertCalled(s.T(), "ExecuteWorkflow", mock.Anything, mock.Anything, "temporal-sys-add-search-attributes-workflow", addsearchattributes.WorkflowParams{
+		CustomAttributesToAdd: map[string]enumspb.IndexedValueType{
+			"CustomAttr2": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		},
+		IndexName:           "random-index-name",
+		NamespaceIndexNames: []string{"random-namespace-index-name"},
+	})
 }
 
 func (s *adminHandlerSuite) Test_GetSearchAttributes() {
@@ -701,6 +732,27 @@ func (s *adminHandlerSuite) Test_RemoveSearchAttributes() {
 	})
 	s.NoError(err)
 	s.NotNil(resp)
+
+	// Success case with dedicated namespace index.
+	handler.ESConfig.NamespaceIndices = map[string]string{
+		"namespace-1": "random-namespace-index-name",
+	}
+	s.mockResource.SearchAttributesManager.EXPECT().SaveSearchAttributes("random-index-name", gomock.Any()).Return(nil)
+	s.mockResource.SearchAttributesProvider.EXPECT().GetSearchAttributes("random-namespace-index-name", true).Return(searchattribute.TestNameTypeMap, nil)
+	s.mockResource.SearchAttributesManager.EXPECT().SaveSearchAttributes("random-namespace-index-name", gomock.Any()).DoAndReturn(
+		func(_ string, newCustomSearchAttributes map[string]enumspb.IndexedValueType) error {
+			s.NotContains(newCustomSearchAttributes, "CustomKeywordField")
+			s.Contains(newCustomSearchAttributes, "CustomTextField")
+			return nil
+		})
+
+	resp, err = handler.RemoveSearchAttributes(ctx, &adminservice.RemoveSearchAttributesRequest{
+		SearchAttributes: []string{
+			"CustomKeywordField",
+		},
+	})
+	s.NoError(err)
+	s.NotNil(resp)
 }
 
 func (s *adminHandlerSuite) Test_RemoveRemoteCluster_Success() {
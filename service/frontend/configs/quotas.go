@@ -106,6 +106,23 @@ var (
 	OtherAPIPriorities = map[int]struct{}{
 		0: {},
 	}
+
+	// MaintenanceModeAllowedAPIs are the workflow service APIs still served while the cluster is in
+	// maintenance mode, so that in-flight work can make progress
+	MaintenanceModeAllowedAPIs = map[string]struct{}{
+		"GetClusterInfo":                   {},
+		"RecordActivityTaskHeartbeat":      {},
+		"RecordActivityTaskHeartbeatById":  {},
+		"RespondActivityTaskCanceled":      {},
+		"RespondActivityTaskCanceledById":  {},
+		"RespondActivityTaskFailed":        {},
+		"RespondActivityTaskFailedById":    {},
+		"RespondActivityTaskCompleted":     {},
+		"RespondActivityTaskCompletedById": {},
+		"RespondWorkflowTaskCompleted":     {},
+		"RespondWorkflowTaskFailed":        {},
+		"RespondQueryTaskCompleted":        {},
+	}
 )
 
 type (
@@ -84,6 +84,13 @@ var selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs = map[string]struct{}
 	"QueryWorkflow":                    {},
 }
 
+// selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs contains a list of read APIs which are served by
+// the current cluster, and are redirected only if the standby replica is too stale to serve them
+var selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs = map[string]struct{}{
+	"DescribeWorkflowExecution":   {},
+	"GetWorkflowExecutionHistory": {},
+}
+
 // RedirectionPolicyGenerator generate corresponding redirection policy
 func RedirectionPolicyGenerator(clusterMetadata cluster.Metadata, config *Config,
 	namespaceRegistry namespace.Registry, policy config.DCRedirectionPolicy) DCRedirectionPolicy {
@@ -180,6 +187,11 @@ func (policy *SelectedAPIsForwardingRedirectionPolicy) getTargetClusterAndIsName
 		return policy.currentClusterName, false
 	}
 
+	if _, ok := selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs[apiName]; ok {
+		// serve standby reads locally, history rejects them with namespace not active error if replication lag is too high
+		return policy.currentClusterName, true
+	}
+
 	_, ok := selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs[apiName]
 	if !ok {
 		// do not do dc redirection if API is not whitelisted
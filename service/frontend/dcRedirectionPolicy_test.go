@@ -334,6 +334,40 @@ func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_G
 	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs), alternativeClustercallCount)
 }
 
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_GlobalNamespace_Forwarding_StandbyRead() {
+	s.setupGlobalNamespaceWithTwoReplicationCluster(true, false)
+
+	currentClustercallCount := 0
+	alternativeClustercallCount := 0
+	callFn := func(targetCluster string) error {
+		switch targetCluster {
+		case s.currentClusterName:
+			currentClustercallCount++
+			if currentClustercallCount%2 == 0 {
+				// standby replica is too stale
+				return serviceerror.NewNamespaceNotActive("", s.currentClusterName, s.alternativeClusterName)
+			}
+			return nil
+		case s.alternativeClusterName:
+			alternativeClustercallCount++
+			return nil
+		default:
+			panic(fmt.Sprintf("unknown cluster name %v", targetCluster))
+		}
+	}
+
+	for apiName := range selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs {
+		err := s.policy.WithNamespaceIDRedirect(context.Background(), s.namespaceID, apiName, callFn)
+		s.Nil(err)
+
+		err = s.policy.WithNamespaceRedirect(context.Background(), s.namespace, apiName, callFn)
+		s.Nil(err)
+	}
+
+	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs), currentClustercallCount)
+	s.Equal(len(selectedAPIsForwardingRedirectionPolicyStandbyReadAPIs), alternativeClustercallCount)
+}
+
 func (s *selectedAPIsForwardingRedirectionPolicySuite) setupLocalNamespace() {
 	namespaceEntry := namespace.NewLocalNamespaceForTest(
 		&persistencespb.NamespaceInfo{Id: s.namespaceID.String(), Name: s.namespace.String()},
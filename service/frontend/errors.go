@@ -71,6 +71,7 @@ var (
 	errFailureMustHaveApplicationFailureInfo              = serviceerror.NewInvalidArgument("Failure must have ApplicationFailureInfo.")
 	errStatusFilterMustBeNotRunning                       = serviceerror.NewInvalidArgument("StatusFilter must be specified and must be not Running.")
 	errShuttingDown                                       = serviceerror.NewUnavailable("Shutting down")
+	errTooManyHistoryLongPolls                            = serviceerror.NewResourceExhausted("Too many concurrent history long polls of the caller, please retry later.")
 
 	errPageSizeTooBigMessage = "PageSize is larger than allowed %d."
 
@@ -64,6 +64,7 @@ var Module = fx.Options(
 	fx.Provide(NamespaceCountLimitInterceptorProvider),
 	fx.Provide(NamespaceValidatorInterceptorProvider),
 	fx.Provide(NamespaceRateLimitInterceptorProvider),
+	fx.Provide(MaintenanceModeInterceptorProvider),
 	fx.Provide(GrpcServerOptionsProvider),
 	fx.Provide(VisibilityManagerProvider),
 	fx.Provide(ThrottledLoggerRpsFnProvider),
@@ -92,6 +93,7 @@ func GrpcServerOptionsProvider(
 	namespaceValidatorInterceptor *interceptor.NamespaceValidatorInterceptor,
 	telemetryInterceptor *interceptor.TelemetryInterceptor,
 	rateLimitInterceptor *interceptor.RateLimitInterceptor,
+	maintenanceModeInterceptor *interceptor.MaintenanceModeInterceptor,
 	authorizer authorization.Authorizer,
 	claimMapper authorization.ClaimMapper,
 	audienceGetter authorization.JWTAudienceMapper,
@@ -116,8 +118,10 @@ func GrpcServerOptionsProvider(
 		namespaceLogInterceptor.Intercept,
 		rpc.ServiceErrorInterceptor,
 		metrics.NewServerMetricsContextInjectorInterceptor(),
+		metrics.NewServerActionUnitsTrailerInterceptor(logger),
 		telemetryInterceptor.Intercept,
 		namespaceValidatorInterceptor.Intercept,
+		maintenanceModeInterceptor.Intercept,
 		rateLimitInterceptor.Intercept,
 		namespaceRateLimiterInterceptor.Intercept,
 		namespaceCountLimiterInterceptor.Intercept,
@@ -193,6 +197,17 @@ func RateLimitInterceptorProvider(
 	)
 }
 
+func MaintenanceModeInterceptorProvider(
+	serviceConfig *Config,
+) *interceptor.MaintenanceModeInterceptor {
+	return interceptor.NewMaintenanceModeInterceptor(
+		serviceName,
+		serviceConfig.MaintenanceMode,
+		serviceConfig.MaintenanceModeRetryAfter,
+		configs.MaintenanceModeAllowedAPIs,
+	)
+}
+
 func NamespaceRateLimitInterceptorProvider(
 	serviceConfig *Config,
 	serviceResource resource.Resource,
@@ -260,6 +275,7 @@ func VisibilityManagerProvider(
 		params.PersistenceConfig,
 		persistenceServiceResolver,
 		esConfig.GetVisibilityIndex(),
+		esConfig.GetNamespaceVisibilityIndices(),
 		esClient,
 		nil, // frontend visibility never write
 		serviceResource.GetSearchAttributesProvider(),
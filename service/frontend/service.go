@@ -37,7 +37,9 @@ import (
 
 	"go.temporal.io/server/api/adminservice/v1"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/membership"
 	"go.temporal.io/server/common/metrics"
@@ -71,6 +73,19 @@ type Config struct {
 	EnableClientVersionCheck     dynamicconfig.BoolPropertyFn
 	DisallowQuery                dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	ShutdownDrainDuration        dynamicconfig.DurationPropertyFn
+	NamespaceShardPartitions     *common.ShardPartitions
+
+	// history long poll protection settings
+	MaxHistoryLongPollsPerCaller dynamicconfig.IntPropertyFnWithNamespaceFilter
+	HistoryLongPollRetryAfter    dynamicconfig.DurationPropertyFn
+
+	// worker identity settings
+	RecordWorkerPrincipal  dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	PerIdentityTaskMetrics dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
+	// maintenance mode settings
+	MaintenanceMode           dynamicconfig.BoolPropertyFn
+	MaintenanceModeRetryAfter dynamicconfig.DurationPropertyFn
 
 	MaxBadBinaries dynamicconfig.IntPropertyFnWithNamespaceFilter
 
@@ -154,6 +169,13 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int32, esIndexName
 		BlobSizeLimitWarn:                      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitWarn, 256*1024),
 		ThrottledLogRPS:                        dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS, 20),
 		ShutdownDrainDuration:                  dc.GetDurationProperty(dynamicconfig.FrontendShutdownDrainDuration, 0),
+		NamespaceShardPartitions:               common.NewShardPartitions(numHistoryShards, dc.GetMapProperty(dynamicconfig.NamespaceShardPartitions, nil), clock.NewRealTimeSource(), log.NewNoopLogger()),
+		MaintenanceMode:                        dc.GetBoolProperty(dynamicconfig.FrontendMaintenanceMode, false),
+		MaintenanceModeRetryAfter:              dc.GetDurationProperty(dynamicconfig.FrontendMaintenanceModeRetryAfter, time.Minute),
+		MaxHistoryLongPollsPerCaller:           dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxHistoryLongPollsPerCaller, 0),
+		HistoryLongPollRetryAfter:              dc.GetDurationProperty(dynamicconfig.FrontendHistoryLongPollRetryAfter, 5*time.Second),
+		RecordWorkerPrincipal:                  dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.FrontendRecordWorkerPrincipal, false),
+		PerIdentityTaskMetrics:                 dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.FrontendPerIdentityTaskMetrics, false),
 		EnableNamespaceNotActiveAutoForwarding: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableNamespaceNotActiveAutoForwarding, true),
 		EnableClientVersionCheck:               dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck, true),
 		SearchAttributesNumberOfKeysLimit:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
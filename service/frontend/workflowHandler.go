@@ -27,6 +27,8 @@ package frontend
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"sync/atomic"
 	"time"
 
@@ -38,7 +40,10 @@ import (
 	"go.temporal.io/api/serviceerror"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 
 	historyspb "go.temporal.io/server/api/history/v1"
 	"go.temporal.io/server/api/historyservice/v1"
@@ -46,6 +51,7 @@ import (
 	tokenspb "go.temporal.io/server/api/token/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/authorization"
 	"go.temporal.io/server/common/backoff"
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/enums"
@@ -58,6 +64,7 @@ import (
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/quotas"
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/common/rpc/interceptor"
 	"go.temporal.io/server/common/searchattribute"
@@ -94,6 +101,8 @@ type (
 		namespaceHandler                namespace.Handler
 		getDefaultWorkflowRetrySettings dynamicconfig.MapPropertyFnWithNamespaceFilter
 		visibilityMrg                   manager.VisibilityManager
+		// historyLongPolls limits the concurrent history long polls per namespace and caller
+		historyLongPolls quotas.KeyedConcurrencyLimiter
 	}
 
 	// HealthStatus is an enum that refers to the rpc handler health status
@@ -334,6 +343,7 @@ func (wh *WorkflowHandler) DeprecateNamespace(ctx context.Context, request *work
 // exists with same workflowId.
 func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *workflowservice.StartWorkflowExecutionRequest) (_ *workflowservice.StartWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
+	requestStartTime := time.Now().UTC()
 
 	if wh.isStopped() {
 		return nil, errShuttingDown
@@ -399,7 +409,11 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 	}
 	wh.GetLogger().Debug("Start workflow execution request namespaceID.", tag.WorkflowNamespaceID(namespaceID.String()))
 
-	resp, err := wh.GetHistoryClient().StartWorkflowExecution(ctx, common.CreateHistoryStartWorkflowRequest(namespaceID.String(), request, nil, time.Now().UTC()))
+	now := time.Now().UTC()
+	wh.metricsScope(ctx).Tagged(metrics.StartStageTag(metrics.FrontendStartStageTagValue)).
+		RecordTimer(metrics.StartWorkflowStageLatency, now.Sub(requestStartTime))
+	ctx = headers.SetRequestStartTime(ctx, requestStartTime)
+	resp, err := wh.GetHistoryClient().StartWorkflowExecution(ctx, common.CreateHistoryStartWorkflowRequest(namespaceID.String(), request, nil, now))
 
 	if err != nil {
 		return nil, err
@@ -470,14 +484,19 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 		expectedNextEventID int64,
 		currentBranchToken []byte,
 	) ([]byte, string, int64, int64, int64, bool, error) {
+		var header metadata.MD
 		response, err := wh.GetHistoryClient().PollMutableState(ctx, &historyservice.PollMutableStateRequest{
 			NamespaceId:         namespaceUUID.String(),
 			Execution:           execution,
 			ExpectedNextEventId: expectedNextEventID,
 			CurrentBranchToken:  currentBranchToken,
-		})
+		}, grpc.Header(&header))
+		forwardReadStaleness(ctx, header)
 
 		if err != nil {
+			if _, ok := err.(*serviceerror.ResourceExhausted); ok && request.GetWaitNewEvent() {
+				wh.setHistoryLongPollRetryAfter(ctx)
+			}
 			return nil, "", 0, 0, 0, false, err
 		}
 		isWorkflowRunning := response.GetWorkflowStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING
@@ -492,6 +511,18 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 	}
 
 	isLongPoll := request.GetWaitNewEvent()
+	if isLongPoll {
+		callerKey := request.GetNamespace() + "/" + historyLongPollCaller(ctx)
+		if !wh.historyLongPolls.TryAcquire(callerKey, wh.config.MaxHistoryLongPollsPerCaller(request.GetNamespace())) {
+			wh.GetMetricsClient().Scope(
+				metrics.FrontendPollWorkflowExecutionHistoryScope,
+				metrics.NamespaceTag(request.GetNamespace()),
+			).IncCounter(metrics.LongPollLimitExceededCounter)
+			wh.setHistoryLongPollRetryAfter(ctx)
+			return nil, errTooManyHistoryLongPolls
+		}
+		defer wh.historyLongPolls.Release(callerKey)
+	}
 	isCloseEventOnly := request.GetHistoryEventFilterType() == enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT
 	execution := request.Execution
 	var continuationToken *tokenspb.HistoryContinuation
@@ -556,7 +587,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 		// lastFirstEventTxnID != 0 exists due to forward / backward compatibility
 		if _, ok := retError.(*serviceerror.DataLoss); ok && lastFirstEventTxnID != 0 {
 			_, _ = wh.GetExecutionManager().TrimHistoryBranch(&persistence.TrimHistoryBranchRequest{
-				ShardID:       common.WorkflowIDToHistoryShard(namespaceID.String(), execution.GetWorkflowId(), wh.config.NumHistoryShards),
+				ShardID:       wh.config.NamespaceShardPartitions.GetShardID(namespaceID.String(), execution.GetWorkflowId()),
 				BranchToken:   continuationToken.BranchToken,
 				NodeID:        lastFirstEventID,
 				TransactionID: lastFirstEventTxnID,
@@ -819,11 +850,19 @@ func (wh *WorkflowHandler) RespondWorkflowTaskCompleted(
 		return nil, err
 	}
 	namespaceId := namespace.ID(taskToken.GetNamespaceId())
+	namespaceName, err := wh.GetNamespaceRegistry().GetNamespaceName(namespaceId)
+	if err != nil {
+		return nil, err
+	}
+	request.Identity = wh.workerIdentity(ctx, namespaceName, request.GetIdentity())
 
+	var trailer metadata.MD
 	histResp, err := wh.GetHistoryClient().RespondWorkflowTaskCompleted(ctx, &historyservice.RespondWorkflowTaskCompletedRequest{
 		NamespaceId:     namespaceId.String(),
 		CompleteRequest: request},
+		grpc.Trailer(&trailer),
 	)
+	forwardLimitWarnings(ctx, trailer)
 	if err != nil {
 		return nil, err
 	}
@@ -895,6 +934,8 @@ func (wh *WorkflowHandler) RespondWorkflowTaskFailed(
 		return nil, errIdentityTooLong
 	}
 
+	request.Identity = wh.workerIdentity(ctx, namespaceEntry.Name(), request.GetIdentity())
+
 	sizeLimitError := wh.config.BlobSizeLimitError(namespaceEntry.Name().String())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.Name().String())
 
@@ -1238,6 +1279,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 		return nil, errIdentityTooLong
 	}
 
+	request.Identity = wh.workerIdentity(ctx, namespaceEntry.Name(), request.GetIdentity())
+
 	sizeLimitError := wh.config.BlobSizeLimitError(namespaceEntry.Name().String())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.Name().String())
 
@@ -1335,6 +1378,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedById(ctx context.Context,
 		return nil, err
 	}
 
+	request.Identity = wh.workerIdentity(ctx, namespaceEntry.Name(), request.GetIdentity())
+
 	sizeLimitError := wh.config.BlobSizeLimitError(namespaceEntry.Name().String())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.Name().String())
 
@@ -1423,6 +1468,8 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 		return nil, errIdentityTooLong
 	}
 
+	request.Identity = wh.workerIdentity(ctx, namespaceEntry.Name(), request.GetIdentity())
+
 	sizeLimitError := wh.config.BlobSizeLimitError(namespaceEntry.Name().String())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.Name().String())
 
@@ -1508,6 +1555,8 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedById(ctx context.Context, re
 		return nil, err
 	}
 
+	request.Identity = wh.workerIdentity(ctx, namespaceEntry.Name(), request.GetIdentity())
+
 	sizeLimitError := wh.config.BlobSizeLimitError(namespaceEntry.Name().String())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.Name().String())
 
@@ -1577,6 +1626,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(ctx context.Context, requ
 		return nil, errIdentityTooLong
 	}
 
+	request.Identity = wh.workerIdentity(ctx, namespaceEntry.Name(), request.GetIdentity())
+
 	sizeLimitError := wh.config.BlobSizeLimitError(namespaceEntry.Name().String())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.Name().String())
 
@@ -1673,6 +1724,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledById(ctx context.Context,
 		return nil, err
 	}
 
+	request.Identity = wh.workerIdentity(ctx, namespaceEntry.Name(), request.GetIdentity())
+
 	sizeLimitError := wh.config.BlobSizeLimitError(namespaceEntry.Name().String())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.Name().String())
 
@@ -1747,6 +1800,7 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(ctx context.Context, r
 		return nil, err
 	}
 
+	ctx = setOperatorIdentity(ctx, request.GetIdentity())
 	_, err = wh.GetHistoryClient().RequestCancelWorkflowExecution(ctx, &historyservice.RequestCancelWorkflowExecutionRequest{
 		NamespaceId:   namespaceID.String(),
 		CancelRequest: request,
@@ -1960,6 +2014,7 @@ func (wh *WorkflowHandler) ResetWorkflowExecution(ctx context.Context, request *
 		return nil, err
 	}
 
+	ctx = setOperatorIdentity(ctx, "")
 	resp, err := wh.GetHistoryClient().ResetWorkflowExecution(ctx, &historyservice.ResetWorkflowExecutionRequest{
 		NamespaceId:  namespaceID.String(),
 		ResetRequest: request,
@@ -1997,6 +2052,7 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context, reque
 		return nil, err
 	}
 
+	ctx = setOperatorIdentity(ctx, request.GetIdentity())
 	_, err = wh.GetHistoryClient().TerminateWorkflowExecution(ctx, &historyservice.TerminateWorkflowExecutionRequest{
 		NamespaceId:      namespaceID.String(),
 		TerminateRequest: request,
@@ -2008,6 +2064,48 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context, reque
 	return &workflowservice.TerminateWorkflowExecutionResponse{}, nil
 }
 
+// setOperatorIdentity passes the identity of the caller terminating, canceling or resetting a workflow execution
+// to history, which records it in history events and the TemporalOperatorIdentity search attribute. It's the
+// subject of the caller's auth claims if there are any, or the identity set on the request otherwise.
+func setOperatorIdentity(ctx context.Context, requestIdentity string) context.Context {
+	identity := requestIdentity
+	if claims, ok := ctx.Value(authorization.MappedClaims).(*authorization.Claims); ok && claims.Subject != "" {
+		identity = claims.Subject
+	}
+	return headers.SetOperatorIdentity(ctx, identity)
+}
+
+// workerIdentity returns the identity to record for a worker completing, failing or canceling a task. It's the
+// identity set on the request, followed by the subject of the worker's auth claims if RecordWorkerPrincipal is
+// enabled, so the events attribute the task to both the worker deployment and the service account it runs as.
+// It also counts the task per identity if PerIdentityTaskMetrics is enabled.
+func (wh *WorkflowHandler) workerIdentity(ctx context.Context, namespaceName namespace.Name, requestIdentity string) string {
+	var principal string
+	if claims, ok := ctx.Value(authorization.MappedClaims).(*authorization.Claims); ok {
+		principal = claims.Subject
+	}
+
+	if wh.config.PerIdentityTaskMetrics(namespaceName.String()) {
+		metricsIdentity := principal
+		if metricsIdentity == "" {
+			metricsIdentity = requestIdentity
+		}
+		wh.metricsScope(ctx).Tagged(metrics.WorkerIdentityTag(metricsIdentity)).IncCounter(metrics.TaskCompletionsPerIdentityCounter)
+	}
+
+	if principal == "" || principal == requestIdentity || !wh.config.RecordWorkerPrincipal(namespaceName.String()) {
+		return requestIdentity
+	}
+	if requestIdentity == "" {
+		return principal
+	}
+	identity := fmt.Sprintf("%v (%v)", requestIdentity, principal)
+	if len(identity) > wh.config.MaxIDLengthLimit() {
+		return requestIdentity
+	}
+	return identity
+}
+
 // ListOpenWorkflowExecutions is a visibility API to list the open executions in a specific namespace.
 func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest) (_ *workflowservice.ListOpenWorkflowExecutionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
@@ -2655,10 +2753,12 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		return nil, err
 	}
 
+	var header metadata.MD
 	response, err := wh.GetHistoryClient().DescribeWorkflowExecution(ctx, &historyservice.DescribeWorkflowExecutionRequest{
 		NamespaceId: namespaceID.String(),
 		Request:     request,
-	})
+	}, grpc.Header(&header))
+	forwardReadStaleness(ctx, header)
 
 	if err != nil {
 		return nil, err
@@ -2795,7 +2895,7 @@ func (wh *WorkflowHandler) getRawHistory(
 	branchToken []byte,
 ) ([]*commonpb.DataBlob, []byte, error) {
 	var rawHistory []*commonpb.DataBlob
-	shardID := common.WorkflowIDToHistoryShard(namespaceID.String(), execution.GetWorkflowId(), wh.config.NumHistoryShards)
+	shardID := wh.config.NamespaceShardPartitions.GetShardID(namespaceID.String(), execution.GetWorkflowId())
 
 	resp, err := wh.GetExecutionManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken:   branchToken,
@@ -2863,7 +2963,7 @@ func (wh *WorkflowHandler) getHistory(
 
 	var size int
 	isFirstPage := len(nextPageToken) == 0
-	shardID := common.WorkflowIDToHistoryShard(namespaceID.String(), execution.GetWorkflowId(), wh.config.NumHistoryShards)
+	shardID := wh.config.NamespaceShardPartitions.GetShardID(namespaceID.String(), execution.GetWorkflowId())
 	var err error
 	var historyEvents []*historypb.HistoryEvent
 	historyEvents, size, nextPageToken, err = persistence.ReadFullPageEvents(wh.GetExecutionManager(), &persistence.ReadHistoryBranchRequest{
@@ -2985,6 +3085,45 @@ func (wh *WorkflowHandler) validateTaskQueue(t *taskqueuepb.TaskQueue) error {
 	return nil
 }
 
+// forwardReadStaleness forwards staleness of a standby namespace read reported by history to the caller
+func forwardReadStaleness(ctx context.Context, header metadata.MD) {
+	if staleness := header.Get(headers.ReadStalenessHeaderName); len(staleness) > 0 {
+		// best effort, the read itself has succeeded
+		_ = grpc.SetHeader(ctx, metadata.Pairs(headers.ReadStalenessHeaderName, staleness[0]))
+	}
+}
+
+// forwardLimitWarnings forwards warnings about limits the workflow execution is approaching reported by history
+// to the caller
+func forwardLimitWarnings(ctx context.Context, trailer metadata.MD) {
+	if warnings := trailer.Get(headers.LimitWarningsTrailerName); len(warnings) > 0 {
+		// best effort, the warnings are informational only
+		_ = grpc.SetTrailer(ctx, metadata.MD{headers.LimitWarningsTrailerName: warnings})
+	}
+}
+
+// historyLongPollCaller identifies the caller of a history long poll by the subject of its claims, or by its
+// host if the request isn't authenticated
+func historyLongPollCaller(ctx context.Context) string {
+	if claims, ok := ctx.Value(authorization.MappedClaims).(*authorization.Claims); ok && claims.Subject != "" {
+		return claims.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// setHistoryLongPollRetryAfter tells a caller whose history long poll was rejected when to retry
+func (wh *WorkflowHandler) setHistoryLongPollRetryAfter(ctx context.Context) {
+	retryAfter := wh.config.HistoryLongPollRetryAfter()
+	// best effort, the request is rejected either way
+	_ = grpc.SetHeader(ctx, metadata.Pairs(interceptor.RetryAfterHeaderName, strconv.FormatInt(int64(retryAfter/time.Second), 10)))
+}
+
 func (wh *WorkflowHandler) validateExecution(w *commonpb.WorkflowExecution) error {
 	err := validateExecution(w)
 	if err != nil {
@@ -58,6 +58,7 @@ import (
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/archiver"
 	"go.temporal.io/server/common/archiver/provider"
+	"go.temporal.io/server/common/authorization"
 	"go.temporal.io/server/common/cluster"
 	dc "go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/metrics"
@@ -1151,7 +1152,7 @@ func (s *workflowHandlerSuite) TestGetWorkflowExecutionHistory() {
 		Execution:           &we,
 		ExpectedNextEventId: common.EndEventID,
 		CurrentBranchToken:  nil,
-	}).Return(&historyservice.PollMutableStateResponse{
+	}, gomock.Any()).Return(&historyservice.PollMutableStateResponse{
 		Execution:           &we,
 		WorkflowType:        &commonpb.WorkflowType{Name: "mytype"},
 		NextEventId:         6,
@@ -1453,6 +1454,22 @@ func (s *workflowHandlerSuite) TestVerifyHistoryIsComplete() {
 	}
 }
 
+func (s *workflowHandlerSuite) TestWorkerIdentity() {
+	testNamespace := namespace.Name("test-namespace")
+	config := s.newConfig()
+	wh := s.getWorkflowHandler(config)
+	ctx := context.WithValue(context.Background(), authorization.MappedClaims, &authorization.Claims{Subject: "billing-workers"})
+
+	// disabled
+	s.Equal("1234@worker-host", wh.workerIdentity(ctx, testNamespace, "1234@worker-host"))
+
+	config.RecordWorkerPrincipal = dc.GetBoolPropertyFnFilteredByNamespace(true)
+	s.Equal("1234@worker-host (billing-workers)", wh.workerIdentity(ctx, testNamespace, "1234@worker-host"))
+	s.Equal("billing-workers", wh.workerIdentity(ctx, testNamespace, ""))
+	s.Equal("billing-workers", wh.workerIdentity(ctx, testNamespace, "billing-workers"))
+	s.Equal("1234@worker-host", wh.workerIdentity(context.Background(), testNamespace, "1234@worker-host"))
+}
+
 func (s *workflowHandlerSuite) newConfig() *Config {
 	return NewConfig(dc.NewCollection(dc.NewNoopClient(), s.mockResource.GetLogger()), numHistoryShards, "", false)
 }
@@ -752,6 +752,10 @@ func (v *commandAttrValidator) validateCrossNamespaceCall(
 		return err
 	}
 
+	if err := v.validateCrossNamespaceTarget(namespaceEntry, targetNamespaceEntry); err != nil {
+		return err
+	}
+
 	// both local namespace
 	if !namespaceEntry.IsGlobalNamespace() && !targetNamespaceEntry.IsGlobalNamespace() {
 		return nil
@@ -768,9 +772,53 @@ func (v *commandAttrValidator) validateCrossNamespaceCall(
 		}
 		return v.createCrossNamespaceCallError(namespaceEntry, targetNamespaceEntry)
 	}
+
+	// global namespaces replicated to the same clusters and active in the same cluster: the command is only
+	// executed by the active cluster of the caller, where the target is active as well
+	if v.config.EnableGlobalCrossNamespaceCommands() &&
+		namespaceEntry.IsGlobalNamespace() && targetNamespaceEntry.IsGlobalNamespace() &&
+		namespaceEntry.ActiveClusterName() == targetNamespaceEntry.ActiveClusterName() &&
+		sameClusters(namespaceClusters, targetNamespaceClusters) {
+		return nil
+	}
 	return v.createCrossNamespaceCallError(namespaceEntry, targetNamespaceEntry)
 }
 
+// validateCrossNamespaceTarget checks that the target namespace is in the CrossNamespaceCommandTargets of the
+// namespace, if it has any
+func (v *commandAttrValidator) validateCrossNamespaceTarget(
+	namespaceEntry *namespace.Namespace,
+	targetNamespaceEntry *namespace.Namespace,
+) error {
+
+	targets := v.config.CrossNamespaceCommandTargets(namespaceEntry.Name().String())
+	if targets == "" {
+		return nil
+	}
+	for _, target := range strings.Split(targets, ",") {
+		if strings.TrimSpace(target) == targetNamespaceEntry.Name().String() {
+			return nil
+		}
+	}
+	return serviceerror.NewInvalidArgument(fmt.Sprintf("namespace %v is not allowed to target namespace %v", namespaceEntry.Name(), targetNamespaceEntry.Name()))
+}
+
+func sameClusters(clusters []string, otherClusters []string) bool {
+	if len(clusters) != len(otherClusters) {
+		return false
+	}
+	clusterSet := make(map[string]struct{}, len(clusters))
+	for _, cluster := range clusters {
+		clusterSet[cluster] = struct{}{}
+	}
+	for _, cluster := range otherClusters {
+		if _, ok := clusterSet[cluster]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (v *commandAttrValidator) createCrossNamespaceCallError(
 	namespaceEntry *namespace.Namespace,
 	targetNamespaceEntry *namespace.Namespace,
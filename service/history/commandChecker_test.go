@@ -106,13 +106,15 @@ func (s *commandAttrValidatorSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockNamespaceCache = namespace.NewMockRegistry(s.controller)
 	config := &configs.Config{
-		MaxIDLengthLimit:                  dynamicconfig.GetIntPropertyFn(1000),
-		SearchAttributesNumberOfKeysLimit: dynamicconfig.GetIntPropertyFilteredByNamespace(100),
-		SearchAttributesSizeOfValueLimit:  dynamicconfig.GetIntPropertyFilteredByNamespace(2 * 1024),
-		SearchAttributesTotalSizeLimit:    dynamicconfig.GetIntPropertyFilteredByNamespace(40 * 1024),
-		DefaultActivityRetryPolicy:        dynamicconfig.GetMapPropertyFnWithNamespaceFilter(common.GetDefaultRetryPolicyConfigOptions()),
-		DefaultWorkflowRetryPolicy:        dynamicconfig.GetMapPropertyFnWithNamespaceFilter(common.GetDefaultRetryPolicyConfigOptions()),
-		EnableCrossNamespaceCommands:      dynamicconfig.GetBoolPropertyFn(true),
+		MaxIDLengthLimit:                   dynamicconfig.GetIntPropertyFn(1000),
+		SearchAttributesNumberOfKeysLimit:  dynamicconfig.GetIntPropertyFilteredByNamespace(100),
+		SearchAttributesSizeOfValueLimit:   dynamicconfig.GetIntPropertyFilteredByNamespace(2 * 1024),
+		SearchAttributesTotalSizeLimit:     dynamicconfig.GetIntPropertyFilteredByNamespace(40 * 1024),
+		DefaultActivityRetryPolicy:         dynamicconfig.GetMapPropertyFnWithNamespaceFilter(common.GetDefaultRetryPolicyConfigOptions()),
+		DefaultWorkflowRetryPolicy:         dynamicconfig.GetMapPropertyFnWithNamespaceFilter(common.GetDefaultRetryPolicyConfigOptions()),
+		EnableCrossNamespaceCommands:       dynamicconfig.GetBoolPropertyFn(true),
+		CrossNamespaceCommandTargets:       dynamicconfig.GetStringPropertyFnFilteredByNamespace(""),
+		EnableGlobalCrossNamespaceCommands: dynamicconfig.GetBoolPropertyFn(false),
 	}
 	s.validator = newCommandAttrValidator(
 		s.mockNamespaceCache,
@@ -511,6 +513,79 @@ func (s *commandAttrValidatorSuite) TestValidateCrossNamespaceCall_GlobalToGloba
 	s.IsType(&serviceerror.InvalidArgument{}, err)
 }
 
+func (s *commandAttrValidatorSuite) TestValidateCrossNamespaceCall_GlobalToGlobal_SameReplicationConfig() {
+	s.validator.config.EnableGlobalCrossNamespaceCommands = dynamicconfig.GetBoolPropertyFn(true)
+	replicationConfig := &persistencespb.NamespaceReplicationConfig{
+		ActiveClusterName: cluster.TestCurrentClusterName,
+		Clusters: []string{
+			cluster.TestCurrentClusterName,
+			cluster.TestAlternativeClusterName,
+		},
+	}
+	namespaceEntry := namespace.NewGlobalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Name: s.testNamespaceID.String()},
+		nil,
+		replicationConfig,
+		1234,
+	)
+	targetNamespaceEntry := namespace.NewGlobalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Name: s.testTargetNamespaceID.String()},
+		nil,
+		&persistencespb.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []string{
+				cluster.TestAlternativeClusterName,
+				cluster.TestCurrentClusterName,
+			},
+		},
+		1234,
+	)
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testTargetNamespaceID).Return(targetNamespaceEntry, nil)
+
+	err := s.validator.validateCrossNamespaceCall(s.testNamespaceID, s.testTargetNamespaceID)
+	s.Nil(err)
+
+	// the target is active in another cluster
+	targetNamespaceEntry = namespace.NewGlobalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Name: s.testTargetNamespaceID.String()},
+		nil,
+		&persistencespb.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestAlternativeClusterName,
+			Clusters:          replicationConfig.Clusters,
+		},
+		1234,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testTargetNamespaceID).Return(targetNamespaceEntry, nil)
+	err = s.validator.validateCrossNamespaceCall(s.testNamespaceID, s.testTargetNamespaceID)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *commandAttrValidatorSuite) TestValidateCrossNamespaceCall_Targets() {
+	namespaceEntry := namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Name: s.testNamespaceID.String()},
+		nil,
+		cluster.TestCurrentClusterName,
+	)
+	targetNamespaceEntry := namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Name: s.testTargetNamespaceID.String()},
+		nil,
+		cluster.TestCurrentClusterName,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil).Times(2)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testTargetNamespaceID).Return(targetNamespaceEntry, nil).Times(2)
+
+	s.validator.config.CrossNamespaceCommandTargets = dynamicconfig.GetStringPropertyFnFilteredByNamespace("other, " + s.testTargetNamespaceID.String())
+	err := s.validator.validateCrossNamespaceCall(s.testNamespaceID, s.testTargetNamespaceID)
+	s.Nil(err)
+
+	s.validator.config.CrossNamespaceCommandTargets = dynamicconfig.GetStringPropertyFnFilteredByNamespace("other")
+	err = s.validator.validateCrossNamespaceCall(s.testNamespaceID, s.testTargetNamespaceID)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
 func (s *commandAttrValidatorSuite) TestValidateCrossNamespaceCall_GlobalToGlobal_SameNamespace() {
 	targetNamespaceID := s.testNamespaceID
 
@@ -30,7 +30,9 @@ import (
 	enumspb "go.temporal.io/api/enums/v1"
 
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence/visibility"
 )
@@ -40,6 +42,8 @@ type Config struct {
 	NumberOfShards             int32
 	DefaultVisibilityIndexName string
 
+	NamespaceShardPartitions *common.ShardPartitions
+
 	// TODO remove this dynamic flag in 1.14.x
 	EnableDBRecordVersion dynamicconfig.BoolPropertyFn
 
@@ -60,6 +64,9 @@ type Config struct {
 	EnableStickyQuery     dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	ShutdownDrainDuration dynamicconfig.DurationPropertyFn
 
+	// MaxCompactedResetPoints bounds the number of reset points dropped from mutable state per workflow task
+	MaxCompactedResetPoints dynamicconfig.IntPropertyFnWithNamespaceFilter
+
 	// HistoryCache settings
 	// Change of these configs require shard restart
 	HistoryCacheInitialSize dynamicconfig.IntPropertyFn
@@ -72,19 +79,32 @@ type Config struct {
 	EventsCacheMaxSize     dynamicconfig.IntPropertyFn
 	EventsCacheTTL         dynamicconfig.DurationPropertyFn
 
+	// CachePinnedNamespace protects cache entries of the namespace from eviction by other namespaces,
+	// evaluated when an entry is inserted
+	CachePinnedNamespace dynamicconfig.BoolPropertyFnWithNamespaceIDFilter
+
 	// ShardController settings
-	RangeSizeBits           uint
-	AcquireShardInterval    dynamicconfig.DurationPropertyFn
-	AcquireShardConcurrency dynamicconfig.IntPropertyFn
+	RangeSizeBits             uint
+	AcquireShardInterval      dynamicconfig.DurationPropertyFn
+	AcquireShardConcurrency   dynamicconfig.IntPropertyFn
+	EnableShardOwnershipHints dynamicconfig.BoolPropertyFn
+
+	// QueueBacklogMetricsInterval is the interval of the per-host queue backlog gauges used for autoscaling
+	QueueBacklogMetricsInterval dynamicconfig.DurationPropertyFn
 
 	// the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay                  dynamicconfig.DurationPropertyFn
 	StandbyTaskMissingEventsResendDelay  dynamicconfig.DurationPropertyFn
 	StandbyTaskMissingEventsDiscardDelay dynamicconfig.DurationPropertyFn
+	StandbyReadMaxStaleness              dynamicconfig.DurationPropertyFnWithNamespaceFilter
+
+	// TaskProcessorThrottleErrorRate the rate of task attempts failing with persistence errors above which the
+	// task processors of a shard lower their worker count, 0 disables throttling
+	TaskProcessorThrottleErrorRate dynamicconfig.FloatPropertyFn
 
 	// TimerQueueProcessor settings
 	TimerTaskBatchSize                                dynamicconfig.IntPropertyFn
-	TimerTaskWorkerCount                              dynamicconfig.IntPropertyFn
+	TimerTaskWorkerCount                              dynamicconfig.IntPropertyFnWithShardIDFilter
 	TimerTaskMaxRetryCount                            dynamicconfig.IntPropertyFn
 	TimerProcessorCompleteTimerFailureRetryCount      dynamicconfig.IntPropertyFn
 	TimerProcessorUpdateAckInterval                   dynamicconfig.DurationPropertyFn
@@ -104,7 +124,7 @@ type Config struct {
 
 	// TransferQueueProcessor settings
 	TransferTaskBatchSize                                dynamicconfig.IntPropertyFn
-	TransferTaskWorkerCount                              dynamicconfig.IntPropertyFn
+	TransferTaskWorkerCount                              dynamicconfig.IntPropertyFnWithShardIDFilter
 	TransferTaskMaxRetryCount                            dynamicconfig.IntPropertyFn
 	TransferProcessorCompleteTransferFailureRetryCount   dynamicconfig.IntPropertyFn
 	TransferProcessorFailoverMaxPollRPS                  dynamicconfig.IntPropertyFn
@@ -144,10 +164,69 @@ type Config struct {
 	// ShardSyncMinInterval the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval            dynamicconfig.DurationPropertyFn
 	ShardSyncTimerJitterCoefficient dynamicconfig.FloatPropertyFn
+	// ShardDrainTimeout the max time an unloading shard waits for outstanding queue tasks before it is stopped
+	ShardDrainTimeout dynamicconfig.DurationPropertyFn
+	// ShardPersistenceMaxQPS the max qps of workflow writes of a shard, 0 means unlimited
+	ShardPersistenceMaxQPS dynamicconfig.IntPropertyFnWithShardIDFilter
+	// ShardPersistenceNamespaceMaxQPS the max qps of workflow writes of a namespace within a shard, 0 means unlimited
+	ShardPersistenceNamespaceMaxQPS dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// ShardPersistenceRetryPolicy the retry policy of persistence operations of shards and workflow transactions
+	ShardPersistenceRetryPolicy RetryPolicyFn
+	// ShardAcquisitionRetryPolicy the retry policy of acquiring a shard
+	ShardAcquisitionRetryPolicy RetryPolicyFn
+	// ShardAcquisitionMaxConcurrency the max number of shards acquired at the same time, 0 means unlimited
+	ShardAcquisitionMaxConcurrency dynamicconfig.IntPropertyFn
+	// ShardAcquisitionPacing the min time between the starts of two shard acquisitions, 0 disables pacing
+	ShardAcquisitionPacing dynamicconfig.DurationPropertyFn
+	// ShardWarmUpMaxExecutions the max number of executions with pending tasks loaded into the caches of a newly
+	// acquired shard, 0 disables the warm-up
+	ShardWarmUpMaxExecutions dynamicconfig.IntPropertyFn
+	// ShardWarmUpTimeout the max time a newly acquired shard spends warming up its caches
+	ShardWarmUpTimeout dynamicconfig.DurationPropertyFn
+	// RangePreallocationThreshold the fraction of the task IDs of a shard range allocated before the next range
+	// is acquired in the background
+	RangePreallocationThreshold dynamicconfig.FloatPropertyFn
+	// RangeRenewalAlertRate the number of range renewals of a shard per minute above which the shard reports
+	// that its ranges are exhausted too fast, 0 disables it
+	RangeRenewalAlertRate dynamicconfig.IntPropertyFn
+	// ShardIdleUnloadTimeout the time without activity after which a shard with no pending tasks is unloaded,
+	// 0 disables unloading idle shards
+	ShardIdleUnloadTimeout dynamicconfig.DurationPropertyFn
+	// ShardReadOnly whether the writes of a shard are rejected
+	ShardReadOnly dynamicconfig.BoolPropertyFnWithShardIDFilter
+	// ShardClockSkewLimit how far a remote cluster clock may be ahead of the local clock, 0 disables detection
+	ShardClockSkewLimit dynamicconfig.DurationPropertyFn
+	// ShardClockSkewBlockTimerAllocation whether timer writes for a skewed remote cluster are rejected
+	ShardClockSkewBlockTimerAllocation dynamicconfig.BoolPropertyFn
+	// ShardLockSlowThreshold the shard lock wait or hold time above which the lock operation is logged, 0 disables it
+	ShardLockSlowThreshold dynamicconfig.DurationPropertyFn
+	// ShardOwnershipHeartbeatInterval the max time an acquired shard goes without persisting shard info, 0 disables it
+	ShardOwnershipHeartbeatInterval dynamicconfig.DurationPropertyFn
+	// ShardOwnershipAssertionRate the fraction of workflow writes preceded by a check of the persisted range ID,
+	// 0 disables it
+	ShardOwnershipAssertionRate dynamicconfig.FloatPropertyFn
+	// ShardPruneRemovedClusters whether ack levels of clusters removed from cluster metadata are pruned on shard load
+	ShardPruneRemovedClusters dynamicconfig.BoolPropertyFn
+	// ShardLogDedupInterval the interval within which identical warn and error messages of the shard throttled
+	// logger are logged once, 0 disables the deduplication
+	ShardLogDedupInterval dynamicconfig.DurationPropertyFn
+	// ShardReadValidateRangeID whether workflow reads are rejected if the shard range ID changed during the read
+	ShardReadValidateRangeID dynamicconfig.BoolPropertyFn
+	// ShardUpdateDirtyUpdatesThreshold the number of shard info updates at which it's flushed sooner, 0 disables it
+	ShardUpdateDirtyUpdatesThreshold dynamicconfig.IntPropertyFn
+	// ShardUpdateProgressAtRiskThreshold the number of unpersisted acked task IDs at which shard info is flushed
+	// sooner, 0 disables it
+	ShardUpdateProgressAtRiskThreshold dynamicconfig.IntPropertyFn
+	// ShardUpdateFastInterval the shortest interval between shard info flushes of a dirty shard
+	ShardUpdateFastInterval dynamicconfig.DurationPropertyFn
+	// ShardUpdateIdleInterval the interval between shard info flushes of an idle shard
+	ShardUpdateIdleInterval dynamicconfig.DurationPropertyFn
 
 	// Time to hold a poll request before returning an empty response
 	// right now only used by GetMutableState
 	LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithNamespaceFilter
+	// MaxLongPollsPerExecution the max number of concurrent long polls on a workflow execution, 0 means unlimited
+	MaxLongPollsPerExecution dynamicconfig.IntPropertyFnWithNamespaceFilter
 
 	// encoding the history events
 	EventEncodingType dynamicconfig.StringPropertyFnWithNamespaceFilter
@@ -170,10 +249,30 @@ type Config struct {
 	BlobSizeLimitWarn      dynamicconfig.IntPropertyFnWithNamespaceFilter
 	MemoSizeLimitError     dynamicconfig.IntPropertyFnWithNamespaceFilter
 	MemoSizeLimitWarn      dynamicconfig.IntPropertyFnWithNamespaceFilter
-	HistorySizeLimitError  dynamicconfig.IntPropertyFnWithNamespaceFilter
-	HistorySizeLimitWarn   dynamicconfig.IntPropertyFnWithNamespaceFilter
-	HistoryCountLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
-	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
+	HistorySizeLimitError  dynamicconfig.IntPropertyFnWithWorkflowTypeFilter
+	HistorySizeLimitWarn   dynamicconfig.IntPropertyFnWithWorkflowTypeFilter
+	HistoryCountLimitError dynamicconfig.IntPropertyFnWithWorkflowTypeFilter
+	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithWorkflowTypeFilter
+
+	// mutable state limits
+	MutableStateSizeLimitError          dynamicconfig.IntPropertyFnWithNamespaceFilter
+	MutableStateSizeLimitWarn           dynamicconfig.IntPropertyFnWithNamespaceFilter
+	NumPendingActivitiesLimitError      dynamicconfig.IntPropertyFnWithNamespaceFilter
+	NumPendingActivitiesLimitWarn       dynamicconfig.IntPropertyFnWithNamespaceFilter
+	NumPendingChildExecutionsLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
+	NumPendingChildExecutionsLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
+	NumPendingSignalsLimitError         dynamicconfig.IntPropertyFnWithNamespaceFilter
+	NumPendingSignalsLimitWarn          dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// EmitLimitWarnings whether workflow task completion responses carry warnings for limits past their warn limit
+	EmitLimitWarnings dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
+	// ReapplyEventTypes the comma separated event types reapplied onto the current run by the default reapply policy
+	ReapplyEventTypes dynamicconfig.StringPropertyFnWithNamespaceFilter
+	// VisibilityRecordRetention how long visibility records are kept after their executions are deleted by retention
+	VisibilityRecordRetention dynamicconfig.DurationPropertyFnWithNamespaceFilter
+
+	// ActivityHeartbeatCoalesceInterval how often heartbeats with unchanged details are written
+	ActivityHeartbeatCoalesceInterval dynamicconfig.DurationPropertyFnWithNamespaceFilter
 
 	// DefaultActivityRetryOptions specifies the out-of-box retry policy if
 	// none is configured on the Activity by the user.
@@ -183,6 +282,10 @@ type Config struct {
 	// any unset fields on a RetryPolicy configured on a Workflow
 	DefaultWorkflowRetryPolicy dynamicconfig.MapPropertyFnWithNamespaceFilter
 
+	// CapActivityTimeoutsByRunDeadline caps the timeouts of a scheduled activity at the time left until the
+	// workflow run times out, so that activities don't outlive their workflow
+	CapActivityTimeoutsByRunDeadline dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
 	// Workflow task settings
 	// StickyTTL is to expire a sticky taskqueue if no update more than this duration
 	// TODO https://go.temporal.io/server/issues/2357
@@ -208,10 +311,17 @@ type Config struct {
 	ReplicationTaskProcessorCleanupJitterCoefficient     dynamicconfig.FloatPropertyFnWithShardIDFilter
 	ReplicationTaskProcessorHostQPS                      dynamicconfig.FloatPropertyFn
 	ReplicationTaskProcessorShardQPS                     dynamicconfig.FloatPropertyFn
+	ReplicationTaskReorderBufferWindow                   dynamicconfig.DurationPropertyFnWithShardIDFilter
+	ReplicationTaskReorderBufferMaxTasksPerExecution     dynamicconfig.IntPropertyFnWithShardIDFilter
+	ReplicationTaskReorderBufferMaxTasks                 dynamicconfig.IntPropertyFnWithShardIDFilter
 
 	// The following are used by consistent query
 	MaxBufferedQueryCount dynamicconfig.IntPropertyFn
 
+	// WorkflowTaskQuarantineThreshold the number of consecutive workflow task failures and timeouts after which
+	// the execution is quarantined, 0 disables quarantining
+	WorkflowTaskQuarantineThreshold dynamicconfig.IntPropertyFnWithNamespaceFilter
+
 	// Data integrity check related config knobs
 	MutableStateChecksumGenProbability    dynamicconfig.IntPropertyFnWithNamespaceFilter
 	MutableStateChecksumVerifyProbability dynamicconfig.IntPropertyFnWithNamespaceFilter
@@ -226,7 +336,7 @@ type Config struct {
 	// ===== Visibility related =====
 	// VisibilityQueueProcessor settings
 	VisibilityTaskBatchSize                                dynamicconfig.IntPropertyFn
-	VisibilityTaskWorkerCount                              dynamicconfig.IntPropertyFn
+	VisibilityTaskWorkerCount                              dynamicconfig.IntPropertyFnWithShardIDFilter
 	VisibilityTaskMaxRetryCount                            dynamicconfig.IntPropertyFn
 	VisibilityProcessorCompleteTaskFailureRetryCount       dynamicconfig.IntPropertyFn
 	VisibilityProcessorFailoverMaxPollRPS                  dynamicconfig.IntPropertyFn
@@ -241,10 +351,12 @@ type Config struct {
 	VisibilityProcessorMaxRedispatchQueueSize              dynamicconfig.IntPropertyFn
 	VisibilityProcessorEnablePriorityTaskProcessor         dynamicconfig.BoolPropertyFn
 	VisibilityProcessorVisibilityArchivalTimeLimit         dynamicconfig.DurationPropertyFn
+	VisibilityProcessorMemoSizeLimit                       dynamicconfig.IntPropertyFnWithNamespaceFilter
+	VisibilityActivityProgressInterval                     dynamicconfig.DurationPropertyFnWithNamespaceFilter
 
 	// TieredStorageQueueProcessor settings
 	TieredStorageTaskBatchSize                                dynamicconfig.IntPropertyFn
-	TieredStorageTaskWorkerCount                              dynamicconfig.IntPropertyFn
+	TieredStorageTaskWorkerCount                              dynamicconfig.IntPropertyFnWithShardIDFilter
 	TieredStorageTaskMaxRetryCount                            dynamicconfig.IntPropertyFn
 	TieredStorageProcessorCompleteTaskFailureRetryCount       dynamicconfig.IntPropertyFn
 	TieredStorageProcessorFailoverMaxPollRPS                  dynamicconfig.IntPropertyFn
@@ -271,6 +383,11 @@ type Config struct {
 	ESProcessorAckTimeout             dynamicconfig.DurationPropertyFn
 
 	EnableCrossNamespaceCommands dynamicconfig.BoolPropertyFn
+	// CrossNamespaceCommandTargets the comma separated namespaces a namespace may target, empty allows all
+	CrossNamespaceCommandTargets dynamicconfig.StringPropertyFnWithNamespaceFilter
+	// EnableGlobalCrossNamespaceCommands whether multi-cluster global namespaces with the same replication config
+	// may target each other
+	EnableGlobalCrossNamespaceCommands dynamicconfig.BoolPropertyFn
 }
 
 const (
@@ -283,6 +400,14 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		NumberOfShards:             numberOfShards,
 		DefaultVisibilityIndexName: defaultVisibilityIndex,
 
+		NamespaceShardPartitions: common.NewShardPartitions(
+			numberOfShards,
+			dc.GetMapProperty(dynamicconfig.NamespaceShardPartitions, nil),
+			clock.NewRealTimeSource(),
+			// ignored partition changes are logged by the history client of this process
+			log.NewNoopLogger(),
+		),
+
 		// TODO remove this dynamic flag in 1.14.x
 		EnableDBRecordVersion: dc.GetBoolProperty(dynamicconfig.EnableDBRecordVersion, true),
 
@@ -292,6 +417,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		PersistenceGlobalMaxQPS:    dc.GetIntProperty(dynamicconfig.HistoryPersistenceGlobalMaxQPS, 0),
 		ShutdownDrainDuration:      dc.GetDurationProperty(dynamicconfig.HistoryShutdownDrainDuration, 0),
 		MaxAutoResetPoints:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryMaxAutoResetPoints, DefaultHistoryMaxAutoResetPoints),
+		MaxCompactedResetPoints:    dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryMaxCompactedResetPoints, 5),
 		DefaultWorkflowTaskTimeout: dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.DefaultWorkflowTaskTimeout, common.DefaultWorkflowTaskTimeout),
 
 		StandardVisibilityPersistenceMaxReadQPS:  dc.GetIntProperty(dynamicconfig.StandardVisibilityPersistenceMaxReadQPS, 9000),
@@ -307,15 +433,21 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		EventsCacheInitialSize:               dc.GetIntProperty(dynamicconfig.EventsCacheInitialSize, 128),
 		EventsCacheMaxSize:                   dc.GetIntProperty(dynamicconfig.EventsCacheMaxSize, 512),
 		EventsCacheTTL:                       dc.GetDurationProperty(dynamicconfig.EventsCacheTTL, time.Hour),
+		CachePinnedNamespace:                 dc.GetBoolPropertyFnWithNamespaceIDFilter(dynamicconfig.HistoryCachePinnedNamespace, false),
 		RangeSizeBits:                        20, // 20 bits for sequencer, 2^20 sequence number for any range
 		AcquireShardInterval:                 dc.GetDurationProperty(dynamicconfig.AcquireShardInterval, time.Minute),
 		AcquireShardConcurrency:              dc.GetIntProperty(dynamicconfig.AcquireShardConcurrency, 10),
+		EnableShardOwnershipHints:            dc.GetBoolProperty(dynamicconfig.EnableShardOwnershipHints, true),
+		QueueBacklogMetricsInterval:          dc.GetDurationProperty(dynamicconfig.HistoryQueueBacklogMetricsInterval, 30*time.Second),
 		StandbyClusterDelay:                  dc.GetDurationProperty(dynamicconfig.StandbyClusterDelay, 5*time.Minute),
 		StandbyTaskMissingEventsResendDelay:  dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsResendDelay, 10*time.Minute),
 		StandbyTaskMissingEventsDiscardDelay: dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsDiscardDelay, 15*time.Minute),
+		StandbyReadMaxStaleness:              dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.StandbyReadMaxStaleness, 0),
+
+		TaskProcessorThrottleErrorRate: dc.GetFloat64Property(dynamicconfig.TaskProcessorThrottleErrorRate, 0),
 
 		TimerTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.TimerTaskBatchSize, 100),
-		TimerTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.TimerTaskWorkerCount, 10),
+		TimerTaskWorkerCount:                              dc.GetIntPropertyFilteredByShardID(dynamicconfig.TimerTaskWorkerCount, 10),
 		TimerTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.TimerTaskMaxRetryCount, 100),
 		TimerProcessorCompleteTimerFailureRetryCount:      dc.GetIntProperty(dynamicconfig.TimerProcessorCompleteTimerFailureRetryCount, 10),
 		TimerProcessorUpdateAckInterval:                   dc.GetDurationProperty(dynamicconfig.TimerProcessorUpdateAckInterval, 30*time.Second),
@@ -336,7 +468,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		TransferTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.TransferTaskBatchSize, 100),
 		TransferProcessorFailoverMaxPollRPS:                  dc.GetIntProperty(dynamicconfig.TransferProcessorFailoverMaxPollRPS, 1),
 		TransferProcessorMaxPollRPS:                          dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollRPS, 20),
-		TransferTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.TransferTaskWorkerCount, 10),
+		TransferTaskWorkerCount:                              dc.GetIntPropertyFilteredByShardID(dynamicconfig.TransferTaskWorkerCount, 10),
 		TransferTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.TransferTaskMaxRetryCount, 100),
 		TransferProcessorCompleteTransferFailureRetryCount:   dc.GetIntProperty(dynamicconfig.TransferProcessorCompleteTransferFailureRetryCount, 10),
 		TransferProcessorMaxPollInterval:                     dc.GetDurationProperty(dynamicconfig.TransferProcessorMaxPollInterval, 1*time.Minute),
@@ -366,15 +498,42 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		ReplicationTaskProcessorHostQPS:                        dc.GetFloat64Property(dynamicconfig.ReplicationTaskProcessorHostQPS, 1500),
 		ReplicationTaskProcessorShardQPS:                       dc.GetFloat64Property(dynamicconfig.ReplicationTaskProcessorShardQPS, 30),
 
-		MaximumBufferedEventsBatch:      dc.GetIntProperty(dynamicconfig.MaximumBufferedEventsBatch, 100),
-		MaximumSignalsPerExecution:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaximumSignalsPerExecution, 0),
-		ShardUpdateMinInterval:          dc.GetDurationProperty(dynamicconfig.ShardUpdateMinInterval, 5*time.Minute),
-		ShardSyncMinInterval:            dc.GetDurationProperty(dynamicconfig.ShardSyncMinInterval, 5*time.Minute),
-		ShardSyncTimerJitterCoefficient: dc.GetFloat64Property(dynamicconfig.TransferProcessorMaxPollIntervalJitterCoefficient, 0.15),
+		MaximumBufferedEventsBatch:         dc.GetIntProperty(dynamicconfig.MaximumBufferedEventsBatch, 100),
+		MaximumSignalsPerExecution:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaximumSignalsPerExecution, 0),
+		ShardUpdateMinInterval:             dc.GetDurationProperty(dynamicconfig.ShardUpdateMinInterval, 5*time.Minute),
+		ShardSyncMinInterval:               dc.GetDurationProperty(dynamicconfig.ShardSyncMinInterval, 5*time.Minute),
+		ShardSyncTimerJitterCoefficient:    dc.GetFloat64Property(dynamicconfig.TransferProcessorMaxPollIntervalJitterCoefficient, 0.15),
+		ShardDrainTimeout:                  dc.GetDurationProperty(dynamicconfig.ShardDrainTimeout, 10*time.Second),
+		ShardPersistenceMaxQPS:             dc.GetIntPropertyFilteredByShardID(dynamicconfig.ShardPersistenceMaxQPS, 0),
+		ShardPersistenceNamespaceMaxQPS:    dc.GetIntPropertyFilteredByNamespace(dynamicconfig.ShardPersistenceNamespaceMaxQPS, 0),
+		ShardPersistenceRetryPolicy:        GetRetryPolicyProperty(dc, dynamicconfig.ShardPersistenceRetryPolicy, common.GetPersistenceRetryPolicySettings()),
+		ShardAcquisitionRetryPolicy:        GetRetryPolicyProperty(dc, dynamicconfig.ShardAcquisitionRetryPolicy, shardAcquisitionRetryPolicySettings),
+		ShardAcquisitionMaxConcurrency:     dc.GetIntProperty(dynamicconfig.ShardAcquisitionMaxConcurrency, 50),
+		ShardAcquisitionPacing:             dc.GetDurationProperty(dynamicconfig.ShardAcquisitionPacing, 0),
+		ShardWarmUpMaxExecutions:           dc.GetIntProperty(dynamicconfig.ShardWarmUpMaxExecutions, 0),
+		ShardWarmUpTimeout:                 dc.GetDurationProperty(dynamicconfig.ShardWarmUpTimeout, 5*time.Second),
+		RangePreallocationThreshold:        dc.GetFloat64Property(dynamicconfig.RangePreallocationThreshold, 0.8),
+		RangeRenewalAlertRate:              dc.GetIntProperty(dynamicconfig.RangeRenewalAlertRate, 0),
+		ShardIdleUnloadTimeout:             dc.GetDurationProperty(dynamicconfig.ShardIdleUnloadTimeout, 0),
+		ShardReadOnly:                      dc.GetBoolPropertyFilteredByShardID(dynamicconfig.ShardReadOnly, false),
+		ShardClockSkewLimit:                dc.GetDurationProperty(dynamicconfig.ShardClockSkewLimit, 0),
+		ShardClockSkewBlockTimerAllocation: dc.GetBoolProperty(dynamicconfig.ShardClockSkewBlockTimerAllocation, false),
+		ShardLockSlowThreshold:             dc.GetDurationProperty(dynamicconfig.ShardLockSlowThreshold, 0),
+		ShardOwnershipHeartbeatInterval:    dc.GetDurationProperty(dynamicconfig.ShardOwnershipHeartbeatInterval, 0),
+		ShardOwnershipAssertionRate:        dc.GetFloat64Property(dynamicconfig.ShardOwnershipAssertionRate, 0),
+		ShardPruneRemovedClusters:          dc.GetBoolProperty(dynamicconfig.ShardPruneRemovedClusters, false),
+		ShardLogDedupInterval:              dc.GetDurationProperty(dynamicconfig.ShardLogDedupInterval, 0),
+		ShardReadValidateRangeID:           dc.GetBoolProperty(dynamicconfig.ShardReadValidateRangeID, false),
+
+		ShardUpdateDirtyUpdatesThreshold:   dc.GetIntProperty(dynamicconfig.ShardUpdateDirtyUpdatesThreshold, 0),
+		ShardUpdateProgressAtRiskThreshold: dc.GetIntProperty(dynamicconfig.ShardUpdateProgressAtRiskThreshold, 0),
+		ShardUpdateFastInterval:            dc.GetDurationProperty(dynamicconfig.ShardUpdateFastInterval, 5*time.Second),
+		ShardUpdateIdleInterval:            dc.GetDurationProperty(dynamicconfig.ShardUpdateIdleInterval, 0),
 
 		// history client: client/history/client.go set the client timeout 30s
 		// TODO: Return this value to the client: go.temporal.io/server/issues/294
 		LongPollExpirationInterval:          dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.HistoryLongPollExpirationInterval, time.Second*20),
+		MaxLongPollsPerExecution:            dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryMaxLongPollsPerExecution, 200),
 		EventEncodingType:                   dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.DefaultEventEncoding, enumspb.ENCODING_TYPE_PROTO3.String()),
 		EnableParentClosePolicy:             dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableParentClosePolicy, true),
 		NumParentClosePolicySystemWorkflows: dc.GetIntProperty(dynamicconfig.NumParentClosePolicySystemWorkflows, 10),
@@ -388,10 +547,25 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		BlobSizeLimitWarn:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitWarn, 512*1024),
 		MemoSizeLimitError:     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MemoSizeLimitError, 2*1024*1024),
 		MemoSizeLimitWarn:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MemoSizeLimitWarn, 2*1024),
-		HistorySizeLimitError:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistorySizeLimitError, 50*1024*1024),
-		HistorySizeLimitWarn:   dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistorySizeLimitWarn, 10*1024*1024),
-		HistoryCountLimitError: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitError, 50*1024),
-		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitWarn, 10*1024),
+		HistorySizeLimitError:  dc.GetIntPropertyFilteredByWorkflowType(dynamicconfig.HistorySizeLimitError, 50*1024*1024),
+		HistorySizeLimitWarn:   dc.GetIntPropertyFilteredByWorkflowType(dynamicconfig.HistorySizeLimitWarn, 10*1024*1024),
+		HistoryCountLimitError: dc.GetIntPropertyFilteredByWorkflowType(dynamicconfig.HistoryCountLimitError, 50*1024),
+		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByWorkflowType(dynamicconfig.HistoryCountLimitWarn, 10*1024),
+
+		MutableStateSizeLimitError:          dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MutableStateSizeLimitError, 8*1024*1024),
+		MutableStateSizeLimitWarn:           dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MutableStateSizeLimitWarn, 1024*1024),
+		NumPendingActivitiesLimitError:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.NumPendingActivitiesLimitError, 0),
+		NumPendingActivitiesLimitWarn:       dc.GetIntPropertyFilteredByNamespace(dynamicconfig.NumPendingActivitiesLimitWarn, 0),
+		NumPendingChildExecutionsLimitError: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.NumPendingChildExecutionsLimitError, 0),
+		NumPendingChildExecutionsLimitWarn:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.NumPendingChildExecutionsLimitWarn, 0),
+		NumPendingSignalsLimitError:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.NumPendingSignalsLimitError, 0),
+		NumPendingSignalsLimitWarn:          dc.GetIntPropertyFilteredByNamespace(dynamicconfig.NumPendingSignalsLimitWarn, 0),
+		EmitLimitWarnings:                   dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EmitLimitWarnings, false),
+
+		ReapplyEventTypes:         dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.ReapplyEventTypes, enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED.String()),
+		VisibilityRecordRetention: dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.VisibilityRecordRetention, 0),
+
+		ActivityHeartbeatCoalesceInterval: dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.ActivityHeartbeatCoalesceInterval, 0),
 
 		ThrottledLogRPS:   dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 4),
 		EnableStickyQuery: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStickyQuery, true),
@@ -401,6 +575,8 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		StickyTTL:                    dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.StickyTTL, time.Hour*24*365),
 		WorkflowTaskHeartbeatTimeout: dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.WorkflowTaskHeartbeatTimeout, time.Minute*30),
 
+		CapActivityTimeoutsByRunDeadline: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.CapActivityTimeoutsByRunDeadline, false),
+
 		ReplicationTaskFetcherParallelism:            dc.GetIntProperty(dynamicconfig.ReplicationTaskFetcherParallelism, 4),
 		ReplicationTaskFetcherAggregationInterval:    dc.GetDurationProperty(dynamicconfig.ReplicationTaskFetcherAggregationInterval, 2*time.Second),
 		ReplicationTaskFetcherTimerJitterCoefficient: dc.GetFloat64Property(dynamicconfig.ReplicationTaskFetcherTimerJitterCoefficient, 0.15),
@@ -414,8 +590,12 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		ReplicationTaskProcessorNoTaskRetryWait:              dc.GetDurationPropertyFilteredByShardID(dynamicconfig.ReplicationTaskProcessorNoTaskInitialWait, 2*time.Second),
 		ReplicationTaskProcessorCleanupInterval:              dc.GetDurationPropertyFilteredByShardID(dynamicconfig.ReplicationTaskProcessorCleanupInterval, 1*time.Minute),
 		ReplicationTaskProcessorCleanupJitterCoefficient:     dc.GetFloat64PropertyFilteredByShardID(dynamicconfig.ReplicationTaskProcessorCleanupJitterCoefficient, 0.15),
+		ReplicationTaskReorderBufferWindow:                   dc.GetDurationPropertyFilteredByShardID(dynamicconfig.ReplicationTaskReorderBufferWindow, 0),
+		ReplicationTaskReorderBufferMaxTasksPerExecution:     dc.GetIntPropertyFilteredByShardID(dynamicconfig.ReplicationTaskReorderBufferMaxTasksPerExecution, 8),
+		ReplicationTaskReorderBufferMaxTasks:                 dc.GetIntPropertyFilteredByShardID(dynamicconfig.ReplicationTaskReorderBufferMaxTasks, 1000),
 
 		MaxBufferedQueryCount:                 dc.GetIntProperty(dynamicconfig.MaxBufferedQueryCount, 1),
+		WorkflowTaskQuarantineThreshold:       dc.GetIntPropertyFilteredByNamespace(dynamicconfig.WorkflowTaskQuarantineThreshold, 0),
 		MutableStateChecksumGenProbability:    dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MutableStateChecksumGenProbability, 0),
 		MutableStateChecksumVerifyProbability: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MutableStateChecksumVerifyProbability, 0),
 		MutableStateChecksumInvalidateBefore:  dc.GetFloat64Property(dynamicconfig.MutableStateChecksumInvalidateBefore, 0),
@@ -429,7 +609,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		VisibilityTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.VisibilityTaskBatchSize, 100),
 		VisibilityProcessorFailoverMaxPollRPS:                  dc.GetIntProperty(dynamicconfig.VisibilityProcessorFailoverMaxPollRPS, 1),
 		VisibilityProcessorMaxPollRPS:                          dc.GetIntProperty(dynamicconfig.VisibilityProcessorMaxPollRPS, 20),
-		VisibilityTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.VisibilityTaskWorkerCount, 10),
+		VisibilityTaskWorkerCount:                              dc.GetIntPropertyFilteredByShardID(dynamicconfig.VisibilityTaskWorkerCount, 10),
 		VisibilityTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.VisibilityTaskMaxRetryCount, 100),
 		VisibilityProcessorCompleteTaskFailureRetryCount:       dc.GetIntProperty(dynamicconfig.VisibilityProcessorCompleteTaskFailureRetryCount, 10),
 		VisibilityProcessorMaxPollInterval:                     dc.GetDurationProperty(dynamicconfig.VisibilityProcessorMaxPollInterval, 1*time.Minute),
@@ -442,12 +622,14 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		VisibilityProcessorMaxRedispatchQueueSize:              dc.GetIntProperty(dynamicconfig.VisibilityProcessorMaxRedispatchQueueSize, 10000),
 		VisibilityProcessorEnablePriorityTaskProcessor:         dc.GetBoolProperty(dynamicconfig.VisibilityProcessorEnablePriorityTaskProcessor, false),
 		VisibilityProcessorVisibilityArchivalTimeLimit:         dc.GetDurationProperty(dynamicconfig.VisibilityProcessorVisibilityArchivalTimeLimit, 200*time.Millisecond),
+		VisibilityProcessorMemoSizeLimit:                       dc.GetIntPropertyFilteredByNamespace(dynamicconfig.VisibilityProcessorMemoSizeLimit, 0),
+		VisibilityActivityProgressInterval:                     dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.VisibilityActivityProgressInterval, 0),
 
 		// ===== Tiered storage =====
 		TieredStorageTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.TieredStorageTaskBatchSize, 100),
 		TieredStorageProcessorFailoverMaxPollRPS:                  dc.GetIntProperty(dynamicconfig.TieredStorageProcessorFailoverMaxPollRPS, 1),
 		TieredStorageProcessorMaxPollRPS:                          dc.GetIntProperty(dynamicconfig.TieredStorageProcessorMaxPollRPS, 20),
-		TieredStorageTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.TieredStorageTaskWorkerCount, 10),
+		TieredStorageTaskWorkerCount:                              dc.GetIntPropertyFilteredByShardID(dynamicconfig.TieredStorageTaskWorkerCount, 10),
 		TieredStorageTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.TieredStorageTaskMaxRetryCount, 100),
 		TieredStorageProcessorCompleteTaskFailureRetryCount:       dc.GetIntProperty(dynamicconfig.TieredStorageProcessorCompleteTaskFailureRetryCount, 10),
 		TieredStorageProcessorMaxPollInterval:                     dc.GetDurationProperty(dynamicconfig.TieredStorageProcessorMaxPollInterval, 1*time.Minute),
@@ -475,7 +657,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 		ESProcessorFlushInterval: dc.GetDurationProperty(dynamicconfig.WorkerESProcessorFlushInterval, 1*time.Second),
 		ESProcessorAckTimeout:    dc.GetDurationProperty(dynamicconfig.WorkerESProcessorAckTimeout, 1*time.Minute),
 
-		EnableCrossNamespaceCommands: dc.GetBoolProperty(dynamicconfig.EnableCrossNamespaceCommands, true),
+		EnableCrossNamespaceCommands:       dc.GetBoolProperty(dynamicconfig.EnableCrossNamespaceCommands, true),
+		CrossNamespaceCommandTargets:       dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.CrossNamespaceCommandTargets, ""),
+		EnableGlobalCrossNamespaceCommands: dc.GetBoolProperty(dynamicconfig.EnableGlobalCrossNamespaceCommands, false),
 	}
 
 	return cfg
@@ -483,7 +667,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int32, isAdvancedVis
 
 // GetShardID return the corresponding shard ID for a given namespaceID and workflowID pair
 func (config *Config) GetShardID(namespaceID namespace.ID, workflowID string) int32 {
-	return common.WorkflowIDToHistoryShard(namespaceID.String(), workflowID, config.NumberOfShards)
+	return config.NamespaceShardPartitions.GetShardID(namespaceID.String(), workflowID)
 }
 
 func NewDynamicConfig() *Config {
@@ -0,0 +1,52 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package configs
+
+import (
+	"time"
+
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/backoff"
+	"go.temporal.io/server/common/dynamicconfig"
+)
+
+// RetryPolicyFn returns the retry policy of a class of operations, as currently configured in dynamic config
+type RetryPolicyFn func() backoff.RetryPolicy
+
+// shardAcquisitionRetryPolicySettings retries acquiring a shard for 5m, with interval up to 10s
+var shardAcquisitionRetryPolicySettings = common.RetryPolicySettings{
+	InitialInterval:    50 * time.Millisecond,
+	MaximumInterval:    10 * time.Second,
+	ExpirationInterval: 5 * time.Minute,
+}
+
+// GetRetryPolicyProperty returns a RetryPolicyFn which overrides defaultSettings with the map property of key.
+// The map may set InitialInterval, MaximumInterval, ExpirationInterval, MaximumAttempts and JitterCoefficient.
+func GetRetryPolicyProperty(dc *dynamicconfig.Collection, key dynamicconfig.Key, defaultSettings common.RetryPolicySettings) RetryPolicyFn {
+	property := dc.GetMapProperty(key, map[string]interface{}{})
+	return func() backoff.RetryPolicy {
+		return common.CreateRetryPolicy(common.FromConfigToRetryPolicySettings(property(), defaultSettings))
+	}
+}
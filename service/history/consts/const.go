@@ -74,6 +74,8 @@ var (
 	ErrUnknownCluster = serviceerror.NewInvalidArgument("unknown cluster")
 	// ErrBufferedQueryCleared is error indicating mutable state is cleared while buffered query is pending
 	ErrBufferedQueryCleared = serviceerror.NewUnavailable("buffered query cleared, please retry")
+	// ErrTooManyLongPolls is error indicating too many long polls are waiting on the same workflow execution
+	ErrTooManyLongPolls = serviceerror.NewResourceExhausted("too many concurrent long polls on workflow execution, please retry later")
 
 	// FailedWorkflowStatuses is a set of failed workflow close states, used for start workflow policy
 	// for start workflow execution API
@@ -27,6 +27,7 @@
 package events
 
 import (
+	"sync/atomic"
 	"time"
 
 	historypb "go.temporal.io/api/history/v1"
@@ -34,6 +35,8 @@ import (
 
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/cache"
+	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
@@ -54,6 +57,8 @@ type (
 		GetEvent(key EventKey, firstEventID int64, branchToken []byte) (*historypb.HistoryEvent, error)
 		PutEvent(key EventKey, event *historypb.HistoryEvent)
 		DeleteEvent(key EventKey)
+		// Bytes returns the approximate size of the cached events
+		Bytes() int64
 	}
 
 	CacheImpl struct {
@@ -63,7 +68,24 @@ type (
 		logger        log.Logger
 		metricsClient metrics.Client
 		shardID       int32
+		isPinned      NamespacePinnedFn
+		// bytes is the size of the cached events, accessed atomically
+		bytes int64
+		// pinnedBytes is the size of the cached events of pinned namespaces, accessed atomically
+		pinnedBytes int64
+		pinnedGauge metrics.Scope
 	}
+
+	// cachedEvent is the value stored in the underlying cache. pinned records whether the event
+	// was counted towards the pinned bytes when it was inserted.
+	cachedEvent struct {
+		event  *historypb.HistoryEvent
+		pinned bool
+	}
+
+	// NamespacePinnedFn reports whether cache entries of a namespace are protected from eviction by
+	// entries of other namespaces
+	NamespacePinnedFn func(namespaceID namespace.ID) bool
 )
 
 var (
@@ -80,19 +102,39 @@ func NewEventsCache(
 	eventsMgr persistence.ExecutionManager,
 	disabled bool,
 	logger log.Logger,
-	metrics metrics.Client,
+	metricsClient metrics.Client,
+	isPinned NamespacePinnedFn,
 ) *CacheImpl {
+	if isPinned == nil {
+		isPinned = func(namespace.ID) bool { return false }
+	}
 	opts := &cache.Options{}
 	opts.InitialCapacity = initialCount
 	opts.TTL = ttl
+	opts.Protected = func(key interface{}) bool {
+		return isPinned(key.(EventKey).NamespaceID)
+	}
 
-	return &CacheImpl{
-		Cache:         cache.New(maxCount, opts),
+	eventsCache := &CacheImpl{
 		eventsMgr:     eventsMgr,
 		disabled:      disabled,
 		logger:        log.With(logger, tag.ComponentEventsCache),
-		metricsClient: metrics,
+		metricsClient: metricsClient,
 		shardID:       shardID,
+		isPinned:      isPinned,
+		pinnedGauge:   metricsClient.Scope(metrics.EventsCachePutEventScope, metrics.InstanceTag(convert.Int32ToString(shardID))),
+	}
+	opts.RemovedFunc = func(value interface{}) {
+		eventsCache.removed(value.(*cachedEvent))
+	}
+	eventsCache.Cache = cache.New(maxCount, opts)
+	return eventsCache
+}
+
+// NewNamespacePinnedFn returns a NamespacePinnedFn backed by dynamic config filtered by namespace ID
+func NewNamespacePinnedFn(pinned dynamicconfig.BoolPropertyFnWithNamespaceIDFilter) NamespacePinnedFn {
+	return func(namespaceID namespace.ID) bool {
+		return pinned(namespaceID.String())
 	}
 }
 
@@ -110,7 +152,8 @@ func (e *CacheImpl) validateKey(key EventKey) bool {
 }
 
 func (e *CacheImpl) GetEvent(key EventKey, firstEventID int64, branchToken []byte) (*historypb.HistoryEvent, error) {
-	e.metricsClient.IncCounter(metrics.EventsCacheGetEventScope, metrics.CacheRequests)
+	tierScope := e.metricsClient.Scope(metrics.EventsCacheGetEventScope, metrics.CacheTierTag(e.isPinned(key.NamespaceID)))
+	tierScope.IncCounter(metrics.CacheRequests)
 	sw := e.metricsClient.StartTimer(metrics.EventsCacheGetEventScope, metrics.CacheLatency)
 	defer sw.Stop()
 
@@ -118,13 +161,12 @@ func (e *CacheImpl) GetEvent(key EventKey, firstEventID int64, branchToken []byt
 
 	// Test hook for disabling cache
 	if !e.disabled {
-		event, cacheHit := e.Cache.Get(key).(*historypb.HistoryEvent)
-		if cacheHit {
-			return event, nil
+		if cached, cacheHit := e.Cache.Get(key).(*cachedEvent); cacheHit {
+			return cached.event, nil
 		}
 	}
 
-	e.metricsClient.IncCounter(metrics.EventsCacheGetEventScope, metrics.CacheMissCounter)
+	tierScope.IncCounter(metrics.CacheMissCounter)
 	event, err := e.getHistoryEventFromStore(key, firstEventID, branchToken)
 	if err != nil {
 		e.metricsClient.IncCounter(metrics.EventsCacheGetEventScope, metrics.CacheFailures)
@@ -139,7 +181,7 @@ func (e *CacheImpl) GetEvent(key EventKey, firstEventID int64, branchToken []byt
 
 	// If invalid, return event anyway, but don't store in cache
 	if validKey {
-		e.Put(key, event)
+		e.put(key, event)
 	}
 	return event, nil
 }
@@ -152,7 +194,31 @@ func (e *CacheImpl) PutEvent(key EventKey, event *historypb.HistoryEvent) {
 	if !e.validateKey(key) {
 		return
 	}
-	e.Put(key, event)
+	e.put(key, event)
+}
+
+func (e *CacheImpl) put(key EventKey, event *historypb.HistoryEvent) {
+	cached := &cachedEvent{
+		event:  event,
+		pinned: e.isPinned(key.NamespaceID),
+	}
+	if existing, ok := e.Put(key, cached).(*cachedEvent); ok {
+		// replaced values are not passed to the removed func
+		e.removed(existing)
+	}
+	size := int64(event.Size())
+	atomic.AddInt64(&e.bytes, size)
+	if cached.pinned {
+		e.pinnedGauge.UpdateGauge(metrics.CachePinnedBytes, float64(atomic.AddInt64(&e.pinnedBytes, size)))
+	}
+}
+
+func (e *CacheImpl) removed(cached *cachedEvent) {
+	size := int64(cached.event.Size())
+	atomic.AddInt64(&e.bytes, -size)
+	if cached.pinned {
+		e.pinnedGauge.UpdateGauge(metrics.CachePinnedBytes, float64(atomic.AddInt64(&e.pinnedBytes, -size)))
+	}
 }
 
 func (e *CacheImpl) DeleteEvent(key EventKey) {
@@ -164,6 +230,10 @@ func (e *CacheImpl) DeleteEvent(key EventKey) {
 	e.Delete(key)
 }
 
+func (e *CacheImpl) Bytes() int64 {
+	return atomic.LoadInt64(&e.bytes)
+}
+
 func (e *CacheImpl) getHistoryEventFromStore(
 	key EventKey,
 	firstEventID int64,
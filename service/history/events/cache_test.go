@@ -26,6 +26,7 @@ package events
 
 import (
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -95,6 +96,7 @@ func (s *eventsCacheSuite) newTestEventsCache() *CacheImpl {
 		false,
 		s.logger,
 		metrics.NewNoopMetricsClient(),
+		nil,
 	)
 }
 
@@ -311,3 +313,58 @@ func (s *eventsCacheSuite) TestEventsCacheInvalidKey() {
 		int64(11), branchToken)
 	s.Equal(gotEvent2, event1)
 }
+
+func (s *eventsCacheSuite) TestEventsCacheBytes() {
+	key := EventKey{namespace.ID("events-cache-bytes-namespace"), "events-cache-bytes-workflow-id", "events-cache-bytes-run-id", 23, common.EmptyVersion}
+	event := &historypb.HistoryEvent{
+		EventId:   key.EventID,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+	}
+	largerEvent := &historypb.HistoryEvent{
+		EventId:   key.EventID,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "events-cache-bytes-signal",
+		}},
+	}
+
+	s.cache.PutEvent(key, event)
+	s.Equal(int64(event.Size()), s.cache.Bytes())
+
+	s.cache.PutEvent(key, largerEvent)
+	s.Equal(int64(largerEvent.Size()), s.cache.Bytes())
+
+	s.cache.DeleteEvent(key)
+	s.Eventually(func() bool { return s.cache.Bytes() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func (s *eventsCacheSuite) TestEventsCachePinnedBytes() {
+	pinnedNamespaceID := namespace.ID("events-cache-pinned-namespace")
+	s.cache = NewEventsCache(
+		int32(10),
+		16,
+		32,
+		time.Minute,
+		s.mockExecutionManager,
+		false,
+		s.logger,
+		metrics.NewNoopMetricsClient(),
+		func(namespaceID namespace.ID) bool { return namespaceID == pinnedNamespaceID },
+	)
+	event := &historypb.HistoryEvent{
+		EventId:   14,
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED,
+	}
+	pinnedKey := EventKey{pinnedNamespaceID, "events-cache-pinned-workflow-id", "events-cache-pinned-run-id", event.GetEventId(), common.EmptyVersion}
+	unpinnedKey := EventKey{"events-cache-unpinned-namespace", "events-cache-pinned-workflow-id", "events-cache-pinned-run-id", event.GetEventId(), common.EmptyVersion}
+
+	s.cache.PutEvent(pinnedKey, event)
+	s.cache.PutEvent(unpinnedKey, event)
+	s.Equal(int64(event.Size()), atomic.LoadInt64(&s.cache.pinnedBytes))
+	s.Equal(int64(2*event.Size()), s.cache.Bytes())
+
+	s.cache.DeleteEvent(pinnedKey)
+	s.Eventually(func() bool {
+		return atomic.LoadInt64(&s.cache.pinnedBytes) == 0 && s.cache.Bytes() == int64(event.Size())
+	}, time.Second, 10*time.Millisecond)
+}
@@ -58,6 +58,20 @@ func (m *MockCache) EXPECT() *MockCacheMockRecorder {
 	return m.recorder
 }
 
+// Bytes mocks base method.
+func (m *MockCache) Bytes() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bytes")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// Bytes indicates an expected call of Bytes.
+func (mr *MockCacheMockRecorder) Bytes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bytes", reflect.TypeOf((*MockCache)(nil).Bytes))
+}
+
 // DeleteEvent mocks base method.
 func (m *MockCache) DeleteEvent(key EventKey) {
 	m.ctrl.T.Helper()
@@ -45,12 +45,14 @@ import (
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/service"
 	"go.temporal.io/server/service/history/configs"
+	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/workflow"
 )
 
 var Module = fx.Options(
 	resource.Module,
 	workflow.Module,
+	shard.Module,
 	fx.Provide(ParamsExpandProvider), // BootstrapParams should be deprecated
 	fx.Provide(dynamicconfig.NewCollection),
 	fx.Provide(ConfigProvider), // might be worth just using provider for configs.Config directly
@@ -139,6 +141,7 @@ func VisibilityManagerProvider(
 		params.PersistenceConfig,
 		persistenceServiceResolver,
 		esConfig.GetVisibilityIndex(),
+		esConfig.GetNamespaceVisibilityIndices(),
 		esClient,
 		esProcessorConfig,
 		serviceResource.GetSearchAttributesProvider(),
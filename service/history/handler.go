@@ -26,9 +26,11 @@ package history
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/api/common/v1"
@@ -44,8 +46,10 @@ import (
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/backoff"
 	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/headers"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/visibility/manager"
@@ -74,6 +78,9 @@ type (
 		replicationTaskFetchers ReplicationTaskFetchers
 		visibilityMrg           manager.VisibilityManager
 		newCacheFn              workflow.NewCacheFn
+		leaseProvider           shard.LeaseProvider
+		lifecycleObservers      shard.LifecycleObservers
+		eventsReapplyPolicy     workflow.EventsReapplyPolicy
 	}
 )
 
@@ -106,14 +113,23 @@ func NewHandler(
 	config *configs.Config,
 	visibilityMrg manager.VisibilityManager,
 	newCacheFn workflow.NewCacheFn,
+	leaseProvider shard.LeaseProvider,
+	lifecycleObservers shard.LifecycleObservers,
+	eventsReapplyPolicy workflow.EventsReapplyPolicy,
 ) *Handler {
+	if eventsReapplyPolicy == nil {
+		eventsReapplyPolicy = workflow.NewEventsReapplyPolicy(config.ReapplyEventTypes)
+	}
 	handler := &Handler{
-		Resource:        resource,
-		status:          common.DaemonStatusInitialized,
-		config:          config,
-		tokenSerializer: common.NewProtoTaskTokenSerializer(),
-		visibilityMrg:   visibilityMrg,
-		newCacheFn:      newCacheFn,
+		Resource:            resource,
+		status:              common.DaemonStatusInitialized,
+		config:              config,
+		tokenSerializer:     common.NewProtoTaskTokenSerializer(),
+		visibilityMrg:       visibilityMrg,
+		newCacheFn:          newCacheFn,
+		leaseProvider:       leaseProvider,
+		lifecycleObservers:  lifecycleObservers,
+		eventsReapplyPolicy: eventsReapplyPolicy,
 	}
 
 	// prevent us from trying to serve requests before shard controller is started and ready
@@ -144,11 +160,16 @@ func (h *Handler) Start() {
 		h.Resource,
 		h,
 		h.config,
+		h.leaseProvider,
 	)
+	for _, observer := range h.lifecycleObservers {
+		h.controller.AddLifecycleObserver(observer)
+	}
 	h.eventNotifier = events.NewNotifier(h.GetTimeSource(), h.GetMetricsClient(), h.config.GetShardID)
 	// events notifier must starts before controller
 	h.eventNotifier.Start()
 	h.controller.Start()
+	registerShardDiagnostics(h.controller)
 
 	h.startWG.Done()
 }
@@ -187,6 +208,7 @@ func (h *Handler) CreateEngine(
 		h.replicationTaskFetchers,
 		h.GetMatchingRawClient(),
 		h.newCacheFn,
+		h.eventsReapplyPolicy,
 	)
 }
 
@@ -233,6 +255,7 @@ func (h *Handler) RecordActivityTaskHeartbeat(ctx context.Context, request *hist
 	}
 	workflowID := taskToken.GetWorkflowId()
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, taskToken.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -258,6 +281,7 @@ func (h *Handler) RecordActivityTaskStarted(ctx context.Context, request *histor
 		return nil, h.convertError(errNamespaceNotSet)
 	}
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -287,12 +311,11 @@ func (h *Handler) RecordWorkflowTaskStarted(ctx context.Context, request *histor
 		return nil, h.convertError(errTaskQueueNotSet)
 	}
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
-		h.GetLogger().Error("RecordWorkflowTaskStarted failed.",
+		log.WithContext(ctx, h.GetLogger()).Error("RecordWorkflowTaskStarted failed.",
 			tag.Error(err1),
-			tag.WorkflowID(request.WorkflowExecution.GetWorkflowId()),
-			tag.WorkflowRunID(request.WorkflowExecution.GetRunId()),
 			tag.WorkflowScheduleID(request.GetScheduleId()),
 		)
 		return nil, h.convertError(err1)
@@ -328,6 +351,7 @@ func (h *Handler) RespondActivityTaskCompleted(ctx context.Context, request *his
 	}
 	workflowID := taskToken.GetWorkflowId()
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, taskToken.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -363,6 +387,7 @@ func (h *Handler) RespondActivityTaskFailed(ctx context.Context, request *histor
 	}
 	workflowID := taskToken.GetWorkflowId()
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, taskToken.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -398,6 +423,7 @@ func (h *Handler) RespondActivityTaskCanceled(ctx context.Context, request *hist
 	}
 	workflowID := taskToken.GetWorkflowId()
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, taskToken.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -439,6 +465,7 @@ func (h *Handler) RespondWorkflowTaskCompleted(ctx context.Context, request *his
 	}
 	workflowID := token.GetWorkflowId()
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, token.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -480,6 +507,7 @@ func (h *Handler) RespondWorkflowTaskFailed(ctx context.Context, request *histor
 	}
 	workflowID := token.GetWorkflowId()
 
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, token.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -505,19 +533,33 @@ func (h *Handler) StartWorkflowExecution(ctx context.Context, request *historyse
 
 	startRequest := request.StartRequest
 	workflowID := startRequest.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, "")
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
 	}
 
+	requestStartTime, hasRequestStartTime := headers.GetRequestStartTime(ctx)
+	if hasRequestStartTime {
+		h.recordStartWorkflowStageLatency(metrics.HistoryReceivedStartStageTagValue, requestStartTime)
+	}
+
 	response, err2 := engine.StartWorkflowExecution(ctx, request)
 	if err2 != nil {
 		return nil, h.convertError(err2)
 	}
 
+	if hasRequestStartTime {
+		h.recordStartWorkflowStageLatency(metrics.HistoryPersistedStartStageTagValue, requestStartTime)
+	}
 	return response, nil
 }
 
+func (h *Handler) recordStartWorkflowStageLatency(stage string, requestStartTime time.Time) {
+	h.GetMetricsClient().Scope(metrics.HistoryStartWorkflowExecutionScope, metrics.StartStageTag(stage)).
+		RecordTimer(metrics.StartWorkflowStageLatency, time.Since(requestStartTime))
+}
+
 // DescribeHistoryHost returns information about the internal states of a history host
 func (h *Handler) DescribeHistoryHost(_ context.Context, _ *historyservice.DescribeHistoryHostRequest) (_ *historyservice.DescribeHistoryHostResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
@@ -612,6 +654,7 @@ func (h *Handler) DescribeMutableState(ctx context.Context, request *historyserv
 
 	workflowExecution := request.Execution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -636,6 +679,7 @@ func (h *Handler) GetMutableState(ctx context.Context, request *historyservice.G
 
 	workflowExecution := request.Execution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -660,6 +704,7 @@ func (h *Handler) PollMutableState(ctx context.Context, request *historyservice.
 
 	workflowExecution := request.Execution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -684,6 +729,7 @@ func (h *Handler) DescribeWorkflowExecution(ctx context.Context, request *histor
 
 	workflowExecution := request.Request.Execution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -718,6 +764,7 @@ func (h *Handler) RequestCancelWorkflowExecution(ctx context.Context, request *h
 		tag.WorkflowRunID(cancelRequest.WorkflowExecution.GetRunId()))
 
 	workflowID := cancelRequest.WorkflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, cancelRequest.WorkflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -748,6 +795,7 @@ func (h *Handler) SignalWorkflowExecution(ctx context.Context, request *historys
 
 	workflowExecution := request.SignalRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -781,6 +829,7 @@ func (h *Handler) SignalWithStartWorkflowExecution(ctx context.Context, request
 
 	signalWithStartRequest := request.SignalWithStartRequest
 	workflowID := signalWithStartRequest.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, "")
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -834,6 +883,7 @@ func (h *Handler) RemoveSignalMutableState(ctx context.Context, request *history
 
 	workflowExecution := request.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -864,6 +914,7 @@ func (h *Handler) TerminateWorkflowExecution(ctx context.Context, request *histo
 
 	workflowExecution := request.TerminateRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -894,6 +945,7 @@ func (h *Handler) ResetWorkflowExecution(ctx context.Context, request *historyse
 
 	workflowExecution := request.ResetRequest.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -922,6 +974,7 @@ func (h *Handler) QueryWorkflow(ctx context.Context, request *historyservice.Que
 	}
 
 	workflowID := request.GetRequest().GetExecution().GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, request.GetRequest().GetExecution().GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -966,6 +1019,7 @@ func (h *Handler) ScheduleWorkflowTask(ctx context.Context, request *historyserv
 
 	workflowExecution := request.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -1000,6 +1054,7 @@ func (h *Handler) RecordChildExecutionCompleted(ctx context.Context, request *hi
 
 	workflowExecution := request.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -1032,6 +1087,7 @@ func (h *Handler) ResetStickyTaskQueue(ctx context.Context, request *historyserv
 	}
 
 	workflowID := request.Execution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, request.Execution.GetRunId())
 	engine, err := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err != nil {
 		return nil, h.convertError(err)
@@ -1061,6 +1117,7 @@ func (h *Handler) ReplicateEventsV2(ctx context.Context, request *historyservice
 
 	workflowExecution := request.WorkflowExecution
 	workflowID := workflowExecution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, workflowExecution.GetRunId())
 	engine, err1 := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err1 != nil {
 		return nil, h.convertError(err1)
@@ -1132,6 +1189,7 @@ func (h *Handler) SyncActivity(ctx context.Context, request *historyservice.Sync
 	}
 
 	workflowID := request.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, request.GetRunId())
 	engine, err := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err != nil {
 		return nil, h.convertError(err)
@@ -1279,6 +1337,7 @@ func (h *Handler) ReapplyEvents(ctx context.Context, request *historyservice.Rea
 
 	namespaceID := namespace.ID(request.GetNamespaceId())
 	workflowID := request.GetRequest().GetWorkflowExecution().GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, request.GetRequest().GetWorkflowExecution().GetRunId())
 	engine, err := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err != nil {
 		return nil, h.convertError(err)
@@ -1384,6 +1443,7 @@ func (h *Handler) RefreshWorkflowTasks(ctx context.Context, request *historyserv
 	namespaceID := namespace.ID(request.GetNamespaceId())
 	execution := request.GetRequest().GetExecution()
 	workflowID := execution.GetWorkflowId()
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, workflowID, execution.GetRunId())
 	engine, err := h.controller.GetEngine(ctx, namespaceID, workflowID)
 	if err != nil {
 		err = h.convertError(err)
@@ -1419,6 +1479,7 @@ func (h *Handler) GenerateLastHistoryReplicationTasks(
 	}
 
 	namespaceID := namespace.ID(request.GetNamespaceId())
+	ctx = h.withWorkflowLogTags(ctx, namespaceID, request.GetExecution().GetWorkflowId(), request.GetExecution().GetRunId())
 	engine, err := h.controller.GetEngine(ctx, namespaceID, request.GetExecution().GetWorkflowId())
 	if err != nil {
 		err = h.convertError(err)
@@ -1465,12 +1526,24 @@ func (h *Handler) GetReplicationStatus(
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
 func (h *Handler) convertError(err error) error {
-	switch err := err.(type) {
-	case *persistence.ShardOwnershipLostError:
-		if info, err := h.GetHistoryServiceResolver().Lookup(convert.Int32ToString(err.ShardID)); err == nil {
+	// range renew errors wrap the persistence error
+	var ownershipLostErr *persistence.ShardOwnershipLostError
+	if errors.As(err, &ownershipLostErr) {
+		if info, err := h.GetHistoryServiceResolver().Lookup(convert.Int32ToString(ownershipLostErr.ShardID)); err == nil {
 			return serviceerrors.NewShardOwnershipLost(h.GetHostInfo().GetAddress(), info.GetAddress())
 		}
 		return serviceerrors.NewShardOwnershipLost(h.GetHostInfo().GetAddress(), "<unknown>")
+	}
+	if errors.Is(err, shard.ErrShardClosed) || errors.Is(err, shard.ErrShardStatusUnknown) {
+		// shard is moving between hosts, let the client hedge to the new owner
+		return serviceerrors.NewShardUnavailable(err.Error())
+	}
+	if errors.Is(err, shard.ErrRangeRenewTimeout) {
+		// shard is being re-acquired, the request can be retried
+		return serviceerror.NewUnavailable(err.Error())
+	}
+
+	switch err := err.(type) {
 	case *persistence.WorkflowConditionFailedError:
 		return serviceerror.NewUnavailable(err.Msg)
 	case *persistence.CurrentWorkflowConditionFailedError:
@@ -1482,6 +1555,26 @@ func (h *Handler) convertError(err error) error {
 	return err
 }
 
+// withWorkflowLogTags attaches workflow execution tags to ctx so that loggers obtained
+// through log.WithContext within the request's call tree carry them. runID is omitted
+// when the request does not target a specific run, e.g. for start and signal with start.
+func (h *Handler) withWorkflowLogTags(
+	ctx context.Context,
+	namespaceID namespace.ID,
+	workflowID string,
+	runID string,
+) context.Context {
+	tags := []tag.Tag{
+		tag.WorkflowNamespaceID(namespaceID.String()),
+		tag.WorkflowID(workflowID),
+		tag.ShardID(h.config.GetShardID(namespaceID, workflowID)),
+	}
+	if runID != "" {
+		tags = append(tags, tag.WorkflowRunID(runID))
+	}
+	return log.ContextWithTags(ctx, tags...)
+}
+
 func validateTaskToken(taskToken *tokenspb.Task) error {
 	if taskToken.GetWorkflowId() == "" {
 		return errWorkflowIDNotSet
@@ -58,6 +58,7 @@ import (
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/definition"
 	"go.temporal.io/server/common/failure"
+	"go.temporal.io/server/common/headers"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
@@ -65,6 +66,7 @@ import (
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/versionhistory"
 	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/quotas"
 	"go.temporal.io/server/common/rpc/interceptor"
 	"go.temporal.io/server/common/searchattribute"
 	serviceerrors "go.temporal.io/server/common/serviceerror"
@@ -96,6 +98,7 @@ type (
 		timerProcessor            timerQueueProcessor
 		visibilityProcessor       visibilityQueueProcessor
 		tieredStorageProcessor    tieredStorageQueueProcessor
+		queueProcessors           map[tasks.Category]common.Daemon
 		nDCReplicator             nDCHistoryReplicator
 		nDCActivityReplicator     nDCActivityReplicator
 		replicatorProcessor       *replicatorQueueProcessorImpl
@@ -116,6 +119,8 @@ type (
 		replicationDLQHandler     replicationDLQHandler
 		searchAttributesValidator *searchattribute.Validator
 		searchAttributesMapper    searchattribute.Mapper
+		// longPolls limits the concurrent mutable state long polls per workflow execution
+		longPolls quotas.KeyedConcurrencyLimiter
 	}
 )
 
@@ -131,6 +136,7 @@ func NewEngineWithShardContext(
 	replicationTaskFetchers ReplicationTaskFetchers,
 	rawMatchingClient matchingservice.MatchingServiceClient,
 	newCacheFn workflow.NewCacheFn,
+	eventsReapplyPolicy workflow.EventsReapplyPolicy,
 ) *historyEngineImpl {
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 
@@ -168,7 +174,8 @@ func NewEngineWithShardContext(
 	historyEngImpl.timerProcessor = newTimerQueueProcessor(shard, historyEngImpl, matching, logger)
 	historyEngImpl.visibilityProcessor = newVisibilityQueueProcessor(shard, historyEngImpl, visibilityMgr, matching, historyClient, logger)
 	historyEngImpl.tieredStorageProcessor = newTieredStorageQueueProcessor(shard, historyEngImpl, matching, historyClient, logger)
-	historyEngImpl.eventsReapplier = newNDCEventsReapplier(shard.GetMetricsClient(), logger)
+	historyEngImpl.queueProcessors = newRegisteredQueueProcessors(shard, historyEngImpl)
+	historyEngImpl.eventsReapplier = newNDCEventsReapplier(shard.GetMetricsClient(), eventsReapplyPolicy, logger)
 
 	if shard.GetClusterMetadata().IsGlobalNamespaceEnabled() {
 		historyEngImpl.replicatorProcessor = newReplicatorQueueProcessor(
@@ -181,6 +188,7 @@ func NewEngineWithShardContext(
 			shard,
 			historyCache,
 			historyEngImpl.eventsReapplier,
+			eventsReapplyPolicy,
 			logger,
 		)
 		historyEngImpl.nDCActivityReplicator = newNDCActivityReplicator(
@@ -192,6 +200,7 @@ func NewEngineWithShardContext(
 	historyEngImpl.workflowResetter = newWorkflowResetter(
 		shard,
 		historyCache,
+		eventsReapplyPolicy,
 		logger,
 	)
 
@@ -284,6 +293,9 @@ func (e *historyEngineImpl) Start() {
 	if e.visibilityProcessor != nil {
 		e.visibilityProcessor.Start()
 	}
+	for _, queueProcessor := range e.queueProcessors {
+		queueProcessor.Start()
+	}
 
 	// failover callback will try to create a failover queue processor to scan all inflight tasks
 	// if domain needs to be failovered. However, in the multicursor queue logic, the scan range
@@ -315,6 +327,9 @@ func (e *historyEngineImpl) Stop() {
 	if e.visibilityProcessor != nil {
 		e.visibilityProcessor.Stop()
 	}
+	for _, queueProcessor := range e.queueProcessors {
+		queueProcessor.Stop()
+	}
 
 	for _, replicationTaskProcessor := range e.replicationTaskProcessors {
 		replicationTaskProcessor.Stop()
@@ -527,6 +542,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 	prevRunID := ""
 	prevLastWriteVersion := int64(0)
 	err = weContext.CreateWorkflowExecution(
+		ctx,
 		now,
 		createMode,
 		prevRunID,
@@ -567,6 +583,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 				return nil, err
 			}
 			err = weContext.CreateWorkflowExecution(
+				ctx,
 				now,
 				createMode,
 				prevRunID,
@@ -601,6 +618,16 @@ func (e *historyEngineImpl) PollMutableState(
 	request *historyservice.PollMutableStateRequest,
 ) (*historyservice.PollMutableStateResponse, error) {
 
+	// only reads on behalf of frontend API callers are gated by staleness, internal GetMutableState callers
+	// such as task processing and archival checks must be served by standby clusters regardless
+	namespaceID := namespace.ID(request.GetNamespaceId())
+	if err := validateNamespaceUUID(namespaceID); err != nil {
+		return nil, err
+	}
+	if err := e.validateStandbyRead(ctx, namespaceID); err != nil {
+		return nil, err
+	}
+
 	response, err := e.getMutableStateOrPolling(ctx, &historyservice.GetMutableStateRequest{
 		NamespaceId:         request.GetNamespaceId(),
 		Execution:           request.Execution,
@@ -664,6 +691,21 @@ func (e *historyEngineImpl) getMutableStateOrPolling(
 	// if caller decide to long poll on workflow execution
 	// and the event ID we are looking for is smaller than current next event ID
 	if expectedNextEventID >= response.GetNextEventId() && response.GetWorkflowStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		namespaceRegistry, err := e.shard.GetNamespaceRegistry().GetNamespaceByID(namespaceID)
+		if err != nil {
+			return nil, err
+		}
+		// a buggy client long polling one execution many times over makes every event of it fan out to all polls
+		longPollKey := namespaceID.String() + "/" + execution.GetWorkflowId() + "/" + execution.GetRunId()
+		if !e.longPolls.TryAcquire(longPollKey, e.config.MaxLongPollsPerExecution(namespaceRegistry.Name().String())) {
+			e.metricsClient.Scope(
+				metrics.HistoryPollMutableStateScope,
+				metrics.NamespaceTag(namespaceRegistry.Name().String()),
+			).IncCounter(metrics.LongPollLimitExceededCounter)
+			return nil, consts.ErrTooManyLongPolls
+		}
+		defer e.longPolls.Release(longPollKey)
+
 		subscriberID, channel, err := e.eventNotifier.WatchHistoryEvent(definition.NewWorkflowKey(namespaceID.String(), execution.GetWorkflowId(), execution.GetRunId()))
 		if err != nil {
 			return nil, err
@@ -682,10 +724,6 @@ func (e *historyEngineImpl) getMutableStateOrPolling(
 			return response, nil
 		}
 
-		namespaceRegistry, err := e.shard.GetNamespaceRegistry().GetNamespaceByID(namespaceID)
-		if err != nil {
-			return nil, err
-		}
 		timer := time.NewTimer(e.shard.GetConfig().LongPollExpirationInterval(namespaceRegistry.Name().String()))
 		defer timer.Stop()
 		for {
@@ -757,7 +795,7 @@ func (e *historyEngineImpl) QueryWorkflow(
 		return nil, err
 	}
 	defer func() { release(retErr) }()
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -876,19 +914,15 @@ func (e *historyEngineImpl) queryDirectlyThroughMatching(
 				}}, nil
 		}
 		if !common.IsContextDeadlineExceededErr(err) && !common.IsContextCanceledErr(err) {
-			e.logger.Error("query directly though matching on sticky failed, will not attempt query on non-sticky",
+			log.WithContext(ctx, e.logger).Error("query directly though matching on sticky failed, will not attempt query on non-sticky",
 				tag.WorkflowNamespace(queryRequest.GetNamespace()),
-				tag.WorkflowID(queryRequest.Execution.GetWorkflowId()),
-				tag.WorkflowRunID(queryRequest.Execution.GetRunId()),
 				tag.WorkflowQueryType(queryRequest.Query.GetQueryType()),
 				tag.Error(err))
 			return nil, err
 		}
 		if msResp.GetWorkflowStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
-			e.logger.Info("query direct through matching failed on sticky, clearing sticky before attempting on non-sticky",
+			log.WithContext(ctx, e.logger).Info("query direct through matching failed on sticky, clearing sticky before attempting on non-sticky",
 				tag.WorkflowNamespace(queryRequest.GetNamespace()),
-				tag.WorkflowID(queryRequest.Execution.GetWorkflowId()),
-				tag.WorkflowRunID(queryRequest.Execution.GetRunId()),
 				tag.WorkflowQueryType(queryRequest.Query.GetQueryType()))
 			resetContext, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			clearStickinessStopWatch := scope.StartTimer(metrics.DirectQueryDispatchClearStickinessLatency)
@@ -906,19 +940,15 @@ func (e *historyEngineImpl) queryDirectlyThroughMatching(
 	}
 
 	if err := common.IsValidContext(ctx); err != nil {
-		e.logger.Info("query context timed out before query on non-sticky task queue could be attempted",
+		log.WithContext(ctx, e.logger).Info("query context timed out before query on non-sticky task queue could be attempted",
 			tag.WorkflowNamespace(queryRequest.GetNamespace()),
-			tag.WorkflowID(queryRequest.Execution.GetWorkflowId()),
-			tag.WorkflowRunID(queryRequest.Execution.GetRunId()),
 			tag.WorkflowQueryType(queryRequest.Query.GetQueryType()))
 		scope.IncCounter(metrics.DirectQueryDispatchTimeoutBeforeNonStickyCount)
 		return nil, err
 	}
 
-	e.logger.Info("query directly through matching on sticky timed out, attempting to query on non-sticky",
+	log.WithContext(ctx, e.logger).Info("query directly through matching on sticky timed out, attempting to query on non-sticky",
 		tag.WorkflowNamespace(queryRequest.GetNamespace()),
-		tag.WorkflowID(queryRequest.Execution.GetWorkflowId()),
-		tag.WorkflowRunID(queryRequest.Execution.GetRunId()),
 		tag.WorkflowQueryType(queryRequest.Query.GetQueryType()),
 		tag.WorkflowTaskQueueName(msResp.GetStickyTaskQueue().GetName()),
 		tag.WorkflowNextEventID(msResp.GetNextEventId()))
@@ -933,10 +963,8 @@ func (e *historyEngineImpl) queryDirectlyThroughMatching(
 	matchingResp, err := e.matchingClient.QueryWorkflow(ctx, nonStickyMatchingRequest)
 	nonStickyStopWatch.Stop()
 	if err != nil {
-		e.logger.Error("query directly though matching on non-sticky failed",
+		log.WithContext(ctx, e.logger).Error("query directly though matching on non-sticky failed",
 			tag.WorkflowNamespace(queryRequest.GetNamespace()),
-			tag.WorkflowID(queryRequest.Execution.GetWorkflowId()),
-			tag.WorkflowRunID(queryRequest.Execution.GetRunId()),
 			tag.WorkflowQueryType(queryRequest.Query.GetQueryType()),
 			tag.Error(err))
 		return nil, err
@@ -966,7 +994,7 @@ func (e *historyEngineImpl) getMutableState(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1042,7 +1070,7 @@ func (e *historyEngineImpl) DescribeMutableState(
 
 	// clear mutable state to force reload from persistence. This API returns both cached and persisted version.
 	context.Clear()
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1100,6 +1128,9 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
+	if err := e.validateStandbyRead(ctx, namespaceID); err != nil {
+		return nil, err
+	}
 
 	execution := *request.Request.Execution
 
@@ -1114,7 +1145,7 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err1 := context.LoadWorkflowExecution()
+	mutableState, err1 := context.LoadWorkflowExecution(ctx)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -1656,7 +1687,7 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(
 		workflowExecution,
 		func(context workflow.Context, mutableState workflow.MutableState) (*updateWorkflowAction, error) {
 			if !mutableState.IsWorkflowExecutionRunning() {
-				e.logger.Debug("Heartbeat failed")
+				log.WithContext(ctx, e.logger).Debug("Heartbeat failed")
 				return nil, consts.ErrWorkflowCompleted
 			}
 
@@ -1683,10 +1714,31 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(
 
 			cancelRequested = ai.CancelRequested
 
-			e.logger.Debug("Activity heartbeat", tag.WorkflowScheduleID(scheduleID), tag.ActivityInfo(ai), tag.Bool(cancelRequested))
+			log.WithContext(ctx, e.logger).Debug("Activity heartbeat", tag.WorkflowScheduleID(scheduleID), tag.ActivityInfo(ai), tag.Bool(cancelRequested))
+
+			// Heartbeats with unchanged details are only written once per interval, the progress of the others is
+			// written with the next update of the workflow, e.g. when the heartbeat timer fires.
+			if mutableState.CoalesceActivityProgress(
+				ai,
+				request,
+				e.config.ActivityHeartbeatCoalesceInterval(namespaceEntry.Name().String()),
+			) {
+				e.metricsClient.IncCounter(metrics.HistoryRecordActivityTaskHeartbeatScope, metrics.ActivityHeartbeatCoalescedCounter)
+				return &updateWorkflowAction{
+					noop:               true,
+					createWorkflowTask: false,
+				}, nil
+			}
 
 			// Save progress and last HB reported time.
+			previousHeartbeatTime := ai.LastHeartbeatUpdateTime
 			mutableState.UpdateActivityProgress(ai, request)
+			workflow.UpsertActivityProgressVisibility(
+				mutableState,
+				ai,
+				previousHeartbeatTime,
+				e.config.VisibilityActivityProgressInterval(namespaceEntry.Name().String()),
+			)
 
 			return &updateWorkflowAction{
 				noop:               false,
@@ -1725,6 +1777,7 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(
 	if len(firstExecutionRunID) == 0 {
 		execution.RunId = request.WorkflowExecution.RunId
 	}
+	request.Identity = headers.GetOperatorIdentity(ctx, request.GetIdentity())
 
 	return e.updateWorkflow(ctx, namespaceID, execution,
 		func(context workflow.Context, mutableState workflow.MutableState) (*updateWorkflowAction, error) {
@@ -1767,6 +1820,14 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(
 			if _, err := mutableState.AddWorkflowExecutionCancelRequestedEvent(req); err != nil {
 				return nil, err
 			}
+			if err := workflow.RecordOperatorIdentity(
+				mutableState,
+				request.GetIdentity(),
+				e.shard.GetTimeSource().Now(),
+				true,
+			); err != nil {
+				return nil, err
+			}
 
 			return updateWorkflowWithNewWorkflowTask, nil
 		})
@@ -1816,10 +1877,8 @@ func (e *historyEngineImpl) SignalWorkflowExecution(
 
 			maxAllowedSignals := e.config.MaximumSignalsPerExecution(namespaceEntry.Name().String())
 			if maxAllowedSignals > 0 && int(executionInfo.SignalCount) >= maxAllowedSignals {
-				e.logger.Info("Execution limit reached for maximum signals", tag.WorkflowSignalCount(executionInfo.SignalCount),
-					tag.WorkflowID(execution.GetWorkflowId()),
-					tag.WorkflowRunID(execution.GetRunId()),
-					tag.WorkflowNamespaceID(namespaceID.String()))
+				log.WithContext(log.ContextWithTags(ctx, tag.WorkflowRunID(context.GetExecution().GetRunId())), e.logger).
+					Info("Execution limit reached for maximum signals", tag.WorkflowSignalCount(executionInfo.SignalCount))
 				return nil, consts.ErrSignalsLimitExceeded
 			}
 
@@ -1882,7 +1941,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 	Just_Signal_Loop:
 		for ; attempt <= conditionalRetryCount; attempt++ {
 			// workflow not exist, will create workflow then signal
-			mutableState, err1 := context.LoadWorkflowExecution()
+			mutableState, err1 := context.LoadWorkflowExecution(ctx)
 			if err1 != nil {
 				if _, ok := err1.(*serviceerror.NotFound); ok {
 					break
@@ -1898,10 +1957,8 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 			executionInfo := mutableState.GetExecutionInfo()
 			maxAllowedSignals := e.config.MaximumSignalsPerExecution(namespace.String())
 			if maxAllowedSignals > 0 && int(executionInfo.SignalCount) >= maxAllowedSignals {
-				e.logger.Info("Execution limit reached for maximum signals", tag.WorkflowSignalCount(executionInfo.SignalCount),
-					tag.WorkflowID(execution.GetWorkflowId()),
-					tag.WorkflowRunID(execution.GetRunId()),
-					tag.WorkflowNamespaceID(namespaceID.String()))
+				log.WithContext(log.ContextWithTags(ctx, tag.WorkflowRunID(context.GetExecution().GetRunId())), e.logger).
+					Info("Execution limit reached for maximum signals", tag.WorkflowSignalCount(executionInfo.SignalCount))
 				return nil, consts.ErrSignalsLimitExceeded
 			}
 
@@ -1923,7 +1980,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 
 			// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict then reload
 			// the history and try the operation again.
-			if err := context.UpdateWorkflowExecutionAsActive(e.shard.GetTimeSource().Now()); err != nil {
+			if err := context.UpdateWorkflowExecutionAsActive(ctx, e.shard.GetTimeSource().Now()); err != nil {
 				if err == consts.ErrConflict {
 					continue Just_Signal_Loop
 				}
@@ -2063,6 +2120,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 		}
 	}
 	err = context.CreateWorkflowExecution(
+		ctx,
 		now,
 		createMode,
 		prevRunID,
@@ -2145,6 +2203,7 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(
 	if len(firstExecutionRunID) == 0 {
 		execution.RunId = request.WorkflowExecution.RunId
 	}
+	identity := headers.GetOperatorIdentity(ctx, request.GetIdentity())
 
 	return e.updateWorkflow(
 		ctx,
@@ -2165,12 +2224,21 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(
 			}
 
 			eventBatchFirstEventID := mutableState.GetNextEventID()
-			return updateWorkflowWithoutWorkflowTask, workflow.TerminateWorkflow(
+			if err := workflow.TerminateWorkflow(
 				mutableState,
 				eventBatchFirstEventID,
 				request.GetReason(),
 				request.GetDetails(),
-				request.GetIdentity(),
+				identity,
+			); err != nil {
+				return nil, err
+			}
+			// closing the execution generates a visibility task which picks up the operator identity
+			return updateWorkflowWithoutWorkflowTask, workflow.RecordOperatorIdentity(
+				mutableState,
+				identity,
+				e.shard.GetTimeSource().Now(),
+				false,
 			)
 		})
 }
@@ -2300,7 +2368,7 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	}
 	defer func() { baseReleaseFn(retError) }()
 
-	baseMutableState, err := baseContext.LoadWorkflowExecution()
+	baseMutableState, err := baseContext.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -2319,6 +2387,9 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	default:
 		return nil, serviceerror.NewInternal("unknown reset type")
 	}
+	if _, err := headers.GetResetReapplyExcludeEventIDs(ctx); err != nil {
+		return nil, serviceerror.NewInvalidArgument(err.Error())
+	}
 
 	// also load the current run of the workflow, it can be different from the base runID
 	resp, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
@@ -2356,7 +2427,7 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 		}
 		defer func() { currentReleaseFn(retError) }()
 
-		currentMutableState, err = currentContext.LoadWorkflowExecution()
+		currentMutableState, err = currentContext.LoadWorkflowExecution(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -2364,10 +2435,8 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 
 	// dedup by requestID
 	if currentMutableState.GetExecutionState().CreateRequestId == request.GetRequestId() {
-		e.logger.Info("Duplicated reset request",
-			tag.WorkflowID(workflowID),
-			tag.WorkflowRunID(currentRunID),
-			tag.WorkflowNamespaceID(namespaceID.String()))
+		log.WithContext(log.ContextWithTags(ctx, tag.WorkflowRunID(currentRunID)), e.logger).
+			Info("Duplicated reset request", tag.WorkflowResetBaseRunID(baseRunID))
 		return &historyservice.ResetWorkflowExecutionResponse{
 			RunId: currentRunID,
 		}, nil
@@ -2430,7 +2499,7 @@ func (e *historyEngineImpl) updateWorkflow(
 	}
 	defer func() { workflowContext.getReleaseFn()(retError) }()
 
-	return e.updateWorkflowHelper(workflowContext, action)
+	return e.updateWorkflowHelper(ctx, workflowContext, action)
 }
 
 func (e *historyEngineImpl) updateWorkflowExecution(
@@ -2446,10 +2515,11 @@ func (e *historyEngineImpl) updateWorkflowExecution(
 	}
 	defer func() { workflowContext.getReleaseFn()(retError) }()
 
-	return e.updateWorkflowHelper(workflowContext, action)
+	return e.updateWorkflowHelper(ctx, workflowContext, action)
 }
 
 func (e *historyEngineImpl) updateWorkflowHelper(
+	ctx context.Context,
 	workflowContext workflowContext,
 	action updateWorkflowActionFunc,
 ) (retError error) {
@@ -2467,7 +2537,7 @@ UpdateHistoryLoop:
 				// Reload workflow execution history
 				workflowContext.getContext().Clear()
 				if attempt != conditionalRetryCount {
-					_, err = workflowContext.reloadMutableState()
+					_, err = workflowContext.reloadMutableState(ctx)
 					if err != nil {
 						return err
 					}
@@ -2493,10 +2563,10 @@ UpdateHistoryLoop:
 			}
 		}
 
-		err = workflowContext.getContext().UpdateWorkflowExecutionAsActive(e.shard.GetTimeSource().Now())
+		err = workflowContext.getContext().UpdateWorkflowExecutionAsActive(ctx, e.shard.GetTimeSource().Now())
 		if err == consts.ErrConflict {
 			if attempt != conditionalRetryCount {
-				_, err = workflowContext.reloadMutableState()
+				_, err = workflowContext.reloadMutableState(ctx)
 				if err != nil {
 					return err
 				}
@@ -2509,6 +2579,7 @@ UpdateHistoryLoop:
 }
 
 func (e *historyEngineImpl) failWorkflowTask(
+	ctx context.Context,
 	context workflow.Context,
 	scheduleID int64,
 	startedID int64,
@@ -2520,7 +2591,7 @@ func (e *historyEngineImpl) failWorkflowTask(
 	context.Clear()
 
 	// Reload workflow execution so we can apply the workflow task failure event
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -2589,6 +2660,19 @@ func (e *historyEngineImpl) NotifyNewVisibilityTasks(
 	}
 }
 
+func (e *historyEngineImpl) DrainQueues(
+	transferLevel int64,
+	drainTime time.Time,
+) bool {
+
+	// all queues are drained every time, so their ack levels move as far as possible
+	drained := e.txProcessor.Drain(transferLevel, drainTime)
+	if e.visibilityProcessor != nil {
+		drained = e.visibilityProcessor.Drain(transferLevel, drainTime) && drained
+	}
+	return e.timerProcessor.Drain(drainTime) && drained
+}
+
 func (e *historyEngineImpl) validateStartWorkflowExecutionRequest(
 	ctx context.Context,
 	request *workflowservice.StartWorkflowExecutionRequest,
@@ -2976,10 +3060,7 @@ func (e *historyEngineImpl) ReapplyEvents(
 				// TODO when https://github.com/uber/cadence/issues/2420 is finished, remove this block,
 				//  since cannot reapply event to a finished workflow which had no workflow tasks started
 				if baseRebuildLastEventID == common.EmptyEventID {
-					e.logger.Warn("cannot reapply event to a finished workflow",
-						tag.WorkflowNamespaceID(namespaceID.String()),
-						tag.WorkflowID(currentExecution.GetWorkflowId()),
-					)
+					log.WithContext(ctx, e.logger).Warn("cannot reapply event to a finished workflow")
 					e.metricsClient.IncCounter(metrics.HistoryReapplyEventsScope, metrics.EventReapplySkippedCount)
 					return &updateWorkflowAction{
 						noop:               true,
@@ -3045,7 +3126,7 @@ func (e *historyEngineImpl) ReapplyEvents(
 				runID,
 			)
 			if err != nil {
-				e.logger.Error("failed to re-apply stale events", tag.Error(err))
+				log.WithContext(ctx, e.logger).Error("failed to re-apply stale events", tag.Error(err))
 				return nil, err
 			}
 			if len(reappliedEvents) == 0 {
@@ -3149,7 +3230,7 @@ func (e *historyEngineImpl) RefreshWorkflowTasks(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return err
 	}
@@ -3172,7 +3253,7 @@ func (e *historyEngineImpl) RefreshWorkflowTasks(
 		return err
 	}
 
-	err = context.UpdateWorkflowExecutionAsActive(now)
+	err = context.UpdateWorkflowExecutionAsActive(ctx, now)
 	if err != nil {
 		return err
 	}
@@ -3200,7 +3281,7 @@ func (e *historyEngineImpl) GenerateLastHistoryReplicationTasks(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -3211,7 +3292,7 @@ func (e *historyEngineImpl) GenerateLastHistoryReplicationTasks(
 		return nil, err
 	}
 
-	err = e.shard.AddTasks(&persistence.AddTasksRequest{
+	err = e.shard.AddTasks(ctx, &persistence.AddTasksRequest{
 		ShardID: e.shard.GetShardID(),
 		// RangeID is set by shard
 		NamespaceID:      string(namespaceID),
@@ -3266,7 +3347,7 @@ func (e *historyEngineImpl) loadWorkflowOnce(
 		return nil, err
 	}
 
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		release(err)
 		return nil, err
@@ -117,12 +117,11 @@ func (s *engine2Suite) SetupTest() {
 	s.config = tests.NewDynamicConfig()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          1,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          1,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		s.config,
 	)
 
@@ -573,7 +572,7 @@ func (s *engine2Suite) TestRecordWorkflowTaskSuccess() {
 		workflow.CallerTypeAPI,
 	)
 	s.NoError(err)
-	loadedMS, err := ctx.LoadWorkflowExecution()
+	loadedMS, err := ctx.LoadWorkflowExecution(context.Background())
 	s.NoError(err)
 	qr := workflow.NewQueryRegistry()
 	id1, _ := qr.BufferQuery(&querypb.WorkflowQuery{})
@@ -103,11 +103,11 @@ func (s *engine3Suite) SetupTest() {
 
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&p.ShardInfoWithFailover{ShardInfo: &persistencespb.ShardInfo{
+		&persistencespb.ShardInfo{
 			ShardId:          1,
 			RangeId:          1,
 			TransferAckLevel: 0,
-		}},
+		},
 		s.config,
 	)
 
@@ -62,6 +62,7 @@ type (
 		getQueueAckLevel() int64
 		getQueueReadLevel() int64
 		updateQueueAckLevel() error
+		isDrained(level int64, since time.Time) bool
 	}
 
 	queueTaskExecutor interface {
@@ -95,5 +96,6 @@ type (
 		getAckLevel() timerKey
 		getReadLevel() timerKey
 		updateAckLevel() error
+		isDrained(level time.Time) bool
 	}
 )
@@ -263,6 +263,20 @@ func (mr *MockqueueAckMgrMockRecorder) getQueueReadLevel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getQueueReadLevel", reflect.TypeOf((*MockqueueAckMgr)(nil).getQueueReadLevel))
 }
 
+// isDrained mocks base method.
+func (m *MockqueueAckMgr) isDrained(level int64, since time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "isDrained", level, since)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// isDrained indicates an expected call of isDrained.
+func (mr *MockqueueAckMgrMockRecorder) isDrained(level, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isDrained", reflect.TypeOf((*MockqueueAckMgr)(nil).isDrained), level, since)
+}
+
 // readQueueTasks mocks base method.
 func (m *MockqueueAckMgr) readQueueTasks() ([]tasks.Task, bool, error) {
 	m.ctrl.T.Helper()
@@ -655,6 +669,20 @@ func (mr *MocktimerQueueAckMgrMockRecorder) getReadLevel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getReadLevel", reflect.TypeOf((*MocktimerQueueAckMgr)(nil).getReadLevel))
 }
 
+// isDrained mocks base method.
+func (m *MocktimerQueueAckMgr) isDrained(level time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "isDrained", level)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// isDrained indicates an expected call of isDrained.
+func (mr *MocktimerQueueAckMgrMockRecorder) isDrained(level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isDrained", reflect.TypeOf((*MocktimerQueueAckMgr)(nil).isDrained), level)
+}
+
 // readTimerTasks mocks base method.
 func (m *MocktimerQueueAckMgr) readTimerTasks() ([]tasks.Task, tasks.Task, bool, error) {
 	m.ctrl.T.Helper()
@@ -60,6 +60,7 @@ import (
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/failure"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/namespace"
@@ -127,12 +128,11 @@ func (s *engineSuite) SetupTest() {
 	s.config = tests.NewDynamicConfig()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          1,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          1,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		s.config,
 	)
 	s.eventsCache = events.NewEventsCache(
@@ -144,6 +144,7 @@ func (s *engineSuite) SetupTest() {
 		false,
 		s.mockShard.GetLogger(),
 		s.mockShard.GetMetricsClient(),
+		nil,
 	)
 	s.mockShard.SetEventsCacheForTesting(s.eventsCache)
 
@@ -331,6 +332,38 @@ func (s *engineSuite) TestGetMutableStateLongPoll() {
 	waitGroup.Wait()
 }
 
+func (s *engineSuite) TestGetMutableStateLongPoll_TooManyLongPolls() {
+	ctx := context.Background()
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "test-get-workflow-execution-event-id",
+		RunId:      tests.RunID,
+	}
+	taskqueue := "testTaskQueue"
+	identity := "testIdentity"
+
+	msBuilder := workflow.TestLocalMutableState(s.mockHistoryEngine.shard, s.eventsCache, tests.LocalNamespaceEntry,
+		log.NewTestLogger(), execution.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", taskqueue, payloads.EncodeString("input"), 100*time.Second, 50*time.Second, 200*time.Second, identity)
+	di := addWorkflowTaskScheduledEvent(msBuilder)
+	addWorkflowTaskStartedEvent(msBuilder, di.ScheduleID, taskqueue, identity)
+	ms := workflow.TestCloneToProto(msBuilder)
+	gweResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.EXPECT().GetWorkflowExecution(gomock.Any()).Return(gweResponse, nil)
+
+	s.config.MaxLongPollsPerExecution = dynamicconfig.GetIntPropertyFilteredByNamespace(1)
+	longPollKey := tests.NamespaceID.String() + "/" + execution.GetWorkflowId() + "/" + execution.GetRunId()
+	s.True(s.mockHistoryEngine.longPolls.TryAcquire(longPollKey, 1))
+	defer s.mockHistoryEngine.longPolls.Release(longPollKey)
+
+	_, err := s.mockHistoryEngine.PollMutableState(ctx, &historyservice.PollMutableStateRequest{
+		NamespaceId:         tests.NamespaceID.String(),
+		Execution:           &execution,
+		ExpectedNextEventId: 4,
+	})
+	s.Equal(consts.ErrTooManyLongPolls, err)
+}
+
 func (s *engineSuite) TestGetMutableStateLongPoll_CurrentBranchChanged() {
 	ctx := context.Background()
 
@@ -427,6 +460,69 @@ func (s *engineSuite) TestGetMutableStateLongPollTimeout() {
 	s.Equal(int64(4), response.GetNextEventId())
 }
 
+func (s *engineSuite) TestDescribeWorkflowExecution_StandbyReadTooStale() {
+	namespaceID := s.setupStaleStandbyNamespace()
+
+	_, err := s.mockHistoryEngine.DescribeWorkflowExecution(context.Background(), &historyservice.DescribeWorkflowExecutionRequest{
+		NamespaceId: namespaceID.String(),
+		Request: &workflowservice.DescribeWorkflowExecutionRequest{
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: "test-describe-standby-read-too-stale",
+				RunId:      tests.RunID,
+			},
+		},
+	})
+	s.IsType(&serviceerror.NamespaceNotActive{}, err)
+}
+
+func (s *engineSuite) TestPollMutableState_StandbyReadTooStale() {
+	namespaceID := s.setupStaleStandbyNamespace()
+
+	_, err := s.mockHistoryEngine.PollMutableState(context.Background(), &historyservice.PollMutableStateRequest{
+		NamespaceId: namespaceID.String(),
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: "test-poll-standby-read-too-stale",
+			RunId:      tests.RunID,
+		},
+	})
+	s.IsType(&serviceerror.NamespaceNotActive{}, err)
+}
+
+func (s *engineSuite) TestGetMutableState_StandbyReadNotGated() {
+	namespaceID := s.setupStaleStandbyNamespace()
+	s.mockExecutionMgr.EXPECT().GetWorkflowExecution(gomock.Any()).Return(nil, serviceerror.NewNotFound("workflow not found"))
+
+	// internal callers, e.g. task processing, read mutable state of standby namespaces regardless of staleness
+	_, err := s.mockHistoryEngine.GetMutableState(context.Background(), &historyservice.GetMutableStateRequest{
+		NamespaceId: namespaceID.String(),
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: "test-get-standby-read-not-gated",
+			RunId:      tests.RunID,
+		},
+	})
+	s.IsType(&serviceerror.NotFound{}, err)
+}
+
+func (s *engineSuite) setupStaleStandbyNamespace() namespace.ID {
+	namespaceID := namespace.ID(uuid.New())
+	standbyNamespaceEntry := namespace.NewGlobalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Id: namespaceID.String(), Name: "standby-namespace"},
+		&persistencespb.NamespaceConfig{Retention: timestamp.DurationFromDays(1)},
+		&persistencespb.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestAlternativeClusterName,
+			Clusters: []string{
+				cluster.TestCurrentClusterName,
+				cluster.TestAlternativeClusterName,
+			},
+		},
+		tests.Version,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(namespaceID).Return(standbyNamespaceEntry, nil).AnyTimes()
+	s.config.StandbyReadMaxStaleness = dynamicconfig.GetDurationPropertyFnFilteredByNamespace(time.Minute)
+	s.mockShard.SetCurrentTime(cluster.TestAlternativeClusterName, time.Now().UTC().Add(-time.Hour))
+	return namespaceID
+}
+
 func (s *engineSuite) TestQueryWorkflow_RejectBasedOnCompleted() {
 	execution := commonpb.WorkflowExecution{
 		WorkflowId: "TestQueryWorkflow_RejectBasedOnCompleted",
@@ -629,7 +725,7 @@ func (s *engineSuite) TestQueryWorkflow_ConsistentQueryBufferFull() {
 		workflow.CallerTypeAPI,
 	)
 	s.NoError(err)
-	loadedMS, err := ctx.LoadWorkflowExecution()
+	loadedMS, err := ctx.LoadWorkflowExecution(context.Background())
 	s.NoError(err)
 	qr := workflow.NewQueryRegistry()
 	qr.BufferQuery(&querypb.WorkflowQuery{})
@@ -4159,7 +4255,7 @@ func (s *engineSuite) TestRequestCancel_RespondWorkflowTaskCompleted_SuccessWith
 		workflow.CallerTypeAPI,
 	)
 	s.NoError(err)
-	loadedMS, err := ctx.LoadWorkflowExecution()
+	loadedMS, err := ctx.LoadWorkflowExecution(context.Background())
 	s.NoError(err)
 	qr := workflow.NewQueryRegistry()
 	id1, _ := qr.BufferQuery(&querypb.WorkflowQuery{})
@@ -0,0 +1,113 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.temporal.io/server/common/headers"
+	"go.temporal.io/server/service/history/configs"
+	"go.temporal.io/server/service/history/workflow"
+)
+
+// setLimitWarnings reports the limits the workflow execution is past the warn limit of to the caller through
+// response trailer, so that clients can react before the execution hits the error limit.
+func setLimitWarnings(
+	ctx context.Context,
+	config *configs.Config,
+	namespaceName string,
+	weContext workflow.Context,
+	mutableState workflow.MutableState,
+) {
+
+	if !config.EmitLimitWarnings(namespaceName) {
+		return
+	}
+	warnings := limitWarnings(config, namespaceName, weContext.GetHistorySize(), mutableState)
+	if len(warnings) == 0 {
+		return
+	}
+	// best effort, there is no response trailer outside of a gRPC call
+	_ = grpc.SetTrailer(ctx, metadata.MD{headers.LimitWarningsTrailerName: warnings})
+}
+
+func limitWarnings(
+	config *configs.Config,
+	namespaceName string,
+	historySize int64,
+	mutableState workflow.MutableState,
+) []string {
+
+	var warnings []string
+	check := func(kind string, value int, limitWarn int, limitError int) {
+		if limitWarn <= 0 || value < limitWarn {
+			return
+		}
+		warnings = append(warnings, fmt.Sprintf("%v is %v, past the warn limit of %v, the error limit is %v.", kind, value, limitWarn, limitError))
+	}
+
+	workflowType := mutableState.GetExecutionInfo().WorkflowTypeName
+	check(
+		"history size",
+		int(historySize),
+		config.HistorySizeLimitWarn(namespaceName, workflowType),
+		config.HistorySizeLimitError(namespaceName, workflowType),
+	)
+	check(
+		"history count",
+		int(mutableState.GetNextEventID()-1),
+		config.HistoryCountLimitWarn(namespaceName, workflowType),
+		config.HistoryCountLimitError(namespaceName, workflowType),
+	)
+	check(
+		"number of pending activities",
+		len(mutableState.GetPendingActivityInfos()),
+		config.NumPendingActivitiesLimitWarn(namespaceName),
+		config.NumPendingActivitiesLimitError(namespaceName),
+	)
+	check(
+		"number of pending child executions",
+		len(mutableState.GetPendingChildExecutionInfos()),
+		config.NumPendingChildExecutionsLimitWarn(namespaceName),
+		config.NumPendingChildExecutionsLimitError(namespaceName),
+	)
+	check(
+		"number of pending signals",
+		len(mutableState.GetPendingSignalExternalInfos()),
+		config.NumPendingSignalsLimitWarn(namespaceName),
+		config.NumPendingSignalsLimitError(namespaceName),
+	)
+	check(
+		"mutable state size",
+		mutableState.GetApproximatePersistedSize(),
+		config.MutableStateSizeLimitWarn(namespaceName),
+		config.MutableStateSizeLimitError(namespaceName),
+	)
+	return warnings
+}
@@ -0,0 +1,67 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/service/history/tests"
+	"go.temporal.io/server/service/history/workflow"
+)
+
+func TestLimitWarnings(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockMutableState := workflow.NewMockMutableState(controller)
+	mockMutableState.EXPECT().GetExecutionInfo().Return(&persistencespb.WorkflowExecutionInfo{}).AnyTimes()
+	mockMutableState.EXPECT().GetNextEventID().Return(int64(10)).AnyTimes()
+	mockMutableState.EXPECT().GetPendingActivityInfos().Return(map[int64]*persistencespb.ActivityInfo{
+		5: {}, 6: {}, 7: {},
+	}).AnyTimes()
+	mockMutableState.EXPECT().GetPendingChildExecutionInfos().Return(nil).AnyTimes()
+	mockMutableState.EXPECT().GetPendingSignalExternalInfos().Return(nil).AnyTimes()
+	mockMutableState.EXPECT().GetApproximatePersistedSize().Return(100).AnyTimes()
+
+	config := tests.NewDynamicConfig()
+	namespaceName := tests.Namespace.String()
+	require.Empty(t, limitWarnings(config, namespaceName, 150, mockMutableState))
+
+	config.HistorySizeLimitWarn = dynamicconfig.GetIntPropertyFilteredByWorkflowType(100)
+	config.HistorySizeLimitError = dynamicconfig.GetIntPropertyFilteredByWorkflowType(200)
+	config.NumPendingActivitiesLimitWarn = dynamicconfig.GetIntPropertyFilteredByNamespace(3)
+	config.NumPendingActivitiesLimitError = dynamicconfig.GetIntPropertyFilteredByNamespace(5)
+	require.Equal(t, []string{
+		"history size is 150, past the warn limit of 100, the error limit is 200.",
+		"number of pending activities is 3, past the warn limit of 3, the error limit is 5.",
+	}, limitWarnings(config, namespaceName, 150, mockMutableState))
+
+	require.Len(t, limitWarnings(config, namespaceName, 50, mockMutableState), 1)
+}
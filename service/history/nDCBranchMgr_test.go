@@ -86,12 +86,11 @@ func (s *nDCBranchMgrSuite) SetupTest() {
 
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          10,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          10,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		tests.NewDynamicConfig(),
 	)
 
@@ -220,7 +219,7 @@ func (s *nDCBranchMgrSuite) TestFlushBufferedEvents() {
 	s.mockClusterMetadata.EXPECT().ClusterNameForFailoverVersion(lastWriteVersion).Return(cluster.TestCurrentClusterName).AnyTimes()
 	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
 
-	s.mockContext.EXPECT().UpdateWorkflowExecutionAsActive(gomock.Any()).Return(nil)
+	s.mockContext.EXPECT().UpdateWorkflowExecutionAsActive(gomock.Any(), gomock.Any()).Return(nil)
 
 	ctx := context.Background()
 
@@ -29,7 +29,6 @@ package history
 import (
 	"context"
 
-	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/api/serviceerror"
 
@@ -50,19 +49,22 @@ type (
 	}
 
 	nDCEventsReapplierImpl struct {
-		metricsClient metrics.Client
-		logger        log.Logger
+		metricsClient       metrics.Client
+		eventsReapplyPolicy workflow.EventsReapplyPolicy
+		logger              log.Logger
 	}
 )
 
 func newNDCEventsReapplier(
 	metricsClient metrics.Client,
+	eventsReapplyPolicy workflow.EventsReapplyPolicy,
 	logger log.Logger,
 ) *nDCEventsReapplierImpl {
 
 	return &nDCEventsReapplierImpl{
-		metricsClient: metricsClient,
-		logger:        logger,
+		metricsClient:       metricsClient,
+		eventsReapplyPolicy: eventsReapplyPolicy,
+		logger:              logger,
 	}
 }
 
@@ -73,17 +75,18 @@ func (r *nDCEventsReapplierImpl) reapplyEvents(
 	runID string,
 ) ([]*historypb.HistoryEvent, error) {
 
+	namespaceName := msBuilder.GetNamespaceEntry().Name()
 	var reappliedEvents []*historypb.HistoryEvent
 	for _, event := range historyEvents {
-		switch event.GetEventType() {
-		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
-			dedupResource := definition.NewEventReappliedID(runID, event.GetEventId(), event.GetVersion())
-			if msBuilder.IsResourceDuplicated(dedupResource) {
-				// skip already applied event
-				continue
-			}
-			reappliedEvents = append(reappliedEvents, event)
+		if !workflow.IsReapplicableEvent(event) || !r.eventsReapplyPolicy.ShouldReapply(namespaceName, event) {
+			continue
 		}
+		dedupResource := definition.NewEventReappliedID(runID, event.GetEventId(), event.GetVersion())
+		if msBuilder.IsResourceDuplicated(dedupResource) {
+			// skip already applied event
+			continue
+		}
+		reappliedEvents = append(reappliedEvents, event)
 	}
 
 	if len(reappliedEvents) == 0 {
@@ -96,13 +99,7 @@ func (r *nDCEventsReapplierImpl) reapplyEvents(
 	}
 
 	for _, event := range reappliedEvents {
-		signal := event.GetWorkflowExecutionSignaledEventAttributes()
-		if _, err := msBuilder.AddWorkflowExecutionSignaled(
-			signal.GetSignalName(),
-			signal.GetInput(),
-			signal.GetIdentity(),
-			signal.GetHeader(),
-		); err != nil {
+		if err := workflow.ReapplyEvent(msBuilder, event); err != nil {
 			return nil, err
 		}
 		deDupResource := definition.NewEventReappliedID(runID, event.GetEventId(), event.GetVersion())
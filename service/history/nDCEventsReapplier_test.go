@@ -39,9 +39,11 @@ import (
 
 	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/payloads"
+	"go.temporal.io/server/service/history/tests"
 	"go.temporal.io/server/service/history/workflow"
 )
 
@@ -70,6 +72,7 @@ func (s *nDCEventReapplicationSuite) SetupTest() {
 	metricsClient := metrics.NewNoopMetricsClient()
 	s.nDCReapplication = newNDCEventsReapplier(
 		metricsClient,
+		workflow.NewEventsReapplyPolicy(dynamicconfig.GetStringPropertyFnFilteredByNamespace("WorkflowExecutionSignaled")),
 		logger,
 	)
 }
@@ -96,6 +99,7 @@ func (s *nDCEventReapplicationSuite) TestReapplyEvents_AppliedEvent() {
 	attr := event.GetWorkflowExecutionSignaledEventAttributes()
 
 	msBuilderCurrent := workflow.NewMockMutableState(s.controller)
+	msBuilderCurrent.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 	msBuilderCurrent.EXPECT().IsWorkflowExecutionRunning().Return(true)
 	msBuilderCurrent.EXPECT().GetLastWriteVersion().Return(int64(1), nil).AnyTimes()
 	msBuilderCurrent.EXPECT().GetExecutionInfo().Return(execution).AnyTimes()
@@ -117,6 +121,34 @@ func (s *nDCEventReapplicationSuite) TestReapplyEvents_AppliedEvent() {
 	s.Equal(1, len(appliedEvent))
 }
 
+func (s *nDCEventReapplicationSuite) TestReapplyEvents_Marker() {
+	runID := uuid.New()
+	event := &historypb.HistoryEvent{
+		EventId:   1,
+		EventType: enumspb.EVENT_TYPE_MARKER_RECORDED,
+		Attributes: &historypb.HistoryEvent_MarkerRecordedEventAttributes{MarkerRecordedEventAttributes: &historypb.MarkerRecordedEventAttributes{
+			MarkerName: "marker",
+			Details:    map[string]*commonpb.Payloads{"detail": payloads.EncodeString("detail")},
+		}},
+	}
+
+	msBuilderCurrent := workflow.NewMockMutableState(s.controller)
+	msBuilderCurrent.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
+	events := []*historypb.HistoryEvent{
+		{EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+		event,
+	}
+	// markers are not reapplied, even if the policy selects them
+	s.nDCReapplication = newNDCEventsReapplier(
+		metrics.NewNoopMetricsClient(),
+		workflow.NewEventsReapplyPolicy(dynamicconfig.GetStringPropertyFnFilteredByNamespace("WorkflowExecutionSignaled, MarkerRecorded")),
+		log.NewTestLogger(),
+	)
+	appliedEvent, err := s.nDCReapplication.reapplyEvents(context.Background(), msBuilderCurrent, events, runID)
+	s.NoError(err)
+	s.Equal(0, len(appliedEvent))
+}
+
 func (s *nDCEventReapplicationSuite) TestReapplyEvents_Noop() {
 	runID := uuid.New()
 	event := &historypb.HistoryEvent{
@@ -130,6 +162,7 @@ func (s *nDCEventReapplicationSuite) TestReapplyEvents_Noop() {
 	}
 
 	msBuilderCurrent := workflow.NewMockMutableState(s.controller)
+	msBuilderCurrent.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 	dedupResource := definition.NewEventReappliedID(runID, event.GetEventId(), event.GetVersion())
 	msBuilderCurrent.EXPECT().IsResourceDuplicated(dedupResource).Return(true)
 	events := []*historypb.HistoryEvent{
@@ -169,6 +202,7 @@ func (s *nDCEventReapplicationSuite) TestReapplyEvents_PartialAppliedEvent() {
 	attr1 := event1.GetWorkflowExecutionSignaledEventAttributes()
 
 	msBuilderCurrent := workflow.NewMockMutableState(s.controller)
+	msBuilderCurrent.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 	msBuilderCurrent.EXPECT().IsWorkflowExecutionRunning().Return(true)
 	msBuilderCurrent.EXPECT().GetLastWriteVersion().Return(int64(1), nil).AnyTimes()
 	msBuilderCurrent.EXPECT().GetExecutionInfo().Return(execution).AnyTimes()
@@ -211,6 +245,7 @@ func (s *nDCEventReapplicationSuite) TestReapplyEvents_Error() {
 	attr := event.GetWorkflowExecutionSignaledEventAttributes()
 
 	msBuilderCurrent := workflow.NewMockMutableState(s.controller)
+	msBuilderCurrent.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 	msBuilderCurrent.EXPECT().IsWorkflowExecutionRunning().Return(true)
 	msBuilderCurrent.EXPECT().GetLastWriteVersion().Return(int64(1), nil).AnyTimes()
 	msBuilderCurrent.EXPECT().GetExecutionInfo().Return(execution).AnyTimes()
@@ -126,10 +126,11 @@ func newNDCHistoryReplicator(
 	shard shard.Context,
 	historyCache workflow.Cache,
 	eventsReapplier nDCEventsReapplier,
+	eventsReapplyPolicy workflow.EventsReapplyPolicy,
 	logger log.Logger,
 ) *nDCHistoryReplicatorImpl {
 
-	transactionMgr := newNDCTransactionMgr(shard, historyCache, eventsReapplier, logger)
+	transactionMgr := newNDCTransactionMgr(shard, historyCache, eventsReapplier, eventsReapplyPolicy, logger)
 	replicator := &nDCHistoryReplicatorImpl{
 		shard:             shard,
 		clusterMetadata:   shard.GetService().GetClusterMetadata(),
@@ -260,9 +261,9 @@ func (r *nDCHistoryReplicatorImpl) applyEvents(
 		if r.shard.GetConfig().ReplicationEventsFromCurrentCluster(namespaceEntry.Name().String()) {
 			// this branch is used when replicating events (generated from current cluster)from remote cluster to current cluster.
 			// this could happen when the events are lost in current cluster and plan to recover them from remote cluster.
-			mutableState, err = context.LoadWorkflowExecutionForReplication(task.getVersion())
+			mutableState, err = context.LoadWorkflowExecutionForReplication(ctx, task.getVersion())
 		} else {
-			mutableState, err = context.LoadWorkflowExecution()
+			mutableState, err = context.LoadWorkflowExecution(ctx)
 		}
 		switch err.(type) {
 		case nil:
@@ -93,12 +93,11 @@ func (s *nDCStateRebuilderSuite) SetupTest() {
 
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          10,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          10,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		tests.NewDynamicConfig(),
 	)
 
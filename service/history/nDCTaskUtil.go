@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"time"
 
 	persistencespb "go.temporal.io/server/api/persistence/v1"
@@ -76,12 +77,14 @@ func verifyTaskVersion(
 // load mutable state, if mutable state's next event ID <= task ID, will attempt to refresh
 // if still mutable state's next event ID <= task ID, will return nil, nil
 func loadMutableStateForTransferTask(
+	ctx context.Context,
 	context workflow.Context,
 	transferTask tasks.Task,
 	metricsClient metrics.Client,
 	logger log.Logger,
 ) (workflow.MutableState, error) {
 	return loadMutableStateForTask(
+		ctx,
 		context,
 		transferTask,
 		getTransferTaskEventIDAndRetryable,
@@ -93,12 +96,14 @@ func loadMutableStateForTransferTask(
 // load mutable state, if mutable state's next event ID <= task ID, will attempt to refresh
 // if still mutable state's next event ID <= task ID, will return nil, nil
 func loadMutableStateForTimerTask(
+	ctx context.Context,
 	context workflow.Context,
 	timerTask tasks.Task,
 	metricsClient metrics.Client,
 	logger log.Logger,
 ) (workflow.MutableState, error) {
 	return loadMutableStateForTask(
+		ctx,
 		context,
 		timerTask,
 		getTimerTaskEventIDAndRetryable,
@@ -108,6 +113,7 @@ func loadMutableStateForTimerTask(
 }
 
 func loadMutableStateForTask(
+	ctx context.Context,
 	context workflow.Context,
 	task tasks.Task,
 	taskEventIDAndRetryable func(task tasks.Task, executionInfo *persistencespb.WorkflowExecutionInfo) (int64, bool),
@@ -115,7 +121,7 @@ func loadMutableStateForTask(
 	logger log.Logger,
 ) (workflow.MutableState, error) {
 
-	mutableState, err := context.LoadWorkflowExecution()
+	mutableState, err := context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +137,7 @@ func loadMutableStateForTask(
 	scope.IncCounter(metrics.StaleMutableStateCounter)
 	context.Clear()
 
-	mutableState, err = context.LoadWorkflowExecution()
+	mutableState, err = context.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +176,7 @@ func newNDCTransactionMgr(
 	shard shard.Context,
 	historyCache workflow.Cache,
 	eventsReapplier nDCEventsReapplier,
+	eventsReapplyPolicy workflow.EventsReapplyPolicy,
 	logger log.Logger,
 ) *nDCTransactionMgrImpl {
 
@@ -190,6 +191,7 @@ func newNDCTransactionMgr(
 		workflowResetter: newWorkflowResetter(
 			shard,
 			historyCache,
+			eventsReapplyPolicy,
 			logger,
 		),
 		eventsReapplier: eventsReapplier,
@@ -249,7 +251,10 @@ func (r *nDCTransactionMgrImpl) backfillWorkflow(
 		}
 	}()
 
+	// the events and the workflow update are one transaction, they take one persistence rate limit token
+	transactionCtx := shard.WithPersistenceToken(ctx)
 	if _, err := targetWorkflow.getContext().PersistWorkflowEvents(
+		transactionCtx,
 		targetWorkflowEvents,
 	); err != nil {
 		return err
@@ -265,6 +270,7 @@ func (r *nDCTransactionMgrImpl) backfillWorkflow(
 	}
 
 	return targetWorkflow.getContext().UpdateWorkflowExecutionWithNew(
+		transactionCtx,
 		now,
 		updateMode,
 		nil,
@@ -384,13 +390,14 @@ func (r *nDCTransactionMgrImpl) backfillWorkflowEventsReapply(
 }
 
 func (r *nDCTransactionMgrImpl) checkWorkflowExists(
-	_ context.Context,
+	ctx context.Context,
 	namespaceID namespace.ID,
 	workflowID string,
 	runID string,
 ) (bool, error) {
 
-	_, err := r.shard.GetExecutionManager().GetWorkflowExecution(
+	_, err := r.shard.GetWorkflowExecution(
+		ctx,
 		&persistence.GetWorkflowExecutionRequest{
 			ShardID:     r.shard.GetShardID(),
 			NamespaceID: namespaceID.String(),
@@ -412,7 +419,7 @@ func (r *nDCTransactionMgrImpl) checkWorkflowExists(
 }
 
 func (r *nDCTransactionMgrImpl) getCurrentWorkflowRunID(
-	_ context.Context,
+	ctx context.Context,
 	namespaceID namespace.ID,
 	workflowID string,
 ) (string, error) {
@@ -456,7 +463,7 @@ func (r *nDCTransactionMgrImpl) loadNDCWorkflow(
 		return nil, err
 	}
 
-	msBuilder, err := weContext.LoadWorkflowExecution()
+	msBuilder, err := weContext.LoadWorkflowExecution(ctx)
 	if err != nil {
 		// no matter what error happen, we need to retry
 		release(err)
@@ -27,12 +27,14 @@ package history
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/backoff"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
 	"go.temporal.io/server/service/history/workflow"
@@ -54,9 +56,13 @@ type (
 
 		sync.RWMutex
 		outstandingTasks map[int64]bool
-		readLevel        int64
+		// outstandingNamespaces is the namespace of each outstanding task, see namespaceAckLevels
+		outstandingNamespaces map[int64]namespace.ID
+		readLevel             int64
 		ackLevel         int64
 		isReadFinished   bool
+		// caughtUpTime is the start time of the last read which returned all tasks up to the max read level
+		caughtUpTime time.Time
 	}
 )
 
@@ -70,13 +76,14 @@ func newQueueAckMgr(shard shard.Context, options *QueueProcessorOptions, process
 		isFailover:       false,
 		shard:            shard,
 		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     nil,
+		processor:             processor,
+		outstandingTasks:      make(map[int64]bool),
+		outstandingNamespaces: make(map[int64]namespace.ID),
+		readLevel:             ackLevel,
+		ackLevel:              ackLevel,
+		logger:                logger,
+		metricsClient:         shard.GetMetricsClient(),
+		finishedChan:          nil,
 	}
 }
 
@@ -86,17 +93,19 @@ func newQueueFailoverAckMgr(shard shard.Context, options *QueueProcessorOptions,
 		isFailover:       true,
 		shard:            shard,
 		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     make(chan struct{}, 1),
+		processor:             processor,
+		outstandingTasks:      make(map[int64]bool),
+		outstandingNamespaces: make(map[int64]namespace.ID),
+		readLevel:             ackLevel,
+		ackLevel:              ackLevel,
+		logger:                logger,
+		metricsClient:         shard.GetMetricsClient(),
+		finishedChan:          make(chan struct{}, 1),
 	}
 }
 
 func (a *queueAckMgrImpl) readQueueTasks() ([]tasks.Task, bool, error) {
+	readTime := a.shard.GetTimeSource().Now()
 	a.RLock()
 	readLevel := a.readLevel
 	a.RUnlock()
@@ -119,6 +128,9 @@ func (a *queueAckMgrImpl) readQueueTasks() ([]tasks.Task, bool, error) {
 	if a.isFailover && !morePage {
 		a.isReadFinished = true
 	}
+	if !morePage {
+		a.caughtUpTime = readTime
+	}
 
 TaskFilterLoop:
 	for _, task := range tasks {
@@ -137,6 +149,7 @@ TaskFilterLoop:
 		a.logger.Debug("Moving read level", tag.TaskID(task.GetTaskID()))
 		a.readLevel = task.GetTaskID()
 		a.outstandingTasks[task.GetTaskID()] = false
+		a.outstandingNamespaces[task.GetTaskID()] = namespace.ID(task.GetNamespaceID())
 	}
 
 	return tasks, morePage, nil
@@ -162,6 +175,22 @@ func (a *queueAckMgrImpl) getQueueReadLevel() int64 {
 	return a.readLevel
 }
 
+// isDrained returns true if a read started after since caught up with the max read level,
+// and all tasks up to level it loaded are completed.
+func (a *queueAckMgrImpl) isDrained(level int64, since time.Time) bool {
+	a.RLock()
+	defer a.RUnlock()
+	if a.caughtUpTime.Before(since) {
+		return false
+	}
+	for taskID, acked := range a.outstandingTasks {
+		if taskID <= level && !acked {
+			return false
+		}
+	}
+	return true
+}
+
 func (a *queueAckMgrImpl) getFinishedChan() <-chan struct{} {
 	return a.finishedChan
 }
@@ -199,6 +228,7 @@ MoveAckLevelLoop:
 		if acked {
 			ackLevel = current
 			delete(a.outstandingTasks, current)
+			delete(a.outstandingNamespaces, current)
 			a.logger.Debug("Moving timer ack level to", tag.AckLevel(ackLevel))
 		} else {
 			break MoveAckLevelLoop
@@ -219,7 +249,19 @@ MoveAckLevelLoop:
 		return nil
 	}
 
+	var namespaceAckLevels map[namespace.ID]tasks.Key
+	if !a.isFailover && a.options.MetricScope == metrics.TransferActiveQueueProcessorScope {
+		namespaceAckLevels = a.namespaceAckLevels()
+	}
+
 	a.Unlock()
+	if namespaceAckLevels != nil {
+		if err := a.shard.UpdateNamespaceAckLevels(tasks.CategoryTransfer, namespaceAckLevels); err != nil {
+			a.metricsClient.IncCounter(a.options.MetricScope, metrics.AckLevelUpdateFailedCounter)
+			a.logger.Error("Error updating namespace ack levels for shard", tag.Error(err), tag.OperationFailed)
+			return err
+		}
+	}
 	if err := a.processor.updateAckLevel(ackLevel); err != nil {
 		a.metricsClient.IncCounter(a.options.MetricScope, metrics.AckLevelUpdateFailedCounter)
 		a.logger.Error("Error updating ack level for shard", tag.Error(err), tag.OperationFailed)
@@ -227,3 +269,19 @@ MoveAckLevelLoop:
 	}
 	return nil
 }
+
+// namespaceAckLevels returns the ack level of each namespace with outstanding tasks, i.e. the task ID preceding its
+// first task which isn't completed. Namespaces without outstanding tasks have completed all tasks up to the read level.
+func (a *queueAckMgrImpl) namespaceAckLevels() map[namespace.ID]tasks.Key {
+	namespaceAckLevels := make(map[namespace.ID]tasks.Key)
+	for taskID, acked := range a.outstandingTasks {
+		if acked {
+			continue
+		}
+		namespaceID := a.outstandingNamespaces[taskID]
+		if ackLevel, ok := namespaceAckLevels[namespaceID]; !ok || taskID-1 < ackLevel.TaskID {
+			namespaceAckLevels[namespaceID] = tasks.Key{TaskID: taskID - 1}
+		}
+	}
+	return namespaceAckLevels
+}
@@ -44,7 +44,7 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
-	p "go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/namespace"
 )
 
 type (
@@ -102,15 +102,13 @@ func (s *queueAckMgrSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&p.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId: 1,
-				RangeId: 1,
-				ClusterTimerAckLevel: map[string]*time.Time{
-					cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtcAddSeconds(-8),
-					cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
-				}},
-		},
+		&persistencespb.ShardInfo{
+			ShardId: 1,
+			RangeId: 1,
+			ClusterTimerAckLevel: map[string]*time.Time{
+				cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtcAddSeconds(-8),
+				cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
+			}},
 		config,
 	)
 
@@ -212,6 +210,38 @@ func (s *queueAckMgrSuite) TestReadCompleteTimerTasks() {
 	s.Equal(map[int64]bool{taskID: true}, s.queueAckMgr.outstandingTasks)
 }
 
+func (s *queueAckMgrSuite) TestIsDrained() {
+	drainTime := s.mockShard.GetTimeSource().Now()
+	// nothing was read since the drain started
+	s.False(s.queueAckMgr.isDrained(60, drainTime))
+
+	taskID1 := int64(59)
+	taskID2 := int64(61)
+	tasksInput := []tasks.Task{
+		&tasks.WorkflowTask{
+			WorkflowKey: definition.NewWorkflowKey(TestNamespaceId, "some random workflow ID", uuid.New()),
+			TaskID:      taskID1,
+			TaskQueue:   "some random task queue",
+			ScheduleID:  28,
+		},
+		&tasks.WorkflowTask{
+			WorkflowKey: definition.NewWorkflowKey(TestNamespaceId, "some random workflow ID", uuid.New()),
+			TaskID:      taskID2,
+			TaskQueue:   "some random task queue",
+			ScheduleID:  28,
+		},
+	}
+	s.mockProcessor.EXPECT().readTasks(s.queueAckMgr.readLevel).Return(tasksInput, false, nil)
+	_, _, err := s.queueAckMgr.readQueueTasks()
+	s.NoError(err)
+	s.False(s.queueAckMgr.isDrained(60, drainTime))
+
+	// tasks created after the drain started don't hold it up
+	s.queueAckMgr.completeQueueTask(taskID1)
+	s.True(s.queueAckMgr.isDrained(60, drainTime))
+	s.False(s.queueAckMgr.isDrained(61, drainTime))
+}
+
 func (s *queueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	readLevel := s.queueAckMgr.readLevel
 	// when the ack manager is first initialized, read == ack level
@@ -278,6 +308,55 @@ func (s *queueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Equal(taskID3, s.queueAckMgr.getQueueAckLevel())
 }
 
+func (s *queueAckMgrSuite) TestUpdateQueueAckLevel_NamespaceAckLevels() {
+	s.mockShard.Resource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).AnyTimes()
+	s.queueAckMgr.options.MetricScope = metrics.TransferActiveQueueProcessorScope
+
+	otherNamespaceID := uuid.New()
+	newTask := func(namespaceID string, taskID int64) tasks.Task {
+		return &tasks.WorkflowTask{
+			WorkflowKey: definition.NewWorkflowKey(
+				namespaceID,
+				"some random workflow ID",
+				uuid.New(),
+			),
+			TaskID:     taskID,
+			TaskQueue:  "some random task queue",
+			ScheduleID: 28,
+		}
+	}
+	tasksInput := []tasks.Task{
+		newTask(TestNamespaceId, 59),
+		newTask(otherNamespaceID, 60),
+		newTask(TestNamespaceId, 61),
+		newTask(otherNamespaceID, 62),
+	}
+	s.mockProcessor.EXPECT().readTasks(s.queueAckMgr.readLevel).Return(tasksInput, false, nil)
+	_, _, err := s.queueAckMgr.readQueueTasks()
+	s.NoError(err)
+
+	s.mockProcessor.EXPECT().updateAckLevel(int64(59)).Return(nil)
+	s.queueAckMgr.completeQueueTask(59)
+	s.queueAckMgr.completeQueueTask(61)
+	s.NoError(s.queueAckMgr.updateQueueAckLevel())
+	s.Equal(map[namespace.ID]tasks.Key{
+		namespace.ID(otherNamespaceID): {TaskID: 59},
+	}, s.mockShard.GetNamespaceAckLevels(tasks.CategoryTransfer))
+
+	s.mockProcessor.EXPECT().updateAckLevel(int64(61)).Return(nil)
+	s.queueAckMgr.completeQueueTask(60)
+	s.NoError(s.queueAckMgr.updateQueueAckLevel())
+	s.Equal(map[namespace.ID]tasks.Key{
+		namespace.ID(otherNamespaceID): {TaskID: 61},
+	}, s.mockShard.GetNamespaceAckLevels(tasks.CategoryTransfer))
+
+	s.mockProcessor.EXPECT().updateAckLevel(int64(62)).Return(nil)
+	s.queueAckMgr.completeQueueTask(62)
+	s.NoError(s.queueAckMgr.updateQueueAckLevel())
+	s.Empty(s.mockShard.GetNamespaceAckLevels(tasks.CategoryTransfer))
+	s.Empty(s.queueAckMgr.outstandingNamespaces)
+}
+
 // Tests for failover ack manager
 func (s *queueFailoverAckMgrSuite) SetupSuite() {
 
@@ -296,15 +375,13 @@ func (s *queueFailoverAckMgrSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&p.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId: 1,
-				RangeId: 1,
-				ClusterTimerAckLevel: map[string]*time.Time{
-					cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtc(),
-					cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
-				}},
-		},
+		&persistencespb.ShardInfo{
+			ShardId: 1,
+			RangeId: 1,
+			ClusterTimerAckLevel: map[string]*time.Time{
+				cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtc(),
+				cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
+			}},
 		config,
 	)
 
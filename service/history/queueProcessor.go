@@ -49,7 +49,7 @@ type (
 	// QueueProcessorOptions is options passed to queue processor implementation
 	QueueProcessorOptions struct {
 		BatchSize                           dynamicconfig.IntPropertyFn
-		WorkerCount                         dynamicconfig.IntPropertyFn
+		WorkerCount                         dynamicconfig.IntPropertyFnWithShardIDFilter
 		MaxPollRPS                          dynamicconfig.IntPropertyFn
 		MaxPollInterval                     dynamicconfig.DurationPropertyFn
 		MaxPollIntervalJitterCoefficient    dynamicconfig.FloatPropertyFn
@@ -104,8 +104,11 @@ func newQueueProcessorBase(
 	var taskProcessor *taskProcessor
 	if !options.EnablePriorityTaskProcessor() {
 		taskProcessorOptions := taskProcessorOptions{
-			queueSize:   options.BatchSize(),
-			workerCount: options.WorkerCount(),
+			queueSize: options.BatchSize(),
+			workerCount: func() int {
+				return options.WorkerCount(shard.GetShardID())
+			},
+			metricsScope: options.MetricScope,
 		}
 		taskProcessor = newTaskProcessor(taskProcessorOptions, shard, historyCache, logger)
 	}
@@ -145,7 +148,7 @@ func (p *queueProcessorBase) Start() {
 	}
 	p.shutdownWG.Add(1)
 	p.notifyNewTask()
-	go p.processorPump()
+	p.shard.GetDiagnostics().Go(p.processorPump)
 }
 
 func (p *queueProcessorBase) Stop() {
@@ -0,0 +1,83 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"sync"
+
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/tasks"
+)
+
+type (
+	// QueueProcessorFactory creates queue processors of a registered task category,
+	// one processor is created for each shard owned by the host along with the history engine
+	QueueProcessorFactory interface {
+		Category() tasks.Category
+		CreateProcessor(shard shard.Context, engine shard.Engine) common.Daemon
+	}
+)
+
+var (
+	queueProcessorFactoriesLock sync.RWMutex
+	queueProcessorFactories     = map[int32]QueueProcessorFactory{}
+)
+
+// RegisterQueueProcessorFactory registers a queue processor factory for its task category, which must be
+// registered with tasks.RegisterCategory first. Builtin categories have their own processors and can not
+// be registered. It panics on invalid registration and is expected to be called during server initialization.
+func RegisterQueueProcessorFactory(factory QueueProcessorFactory) {
+	queueProcessorFactoriesLock.Lock()
+	defer queueProcessorFactoriesLock.Unlock()
+
+	category := factory.Category()
+	if tasks.IsBuiltinCategory(category) {
+		panic(fmt.Sprintf("queue processor of builtin task category %v can not be replaced", category))
+	}
+	if registered, ok := tasks.GetCategoryByID(category.ID()); !ok || registered != category {
+		panic(fmt.Sprintf("task category %v is not registered", category))
+	}
+	if _, ok := queueProcessorFactories[category.ID()]; ok {
+		panic(fmt.Sprintf("queue processor factory of task category %v already registered", category))
+	}
+	queueProcessorFactories[category.ID()] = factory
+}
+
+// newRegisteredQueueProcessors creates processors of all registered task categories for the shard
+func newRegisteredQueueProcessors(
+	shard shard.Context,
+	engine shard.Engine,
+) map[tasks.Category]common.Daemon {
+	queueProcessorFactoriesLock.RLock()
+	defer queueProcessorFactoriesLock.RUnlock()
+
+	processors := make(map[tasks.Category]common.Daemon, len(queueProcessorFactories))
+	for _, factory := range queueProcessorFactories {
+		processors[factory.Category()] = factory.CreateProcessor(shard, engine)
+	}
+	return processors
+}
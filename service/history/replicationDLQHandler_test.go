@@ -92,13 +92,12 @@ func (s *replicationDLQHandlerSuite) SetupTest() {
 
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:                0,
-				RangeId:                1,
-				ReplicationAckLevel:    0,
-				ReplicationDlqAckLevel: map[string]int64{cluster.TestAlternativeClusterName: persistence.EmptyQueueMessageID},
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:                0,
+			RangeId:                1,
+			ReplicationAckLevel:    0,
+			ReplicationDlqAckLevel: map[string]int64{cluster.TestAlternativeClusterName: persistence.EmptyQueueMessageID},
+		},
 		tests.NewDynamicConfig(),
 	)
 	s.mockResource = s.mockShard.Resource
@@ -204,7 +203,6 @@ func (s *replicationDLQHandlerSuite) TestPurgeMessages() {
 			InclusiveEndTaskID:   lastMessageID,
 		}).Return(nil)
 
-	s.shardManager.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	err := s.replicationMessageHandler.purgeMessages(s.sourceCluster, lastMessageID)
 	s.NoError(err)
 }
@@ -279,8 +277,6 @@ func (s *replicationDLQHandlerSuite) TestMergeMessages() {
 		InclusiveEndTaskID:   lastMessageID,
 	}).Return(nil)
 
-	s.shardManager.EXPECT().UpdateShard(gomock.Any()).Return(nil)
-
 	token, err := s.replicationMessageHandler.mergeMessages(ctx, s.sourceCluster, lastMessageID, pageSize, pageToken)
 	s.NoError(err)
 	s.Equal(pageToken, token)
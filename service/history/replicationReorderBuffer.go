@@ -0,0 +1,175 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	replicationspb "go.temporal.io/server/api/replication/v1"
+	"go.temporal.io/server/common/definition"
+)
+
+type (
+	// replicationReorderBuffer holds history replication tasks which arrived before the events they depend on,
+	// e.g. when the source cluster retries sending an earlier batch, so they can be applied once the missing
+	// events arrive instead of triggering a history resend for every gap.
+	replicationReorderBuffer struct {
+		sync.Mutex
+
+		maxTasksPerExecution func() int
+		maxTasks             func() int
+
+		numTasks int
+		tasks    map[definition.WorkflowKey][]*bufferedReplicationTask
+	}
+
+	bufferedReplicationTask struct {
+		task         *replicationspb.ReplicationTask
+		bufferedTime time.Time
+	}
+)
+
+func newReplicationReorderBuffer(
+	maxTasksPerExecution func() int,
+	maxTasks func() int,
+) *replicationReorderBuffer {
+	return &replicationReorderBuffer{
+		maxTasksPerExecution: maxTasksPerExecution,
+		maxTasks:             maxTasks,
+		tasks:                make(map[definition.WorkflowKey][]*bufferedReplicationTask),
+	}
+}
+
+// add buffers the task of the given execution, returns false if the buffer is full
+func (b *replicationReorderBuffer) add(
+	key definition.WorkflowKey,
+	task *replicationspb.ReplicationTask,
+	now time.Time,
+) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	entries := b.tasks[key]
+	for _, entry := range entries {
+		if entry.task.GetSourceTaskId() == task.GetSourceTaskId() {
+			// same task fetched again, keep the original buffered time
+			return true
+		}
+	}
+	if len(entries) >= b.maxTasksPerExecution() || b.numTasks >= b.maxTasks() {
+		return false
+	}
+
+	b.tasks[key] = append(entries, &bufferedReplicationTask{
+		task:         task,
+		bufferedTime: now,
+	})
+	b.numTasks++
+	return true
+}
+
+// remove takes all buffered tasks of the given execution out of the buffer, in source task order
+func (b *replicationReorderBuffer) remove(
+	key definition.WorkflowKey,
+) []*bufferedReplicationTask {
+	b.Lock()
+	defer b.Unlock()
+
+	entries := b.tasks[key]
+	delete(b.tasks, key)
+	b.numTasks -= len(entries)
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].task.GetSourceTaskId() < entries[j].task.GetSourceTaskId()
+	})
+	return entries
+}
+
+// restore puts tasks previously removed from the buffer back, regardless of the buffer limits
+func (b *replicationReorderBuffer) restore(
+	key definition.WorkflowKey,
+	entries []*bufferedReplicationTask,
+) {
+	if len(entries) == 0 {
+		return
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.tasks[key] = append(b.tasks[key], entries...)
+	b.numTasks += len(entries)
+}
+
+// removeExpired takes all tasks buffered before the given time out of the buffer, in source task order
+func (b *replicationReorderBuffer) removeExpired(
+	expireTime time.Time,
+) []*replicationspb.ReplicationTask {
+	b.Lock()
+	defer b.Unlock()
+
+	var expired []*replicationspb.ReplicationTask
+	for key, entries := range b.tasks {
+		remaining := entries[:0]
+		for _, entry := range entries {
+			if entry.bufferedTime.After(expireTime) {
+				remaining = append(remaining, entry)
+			} else {
+				expired = append(expired, entry.task)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(b.tasks, key)
+		} else {
+			b.tasks[key] = remaining
+		}
+	}
+	b.numTasks -= len(expired)
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].GetSourceTaskId() < expired[j].GetSourceTaskId()
+	})
+	return expired
+}
+
+// minSourceTaskID returns the smallest source task ID of all buffered tasks
+func (b *replicationReorderBuffer) minSourceTaskID() (int64, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	minTaskID := int64(0)
+	found := false
+	for _, entries := range b.tasks {
+		for _, entry := range entries {
+			if taskID := entry.task.GetSourceTaskId(); !found || taskID < minTaskID {
+				minTaskID = taskID
+				found = true
+			}
+		}
+	}
+	return minTaskID, found
+}
@@ -0,0 +1,122 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	replicationspb "go.temporal.io/server/api/replication/v1"
+	"go.temporal.io/server/common/definition"
+)
+
+type (
+	replicationReorderBufferSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		buffer *replicationReorderBuffer
+	}
+)
+
+func TestReplicationReorderBufferSuite(t *testing.T) {
+	s := new(replicationReorderBufferSuite)
+	suite.Run(t, s)
+}
+
+func (s *replicationReorderBufferSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.buffer = newReplicationReorderBuffer(
+		func() int { return 2 },
+		func() int { return 3 },
+	)
+}
+
+func (s *replicationReorderBufferSuite) TestAddRemove() {
+	now := time.Now()
+	key := definition.NewWorkflowKey("namespace-id", "workflow-id", "run-id")
+
+	s.True(s.buffer.add(key, &replicationspb.ReplicationTask{SourceTaskId: 12}, now))
+	s.True(s.buffer.add(key, &replicationspb.ReplicationTask{SourceTaskId: 11}, now))
+	// same task fetched again does not take extra space
+	s.True(s.buffer.add(key, &replicationspb.ReplicationTask{SourceTaskId: 11}, now))
+	// max tasks per execution reached
+	s.False(s.buffer.add(key, &replicationspb.ReplicationTask{SourceTaskId: 13}, now))
+
+	minTaskID, ok := s.buffer.minSourceTaskID()
+	s.True(ok)
+	s.Equal(int64(11), minTaskID)
+
+	entries := s.buffer.remove(key)
+	s.Len(entries, 2)
+	s.Equal(int64(11), entries[0].task.GetSourceTaskId())
+	s.Equal(int64(12), entries[1].task.GetSourceTaskId())
+	s.Empty(s.buffer.remove(key))
+	_, ok = s.buffer.minSourceTaskID()
+	s.False(ok)
+
+	s.buffer.restore(key, entries[1:])
+	minTaskID, ok = s.buffer.minSourceTaskID()
+	s.True(ok)
+	s.Equal(int64(12), minTaskID)
+}
+
+func (s *replicationReorderBufferSuite) TestMaxTasks() {
+	now := time.Now()
+	key1 := definition.NewWorkflowKey("namespace-id", "workflow-id", "run-id-1")
+	key2 := definition.NewWorkflowKey("namespace-id", "workflow-id", "run-id-2")
+
+	s.True(s.buffer.add(key1, &replicationspb.ReplicationTask{SourceTaskId: 1}, now))
+	s.True(s.buffer.add(key1, &replicationspb.ReplicationTask{SourceTaskId: 2}, now))
+	s.True(s.buffer.add(key2, &replicationspb.ReplicationTask{SourceTaskId: 3}, now))
+	s.False(s.buffer.add(key2, &replicationspb.ReplicationTask{SourceTaskId: 4}, now))
+
+	s.Len(s.buffer.remove(key1), 2)
+	s.True(s.buffer.add(key2, &replicationspb.ReplicationTask{SourceTaskId: 4}, now))
+}
+
+func (s *replicationReorderBufferSuite) TestRemoveExpired() {
+	now := time.Now()
+	key1 := definition.NewWorkflowKey("namespace-id", "workflow-id", "run-id-1")
+	key2 := definition.NewWorkflowKey("namespace-id", "workflow-id", "run-id-2")
+
+	s.True(s.buffer.add(key2, &replicationspb.ReplicationTask{SourceTaskId: 3}, now.Add(-time.Minute)))
+	s.True(s.buffer.add(key1, &replicationspb.ReplicationTask{SourceTaskId: 1}, now.Add(-time.Minute)))
+	s.True(s.buffer.add(key1, &replicationspb.ReplicationTask{SourceTaskId: 2}, now))
+
+	expired := s.buffer.removeExpired(now.Add(-time.Second))
+	s.Len(expired, 2)
+	s.Equal(int64(1), expired[0].GetSourceTaskId())
+	s.Equal(int64(3), expired[1].GetSourceTaskId())
+
+	minTaskID, ok := s.buffer.minSourceTaskID()
+	s.True(ok)
+	s.Equal(int64(2), minTaskID)
+	s.Empty(s.buffer.remove(key2))
+	s.Len(s.buffer.remove(key1), 1)
+}
@@ -34,6 +34,7 @@ import (
 	enumsspb "go.temporal.io/server/api/enums/v1"
 	"go.temporal.io/server/api/historyservice/v1"
 	replicationspb "go.temporal.io/server/api/replication/v1"
+	"go.temporal.io/server/common/definition"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
@@ -46,6 +47,8 @@ import (
 type (
 	replicationTaskExecutor interface {
 		execute(replicationTask *replicationspb.ReplicationTask, forceApply bool) (int, error)
+		expireBufferedTasks() []*replicationspb.ReplicationTask
+		minBufferedTaskID() (int64, bool)
 	}
 
 	replicationTaskExecutorImpl struct {
@@ -55,6 +58,7 @@ type (
 		namespaceRegistry  namespace.Registry
 		nDCHistoryResender xdc.NDCHistoryResender
 		historyEngine      shard.Engine
+		reorderBuffer      *replicationReorderBuffer
 
 		metricsClient metrics.Client
 		logger        log.Logger
@@ -72,6 +76,8 @@ func newReplicationTaskExecutor(
 	metricsClient metrics.Client,
 	logger log.Logger,
 ) replicationTaskExecutor {
+	shardID := shard.GetShardID()
+	config := shard.GetConfig()
 	return &replicationTaskExecutorImpl{
 		currentCluster:     shard.GetClusterMetadata().GetCurrentClusterName(),
 		sourceCluster:      sourceCluster,
@@ -79,8 +85,12 @@ func newReplicationTaskExecutor(
 		namespaceRegistry:  namespaceRegistry,
 		nDCHistoryResender: nDCHistoryResender,
 		historyEngine:      historyEngine,
-		metricsClient:      metricsClient,
-		logger:             logger,
+		reorderBuffer: newReplicationReorderBuffer(
+			func() int { return config.ReplicationTaskReorderBufferMaxTasksPerExecution(shardID) },
+			func() int { return config.ReplicationTaskReorderBufferMaxTasks(shardID) },
+		),
+		metricsClient: metricsClient,
+		logger:        logger,
 	}
 }
 
@@ -190,26 +200,23 @@ func (e *replicationTaskExecutorImpl) handleHistoryReplicationTask(
 	replicationStopWatch := e.metricsClient.StartTimer(metrics.HistoryReplicationTaskScope, metrics.ServiceLatency)
 	defer replicationStopWatch.Stop()
 
-	request := &historyservice.ReplicateEventsV2Request{
-		NamespaceId: attr.NamespaceId,
-		WorkflowExecution: &commonpb.WorkflowExecution{
-			WorkflowId: attr.WorkflowId,
-			RunId:      attr.RunId,
-		},
-		VersionHistoryItems: attr.VersionHistoryItems,
-		Events:              attr.Events,
-		// new run events does not need version history since there is no prior events
-		NewRunEvents: attr.NewRunEvents,
-	}
+	workflowKey := definition.NewWorkflowKey(attr.GetNamespaceId(), attr.GetWorkflowId(), attr.GetRunId())
+	request := newReplicateEventsV2Request(attr)
 	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
 	defer cancel()
 
 	err = e.historyEngine.ReplicateEventsV2(ctx, request)
 	switch retryErr := err.(type) {
 	case nil:
+		e.applyBufferedTasks(workflowKey)
 		return nil
 
 	case *serviceerrors.RetryReplication:
+		if !forceApply && e.bufferTask(workflowKey, task) {
+			return nil
+		}
+
+		e.metricsClient.IncCounter(metrics.HistoryReplicationTaskScope, metrics.ReplicationTasksResent)
 		e.metricsClient.IncCounter(metrics.HistoryRereplicationByHistoryReplicationScope, metrics.ClientRequests)
 		resendStopWatch := e.metricsClient.StartTimer(metrics.HistoryRereplicationByHistoryReplicationScope, metrics.ClientLatency)
 		defer resendStopWatch.Stop()
@@ -228,13 +235,83 @@ func (e *replicationTaskExecutorImpl) handleHistoryReplicationTask(
 			return err
 		}
 
-		return e.historyEngine.ReplicateEventsV2(ctx, request)
+		if err := e.historyEngine.ReplicateEventsV2(ctx, request); err != nil {
+			return err
+		}
+		e.applyBufferedTasks(workflowKey)
+		return nil
 
 	default:
 		return err
 	}
 }
 
+// expireBufferedTasks returns the buffered tasks whose missing events did not arrive within the reorder window,
+// the caller is responsible for applying them with history resend
+func (e *replicationTaskExecutorImpl) expireBufferedTasks() []*replicationspb.ReplicationTask {
+	window := e.shard.GetConfig().ReplicationTaskReorderBufferWindow(e.shard.GetShardID())
+	expired := e.reorderBuffer.removeExpired(e.shard.GetTimeSource().Now().Add(-window))
+	if len(expired) != 0 {
+		e.metricsClient.AddCounter(metrics.HistoryReplicationTaskScope, metrics.ReplicationTasksBufferExpired, int64(len(expired)))
+	}
+	return expired
+}
+
+// minBufferedTaskID returns the smallest source task ID of the buffered tasks
+func (e *replicationTaskExecutorImpl) minBufferedTaskID() (int64, bool) {
+	return e.reorderBuffer.minSourceTaskID()
+}
+
+func (e *replicationTaskExecutorImpl) bufferTask(
+	workflowKey definition.WorkflowKey,
+	task *replicationspb.ReplicationTask,
+) bool {
+
+	if e.shard.GetConfig().ReplicationTaskReorderBufferWindow(e.shard.GetShardID()) <= 0 {
+		return false
+	}
+	if !e.reorderBuffer.add(workflowKey, task, e.shard.GetTimeSource().Now()) {
+		return false
+	}
+	e.metricsClient.IncCounter(metrics.HistoryReplicationTaskScope, metrics.ReplicationTasksBuffered)
+	return true
+}
+
+// applyBufferedTasks applies the buffered tasks of the execution after events before them are replicated,
+// tasks which still cannot be applied stay in the buffer until they expire
+func (e *replicationTaskExecutorImpl) applyBufferedTasks(
+	workflowKey definition.WorkflowKey,
+) {
+
+	entries := e.reorderBuffer.remove(workflowKey)
+	for i, entry := range entries {
+		ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+		err := e.historyEngine.ReplicateEventsV2(ctx, newReplicateEventsV2Request(entry.task.GetHistoryTaskV2Attributes()))
+		cancel()
+		if err != nil {
+			e.reorderBuffer.restore(workflowKey, entries[i:])
+			return
+		}
+		e.metricsClient.IncCounter(metrics.HistoryReplicationTaskScope, metrics.ReplicationTasksBufferApplied)
+	}
+}
+
+func newReplicateEventsV2Request(
+	attr *replicationspb.HistoryTaskV2Attributes,
+) *historyservice.ReplicateEventsV2Request {
+	return &historyservice.ReplicateEventsV2Request{
+		NamespaceId: attr.NamespaceId,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: attr.WorkflowId,
+			RunId:      attr.RunId,
+		},
+		VersionHistoryItems: attr.VersionHistoryItems,
+		Events:              attr.Events,
+		// new run events does not need version history since there is no prior events
+		NewRunEvents: attr.NewRunEvents,
+	}
+}
+
 func (e *replicationTaskExecutorImpl) filterTask(
 	namespaceID namespace.ID,
 	forceApply bool,
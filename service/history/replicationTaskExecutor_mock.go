@@ -72,3 +72,32 @@ func (mr *MockreplicationTaskExecutorMockRecorder) execute(replicationTask, forc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "execute", reflect.TypeOf((*MockreplicationTaskExecutor)(nil).execute), replicationTask, forceApply)
 }
+
+// expireBufferedTasks mocks base method.
+func (m *MockreplicationTaskExecutor) expireBufferedTasks() []*repication.ReplicationTask {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "expireBufferedTasks")
+	ret0, _ := ret[0].([]*repication.ReplicationTask)
+	return ret0
+}
+
+// expireBufferedTasks indicates an expected call of expireBufferedTasks.
+func (mr *MockreplicationTaskExecutorMockRecorder) expireBufferedTasks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "expireBufferedTasks", reflect.TypeOf((*MockreplicationTaskExecutor)(nil).expireBufferedTasks))
+}
+
+// minBufferedTaskID mocks base method.
+func (m *MockreplicationTaskExecutor) minBufferedTaskID() (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "minBufferedTaskID")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// minBufferedTaskID indicates an expected call of minBufferedTaskID.
+func (mr *MockreplicationTaskExecutorMockRecorder) minBufferedTaskID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "minBufferedTaskID", reflect.TypeOf((*MockreplicationTaskExecutor)(nil).minBufferedTaskID))
+}
@@ -27,6 +27,7 @@ package history
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
@@ -97,15 +98,14 @@ func (s *replicationTaskExecutorSuite) SetupTest() {
 	s.config = tests.NewDynamicConfig()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:             0,
-				RangeId:             1,
-				ReplicationAckLevel: 0,
-				ReplicationDlqAckLevel: map[string]int64{
-					cluster.TestAlternativeClusterName: persistence.EmptyQueueMessageID,
-				},
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:             0,
+			RangeId:             1,
+			ReplicationAckLevel: 0,
+			ReplicationDlqAckLevel: map[string]int64{
+				cluster.TestAlternativeClusterName: persistence.EmptyQueueMessageID,
+			},
+		},
 		s.config,
 	)
 	s.mockEngine = shard.NewMockEngine(s.controller)
@@ -377,3 +377,71 @@ func (s *replicationTaskExecutorSuite) TestProcess_HistoryReplicationTask_Resend
 	_, err := s.replicationTaskHandler.execute(task, true)
 	s.NoError(err)
 }
+
+func (s *replicationTaskExecutorSuite) TestProcess_HistoryReplicationTask_BufferOutOfOrder() {
+	s.config.ReplicationTaskReorderBufferWindow = func(shardID int32) time.Duration { return time.Minute }
+
+	namespaceID := namespace.ID(uuid.New())
+	workflowID := uuid.New()
+	runID := uuid.New()
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(namespaceID).Return(namespace.NewGlobalNamespaceForTest(
+		nil,
+		nil,
+		&persistencespb.NamespaceReplicationConfig{Clusters: []string{cluster.TestCurrentClusterName}},
+		0,
+	), nil).AnyTimes()
+
+	newTask := func(sourceTaskID int64, eventID int64) (*replicationspb.ReplicationTask, *historyservice.ReplicateEventsV2Request) {
+		task := &replicationspb.ReplicationTask{
+			TaskType:     enumsspb.REPLICATION_TASK_TYPE_HISTORY_V2_TASK,
+			SourceTaskId: sourceTaskID,
+			Attributes: &replicationspb.ReplicationTask_HistoryTaskV2Attributes{
+				HistoryTaskV2Attributes: &replicationspb.HistoryTaskV2Attributes{
+					NamespaceId:         namespaceID.String(),
+					WorkflowId:          workflowID,
+					RunId:               runID,
+					VersionHistoryItems: []*historyspb.VersionHistoryItem{{EventId: eventID, Version: 2333}},
+				},
+			},
+		}
+		request := &historyservice.ReplicateEventsV2Request{
+			NamespaceId: namespaceID.String(),
+			WorkflowExecution: &commonpb.WorkflowExecution{
+				WorkflowId: workflowID,
+				RunId:      runID,
+			},
+			VersionHistoryItems: []*historyspb.VersionHistoryItem{{EventId: eventID, Version: 2333}},
+		}
+		return task, request
+	}
+	task1, request1 := newTask(101, 10)
+	task2, request2 := newTask(102, 20)
+
+	// task 2 arrives first and is buffered instead of triggering history resend
+	s.mockEngine.EXPECT().ReplicateEventsV2(gomock.Any(), request2).Return(serviceerrors.NewRetryReplication(
+		"missing events",
+		namespaceID.String(),
+		workflowID,
+		runID,
+		5,
+		2333,
+		11,
+		2333,
+	))
+	_, err := s.replicationTaskHandler.execute(task2, false)
+	s.NoError(err)
+	minTaskID, ok := s.replicationTaskHandler.minBufferedTaskID()
+	s.True(ok)
+	s.Equal(int64(102), minTaskID)
+
+	// task 1 fills the gap, task 2 is applied from the buffer
+	gomock.InOrder(
+		s.mockEngine.EXPECT().ReplicateEventsV2(gomock.Any(), request1).Return(nil),
+		s.mockEngine.EXPECT().ReplicateEventsV2(gomock.Any(), request2).Return(nil),
+	)
+	_, err = s.replicationTaskHandler.execute(task1, false)
+	s.NoError(err)
+	_, ok = s.replicationTaskHandler.minBufferedTaskID()
+	s.False(ok)
+	s.Empty(s.replicationTaskHandler.expireBufferedTasks())
+}
@@ -167,7 +167,7 @@ func (p *ReplicationTaskProcessorImpl) Start() {
 		return
 	}
 
-	go p.eventLoop()
+	p.shard.GetDiagnostics().Go(p.eventLoop)
 
 	p.logger.Info("ReplicationTaskProcessor started.")
 }
@@ -250,6 +250,13 @@ func (p *ReplicationTaskProcessorImpl) pollProcessReplicationTasks() (retError e
 		}
 	}()
 
+	for _, replicationTask := range p.replicationTaskExecutor.expireBufferedTasks() {
+		// buffered tasks already passed the namespace filter, force apply them so the missing events are resent
+		if err := p.applyReplicationTask(replicationTask, true); err != nil {
+			return err
+		}
+	}
+
 	taskIterator := collection.NewPagingIterator(p.paginationFn)
 	for taskIterator.HasNext() && !p.isStopped() {
 		task, err := taskIterator.Next()
@@ -258,10 +265,10 @@ func (p *ReplicationTaskProcessorImpl) pollProcessReplicationTasks() (retError e
 		}
 
 		replicationTask := task.(*replicationspb.ReplicationTask)
-		if err = p.applyReplicationTask(replicationTask); err != nil {
+		if err = p.applyReplicationTask(replicationTask, false); err != nil {
 			return err
 		}
-		p.maxRxProcessedTaskID = replicationTask.GetSourceTaskId()
+		p.updateMaxRxProcessedTaskID(replicationTask.GetSourceTaskId())
 		p.maxRxProcessedTimestamp = timestamp.TimeValue(replicationTask.GetVisibilityTime())
 	}
 
@@ -269,16 +276,28 @@ func (p *ReplicationTaskProcessorImpl) pollProcessReplicationTasks() (retError e
 		// all tasks fetched successfully processed
 		// setting the receiver side max processed task ID to max received task ID
 		// since task ID is not contiguous
-		p.maxRxProcessedTaskID = p.maxRxReceivedTaskID
+		p.updateMaxRxProcessedTaskID(p.maxRxReceivedTaskID)
 	}
 
 	return nil
 }
 
+// updateMaxRxProcessedTaskID moves the receiver side max processed task ID, but never past a task held
+// in the reorder buffer, so buffered tasks are fetched again if this processor is restarted
+func (p *ReplicationTaskProcessorImpl) updateMaxRxProcessedTaskID(
+	taskID int64,
+) {
+	if minBufferedTaskID, ok := p.replicationTaskExecutor.minBufferedTaskID(); ok && taskID >= minBufferedTaskID {
+		taskID = minBufferedTaskID - 1
+	}
+	p.maxRxProcessedTaskID = taskID
+}
+
 func (p *ReplicationTaskProcessorImpl) applyReplicationTask(
 	replicationTask *replicationspb.ReplicationTask,
+	forceApply bool,
 ) error {
-	err := p.handleReplicationTask(replicationTask)
+	err := p.handleReplicationTask(replicationTask, forceApply)
 	if err == nil || p.isStopped() {
 		return err
 	}
@@ -322,12 +341,13 @@ func (p *ReplicationTaskProcessorImpl) handleSyncShardStatus(
 
 func (p *ReplicationTaskProcessorImpl) handleReplicationTask(
 	replicationTask *replicationspb.ReplicationTask,
+	forceApply bool,
 ) error {
 
 	_ = p.rateLimiter.Wait(context.Background())
 
 	operation := func() error {
-		scope, err := p.replicationTaskExecutor.execute(replicationTask, false)
+		scope, err := p.replicationTaskExecutor.execute(replicationTask, forceApply)
 		p.emitTaskMetrics(scope, err)
 		return err
 	}
@@ -485,6 +505,10 @@ func (p *ReplicationTaskProcessorImpl) cleanupReplicationTasks() error {
 		return nil
 	}
 
+	if err := p.shard.AssertWritable(); err != nil {
+		return err
+	}
+
 	p.logger.Info("cleaning up replication task queue", tag.ReadLevel(*minAckedTaskID))
 	p.metricsClient.Scope(metrics.ReplicationTaskCleanupScope).IncCounter(metrics.ReplicationTaskCleanupCount)
 	p.metricsClient.Scope(
@@ -108,14 +108,12 @@ func (s *replicationTaskProcessorSuite) SetupTest() {
 	s.shardID = rand.Int31()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          s.shardID,
-				RangeId:          1,
-				TransferAckLevel: 0,
-				ClusterReplicationLevel: map[string]int64{
-					cluster.TestAlternativeClusterName: persistence.EmptyQueueMessageID,
-				},
+		&persistencespb.ShardInfo{
+			ShardId:          s.shardID,
+			RangeId:          1,
+			TransferAckLevel: 0,
+			ClusterReplicationLevel: map[string]int64{
+				cluster.TestAlternativeClusterName: persistence.EmptyQueueMessageID,
 			},
 		},
 		s.config,
@@ -196,7 +194,7 @@ func (s *replicationTaskProcessorSuite) TestHandleReplicationTask_SyncActivity()
 	}
 
 	s.mockReplicationTaskExecutor.EXPECT().execute(task, false).Return(0, nil)
-	err := s.replicationTaskProcessor.handleReplicationTask(task)
+	err := s.replicationTaskProcessor.handleReplicationTask(task, false)
 	s.NoError(err)
 }
 
@@ -233,7 +231,7 @@ func (s *replicationTaskProcessorSuite) TestHandleReplicationTask_History() {
 	}
 
 	s.mockReplicationTaskExecutor.EXPECT().execute(task, false).Return(0, nil)
-	err = s.replicationTaskProcessor.handleReplicationTask(task)
+	err = s.replicationTaskProcessor.handleReplicationTask(task, false)
 	s.NoError(err)
 }
 
@@ -362,7 +360,6 @@ func (s *replicationTaskProcessorSuite) TestConvertTaskToDLQTask_History() {
 
 func (s *replicationTaskProcessorSuite) TestCleanupReplicationTask_Noop() {
 	ackedTaskID := int64(12345)
-	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	err := s.mockShard.UpdateClusterReplicationLevel(cluster.TestAlternativeClusterName, ackedTaskID, time.Time{})
 	s.NoError(err)
 
@@ -373,7 +370,6 @@ func (s *replicationTaskProcessorSuite) TestCleanupReplicationTask_Noop() {
 
 func (s *replicationTaskProcessorSuite) TestCleanupReplicationTask_Cleanup() {
 	ackedTaskID := int64(12345)
-	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	err := s.mockShard.UpdateClusterReplicationLevel(cluster.TestAlternativeClusterName, ackedTaskID, time.Time{})
 	s.NoError(err)
 
@@ -136,6 +136,7 @@ func (p *replicatorQueueProcessorImpl) paginateTasks(
 	minTaskID, maxTaskID := p.taskIDsRange(queryMessageID)
 	replicationTasks, lastTaskID, err := p.getTasks(
 		ctx,
+		pollingCluster,
 		minTaskID,
 		maxTaskID,
 		p.pageSize,
@@ -177,6 +178,7 @@ func (p *replicatorQueueProcessorImpl) paginateTasks(
 
 func (p *replicatorQueueProcessorImpl) getTasks(
 	ctx context.Context,
+	pollingCluster string,
 	minTaskID int64,
 	maxTaskID int64,
 	batchSize int,
@@ -202,6 +204,9 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 
 		token = response.NextPageToken
 		for _, task := range response.Tasks {
+			if !p.allowedByDataResidency(task, pollingCluster) {
+				continue
+			}
 			if replicationTask, err := p.taskInfoToTask(
 				ctx,
 				task,
@@ -230,6 +235,29 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 	return tasks, tasks[len(tasks)-1].GetSourceTaskId(), nil
 }
 
+// allowedByDataResidency checks the namespace data residency constraints at
+// read time, so tasks generated before a constraint was declared never leave
+// the allowed clusters.
+func (p *replicatorQueueProcessorImpl) allowedByDataResidency(
+	task tasks.Task,
+	pollingCluster string,
+) bool {
+
+	namespaceEntry, err := p.shard.GetNamespaceRegistry().GetNamespaceByID(namespace.ID(task.GetNamespaceID()))
+	if err != nil {
+		// let task conversion deal with namespace lookup errors
+		return true
+	}
+	if namespaceEntry.DataResidency().AllowsCluster(pollingCluster) {
+		return true
+	}
+	p.metricsClient.Scope(
+		metrics.ReplicatorQueueProcessorScope,
+		metrics.TargetClusterTag(pollingCluster),
+	).IncCounter(metrics.ReplicationTasksSkipped)
+	return false
+}
+
 func (p *replicatorQueueProcessorImpl) getTask(
 	ctx context.Context,
 	taskInfo *replicationspb.ReplicationTaskInfo,
@@ -551,7 +579,7 @@ func (p *replicatorQueueProcessorImpl) processReplication(
 	}
 	defer func() { release(retError) }()
 
-	msBuilder, err := context.LoadWorkflowExecution()
+	msBuilder, err := context.LoadWorkflowExecution(ctx)
 	switch err.(type) {
 	case nil:
 		if !processTaskIfClosed && !msBuilder.IsWorkflowExecutionRunning() {
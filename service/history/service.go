@@ -39,6 +39,7 @@ import (
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/service/history/configs"
+	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/workflow"
 )
 
@@ -64,12 +65,15 @@ func NewService(
 	serviceConfig *configs.Config,
 	visibilityMgr manager.VisibilityManager,
 	newCacheFn workflow.NewCacheFn,
+	leaseProvider shard.LeaseProvider,
+	lifecycleObservers shard.LifecycleObservers,
+	eventsReapplyPolicy workflow.EventsReapplyPolicy,
 ) *Service {
 	return &Service{
 		Resource:          serviceResource,
 		status:            common.DaemonStatusInitialized,
 		server:            grpc.NewServer(grpcServerOptions...),
-		handler:           NewHandler(serviceResource, serviceConfig, visibilityMgr, newCacheFn),
+		handler:           NewHandler(serviceResource, serviceConfig, visibilityMgr, newCacheFn, leaseProvider, lifecycleObservers, eventsReapplyPolicy),
 		visibilityManager: visibilityMgr,
 		config:            serviceConfig,
 	}
@@ -109,6 +113,7 @@ func (s *Service) Stop() {
 	}
 
 	// initiate graceful shutdown :
+	// 0. publish owned shards, so that other members can prioritize acquisition of the busiest ones
 	// 1. remove self from the membership ring
 	// 2. wait for other members to discover we are going down
 	// 3. stop acquiring new shards (periodically or based on other membership changes)
@@ -125,6 +130,12 @@ func (s *Service) Stop() {
 
 	remainingTime := s.config.ShutdownDrainDuration()
 
+	if s.config.EnableShardOwnershipHints() {
+		logger.Info("ShutdownHandler: Publishing shard ownership hints")
+		s.handler.controller.PublishOwnershipHints()
+		remainingTime = s.sleep(gossipPropagationDelay, remainingTime)
+	}
+
 	logger.Info("ShutdownHandler: Evicting self from membership ring")
 	_ = s.GetMembershipMonitor().EvictSelf()
 
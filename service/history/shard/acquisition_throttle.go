@@ -0,0 +1,139 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"sync"
+	"time"
+
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/metrics"
+)
+
+type (
+	// acquisitionThrottle is shared by all shards of a host and bounds how many of them acquire their range and
+	// load their metadata at the same time, and how often a new acquisition starts. Without it a host starting up,
+	// or taking over the shards of a dead peer, hits the shard table with hundreds of acquisitions at once.
+	acquisitionThrottle struct {
+		maxConcurrency dynamicconfig.IntPropertyFn
+		pacing         dynamicconfig.DurationPropertyFn
+		metricsScope   metrics.Scope
+
+		sync.Mutex
+		inflight  int
+		waiting   int
+		nextStart time.Time
+		// releasedCh is closed and replaced whenever a slot is released, to wake up the waiters
+		releasedCh chan struct{}
+	}
+)
+
+func newAcquisitionThrottle(
+	maxConcurrency dynamicconfig.IntPropertyFn,
+	pacing dynamicconfig.DurationPropertyFn,
+	metricsScope metrics.Scope,
+) *acquisitionThrottle {
+	return &acquisitionThrottle{
+		maxConcurrency: maxConcurrency,
+		pacing:         pacing,
+		metricsScope:   metricsScope,
+		releasedCh:     make(chan struct{}),
+	}
+}
+
+// acquire blocks until a shard acquisition may start and returns the function releasing its slot. It returns
+// errStoppingContext if stopCh is closed while waiting. A nil throttle doesn't throttle.
+func (t *acquisitionThrottle) acquire(stopCh <-chan struct{}) (func(), error) {
+	if t == nil {
+		return func() {}, nil
+	}
+
+	for {
+		t.Lock()
+		maxConcurrency := t.maxConcurrency()
+		if maxConcurrency <= 0 || t.inflight < maxConcurrency {
+			t.inflight++
+			delay := t.reserveStartLocked()
+			t.Unlock()
+
+			if delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-stopCh:
+					timer.Stop()
+					t.release()
+					return nil, errStoppingContext
+				}
+			}
+			return t.release, nil
+		}
+
+		t.waiting++
+		t.metricsScope.UpdateGauge(metrics.ShardAcquisitionQueueDepthGauge, float64(t.waiting))
+		releasedCh := t.releasedCh
+		t.Unlock()
+
+		var stopped bool
+		select {
+		case <-releasedCh:
+		case <-stopCh:
+			stopped = true
+		}
+
+		t.Lock()
+		t.waiting--
+		t.metricsScope.UpdateGauge(metrics.ShardAcquisitionQueueDepthGauge, float64(t.waiting))
+		t.Unlock()
+		if stopped {
+			return nil, errStoppingContext
+		}
+	}
+}
+
+// reserveStartLocked reserves the next start time allowed by the pacing and returns how long the caller has to
+// wait for it
+func (t *acquisitionThrottle) reserveStartLocked() time.Duration {
+	pacing := t.pacing()
+	if pacing <= 0 {
+		return 0
+	}
+	now := time.Now()
+	start := t.nextStart
+	if start.Before(now) {
+		start = now
+	}
+	t.nextStart = start.Add(pacing)
+	return start.Sub(now)
+}
+
+func (t *acquisitionThrottle) release() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.inflight--
+	close(t.releasedCh)
+	t.releasedCh = make(chan struct{})
+}
@@ -0,0 +1,100 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/metrics"
+)
+
+func TestAcquisitionThrottle_MaxConcurrency(t *testing.T) {
+	throttle := newAcquisitionThrottle(
+		dynamicconfig.GetIntPropertyFn(1),
+		dynamicconfig.GetDurationPropertyFn(0),
+		metrics.NoopScope(metrics.History),
+	)
+
+	release, err := throttle.acquire(nil)
+	require.NoError(t, err)
+
+	acquiredCh := make(chan struct{})
+	go func() {
+		secondRelease, err := throttle.acquire(nil)
+		require.NoError(t, err)
+		secondRelease()
+		close(acquiredCh)
+	}()
+
+	select {
+	case <-acquiredCh:
+		t.Fatal("second acquisition started while the first one was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release()
+	select {
+	case <-acquiredCh:
+	case <-time.After(time.Second):
+		t.Fatal("second acquisition didn't start after the first one was released")
+	}
+}
+
+func TestAcquisitionThrottle_Stopped(t *testing.T) {
+	throttle := newAcquisitionThrottle(
+		dynamicconfig.GetIntPropertyFn(1),
+		dynamicconfig.GetDurationPropertyFn(0),
+		metrics.NoopScope(metrics.History),
+	)
+
+	release, err := throttle.acquire(nil)
+	require.NoError(t, err)
+	defer release()
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	_, err = throttle.acquire(stopCh)
+	require.Equal(t, errStoppingContext, err)
+}
+
+func TestAcquisitionThrottle_Pacing(t *testing.T) {
+	pacing := 20 * time.Millisecond
+	throttle := newAcquisitionThrottle(
+		dynamicconfig.GetIntPropertyFn(0),
+		dynamicconfig.GetDurationPropertyFn(pacing),
+		metrics.NoopScope(metrics.History),
+	)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := throttle.acquire(nil)
+		require.NoError(t, err)
+		release()
+	}
+	require.GreaterOrEqual(t, time.Since(start), 2*pacing)
+}
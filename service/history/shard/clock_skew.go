@@ -0,0 +1,84 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package shard
+
+import (
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+)
+
+var (
+	// ErrClockSkewExceeded is returned when timer tasks are written for a remote cluster whose clock is ahead of
+	// the local clock by more than the configured limit, and blocking timer allocation is enabled
+	ErrClockSkewExceeded = serviceerror.NewUnavailable("clock skew with remote cluster exceeds limit")
+)
+
+// recordClockDriftLocked records how far the time reported by a remote cluster is ahead of the local clock and
+// reports the drift if it exceeds the limit. Remote time normally lags behind because of replication delay, so
+// only a remote time ahead of the local clock is treated as skew. remoteClusterLock must be held for writing.
+func (s *ContextImpl) recordClockDriftLocked(cluster string, remoteTime time.Time) {
+	drift := remoteTime.Sub(s.GetTimeSource().Now())
+	s.getRemoteClusterInfoLocked(cluster).ClockDrift = drift
+
+	scope := s.GetMetricsClient().Scope(
+		metrics.ShardInfoScope,
+		metrics.TargetClusterTag(cluster),
+		metrics.InstanceTag(convert.Int32ToString(s.shardID)),
+	)
+	scope.UpdateGauge(metrics.ShardInfoClusterClockDriftGauge, float64(drift.Milliseconds()))
+
+	limit := s.config.ShardClockSkewLimit()
+	if limit > 0 && drift > limit {
+		scope.IncCounter(metrics.ShardClockSkewExceededCounter)
+		s.throttledLogger.Error("Remote cluster clock is ahead of local clock by more than the limit.",
+			tag.ClusterName(cluster),
+			tag.Timestamp(remoteTime),
+			tag.Value(drift),
+		)
+	}
+}
+
+// errorByClockSkew returns ErrClockSkewExceeded if timer allocation for the cluster is blocked because of its
+// clock skew
+func (s *ContextImpl) errorByClockSkew(cluster string) error {
+	if !s.config.ShardClockSkewBlockTimerAllocation() {
+		return nil
+	}
+	limit := s.config.ShardClockSkewLimit()
+	if limit <= 0 {
+		return nil
+	}
+
+	s.remoteClusterLock.RLock()
+	defer s.remoteClusterLock.RUnlock()
+	if info, ok := s.remoteClusterInfos[cluster]; ok && info.ClockDrift > limit {
+		return ErrClockSkewExceeded
+	}
+	return nil
+}
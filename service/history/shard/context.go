@@ -25,6 +25,7 @@
 package shard
 
 import (
+	"context"
 	"time"
 
 	commonpb "go.temporal.io/api/common/v1"
@@ -40,6 +41,7 @@ import (
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/service/history/configs"
 	"go.temporal.io/server/service/history/events"
+	"go.temporal.io/server/service/history/tasks"
 )
 
 //go:generate mockgen -copyright_file ../../../LICENSE -package $GOPACKAGE -source $GOFILE -destination context_mock.go
@@ -54,6 +56,7 @@ type (
 		GetClusterMetadata() cluster.Metadata
 		GetConfig() *configs.Config
 		GetEventsCache() events.Cache
+		GetDiagnostics() *Diagnostics
 		GetLogger() log.Logger
 		GetThrottledLogger() log.Logger
 		GetMetricsClient() metrics.Client
@@ -74,6 +77,11 @@ type (
 
 		GetRemoteClusterAckInfo(cluster []string) (map[string]*historyservice.ShardReplicationStatusPerCluster, error)
 
+		GetQueueAckLevel(category tasks.Category) tasks.Key
+		UpdateQueueAckLevel(category tasks.Category, ackLevel tasks.Key) error
+		GetNamespaceAckLevels(category tasks.Category) map[namespace.ID]tasks.Key
+		UpdateNamespaceAckLevels(category tasks.Category, ackLevels map[namespace.ID]tasks.Key) error
+
 		GetTransferAckLevel() int64
 		UpdateTransferAckLevel(ackLevel int64) error
 		GetTransferClusterAckLevel(cluster string) int64
@@ -109,13 +117,23 @@ type (
 		GetNamespaceNotificationVersion() int64
 		UpdateNamespaceNotificationVersion(namespaceNotificationVersion int64) error
 
-		CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error)
-		UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error)
-		ConflictResolveWorkflowExecution(request *persistence.ConflictResolveWorkflowExecutionRequest) (*persistence.ConflictResolveWorkflowExecutionResponse, error)
-		// Delete workflow execution, current workflow execution, and add task to delete visibility.
+		// AssertWritable returns ErrShardReadOnly if the shard is set to read only, for writes which bypass the
+		// shard context, e.g. range completion of tasks
+		AssertWritable() error
+
+		// GetWorkflowExecution reads the mutable state of a workflow, it fails with ErrShardStatusUnknown or
+		// ErrShardClosed instead of returning mutable state read by a shard which no longer owns the workflow.
+		GetWorkflowExecution(ctx context.Context, request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error)
+		CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error)
+		UpdateWorkflowExecution(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error)
+		ConflictResolveWorkflowExecution(ctx context.Context, request *persistence.ConflictResolveWorkflowExecutionRequest) (*persistence.ConflictResolveWorkflowExecutionResponse, error)
+		// Add task to delete visibility, then delete workflow execution and current workflow execution.
 		// If branchToken != nil, then delete history also, otherwise leave history.
-		DeleteWorkflowExecution(workflowKey definition.WorkflowKey, branchToken []byte, version int64) error
-		AddTasks(request *persistence.AddTasksRequest) error
-		AppendHistoryEvents(request *persistence.AppendHistoryNodesRequest, namespaceID namespace.ID, execution commonpb.WorkflowExecution) (int, error)
+		// ctx is only checked before the first write, once started the deletion isn't abandoned halfway.
+		DeleteWorkflowExecution(ctx context.Context, workflowKey definition.WorkflowKey, branchToken []byte, version int64) error
+		AddTasks(ctx context.Context, request *persistence.AddTasksRequest) error
+		// Appended events are fenced by the shard range ID on SQL stores, on Cassandra only the workflow
+		// write that references them is.
+		AppendHistoryEvents(ctx context.Context, request *persistence.AppendHistoryNodesRequest, namespaceID namespace.ID, execution commonpb.WorkflowExecution) (int, error)
 	}
 )
@@ -26,7 +26,11 @@ package shard
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	commonpb "go.temporal.io/api/common/v1"
@@ -53,8 +57,6 @@ import (
 
 var (
 	defaultTime = time.Unix(0, 0)
-
-	persistenceOperationRetryPolicy = common.CreatePersistenceRetryPolicy()
 )
 
 const (
@@ -64,6 +66,7 @@ const (
 	contextStateInitialized contextState = iota
 	contextStateAcquiring
 	contextStateAcquired
+	contextStateDraining
 	contextStateStopping
 	contextStateStopped
 
@@ -73,6 +76,7 @@ const (
 	contextRequestLost
 	contextRequestStop
 	contextRequestFinishStop
+	contextRequestDrain
 )
 
 type (
@@ -91,26 +95,93 @@ type (
 		logger           log.Logger
 		throttledLogger  log.Logger
 		engineFactory    EngineFactory
-
-		// All following fields are protected by rwLock, and only valid if state >= Acquiring:
+		rateLimiter      *persistenceRateLimiter
+		leaseProvider    LeaseProvider
+		// observers are the lifecycle observers shared by all shards of the controller
+		observers *lifecycleObservers
+		// acquireThrottle is shared by all shards of the controller and throttles concurrent acquisitions
+		acquireThrottle *acquisitionThrottle
+		// diagnostics attributes goroutines and outstanding persistence calls to the shard
+		diagnostics *Diagnostics
+		// acquireShardHook is called with the lock held instead of starting the acquireShard goroutine if set,
+		// only used by tests
+		acquireShardHook func()
+
+		// lastActivity is the unix nano time of the last API request or task ID allocation, accessed atomically
+		lastActivity int64
+		// assertOwnershipPending is 1 if the next write asserts the shard ownership, accessed atomically
+		assertOwnershipPending int32
+		// renewedRangeID is the last range ID persisted by renewRangeAsync, accessed atomically. It's ahead of
+		// the range ID of shardInfo until the renewed range is installed.
+		renewedRangeID int64
+
+		// flushLock serializes shardInfo flushes, it's acquired before rwLock
+		flushLock   sync.Mutex
+		flushCh     chan struct{}
+		flushStopCh chan struct{}
+
+		// All following fields are only valid if state >= Acquiring. Locks are acquired in the order rwLock,
+		// writeLock, readLevelLock, remoteClusterLock, and ackLock is never held together with the latter two.
+
+		// rwLock is held for writing while allocating task IDs and renewing the range:
 		rwLock                    sync.RWMutex
 		state                     contextState
 		engine                    Engine
+		lease                     Lease
 		lastUpdated               time.Time
-		shardInfo                 *persistence.ShardInfoWithFailover
+		shardInfoDirty            bool          // shardInfo changed since it was last flushed
+		rangeRenewing             bool          // the range is being renewed in the background
+		rangePersisting           chan struct{} // closed once the range renewed in the background is persisted
+		rangeRenewalWindowStart   time.Time
+		rangeRenewalsInWindow     int // range renewals since rangeRenewalWindowStart
 		transferSequenceNumber    int64
 		maxTransferSequenceNumber int64
-		transferMaxReadLevel      int64
-		timerMaxReadLevelMap      map[string]time.Time // cluster -> timerMaxReadLevel
-
-		// exist only in memory
+		// wLockOperation is the operation holding rwLock for writing since wLockTime
+		wLockOperation lockOperation
+		wLockTime      time.Time
+		lockScopes     map[lockOperation]metrics.Scope
+		// dirtyUpdates counts shardInfo updates since it was last flushed, and flushedTaskAckLevels is the sum of
+		// the task ID ack levels it last persisted, see shardUpdateIntervalLocked
+		dirtyUpdates         int
+		flushedTaskAckLevels int64
+
+		// The following fields are only written while holding both rwLock for writing and ackLock, so they
+		// can be read holding either one, and readers of ack levels don't wait for persistence writes:
+		ackLock   sync.RWMutex
+		shardInfo *persistencespb.ShardInfo
+
+		// writeLock is held for reading by execution writes while they are in flight outside of rwLock, and
+		// for writing while renewing the range or moving the timer read level, to wait for in-flight writes.
+		writeLock sync.RWMutex
+
+		// The following fields are only written while holding readLevelLock for writing, timerMaxReadLevelMap
+		// also holding rwLock. transferMaxReadLevel doesn't move past the first task ID of any write in flight:
+		readLevelLock         sync.RWMutex
+		transferMaxReadLevel  int64
+		timerMaxReadLevelMap  map[string]time.Time // cluster -> timerMaxReadLevel
+		inFlightWrites        map[int64]int        // first task ID -> number of writes in flight
+		writesAwaitingRange   int                  // writes waiting for the range renewed in the background
+		completedMaxReadLevel int64                // max task ID allocated by completed writes
+
+		// exist only in memory, protected by remoteClusterLock. The acked replication task IDs are restored from
+		// ClusterReplicationLevel of shardInfo when the shard is loaded.
+		remoteClusterLock  sync.RWMutex
 		remoteClusterInfos map[string]*remoteClusterInfo
 	}
 
+	// RangeRenewError is returned when the shard fails to renew its range ID. It matches ErrRangeStolen or
+	// ErrRangeRenewTimeout with errors.Is, and unwraps to the underlying persistence error.
+	RangeRenewError struct {
+		Kind  error
+		Cause error
+	}
+
 	remoteClusterInfo struct {
 		CurrentTime               time.Time
 		AckedReplicationTaskID    int64
 		AckedReplicationTimestamp time.Time
+		// ClockDrift is how far the last time reported by the cluster was ahead of the local clock
+		ClockDrift time.Duration
 	}
 )
 
@@ -124,6 +195,18 @@ var (
 	// during short windows at initialization and if we've lost the connection to the database.
 	ErrShardStatusUnknown = serviceerror.NewUnavailable("shard status unknown")
 
+	// ErrRangeStolen means the shard range ID was taken by another host while renewing it. The shard is
+	// closing, requests and tasks should move to the new owner instead of being retried here.
+	ErrRangeStolen = errors.New("shard range stolen")
+
+	// ErrRangeRenewTimeout means renewing the shard range ID timed out and its outcome is unknown. The shard
+	// is being re-acquired, so operations can be retried once it is acquired again.
+	ErrRangeRenewTimeout = errors.New("shard range renew timeout")
+
+	// ErrShardReadOnly is returned by the write methods of a shard which is set to read only in dynamic config,
+	// reads of the shard keep working. It's not retryable, the shard stays read only until the config changes.
+	ErrShardReadOnly = serviceerror.NewPermissionDenied("shard is read only", "")
+
 	// errStoppingContext is an internal error used to abort acquireShard
 	errStoppingContext = serviceerror.NewUnavailable("stopping context")
 )
@@ -132,6 +215,8 @@ const (
 	logWarnTransferLevelDiff = 3000000 // 3 million
 	logWarnTimerLevelDiff    = time.Duration(30 * time.Minute)
 	historySizeLogThreshold  = 10 * 1024 * 1024
+	shardDrainPollInterval   = 100 * time.Millisecond
+	shardLeaseAcquireTimeout = 10 * time.Second
 )
 
 func (s *ContextImpl) GetShardID() int32 {
@@ -150,7 +235,7 @@ func (s *ContextImpl) GetExecutionManager() persistence.ExecutionManager {
 }
 
 func (s *ContextImpl) GetEngine() (Engine, error) {
-	s.rLock()
+	s.rLock(lockOperationGetEngine)
 	defer s.rUnlock()
 
 	if err := s.errorByStateLocked(); err != nil {
@@ -161,14 +246,14 @@ func (s *ContextImpl) GetEngine() (Engine, error) {
 }
 
 func (s *ContextImpl) GenerateTransferTaskID() (int64, error) {
-	s.wLock()
+	s.wLock(lockOperationGenerateTaskIDs)
 	defer s.wUnlock()
 
 	return s.generateTransferTaskIDLocked()
 }
 
 func (s *ContextImpl) GenerateTransferTaskIDs(number int) ([]int64, error) {
-	s.wLock()
+	s.wLock(lockOperationGenerateTaskIDs)
 	defer s.wUnlock()
 
 	result := []int64{}
@@ -183,30 +268,140 @@ func (s *ContextImpl) GenerateTransferTaskIDs(number int) ([]int64, error) {
 }
 
 func (s *ContextImpl) GetTransferMaxReadLevel() int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.readLevelLock.RLock()
+	defer s.readLevelLock.RUnlock()
 	return s.transferMaxReadLevel
 }
 
+// GetQueueAckLevel returns ack level of the queue of given task category, immediate categories
+// are acked by task ID and scheduled categories are acked by fire time
+func (s *ContextImpl) GetQueueAckLevel(category tasks.Category) tasks.Key {
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
+
+	switch category.ID() {
+	case tasks.CategoryIDTransfer:
+		return tasks.Key{TaskID: s.shardInfo.TransferAckLevel}
+	case tasks.CategoryIDTimer:
+		return tasks.Key{FireTime: timestamp.TimeValue(s.shardInfo.TimerAckLevelTime)}
+	case tasks.CategoryIDReplication:
+		return tasks.Key{TaskID: s.shardInfo.ReplicationAckLevel}
+	case tasks.CategoryIDVisibility:
+		return tasks.Key{TaskID: s.shardInfo.VisibilityAckLevel}
+	case tasks.CategoryIDTieredStorage:
+		return tasks.Key{TaskID: s.shardInfo.TieredStorageAckLevel}
+	default:
+		ackLevel := s.shardInfo.QueueAckLevels[category.ID()]
+		if category.Type() == tasks.CategoryTypeScheduled {
+			return tasks.Key{FireTime: time.Unix(0, ackLevel).UTC()}
+		}
+		return tasks.Key{TaskID: ackLevel}
+	}
+}
+
+func (s *ContextImpl) UpdateQueueAckLevel(category tasks.Category, ackLevel tasks.Key) error {
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
+	defer s.wUnlock()
+
+	s.ackLock.Lock()
+	switch category.ID() {
+	case tasks.CategoryIDTransfer:
+		s.shardInfo.TransferAckLevel = ackLevel.TaskID
+	case tasks.CategoryIDTimer:
+		s.shardInfo.TimerAckLevelTime = &ackLevel.FireTime
+	case tasks.CategoryIDReplication:
+		s.shardInfo.ReplicationAckLevel = ackLevel.TaskID
+	case tasks.CategoryIDVisibility:
+		s.shardInfo.VisibilityAckLevel = ackLevel.TaskID
+	case tasks.CategoryIDTieredStorage:
+		s.shardInfo.TieredStorageAckLevel = ackLevel.TaskID
+	default:
+		if s.shardInfo.QueueAckLevels == nil {
+			s.shardInfo.QueueAckLevels = make(map[int32]int64)
+		}
+		if category.Type() == tasks.CategoryTypeScheduled {
+			s.shardInfo.QueueAckLevels[category.ID()] = ackLevel.FireTime.UnixNano()
+		} else {
+			s.shardInfo.QueueAckLevels[category.ID()] = ackLevel.TaskID
+		}
+	}
+	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
+	return s.updateShardInfoLocked()
+}
+
+// GetNamespaceAckLevels returns ack levels of the namespaces with outstanding tasks in the queue of given
+// category, namespaces which are not included have acked all tasks read by the queue
+func (s *ContextImpl) GetNamespaceAckLevels(category tasks.Category) map[namespace.ID]tasks.Key {
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
+
+	ackLevels := make(map[namespace.ID]tasks.Key)
+	for namespaceID, ackLevel := range s.shardInfo.NamespaceAckLevels[category.ID()].GetAckLevels() {
+		if category.Type() == tasks.CategoryTypeScheduled {
+			ackLevels[namespace.ID(namespaceID)] = tasks.Key{FireTime: time.Unix(0, ackLevel).UTC()}
+		} else {
+			ackLevels[namespace.ID(namespaceID)] = tasks.Key{TaskID: ackLevel}
+		}
+	}
+	return ackLevels
+}
+
+func (s *ContextImpl) UpdateNamespaceAckLevels(category tasks.Category, ackLevels map[namespace.ID]tasks.Key) error {
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
+	defer s.wUnlock()
+
+	namespaceAckLevels := make(map[string]int64, len(ackLevels))
+	for namespaceID, ackLevel := range ackLevels {
+		if category.Type() == tasks.CategoryTypeScheduled {
+			namespaceAckLevels[namespaceID.String()] = ackLevel.FireTime.UnixNano()
+		} else {
+			namespaceAckLevels[namespaceID.String()] = ackLevel.TaskID
+		}
+	}
+
+	s.ackLock.Lock()
+	if s.shardInfo.NamespaceAckLevels == nil {
+		s.shardInfo.NamespaceAckLevels = make(map[int32]*persistencespb.NamespaceAckLevels)
+	}
+	s.shardInfo.NamespaceAckLevels[category.ID()] = &persistencespb.NamespaceAckLevels{AckLevels: namespaceAckLevels}
+	s.ackLock.Unlock()
+	return s.updateShardInfoLocked()
+}
+
 func (s *ContextImpl) GetTransferAckLevel() int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	return s.shardInfo.TransferAckLevel
 }
 
 func (s *ContextImpl) UpdateTransferAckLevel(ackLevel int64) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.TransferAckLevel = ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetTransferClusterAckLevel(cluster string) int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	// if we can find corresponding ack level
 	if ackLevel, ok := s.shardInfo.ClusterTransferAckLevel[cluster]; ok {
@@ -218,64 +413,89 @@ func (s *ContextImpl) GetTransferClusterAckLevel(cluster string) int64 {
 }
 
 func (s *ContextImpl) UpdateTransferClusterAckLevel(cluster string, ackLevel int64) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.ClusterTransferAckLevel[cluster] = ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetVisibilityAckLevel() int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	return s.shardInfo.VisibilityAckLevel
 }
 
 func (s *ContextImpl) UpdateVisibilityAckLevel(ackLevel int64) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.VisibilityAckLevel = ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetTieredStorageAckLevel() int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	return s.shardInfo.TieredStorageAckLevel
 }
 
 func (s *ContextImpl) UpdateTieredStorageAckLevel(ackLevel int64) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.TieredStorageAckLevel = ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetReplicatorAckLevel() int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	return s.shardInfo.ReplicationAckLevel
 }
 
 func (s *ContextImpl) UpdateReplicatorAckLevel(ackLevel int64) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
+
+	s.ackLock.Lock()
 	s.shardInfo.ReplicationAckLevel = ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetReplicatorDLQAckLevel(sourceCluster string) int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	if ackLevel, ok := s.shardInfo.ReplicationDlqAckLevel[sourceCluster]; ok {
 		return ackLevel
@@ -288,11 +508,17 @@ func (s *ContextImpl) UpdateReplicatorDLQAckLevel(
 	ackLevel int64,
 ) error {
 
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.ReplicationDlqAckLevel[sourceCluster] = ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	if err := s.updateShardInfoLocked(); err != nil {
 		return err
 	}
@@ -309,8 +535,8 @@ func (s *ContextImpl) UpdateReplicatorDLQAckLevel(
 }
 
 func (s *ContextImpl) GetClusterReplicationLevel(cluster string) int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	// if we can find corresponding replication level
 	if replicationLevel, ok := s.shardInfo.ClusterReplicationLevel[cluster]; ok {
@@ -322,35 +548,50 @@ func (s *ContextImpl) GetClusterReplicationLevel(cluster string) int64 {
 }
 
 func (s *ContextImpl) UpdateClusterReplicationLevel(cluster string, ackTaskID int64, ackTimestamp time.Time) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.ClusterReplicationLevel[cluster] = ackTaskID
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
+
+	s.remoteClusterLock.Lock()
 	s.getRemoteClusterInfoLocked(cluster).AckedReplicationTaskID = ackTaskID
 	s.getRemoteClusterInfoLocked(cluster).AckedReplicationTimestamp = ackTimestamp
+	s.remoteClusterLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetTimerAckLevel() time.Time {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	return timestamp.TimeValue(s.shardInfo.TimerAckLevelTime)
 }
 
 func (s *ContextImpl) UpdateTimerAckLevel(ackLevel time.Time) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.TimerAckLevelTime = &ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetTimerClusterAckLevel(cluster string) time.Time {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	// if we can find corresponding ack level
 	if ackLevel, ok := s.shardInfo.ClusterTimerAckLevel[cluster]; ok {
@@ -362,115 +603,203 @@ func (s *ContextImpl) GetTimerClusterAckLevel(cluster string) time.Time {
 }
 
 func (s *ContextImpl) UpdateTimerClusterAckLevel(cluster string, ackLevel time.Time) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateAckLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.ClusterTimerAckLevel[cluster] = &ackLevel
 	s.shardInfo.StolenSinceRenew = 0
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateFailoverLevel)
 	defer s.wUnlock()
 
-	s.shardInfo.TransferFailoverLevels[failoverID] = level
+	s.ackLock.Lock()
+	s.shardInfo.TransferFailoverLevels[failoverID] = transferFailoverLevelToProto(level)
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) DeleteTransferFailoverLevel(failoverID string) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateFailoverLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	if level, ok := s.shardInfo.TransferFailoverLevels[failoverID]; ok {
-		s.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTransferFailoverLatencyTimer, time.Since(level.StartTime))
+		s.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTransferFailoverLatencyTimer, time.Since(timestamp.TimeValue(level.StartTime)))
 		delete(s.shardInfo.TransferFailoverLevels, failoverID)
 	}
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetAllTransferFailoverLevels() map[string]persistence.TransferFailoverLevel {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	ret := map[string]persistence.TransferFailoverLevel{}
 	for k, v := range s.shardInfo.TransferFailoverLevels {
-		ret[k] = v
+		ret[k] = transferFailoverLevelFromProto(v)
 	}
 	return ret
 }
 
 func (s *ContextImpl) UpdateTimerFailoverLevel(failoverID string, level persistence.TimerFailoverLevel) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateFailoverLevel)
 	defer s.wUnlock()
 
-	s.shardInfo.TimerFailoverLevels[failoverID] = level
+	s.ackLock.Lock()
+	s.shardInfo.TimerFailoverLevels[failoverID] = timerFailoverLevelToProto(level)
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) DeleteTimerFailoverLevel(failoverID string) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateFailoverLevel)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	if level, ok := s.shardInfo.TimerFailoverLevels[failoverID]; ok {
-		s.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTimerFailoverLatencyTimer, time.Since(level.StartTime))
+		s.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTimerFailoverLatencyTimer, time.Since(timestamp.TimeValue(level.StartTime)))
 		delete(s.shardInfo.TimerFailoverLevels, failoverID)
 	}
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetAllTimerFailoverLevels() map[string]persistence.TimerFailoverLevel {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	ret := map[string]persistence.TimerFailoverLevel{}
 	for k, v := range s.shardInfo.TimerFailoverLevels {
-		ret[k] = v
+		ret[k] = timerFailoverLevelFromProto(v)
 	}
 	return ret
 }
 
 func (s *ContextImpl) GetNamespaceNotificationVersion() int64 {
-	s.rLock()
-	defer s.rUnlock()
+	s.ackLock.RLock()
+	defer s.ackLock.RUnlock()
 
 	return s.shardInfo.NamespaceNotificationVersion
 }
 
 func (s *ContextImpl) UpdateNamespaceNotificationVersion(namespaceNotificationVersion int64) error {
-	s.wLock()
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
+
+	s.wLock(lockOperationUpdateNamespaceVersion)
 	defer s.wUnlock()
 
+	s.ackLock.Lock()
 	s.shardInfo.NamespaceNotificationVersion = namespaceNotificationVersion
+	s.ackLock.Unlock()
 	return s.updateShardInfoLocked()
 }
 
 func (s *ContextImpl) GetTimerMaxReadLevel(cluster string) time.Time {
-	s.rLock()
-	defer s.rUnlock()
+	s.readLevelLock.RLock()
+	defer s.readLevelLock.RUnlock()
 
 	return s.timerMaxReadLevelMap[cluster]
 }
 
 func (s *ContextImpl) UpdateTimerMaxReadLevel(cluster string) time.Time {
-	s.wLock()
-	defer s.wUnlock()
+	// timer tasks are allocated holding rwLock for writing and persisted holding writeLock for reading,
+	// so the read level doesn't move past timers which are still being persisted
+	s.rLock(lockOperationUpdateTimerMaxReadLevel)
+	defer s.rUnlock()
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	s.readLevelLock.Lock()
+	defer s.readLevelLock.Unlock()
+
+	if s.writesAwaitingRange > 0 {
+		// writes waiting for the renewed range don't hold writeLock yet, their timers may still be persisted
+		return s.timerMaxReadLevelMap[cluster]
+	}
 
 	currentTime := s.GetTimeSource().Now()
 	if cluster != "" && cluster != s.GetClusterMetadata().GetCurrentClusterName() {
-		currentTime = s.getRemoteClusterInfoLocked(cluster).CurrentTime
+		currentTime = s.GetCurrentTime(cluster)
 	}
 
 	s.timerMaxReadLevelMap[cluster] = currentTime.Add(s.config.TimerProcessorMaxTimeShift()).Truncate(time.Millisecond)
 	return s.timerMaxReadLevelMap[cluster]
 }
 
+func (s *ContextImpl) GetWorkflowExecution(
+	ctx context.Context,
+	request *persistence.GetWorkflowExecutionRequest,
+) (*persistence.GetWorkflowExecutionResponse, error) {
+	rangeID, err := s.getRangeIDIfAcquired()
+	if err != nil {
+		return nil, err
+	}
+
+	done := s.diagnostics.startPersistenceCall()
+	resp, err := s.executionManager.GetWorkflowExecution(request)
+	done()
+	if err != nil {
+		return nil, err
+	}
+
+	// the shard may have been stolen or lost while the read was in flight
+	currentRangeID, err := s.getRangeIDIfAcquired()
+	if err != nil {
+		return nil, err
+	}
+	if currentRangeID != rangeID && s.config.ShardReadValidateRangeID() {
+		return nil, ErrShardStatusUnknown
+	}
+	return resp, nil
+}
+
+func (s *ContextImpl) getRangeIDIfAcquired() (int64, error) {
+	s.rLock(lockOperationGetWorkflow)
+	defer s.rUnlock()
+	if err := s.errorByStateLocked(); err != nil {
+		return 0, err
+	}
+	return s.getRangeIDLocked(), nil
+}
+
 func (s *ContextImpl) CreateWorkflowExecution(
+	ctx context.Context,
 	request *persistence.CreateWorkflowExecutionRequest,
 ) (*persistence.CreateWorkflowExecutionResponse, error) {
 	if err := s.errorByState(); err != nil {
 		return nil, err
 	}
+	if err := s.errorByReadOnly(); err != nil {
+		return nil, err
+	}
 
 	namespaceID := namespace.ID(request.NewWorkflowSnapshot.ExecutionInfo.NamespaceId)
 	workflowID := request.NewWorkflowSnapshot.ExecutionInfo.WorkflowId
@@ -480,39 +809,49 @@ func (s *ContextImpl) CreateWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
-
-	s.wLock()
-	defer s.wUnlock()
-
-	transferMaxReadLevel := int64(0)
-	if err := s.allocateTaskIDsLocked(
-		namespaceEntry,
-		workflowID,
-		request.NewWorkflowSnapshot.TransferTasks,
-		request.NewWorkflowSnapshot.ReplicationTasks,
-		request.NewWorkflowSnapshot.TimerTasks,
-		request.NewWorkflowSnapshot.VisibilityTasks,
-		&transferMaxReadLevel,
-	); err != nil {
+	if err := s.rateLimiter.allow(ctx, namespaceEntry.Name()); err != nil {
 		return nil, err
 	}
-	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
 
-	currentRangeID := s.getRangeIDLocked()
-	request.RangeID = currentRangeID
-	resp, err := s.executionManager.CreateWorkflowExecution(request)
-	if err = s.handleErrorLocked(err); err != nil {
+	var resp *persistence.CreateWorkflowExecutionResponse
+	err = s.pipelineWrite(
+		ctx,
+		lockOperationCreateWorkflow,
+		func(transferMaxReadLevel *int64) error {
+			return s.allocateTaskIDsLocked(
+				namespaceEntry,
+				workflowID,
+				request.NewWorkflowSnapshot.TransferTasks,
+				request.NewWorkflowSnapshot.ReplicationTasks,
+				request.NewWorkflowSnapshot.TimerTasks,
+				request.NewWorkflowSnapshot.VisibilityTasks,
+				transferMaxReadLevel,
+			)
+		},
+		func(rangeID int64) error {
+			request.RangeID = rangeID
+			defer s.diagnostics.startPersistenceCall()()
+			var err error
+			resp, err = s.executionManager.CreateWorkflowExecution(request)
+			return err
+		},
+	)
+	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
 func (s *ContextImpl) UpdateWorkflowExecution(
+	ctx context.Context,
 	request *persistence.UpdateWorkflowExecutionRequest,
 ) (*persistence.UpdateWorkflowExecutionResponse, error) {
 	if err := s.errorByState(); err != nil {
 		return nil, err
 	}
+	if err := s.errorByReadOnly(); err != nil {
+		return nil, err
+	}
 
 	namespaceID := namespace.ID(request.UpdateWorkflowMutation.ExecutionInfo.NamespaceId)
 	workflowID := request.UpdateWorkflowMutation.ExecutionInfo.WorkflowId
@@ -522,52 +861,63 @@ func (s *ContextImpl) UpdateWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
-
-	s.wLock()
-	defer s.wUnlock()
-
-	transferMaxReadLevel := int64(0)
-	if err := s.allocateTaskIDsLocked(
-		namespaceEntry,
-		workflowID,
-		request.UpdateWorkflowMutation.TransferTasks,
-		request.UpdateWorkflowMutation.ReplicationTasks,
-		request.UpdateWorkflowMutation.TimerTasks,
-		request.UpdateWorkflowMutation.VisibilityTasks,
-		&transferMaxReadLevel,
-	); err != nil {
+	if err := s.rateLimiter.allow(ctx, namespaceEntry.Name()); err != nil {
 		return nil, err
 	}
-	if request.NewWorkflowSnapshot != nil {
-		if err := s.allocateTaskIDsLocked(
-			namespaceEntry,
-			workflowID,
-			request.NewWorkflowSnapshot.TransferTasks,
-			request.NewWorkflowSnapshot.ReplicationTasks,
-			request.NewWorkflowSnapshot.TimerTasks,
-			request.NewWorkflowSnapshot.VisibilityTasks,
-			&transferMaxReadLevel,
-		); err != nil {
-			return nil, err
-		}
-	}
-	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
 
-	currentRangeID := s.getRangeIDLocked()
-	request.RangeID = currentRangeID
-	resp, err := s.executionManager.UpdateWorkflowExecution(request)
-	if err = s.handleErrorLocked(err); err != nil {
+	var resp *persistence.UpdateWorkflowExecutionResponse
+	err = s.pipelineWrite(
+		ctx,
+		lockOperationUpdateWorkflow,
+		func(transferMaxReadLevel *int64) error {
+			if err := s.allocateTaskIDsLocked(
+				namespaceEntry,
+				workflowID,
+				request.UpdateWorkflowMutation.TransferTasks,
+				request.UpdateWorkflowMutation.ReplicationTasks,
+				request.UpdateWorkflowMutation.TimerTasks,
+				request.UpdateWorkflowMutation.VisibilityTasks,
+				transferMaxReadLevel,
+			); err != nil {
+				return err
+			}
+			if request.NewWorkflowSnapshot != nil {
+				return s.allocateTaskIDsLocked(
+					namespaceEntry,
+					workflowID,
+					request.NewWorkflowSnapshot.TransferTasks,
+					request.NewWorkflowSnapshot.ReplicationTasks,
+					request.NewWorkflowSnapshot.TimerTasks,
+					request.NewWorkflowSnapshot.VisibilityTasks,
+					transferMaxReadLevel,
+				)
+			}
+			return nil
+		},
+		func(rangeID int64) error {
+			request.RangeID = rangeID
+			defer s.diagnostics.startPersistenceCall()()
+			var err error
+			resp, err = s.executionManager.UpdateWorkflowExecution(request)
+			return err
+		},
+	)
+	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
 func (s *ContextImpl) ConflictResolveWorkflowExecution(
+	ctx context.Context,
 	request *persistence.ConflictResolveWorkflowExecutionRequest,
 ) (*persistence.ConflictResolveWorkflowExecutionResponse, error) {
 	if err := s.errorByState(); err != nil {
 		return nil, err
 	}
+	if err := s.errorByReadOnly(); err != nil {
+		return nil, err
+	}
 
 	namespaceID := namespace.ID(request.ResetWorkflowSnapshot.ExecutionInfo.NamespaceId)
 	workflowID := request.ResetWorkflowSnapshot.ExecutionInfo.WorkflowId
@@ -578,64 +928,72 @@ func (s *ContextImpl) ConflictResolveWorkflowExecution(
 		return nil, err
 	}
 
-	s.wLock()
-	defer s.wUnlock()
-
-	transferMaxReadLevel := int64(0)
-	if request.CurrentWorkflowMutation != nil {
-		if err := s.allocateTaskIDsLocked(
-			namespaceEntry,
-			workflowID,
-			request.CurrentWorkflowMutation.TransferTasks,
-			request.CurrentWorkflowMutation.ReplicationTasks,
-			request.CurrentWorkflowMutation.TimerTasks,
-			request.CurrentWorkflowMutation.VisibilityTasks,
-			&transferMaxReadLevel,
-		); err != nil {
-			return nil, err
-		}
-	}
-	if err := s.allocateTaskIDsLocked(
-		namespaceEntry,
-		workflowID,
-		request.ResetWorkflowSnapshot.TransferTasks,
-		request.ResetWorkflowSnapshot.ReplicationTasks,
-		request.ResetWorkflowSnapshot.TimerTasks,
-		request.ResetWorkflowSnapshot.VisibilityTasks,
-		&transferMaxReadLevel,
-	); err != nil {
-		return nil, err
-	}
-	if request.NewWorkflowSnapshot != nil {
-		if err := s.allocateTaskIDsLocked(
-			namespaceEntry,
-			workflowID,
-			request.NewWorkflowSnapshot.TransferTasks,
-			request.NewWorkflowSnapshot.ReplicationTasks,
-			request.NewWorkflowSnapshot.TimerTasks,
-			request.NewWorkflowSnapshot.VisibilityTasks,
-			&transferMaxReadLevel,
-		); err != nil {
-			return nil, err
-		}
-	}
-	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
-
-	currentRangeID := s.getRangeIDLocked()
-	request.RangeID = currentRangeID
-	resp, err := s.executionManager.ConflictResolveWorkflowExecution(request)
-	if err := s.handleErrorLocked(err); err != nil {
+	var resp *persistence.ConflictResolveWorkflowExecutionResponse
+	err = s.pipelineWrite(
+		ctx,
+		lockOperationConflictResolve,
+		func(transferMaxReadLevel *int64) error {
+			if request.CurrentWorkflowMutation != nil {
+				if err := s.allocateTaskIDsLocked(
+					namespaceEntry,
+					workflowID,
+					request.CurrentWorkflowMutation.TransferTasks,
+					request.CurrentWorkflowMutation.ReplicationTasks,
+					request.CurrentWorkflowMutation.TimerTasks,
+					request.CurrentWorkflowMutation.VisibilityTasks,
+					transferMaxReadLevel,
+				); err != nil {
+					return err
+				}
+			}
+			if err := s.allocateTaskIDsLocked(
+				namespaceEntry,
+				workflowID,
+				request.ResetWorkflowSnapshot.TransferTasks,
+				request.ResetWorkflowSnapshot.ReplicationTasks,
+				request.ResetWorkflowSnapshot.TimerTasks,
+				request.ResetWorkflowSnapshot.VisibilityTasks,
+				transferMaxReadLevel,
+			); err != nil {
+				return err
+			}
+			if request.NewWorkflowSnapshot != nil {
+				return s.allocateTaskIDsLocked(
+					namespaceEntry,
+					workflowID,
+					request.NewWorkflowSnapshot.TransferTasks,
+					request.NewWorkflowSnapshot.ReplicationTasks,
+					request.NewWorkflowSnapshot.TimerTasks,
+					request.NewWorkflowSnapshot.VisibilityTasks,
+					transferMaxReadLevel,
+				)
+			}
+			return nil
+		},
+		func(rangeID int64) error {
+			request.RangeID = rangeID
+			defer s.diagnostics.startPersistenceCall()()
+			var err error
+			resp, err = s.executionManager.ConflictResolveWorkflowExecution(request)
+			return err
+		},
+	)
+	if err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
 func (s *ContextImpl) AddTasks(
+	ctx context.Context,
 	request *persistence.AddTasksRequest,
 ) error {
 	if err := s.errorByState(); err != nil {
 		return err
 	}
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
 
 	namespaceID := namespace.ID(request.NamespaceID)
 
@@ -644,33 +1002,67 @@ func (s *ContextImpl) AddTasks(
 	if err != nil {
 		return err
 	}
+	if err := s.rateLimiter.allow(ctx, namespaceEntry.Name()); err != nil {
+		return err
+	}
 
-	s.wLock()
-	defer s.wUnlock()
-
-	return s.addTasksLocked(request, namespaceEntry)
+	var engine Engine
+	if err := s.pipelineWrite(
+		ctx,
+		lockOperationAddTasks,
+		func(transferMaxReadLevel *int64) error {
+			engine = s.engine
+			return s.allocateAddTasksIDsLocked(request, namespaceEntry, transferMaxReadLevel)
+		},
+		func(rangeID int64) error {
+			request.RangeID = rangeID
+			defer s.diagnostics.startPersistenceCall()()
+			return s.executionManager.AddTasks(request)
+		},
+	); err != nil {
+		return err
+	}
+	engine.NotifyNewTransferTasks(request.TransferTasks)
+	engine.NotifyNewTimerTasks(request.TimerTasks)
+	engine.NotifyNewVisibilityTasks(request.VisibilityTasks)
+	engine.NotifyNewReplicationTasks(request.ReplicationTasks)
+	return nil
 }
 
-func (s *ContextImpl) addTasksLocked(
+func (s *ContextImpl) allocateAddTasksIDsLocked(
 	request *persistence.AddTasksRequest,
 	namespaceEntry *namespace.Namespace,
+	transferMaxReadLevel *int64,
 ) error {
-	transferMaxReadLevel := int64(0)
-	if err := s.allocateTaskIDsLocked(
+	return s.allocateTaskIDsLocked(
 		namespaceEntry,
 		request.WorkflowID,
 		request.TransferTasks,
 		request.ReplicationTasks,
 		request.TimerTasks,
 		request.VisibilityTasks,
-		&transferMaxReadLevel,
-	); err != nil {
+		transferMaxReadLevel,
+	)
+}
+
+func (s *ContextImpl) addTasksLocked(
+	request *persistence.AddTasksRequest,
+	namespaceEntry *namespace.Namespace,
+) error {
+	firstTaskID := s.transferSequenceNumber
+	transferMaxReadLevel := int64(0)
+	if err := s.allocateAddTasksIDsLocked(request, namespaceEntry, &transferMaxReadLevel); err != nil {
 		return err
 	}
-	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
 
-	request.RangeID = s.getRangeIDLocked()
+	s.writeLock.RLock()
+	s.beginWrite(firstTaskID)
+	request.RangeID = s.getFenceRangeID()
+	done := s.diagnostics.startPersistenceCall()
 	err := s.executionManager.AddTasks(request)
+	done()
+	s.endWrite(firstTaskID, transferMaxReadLevel)
+	s.writeLock.RUnlock()
 	if err = s.handleErrorLocked(err); err != nil {
 		return err
 	}
@@ -682,6 +1074,7 @@ func (s *ContextImpl) addTasksLocked(
 }
 
 func (s *ContextImpl) AppendHistoryEvents(
+	ctx context.Context,
 	request *persistence.AppendHistoryNodesRequest,
 	namespaceID namespace.ID,
 	execution commonpb.WorkflowExecution,
@@ -689,18 +1082,38 @@ func (s *ContextImpl) AppendHistoryEvents(
 	if err := s.errorByState(); err != nil {
 		return 0, err
 	}
+	if err := s.errorByReadOnly(); err != nil {
+		return 0, err
+	}
+	if err := s.maybeAssertOwnership(); err != nil {
+		return 0, err
+	}
 
 	request.ShardID = s.shardID
+	request.NamespaceID = namespaceID.String()
+	s.rLock(lockOperationAppendHistoryEvents)
+	request.RangeID = s.getRangeIDLocked()
+	s.rUnlock()
+
+	// namespace lookup failure only skips the namespace rate limit and metrics
+	entry, entryErr := s.GetNamespaceRegistry().GetNamespaceByID(namespaceID)
+	var namespaceName namespace.Name
+	if entryErr == nil && entry != nil {
+		namespaceName = entry.Name()
+	}
+	if err := s.rateLimiter.allow(ctx, namespaceName); err != nil {
+		return 0, err
+	}
 
 	size := 0
 	defer func() {
 		// N.B. - Dual emit here makes sense so that we can see aggregate timer stats across all
 		// namespaces along with the individual namespaces stats
 		s.GetMetricsClient().RecordDistribution(metrics.SessionSizeStatsScope, metrics.HistorySize, size)
-		if entry, err := s.GetNamespaceRegistry().GetNamespaceByID(namespaceID); err == nil && entry != nil {
+		if namespaceName != "" {
 			s.GetMetricsClient().Scope(
 				metrics.SessionSizeStatsScope,
-				metrics.NamespaceTag(entry.Name().String()),
+				metrics.NamespaceTag(namespaceName.String()),
 			).RecordDistribution(metrics.HistorySize, size)
 		}
 		if size >= historySizeLogThreshold {
@@ -711,14 +1124,26 @@ func (s *ContextImpl) AppendHistoryEvents(
 				tag.WorkflowHistorySizeBytes(size))
 		}
 	}()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	done := s.diagnostics.startPersistenceCall()
 	resp, err0 := s.GetExecutionManager().AppendHistoryNodes(request)
+	done()
 	if resp != nil {
 		size = resp.Size
 	}
+	if err0 != nil {
+		s.markOwnershipUnknown(err0)
+		s.wLock(lockOperationAppendHistoryEvents)
+		err0 = s.handleOwnershipLostLocked(err0)
+		s.wUnlock()
+	}
 	return size, err0
 }
 
 func (s *ContextImpl) DeleteWorkflowExecution(
+	ctx context.Context,
 	key definition.WorkflowKey,
 	branchToken []byte,
 	version int64,
@@ -726,6 +1151,9 @@ func (s *ContextImpl) DeleteWorkflowExecution(
 	if err := s.errorByState(); err != nil {
 		return err
 	}
+	if err := s.errorByReadOnly(); err != nil {
+		return err
+	}
 
 	// do not try to get namespace cache within shard lock
 	namespaceEntry, err := s.GetNamespaceRegistry().GetNamespaceByID(namespace.ID(key.NamespaceID))
@@ -733,19 +1161,44 @@ func (s *ContextImpl) DeleteWorkflowExecution(
 		return err
 	}
 
-	s.wLock()
+	s.wLock(lockOperationDeleteWorkflow)
 	defer s.wUnlock()
 
-	delCurRequest := &persistence.DeleteCurrentWorkflowExecutionRequest{
+	// The caller may give up only before the first write. Once the visibility task is added, the deletion is
+	// completed regardless of ctx, and in this order, so that no step leaves the visibility record of an
+	// execution behind which doesn't exist anymore.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Delete visibility
+	addTasksRequest := &persistence.AddTasksRequest{
 		ShardID:     s.shardID,
 		NamespaceID: key.NamespaceID,
 		WorkflowID:  key.WorkflowID,
 		RunID:       key.RunID,
+
+		TransferTasks:    nil,
+		TimerTasks:       nil,
+		ReplicationTasks: nil,
+		VisibilityTasks: []tasks.Task{&tasks.DeleteExecutionVisibilityTask{
+			// TaskID is set by addTasksLocked
+			WorkflowKey:         key,
+			VisibilityTimestamp: s.GetTimeSource().Now(),
+			Version:             version,
+		}},
 	}
-	op := func() error {
-		return s.GetExecutionManager().DeleteCurrentWorkflowExecution(delCurRequest)
+	if retention := s.config.VisibilityRecordRetention(namespaceEntry.Name().String()); retention > 0 {
+		// keep the visibility record, which has the final state of the execution, until the timer fires
+		addTasksRequest.VisibilityTasks = nil
+		addTasksRequest.TimerTasks = []tasks.Task{&tasks.DeleteExecutionVisibilityTimerTask{
+			// TaskID is set by addTasksLocked
+			WorkflowKey:         key,
+			VisibilityTimestamp: s.GetTimeSource().Now().Add(retention),
+			Version:             version,
+		}}
 	}
-	err = backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
+	err = s.addTasksLocked(addTasksRequest, namespaceEntry)
 	if err != nil {
 		return err
 	}
@@ -756,12 +1209,34 @@ func (s *ContextImpl) DeleteWorkflowExecution(
 		WorkflowID:  key.WorkflowID,
 		RunID:       key.RunID,
 	}
-	op = func() error {
+	op := func() error {
+		s.writeLock.RLock()
+		defer s.writeLock.RUnlock()
+		delRequest.RangeID = s.getFenceRangeID()
+		defer s.diagnostics.startPersistenceCall()()
 		return s.GetExecutionManager().DeleteWorkflowExecution(delRequest)
 	}
-	err = backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
+	err = backoff.Retry(op, s.config.ShardPersistenceRetryPolicy(), common.IsPersistenceTransientError)
 	if err != nil {
-		return err
+		return s.handleOwnershipLostLocked(err)
+	}
+
+	delCurRequest := &persistence.DeleteCurrentWorkflowExecutionRequest{
+		ShardID:     s.shardID,
+		NamespaceID: key.NamespaceID,
+		WorkflowID:  key.WorkflowID,
+		RunID:       key.RunID,
+	}
+	op = func() error {
+		s.writeLock.RLock()
+		defer s.writeLock.RUnlock()
+		delCurRequest.RangeID = s.getFenceRangeID()
+		defer s.diagnostics.startPersistenceCall()()
+		return s.GetExecutionManager().DeleteCurrentWorkflowExecution(delCurRequest)
+	}
+	err = backoff.Retry(op, s.config.ShardPersistenceRetryPolicy(), common.IsPersistenceTransientError)
+	if err != nil {
+		return s.handleOwnershipLostLocked(err)
 	}
 
 	if branchToken != nil {
@@ -770,36 +1245,15 @@ func (s *ContextImpl) DeleteWorkflowExecution(
 			ShardID:     s.shardID,
 		}
 		op := func() error {
+			defer s.diagnostics.startPersistenceCall()()
 			return s.GetExecutionManager().DeleteHistoryBranch(delHistoryRequest)
 		}
-		err = backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
+		err = backoff.Retry(op, s.config.ShardPersistenceRetryPolicy(), common.IsPersistenceTransientError)
 		if err != nil {
 			return err
 		}
 	}
 
-	// Delete visibility
-	addTasksRequest := &persistence.AddTasksRequest{
-		ShardID:     s.shardID,
-		NamespaceID: key.NamespaceID,
-		WorkflowID:  key.WorkflowID,
-		RunID:       key.RunID,
-
-		TransferTasks:    nil,
-		TimerTasks:       nil,
-		ReplicationTasks: nil,
-		VisibilityTasks: []tasks.Task{&tasks.DeleteExecutionVisibilityTask{
-			// TaskID is set by addTasksLocked
-			WorkflowKey:         key,
-			VisibilityTimestamp: s.GetTimeSource().Now(),
-			Version:             version,
-		}},
-	}
-	err = s.addTasksLocked(addTasksRequest, namespaceEntry)
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -827,17 +1281,43 @@ func (s *ContextImpl) getRangeIDLocked() int64 {
 	return s.shardInfo.GetRangeId()
 }
 
+// getFenceRangeID returns the range ID execution writes are fenced by: the range renewed in the background
+// once it's persisted, even before it's installed. Writes read it holding writeLock for reading, so that they
+// don't race with renewRangeAsync persisting the next range.
+func (s *ContextImpl) getFenceRangeID() int64 {
+	s.ackLock.RLock()
+	rangeID := s.shardInfo.GetRangeId()
+	s.ackLock.RUnlock()
+	if renewedRangeID := atomic.LoadInt64(&s.renewedRangeID); renewedRangeID > rangeID {
+		return renewedRangeID
+	}
+	return rangeID
+}
+
 func (s *ContextImpl) errorByState() error {
-	s.rLock()
+	s.rLock(lockOperationErrorByState)
 	defer s.rUnlock()
 	return s.errorByStateLocked()
 }
 
+func (s *ContextImpl) AssertWritable() error {
+	return s.errorByReadOnly()
+}
+
+func (s *ContextImpl) errorByReadOnly() error {
+	if s.config.ShardReadOnly(s.shardID) {
+		return ErrShardReadOnly
+	}
+	return nil
+}
+
 func (s *ContextImpl) errorByStateLocked() error {
 	switch s.state {
 	case contextStateInitialized, contextStateAcquiring:
 		return ErrShardStatusUnknown
-	case contextStateAcquired:
+	case contextStateAcquired, contextStateDraining:
+		// writes of in-flight requests and queue processors are still allowed while draining,
+		// new requests are rejected by getOrCreateEngine
 		return nil
 	case contextStateStopping, contextStateStopped:
 		return ErrShardClosed
@@ -846,35 +1326,152 @@ func (s *ContextImpl) errorByStateLocked() error {
 	}
 }
 
-func (s *ContextImpl) generateTransferTaskIDLocked() (int64, error) {
-	if err := s.updateRangeIfNeededLocked(); err != nil {
-		return -1, err
+func (s *ContextImpl) generateTransferTaskIDLocked() (int64, error) {
+	if err := s.updateRangeIfNeededLocked(); err != nil {
+		return -1, err
+	}
+
+	taskID := s.transferSequenceNumber
+	s.transferSequenceNumber++
+	s.maybeRenewRangeAsyncLocked()
+	s.markActivity()
+
+	return taskID, nil
+}
+
+func (s *ContextImpl) updateRangeIfNeededLocked() error {
+	if s.transferSequenceNumber < s.maxTransferSequenceNumber {
+		return nil
+	}
+
+	if s.installRenewedRangeLocked() {
+		return nil
+	}
+	// the write waits for the range to be persisted, RangePreallocationThreshold didn't renew it in time
+	s.metricsClient.IncCounter(metrics.ShardInfoScope, metrics.ShardRangeExhaustedCounter)
+	return s.renewRangeLocked(false)
+}
+
+// maybeRenewRangeAsyncLocked renews the range in the background once RangePreallocationThreshold of its task IDs
+// are allocated, so that the range is rarely exhausted in the middle of a workflow transaction, where the caller
+// would wait for updateRangeIfNeededLocked to persist the new range.
+func (s *ContextImpl) maybeRenewRangeAsyncLocked() {
+	threshold := s.config.RangePreallocationThreshold()
+	if s.rangeRenewing || threshold <= 0 || threshold >= 1 {
+		return
+	}
+	rangeID := s.getRangeIDLocked()
+	rangeSize := int64(1) << s.config.RangeSizeBits
+	allocated := s.transferSequenceNumber - rangeID<<s.config.RangeSizeBits
+	if float64(allocated) < threshold*float64(rangeSize) {
+		return
+	}
+
+	s.rangeRenewing = true
+	s.diagnostics.Go(func() { s.renewRangeAsync(rangeID) })
+}
+
+// renewRangeAsync persists the next range without holding rwLock, so that task IDs are still allocated from
+// the current range meanwhile. Writes allocated in the meantime wait for the next range to be persisted and are
+// fenced by it, see pipelineWrite, and the next range is installed right after.
+func (s *ContextImpl) renewRangeAsync(rangeID int64) {
+	// shardInfo flushes are conditioned on the installed range ID
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	s.wLock(lockOperationRenewRange)
+	// the range was renewed by updateRangeIfNeededLocked in the meantime, or the shard is no longer serving or
+	// read only
+	if s.state != contextStateAcquired || s.getRangeIDLocked() != rangeID || s.config.ShardReadOnly(s.shardID) {
+		s.rangeRenewing = false
+		s.wUnlock()
+		return
 	}
+	updatedShardInfo := copyShardInfo(s.shardInfo)
+	updatedShardInfo.RangeId++
+	rangePersisting := make(chan struct{})
+	s.rangePersisting = rangePersisting
+	s.wUnlock()
 
-	taskID := s.transferSequenceNumber
-	s.transferSequenceNumber++
+	// writes in flight are fenced by the current range ID, wait for them to complete
+	s.writeLock.Lock()
+	done := s.diagnostics.startPersistenceCall()
+	err := s.GetShardManager().UpdateShard(&persistence.UpdateShardRequest{
+		ShardInfo:       updatedShardInfo,
+		PreviousRangeID: rangeID,
+	})
+	done()
+	if err == nil {
+		atomic.StoreInt64(&s.renewedRangeID, updatedShardInfo.GetRangeId())
+	}
+	s.writeLock.Unlock()
+	close(rangePersisting)
 
-	return taskID, nil
+	s.wLock(lockOperationRenewRange)
+	defer s.wUnlock()
+	s.rangeRenewing = false
+	s.rangePersisting = nil
+	if err != nil {
+		s.logger.Warn("Failed to renew range in the background", tag.Error(err))
+		_ = s.handleErrorLocked(err)
+		return
+	}
+	s.installRenewedRangeLocked()
 }
 
-func (s *ContextImpl) updateRangeIfNeededLocked() error {
-	if s.transferSequenceNumber < s.maxTransferSequenceNumber {
-		return nil
+// installRenewedRangeLocked moves the shard to the range persisted by renewRangeAsync, if it's not installed yet.
+// The task IDs of the next range follow the current range's, so the IDs left in the current range are still
+// allocated before the ones of the next range.
+func (s *ContextImpl) installRenewedRangeLocked() bool {
+	renewedRangeID := atomic.LoadInt64(&s.renewedRangeID)
+	if renewedRangeID <= s.getRangeIDLocked() {
+		return false
 	}
 
-	return s.renewRangeLocked(false)
+	s.logger.Info("Range updated for shardID",
+		tag.ShardRangeID(renewedRangeID),
+		tag.PreviousShardRangeID(s.getRangeIDLocked()),
+		tag.Number(s.transferSequenceNumber),
+		tag.NextNumber(s.maxTransferSequenceNumber),
+	)
+	s.maxTransferSequenceNumber = (renewedRangeID + 1) << s.config.RangeSizeBits
+	s.ackLock.Lock()
+	s.shardInfo.RangeId = renewedRangeID
+	s.ackLock.Unlock()
+
+	s.recordRangeRenewalLocked()
+	return true
 }
 
+// renewRangeLocked persists the next range. Stealing the range while acquiring the shard is allowed even if the
+// shard is read only, it's what fences the previous owner.
 func (s *ContextImpl) renewRangeLocked(isStealing bool) error {
+	if !isStealing {
+		if err := s.errorByReadOnly(); err != nil {
+			return err
+		}
+	}
+
+	// writes in flight are fenced by the current range ID, wait for them to complete
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	// the range renewed in the background was persisted while waiting for writeLock, a stolen range follows it
+	if s.installRenewedRangeLocked() && !isStealing {
+		return nil
+	}
+
 	updatedShardInfo := copyShardInfo(s.shardInfo)
 	updatedShardInfo.RangeId++
 	if isStealing {
 		updatedShardInfo.StolenSinceRenew++
 	}
 
+	done := s.diagnostics.startPersistenceCall()
 	err := s.GetShardManager().UpdateShard(&persistence.UpdateShardRequest{
-		ShardInfo:       updatedShardInfo.ShardInfo,
+		ShardInfo:       updatedShardInfo,
 		PreviousRangeID: s.shardInfo.GetRangeId()})
+	done()
 	if err != nil {
 		// Failure in updating shard to grab new RangeID
 		s.logger.Error("Persistent store operation failure",
@@ -883,7 +1480,7 @@ func (s *ContextImpl) renewRangeLocked(isStealing bool) error {
 			tag.ShardRangeID(updatedShardInfo.GetRangeId()),
 			tag.PreviousShardRangeID(s.shardInfo.GetRangeId()),
 		)
-		return s.handleErrorLocked(err)
+		return newRangeRenewError(s.handleErrorLocked(err))
 	}
 
 	// Range is successfully updated in cassandra now update shard context to reflect new range
@@ -896,42 +1493,289 @@ func (s *ContextImpl) renewRangeLocked(isStealing bool) error {
 
 	s.transferSequenceNumber = updatedShardInfo.GetRangeId() << s.config.RangeSizeBits
 	s.maxTransferSequenceNumber = (updatedShardInfo.GetRangeId() + 1) << s.config.RangeSizeBits
-	s.transferMaxReadLevel = s.transferSequenceNumber - 1
+
+	// writes waiting for the range renewed in the background still make the task IDs they allocated from the
+	// previous range
+	s.readLevelLock.Lock()
+	s.completedMaxReadLevel = s.transferSequenceNumber - 1
+	s.transferMaxReadLevel = s.inFlightReadLevelLocked(s.completedMaxReadLevel)
+	s.readLevelLock.Unlock()
+
+	s.ackLock.Lock()
 	s.shardInfo = updatedShardInfo
+	s.ackLock.Unlock()
+	s.flushedTaskAckLevels = s.taskAckLevelsLocked()
 
+	if !isStealing {
+		s.recordRangeRenewalLocked()
+	}
 	return nil
 }
 
-func (s *ContextImpl) updateMaxReadLevelLocked(rl int64) {
+// recordRangeRenewalLocked reports a shard which renews its range more than RangeRenewalAlertRate times a minute,
+// once per minute.
+func (s *ContextImpl) recordRangeRenewalLocked() {
+	limit := s.config.RangeRenewalAlertRate()
+	if limit <= 0 {
+		return
+	}
+	now := s.GetTimeSource().Now()
+	if now.Sub(s.rangeRenewalWindowStart) >= time.Minute {
+		s.rangeRenewalWindowStart = now
+		s.rangeRenewalsInWindow = 0
+	}
+	s.rangeRenewalsInWindow++
+	if s.rangeRenewalsInWindow != limit+1 {
+		return
+	}
+	s.metricsClient.IncCounter(metrics.ShardInfoScope, metrics.ShardRangeRenewalRateExceededCounter)
+	s.logger.Warn("Shard renews its range too often, consider increasing RangeSizeBits",
+		tag.Counter(s.rangeRenewalsInWindow),
+		tag.ShardRangeID(s.shardInfo.GetRangeId()),
+	)
+}
+
+// pipelineWrite allocates task IDs of an execution write with allocate while holding rwLock, and then makes the
+// write outside of rwLock, so that writes to unrelated executions of the shard are made concurrently. The range
+// ID passed to write is fenced by the store, and the range isn't renewed while the write is in flight.
+func (s *ContextImpl) pipelineWrite(
+	ctx context.Context,
+	op lockOperation,
+	allocate func(transferMaxReadLevel *int64) error,
+	write func(rangeID int64) error,
+) error {
+	if err := s.maybeAssertOwnership(); err != nil {
+		return err
+	}
+
+	s.wLock(op)
+	// the caller may have given up while waiting for the shard lock
+	if err := ctx.Err(); err != nil {
+		s.wUnlock()
+		return err
+	}
+
+	firstTaskID := s.transferSequenceNumber
+	transferMaxReadLevel := int64(0)
+	if err := allocate(&transferMaxReadLevel); err != nil {
+		s.wUnlock()
+		return err
+	}
+	s.beginWrite(firstTaskID)
+	if rangePersisting := s.rangePersisting; rangePersisting != nil {
+		// the write can't be fenced by the current range ID, which is being renewed, wait for the next range
+		// without holding rwLock
+		rangeID := s.getRangeIDLocked()
+		s.awaitRange(1)
+		s.wUnlock()
+		<-rangePersisting
+		s.writeLock.RLock()
+		s.awaitRange(-1)
+		// the next range may or may not have been persisted, e.g. if UpdateShard timed out, the write can be fenced
+		// by neither range
+		if atomic.LoadInt64(&s.renewedRangeID) <= rangeID {
+			s.endWrite(firstTaskID, transferMaxReadLevel)
+			s.writeLock.RUnlock()
+			return ErrShardStatusUnknown
+		}
+	} else {
+		s.writeLock.RLock()
+		s.wUnlock()
+	}
+
+	err := write(s.getFenceRangeID())
+	s.endWrite(firstTaskID, transferMaxReadLevel)
+	s.writeLock.RUnlock()
+	if err == nil {
+		return nil
+	}
+	s.markOwnershipUnknown(err)
+
+	s.wLock(op)
+	defer s.wUnlock()
+	return s.handleErrorLocked(err)
+}
+
+// awaitRange counts the writes waiting for the range renewed in the background, which hold back the timer
+// read level until they hold writeLock.
+func (s *ContextImpl) awaitRange(delta int) {
+	s.readLevelLock.Lock()
+	defer s.readLevelLock.Unlock()
+	s.writesAwaitingRange += delta
+}
+
+// beginWrite registers a write in flight whose task IDs were allocated from firstTaskID on, which holds
+// transferMaxReadLevel below firstTaskID until the write completes.
+func (s *ContextImpl) beginWrite(firstTaskID int64) {
+	s.readLevelLock.Lock()
+	defer s.readLevelLock.Unlock()
+
+	if s.inFlightWrites == nil {
+		s.inFlightWrites = make(map[int64]int)
+	}
+	s.inFlightWrites[firstTaskID]++
+}
+
+// endWrite unregisters a completed write, and moves transferMaxReadLevel up to maxReadLevel, the max task ID
+// allocated by the write, unless there are writes in flight with lower task IDs.
+func (s *ContextImpl) endWrite(firstTaskID int64, maxReadLevel int64) {
+	s.readLevelLock.Lock()
+	defer s.readLevelLock.Unlock()
+
+	if s.inFlightWrites[firstTaskID]--; s.inFlightWrites[firstTaskID] <= 0 {
+		delete(s.inFlightWrites, firstTaskID)
+	}
+	if maxReadLevel > s.completedMaxReadLevel {
+		s.completedMaxReadLevel = maxReadLevel
+	}
+
+	rl := s.inFlightReadLevelLocked(s.completedMaxReadLevel)
 	if rl > s.transferMaxReadLevel {
 		s.logger.Debug("Updating MaxTaskID", tag.MaxLevel(rl))
 		s.transferMaxReadLevel = rl
 	}
 }
 
+// inFlightReadLevelLocked returns readLevel held below the task IDs of the writes in flight.
+func (s *ContextImpl) inFlightReadLevelLocked(readLevel int64) int64 {
+	for inFlightTaskID := range s.inFlightWrites {
+		if inFlightTaskID-1 < readLevel {
+			readLevel = inFlightTaskID - 1
+		}
+	}
+	return readLevel
+}
+
+// updateShardInfoLocked marks shardInfo as dirty, and wakes up the background flusher if the shard update interval
+// has passed since the last flush. Updates in between are coalesced into the next flush.
 func (s *ContextImpl) updateShardInfoLocked() error {
 	if err := s.errorByStateLocked(); err != nil {
 		return err
 	}
 
-	var err error
+	s.shardInfoDirty = true
+	s.dirtyUpdates++
+	if s.lastUpdated.Add(s.shardUpdateIntervalLocked()).After(clock.NewRealTimeSource().Now()) {
+		return nil
+	}
+	select {
+	case s.flushCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// flushShardInfo persists shardInfo if it's dirty and the shard update interval has passed since the last flush,
+// or unconditionally if force is set. The persistence call is made without holding rwLock, so ack level updates
+// and task ID allocation don't wait for it.
+func (s *ContextImpl) flushShardInfo(force bool) error {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	s.wLock(lockOperationFlushShardInfo)
 	now := clock.NewRealTimeSource().Now()
-	if s.lastUpdated.Add(s.config.ShardUpdateMinInterval()).After(now) {
+	if !force && !s.shardInfoDirty {
+		s.wUnlock()
+		return nil
+	}
+	if !force && s.lastUpdated.Add(s.shardUpdateIntervalLocked()).After(now) {
+		s.metricsClient.RecordDistribution(metrics.ShardInfoScope, metrics.ShardInfoFlushSkippedProgressAtRisk, int(s.taskProgressAtRiskLocked()))
+		s.wUnlock()
 		return nil
 	}
+	if err := s.errorByStateLocked(); err != nil {
+		s.wUnlock()
+		return err
+	}
+	// shardInfo stays dirty and is flushed once the shard is writable again
+	if err := s.errorByReadOnly(); err != nil {
+		s.wUnlock()
+		return err
+	}
 	updatedShardInfo := copyShardInfo(s.shardInfo)
 	s.emitShardInfoMetricsLogsLocked()
+	s.metricsClient.RecordDistribution(metrics.ShardInfoScope, metrics.ShardInfoFlushDirtyUpdates, s.dirtyUpdates)
+	dirtyUpdates := s.dirtyUpdates
+	flushedTaskAckLevels := s.flushedTaskAckLevels
+	s.shardInfoDirty = false
+	s.dirtyUpdates = 0
+	s.flushedTaskAckLevels = s.taskAckLevelsLocked()
+	s.wUnlock()
 
-	err = s.GetShardManager().UpdateShard(&persistence.UpdateShardRequest{
-		ShardInfo:       updatedShardInfo.ShardInfo,
-		PreviousRangeID: s.shardInfo.GetRangeId(),
+	done := s.diagnostics.startPersistenceCall()
+	err := s.GetShardManager().UpdateShard(&persistence.UpdateShardRequest{
+		ShardInfo:       updatedShardInfo,
+		PreviousRangeID: updatedShardInfo.GetRangeId(),
 	})
-	if err != nil {
-		return s.handleErrorLocked(err)
+	done()
+
+	s.wLock(lockOperationFlushShardInfo)
+	defer s.wUnlock()
+	if err == nil {
+		// only a successful flush delays the next one by the shard update interval
+		s.lastUpdated = now
+		return nil
+	}
+	s.shardInfoDirty = true
+	s.dirtyUpdates += dirtyUpdates
+	if s.getRangeIDLocked() != updatedShardInfo.GetRangeId() {
+		// The range was renewed while flushing, which already persisted a newer copy of shardInfo.
+		return nil
 	}
+	s.flushedTaskAckLevels = flushedTaskAckLevels
+	return s.handleErrorLocked(err)
+}
 
-	s.lastUpdated = now
-	return nil
+// shardInfoFlushLoop flushes dirty shardInfo in the background until the shard is stopped, and heartbeats the
+// shard ownership if it's enabled.
+func (s *ContextImpl) shardInfoFlushLoop() {
+	timer := time.NewTimer(s.flushLoopInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.flushStopCh:
+			return
+		case <-s.flushCh:
+		case <-timer.C:
+			timer.Reset(s.flushLoopInterval())
+		}
+		if err := s.flushShardInfo(false); err != nil {
+			s.throttledLogger.Warn("Failed to flush shard info", tag.Error(err))
+		}
+		if err := s.heartbeatOwnership(); err != nil {
+			s.throttledLogger.Warn("Failed to heartbeat shard ownership", tag.Error(err))
+		}
+	}
+}
+
+// flushLoopInterval is the shorter of ShardUpdateMinInterval and ShardOwnershipHeartbeatInterval if it's enabled.
+func (s *ContextImpl) flushLoopInterval() time.Duration {
+	interval := s.config.ShardUpdateMinInterval()
+	if heartbeatInterval := s.config.ShardOwnershipHeartbeatInterval(); heartbeatInterval > 0 && heartbeatInterval < interval {
+		return heartbeatInterval
+	}
+	return interval
+}
+
+// heartbeatOwnership persists shardInfo if the shard is acquired and wasn't persisted within
+// ShardOwnershipHeartbeatInterval. The write is conditioned on the range ID like any shardInfo flush, so an idle
+// shard whose range was taken by another host finds out without waiting for an ack level change.
+func (s *ContextImpl) heartbeatOwnership() error {
+	interval := s.config.ShardOwnershipHeartbeatInterval()
+	if interval <= 0 || s.config.ShardReadOnly(s.shardID) {
+		return nil
+	}
+
+	s.rLock(lockOperationOwnershipHeartbeat)
+	due := s.state == contextStateAcquired && !s.lastUpdated.Add(interval).After(clock.NewRealTimeSource().Now())
+	s.rUnlock()
+	if !due {
+		return nil
+	}
+
+	s.metricsClient.IncCounter(metrics.ShardInfoScope, metrics.ShardOwnershipHeartbeatCounter)
+	return s.flushShardInfo(true)
 }
 
 func (s *ContextImpl) emitShardInfoMetricsLogsLocked() {
@@ -962,8 +1806,9 @@ func (s *ContextImpl) emitShardInfoMetricsLogsLocked() {
 	}
 	diffTimerLevel := maxTimerLevel.Sub(minTimerLevel)
 
-	replicationLag := s.transferMaxReadLevel - s.shardInfo.ReplicationAckLevel
-	transferLag := s.transferMaxReadLevel - s.shardInfo.TransferAckLevel
+	transferMaxReadLevel := s.GetTransferMaxReadLevel()
+	replicationLag := transferMaxReadLevel - s.shardInfo.ReplicationAckLevel
+	transferLag := transferMaxReadLevel - s.shardInfo.TransferAckLevel
 	timerLag := time.Since(timestamp.TimeValue(s.shardInfo.TimerAckLevelTime))
 
 	transferFailoverInProgress := len(s.shardInfo.TransferFailoverLevels)
@@ -990,6 +1835,26 @@ func (s *ContextImpl) emitShardInfoMetricsLogsLocked() {
 
 	s.GetMetricsClient().RecordDistribution(metrics.ShardInfoScope, metrics.ShardInfoTransferFailoverInProgressTimer, transferFailoverInProgress)
 	s.GetMetricsClient().RecordDistribution(metrics.ShardInfoScope, metrics.ShardInfoTimerFailoverInProgressTimer, timerFailoverInProgress)
+
+	// the lag of namespaces with outstanding tasks, so that the shard lag caused by a single namespace can be told apart
+	for namespaceID, ackLevel := range s.shardInfo.NamespaceAckLevels[tasks.CategoryIDTransfer].GetAckLevels() {
+		if scope, ok := s.namespaceMetricsScope(namespace.ID(namespaceID)); ok {
+			scope.RecordDistribution(metrics.ShardInfoNamespaceTransferLagTimer, int(transferMaxReadLevel-ackLevel))
+		}
+	}
+	for namespaceID, ackLevel := range s.shardInfo.NamespaceAckLevels[tasks.CategoryIDTimer].GetAckLevels() {
+		if scope, ok := s.namespaceMetricsScope(namespace.ID(namespaceID)); ok {
+			scope.RecordTimer(metrics.ShardInfoNamespaceTimerLagTimer, time.Since(time.Unix(0, ackLevel)))
+		}
+	}
+}
+
+func (s *ContextImpl) namespaceMetricsScope(namespaceID namespace.ID) (metrics.Scope, bool) {
+	namespaceName, err := s.GetNamespaceRegistry().GetNamespaceName(namespaceID)
+	if err != nil {
+		return nil, false
+	}
+	return s.GetMetricsClient().Scope(metrics.ShardInfoScope, metrics.NamespaceTag(namespaceName.String())), true
 }
 
 func (s *ContextImpl) allocateTaskIDsLocked(
@@ -1059,6 +1924,9 @@ func (s *ContextImpl) allocateTimerIDsLocked(
 			// or otherwise, failover + active processing logic may not pick up the task.
 			currentCluster = namespaceEntry.ActiveClusterName()
 		}
+		if err := s.errorByClockSkew(currentCluster); err != nil {
+			return err
+		}
 		readCursorTS := s.timerMaxReadLevelMap[currentCluster]
 		if ts.Before(readCursorTS) {
 			// This can happen if shard move and new host have a time SKU, or there is db write delay.
@@ -1085,9 +1953,10 @@ func (s *ContextImpl) allocateTimerIDsLocked(
 }
 
 func (s *ContextImpl) SetCurrentTime(cluster string, currentTime time.Time) {
-	s.wLock()
-	defer s.wUnlock()
+	s.remoteClusterLock.Lock()
+	defer s.remoteClusterLock.Unlock()
 	if cluster != s.GetClusterMetadata().GetCurrentClusterName() {
+		s.recordClockDriftLocked(cluster, currentTime)
 		prevTime := s.getRemoteClusterInfoLocked(cluster).CurrentTime
 		if prevTime.Before(currentTime) {
 			s.getRemoteClusterInfoLocked(cluster).CurrentTime = currentTime
@@ -1098,16 +1967,19 @@ func (s *ContextImpl) SetCurrentTime(cluster string, currentTime time.Time) {
 }
 
 func (s *ContextImpl) GetCurrentTime(cluster string) time.Time {
-	s.rLock()
-	defer s.rUnlock()
 	if cluster != s.GetClusterMetadata().GetCurrentClusterName() {
-		return s.getRemoteClusterInfoLocked(cluster).CurrentTime
+		s.remoteClusterLock.RLock()
+		defer s.remoteClusterLock.RUnlock()
+		if info, ok := s.remoteClusterInfos[cluster]; ok {
+			return info.CurrentTime
+		}
+		return time.Time{}
 	}
 	return s.GetTimeSource().Now().UTC()
 }
 
 func (s *ContextImpl) GetLastUpdatedTime() time.Time {
-	s.rLock()
+	s.rLock(lockOperationGetLastUpdatedTime)
 	defer s.rUnlock()
 	return s.lastUpdated
 }
@@ -1139,6 +2011,40 @@ func (s *ContextImpl) handleErrorLocked(err error) error {
 	}
 }
 
+// handleOwnershipLostLocked stops the shard on ShardOwnershipLostError and leaves other errors
+// to the caller, for writes that are retried instead of triggering shard re-acquisition.
+func (s *ContextImpl) handleOwnershipLostLocked(err error) error {
+	if _, ok := err.(*persistence.ShardOwnershipLostError); ok {
+		return s.handleErrorLocked(err)
+	}
+	return err
+}
+
+func newRangeRenewError(err error) error {
+	switch err.(type) {
+	case *persistence.ShardOwnershipLostError:
+		return &RangeRenewError{Kind: ErrRangeStolen, Cause: err}
+	case *persistence.TimeoutError:
+		return &RangeRenewError{Kind: ErrRangeRenewTimeout, Cause: err}
+	}
+	if common.IsContextDeadlineExceededErr(err) {
+		return &RangeRenewError{Kind: ErrRangeRenewTimeout, Cause: err}
+	}
+	return err
+}
+
+func (e *RangeRenewError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Kind, e.Cause)
+}
+
+func (e *RangeRenewError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *RangeRenewError) Unwrap() error {
+	return e.Cause
+}
+
 func (s *ContextImpl) maybeRecordShardAcquisitionLatency(ownershipChanged bool) {
 	if ownershipChanged {
 		s.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardContextAcquisitionLatency,
@@ -1163,8 +2069,11 @@ func (s *ContextImpl) getOrCreateEngine(ctx context.Context) (engine Engine, ret
 	isRetryable := func(err error) bool { return err == ErrShardStatusUnknown }
 
 	op := func(context.Context) error {
-		s.rLock()
+		s.rLock(lockOperationGetEngine)
 		defer s.rUnlock()
+		if s.state == contextStateDraining {
+			return ErrShardClosed
+		}
 		err := s.errorByStateLocked()
 		if err == nil {
 			engine = s.engine
@@ -1182,19 +2091,80 @@ func (s *ContextImpl) getOrCreateEngine(ctx context.Context) (engine Engine, ret
 
 // start should only be called by the controller.
 func (s *ContextImpl) start() {
-	s.wLock()
+	s.wLock(lockOperationLifecycle)
 	defer s.wUnlock()
+	s.diagnostics.Go(s.shardInfoFlushLoop)
 	s.transitionLocked(contextRequestAcquire)
 }
 
+// drain should only be called by the controller, before stop(). It stops handing out the engine to new
+// requests, waits up to timeout for the active queue processors to finish the tasks created and the timers
+// due before the drain started, and then persists their ack levels so that the next owner doesn't process
+// those tasks again. Standby tasks are left to the next owner.
+func (s *ContextImpl) drain(timeout time.Duration) {
+	s.wLock(lockOperationLifecycle)
+	if s.state != contextStateAcquired {
+		s.wUnlock()
+		return
+	}
+	s.transitionLocked(contextRequestDrain)
+	engine := s.engine
+	transferLevel := s.GetTransferMaxReadLevel()
+	s.wUnlock()
+
+	if engine != nil && timeout > 0 {
+		s.logger.Info("Draining shard", tag.Timeout(timeout.String()))
+		drainTime := s.GetTimeSource().Now()
+		deadline := time.NewTimer(timeout)
+		defer deadline.Stop()
+		ticker := time.NewTicker(shardDrainPollInterval)
+		defer ticker.Stop()
+
+		// the engine moves the in-memory ack levels of its queue processors to the shard info as it goes,
+		// so even a timed out drain checkpoints the progress made so far
+	DrainLoop:
+		for s.isDraining() && !engine.DrainQueues(transferLevel, drainTime) {
+			select {
+			case <-deadline.C:
+				s.logger.Warn("Timed out draining shard, outstanding tasks will be processed by the next owner")
+				break DrainLoop
+			case <-ticker.C:
+			}
+		}
+	}
+
+	if s.errorByState() != nil {
+		return
+	}
+	// bypass ShardUpdateMinInterval for the final checkpoint
+	if err := s.flushShardInfo(true); err != nil {
+		s.logger.Warn("Failed to checkpoint ack levels of draining shard", tag.Error(err))
+	}
+}
+
+func (s *ContextImpl) isDraining() bool {
+	s.rLock(lockOperationLifecycle)
+	defer s.rUnlock()
+	return s.state == contextStateDraining
+}
+
 // stop should only be called by the controller.
 func (s *ContextImpl) stop() {
-	s.wLock()
+	s.wLock(lockOperationLifecycle)
+	if s.state != contextStateStopped {
+		close(s.flushStopCh)
+	}
 	s.transitionLocked(contextRequestFinishStop)
 	engine := s.engine
 	s.engine = nil
+	lease := s.lease
+	s.lease = nil
 	s.wUnlock()
 
+	if lease != nil {
+		lease.Release()
+	}
+
 	// Stop the engine if it was running (outside the lock but before returning)
 	if engine != nil {
 		s.logger.Info("", tag.LifeCycleStopping, tag.ComponentShardEngine)
@@ -1204,31 +2174,38 @@ func (s *ContextImpl) stop() {
 }
 
 func (s *ContextImpl) isValid() bool {
-	s.rLock()
+	s.rLock(lockOperationLifecycle)
 	defer s.rUnlock()
 	return s.state < contextStateStopping
 }
 
-func (s *ContextImpl) wLock() {
-	scope := metrics.ShardInfoScope
-	s.metricsClient.IncCounter(scope, metrics.LockRequests)
-	sw := s.metricsClient.StartTimer(scope, metrics.LockLatency)
-	defer sw.Stop()
+func (s *ContextImpl) wLock(op lockOperation) {
+	scope := s.lockScope(op)
+	scope.IncCounter(metrics.LockRequests)
+	startTime := time.Now()
 
 	s.rwLock.Lock()
+
+	s.wLockOperation = op
+	s.wLockTime = time.Now()
+	s.recordLockWait(scope, op, s.wLockTime.Sub(startTime))
 }
 
-func (s *ContextImpl) rLock() {
-	scope := metrics.ShardInfoScope
-	s.metricsClient.IncCounter(scope, metrics.LockRequests)
-	sw := s.metricsClient.StartTimer(scope, metrics.LockLatency)
-	defer sw.Stop()
+func (s *ContextImpl) rLock(op lockOperation) {
+	scope := s.lockScope(op)
+	scope.IncCounter(metrics.LockRequests)
+	startTime := time.Now()
 
 	s.rwLock.RLock()
+
+	s.recordLockWait(scope, op, time.Since(startTime))
 }
 
 func (s *ContextImpl) wUnlock() {
+	op, hold := s.wLockOperation, time.Since(s.wLockTime)
 	s.rwLock.Unlock()
+
+	s.recordLockHold(op, hold)
 }
 
 func (s *ContextImpl) rUnlock() {
@@ -1259,6 +2236,14 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 			controller removes from map and calls stop()
 		Stopped
 
+	If the controller unloads the shard:
+		Acquired
+			controller removes from map and calls drain()
+		Draining
+			queue processors finish outstanding tasks or drain times out, ack levels are persisted
+			controller calls stop()
+		Stopped
+
 	Stopping can be triggered internally (if we get a ShardOwnershipLostError, or fail to acquire the rangeid
 	lock after several minutes) or externally (from controller, e.g. controller shutting down or admin force-
 	unload shard). If it's triggered internally, we transition to Stopping, then make an asynchronous callback
@@ -1270,7 +2255,11 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 	check the state each time it acquires the lock, and do nothing if the state has changed to Stopping (or
 	Stopped).
 
+	While Draining, the engine keeps running but isn't returned for new requests. Any persistence error
+	during the drain stops the shard, since it can't be re-acquired anyway.
+
 	Invariants:
+	- Only Acquired can go to Draining, and Draining can only go to Stopping or Stopped.
 	- Once state is Stopping, it can only go to Stopped.
 	- Once state is Stopped, it can't go anywhere else.
 	- At the start of acquireShard, state must be Acquiring.
@@ -1286,16 +2275,24 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 
 	setStateAcquiring := func() {
 		s.state = contextStateAcquiring
+		s.notifyLifecycleLocked(LifecycleStateAcquiring)
+		if s.acquireShardHook != nil {
+			s.acquireShardHook()
+			return
+		}
 		go s.acquireShard()
 	}
 
 	setStateStopping := func() {
 		s.state = contextStateStopping
+		s.notifyLifecycleLocked(LifecycleStateStopping)
 		// The change in state should cause all write methods to fail, but just in case, set this also,
 		// which will cause failures at the persistence level. (Note that if persistence is unavailable
 		// and we couldn't even load the shard metadata, shardInfo may still be nil here.)
 		if s.shardInfo != nil {
+			s.ackLock.Lock()
 			s.shardInfo.RangeId = -1
+			s.ackLock.Unlock()
 		}
 		// This will cause the controller to remove this shard from the map and then call s.stop()
 		go s.closeCallback(s)
@@ -1303,6 +2300,7 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 
 	setStateStopped := func() {
 		s.state = contextStateStopped
+		s.notifyLifecycleLocked(LifecycleStateStopped)
 	}
 
 	switch s.state {
@@ -1324,6 +2322,7 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 			return // nothing to do, already acquiring
 		case contextRequestAcquired:
 			s.state = contextStateAcquired
+			s.notifyLifecycleLocked(LifecycleStateAcquired)
 			return
 		case contextRequestLost:
 			return // nothing to do, already acquiring
@@ -1339,6 +2338,7 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 		case contextRequestAcquire:
 			return // nothing to to do, already acquired
 		case contextRequestLost:
+			s.notifyLifecycleLocked(LifecycleStateLost)
 			setStateAcquiring()
 			return
 		case contextRequestStop:
@@ -1347,6 +2347,21 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 		case contextRequestFinishStop:
 			setStateStopped()
 			return
+		case contextRequestDrain:
+			s.state = contextStateDraining
+			s.notifyLifecycleLocked(LifecycleStateDraining)
+			return
+		}
+	case contextStateDraining:
+		switch request {
+		case contextRequestDrain:
+			return // nothing to do, already draining
+		case contextRequestLost, contextRequestStop:
+			setStateStopping()
+			return
+		case contextRequestFinishStop:
+			setStateStopped()
+			return
 		}
 	case contextStateStopping:
 		switch request {
@@ -1366,7 +2381,7 @@ func (s *ContextImpl) transitionLocked(request contextRequest) {
 
 func (s *ContextImpl) loadShardMetadata(ownershipChanged *bool) error {
 	// Only have to do this once, we can just re-acquire the rangeid lock after that
-	s.rLock()
+	s.rLock(lockOperationAcquireShard)
 
 	if s.state >= contextStateStopping {
 		return errStoppingContext
@@ -1380,15 +2395,17 @@ func (s *ContextImpl) loadShardMetadata(ownershipChanged *bool) error {
 	s.rUnlock()
 
 	// We don't have any shardInfo yet, load it (outside of context rwlock)
+	done := s.diagnostics.startPersistenceCall()
 	resp, err := s.GetShardManager().GetOrCreateShard(&persistence.GetOrCreateShardRequest{
 		ShardID:         s.shardID,
 		CreateIfMissing: true,
 	})
+	done()
 	if err != nil {
 		s.logger.Error("Failed to load shard", tag.Error(err))
 		return err
 	}
-	shardInfo := &persistence.ShardInfoWithFailover{ShardInfo: resp.ShardInfo}
+	shardInfo := resp.ShardInfo
 
 	// shardInfo is a fresh value, so we don't really need to copy, but
 	// copyShardInfo also ensures that all maps are non-nil
@@ -1396,6 +2413,14 @@ func (s *ContextImpl) loadShardMetadata(ownershipChanged *bool) error {
 	*ownershipChanged = shardInfo.Owner != s.GetHostInfo().Identity()
 	updatedShardInfo.Owner = s.GetHostInfo().Identity()
 
+	var removedClusters []string
+	if s.config.ShardPruneRemovedClusters() {
+		removedClusters = pruneRemovedClusters(updatedShardInfo, s.knownClusters())
+		if len(removedClusters) > 0 {
+			s.logger.Info("Pruned ack levels of clusters removed from cluster metadata.", tag.Value(removedClusters))
+		}
+	}
+
 	// initialize the cluster current time to be the same as ack level
 	remoteClusterInfos := make(map[string]*remoteClusterInfo)
 	timerMaxReadLevelMap := make(map[string]time.Time)
@@ -1410,7 +2435,17 @@ func (s *ContextImpl) loadShardMetadata(ownershipChanged *bool) error {
 				currentReadTime = timestamp.TimeValue(currentTime)
 			}
 
-			remoteClusterInfos[clusterName] = &remoteClusterInfo{CurrentTime: currentReadTime}
+			// the replication level acked by the cluster is persisted, so that replication status survives shard
+			// moves. The visibility time of the acked task isn't, it's reported as unknown (zero, i.e. lagging)
+			// until the cluster acks again.
+			ackedReplicationTaskID := persistence.EmptyQueueMessageID
+			if replicationLevel, ok := updatedShardInfo.ClusterReplicationLevel[clusterName]; ok {
+				ackedReplicationTaskID = replicationLevel
+			}
+			remoteClusterInfos[clusterName] = &remoteClusterInfo{
+				CurrentTime:            currentReadTime,
+				AckedReplicationTaskID: ackedReplicationTaskID,
+			}
 			timerMaxReadLevelMap[clusterName] = currentReadTime
 		} else { // active cluster
 			timerMaxReadLevelMap[clusterName] = currentReadTime
@@ -1419,24 +2454,37 @@ func (s *ContextImpl) loadShardMetadata(ownershipChanged *bool) error {
 		timerMaxReadLevelMap[clusterName] = timerMaxReadLevelMap[clusterName].Truncate(time.Millisecond)
 	}
 
-	s.wLock()
+	s.wLock(lockOperationAcquireShard)
 	defer s.wUnlock()
 
 	if s.state >= contextStateStopping {
 		return errStoppingContext
 	}
 
+	s.ackLock.Lock()
 	s.shardInfo = updatedShardInfo
+	s.ackLock.Unlock()
+	s.flushedTaskAckLevels = s.taskAckLevelsLocked()
+	if len(removedClusters) > 0 {
+		// persisted by the next shardInfo flush
+		s.shardInfoDirty = true
+	}
+
+	s.remoteClusterLock.Lock()
 	s.remoteClusterInfos = remoteClusterInfos
+	s.remoteClusterLock.Unlock()
+
+	s.readLevelLock.Lock()
 	s.timerMaxReadLevelMap = timerMaxReadLevelMap
+	s.readLevelLock.Unlock()
 
 	return nil
 }
 
 func (s *ContextImpl) GetRemoteClusterAckInfo(cluster []string) (map[string]*historyservice.ShardReplicationStatusPerCluster, error) {
 	resp := make(map[string]*historyservice.ShardReplicationStatusPerCluster)
-	s.rLock()
-	defer s.rUnlock()
+	s.remoteClusterLock.RLock()
+	defer s.remoteClusterLock.RUnlock()
 	if len(cluster) == 0 {
 		// remote acked info for all known remote clusters
 		for k, v := range s.remoteClusterInfos {
@@ -1471,21 +2519,31 @@ func (s *ContextImpl) getRemoteClusterInfoLocked(clusterName string) *remoteClus
 }
 
 func (s *ContextImpl) acquireShard() {
-	// Retry for 5m, with interval up to 10s (default)
-	policy := backoff.NewExponentialRetryPolicy(50 * time.Millisecond)
-	policy.SetExpirationInterval(5 * time.Minute)
+	policy := s.config.ShardAcquisitionRetryPolicy()
 
 	// Remember this value across attempts
 	ownershipChanged := false
+	// set when the engine is created, which is when the caches are cold
+	var newEngine Engine
 
 	op := func() error {
+		release, err := s.acquireThrottle.acquire(s.flushStopCh)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		if err := s.acquireLease(); err != nil {
+			return err
+		}
+
 		// Initial load of shard metadata
-		err := s.loadShardMetadata(&ownershipChanged)
+		err = s.loadShardMetadata(&ownershipChanged)
 		if err != nil {
 			return err
 		}
 
-		s.wLock()
+		s.wLock(lockOperationAcquireShard)
 		defer s.wUnlock()
 
 		// Check that we should still be running
@@ -1516,18 +2574,24 @@ func (s *ContextImpl) acquireShard() {
 			s.wUnlock()
 			s.maybeRecordShardAcquisitionLatency(ownershipChanged)
 			engine := s.createEngine()
-			s.wLock()
+			s.wLock(lockOperationAcquireShard)
 			if s.state >= contextStateStopping {
 				engine.Stop()
 				return errStoppingContext
 			}
 			s.engine = engine
+			newEngine = engine
 		}
 		s.transitionLocked(contextRequestAcquired)
 		return nil
 	}
 
 	err := backoff.Retry(op, policy, common.IsPersistenceTransientError)
+	if err == nil && newEngine != nil {
+		// Executions are read through the shard, which only serves reads once it's acquired. So the caches
+		// are warmed up right after that, while the shard already serves its first requests.
+		s.warmUp(newEngine)
+	}
 	if err == errStoppingContext {
 		// State changed since this goroutine started, exit silently.
 		return
@@ -1537,7 +2601,7 @@ func (s *ContextImpl) acquireShard() {
 
 		// If there's been another state change since we started (e.g. to Stopping), then don't do anything
 		// here. But if not (i.e. timed out or error), initiate shutting down the shard.
-		s.wLock()
+		s.wLock(lockOperationAcquireShard)
 		defer s.wUnlock()
 		if s.state >= contextStateStopping {
 			return
@@ -1546,15 +2610,66 @@ func (s *ContextImpl) acquireShard() {
 	}
 }
 
+// acquireLease acquires the ownership lease of the shard from the lease provider before the range is
+// acquired. The lease is kept across acquisition attempts, and losing it closes the shard.
+func (s *ContextImpl) acquireLease() error {
+	s.rLock(lockOperationAcquireShard)
+	acquired := s.lease != nil
+	s.rUnlock()
+	if acquired {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shardLeaseAcquireTimeout)
+	defer cancel()
+	lease, err := s.leaseProvider.AcquireLease(ctx, s.shardID, s.GetHostInfo().Identity())
+	if err != nil {
+		s.logger.Warn("Failed to acquire shard lease", tag.Error(err))
+		return err
+	}
+
+	s.wLock(lockOperationAcquireShard)
+	defer s.wUnlock()
+	if s.state >= contextStateStopping {
+		lease.Release()
+		return errStoppingContext
+	}
+	s.lease = lease
+	if lost := lease.Lost(); lost != nil {
+		s.diagnostics.Go(func() { s.watchLease(lease, lost) })
+	}
+	return nil
+}
+
+func (s *ContextImpl) watchLease(lease Lease, lost <-chan struct{}) {
+	<-lost
+
+	s.wLock(lockOperationAcquireShard)
+	defer s.wUnlock()
+	// the lease is released when the shard stops
+	if s.lease != lease || s.state >= contextStateStopping {
+		return
+	}
+	s.logger.Warn("Shard lease lost, closing shard")
+	s.transitionLocked(contextRequestStop)
+}
+
 func newContext(
 	resource resource.Resource,
 	shardID int32,
 	factory EngineFactory,
 	config *configs.Config,
+	leaseProvider LeaseProvider,
+	lifecycleObservers *lifecycleObservers,
+	acquisitionThrottle *acquisitionThrottle,
 	closeCallback func(*ContextImpl),
 ) (*ContextImpl, error) {
 
 	hostIdentity := resource.GetHostInfo().Identity()
+	// persistence failures are logged for every request during an outage, log identical ones once per interval
+	throttledLogger := log.NewDedupLogger(resource.GetThrottledLogger(), func() time.Duration {
+		return config.ShardLogDedupInterval()
+	})
 
 	shardContext := &ContextImpl{
 		Resource:         resource,
@@ -1562,11 +2677,20 @@ func newContext(
 		shardID:          shardID,
 		executionManager: resource.GetExecutionManager(),
 		metricsClient:    resource.GetMetricsClient(),
+		lockScopes:       newLockScopes(resource.GetMetricsClient()),
 		closeCallback:    closeCallback,
 		config:           config,
 		logger:           log.With(resource.GetLogger(), tag.ShardID(shardID), tag.Address(hostIdentity)),
-		throttledLogger:  log.With(resource.GetThrottledLogger(), tag.ShardID(shardID), tag.Address(hostIdentity)),
+		throttledLogger:  log.With(throttledLogger, tag.ShardID(shardID), tag.Address(hostIdentity)),
 		engineFactory:    factory,
+		rateLimiter:      newPersistenceRateLimiter(shardID, config, resource.GetTimeSource()),
+		leaseProvider:    leaseProvider,
+		observers:        lifecycleObservers,
+		acquireThrottle:  acquisitionThrottle,
+		diagnostics:      &Diagnostics{},
+		lastActivity:     resource.GetTimeSource().Now().UnixNano(),
+		flushCh:          make(chan struct{}, 1),
+		flushStopCh:      make(chan struct{}),
 	}
 	shardContext.eventsCache = events.NewEventsCache(
 		shardContext.GetShardID(),
@@ -1577,17 +2701,18 @@ func newContext(
 		false,
 		shardContext.GetLogger(),
 		shardContext.GetMetricsClient(),
+		events.NewNamespacePinnedFn(config.CachePinnedNamespace),
 	)
 
 	return shardContext, nil
 }
 
-func copyShardInfo(shardInfo *persistence.ShardInfoWithFailover) *persistence.ShardInfoWithFailover {
-	transferFailoverLevels := map[string]persistence.TransferFailoverLevel{}
+func copyShardInfo(shardInfo *persistencespb.ShardInfo) *persistencespb.ShardInfo {
+	transferFailoverLevels := make(map[string]*persistencespb.TransferFailoverLevel)
 	for k, v := range shardInfo.TransferFailoverLevels {
 		transferFailoverLevels[k] = v
 	}
-	timerFailoverLevels := map[string]persistence.TimerFailoverLevel{}
+	timerFailoverLevels := make(map[string]*persistencespb.TimerFailoverLevel)
 	for k, v := range shardInfo.TimerFailoverLevels {
 		timerFailoverLevels[k] = v
 	}
@@ -1610,29 +2735,101 @@ func copyShardInfo(shardInfo *persistence.ShardInfoWithFailover) *persistence.Sh
 	for k, v := range shardInfo.ReplicationDlqAckLevel {
 		clusterReplicationDLQLevel[k] = v
 	}
+	var queueAckLevels map[int32]int64
+	if shardInfo.QueueAckLevels != nil {
+		queueAckLevels = make(map[int32]int64, len(shardInfo.QueueAckLevels))
+		for k, v := range shardInfo.QueueAckLevels {
+			queueAckLevels[k] = v
+		}
+	}
+	var namespaceAckLevels map[int32]*persistencespb.NamespaceAckLevels
+	if shardInfo.NamespaceAckLevels != nil {
+		namespaceAckLevels = make(map[int32]*persistencespb.NamespaceAckLevels, len(shardInfo.NamespaceAckLevels))
+		for k, v := range shardInfo.NamespaceAckLevels {
+			namespaceAckLevels[k] = v
+		}
+	}
 	if timestamp.TimeValue(shardInfo.TimerAckLevelTime).IsZero() {
 		shardInfo.TimerAckLevelTime = timestamp.TimePtr(defaultTime)
 	}
-	shardInfoCopy := &persistence.ShardInfoWithFailover{
-		ShardInfo: &persistencespb.ShardInfo{
-			ShardId:                      shardInfo.GetShardId(),
-			Owner:                        shardInfo.Owner,
-			RangeId:                      shardInfo.GetRangeId(),
-			StolenSinceRenew:             shardInfo.StolenSinceRenew,
-			ReplicationAckLevel:          shardInfo.ReplicationAckLevel,
-			TransferAckLevel:             shardInfo.TransferAckLevel,
-			TimerAckLevelTime:            shardInfo.TimerAckLevelTime,
-			ClusterTransferAckLevel:      clusterTransferAckLevel,
-			ClusterTimerAckLevel:         clusterTimerAckLevel,
-			NamespaceNotificationVersion: shardInfo.NamespaceNotificationVersion,
-			ClusterReplicationLevel:      clusterReplicationLevel,
-			ReplicationDlqAckLevel:       clusterReplicationDLQLevel,
-			UpdateTime:                   shardInfo.UpdateTime,
-			VisibilityAckLevel:           shardInfo.VisibilityAckLevel,
-		},
-		TransferFailoverLevels: transferFailoverLevels,
-		TimerFailoverLevels:    timerFailoverLevels,
+	shardInfoCopy := &persistencespb.ShardInfo{
+		ShardId:                      shardInfo.GetShardId(),
+		Owner:                        shardInfo.Owner,
+		RangeId:                      shardInfo.GetRangeId(),
+		StolenSinceRenew:             shardInfo.StolenSinceRenew,
+		ReplicationAckLevel:          shardInfo.ReplicationAckLevel,
+		TransferAckLevel:             shardInfo.TransferAckLevel,
+		TimerAckLevelTime:            shardInfo.TimerAckLevelTime,
+		ClusterTransferAckLevel:      clusterTransferAckLevel,
+		ClusterTimerAckLevel:         clusterTimerAckLevel,
+		NamespaceNotificationVersion: shardInfo.NamespaceNotificationVersion,
+		ClusterReplicationLevel:      clusterReplicationLevel,
+		ReplicationDlqAckLevel:       clusterReplicationDLQLevel,
+		UpdateTime:                   shardInfo.UpdateTime,
+		VisibilityAckLevel:           shardInfo.VisibilityAckLevel,
+		TieredStorageAckLevel:        shardInfo.TieredStorageAckLevel,
+		QueueAckLevels:               queueAckLevels,
+		TransferFailoverLevels:       transferFailoverLevels,
+		TimerFailoverLevels:          timerFailoverLevels,
+		NamespaceAckLevels:           namespaceAckLevels,
 	}
 
 	return shardInfoCopy
 }
+
+func transferFailoverLevelToProto(level persistence.TransferFailoverLevel) *persistencespb.TransferFailoverLevel {
+	return &persistencespb.TransferFailoverLevel{
+		StartTime:    timestamp.TimePtr(level.StartTime),
+		MinLevel:     level.MinLevel,
+		CurrentLevel: level.CurrentLevel,
+		MaxLevel:     level.MaxLevel,
+		NamespaceIds: namespaceIDsToProto(level.NamespaceIDs),
+	}
+}
+
+func transferFailoverLevelFromProto(level *persistencespb.TransferFailoverLevel) persistence.TransferFailoverLevel {
+	return persistence.TransferFailoverLevel{
+		StartTime:    timestamp.TimeValue(level.StartTime),
+		MinLevel:     level.MinLevel,
+		CurrentLevel: level.CurrentLevel,
+		MaxLevel:     level.MaxLevel,
+		NamespaceIDs: namespaceIDsFromProto(level.NamespaceIds),
+	}
+}
+
+func timerFailoverLevelToProto(level persistence.TimerFailoverLevel) *persistencespb.TimerFailoverLevel {
+	return &persistencespb.TimerFailoverLevel{
+		StartTime:    timestamp.TimePtr(level.StartTime),
+		MinLevel:     timestamp.TimePtr(level.MinLevel),
+		CurrentLevel: timestamp.TimePtr(level.CurrentLevel),
+		MaxLevel:     timestamp.TimePtr(level.MaxLevel),
+		NamespaceIds: namespaceIDsToProto(level.NamespaceIDs),
+	}
+}
+
+func timerFailoverLevelFromProto(level *persistencespb.TimerFailoverLevel) persistence.TimerFailoverLevel {
+	return persistence.TimerFailoverLevel{
+		StartTime:    timestamp.TimeValue(level.StartTime),
+		MinLevel:     timestamp.TimeValue(level.MinLevel),
+		CurrentLevel: timestamp.TimeValue(level.CurrentLevel),
+		MaxLevel:     timestamp.TimeValue(level.MaxLevel),
+		NamespaceIDs: namespaceIDsFromProto(level.NamespaceIds),
+	}
+}
+
+func namespaceIDsToProto(namespaceIDs map[string]struct{}) []string {
+	ids := make([]string, 0, len(namespaceIDs))
+	for id := range namespaceIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func namespaceIDsFromProto(ids []string) map[string]struct{} {
+	namespaceIDs := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		namespaceIDs[id] = struct{}{}
+	}
+	return namespaceIDs
+}
@@ -29,6 +29,7 @@
 package shard
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -45,6 +46,7 @@ import (
 	resource "go.temporal.io/server/common/resource"
 	configs "go.temporal.io/server/service/history/configs"
 	events "go.temporal.io/server/service/history/events"
+	tasks "go.temporal.io/server/service/history/tasks"
 )
 
 // MockContext is a mock of Context interface.
@@ -71,62 +73,76 @@ func (m *MockContext) EXPECT() *MockContextMockRecorder {
 }
 
 // AddTasks mocks base method.
-func (m *MockContext) AddTasks(request *persistence.AddTasksRequest) error {
+func (m *MockContext) AddTasks(ctx context.Context, request *persistence.AddTasksRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTasks", request)
+	ret := m.ctrl.Call(m, "AddTasks", ctx, request)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddTasks indicates an expected call of AddTasks.
-func (mr *MockContextMockRecorder) AddTasks(request interface{}) *gomock.Call {
+func (mr *MockContextMockRecorder) AddTasks(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockContext)(nil).AddTasks), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockContext)(nil).AddTasks), ctx, request)
 }
 
 // AppendHistoryEvents mocks base method.
-func (m *MockContext) AppendHistoryEvents(request *persistence.AppendHistoryNodesRequest, namespaceID namespace.ID, execution v1.WorkflowExecution) (int, error) {
+func (m *MockContext) AppendHistoryEvents(ctx context.Context, request *persistence.AppendHistoryNodesRequest, namespaceID namespace.ID, execution v1.WorkflowExecution) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AppendHistoryEvents", request, namespaceID, execution)
+	ret := m.ctrl.Call(m, "AppendHistoryEvents", ctx, request, namespaceID, execution)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AppendHistoryEvents indicates an expected call of AppendHistoryEvents.
-func (mr *MockContextMockRecorder) AppendHistoryEvents(request, namespaceID, execution interface{}) *gomock.Call {
+func (mr *MockContextMockRecorder) AppendHistoryEvents(ctx, request, namespaceID, execution interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendHistoryEvents", reflect.TypeOf((*MockContext)(nil).AppendHistoryEvents), request, namespaceID, execution)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendHistoryEvents", reflect.TypeOf((*MockContext)(nil).AppendHistoryEvents), ctx, request, namespaceID, execution)
+}
+
+// AssertWritable mocks base method.
+func (m *MockContext) AssertWritable() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssertWritable")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssertWritable indicates an expected call of AssertWritable.
+func (mr *MockContextMockRecorder) AssertWritable() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssertWritable", reflect.TypeOf((*MockContext)(nil).AssertWritable))
 }
 
 // ConflictResolveWorkflowExecution mocks base method.
-func (m *MockContext) ConflictResolveWorkflowExecution(request *persistence.ConflictResolveWorkflowExecutionRequest) (*persistence.ConflictResolveWorkflowExecutionResponse, error) {
+func (m *MockContext) ConflictResolveWorkflowExecution(ctx context.Context, request *persistence.ConflictResolveWorkflowExecutionRequest) (*persistence.ConflictResolveWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConflictResolveWorkflowExecution", request)
+	ret := m.ctrl.Call(m, "ConflictResolveWorkflowExecution", ctx, request)
 	ret0, _ := ret[0].(*persistence.ConflictResolveWorkflowExecutionResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ConflictResolveWorkflowExecution indicates an expected call of ConflictResolveWorkflowExecution.
-func (mr *MockContextMockRecorder) ConflictResolveWorkflowExecution(request interface{}) *gomock.Call {
+func (mr *MockContextMockRecorder) ConflictResolveWorkflowExecution(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConflictResolveWorkflowExecution", reflect.TypeOf((*MockContext)(nil).ConflictResolveWorkflowExecution), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConflictResolveWorkflowExecution", reflect.TypeOf((*MockContext)(nil).ConflictResolveWorkflowExecution), ctx, request)
 }
 
 // CreateWorkflowExecution mocks base method.
-func (m *MockContext) CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error) {
+func (m *MockContext) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWorkflowExecution", request)
+	ret := m.ctrl.Call(m, "CreateWorkflowExecution", ctx, request)
 	ret0, _ := ret[0].(*persistence.CreateWorkflowExecutionResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateWorkflowExecution indicates an expected call of CreateWorkflowExecution.
-func (mr *MockContextMockRecorder) CreateWorkflowExecution(request interface{}) *gomock.Call {
+func (mr *MockContextMockRecorder) CreateWorkflowExecution(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkflowExecution", reflect.TypeOf((*MockContext)(nil).CreateWorkflowExecution), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkflowExecution", reflect.TypeOf((*MockContext)(nil).CreateWorkflowExecution), ctx, request)
 }
 
 // DeleteTimerFailoverLevel mocks base method.
@@ -158,17 +174,17 @@ func (mr *MockContextMockRecorder) DeleteTransferFailoverLevel(failoverID interf
 }
 
 // DeleteWorkflowExecution mocks base method.
-func (m *MockContext) DeleteWorkflowExecution(workflowKey definition.WorkflowKey, branchToken []byte, version int64) error {
+func (m *MockContext) DeleteWorkflowExecution(ctx context.Context, workflowKey definition.WorkflowKey, branchToken []byte, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", workflowKey, branchToken, version)
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", ctx, workflowKey, branchToken, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteWorkflowExecution indicates an expected call of DeleteWorkflowExecution.
-func (mr *MockContextMockRecorder) DeleteWorkflowExecution(workflowKey, branchToken, version interface{}) *gomock.Call {
+func (mr *MockContextMockRecorder) DeleteWorkflowExecution(ctx, workflowKey, branchToken, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflowExecution", reflect.TypeOf((*MockContext)(nil).DeleteWorkflowExecution), workflowKey, branchToken, version)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflowExecution", reflect.TypeOf((*MockContext)(nil).DeleteWorkflowExecution), ctx, workflowKey, branchToken, version)
 }
 
 // GenerateTransferTaskID mocks base method.
@@ -285,6 +301,20 @@ func (mr *MockContextMockRecorder) GetCurrentTime(cluster interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentTime", reflect.TypeOf((*MockContext)(nil).GetCurrentTime), cluster)
 }
 
+// GetDiagnostics mocks base method.
+func (m *MockContext) GetDiagnostics() *Diagnostics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiagnostics")
+	ret0, _ := ret[0].(*Diagnostics)
+	return ret0
+}
+
+// GetDiagnostics indicates an expected call of GetDiagnostics.
+func (mr *MockContextMockRecorder) GetDiagnostics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnostics", reflect.TypeOf((*MockContext)(nil).GetDiagnostics))
+}
+
 // GetEngine mocks base method.
 func (m *MockContext) GetEngine() (Engine, error) {
 	m.ctrl.T.Helper()
@@ -370,6 +400,20 @@ func (mr *MockContextMockRecorder) GetMetricsClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricsClient", reflect.TypeOf((*MockContext)(nil).GetMetricsClient))
 }
 
+// GetNamespaceAckLevels mocks base method.
+func (m *MockContext) GetNamespaceAckLevels(category tasks.Category) map[namespace.ID]tasks.Key {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespaceAckLevels", category)
+	ret0, _ := ret[0].(map[namespace.ID]tasks.Key)
+	return ret0
+}
+
+// GetNamespaceAckLevels indicates an expected call of GetNamespaceAckLevels.
+func (mr *MockContextMockRecorder) GetNamespaceAckLevels(category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceAckLevels", reflect.TypeOf((*MockContext)(nil).GetNamespaceAckLevels), category)
+}
+
 // GetNamespaceNotificationVersion mocks base method.
 func (m *MockContext) GetNamespaceNotificationVersion() int64 {
 	m.ctrl.T.Helper()
@@ -398,6 +442,20 @@ func (mr *MockContextMockRecorder) GetNamespaceRegistry() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceRegistry", reflect.TypeOf((*MockContext)(nil).GetNamespaceRegistry))
 }
 
+// GetQueueAckLevel mocks base method.
+func (m *MockContext) GetQueueAckLevel(category tasks.Category) tasks.Key {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueAckLevel", category)
+	ret0, _ := ret[0].(tasks.Key)
+	return ret0
+}
+
+// GetQueueAckLevel indicates an expected call of GetQueueAckLevel.
+func (mr *MockContextMockRecorder) GetQueueAckLevel(category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueAckLevel", reflect.TypeOf((*MockContext)(nil).GetQueueAckLevel), category)
+}
+
 // GetRemoteClusterAckInfo mocks base method.
 func (m *MockContext) GetRemoteClusterAckInfo(cluster []string) (map[string]*v10.ShardReplicationStatusPerCluster, error) {
 	m.ctrl.T.Helper()
@@ -609,6 +667,21 @@ func (mr *MockContextMockRecorder) GetVisibilityAckLevel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibilityAckLevel", reflect.TypeOf((*MockContext)(nil).GetVisibilityAckLevel))
 }
 
+// GetWorkflowExecution mocks base method.
+func (m *MockContext) GetWorkflowExecution(ctx context.Context, request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflowExecution", ctx, request)
+	ret0, _ := ret[0].(*persistence.GetWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflowExecution indicates an expected call of GetWorkflowExecution.
+func (mr *MockContextMockRecorder) GetWorkflowExecution(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflowExecution", reflect.TypeOf((*MockContext)(nil).GetWorkflowExecution), ctx, request)
+}
+
 // SetCurrentTime mocks base method.
 func (m *MockContext) SetCurrentTime(cluster string, currentTime time.Time) {
 	m.ctrl.T.Helper()
@@ -635,6 +708,20 @@ func (mr *MockContextMockRecorder) UpdateClusterReplicationLevel(cluster, ackTas
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterReplicationLevel", reflect.TypeOf((*MockContext)(nil).UpdateClusterReplicationLevel), cluster, ackTaskID, ackTimestamp)
 }
 
+// UpdateNamespaceAckLevels mocks base method.
+func (m *MockContext) UpdateNamespaceAckLevels(category tasks.Category, ackLevels map[namespace.ID]tasks.Key) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNamespaceAckLevels", category, ackLevels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNamespaceAckLevels indicates an expected call of UpdateNamespaceAckLevels.
+func (mr *MockContextMockRecorder) UpdateNamespaceAckLevels(category, ackLevels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceAckLevels", reflect.TypeOf((*MockContext)(nil).UpdateNamespaceAckLevels), category, ackLevels)
+}
+
 // UpdateNamespaceNotificationVersion mocks base method.
 func (m *MockContext) UpdateNamespaceNotificationVersion(namespaceNotificationVersion int64) error {
 	m.ctrl.T.Helper()
@@ -649,6 +736,20 @@ func (mr *MockContextMockRecorder) UpdateNamespaceNotificationVersion(namespaceN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceNotificationVersion", reflect.TypeOf((*MockContext)(nil).UpdateNamespaceNotificationVersion), namespaceNotificationVersion)
 }
 
+// UpdateQueueAckLevel mocks base method.
+func (m *MockContext) UpdateQueueAckLevel(category tasks.Category, ackLevel tasks.Key) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQueueAckLevel", category, ackLevel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQueueAckLevel indicates an expected call of UpdateQueueAckLevel.
+func (mr *MockContextMockRecorder) UpdateQueueAckLevel(category, ackLevel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQueueAckLevel", reflect.TypeOf((*MockContext)(nil).UpdateQueueAckLevel), category, ackLevel)
+}
+
 // UpdateReplicatorAckLevel mocks base method.
 func (m *MockContext) UpdateReplicatorAckLevel(ackLevel int64) error {
 	m.ctrl.T.Helper()
@@ -804,16 +905,16 @@ func (mr *MockContextMockRecorder) UpdateVisibilityAckLevel(ackLevel interface{}
 }
 
 // UpdateWorkflowExecution mocks base method.
-func (m *MockContext) UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error) {
+func (m *MockContext) UpdateWorkflowExecution(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateWorkflowExecution", request)
+	ret := m.ctrl.Call(m, "UpdateWorkflowExecution", ctx, request)
 	ret0, _ := ret[0].(*persistence.UpdateWorkflowExecutionResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateWorkflowExecution indicates an expected call of UpdateWorkflowExecution.
-func (mr *MockContextMockRecorder) UpdateWorkflowExecution(request interface{}) *gomock.Call {
+func (mr *MockContextMockRecorder) UpdateWorkflowExecution(ctx, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkflowExecution", reflect.TypeOf((*MockContext)(nil).UpdateWorkflowExecution), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkflowExecution", reflect.TypeOf((*MockContext)(nil).UpdateWorkflowExecution), ctx, request)
 }
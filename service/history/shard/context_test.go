@@ -25,15 +25,24 @@
 package shard
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
 
 	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/api/historyservice/v1"
 	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/cluster"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/primitives/timestamp"
@@ -60,8 +69,29 @@ type (
 		mockExecutionManager *persistence.MockExecutionManager
 		mockHistoryEngine    *MockEngine
 	}
+
+	testLeaseProvider struct {
+		lease *testLease
+	}
+
+	testLease struct {
+		lostCh   chan struct{}
+		released bool
+	}
 )
 
+func (p *testLeaseProvider) AcquireLease(_ context.Context, _ int32, _ string) (Lease, error) {
+	return p.lease, nil
+}
+
+func (l *testLease) Lost() <-chan struct{} {
+	return l.lostCh
+}
+
+func (l *testLease) Release() {
+	l.released = true
+}
+
 func TestShardContextSuite(t *testing.T) {
 	s := &contextSuite{}
 	suite.Run(t, s)
@@ -74,12 +104,11 @@ func (s *contextSuite) SetupTest() {
 
 	shardContext := NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          0,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          0,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		tests.NewDynamicConfig(),
 	)
 	s.shardContext = shardContext
@@ -138,6 +167,1021 @@ func (s *contextSuite) TestAddTasks_Success() {
 	s.mockHistoryEngine.EXPECT().NotifyNewVisibilityTasks(visibilityTasks)
 	s.mockHistoryEngine.EXPECT().NotifyNewReplicationTasks(replicationTasks)
 
-	err := s.shardContext.AddTasks(addTasksRequest)
+	err := s.shardContext.AddTasks(context.Background(), addTasksRequest)
+	s.NoError(err)
+}
+
+func (s *contextSuite) TestAddTasks_ContextCanceled() {
+	addTasksRequest := &persistence.AddTasksRequest{
+		ShardID:     s.shardContext.GetShardID(),
+		NamespaceID: s.namespaceID.String(),
+		WorkflowID:  "workflow-id",
+		RunID:       "run-id",
+
+		TransferTasks: []tasks.Task{&tasks.ActivityTask{}},
+	}
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := s.shardContext.AddTasks(ctx, addTasksRequest)
+	s.Equal(context.Canceled, err)
+}
+
+func (s *contextSuite) TestAddTasks_ReadOnly() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardReadOnly = dynamicconfig.GetBoolPropertyFnFilteredByShardID(true)
+
+	addTasksRequest := &persistence.AddTasksRequest{
+		ShardID:     s.shardContext.GetShardID(),
+		NamespaceID: s.namespaceID.String(),
+		WorkflowID:  "workflow-id",
+		RunID:       "run-id",
+
+		TransferTasks: []tasks.Task{&tasks.ActivityTask{}},
+	}
+
+	err := s.shardContext.AddTasks(context.Background(), addTasksRequest)
+	s.Equal(ErrShardReadOnly, err)
+}
+
+func (s *contextSuite) TestUpdateAckLevel_ReadOnly() {
+	shard := s.shardContext.(*ContextTest)
+	s.NoError(shard.UpdateTransferAckLevel(5))
+	shard.config.ShardReadOnly = dynamicconfig.GetBoolPropertyFnFilteredByShardID(true)
+
+	s.Equal(ErrShardReadOnly, shard.UpdateTransferAckLevel(10))
+	s.Equal(int64(5), shard.GetTransferAckLevel())
+	s.Equal(ErrShardReadOnly, shard.AssertWritable())
+	// no UpdateShard is expected
+	s.Equal(ErrShardReadOnly, shard.flushShardInfo(true))
+	s.Equal(ErrShardReadOnly, shard.renewRangeLocked(false))
+}
+
+func (s *contextSuite) TestGetWorkflowExecution_ShardNotAcquired() {
+	shard := s.shardContext.(*ContextTest)
+	shard.SetInitializedForTesting(nil, nil)
+
+	_, err := s.shardContext.GetWorkflowExecution(context.Background(), &persistence.GetWorkflowExecutionRequest{
+		ShardID:     s.shardContext.GetShardID(),
+		NamespaceID: s.namespaceID.String(),
+		Execution:   commonpb.WorkflowExecution{WorkflowId: "workflow-id", RunId: "run-id"},
+	})
+	s.Equal(ErrShardStatusUnknown, err)
+}
+
+func (s *contextSuite) TestGetWorkflowExecution_RangeIDChanged() {
+	shard := s.shardContext.(*ContextTest)
+	request := &persistence.GetWorkflowExecutionRequest{
+		ShardID:     s.shardContext.GetShardID(),
+		NamespaceID: s.namespaceID.String(),
+		Execution:   commonpb.WorkflowExecution{WorkflowId: "workflow-id", RunId: "run-id"},
+	}
+	s.mockExecutionManager.EXPECT().GetWorkflowExecution(request).DoAndReturn(
+		func(_ *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
+			shard.shardInfo.RangeId++
+			return &persistence.GetWorkflowExecutionResponse{}, nil
+		},
+	).Times(2)
+
+	_, err := s.shardContext.GetWorkflowExecution(context.Background(), request)
+	s.NoError(err)
+
+	shard.config.ShardReadValidateRangeID = dynamicconfig.GetBoolPropertyFn(true)
+	_, err = s.shardContext.GetWorkflowExecution(context.Background(), request)
+	s.Equal(ErrShardStatusUnknown, err)
+}
+
+func (s *contextSuite) TestLock_TracksWriteHolder() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardLockSlowThreshold = dynamicconfig.GetDurationPropertyFn(time.Nanosecond)
+
+	shard.wLock(lockOperationUpdateWorkflow)
+	s.Equal(lockOperationUpdateWorkflow, shard.wLockOperation)
+	s.False(shard.wLockTime.IsZero())
+	shard.wUnlock()
+
+	shard.rLock(lockOperationGetEngine)
+	s.Equal(lockOperationUpdateWorkflow, shard.wLockOperation)
+	shard.rUnlock()
+}
+
+func (s *contextSuite) TestClockSkew_BlocksTimerAllocation() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardClockSkewLimit = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	shard.config.ShardClockSkewBlockTimerAllocation = dynamicconfig.GetBoolPropertyFn(true)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+
+	namespaceEntry := namespace.NewGlobalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Id: s.namespaceID.String()},
+		&persistencespb.NamespaceConfig{},
+		&persistencespb.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestAlternativeClusterName,
+			Clusters:          []string{cluster.TestCurrentClusterName, cluster.TestAlternativeClusterName},
+		},
+		1,
+	)
+	newTimerTasks := func() []tasks.Task {
+		return []tasks.Task{&tasks.UserTimerTask{VisibilityTimestamp: time.Now().UTC(), Version: 1}}
+	}
+
+	shard.SetCurrentTime(cluster.TestAlternativeClusterName, time.Now().UTC().Add(time.Second))
+	shard.wLock(lockOperationTesting)
+	s.NoError(shard.allocateTimerIDsLocked(namespaceEntry, "workflow-id", newTimerTasks()))
+	shard.wUnlock()
+
+	shard.SetCurrentTime(cluster.TestAlternativeClusterName, time.Now().UTC().Add(time.Hour))
+	shard.wLock(lockOperationTesting)
+	s.Equal(ErrClockSkewExceeded, shard.allocateTimerIDsLocked(namespaceEntry, "workflow-id", newTimerTasks()))
+	shard.wUnlock()
+
+	shard.config.ShardClockSkewBlockTimerAllocation = dynamicconfig.GetBoolPropertyFn(false)
+	shard.wLock(lockOperationTesting)
+	s.NoError(shard.allocateTimerIDsLocked(namespaceEntry, "workflow-id", newTimerTasks()))
+	shard.wUnlock()
+}
+
+func (s *contextSuite) TestQueueAckLevel() {
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).AnyTimes()
+
+	now := time.Now().UTC()
+	s.NoError(s.shardContext.UpdateQueueAckLevel(tasks.CategoryTimer, tasks.Key{FireTime: now}))
+	s.Equal(now, s.shardContext.GetTimerAckLevel())
+	s.Equal(tasks.Key{FireTime: now}, s.shardContext.GetQueueAckLevel(tasks.CategoryTimer))
+
+	s.NoError(s.shardContext.UpdateQueueAckLevel(tasks.CategoryVisibility, tasks.Key{TaskID: 100}))
+	s.Equal(int64(100), s.shardContext.GetVisibilityAckLevel())
+
+	category := tasks.NewCategory(100, tasks.CategoryTypeImmediate, "test-category")
+	s.Equal(tasks.Key{}, s.shardContext.GetQueueAckLevel(category))
+	s.NoError(s.shardContext.UpdateQueueAckLevel(category, tasks.Key{TaskID: 200}))
+	s.Equal(tasks.Key{TaskID: 200}, s.shardContext.GetQueueAckLevel(category))
+
+	scheduledCategory := tasks.NewCategory(101, tasks.CategoryTypeScheduled, "test-scheduled-category")
+	s.NoError(s.shardContext.UpdateQueueAckLevel(scheduledCategory, tasks.Key{FireTime: now}))
+	s.Equal(tasks.Key{FireTime: now}, s.shardContext.GetQueueAckLevel(scheduledCategory))
+
+	// ack levels of registered categories are persisted with shard info and survive a shard reload
+	shardInfo := copyShardInfo(s.shardContext.(*ContextTest).shardInfo)
+	s.Equal(int64(200), shardInfo.QueueAckLevels[category.ID()])
+	s.Equal(now.UnixNano(), shardInfo.QueueAckLevels[scheduledCategory.ID()])
+}
+
+func (s *contextSuite) TestNamespaceAckLevels() {
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).AnyTimes()
+
+	now := time.Now().UTC()
+	s.Empty(s.shardContext.GetNamespaceAckLevels(tasks.CategoryTransfer))
+	s.NoError(s.shardContext.UpdateNamespaceAckLevels(tasks.CategoryTransfer, map[namespace.ID]tasks.Key{
+		"namespace-1": {TaskID: 100},
+		"namespace-2": {TaskID: 200},
+	}))
+	s.NoError(s.shardContext.UpdateNamespaceAckLevels(tasks.CategoryTimer, map[namespace.ID]tasks.Key{
+		"namespace-1": {FireTime: now},
+	}))
+	s.Equal(map[namespace.ID]tasks.Key{
+		"namespace-1": {TaskID: 100},
+		"namespace-2": {TaskID: 200},
+	}, s.shardContext.GetNamespaceAckLevels(tasks.CategoryTransfer))
+	s.Equal(map[namespace.ID]tasks.Key{
+		"namespace-1": {FireTime: now},
+	}, s.shardContext.GetNamespaceAckLevels(tasks.CategoryTimer))
+
+	// namespaces which are no longer reported have acked all their tasks
+	s.NoError(s.shardContext.UpdateNamespaceAckLevels(tasks.CategoryTransfer, map[namespace.ID]tasks.Key{
+		"namespace-2": {TaskID: 250},
+	}))
+	s.Equal(map[namespace.ID]tasks.Key{
+		"namespace-2": {TaskID: 250},
+	}, s.shardContext.GetNamespaceAckLevels(tasks.CategoryTransfer))
+
+	// namespace ack levels are persisted with shard info and survive a shard reload
+	shardInfo := copyShardInfo(s.shardContext.(*ContextTest).shardInfo)
+	s.Equal(map[string]int64{"namespace-2": 250}, shardInfo.NamespaceAckLevels[tasks.CategoryIDTransfer].GetAckLevels())
+	s.Equal(map[string]int64{"namespace-1": now.UnixNano()}, shardInfo.NamespaceAckLevels[tasks.CategoryIDTimer].GetAckLevels())
+}
+
+func (s *contextSuite) TestAckLevelReadsDoNotWaitForPersistence() {
+	addTasksRequest := &persistence.AddTasksRequest{
+		ShardID:     s.shardContext.GetShardID(),
+		NamespaceID: s.namespaceID.String(),
+		WorkflowID:  "workflow-id",
+		RunID:       "run-id",
+
+		TransferTasks: []tasks.Task{&tasks.ActivityTask{}},
+	}
+
+	persisting := make(chan struct{})
+	release := make(chan struct{})
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName)
+	s.mockExecutionManager.EXPECT().AddTasks(addTasksRequest).DoAndReturn(func(_ *persistence.AddTasksRequest) error {
+		close(persisting)
+		<-release
+		return nil
+	})
+	s.mockHistoryEngine.EXPECT().NotifyNewTransferTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewTimerTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewVisibilityTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewReplicationTasks(gomock.Any())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.shardContext.AddTasks(context.Background(), addTasksRequest)
+	}()
+	<-persisting
+
+	s.Equal(int64(0), s.shardContext.GetTransferAckLevel())
+	s.Equal(tasks.Key{TaskID: 0}, s.shardContext.GetQueueAckLevel(tasks.CategoryTransfer))
+	s.Equal(int64(0), s.shardContext.GetTransferMaxReadLevel())
+	s.Equal(time.Time{}, s.shardContext.GetTimerMaxReadLevel(cluster.TestCurrentClusterName))
+
+	close(release)
+	s.NoError(<-errCh)
+	s.Equal(addTasksRequest.TransferTasks[0].GetTaskID(), s.shardContext.GetTransferMaxReadLevel())
+}
+
+func (s *contextSuite) TestAddTasks_ConcurrentWrites() {
+	newAddTasksRequest := func(workflowID string) *persistence.AddTasksRequest {
+		return &persistence.AddTasksRequest{
+			ShardID:     s.shardContext.GetShardID(),
+			NamespaceID: s.namespaceID.String(),
+			WorkflowID:  workflowID,
+			RunID:       "run-id",
+
+			TransferTasks: []tasks.Task{&tasks.ActivityTask{}},
+		}
+	}
+	firstRequest := newAddTasksRequest("workflow-id-1")
+	secondRequest := newAddTasksRequest("workflow-id-2")
+
+	persisting := make(chan struct{})
+	release := make(chan struct{})
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil).Times(2)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).Times(2)
+	s.mockExecutionManager.EXPECT().AddTasks(firstRequest).DoAndReturn(func(_ *persistence.AddTasksRequest) error {
+		close(persisting)
+		<-release
+		return nil
+	})
+	s.mockExecutionManager.EXPECT().AddTasks(secondRequest).Return(nil)
+	s.mockHistoryEngine.EXPECT().NotifyNewTransferTasks(gomock.Any()).Times(2)
+	s.mockHistoryEngine.EXPECT().NotifyNewTimerTasks(gomock.Any()).Times(2)
+	s.mockHistoryEngine.EXPECT().NotifyNewVisibilityTasks(gomock.Any()).Times(2)
+	s.mockHistoryEngine.EXPECT().NotifyNewReplicationTasks(gomock.Any()).Times(2)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.shardContext.AddTasks(context.Background(), firstRequest)
+	}()
+	<-persisting
+
+	// the second write doesn't wait for the first one, but the read level doesn't move past tasks still in flight
+	s.NoError(s.shardContext.AddTasks(context.Background(), secondRequest))
+	firstTaskID := firstRequest.TransferTasks[0].GetTaskID()
+	secondTaskID := secondRequest.TransferTasks[0].GetTaskID()
+	s.Greater(secondTaskID, firstTaskID)
+	s.Equal(firstTaskID-1, s.shardContext.GetTransferMaxReadLevel())
+
+	close(release)
+	s.NoError(<-errCh)
+	s.Equal(secondTaskID, s.shardContext.GetTransferMaxReadLevel())
+}
+
+func (s *contextSuite) TestFlushShardInfo() {
+	shard := s.shardContext.(*ContextTest)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+
+	// updates are only persisted by the flusher
+	s.NoError(shard.UpdateTransferAckLevel(10))
+	s.NoError(shard.UpdateVisibilityAckLevel(20))
+	s.True(shard.shardInfoDirty)
+
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).DoAndReturn(func(request *persistence.UpdateShardRequest) error {
+		s.Equal(int64(10), request.ShardInfo.TransferAckLevel)
+		s.Equal(int64(20), request.ShardInfo.VisibilityAckLevel)
+		s.Equal(int64(1), request.PreviousRangeID)
+		return nil
+	}).Times(1)
+	s.NoError(shard.flushShardInfo(false))
+	s.False(shard.shardInfoDirty)
+
+	// within ShardUpdateMinInterval of the last flush, only a forced flush is persisted
+	s.NoError(shard.UpdateTransferAckLevel(30))
+	s.NoError(shard.flushShardInfo(false))
+	s.True(shard.shardInfoDirty)
+
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).Times(1)
+	s.NoError(shard.flushShardInfo(true))
+	s.False(shard.shardInfoDirty)
+}
+
+func (s *contextSuite) TestFlushShardInfo_FailedFlushIsRetried() {
+	shard := s.shardContext.(*ContextTest)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	lastUpdated := shard.GetLastUpdatedTime()
+
+	s.NoError(shard.UpdateTransferAckLevel(10))
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(serviceerror.NewResourceExhausted("busy")).Times(1)
+	s.Error(shard.flushShardInfo(false))
+	s.True(shard.shardInfoDirty)
+	s.Equal(lastUpdated, shard.GetLastUpdatedTime())
+
+	// the next flush doesn't wait for the shard update interval
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).Times(1)
+	s.NoError(shard.flushShardInfo(false))
+	s.False(shard.shardInfoDirty)
+	s.True(shard.GetLastUpdatedTime().After(lastUpdated))
+}
+
+func (s *contextSuite) TestRangeRenewError() {
+	ownershipLostErr := &persistence.ShardOwnershipLostError{ShardID: 1, Msg: "range stolen"}
+	err := newRangeRenewError(ownershipLostErr)
+	s.True(errors.Is(err, ErrRangeStolen))
+	s.False(errors.Is(err, ErrRangeRenewTimeout))
+	s.True(IsShardOwnershipLostError(err))
+
+	err = newRangeRenewError(&persistence.TimeoutError{Msg: "timeout"})
+	s.True(errors.Is(err, ErrRangeRenewTimeout))
+	s.False(errors.Is(err, ErrRangeStolen))
+	var timeoutErr *persistence.TimeoutError
+	s.True(errors.As(err, &timeoutErr))
+
+	err = newRangeRenewError(context.DeadlineExceeded)
+	s.True(errors.Is(err, ErrRangeRenewTimeout))
+
+	unavailableErr := serviceerror.NewUnavailable("db down")
+	s.Equal(unavailableErr, newRangeRenewError(unavailableErr))
+	s.Nil(newRangeRenewError(nil))
+}
+
+func (s *contextSuite) TestDrain() {
+	shard := s.shardContext.(*ContextTest)
+	shard.transferMaxReadLevel = 10
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).Times(1)
+	gomock.InOrder(
+		s.mockHistoryEngine.EXPECT().DrainQueues(int64(10), gomock.Any()).Return(false),
+		s.mockHistoryEngine.EXPECT().DrainQueues(int64(10), gomock.Any()).Return(true),
+	)
+
+	// returns as soon as the queues are drained, long before the timeout
+	shard.drain(time.Minute)
+	s.Equal(contextStateDraining, shard.state)
+	s.NoError(shard.errorByState())
+
+	_, err := shard.getOrCreateEngine(context.Background())
+	s.Equal(ErrShardClosed, err)
+}
+
+func (s *contextSuite) TestDrain_Timeout() {
+	shard := s.shardContext.(*ContextTest)
+	shard.transferMaxReadLevel = 10
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).Times(1)
+	s.mockHistoryEngine.EXPECT().DrainQueues(int64(10), gomock.Any()).Return(false).MinTimes(1)
+
+	// the ack levels are still checkpointed
+	shard.drain(10 * time.Millisecond)
+	s.Equal(contextStateDraining, shard.state)
+}
+
+func (s *contextSuite) TestPruneRemovedClusters() {
+	ackTime := time.Now().UTC()
+	shardInfo := copyShardInfo(&persistencespb.ShardInfo{
+		ClusterTransferAckLevel: map[string]int64{"active": 10, "removed": 5},
+		ClusterTimerAckLevel:    map[string]*time.Time{"active": &ackTime, "standby": &ackTime, "removed": &ackTime},
+		ClusterReplicationLevel: map[string]int64{"standby": 10, "other-removed": 5},
+		ReplicationDlqAckLevel:  map[string]int64{"other-removed": 5},
+	})
+
+	removed := pruneRemovedClusters(shardInfo, map[string]struct{}{"active": {}, "standby": {}})
+	s.Equal([]string{"other-removed", "removed"}, removed)
+	s.Equal(map[string]int64{"active": 10}, shardInfo.ClusterTransferAckLevel)
+	s.Equal(map[string]*time.Time{"active": &ackTime, "standby": &ackTime}, shardInfo.ClusterTimerAckLevel)
+	s.Equal(map[string]int64{"standby": 10}, shardInfo.ClusterReplicationLevel)
+	s.Empty(shardInfo.ReplicationDlqAckLevel)
+}
+
+func (s *contextSuite) TestLoadShardMetadata_RestoresReplicationAckLevels() {
+	shard := s.shardContext.(*ContextTest)
+	shard.shardInfo = nil
+	s.mockResource.ShardMgr.EXPECT().GetOrCreateShard(gomock.Any()).Return(&persistence.GetOrCreateShardResponse{
+		ShardInfo: &persistencespb.ShardInfo{
+			ShardId:                 0,
+			RangeId:                 1,
+			ClusterReplicationLevel: map[string]int64{cluster.TestAlternativeClusterName: 42},
+		},
+	}, nil)
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestAllClusterInfo).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+
+	var ownershipChanged bool
+	s.NoError(shard.loadShardMetadata(&ownershipChanged))
+
+	ackInfo, err := shard.GetRemoteClusterAckInfo([]string{cluster.TestAlternativeClusterName})
+	s.NoError(err)
+	s.Equal(int64(42), ackInfo[cluster.TestAlternativeClusterName].AckedTaskId)
+}
+
+func (s *contextSuite) TestLoadShardMetadata_RestoresFailoverLevels() {
+	shard := s.shardContext.(*ContextTest)
+	shard.shardInfo = nil
+	startTime := time.Now().UTC()
+	s.mockResource.ShardMgr.EXPECT().GetOrCreateShard(gomock.Any()).Return(&persistence.GetOrCreateShardResponse{
+		ShardInfo: &persistencespb.ShardInfo{
+			ShardId: 0,
+			RangeId: 1,
+			TransferFailoverLevels: map[string]*persistencespb.TransferFailoverLevel{
+				"transfer-failover": {
+					StartTime:    &startTime,
+					MinLevel:     10,
+					CurrentLevel: 15,
+					MaxLevel:     20,
+					NamespaceIds: []string{"namespace-1", "namespace-2"},
+				},
+			},
+		},
+	}, nil)
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestAllClusterInfo).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+
+	var ownershipChanged bool
+	s.NoError(shard.loadShardMetadata(&ownershipChanged))
+
+	s.Equal(map[string]persistence.TransferFailoverLevel{
+		"transfer-failover": {
+			StartTime:    startTime,
+			MinLevel:     10,
+			CurrentLevel: 15,
+			MaxLevel:     20,
+			NamespaceIDs: map[string]struct{}{"namespace-1": {}, "namespace-2": {}},
+		},
+	}, shard.GetAllTransferFailoverLevels())
+	s.Empty(shard.GetAllTimerFailoverLevels())
+
+	// failover progress is persisted with the shard info
+	minLevel := startTime.Add(-time.Minute)
+	s.NoError(shard.UpdateTimerFailoverLevel("timer-failover", persistence.TimerFailoverLevel{
+		StartTime:    startTime,
+		MinLevel:     minLevel,
+		CurrentLevel: minLevel,
+		MaxLevel:     startTime,
+		NamespaceIDs: map[string]struct{}{"namespace-1": {}},
+	}))
+	s.NoError(shard.DeleteTransferFailoverLevel("transfer-failover"))
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).DoAndReturn(func(request *persistence.UpdateShardRequest) error {
+		s.Empty(request.ShardInfo.TransferFailoverLevels)
+		s.Equal(&persistencespb.TimerFailoverLevel{
+			StartTime:    &startTime,
+			MinLevel:     &minLevel,
+			CurrentLevel: &minLevel,
+			MaxLevel:     &startTime,
+			NamespaceIds: []string{"namespace-1"},
+		}, request.ShardInfo.TimerFailoverLevels["timer-failover"])
+		return nil
+	}).Times(1)
+	s.NoError(shard.flushShardInfo(true))
+}
+
+func (s *contextSuite) TestHeartbeatOwnership() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardOwnershipHeartbeatInterval = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).Times(1)
+
+	// shard info was never persisted, so the heartbeat is due
+	s.NoError(shard.heartbeatOwnership())
+	// shard info was just persisted, so the heartbeat is skipped
+	s.NoError(shard.heartbeatOwnership())
+}
+
+func (s *contextSuite) TestHeartbeatOwnership_OwnershipLost() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardOwnershipHeartbeatInterval = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(&persistence.ShardOwnershipLostError{})
+	closedCh := make(chan struct{})
+	shard.closeCallback = func(*ContextImpl) { close(closedCh) }
+
+	s.Error(shard.heartbeatOwnership())
+	s.Equal(contextStateStopping, shard.state)
+	<-closedCh
+}
+
+func (s *contextSuite) TestLeaseLost() {
+	shard := s.shardContext.(*ContextTest)
+	lease := &testLease{lostCh: make(chan struct{})}
+	shard.leaseProvider = &testLeaseProvider{lease: lease}
+	closedCh := make(chan struct{})
+	shard.closeCallback = func(*ContextImpl) { close(closedCh) }
+
+	s.NoError(shard.acquireLease())
+	s.Equal(Lease(lease), shard.lease)
+	// the lease is kept across acquisition attempts
+	s.NoError(shard.acquireLease())
+
+	close(lease.lostCh)
+	select {
+	case <-closedCh:
+	case <-time.After(time.Second):
+		s.Fail("shard not closed after its lease was lost")
+	}
+	s.False(shard.isValid())
+
+	s.mockHistoryEngine.EXPECT().Stop()
+	shard.stop()
+	s.True(lease.released)
+	s.Nil(shard.lease)
+}
+
+func (s *contextSuite) TestStateTransitions_TestHooks() {
+	shard := s.shardContext.(*ContextTest)
+	engineFactory := NewMockEngineFactory(s.controller)
+	closedCh := make(chan struct{})
+	shard.SetInitializedForTesting(engineFactory, func(*ContextImpl) { close(closedCh) })
+	acquireRequested := false
+	shard.SetAcquireShardHookForTesting(func() { acquireRequested = true })
+	s.Equal(LifecycleStateUnspecified, shard.LifecycleStateForTesting())
+
+	shard.TransitionForTesting(ContextTransitionAcquire)
+	s.True(acquireRequested)
+	s.Equal(LifecycleStateAcquiring, shard.LifecycleStateForTesting())
+	_, err := shard.GetEngine()
+	s.Equal(ErrShardStatusUnknown, err)
+
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
+	gomock.InOrder(
+		engineFactory.EXPECT().CreateEngine(shard.ContextImpl).Return(s.mockHistoryEngine),
+		s.mockHistoryEngine.EXPECT().Start(),
+		s.mockHistoryEngine.EXPECT().Stop(),
+	)
+	shard.AcquireShardForTesting()
+	s.Equal(LifecycleStateAcquired, shard.LifecycleStateForTesting())
+
+	shard.TransitionForTesting(ContextTransitionStop)
+	s.Equal(LifecycleStateStopping, shard.LifecycleStateForTesting())
+	select {
+	case <-closedCh:
+	case <-time.After(time.Second):
+		s.Fail("close callback not called after the shard moved to stopping")
+	}
+
+	shard.StopForTest()
+	s.Equal(LifecycleStateStopped, shard.LifecycleStateForTesting())
+}
+
+func (s *contextSuite) TestAppendHistoryEvents_ShardOwnershipLost() {
+	shard := s.shardContext.(*ContextTest)
+	closedCh := make(chan struct{})
+	shard.closeCallback = func(*ContextImpl) { close(closedCh) }
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil)
+	s.mockExecutionManager.EXPECT().AppendHistoryNodes(gomock.Any()).DoAndReturn(
+		func(request *persistence.AppendHistoryNodesRequest) (*persistence.AppendHistoryNodesResponse, error) {
+			s.Equal(int64(1), request.RangeID)
+			return nil, &persistence.ShardOwnershipLostError{ShardID: request.ShardID}
+		},
+	)
+
+	_, err := shard.AppendHistoryEvents(
+		context.Background(),
+		&persistence.AppendHistoryNodesRequest{},
+		s.namespaceID,
+		commonpb.WorkflowExecution{WorkflowId: "workflow-id", RunId: "run-id"},
+	)
+	s.IsType(&persistence.ShardOwnershipLostError{}, err)
+	select {
+	case <-closedCh:
+	case <-time.After(time.Second):
+		s.Fail("shard not closed after its ownership was lost")
+	}
+	s.False(shard.isValid())
+}
+
+func (s *contextSuite) TestAssertOwnership_RangeTakenOver() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardOwnershipAssertionRate = dynamicconfig.GetFloatPropertyFn(1)
+	closedCh := make(chan struct{})
+	shard.closeCallback = func(*ContextImpl) { close(closedCh) }
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil)
+	s.mockResource.ShardMgr.EXPECT().GetOrCreateShard(gomock.Any()).Return(&persistence.GetOrCreateShardResponse{
+		ShardInfo: &persistencespb.ShardInfo{ShardId: shard.GetShardID(), RangeId: 2},
+	}, nil)
+
+	err := shard.AddTasks(context.Background(), &persistence.AddTasksRequest{
+		ShardID:       shard.GetShardID(),
+		NamespaceID:   s.namespaceID.String(),
+		WorkflowID:    "workflow-id",
+		RunID:         "run-id",
+		TransferTasks: []tasks.Task{&tasks.ActivityTask{}},
+	})
+	s.IsType(&persistence.ShardOwnershipLostError{}, err)
+	select {
+	case <-closedCh:
+	case <-time.After(time.Second):
+		s.Fail("shard not closed after its ownership assertion failed")
+	}
+	s.False(shard.isValid())
+}
+
+func (s *contextSuite) TestAssertOwnership_AfterTransientError() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardOwnershipAssertionRate = dynamicconfig.GetFloatPropertyFn(0.000001)
+
+	shard.markOwnershipUnknown(serviceerror.NewUnavailable("db down"))
+	s.mockResource.ShardMgr.EXPECT().GetOrCreateShard(gomock.Any()).Return(&persistence.GetOrCreateShardResponse{
+		ShardInfo: &persistencespb.ShardInfo{ShardId: shard.GetShardID(), RangeId: 1},
+	}, nil).Times(1)
+	s.NoError(shard.maybeAssertOwnership())
+	s.Equal(int32(0), shard.assertOwnershipPending)
+}
+
+func (s *contextSuite) TestLifecycleObservers() {
+	shard := s.shardContext.(*ContextTest)
+	shard.observers = newLifecycleObservers()
+
+	var states []LifecycleState
+	remove := shard.observers.add(func(event LifecycleEvent) {
+		s.Equal(shard.shardID, event.ShardID)
+		s.Equal(int64(1), event.RangeID)
+		s.Equal(shard.GetHostInfo().Identity(), event.Owner)
+		states = append(states, event.State)
+	})
+
+	shard.wLock(lockOperationTesting)
+	shard.transitionLocked(contextRequestDrain)
+	shard.wUnlock()
+	remove()
+	shard.wLock(lockOperationTesting)
+	shard.transitionLocked(contextRequestFinishStop)
+	shard.wUnlock()
+
+	s.Equal([]LifecycleState{LifecycleStateDraining}, states)
+}
+
+func (s *contextSuite) TestWarmUp() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardWarmUpMaxExecutions = dynamicconfig.GetIntPropertyFn(10)
+	shard.transferMaxReadLevel = 10
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+
+	workflowKey := definition.NewWorkflowKey(s.namespaceID.String(), "workflow-id", "run-id")
+	otherWorkflowKey := definition.NewWorkflowKey(s.namespaceID.String(), "other-workflow-id", "run-id")
+	s.mockExecutionManager.EXPECT().GetTransferTasks(&persistence.GetTransferTasksRequest{
+		ShardID:      shard.shardID,
+		ReadLevel:    0,
+		MaxReadLevel: 10,
+		BatchSize:    warmUpPageSize,
+	}).Return(&persistence.GetTransferTasksResponse{
+		Tasks: []tasks.Task{
+			&tasks.ActivityTask{WorkflowKey: workflowKey, TaskID: 1},
+			&tasks.WorkflowTask{WorkflowKey: workflowKey, TaskID: 2},
+			&tasks.ActivityTask{WorkflowKey: otherWorkflowKey, TaskID: 3},
+		},
+	}, nil)
+
+	branchToken := []byte("branch-token")
+	s.mockHistoryEngine.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(&historyservice.GetMutableStateResponse{
+		CurrentBranchToken: branchToken,
+	}, nil).Times(2)
+	shard.MockEventsCache.EXPECT().GetEvent(gomock.Any(), common.FirstEventID, branchToken).Return(nil, nil).Times(2)
+
+	shard.warmUp(s.mockHistoryEngine)
+}
+
+func (s *contextSuite) TestAcquireShard_WarmsUpThroughShardReads() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.ShardWarmUpMaxExecutions = dynamicconfig.GetIntPropertyFn(10)
+	engineFactory := NewMockEngineFactory(s.controller)
+	shard.SetInitializedForTesting(engineFactory, func(*ContextImpl) {})
+	shard.SetAcquireShardHookForTesting(func() {})
+	shard.TransitionForTesting(ContextTransitionAcquire)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
+
+	workflowKey := definition.NewWorkflowKey(s.namespaceID.String(), "workflow-id", "run-id")
+	branchToken := []byte("branch-token")
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
+	engineFactory.EXPECT().CreateEngine(shard.ContextImpl).Return(s.mockHistoryEngine)
+	s.mockHistoryEngine.EXPECT().Start()
+	s.mockExecutionManager.EXPECT().GetTransferTasks(gomock.Any()).Return(&persistence.GetTransferTasksResponse{
+		Tasks: []tasks.Task{&tasks.ActivityTask{WorkflowKey: workflowKey, TaskID: 1}},
+	}, nil)
+	s.mockExecutionManager.EXPECT().GetTimerTasks(gomock.Any()).Return(&persistence.GetTimerTasksResponse{}, nil).AnyTimes()
+	// the engine reads the execution through the shard, as it does for GetMutableState
+	s.mockHistoryEngine.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *historyservice.GetMutableStateRequest) (*historyservice.GetMutableStateResponse, error) {
+			_, err := shard.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+				ShardID:     shard.shardID,
+				NamespaceID: request.GetNamespaceId(),
+				Execution:   *request.GetExecution(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &historyservice.GetMutableStateResponse{CurrentBranchToken: branchToken}, nil
+		})
+	s.mockExecutionManager.EXPECT().GetWorkflowExecution(gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{}, nil)
+	shard.MockEventsCache.EXPECT().GetEvent(gomock.Any(), common.FirstEventID, branchToken).Return(nil, nil)
+
+	shard.AcquireShardForTesting()
+	s.Equal(LifecycleStateAcquired, shard.LifecycleStateForTesting())
+
+	s.mockHistoryEngine.EXPECT().Stop()
+	shard.StopForTest()
+}
+
+func (s *contextSuite) TestGenerateTransferTaskID_RenewsRangeInBackground() {
+	shard := s.shardContext.(*ContextTest)
+	rangeSize := int64(1) << shard.config.RangeSizeBits
+	shard.transferSequenceNumber = rangeSize + rangeSize*8/10 - 1
+	shard.maxTransferSequenceNumber = 2 * rangeSize
+
+	renewed := make(chan struct{})
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).DoAndReturn(func(request *persistence.UpdateShardRequest) error {
+		s.Equal(int64(1), request.PreviousRangeID)
+		s.Equal(int64(2), request.ShardInfo.RangeId)
+		close(renewed)
+		return nil
+	}).Times(1)
+
+	// IDs are allocated from the current range while the next one is acquired
+	taskID, err := shard.GenerateTransferTaskID()
+	s.NoError(err)
+	s.Equal(rangeSize+rangeSize*8/10-1, taskID)
+	_, err = shard.GenerateTransferTaskID()
+	s.NoError(err)
+
+	select {
+	case <-renewed:
+	case <-time.After(time.Second):
+		s.Fail("range wasn't renewed")
+	}
+	s.Eventually(func() bool {
+		shard.rLock(lockOperationTesting)
+		defer shard.rUnlock()
+		return !shard.rangeRenewing && shard.getRangeIDLocked() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// the rest of the current range is still allocated before the next range
+	shard.rLock(lockOperationTesting)
+	s.Equal(rangeSize+rangeSize*8/10+1, shard.transferSequenceNumber)
+	s.Equal(3*rangeSize, shard.maxTransferSequenceNumber)
+	shard.rUnlock()
+}
+
+func (s *contextSuite) TestRenewRangeAsync_DoesNotBlockTaskIDAllocation() {
+	shard := s.shardContext.(*ContextTest)
+	rangeSize := int64(1) << shard.config.RangeSizeBits
+	shard.transferSequenceNumber = rangeSize + rangeSize*8/10
+	shard.maxTransferSequenceNumber = 2 * rangeSize
+	shard.rangeRenewing = true
+
+	persisting := make(chan struct{})
+	release := make(chan struct{})
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).DoAndReturn(func(request *persistence.UpdateShardRequest) error {
+		s.Equal(int64(1), request.PreviousRangeID)
+		close(persisting)
+		<-release
+		return nil
+	}).Times(1)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		shard.renewRangeAsync(1)
+	}()
+	<-persisting
+
+	// task IDs are allocated and ack levels read while the next range is persisted
+	taskID, err := shard.GenerateTransferTaskID()
+	s.NoError(err)
+	s.Equal(rangeSize+rangeSize*8/10, taskID)
+	rangeID := func() int64 {
+		shard.rLock(lockOperationTesting)
+		defer shard.rUnlock()
+		return shard.getRangeIDLocked()
+	}
+	s.Equal(int64(1), rangeID())
+
+	// the write allocated meanwhile waits for the next range and is fenced by it
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockExecutionManager.EXPECT().AddTasks(gomock.Any()).DoAndReturn(func(request *persistence.AddTasksRequest) error {
+		s.Equal(int64(2), request.RangeID)
+		return nil
+	})
+	s.mockHistoryEngine.EXPECT().NotifyNewTransferTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewTimerTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewVisibilityTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewReplicationTasks(gomock.Any())
+	written := make(chan error)
+	go func() {
+		written <- shard.AddTasks(context.Background(), &persistence.AddTasksRequest{
+			ShardID:       shard.GetShardID(),
+			NamespaceID:   s.namespaceID.String(),
+			WorkflowID:    "workflow-id",
+			RunID:         "run-id",
+			TransferTasks: []tasks.Task{&tasks.ActivityTask{}},
+		})
+	}()
+
+	close(release)
+	s.NoError(<-written)
+	<-renewed
+	s.Equal(int64(2), rangeID())
+}
+
+func (s *contextSuite) TestRenewRangeAsync_FailedRenewalFailsWaitingWrites() {
+	shard := s.shardContext.(*ContextTest)
+	rangeSize := int64(1) << shard.config.RangeSizeBits
+	shard.transferSequenceNumber = rangeSize + rangeSize*8/10
+	shard.maxTransferSequenceNumber = 2 * rangeSize
+	shard.rangeRenewing = true
+
+	persisting := make(chan struct{})
+	release := make(chan struct{})
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).DoAndReturn(func(request *persistence.UpdateShardRequest) error {
+		close(persisting)
+		<-release
+		return serviceerror.NewResourceExhausted("update shard throttled")
+	}).Times(1)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		shard.renewRangeAsync(1)
+	}()
+	<-persisting
+
+	// the write allocated meanwhile can be fenced by neither range, it's not made
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	written := make(chan error)
+	go func() {
+		written <- shard.AddTasks(context.Background(), &persistence.AddTasksRequest{
+			ShardID:       shard.GetShardID(),
+			NamespaceID:   s.namespaceID.String(),
+			WorkflowID:    "workflow-id",
+			RunID:         "run-id",
+			TransferTasks: []tasks.Task{&tasks.ActivityTask{}},
+		})
+	}()
+	s.Eventually(func() bool {
+		shard.readLevelLock.Lock()
+		defer shard.readLevelLock.Unlock()
+		return shard.writesAwaitingRange == 1
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	s.Equal(ErrShardStatusUnknown, <-written)
+	<-renewed
+
+	shard.rLock(lockOperationTesting)
+	defer shard.rUnlock()
+	s.Equal(int64(1), shard.getRangeIDLocked())
+	s.Empty(shard.inFlightWrites)
+}
+
+func (s *contextSuite) TestRenewRangeLocked_HoldsReadLevelBelowWaitingWrites() {
+	shard := s.shardContext.(*ContextTest)
+	rangeSize := int64(1) << shard.config.RangeSizeBits
+	shard.transferSequenceNumber = rangeSize + 10
+	shard.maxTransferSequenceNumber = 2 * rangeSize
+	// a write waiting for the range renewed in the background allocated task IDs from rangeSize+5 on
+	shard.beginWrite(rangeSize + 5)
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).Times(1)
+
+	shard.wLock(lockOperationTesting)
+	s.NoError(shard.renewRangeLocked(true))
+	s.Equal(int64(2), shard.getRangeIDLocked())
+	shard.wUnlock()
+
+	s.Equal(rangeSize+4, shard.GetTransferMaxReadLevel())
+	shard.endWrite(rangeSize+5, rangeSize+5)
+	s.Equal(2*rangeSize-1, shard.GetTransferMaxReadLevel())
+}
+
+func (s *contextSuite) TestRenewRange_AlertsOnHighRenewalRate() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.RangeRenewalAlertRate = dynamicconfig.GetIntPropertyFn(2)
+	s.mockResource.ShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).Times(3)
+
+	shard.wLock(lockOperationTesting)
+	defer shard.wUnlock()
+	for i := 0; i < 3; i++ {
+		s.NoError(shard.renewRangeLocked(false))
+	}
+	s.Equal(3, shard.rangeRenewalsInWindow)
+	s.Equal(int64(4), shard.getRangeIDLocked())
+}
+
+func (s *contextSuite) TestGetIdleNextTimer() {
+	shard := s.shardContext.(*ContextTest)
+	now := time.Now().UTC()
+	idleTimeout := 10 * time.Minute
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
+	nextTimer := now.Add(time.Hour)
+	s.mockExecutionManager.EXPECT().GetTimerTasks(gomock.Any()).Return(&persistence.GetTimerTasksResponse{
+		Tasks: []tasks.Task{&tasks.UserTimerTask{VisibilityTimestamp: nextTimer}},
+	}, nil).Times(1)
+
+	idleNextTimer, ok := shard.getIdleNextTimer(now, idleTimeout)
+	s.True(ok)
+	s.Equal(nextTimer, idleNextTimer)
+
+	// the next timer fires before the shard would be considered idle again
+	s.mockExecutionManager.EXPECT().GetTimerTasks(gomock.Any()).Return(&persistence.GetTimerTasksResponse{
+		Tasks: []tasks.Task{&tasks.UserTimerTask{VisibilityTimestamp: now.Add(time.Minute)}},
+	}, nil).Times(1)
+	_, ok = shard.getIdleNextTimer(now, idleTimeout)
+	s.False(ok)
+
+	// pending transfer tasks
+	shard.transferMaxReadLevel = 10
+	_, ok = shard.getIdleNextTimer(now, idleTimeout)
+	s.False(ok)
+
+	shard.markActivity()
+	_, ok = shard.getIdleNextTimer(time.Now().UTC(), idleTimeout)
+	s.False(ok)
+}
+
+func (s *contextSuite) TestGetIdleNextTimer_RemoteClusters() {
+	shard := s.shardContext.(*ContextTest)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestAllClusterInfo).AnyTimes()
+
+	// replication from the remote cluster keeps the shard loaded
+	_, ok := shard.getIdleNextTimer(time.Now().UTC(), 10*time.Minute)
+	s.False(ok)
+}
+
+func (s *contextSuite) TestDeleteWorkflowExecution_CompletesOnceStarted() {
+	key := definition.NewWorkflowKey(s.namespaceID.String(), "workflow-id", "run-id")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	// the caller gives up after the first write, the execution is deleted anyway, visibility first
+	gomock.InOrder(
+		s.mockExecutionManager.EXPECT().AddTasks(gomock.Any()).DoAndReturn(func(request *persistence.AddTasksRequest) error {
+			s.Len(request.VisibilityTasks, 1)
+			cancel()
+			return nil
+		}),
+		s.mockExecutionManager.EXPECT().DeleteWorkflowExecution(gomock.Any()).Return(nil),
+		s.mockExecutionManager.EXPECT().DeleteCurrentWorkflowExecution(gomock.Any()).Return(nil),
+		s.mockExecutionManager.EXPECT().DeleteHistoryBranch(gomock.Any()).Return(nil),
+	)
+	s.mockHistoryEngine.EXPECT().NotifyNewTransferTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewTimerTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewVisibilityTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewReplicationTasks(gomock.Any())
+
+	err := s.shardContext.DeleteWorkflowExecution(ctx, key, []byte("branch-token"), 1)
+	s.NoError(err)
+}
+
+func (s *contextSuite) TestDeleteWorkflowExecution_ContextCanceled() {
+	key := definition.NewWorkflowKey(s.namespaceID.String(), "workflow-id", "run-id")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// nothing is written if the caller gave up before the deletion started
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil)
+	err := s.shardContext.DeleteWorkflowExecution(ctx, key, nil, 1)
+	s.Equal(context.Canceled, err)
+}
+
+func (s *contextSuite) TestDeleteWorkflowExecution_RetainsVisibilityRecord() {
+	shard := s.shardContext.(*ContextTest)
+	shard.config.VisibilityRecordRetention = dynamicconfig.GetDurationPropertyFnFilteredByNamespace(24 * time.Hour)
+	key := definition.NewWorkflowKey(s.namespaceID.String(), "workflow-id", "run-id")
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.namespaceID).Return(s.namespaceEntry, nil).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockExecutionManager.EXPECT().DeleteCurrentWorkflowExecution(gomock.Any()).Return(nil)
+	s.mockExecutionManager.EXPECT().DeleteWorkflowExecution(gomock.Any()).Return(nil)
+	s.mockExecutionManager.EXPECT().AddTasks(gomock.Any()).DoAndReturn(func(request *persistence.AddTasksRequest) error {
+		s.Empty(request.VisibilityTasks)
+		s.Len(request.TimerTasks, 1)
+		timerTask, ok := request.TimerTasks[0].(*tasks.DeleteExecutionVisibilityTimerTask)
+		s.True(ok)
+		s.Equal(key, timerTask.WorkflowKey)
+		s.True(timerTask.VisibilityTimestamp.After(time.Now().Add(23 * time.Hour)))
+		return nil
+	})
+	s.mockHistoryEngine.EXPECT().NotifyNewTransferTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewTimerTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewVisibilityTasks(gomock.Any())
+	s.mockHistoryEngine.EXPECT().NotifyNewReplicationTasks(gomock.Any())
+
+	err := s.shardContext.DeleteWorkflowExecution(context.Background(), key, nil, 1)
 	s.NoError(err)
 }
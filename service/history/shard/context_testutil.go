@@ -29,13 +29,25 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common/metrics"
-	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/service/history/configs"
 	"go.temporal.io/server/service/history/events"
 )
 
+// ContextTransition is a shard state transition request, used by tests to drive the shard state machine
+type ContextTransition int
+
+const (
+	ContextTransitionAcquire    = ContextTransition(contextRequestAcquire)
+	ContextTransitionAcquired   = ContextTransition(contextRequestAcquired)
+	ContextTransitionLost       = ContextTransition(contextRequestLost)
+	ContextTransitionStop       = ContextTransition(contextRequestStop)
+	ContextTransitionFinishStop = ContextTransition(contextRequestFinishStop)
+	ContextTransitionDrain      = ContextTransition(contextRequestDrain)
+)
+
 type ContextTest struct {
 	*ContextImpl
 
@@ -47,7 +59,7 @@ var _ Context = (*ContextTest)(nil)
 
 func NewTestContext(
 	ctrl *gomock.Controller,
-	shardInfo *persistence.ShardInfoWithFailover,
+	shardInfo *persistencespb.ShardInfo,
 	config *configs.Config,
 ) *ContextTest {
 	resource := resource.NewTest(ctrl, metrics.History)
@@ -57,10 +69,16 @@ func NewTestContext(
 		shardID:          shardInfo.GetShardId(),
 		executionManager: resource.ExecutionMgr,
 		metricsClient:    resource.MetricsClient,
+		lockScopes:       newLockScopes(resource.MetricsClient),
 		eventsCache:      eventsCache,
 		config:           config,
 		logger:           resource.GetLogger(),
 		throttledLogger:  resource.GetThrottledLogger(),
+		rateLimiter:      newPersistenceRateLimiter(shardInfo.GetShardId(), config, resource.GetTimeSource()),
+		leaseProvider:    NewRangeLeaseProvider(),
+		diagnostics:      &Diagnostics{},
+		flushCh:          make(chan struct{}, 1),
+		flushStopCh:      make(chan struct{}),
 
 		state:                     contextStateAcquired,
 		shardInfo:                 shardInfo,
@@ -93,3 +111,58 @@ func (s *ContextTest) SetEventsCacheForTesting(c events.Cache) {
 func (s *ContextTest) StopForTest() {
 	s.stop()
 }
+
+// SetInitializedForTesting moves the shard back to the Initialized state without an engine, so that tests can
+// drive the state machine from the beginning. The engine factory is used when the shard is acquired, and the
+// close callback is called when the shard moves to Stopping.
+func (s *ContextTest) SetInitializedForTesting(engineFactory EngineFactory, closeCallback func(*ContextImpl)) {
+	s.wLock(lockOperationTesting)
+	defer s.wUnlock()
+	s.state = contextStateInitialized
+	s.engine = nil
+	s.engineFactory = engineFactory
+	s.closeCallback = closeCallback
+}
+
+// SetAcquireShardHookForTesting makes the shard call hook instead of starting the acquireShard goroutine when
+// it moves to Acquiring. The hook is called with the shard lock held, tests should record the call and run
+// AcquireShardForTesting afterwards, so that the Acquiring window lasts as long as the test needs.
+func (s *ContextTest) SetAcquireShardHookForTesting(hook func()) {
+	s.wLock(lockOperationTesting)
+	defer s.wUnlock()
+	s.acquireShardHook = hook
+}
+
+// AcquireShardForTesting runs the shard acquisition synchronously. It creates and starts the engine the first
+// time the shard is acquired and moves the shard to Acquired, unless the shard was stopped meanwhile.
+func (s *ContextTest) AcquireShardForTesting() {
+	s.acquireShard()
+}
+
+// TransitionForTesting requests a shard state transition, as the controller or a persistence error would.
+func (s *ContextTest) TransitionForTesting(transition ContextTransition) {
+	s.wLock(lockOperationTesting)
+	defer s.wUnlock()
+	s.transitionLocked(contextRequest(transition))
+}
+
+// LifecycleStateForTesting returns the current shard state, LifecycleStateUnspecified if the shard is
+// still Initialized.
+func (s *ContextTest) LifecycleStateForTesting() LifecycleState {
+	s.rLock(lockOperationTesting)
+	defer s.rUnlock()
+	switch s.state {
+	case contextStateAcquiring:
+		return LifecycleStateAcquiring
+	case contextStateAcquired:
+		return LifecycleStateAcquired
+	case contextStateDraining:
+		return LifecycleStateDraining
+	case contextStateStopping:
+		return LifecycleStateStopping
+	case contextStateStopped:
+		return LifecycleStateStopped
+	default:
+		return LifecycleStateUnspecified
+	}
+}
@@ -26,6 +26,7 @@ package shard
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -61,10 +62,17 @@ type (
 		logger             log.Logger
 		throttledLogger    log.Logger
 		config             *configs.Config
+		leaseProvider      LeaseProvider
+		lifecycleObservers *lifecycleObservers
 		metricsScope       metrics.Scope
+		// acquireThrottle throttles the shard acquisitions of all shards of this host
+		acquireThrottle *acquisitionThrottle
 
 		sync.RWMutex
 		historyShards map[int32]*ContextImpl
+		// idleShards are the shards unloaded while idle, mapped to the time they are reloaded to fire their next
+		// timer, or zero time if only traffic reloads them
+		idleShards map[int32]time.Time
 	}
 )
 
@@ -72,19 +80,29 @@ func NewController(
 	resource resource.Resource,
 	factory EngineFactory,
 	config *configs.Config,
+	leaseProvider LeaseProvider,
 ) *ControllerImpl {
 	hostIdentity := resource.GetHostInfo().Identity()
+	metricsScope := resource.GetMetricsClient().Scope(metrics.HistoryShardControllerScope)
 	return &ControllerImpl{
 		Resource:           resource,
 		status:             common.DaemonStatusInitialized,
 		membershipUpdateCh: make(chan *membership.ChangedEvent, 10),
 		engineFactory:      factory,
 		historyShards:      make(map[int32]*ContextImpl),
+		idleShards:         make(map[int32]time.Time),
 		shutdownCh:         make(chan struct{}),
 		logger:             log.With(resource.GetLogger(), tag.ComponentShardController, tag.Address(hostIdentity)),
 		throttledLogger:    log.With(resource.GetThrottledLogger(), tag.ComponentShardController, tag.Address(hostIdentity)),
 		config:             config,
-		metricsScope:       resource.GetMetricsClient().Scope(metrics.HistoryShardControllerScope),
+		leaseProvider:      leaseProvider,
+		lifecycleObservers: newLifecycleObservers(),
+		metricsScope:       metricsScope,
+		acquireThrottle: newAcquisitionThrottle(
+			config.ShardAcquisitionMaxConcurrency,
+			config.ShardAcquisitionPacing,
+			metricsScope,
+		),
 	}
 }
 
@@ -97,7 +115,7 @@ func (c *ControllerImpl) Start() {
 		return
 	}
 
-	c.acquireShards()
+	c.acquireShards(nil)
 	c.shutdownWG.Add(1)
 	go c.shardManagementPump()
 
@@ -139,6 +157,12 @@ func (c *ControllerImpl) Status() int32 {
 	return atomic.LoadInt32(&c.status)
 }
 
+// AddLifecycleObserver subscribes observer to state transitions of the shards owned by this host,
+// and returns a function to unsubscribe it. Shards already loaded only report their later transitions.
+func (c *ControllerImpl) AddLifecycleObserver(observer LifecycleObserver) func() {
+	return c.lifecycleObservers.add(observer)
+}
+
 func (c *ControllerImpl) GetEngine(ctx context.Context, namespaceID namespace.ID, workflowID string) (Engine, error) {
 	shardID := c.config.GetShardID(namespaceID, workflowID)
 	return c.GetEngineForShard(ctx, shardID)
@@ -151,9 +175,27 @@ func (c *ControllerImpl) GetEngineForShard(ctx context.Context, shardID int32) (
 	if err != nil {
 		return nil, err
 	}
+	shard.markActivity()
 	return shard.getOrCreateEngine(ctx)
 }
 
+// acquireShard loads a shard owned by this host, unless it was unloaded while idle and its next timer isn't due yet.
+// Unlike GetEngineForShard, it doesn't count as activity of the shard.
+func (c *ControllerImpl) acquireShard(ctx context.Context, shardID int32) error {
+	sw := c.metricsScope.StartTimer(metrics.GetEngineForShardLatency)
+	defer sw.Stop()
+
+	if c.isShardIdle(shardID, c.GetTimeSource().Now()) {
+		return nil
+	}
+	shard, err := c.getOrCreateShardContext(shardID)
+	if err != nil {
+		return err
+	}
+	_, err = shard.getOrCreateEngine(ctx)
+	return err
+}
+
 func (c *ControllerImpl) CloseShardByID(shardID int32) {
 	sw := c.metricsScope.StartTimer(metrics.RemoveEngineForShardLatency)
 	defer sw.Stop()
@@ -162,6 +204,7 @@ func (c *ControllerImpl) CloseShardByID(shardID int32) {
 	// Stop the current shard, if it exists.
 	if shard != nil {
 		shard.logger.Info("", tag.LifeCycleStopping, tag.ComponentShardContext, tag.ShardID(shardID))
+		shard.drain(c.config.ShardDrainTimeout())
 		shard.stop()
 		c.metricsScope.IncCounter(metrics.ShardContextRemovedCounter)
 		shard.logger.Info("", tag.LifeCycleStopped, tag.ComponentShardContext, tag.Number(newNumShards))
@@ -222,6 +265,9 @@ func (c *ControllerImpl) getOrCreateShardContext(shardID int32) (*ContextImpl, e
 		shardID,
 		c.engineFactory,
 		c.config,
+		c.leaseProvider,
+		c.lifecycleObservers,
+		c.acquireThrottle,
 		c.shardClosedCallback,
 	)
 	if err != nil {
@@ -229,6 +275,7 @@ func (c *ControllerImpl) getOrCreateShardContext(shardID int32) (*ContextImpl, e
 	}
 	shard.start()
 	c.historyShards[shardID] = shard
+	delete(c.idleShards, shardID)
 	c.metricsScope.IncCounter(metrics.ShardContextCreatedCounter)
 
 	shard.logger.Info("", tag.LifeCycleStarted, tag.ComponentShardContext)
@@ -269,6 +316,11 @@ func (c *ControllerImpl) shardManagementPump() {
 	acquireTicker := time.NewTicker(c.config.AcquireShardInterval())
 	defer acquireTicker.Stop()
 
+	backlogReporter := newQueueBacklogReporter(c.GetMetricsClient())
+	// interval is re-read on every tick so that dynamic config changes take effect
+	backlogTimer := time.NewTimer(c.config.QueueBacklogMetricsInterval())
+	defer backlogTimer.Stop()
+
 	for {
 
 		select {
@@ -276,7 +328,11 @@ func (c *ControllerImpl) shardManagementPump() {
 			c.doShutdown()
 			return
 		case <-acquireTicker.C:
-			c.acquireShards()
+			c.acquireShards(nil)
+			c.unloadIdleShards()
+		case <-backlogTimer.C:
+			c.reportQueueBacklogs(backlogReporter)
+			backlogTimer.Reset(c.config.QueueBacklogMetricsInterval())
 		case changedEvent := <-c.membershipUpdateCh:
 			c.metricsScope.IncCounter(metrics.MembershipChangedCounter)
 
@@ -284,12 +340,14 @@ func (c *ControllerImpl) shardManagementPump() {
 				tag.NumberProcessed(len(changedEvent.HostsAdded)),
 				tag.NumberDeleted(len(changedEvent.HostsRemoved)),
 				tag.Number(int64(len(changedEvent.HostsUpdated))))
-			c.acquireShards()
+			c.acquireShards(decodeOwnershipHints(changedEvent.HostsRemoved))
 		}
 	}
 }
 
-func (c *ControllerImpl) acquireShards() {
+// acquireShards looks up owner of every shard and acquires the ones owned by this host.
+// Shards from prioritizedShardIDs are looked up first, in the given order.
+func (c *ControllerImpl) acquireShards(prioritizedShardIDs []int32) {
 	c.metricsScope.IncCounter(metrics.AcquireShardsCounter)
 	sw := c.metricsScope.StartTimer(metrics.AcquireShardsLatency)
 	defer sw.Stop()
@@ -314,13 +372,20 @@ func (c *ControllerImpl) acquireShards() {
 					} else {
 						if info.Identity() == c.GetHostInfo().Identity() {
 							ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-							if _, err := c.GetEngineForShard(ctx, shardID); err != nil {
+							if err := c.acquireShard(ctx, shardID); err != nil {
 								c.metricsScope.IncCounter(metrics.GetEngineForShardErrorCounter)
 								c.logger.Error("Unable to create history shard context", tag.Error(err), tag.OperationFailed, tag.ShardID(shardID))
 							}
 							cancel()
+						} else {
+							c.forgetIdleShard(shardID)
+							if c.isShardLoaded(shardID) {
+								// Ownership moved to another host (e.g. the shard was pinned elsewhere),
+								// drain and unload our copy so the new owner can acquire it.
+								c.logger.Info("Unloading shard owned by another host", tag.ShardID(shardID), tag.Address(info.GetAddress()))
+								c.CloseShardByID(shardID)
+							}
 						}
-						// TODO: If we're _not_ the owner for this shard, and we have it loaded, we should unload it.
 					}
 				}
 			}
@@ -328,8 +393,18 @@ func (c *ControllerImpl) acquireShards() {
 	}
 
 	// Submit tasks to the channel.
-LoopSubmit:
+	submitted := make(map[int32]struct{}, len(prioritizedShardIDs))
+	shardIDs := make([]int32, 0, int(c.config.NumberOfShards)+len(prioritizedShardIDs))
+	shardIDs = append(shardIDs, prioritizedShardIDs...)
 	for shardID := int32(1); shardID <= c.config.NumberOfShards; shardID++ {
+		shardIDs = append(shardIDs, shardID)
+	}
+LoopSubmit:
+	for _, shardID := range shardIDs {
+		if _, ok := submitted[shardID]; ok || shardID < 1 || shardID > c.config.NumberOfShards {
+			continue
+		}
+		submitted[shardID] = struct{}{}
 		select {
 		case <-c.shutdownCh:
 			break LoopSubmit
@@ -344,14 +419,134 @@ LoopSubmit:
 	c.metricsScope.UpdateGauge(metrics.NumShardsGauge, float64(c.NumShards()))
 }
 
-func (c *ControllerImpl) doShutdown() {
-	c.logger.Info("", tag.LifeCycleStopping)
+// PublishOwnershipHints publishes shards owned by this host along with their ack levels via membership,
+// so that hosts taking over these shards after this host leaves the ring can acquire the busiest shards first.
+func (c *ControllerImpl) PublishOwnershipHints() {
+	if !c.config.EnableShardOwnershipHints() {
+		return
+	}
+
+	c.RLock()
+	hints := make([]ownershipHint, 0, len(c.historyShards))
+	for _, shard := range c.historyShards {
+		if hint, ok := shard.getOwnershipHint(); ok {
+			hints = append(hints, hint)
+		}
+	}
+	c.RUnlock()
+
+	value, err := encodeOwnershipHints(hints, membership.LabelValueMaxSize)
+	if err != nil {
+		c.logger.Error("Unable to encode shard ownership hints", tag.Error(err))
+		return
+	}
+	if err := c.GetMembershipMonitor().SetLabel(ownershipHintsLabel, value); err != nil {
+		c.logger.Error("Unable to publish shard ownership hints", tag.Error(err))
+		return
+	}
+	c.logger.Info("Published shard ownership hints", tag.Number(int64(len(hints))))
+}
+
+// unloadIdleShards unloads the shards which had no API requests or writes for ShardIdleUnloadTimeout and have no
+// pending tasks. Draining the shard checkpoints its ack levels. It's reloaded by the next request routed to it, or
+// by the acquire loop when its next timer is about to fire.
+func (c *ControllerImpl) unloadIdleShards() {
+	idleTimeout := c.config.ShardIdleUnloadTimeout()
+	if idleTimeout <= 0 {
+		return
+	}
+
+	c.RLock()
+	shards := make([]*ContextImpl, 0, len(c.historyShards))
+	for _, shard := range c.historyShards {
+		shards = append(shards, shard)
+	}
+	c.RUnlock()
+
+	for _, shard := range shards {
+		now := c.GetTimeSource().Now()
+		nextTimer, ok := shard.getIdleNextTimer(now, idleTimeout)
+		if !ok {
+			continue
+		}
+		var reloadTime time.Time
+		if !nextTimer.IsZero() {
+			// the acquire loop runs every AcquireShardInterval, reload the shard on the run before the timer is due
+			reloadTime = nextTimer.Add(-c.config.AcquireShardInterval())
+			if !reloadTime.After(now) {
+				continue
+			}
+		}
+
+		c.Lock()
+		if c.historyShards[shard.shardID] != shard {
+			c.Unlock()
+			continue
+		}
+		c.idleShards[shard.shardID] = reloadTime
+		c.Unlock()
+
+		shard.logger.Info("Unloading idle shard", tag.Timestamp(reloadTime))
+		c.CloseShardByID(shard.shardID)
+		c.metricsScope.IncCounter(metrics.ShardContextIdleUnloadedCounter)
+	}
+}
+
+// isShardIdle returns true if the shard was unloaded while idle and isn't due to be reloaded at now
+func (c *ControllerImpl) isShardIdle(shardID int32, now time.Time) bool {
+	c.RLock()
+	defer c.RUnlock()
+	reloadTime, ok := c.idleShards[shardID]
+	return ok && (reloadTime.IsZero() || now.Before(reloadTime))
+}
+
+func (c *ControllerImpl) forgetIdleShard(shardID int32) {
 	c.Lock()
 	defer c.Unlock()
-	for _, shard := range c.historyShards {
-		shard.stop()
+	delete(c.idleShards, shardID)
+}
+
+func (c *ControllerImpl) reportQueueBacklogs(reporter *queueBacklogReporter) {
+	now := time.Now().UTC()
+
+	c.RLock()
+	backlogs := make(map[int32]queueBacklogs, len(c.historyShards))
+	for shardID, shard := range c.historyShards {
+		if shardBacklogs, ok := shard.getQueueBacklogs(now); ok {
+			backlogs[shardID] = shardBacklogs
+		}
 	}
+	c.RUnlock()
+
+	reporter.report(now, backlogs)
+}
+
+func (c *ControllerImpl) doShutdown() {
+	c.logger.Info("", tag.LifeCycleStopping)
+	c.Lock()
+	historyShards := c.historyShards
 	c.historyShards = nil
+	c.Unlock()
+
+	// the shards are drained without holding the controller lock, so that lookups don't wait for the drain
+	drainTimeout := c.config.ShardDrainTimeout()
+	var wg sync.WaitGroup
+	for _, shard := range historyShards {
+		wg.Add(1)
+		go func(shard *ContextImpl) {
+			defer wg.Done()
+			shard.drain(drainTimeout)
+			shard.stop()
+		}(shard)
+	}
+	wg.Wait()
+}
+
+func (c *ControllerImpl) isShardLoaded(shardID int32) bool {
+	c.RLock()
+	defer c.RUnlock()
+	_, ok := c.historyShards[shardID]
+	return ok
 }
 
 func (c *ControllerImpl) NumShards() int {
@@ -372,10 +567,6 @@ func (c *ControllerImpl) ShardIDs() []int32 {
 }
 
 func IsShardOwnershipLostError(err error) bool {
-	switch err.(type) {
-	case *persistence.ShardOwnershipLostError:
-		return true
-	}
-
-	return false
+	var ownershipLostErr *persistence.ShardOwnershipLostError
+	return errors.As(err, &ownershipLostErr)
 }
@@ -98,8 +98,9 @@ func (s *controllerSuite) SetupTest() {
 
 	s.logger = s.mockResource.Logger
 	s.config = tests.NewDynamicConfig()
+	s.config.ShardDrainTimeout = dynamicconfig.GetDurationPropertyFn(0)
 
-	s.shardController = NewController(s.mockResource, s.mockEngineFactory, s.config)
+	s.shardController = NewController(s.mockResource, s.mockEngineFactory, s.config, NewRangeLeaseProvider())
 }
 
 func (s *controllerSuite) TearDownTest() {
@@ -167,6 +168,8 @@ func (s *controllerSuite) TestAcquireShardSuccess() {
 					},
 					ClusterReplicationLevel: map[string]int64{},
 					ReplicationDlqAckLevel:  map[string]int64{},
+					TransferFailoverLevels:  map[string]*persistencespb.TransferFailoverLevel{},
+					TimerFailoverLevels:     map[string]*persistencespb.TimerFailoverLevel{},
 				},
 				PreviousRangeID: 5,
 			}).Return(nil)
@@ -179,7 +182,7 @@ func (s *controllerSuite) TestAcquireShardSuccess() {
 	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
 	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
 	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
-	s.shardController.acquireShards()
+	s.shardController.acquireShards(nil)
 	count := 0
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -190,6 +193,52 @@ func (s *controllerSuite) TestAcquireShardSuccess() {
 	s.Equal(2, count)
 }
 
+func (s *controllerSuite) TestAcquireShards_UnloadsShardOwnedByAnotherHost() {
+	numShards := int32(2)
+	s.config.NumberOfShards = numShards
+
+	historyEngines := make(map[int32]*MockEngine)
+	for shardID := int32(1); shardID <= numShards; shardID++ {
+		mockEngine := NewMockEngine(s.controller)
+		historyEngines[shardID] = mockEngine
+		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+	}
+
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
+	s.shardController.acquireShards(nil)
+	s.Equal(int(numShards), s.shardController.NumShards())
+
+	// shard 1 is pinned to another host, shard 2 stays here
+	s.mockShardManager.EXPECT().UpdateShard(gomock.Any()).Return(nil).AnyTimes()
+	historyEngines[1].EXPECT().Stop()
+	s.mockServiceResolver.EXPECT().Lookup(convert.Int32ToString(1)).Return(membership.NewHostInfo("another-host", nil), nil)
+	s.mockServiceResolver.EXPECT().Lookup(convert.Int32ToString(2)).Return(s.hostInfo, nil)
+	s.shardController.acquireShards(nil)
+
+	s.Equal([]int32{2}, s.shardController.ShardIDs())
+}
+
+func (s *controllerSuite) TestAcquireShards_SkipsIdleShards() {
+	s.config.NumberOfShards = 1
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
+
+	// unloaded while idle without timers, stays unloaded
+	s.shardController.idleShards[1] = time.Time{}
+	s.mockServiceResolver.EXPECT().Lookup(convert.Int32ToString(1)).Return(s.hostInfo, nil)
+	s.shardController.acquireShards(nil)
+	s.Equal(0, s.shardController.NumShards())
+
+	// reloaded once its next timer is about to fire
+	s.shardController.idleShards[1] = time.Now().Add(-time.Second)
+	s.setupMocksForAcquireShard(1, NewMockEngine(s.controller), 5, 6)
+	s.shardController.acquireShards(nil)
+	s.Equal(1, s.shardController.NumShards())
+	s.Empty(s.shardController.idleShards)
+}
+
 func (s *controllerSuite) TestAcquireShardsConcurrently() {
 	numShards := int32(10)
 	s.config.NumberOfShards = numShards
@@ -254,6 +303,8 @@ func (s *controllerSuite) TestAcquireShardsConcurrently() {
 					},
 					ClusterReplicationLevel: map[string]int64{},
 					ReplicationDlqAckLevel:  map[string]int64{},
+					TransferFailoverLevels:  map[string]*persistencespb.TransferFailoverLevel{},
+					TimerFailoverLevels:     map[string]*persistencespb.TimerFailoverLevel{},
 				},
 				PreviousRangeID: 5,
 			}).Return(nil)
@@ -266,7 +317,7 @@ func (s *controllerSuite) TestAcquireShardsConcurrently() {
 	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
 	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
 	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
-	s.shardController.acquireShards()
+	s.shardController.acquireShards(nil)
 	count := 0
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -284,7 +335,7 @@ func (s *controllerSuite) TestAcquireShardLookupFailure() {
 		s.mockServiceResolver.EXPECT().Lookup(convert.Int32ToString(shardID)).Return(nil, errors.New("ring failure"))
 	}
 
-	s.shardController.acquireShards()
+	s.shardController.acquireShards(nil)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	for shardID := int32(1); shardID <= numShards; shardID++ {
@@ -350,6 +401,8 @@ func (s *controllerSuite) TestAcquireShardRenewSuccess() {
 				},
 				ClusterReplicationLevel: map[string]int64{},
 				ReplicationDlqAckLevel:  map[string]int64{},
+				TransferFailoverLevels:  map[string]*persistencespb.TransferFailoverLevel{},
+				TimerFailoverLevels:     map[string]*persistencespb.TimerFailoverLevel{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil)
@@ -358,12 +411,12 @@ func (s *controllerSuite) TestAcquireShardRenewSuccess() {
 	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
 	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
 	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
-	s.shardController.acquireShards()
+	s.shardController.acquireShards(nil)
 
 	for shardID := int32(1); shardID <= numShards; shardID++ {
 		s.mockServiceResolver.EXPECT().Lookup(convert.Int32ToString(shardID)).Return(s.hostInfo, nil)
 	}
-	s.shardController.acquireShards()
+	s.shardController.acquireShards(nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -429,6 +482,8 @@ func (s *controllerSuite) TestAcquireShardRenewLookupFailed() {
 				},
 				ClusterReplicationLevel: map[string]int64{},
 				ReplicationDlqAckLevel:  map[string]int64{},
+				TransferFailoverLevels:  map[string]*persistencespb.TransferFailoverLevel{},
+				TimerFailoverLevels:     map[string]*persistencespb.TimerFailoverLevel{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil)
@@ -437,12 +492,12 @@ func (s *controllerSuite) TestAcquireShardRenewLookupFailed() {
 	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
 	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
 	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
-	s.shardController.acquireShards()
+	s.shardController.acquireShards(nil)
 
 	for shardID := int32(1); shardID <= numShards; shardID++ {
 		s.mockServiceResolver.EXPECT().Lookup(convert.Int32ToString(shardID)).Return(nil, errors.New("ring failure"))
 	}
-	s.shardController.acquireShards()
+	s.shardController.acquireShards(nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -454,7 +509,7 @@ func (s *controllerSuite) TestAcquireShardRenewLookupFailed() {
 func (s *controllerSuite) TestHistoryEngineClosed() {
 	numShards := int32(4)
 	s.config.NumberOfShards = numShards
-	s.shardController = NewController(s.mockResource, s.mockEngineFactory, s.config)
+	s.shardController = NewController(s.mockResource, s.mockEngineFactory, s.config, NewRangeLeaseProvider())
 	historyEngines := make(map[int32]*MockEngine)
 	for shardID := int32(1); shardID <= numShards; shardID++ {
 		mockEngine := NewMockEngine(s.controller)
@@ -488,6 +543,8 @@ func (s *controllerSuite) TestHistoryEngineClosed() {
 
 	workerWG.Wait()
 
+	// ack levels are checkpointed when shards are drained
+	s.mockShardManager.EXPECT().UpdateShard(gomock.Any()).Return(nil).AnyTimes()
 	differentHostInfo := membership.NewHostInfo("another-host", nil)
 	for shardID := int32(1); shardID <= 2; shardID++ {
 		mockEngine := historyEngines[shardID]
@@ -545,7 +602,7 @@ func (s *controllerSuite) TestHistoryEngineClosed() {
 func (s *controllerSuite) TestShardControllerClosed() {
 	numShards := int32(4)
 	s.config.NumberOfShards = numShards
-	s.shardController = NewController(s.mockResource, s.mockEngineFactory, s.config)
+	s.shardController = NewController(s.mockResource, s.mockEngineFactory, s.config, NewRangeLeaseProvider())
 	historyEngines := make(map[int32]*MockEngine)
 	for shardID := int32(1); shardID <= numShards; shardID++ {
 		mockEngine := NewMockEngine(s.controller)
@@ -584,6 +641,8 @@ func (s *controllerSuite) TestShardControllerClosed() {
 	}
 
 	s.mockServiceResolver.EXPECT().RemoveListener(shardControllerMembershipUpdateListenerName).Return(nil).AnyTimes()
+	// ack levels are checkpointed when shards are drained
+	s.mockShardManager.EXPECT().UpdateShard(gomock.Any()).Return(nil).AnyTimes()
 	for shardID := int32(1); shardID <= numShards; shardID++ {
 		mockEngine := historyEngines[shardID]
 		mockEngine.EXPECT().Stop()
@@ -649,6 +708,8 @@ func (s *controllerSuite) setupMocksForAcquireShard(shardID int32, mockEngine *M
 			},
 			ClusterReplicationLevel: map[string]int64{},
 			ReplicationDlqAckLevel:  map[string]int64{},
+			TransferFailoverLevels:  map[string]*persistencespb.TransferFailoverLevel{},
+			TimerFailoverLevels:     map[string]*persistencespb.TimerFailoverLevel{},
 		},
 		PreviousRangeID: currentRangeID,
 	}).Return(nil)
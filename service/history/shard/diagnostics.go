@@ -0,0 +1,95 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+type (
+	// Diagnostics attributes Go runtime resources of the history host to a shard, so a shard starving the
+	// others of goroutines, persistence capacity or cache memory can be told apart while the host is running
+	Diagnostics struct {
+		// all fields are accessed atomically
+		goroutines       int64
+		persistenceCalls int64
+	}
+
+	// DiagnosticsSnapshot is the resource usage of a shard at the time it was taken, served as JSON by the
+	// debug endpoint
+	DiagnosticsSnapshot struct {
+		ShardID                     int32 `json:"shardId"`
+		Goroutines                  int64 `json:"goroutines"`
+		OutstandingPersistenceCalls int64 `json:"outstandingPersistenceCalls"`
+		EventsCacheBytes            int64 `json:"eventsCacheBytes"`
+	}
+)
+
+// Go runs fn in a goroutine counted against the shard until fn returns
+func (d *Diagnostics) Go(fn func()) {
+	atomic.AddInt64(&d.goroutines, 1)
+	go func() {
+		defer atomic.AddInt64(&d.goroutines, -1)
+		fn()
+	}()
+}
+
+// startPersistenceCall counts a persistence call against the shard until the returned func is called
+func (d *Diagnostics) startPersistenceCall() func() {
+	atomic.AddInt64(&d.persistenceCalls, 1)
+	return func() {
+		atomic.AddInt64(&d.persistenceCalls, -1)
+	}
+}
+
+func (s *ContextImpl) GetDiagnostics() *Diagnostics {
+	// constant from initialization, no need for locks
+	return s.diagnostics
+}
+
+func (s *ContextImpl) diagnosticsSnapshot() DiagnosticsSnapshot {
+	return DiagnosticsSnapshot{
+		ShardID:                     s.shardID,
+		Goroutines:                  atomic.LoadInt64(&s.diagnostics.goroutines),
+		OutstandingPersistenceCalls: atomic.LoadInt64(&s.diagnostics.persistenceCalls),
+		EventsCacheBytes:            s.eventsCache.Bytes(),
+	}
+}
+
+// Diagnostics returns the resource usage of the shards owned by this host, ordered by shard ID
+func (c *ControllerImpl) Diagnostics() []DiagnosticsSnapshot {
+	c.RLock()
+	snapshots := make([]DiagnosticsSnapshot, 0, len(c.historyShards))
+	for _, shard := range c.historyShards {
+		snapshots = append(snapshots, shard.diagnosticsSnapshot())
+	}
+	c.RUnlock()
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].ShardID < snapshots[j].ShardID
+	})
+	return snapshots
+}
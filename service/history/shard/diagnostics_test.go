@@ -0,0 +1,55 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics(t *testing.T) {
+	diagnostics := &Diagnostics{}
+
+	done := diagnostics.startPersistenceCall()
+	require.Equal(t, int64(1), atomic.LoadInt64(&diagnostics.persistenceCalls))
+	done()
+	require.Equal(t, int64(0), atomic.LoadInt64(&diagnostics.persistenceCalls))
+
+	startedCh := make(chan struct{})
+	stopCh := make(chan struct{})
+	diagnostics.Go(func() {
+		close(startedCh)
+		<-stopCh
+	})
+	<-startedCh
+	require.Equal(t, int64(1), atomic.LoadInt64(&diagnostics.goroutines))
+	close(stopCh)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&diagnostics.goroutines) == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -86,5 +86,9 @@ type (
 		NotifyNewTimerTasks(tasks []tasks.Task)
 		NotifyNewVisibilityTasks(tasks []tasks.Task)
 		NotifyNewReplicationTasks(tasks []tasks.Task)
+
+		// DrainQueues moves the in-memory ack levels of the active transfer, visibility and timer queues to the
+		// shard, and returns true once all their tasks up to transferLevel and timers up to drainTime are completed.
+		DrainQueues(transferLevel int64, drainTime time.Time) bool
 	}
 )
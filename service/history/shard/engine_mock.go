@@ -96,6 +96,20 @@ func (mr *MockEngineMockRecorder) DescribeWorkflowExecution(ctx, request interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).DescribeWorkflowExecution), ctx, request)
 }
 
+// DrainQueues mocks base method.
+func (m *MockEngine) DrainQueues(transferLevel int64, drainTime time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainQueues", transferLevel, drainTime)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DrainQueues indicates an expected call of DrainQueues.
+func (mr *MockEngineMockRecorder) DrainQueues(transferLevel, drainTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainQueues", reflect.TypeOf((*MockEngine)(nil).DrainQueues), transferLevel, drainTime)
+}
+
 // GenerateLastHistoryReplicationTasks mocks base method.
 func (m *MockEngine) GenerateLastHistoryReplicationTasks(ctx context.Context, request *historyservice.GenerateLastHistoryReplicationTasksRequest) (*historyservice.GenerateLastHistoryReplicationTasksResponse, error) {
 	m.ctrl.T.Helper()
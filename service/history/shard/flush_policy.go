@@ -0,0 +1,103 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"time"
+)
+
+const (
+	// idleShardUpdatePressure is the fraction of the dirty updates and progress at risk thresholds below which
+	// the shard is considered idle
+	idleShardUpdatePressure = 0.1
+)
+
+// shardUpdateIntervalLocked returns the interval after the last flush at which dirty shardInfo is flushed again.
+// It's ShardUpdateMinInterval unless the dirty updates or progress at risk thresholds are enabled, in which case
+// it shrinks as the shard goes past the thresholds and grows to ShardUpdateIdleInterval while it's far below them.
+func (s *ContextImpl) shardUpdateIntervalLocked() time.Duration {
+	minInterval := s.config.ShardUpdateMinInterval()
+	updatesThreshold := s.config.ShardUpdateDirtyUpdatesThreshold()
+	progressThreshold := s.config.ShardUpdateProgressAtRiskThreshold()
+	if updatesThreshold <= 0 && progressThreshold <= 0 {
+		return minInterval
+	}
+
+	pressure := 0.0
+	if updatesThreshold > 0 {
+		pressure = float64(s.dirtyUpdates) / float64(updatesThreshold)
+	}
+	if progressThreshold > 0 {
+		if progressPressure := float64(s.taskProgressAtRiskLocked()) / float64(progressThreshold); progressPressure > pressure {
+			pressure = progressPressure
+		}
+	}
+	return adaptiveShardUpdateInterval(
+		minInterval,
+		s.config.ShardUpdateFastInterval(),
+		s.config.ShardUpdateIdleInterval(),
+		pressure,
+	)
+}
+
+// adaptiveShardUpdateInterval divides minInterval by pressure, the ratio of shard dirtiness to its threshold, once
+// it's at least 1, but not below fastInterval. Below idleShardUpdatePressure it's idleInterval if that's longer.
+func adaptiveShardUpdateInterval(
+	minInterval time.Duration,
+	fastInterval time.Duration,
+	idleInterval time.Duration,
+	pressure float64,
+) time.Duration {
+	switch {
+	case pressure >= 1:
+		interval := time.Duration(float64(minInterval) / pressure)
+		if interval < fastInterval {
+			return fastInterval
+		}
+		return interval
+	case pressure < idleShardUpdatePressure && idleInterval > minInterval:
+		return idleInterval
+	default:
+		return minInterval
+	}
+}
+
+// taskProgressAtRiskLocked returns the number of task IDs acked since shardInfo was last persisted, which are
+// processed again if the shard is reloaded before the next flush
+func (s *ContextImpl) taskProgressAtRiskLocked() int64 {
+	progress := s.taskAckLevelsLocked() - s.flushedTaskAckLevels
+	if progress < 0 {
+		return 0
+	}
+	return progress
+}
+
+// taskAckLevelsLocked sums the ack levels of the queues keyed by task ID
+func (s *ContextImpl) taskAckLevelsLocked() int64 {
+	return s.shardInfo.TransferAckLevel +
+		s.shardInfo.VisibilityAckLevel +
+		s.shardInfo.TieredStorageAckLevel +
+		s.shardInfo.ReplicationAckLevel
+}
@@ -0,0 +1,47 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveShardUpdateInterval(t *testing.T) {
+	minInterval := 5 * time.Minute
+	fastInterval := 5 * time.Second
+	idleInterval := 30 * time.Minute
+
+	require.Equal(t, minInterval, adaptiveShardUpdateInterval(minInterval, fastInterval, idleInterval, 0.5))
+	require.Equal(t, minInterval, adaptiveShardUpdateInterval(minInterval, fastInterval, idleInterval, 1))
+	require.Equal(t, time.Minute, adaptiveShardUpdateInterval(minInterval, fastInterval, idleInterval, 5))
+	require.Equal(t, fastInterval, adaptiveShardUpdateInterval(minInterval, fastInterval, idleInterval, 1000))
+
+	// idle shards are flushed less often only if the idle interval is longer
+	require.Equal(t, idleInterval, adaptiveShardUpdateInterval(minInterval, fastInterval, idleInterval, 0.01))
+	require.Equal(t, minInterval, adaptiveShardUpdateInterval(minInterval, fastInterval, 0, 0.01))
+}
@@ -0,0 +1,103 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+)
+
+var (
+	maxTimerTaskTimestamp = time.Unix(0, math.MaxInt64).UTC()
+)
+
+// markActivity records that the shard served an API request or allocated task IDs for a write
+func (s *ContextImpl) markActivity() {
+	atomic.StoreInt64(&s.lastActivity, s.GetTimeSource().Now().UnixNano())
+}
+
+func (s *ContextImpl) getLastActivity() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&s.lastActivity)).UTC()
+}
+
+// getIdleNextTimer returns true if the shard had no activity for idleTimeout, its immediate queues are caught up
+// and its next timer isn't due within idleTimeout, so it can be unloaded. It also returns the time the next timer
+// is due, or zero time if the shard has no timers.
+func (s *ContextImpl) getIdleNextTimer(now time.Time, idleTimeout time.Duration) (time.Time, bool) {
+	if now.Sub(s.getLastActivity()) < idleTimeout {
+		return time.Time{}, false
+	}
+	// the replication task processors of the shard pull tasks from the remote clusters, which is no activity of
+	// the shard but stops while it is unloaded
+	if s.hasRemoteClusters() {
+		return time.Time{}, false
+	}
+
+	backlogs, ok := s.getQueueBacklogs(now)
+	if !ok {
+		return time.Time{}, false
+	}
+	for scope, backlog := range backlogs {
+		// timer backlog only measures the age of the ack level, pending timers are checked below
+		if scope != metrics.TimerQueueProcessorScope && backlog.Backlog > 0 {
+			return time.Time{}, false
+		}
+	}
+
+	resp, err := s.executionManager.GetTimerTasks(&persistence.GetTimerTasksRequest{
+		ShardID:      s.shardID,
+		MinTimestamp: s.GetTimerAckLevel(),
+		MaxTimestamp: maxTimerTaskTimestamp,
+		BatchSize:    1,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to read next timer task of idle shard", tag.Error(err))
+		return time.Time{}, false
+	}
+	if len(resp.Tasks) == 0 {
+		return time.Time{}, true
+	}
+	nextTimer := resp.Tasks[0].GetVisibilityTime()
+	if nextTimer.Before(now.Add(idleTimeout)) {
+		return time.Time{}, false
+	}
+	return nextTimer, true
+}
+
+// hasRemoteClusters returns true if there are enabled remote clusters which replicate to the shard
+func (s *ContextImpl) hasRemoteClusters() bool {
+	currentCluster := s.GetClusterMetadata().GetCurrentClusterName()
+	for clusterName, info := range s.GetClusterMetadata().GetAllClusterInfo() {
+		if info.Enabled && clusterName != currentCluster {
+			return true
+		}
+	}
+	return false
+}
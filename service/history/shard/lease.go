@@ -0,0 +1,72 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"context"
+)
+
+type (
+	// LeaseProvider grants exclusive ownership leases of history shards. Shard ownership is always fenced by
+	// the range ID in the shard table, a lease provider backed by a lock service (e.g. etcd, Consul or
+	// ZooKeeper) is consulted before the range is acquired, and losing the lease closes the shard right away
+	// instead of waiting for the next persistence write to fail.
+	LeaseProvider interface {
+		// AcquireLease blocks until owner holds the lease of the shard, or ctx is done. Failing to acquire
+		// the lease should return a serviceerror.Unavailable, so that shard acquisition retries it.
+		AcquireLease(ctx context.Context, shardID int32, owner string) (Lease, error)
+	}
+
+	// Lease is an ownership lease of a history shard.
+	Lease interface {
+		// Lost returns a channel that is closed once the lease is no longer held, including after Release.
+		// A nil channel means the lease is only lost through range ID fencing.
+		Lost() <-chan struct{}
+		// Release gives up the lease, it's called once when the shard is stopped.
+		Release()
+	}
+
+	rangeLeaseProvider struct{}
+	rangeLease         struct{}
+)
+
+var _ LeaseProvider = (*rangeLeaseProvider)(nil)
+var _ Lease = (*rangeLease)(nil)
+
+// NewRangeLeaseProvider returns the default LeaseProvider, which grants every lease immediately and leaves
+// ownership to range ID fencing in the shard table.
+func NewRangeLeaseProvider() LeaseProvider {
+	return &rangeLeaseProvider{}
+}
+
+func (p *rangeLeaseProvider) AcquireLease(_ context.Context, _ int32, _ string) (Lease, error) {
+	return &rangeLease{}, nil
+}
+
+func (l *rangeLease) Lost() <-chan struct{} {
+	return nil
+}
+
+func (l *rangeLease) Release() {}
@@ -0,0 +1,138 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"sync"
+)
+
+type (
+	// LifecycleState is a shard state reported to lifecycle observers
+	LifecycleState int
+
+	// LifecycleEvent describes a state transition of a shard owned by this host
+	LifecycleEvent struct {
+		ShardID int32
+		// RangeID is the range ID of the shard at the transition, zero if shard info is not loaded yet
+		RangeID int64
+		Owner   string
+		State   LifecycleState
+	}
+
+	// LifecycleObserver is notified of shard state transitions. It is called with the shard lock held,
+	// so it must return quickly and must not call back into the shard.
+	LifecycleObserver func(event LifecycleEvent)
+
+	// LifecycleObservers are subscribed to the shard controller before it acquires any shard
+	LifecycleObservers []LifecycleObserver
+
+	lifecycleObservers struct {
+		sync.RWMutex
+		nextID    int
+		observers map[int]LifecycleObserver
+	}
+)
+
+const (
+	LifecycleStateUnspecified LifecycleState = iota
+	// LifecycleStateAcquiring means the shard is loading or renewing its range ID and doesn't serve requests
+	LifecycleStateAcquiring
+	// LifecycleStateAcquired means the shard owns its range ID and serves requests
+	LifecycleStateAcquired
+	// LifecycleStateLost means the shard got an unknown persistence error and will be re-acquired,
+	// anything cached from it may be stale
+	LifecycleStateLost
+	// LifecycleStateDraining means the shard is being unloaded and doesn't accept new requests
+	LifecycleStateDraining
+	// LifecycleStateStopping means the shard lost its ownership and is about to be stopped
+	LifecycleStateStopping
+	// LifecycleStateStopped is the final state of a shard
+	LifecycleStateStopped
+)
+
+func (s LifecycleState) String() string {
+	switch s {
+	case LifecycleStateAcquiring:
+		return "Acquiring"
+	case LifecycleStateAcquired:
+		return "Acquired"
+	case LifecycleStateLost:
+		return "Lost"
+	case LifecycleStateDraining:
+		return "Draining"
+	case LifecycleStateStopping:
+		return "Stopping"
+	case LifecycleStateStopped:
+		return "Stopped"
+	default:
+		return "Unspecified"
+	}
+}
+
+func newLifecycleObservers() *lifecycleObservers {
+	return &lifecycleObservers{
+		observers: make(map[int]LifecycleObserver),
+	}
+}
+
+// add subscribes observer and returns a function to unsubscribe it
+func (o *lifecycleObservers) add(observer LifecycleObserver) func() {
+	o.Lock()
+	defer o.Unlock()
+
+	id := o.nextID
+	o.nextID++
+	o.observers[id] = observer
+	return func() {
+		o.Lock()
+		defer o.Unlock()
+		delete(o.observers, id)
+	}
+}
+
+func (o *lifecycleObservers) notify(event LifecycleEvent) {
+	o.RLock()
+	defer o.RUnlock()
+
+	for _, observer := range o.observers {
+		observer(event)
+	}
+}
+
+// notifyLifecycleLocked reports the shard state to lifecycle observers, must be called with the shard lock held
+func (s *ContextImpl) notifyLifecycleLocked(state LifecycleState) {
+	if s.observers == nil {
+		return
+	}
+	event := LifecycleEvent{
+		ShardID: s.shardID,
+		Owner:   s.GetHostInfo().Identity(),
+		State:   state,
+	}
+	if s.shardInfo != nil {
+		event.RangeID = s.getRangeIDLocked()
+	}
+	s.observers.notify(event)
+}
@@ -0,0 +1,138 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"time"
+
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+)
+
+type lockOperation string
+
+// Operations acquiring the shard lock, used to tag lock metrics and slow lock logs so the source of lock
+// contention on a shard can be told apart
+const (
+	lockOperationGetEngine               lockOperation = "GetEngine"
+	lockOperationGenerateTaskIDs         lockOperation = "GenerateTaskIDs"
+	lockOperationUpdateAckLevel          lockOperation = "UpdateAckLevel"
+	lockOperationUpdateFailoverLevel     lockOperation = "UpdateFailoverLevel"
+	lockOperationUpdateNamespaceVersion  lockOperation = "UpdateNamespaceNotificationVersion"
+	lockOperationUpdateTimerMaxReadLevel lockOperation = "UpdateTimerMaxReadLevel"
+	lockOperationGetWorkflow             lockOperation = "GetWorkflowExecution"
+	lockOperationCreateWorkflow          lockOperation = "CreateWorkflowExecution"
+	lockOperationUpdateWorkflow          lockOperation = "UpdateWorkflowExecution"
+	lockOperationConflictResolve         lockOperation = "ConflictResolveWorkflowExecution"
+	lockOperationAddTasks                lockOperation = "AddTasks"
+	lockOperationAppendHistoryEvents     lockOperation = "AppendHistoryEvents"
+	lockOperationDeleteWorkflow          lockOperation = "DeleteWorkflowExecution"
+	lockOperationErrorByState            lockOperation = "ErrorByState"
+	lockOperationRenewRange              lockOperation = "RenewRange"
+	lockOperationFlushShardInfo          lockOperation = "FlushShardInfo"
+	lockOperationOwnershipHeartbeat      lockOperation = "OwnershipHeartbeat"
+	lockOperationAssertOwnership         lockOperation = "AssertOwnership"
+	lockOperationGetLastUpdatedTime      lockOperation = "GetLastUpdatedTime"
+	lockOperationLifecycle               lockOperation = "Lifecycle"
+	lockOperationAcquireShard            lockOperation = "AcquireShard"
+	lockOperationQueueBacklog            lockOperation = "QueueBacklog"
+	lockOperationOwnershipHints          lockOperation = "OwnershipHints"
+	lockOperationWarmup                  lockOperation = "Warmup"
+	lockOperationTesting                 lockOperation = "Testing"
+)
+
+var lockOperations = []lockOperation{
+	lockOperationGetEngine,
+	lockOperationGenerateTaskIDs,
+	lockOperationUpdateAckLevel,
+	lockOperationUpdateFailoverLevel,
+	lockOperationUpdateNamespaceVersion,
+	lockOperationUpdateTimerMaxReadLevel,
+	lockOperationGetWorkflow,
+	lockOperationCreateWorkflow,
+	lockOperationUpdateWorkflow,
+	lockOperationConflictResolve,
+	lockOperationAddTasks,
+	lockOperationAppendHistoryEvents,
+	lockOperationDeleteWorkflow,
+	lockOperationErrorByState,
+	lockOperationRenewRange,
+	lockOperationFlushShardInfo,
+	lockOperationOwnershipHeartbeat,
+	lockOperationAssertOwnership,
+	lockOperationGetLastUpdatedTime,
+	lockOperationLifecycle,
+	lockOperationAcquireShard,
+	lockOperationQueueBacklog,
+	lockOperationOwnershipHints,
+	lockOperationWarmup,
+	lockOperationTesting,
+}
+
+// newLockScopes creates the metrics scope of every lock operation up front, as the shard lock is taken on every
+// workflow write and tagging a scope allocates
+func newLockScopes(metricsClient metrics.Client) map[lockOperation]metrics.Scope {
+	scopes := make(map[lockOperation]metrics.Scope, len(lockOperations))
+	for _, op := range lockOperations {
+		scopes[op] = metricsClient.Scope(metrics.ShardInfoScope, metrics.LockOperationTag(string(op)))
+	}
+	return scopes
+}
+
+func (s *ContextImpl) lockScope(op lockOperation) metrics.Scope {
+	if scope, ok := s.lockScopes[op]; ok {
+		return scope
+	}
+	return s.metricsClient.Scope(metrics.ShardInfoScope, metrics.LockOperationTag(string(op)))
+}
+
+// recordLockWait records how long op waited for the shard lock, and logs it if it exceeds the slow lock threshold
+func (s *ContextImpl) recordLockWait(scope metrics.Scope, op lockOperation, wait time.Duration) {
+	scope.RecordTimer(metrics.LockLatency, wait)
+
+	threshold := s.config.ShardLockSlowThreshold()
+	if threshold <= 0 || wait < threshold {
+		return
+	}
+	s.throttledLogger.Warn("Slow shard lock acquisition",
+		tag.NewStringTag("lock-operation", string(op)),
+		tag.NewDurationTag("lock-wait", wait),
+	)
+}
+
+// recordLockHold records how long op held the shard lock for writing, and logs it if it exceeds the slow lock
+// threshold
+func (s *ContextImpl) recordLockHold(op lockOperation, hold time.Duration) {
+	s.lockScope(op).RecordTimer(metrics.LockHoldLatency, hold)
+
+	threshold := s.config.ShardLockSlowThreshold()
+	if threshold <= 0 || hold < threshold {
+		return
+	}
+	s.throttledLogger.Warn("Slow shard lock release",
+		tag.NewStringTag("lock-operation", string(op)),
+		tag.NewDurationTag("lock-hold", hold),
+	)
+}
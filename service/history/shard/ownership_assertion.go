@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"math/rand"
+	"sync/atomic"
+
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+)
+
+// maybeAssertOwnership verifies that the persisted range ID of the shard is still the one the shard writes with,
+// before a sample of the writes and before the first write after a transient persistence error. A shard whose
+// range was taken over by another host is stopped instead of serving requests next to the new owner.
+func (s *ContextImpl) maybeAssertOwnership() error {
+	rate := s.config.ShardOwnershipAssertionRate()
+	if rate <= 0 {
+		return nil
+	}
+	afterError := atomic.CompareAndSwapInt32(&s.assertOwnershipPending, 1, 0)
+	if !afterError && rand.Float64() >= rate {
+		return nil
+	}
+
+	rangeID := s.getFenceRangeID()
+
+	resp, err := s.GetShardManager().GetOrCreateShard(&persistence.GetOrCreateShardRequest{
+		ShardID: s.shardID,
+	})
+	if err != nil {
+		// the write is still fenced by the range ID, assert the ownership on the next one
+		s.logger.Warn("Failed to assert shard ownership", tag.Error(err))
+		atomic.StoreInt32(&s.assertOwnershipPending, 1)
+		return nil
+	}
+	persistedRangeID := resp.ShardInfo.GetRangeId()
+	if persistedRangeID <= rangeID {
+		return nil
+	}
+
+	s.wLock(lockOperationAssertOwnership)
+	defer s.wUnlock()
+	// the shard renewed its own range in the meantime
+	if s.getFenceRangeID() != rangeID {
+		return nil
+	}
+	s.metricsClient.IncCounter(metrics.ShardInfoScope, metrics.ShardOwnershipAssertionFailedCounter)
+	s.logger.Error("Shard ownership assertion failed, the shard was acquired by another host",
+		tag.ShardRangeID(persistedRangeID),
+		tag.PreviousShardRangeID(rangeID),
+	)
+	s.transitionLocked(contextRequestStop)
+	return &persistence.ShardOwnershipLostError{
+		ShardID: s.shardID,
+		Msg:     "shard was acquired by another host",
+	}
+}
+
+// markOwnershipUnknown makes the next write assert the shard ownership if err is transient.
+func (s *ContextImpl) markOwnershipUnknown(err error) {
+	if common.IsPersistenceTransientError(err) {
+		atomic.StoreInt32(&s.assertOwnershipPending, 1)
+	}
+}
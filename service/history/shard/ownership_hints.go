@@ -0,0 +1,109 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"encoding/json"
+	"sort"
+
+	"go.temporal.io/server/common/membership"
+)
+
+const (
+	// ownershipHintsLabel is the membership label used by a departing host to publish the shards it owned
+	ownershipHintsLabel = "shardOwnershipHints"
+)
+
+type (
+	// ownershipHint describes a shard owned by a departing host
+	ownershipHint struct {
+		ShardID          int32 `json:"s"`
+		TransferAckLevel int64 `json:"a"`
+		// TransferBacklog is the number of transfer task IDs between ack level and max read level,
+		// which is used as a measure of how busy the shard is
+		TransferBacklog int64 `json:"b"`
+	}
+)
+
+// getOwnershipHint returns ownership hint for this shard, or false if the shard is not acquired
+func (s *ContextImpl) getOwnershipHint() (ownershipHint, bool) {
+	s.rLock(lockOperationOwnershipHints)
+	defer s.rUnlock()
+
+	if s.state != contextStateAcquired {
+		return ownershipHint{}, false
+	}
+	return ownershipHint{
+		ShardID:          s.shardID,
+		TransferAckLevel: s.shardInfo.TransferAckLevel,
+		TransferBacklog:  s.GetTransferMaxReadLevel() - s.shardInfo.TransferAckLevel,
+	}, true
+}
+
+// encodeOwnershipHints sorts hints by backlog, busiest first, and encodes as many of them
+// as fit into maxSize bytes
+func encodeOwnershipHints(hints []ownershipHint, maxSize int) (string, error) {
+	sort.SliceStable(hints, func(i, j int) bool {
+		return hints[i].TransferBacklog > hints[j].TransferBacklog
+	})
+
+	for len(hints) > 0 {
+		data, err := json.Marshal(hints)
+		if err != nil {
+			return "", err
+		}
+		if len(data) <= maxSize {
+			return string(data), nil
+		}
+		// drop least busy shards proportionally to the excess size
+		hints = hints[:len(hints)*maxSize/len(data)]
+	}
+	return "", nil
+}
+
+// decodeOwnershipHints returns IDs of shards published by departed hosts, busiest first
+func decodeOwnershipHints(hosts []*membership.HostInfo) []int32 {
+	var hints []ownershipHint
+	for _, host := range hosts {
+		value, ok := host.Label(ownershipHintsLabel)
+		if !ok || value == "" {
+			continue
+		}
+		var hostHints []ownershipHint
+		if err := json.Unmarshal([]byte(value), &hostHints); err != nil {
+			continue
+		}
+		hints = append(hints, hostHints...)
+	}
+
+	sort.SliceStable(hints, func(i, j int) bool {
+		return hints[i].TransferBacklog > hints[j].TransferBacklog
+	})
+	shardIDs := make([]int32, len(hints))
+	for i, hint := range hints {
+		shardIDs[i] = hint.ShardID
+	}
+	return shardIDs
+}
@@ -0,0 +1,68 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/membership"
+)
+
+func TestOwnershipHints(t *testing.T) {
+	hints := []ownershipHint{
+		{ShardID: 1, TransferAckLevel: 100, TransferBacklog: 5},
+		{ShardID: 2, TransferAckLevel: 200, TransferBacklog: 50},
+		{ShardID: 3, TransferAckLevel: 300, TransferBacklog: 0},
+	}
+	value, err := encodeOwnershipHints(hints, membership.LabelValueMaxSize)
+	require.NoError(t, err)
+
+	hosts := []*membership.HostInfo{
+		membership.NewHostInfo("127.0.0.1:7234", map[string]string{ownershipHintsLabel: value}),
+		membership.NewHostInfo("127.0.0.1:7235", map[string]string{ownershipHintsLabel: `[{"s":4,"a":400,"b":20}]`}),
+		membership.NewHostInfo("127.0.0.1:7236", nil),
+		membership.NewHostInfo("127.0.0.1:7237", map[string]string{ownershipHintsLabel: "invalid"}),
+	}
+	require.Equal(t, []int32{2, 4, 1, 3}, decodeOwnershipHints(hosts))
+}
+
+func TestOwnershipHints_Truncated(t *testing.T) {
+	var hints []ownershipHint
+	for shardID := int32(1); shardID <= 1000; shardID++ {
+		hints = append(hints, ownershipHint{ShardID: shardID, TransferAckLevel: 1 << 40, TransferBacklog: int64(shardID)})
+	}
+	value, err := encodeOwnershipHints(hints, 512)
+	require.NoError(t, err)
+	require.True(t, len(value) <= 512)
+
+	shardIDs := decodeOwnershipHints([]*membership.HostInfo{
+		membership.NewHostInfo("127.0.0.1:7234", map[string]string{ownershipHintsLabel: value}),
+	})
+	require.NotEmpty(t, shardIDs)
+	// the busiest shards are kept
+	require.Equal(t, int32(1000), shardIDs[0])
+}
@@ -0,0 +1,174 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/quotas"
+	"go.temporal.io/server/service/history/configs"
+)
+
+const (
+	// namespaceLimiterIdleTimeout is how long a namespace limiter is kept without writes. An idle limiter
+	// has long refilled its burst, so dropping and recreating it doesn't change the limit.
+	namespaceLimiterIdleTimeout = time.Minute
+)
+
+type (
+	// persistenceRateLimiter applies backpressure to workflow writes of a shard, so that a few hot
+	// workflows or namespaces can't saturate the persistence partition serving the whole shard
+	persistenceRateLimiter struct {
+		shardID         int32
+		shardMaxQPS     dynamicconfig.IntPropertyFnWithShardIDFilter
+		namespaceMaxQPS dynamicconfig.IntPropertyFnWithNamespaceFilter
+		timeSource      clock.TimeSource
+
+		// limiters are created once their limit is enabled, so that they start with a non-zero rate
+		sync.Mutex
+		shardLimiter      quotas.RateLimiter
+		namespaceLimiters map[namespace.Name]*namespaceLimiter
+		lastEviction      time.Time
+	}
+
+	namespaceLimiter struct {
+		quotas.RateLimiter
+		lastUsed time.Time
+	}
+
+	// persistenceToken is the rate limit token of a transaction of shard writes, see WithPersistenceToken
+	persistenceToken struct {
+		taken int32
+	}
+
+	persistenceTokenKey struct{}
+)
+
+var (
+	// ErrPersistenceRateLimited is returned when a shard rejects a workflow write to protect persistence
+	ErrPersistenceRateLimited = serviceerror.NewResourceExhausted("shard persistence rate limit exceeded")
+)
+
+// WithPersistenceToken returns a ctx whose shard writes share one persistence rate limit token: the first
+// write takes it and the following writes and retries don't take any, so a transaction isn't rejected
+// after some of its writes went through. ctx is returned as is if it already carries a token.
+func WithPersistenceToken(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(persistenceTokenKey{}).(*persistenceToken); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, persistenceTokenKey{}, &persistenceToken{})
+}
+
+func newPersistenceRateLimiter(shardID int32, config *configs.Config, timeSource clock.TimeSource) *persistenceRateLimiter {
+	return &persistenceRateLimiter{
+		shardID:         shardID,
+		shardMaxQPS:     config.ShardPersistenceMaxQPS,
+		namespaceMaxQPS: config.ShardPersistenceNamespaceMaxQPS,
+		timeSource:      timeSource,
+
+		namespaceLimiters: make(map[namespace.Name]*namespaceLimiter),
+		lastEviction:      timeSource.Now(),
+	}
+}
+
+// allow returns ErrPersistenceRateLimited if the write should be rejected. Limits of 0 or less are unlimited.
+// An empty namespace name is only subject to the shard limit. Writes of a ctx whose persistence token was
+// already taken are always allowed.
+func (r *persistenceRateLimiter) allow(ctx context.Context, namespaceName namespace.Name) error {
+	token, _ := ctx.Value(persistenceTokenKey{}).(*persistenceToken)
+	if token != nil && atomic.LoadInt32(&token.taken) == 1 {
+		return nil
+	}
+
+	if namespaceName != "" && r.namespaceMaxQPS(namespaceName.String()) > 0 {
+		if !r.getOrCreateNamespaceLimiter(namespaceName).Allow() {
+			return ErrPersistenceRateLimited
+		}
+	}
+	if r.shardMaxQPS(r.shardID) > 0 {
+		if !r.getOrCreateShardLimiter().Allow() {
+			return ErrPersistenceRateLimited
+		}
+	}
+
+	if token != nil {
+		atomic.StoreInt32(&token.taken, 1)
+	}
+	return nil
+}
+
+func (r *persistenceRateLimiter) getOrCreateShardLimiter() quotas.RateLimiter {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.shardLimiter == nil {
+		r.shardLimiter = quotas.NewDefaultIncomingRateLimiter(
+			func() float64 { return float64(r.shardMaxQPS(r.shardID)) },
+		)
+	}
+	return r.shardLimiter
+}
+
+func (r *persistenceRateLimiter) getOrCreateNamespaceLimiter(namespaceName namespace.Name) quotas.RateLimiter {
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.timeSource.Now()
+	r.evictIdleLimitersLocked(now)
+
+	limiter, ok := r.namespaceLimiters[namespaceName]
+	if !ok {
+		limiter = &namespaceLimiter{
+			RateLimiter: quotas.NewDefaultIncomingRateLimiter(
+				func() float64 { return float64(r.namespaceMaxQPS(namespaceName.String())) },
+			),
+		}
+		r.namespaceLimiters[namespaceName] = limiter
+	}
+	limiter.lastUsed = now
+	return limiter
+}
+
+// evictIdleLimitersLocked drops the namespace limiters idle for namespaceLimiterIdleTimeout, at most once
+// per timeout so that the scan is amortized over the writes
+func (r *persistenceRateLimiter) evictIdleLimitersLocked(now time.Time) {
+	if now.Sub(r.lastEviction) < namespaceLimiterIdleTimeout {
+		return
+	}
+	r.lastEviction = now
+	for namespaceName, limiter := range r.namespaceLimiters {
+		if now.Sub(limiter.lastUsed) >= namespaceLimiterIdleTimeout {
+			delete(r.namespaceLimiters, namespaceName)
+		}
+	}
+}
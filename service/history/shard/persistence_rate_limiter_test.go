@@ -0,0 +1,110 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/service/history/tests"
+)
+
+func TestPersistenceRateLimiter_Unlimited(t *testing.T) {
+	limiter := newPersistenceRateLimiter(1, tests.NewDynamicConfig(), clock.NewRealTimeSource())
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, limiter.allow(context.Background(), "test-namespace"))
+	}
+}
+
+func TestPersistenceRateLimiter_ShardLimit(t *testing.T) {
+	config := tests.NewDynamicConfig()
+	config.ShardPersistenceMaxQPS = func(shardID int32) int { return 1 }
+	limiter := newPersistenceRateLimiter(1, config, clock.NewRealTimeSource())
+
+	// default incoming burst is twice the rate
+	require.NoError(t, limiter.allow(context.Background(), "test-namespace"))
+	require.NoError(t, limiter.allow(context.Background(), ""))
+	require.Equal(t, ErrPersistenceRateLimited, limiter.allow(context.Background(), "test-namespace"))
+}
+
+func TestPersistenceRateLimiter_NamespaceLimit(t *testing.T) {
+	config := tests.NewDynamicConfig()
+	config.ShardPersistenceNamespaceMaxQPS = func(namespaceName string) int {
+		if namespaceName == "hot-namespace" {
+			return 1
+		}
+		return 0
+	}
+	limiter := newPersistenceRateLimiter(1, config, clock.NewRealTimeSource())
+
+	hot := namespace.Name("hot-namespace")
+	require.NoError(t, limiter.allow(context.Background(), hot))
+	require.NoError(t, limiter.allow(context.Background(), hot))
+	require.Equal(t, ErrPersistenceRateLimited, limiter.allow(context.Background(), hot))
+	// other namespaces are not affected
+	require.NoError(t, limiter.allow(context.Background(), "other-namespace"))
+}
+
+func TestPersistenceRateLimiter_OneTokenPerTransaction(t *testing.T) {
+	config := tests.NewDynamicConfig()
+	config.ShardPersistenceMaxQPS = func(shardID int32) int { return 1 }
+	limiter := newPersistenceRateLimiter(1, config, clock.NewRealTimeSource())
+
+	ctx := WithPersistenceToken(context.Background())
+	require.Equal(t, ctx, WithPersistenceToken(ctx))
+	require.NoError(t, limiter.allow(ctx, "test-namespace"))
+	require.NoError(t, limiter.allow(context.Background(), "test-namespace"))
+	require.Equal(t, ErrPersistenceRateLimited, limiter.allow(context.Background(), "test-namespace"))
+	// the transaction already has its token
+	require.NoError(t, limiter.allow(ctx, "test-namespace"))
+
+	// a rejected first write doesn't take the token of its transaction
+	ctx = WithPersistenceToken(context.Background())
+	require.Equal(t, ErrPersistenceRateLimited, limiter.allow(ctx, "test-namespace"))
+	require.Equal(t, ErrPersistenceRateLimited, limiter.allow(ctx, "test-namespace"))
+}
+
+func TestPersistenceRateLimiter_EvictIdleNamespaceLimiters(t *testing.T) {
+	config := tests.NewDynamicConfig()
+	config.ShardPersistenceNamespaceMaxQPS = func(namespaceName string) int { return 100 }
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	limiter := newPersistenceRateLimiter(1, config, timeSource)
+
+	require.NoError(t, limiter.allow(context.Background(), "idle-namespace"))
+	require.NoError(t, limiter.allow(context.Background(), "active-namespace"))
+	require.Len(t, limiter.namespaceLimiters, 2)
+
+	timeSource.Update(timeSource.Now().Add(namespaceLimiterIdleTimeout / 2))
+	require.NoError(t, limiter.allow(context.Background(), "active-namespace"))
+	timeSource.Update(timeSource.Now().Add(namespaceLimiterIdleTimeout / 2))
+	require.NoError(t, limiter.allow(context.Background(), "active-namespace"))
+	require.Len(t, limiter.namespaceLimiters, 1)
+	require.Contains(t, limiter.namespaceLimiters, namespace.Name("active-namespace"))
+}
@@ -0,0 +1,135 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"time"
+
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+type (
+	// queueBacklog is the processing state of one history queue category of a shard.
+	// Task IDs are allocated across all categories, so immediate queues are measured by the range of
+	// task IDs between ack level and max read level, which bounds but doesn't count their tasks.
+	// The timer queue is measured in seconds its ack level lags behind now.
+	queueBacklog struct {
+		AckLevel int64
+		Backlog  int64
+	}
+
+	// queueBacklogs is a snapshot of queue backlogs keyed by queue processor metrics scope
+	queueBacklogs map[int]queueBacklog
+
+	// queueBacklogReporter aggregates shard queue backlogs into per-host gauges, which are designed
+	// to be consumed as external metrics by autoscalers such as Kubernetes HPA or KEDA
+	queueBacklogReporter struct {
+		metricsClient metrics.Client
+		lastReport    time.Time
+		lastBacklogs  map[int32]queueBacklogs
+	}
+)
+
+// getQueueBacklogs returns backlog of each queue category of this shard, or false if the shard is not acquired
+func (s *ContextImpl) getQueueBacklogs(now time.Time) (queueBacklogs, bool) {
+	s.rLock(lockOperationQueueBacklog)
+	defer s.rUnlock()
+
+	if s.state != contextStateAcquired {
+		return nil, false
+	}
+
+	backlogs := queueBacklogs{
+		metrics.TransferQueueProcessorScope:      s.immediateQueueBacklog(s.shardInfo.TransferAckLevel),
+		metrics.VisibilityQueueProcessorScope:    s.immediateQueueBacklog(s.shardInfo.VisibilityAckLevel),
+		metrics.TieredStorageQueueProcessorScope: s.immediateQueueBacklog(s.shardInfo.TieredStorageAckLevel),
+		metrics.ReplicatorQueueProcessorScope:    s.immediateQueueBacklog(s.shardInfo.ReplicationAckLevel),
+	}
+	timerAckLevel := timestamp.TimeValue(s.shardInfo.TimerAckLevelTime)
+	backlogs[metrics.TimerQueueProcessorScope] = queueBacklog{
+		AckLevel: timerAckLevel.Unix(),
+		Backlog:  nonNegative(int64(now.Sub(timerAckLevel) / time.Second)),
+	}
+	return backlogs, true
+}
+
+func (s *ContextImpl) immediateQueueBacklog(ackLevel int64) queueBacklog {
+	return queueBacklog{
+		AckLevel: ackLevel,
+		Backlog:  nonNegative(s.GetTransferMaxReadLevel() - ackLevel),
+	}
+}
+
+func newQueueBacklogReporter(metricsClient metrics.Client) *queueBacklogReporter {
+	return &queueBacklogReporter{
+		metricsClient: metricsClient,
+	}
+}
+
+// report emits total backlog and processing rate of each queue category across the given shards.
+// Processing rate is the ack level progress per second since the previous report, summed over
+// shards that were owned by this host at both reports.
+func (r *queueBacklogReporter) report(now time.Time, backlogs map[int32]queueBacklogs) {
+	totalBacklog := make(map[int]int64)
+	totalProgress := make(map[int]int64)
+	for shardID, shardBacklogs := range backlogs {
+		lastShardBacklogs := r.lastBacklogs[shardID]
+		for scope, backlog := range shardBacklogs {
+			totalBacklog[scope] += backlog.Backlog
+			if last, ok := lastShardBacklogs[scope]; ok {
+				totalProgress[scope] += nonNegative(backlog.AckLevel - last.AckLevel)
+			}
+		}
+	}
+
+	elapsed := now.Sub(r.lastReport).Seconds()
+	for scope, backlog := range totalBacklog {
+		backlogGauge, rateGauge := queueBacklogGauges(scope)
+		r.metricsClient.UpdateGauge(scope, backlogGauge, float64(backlog))
+		if !r.lastReport.IsZero() && elapsed > 0 {
+			r.metricsClient.UpdateGauge(scope, rateGauge, float64(totalProgress[scope])/elapsed)
+		}
+	}
+
+	r.lastReport = now
+	r.lastBacklogs = backlogs
+}
+
+// queueBacklogGauges returns the backlog and processing rate gauges of the queue of given metrics scope,
+// which differ by the unit the queue is measured in
+func queueBacklogGauges(scope int) (int, int) {
+	if scope == metrics.TimerQueueProcessorScope {
+		return metrics.QueueTimerLagGauge, metrics.QueueTimerProcessingRateGauge
+	}
+	return metrics.QueueTaskIDBacklogGauge, metrics.QueueTaskIDProcessingRateGauge
+}
+
+func nonNegative(value int64) int64 {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"go.temporal.io/server/common/metrics"
+)
+
+func TestQueueBacklogReporter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metricsClient := metrics.NewMockClient(ctrl)
+	reporter := newQueueBacklogReporter(metricsClient)
+	scope := metrics.TransferQueueProcessorScope
+	now := time.Now().UTC()
+
+	// first report has no previous snapshot to compute processing rate from
+	metricsClient.EXPECT().UpdateGauge(scope, metrics.QueueTaskIDBacklogGauge, float64(30))
+	reporter.report(now, map[int32]queueBacklogs{
+		1: {scope: {AckLevel: 100, Backlog: 10}},
+		2: {scope: {AckLevel: 200, Backlog: 20}},
+	})
+
+	// shard 2 moved away and shard 3 was acquired, so only shard 1 contributes to the rate
+	metricsClient.EXPECT().UpdateGauge(scope, metrics.QueueTaskIDBacklogGauge, float64(15))
+	metricsClient.EXPECT().UpdateGauge(scope, metrics.QueueTaskIDProcessingRateGauge, float64(5))
+	reporter.report(now.Add(10*time.Second), map[int32]queueBacklogs{
+		1: {scope: {AckLevel: 150, Backlog: 5}},
+		3: {scope: {AckLevel: 900, Backlog: 10}},
+	})
+}
+
+func TestQueueBacklogReporter_TimerQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metricsClient := metrics.NewMockClient(ctrl)
+	reporter := newQueueBacklogReporter(metricsClient)
+	now := time.Now().UTC()
+
+	// timer queue is measured in seconds, so it is reported under its own gauges
+	metricsClient.EXPECT().UpdateGauge(metrics.TimerQueueProcessorScope, metrics.QueueTimerLagGauge, float64(60))
+	metricsClient.EXPECT().UpdateGauge(metrics.TransferQueueProcessorScope, metrics.QueueTaskIDBacklogGauge, float64(10))
+	reporter.report(now, map[int32]queueBacklogs{
+		1: {
+			metrics.TimerQueueProcessorScope:    {AckLevel: now.Unix() - 60, Backlog: 60},
+			metrics.TransferQueueProcessorScope: {AckLevel: 100, Backlog: 10},
+		},
+	})
+
+	metricsClient.EXPECT().UpdateGauge(metrics.TimerQueueProcessorScope, metrics.QueueTimerLagGauge, float64(50))
+	metricsClient.EXPECT().UpdateGauge(metrics.TimerQueueProcessorScope, metrics.QueueTimerProcessingRateGauge, float64(2))
+	metricsClient.EXPECT().UpdateGauge(metrics.TransferQueueProcessorScope, metrics.QueueTaskIDBacklogGauge, float64(0))
+	metricsClient.EXPECT().UpdateGauge(metrics.TransferQueueProcessorScope, metrics.QueueTaskIDProcessingRateGauge, float64(1))
+	reporter.report(now.Add(10*time.Second), map[int32]queueBacklogs{
+		1: {
+			metrics.TimerQueueProcessorScope:    {AckLevel: now.Unix() - 40, Backlog: 50},
+			metrics.TransferQueueProcessorScope: {AckLevel: 110, Backlog: 0},
+		},
+	})
+}
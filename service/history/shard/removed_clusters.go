@@ -0,0 +1,82 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"sort"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+)
+
+// pruneRemovedClusters deletes the per cluster ack levels of clusters which are not in knownClusters from
+// shardInfo, and returns the names of the removed clusters.
+func pruneRemovedClusters(
+	shardInfo *persistencespb.ShardInfo,
+	knownClusters map[string]struct{},
+) []string {
+
+	removed := make(map[string]struct{})
+	for clusterName := range shardInfo.ClusterTransferAckLevel {
+		if _, ok := knownClusters[clusterName]; !ok {
+			delete(shardInfo.ClusterTransferAckLevel, clusterName)
+			removed[clusterName] = struct{}{}
+		}
+	}
+	for clusterName := range shardInfo.ClusterTimerAckLevel {
+		if _, ok := knownClusters[clusterName]; !ok {
+			delete(shardInfo.ClusterTimerAckLevel, clusterName)
+			removed[clusterName] = struct{}{}
+		}
+	}
+	for clusterName := range shardInfo.ClusterReplicationLevel {
+		if _, ok := knownClusters[clusterName]; !ok {
+			delete(shardInfo.ClusterReplicationLevel, clusterName)
+			removed[clusterName] = struct{}{}
+		}
+	}
+	for clusterName := range shardInfo.ReplicationDlqAckLevel {
+		if _, ok := knownClusters[clusterName]; !ok {
+			delete(shardInfo.ReplicationDlqAckLevel, clusterName)
+			removed[clusterName] = struct{}{}
+		}
+	}
+
+	removedClusters := make([]string, 0, len(removed))
+	for clusterName := range removed {
+		removedClusters = append(removedClusters, clusterName)
+	}
+	sort.Strings(removedClusters)
+	return removedClusters
+}
+
+// knownClusters returns the names of all clusters in the cluster metadata, enabled or not.
+func (s *ContextImpl) knownClusters() map[string]struct{} {
+	clusters := make(map[string]struct{})
+	for clusterName := range s.GetClusterMetadata().GetAllClusterInfo() {
+		clusters[clusterName] = struct{}{}
+	}
+	clusters[s.GetClusterMetadata().GetCurrentClusterName()] = struct{}{}
+	return clusters
+}
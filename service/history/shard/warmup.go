@@ -0,0 +1,185 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shard
+
+import (
+	"context"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"go.temporal.io/server/service/history/events"
+	"go.temporal.io/server/service/history/tasks"
+)
+
+const (
+	warmUpPageSize = 100
+)
+
+// warmUp loads the executions with pending transfer and timer tasks, i.e. the executions queue processors and
+// clients are about to touch, into the mutable state cache of the engine and their start events into the events
+// cache, right after the shard is acquired for the first time, so that the caches warm up before most requests and
+// tasks miss them. It's best effort and bounded by ShardWarmUpMaxExecutions and ShardWarmUpTimeout.
+func (s *ContextImpl) warmUp(engine Engine) {
+	maxExecutions := s.config.ShardWarmUpMaxExecutions()
+	if maxExecutions <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShardWarmUpTimeout())
+	defer cancel()
+
+	startTime := s.GetTimeSource().Now()
+	executions := s.getPendingTaskExecutions(ctx, maxExecutions)
+	loaded := 0
+	for _, key := range executions {
+		if ctx.Err() != nil || s.isStopping() {
+			break
+		}
+		resp, err := engine.GetMutableState(ctx, &historyservice.GetMutableStateRequest{
+			NamespaceId: key.NamespaceID,
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: key.WorkflowID,
+				RunId:      key.RunID,
+			},
+		})
+		if err != nil {
+			continue
+		}
+		loaded++
+		s.warmUpStartEvent(key, resp)
+	}
+
+	metricsClient := s.GetMetricsClient()
+	metricsClient.RecordTimer(metrics.ShardInfoScope, metrics.ShardContextWarmUpLatency, s.GetTimeSource().Now().Sub(startTime))
+	metricsClient.RecordDistribution(metrics.ShardInfoScope, metrics.ShardContextWarmUpExecutions, loaded)
+	s.logger.Info("Warmed up shard caches", tag.Counter(loaded))
+}
+
+func (s *ContextImpl) isStopping() bool {
+	s.rLock(lockOperationWarmup)
+	defer s.rUnlock()
+	return s.state >= contextStateStopping
+}
+
+// getPendingTaskExecutions returns up to maxExecutions distinct executions with transfer tasks between the transfer
+// ack level and max read level, followed by executions with timer tasks between the timer ack level and max read level.
+func (s *ContextImpl) getPendingTaskExecutions(ctx context.Context, maxExecutions int) []definition.WorkflowKey {
+	var executions []definition.WorkflowKey
+	seen := make(map[definition.WorkflowKey]struct{})
+	add := func(tasks []tasks.Task) bool {
+		for _, task := range tasks {
+			key := definition.NewWorkflowKey(task.GetNamespaceID(), task.GetWorkflowID(), task.GetRunID())
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			executions = append(executions, key)
+			if len(executions) >= maxExecutions {
+				return false
+			}
+		}
+		return ctx.Err() == nil
+	}
+
+	transferRequest := &persistence.GetTransferTasksRequest{
+		ShardID:      s.shardID,
+		ReadLevel:    s.GetTransferAckLevel(),
+		MaxReadLevel: s.GetTransferMaxReadLevel(),
+		BatchSize:    warmUpPageSize,
+	}
+	for transferRequest.ReadLevel < transferRequest.MaxReadLevel {
+		resp, err := s.executionManager.GetTransferTasks(transferRequest)
+		if err != nil {
+			s.logger.Warn("Failed to read transfer tasks to warm up shard caches", tag.Error(err))
+			return executions
+		}
+		if !add(resp.Tasks) {
+			return executions
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		transferRequest.NextPageToken = resp.NextPageToken
+	}
+
+	timerRequest := &persistence.GetTimerTasksRequest{
+		ShardID:      s.shardID,
+		MinTimestamp: s.GetTimerAckLevel(),
+		MaxTimestamp: s.GetTimerMaxReadLevel(s.GetClusterMetadata().GetCurrentClusterName()),
+		BatchSize:    warmUpPageSize,
+	}
+	for timerRequest.MinTimestamp.Before(timerRequest.MaxTimestamp) {
+		resp, err := s.executionManager.GetTimerTasks(timerRequest)
+		if err != nil {
+			s.logger.Warn("Failed to read timer tasks to warm up shard caches", tag.Error(err))
+			return executions
+		}
+		if !add(resp.Tasks) {
+			return executions
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		timerRequest.NextPageToken = resp.NextPageToken
+	}
+	return executions
+}
+
+// warmUpStartEvent loads the start event of an execution into the events cache, it's read by most task
+// processors, e.g. to schedule retries and to close the execution.
+func (s *ContextImpl) warmUpStartEvent(key definition.WorkflowKey, resp *historyservice.GetMutableStateResponse) {
+	startVersion := common.EmptyVersion
+	if resp.GetVersionHistories() != nil {
+		versionHistory, err := versionhistory.GetCurrentVersionHistory(resp.GetVersionHistories())
+		if err != nil {
+			return
+		}
+		firstItem, err := versionhistory.GetFirstVersionHistoryItem(versionHistory)
+		if err != nil {
+			return
+		}
+		startVersion = firstItem.GetVersion()
+	}
+
+	_, _ = s.eventsCache.GetEvent(
+		events.EventKey{
+			NamespaceID: namespace.ID(key.NamespaceID),
+			WorkflowID:  key.WorkflowID,
+			RunID:       key.RunID,
+			EventID:     common.FirstEventID,
+			Version:     startVersion,
+		},
+		common.FirstEventID,
+		resp.GetCurrentBranchToken(),
+	)
+}
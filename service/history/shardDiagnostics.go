@@ -0,0 +1,61 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"go.temporal.io/server/service/history/shard"
+)
+
+// shardDiagnosticsPath serves the resource usage of the shards owned by this host on the debug endpoint,
+// which serves http.DefaultServeMux when pprof is enabled
+const shardDiagnosticsPath = "/debug/history/shards"
+
+var (
+	registerShardDiagnosticsOnce sync.Once
+	// shardDiagnosticsController holds the *shard.ControllerImpl of the last started handler, a process may
+	// run several history services in tests but the mux takes a path only once
+	shardDiagnosticsController atomic.Value
+)
+
+func registerShardDiagnostics(controller *shard.ControllerImpl) {
+	shardDiagnosticsController.Store(controller)
+	registerShardDiagnosticsOnce.Do(func() {
+		http.HandleFunc(shardDiagnosticsPath, serveShardDiagnostics)
+	})
+}
+
+func serveShardDiagnostics(w http.ResponseWriter, _ *http.Request) {
+	snapshots := []shard.DiagnosticsSnapshot{}
+	if controller, ok := shardDiagnosticsController.Load().(*shard.ControllerImpl); ok {
+		snapshots = controller.Diagnostics()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(snapshots)
+}
@@ -0,0 +1,78 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"strconv"
+
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.temporal.io/server/common/headers"
+	"go.temporal.io/server/common/namespace"
+)
+
+// validateStandbyRead checks that a workflow read of a standby namespace can be served from this cluster.
+// Staleness of the local replica is the time since the last sync with the active cluster, it is reported
+// to the caller through response header and reads exceeding the configured bound are rejected with
+// NamespaceNotActive error, so that frontend can forward them to the active cluster.
+// It only applies to the reads frontend serves to API callers, i.e. PollMutableState and DescribeWorkflowExecution.
+func (e *historyEngineImpl) validateStandbyRead(
+	ctx context.Context,
+	namespaceID namespace.ID,
+) error {
+
+	namespaceEntry, err := e.shard.GetNamespaceRegistry().GetNamespaceByID(namespaceID)
+	if err != nil {
+		return err
+	}
+	currentClusterName := e.shard.GetClusterMetadata().GetCurrentClusterName()
+	if !namespaceEntry.IsGlobalNamespace() || namespaceEntry.ActiveInCluster(currentClusterName) {
+		return nil
+	}
+	maxStaleness := e.config.StandbyReadMaxStaleness(namespaceEntry.Name().String())
+	if maxStaleness <= 0 {
+		return nil
+	}
+
+	activeClusterName := namespaceEntry.ActiveClusterName()
+	staleness := e.shard.GetTimeSource().Now().Sub(e.shard.GetCurrentTime(activeClusterName))
+	if staleness < 0 {
+		staleness = 0
+	}
+	// best effort, there is no response header outside of a gRPC call
+	_ = grpc.SetHeader(ctx, metadata.Pairs(headers.ReadStalenessHeaderName, strconv.FormatInt(staleness.Milliseconds(), 10)))
+
+	if staleness > maxStaleness {
+		return serviceerror.NewNamespaceNotActive(
+			namespaceEntry.Name().String(),
+			currentClusterName,
+			activeClusterName,
+		)
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ package history
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -48,12 +49,22 @@ import (
 
 const (
 	taskTimeout = time.Second * 10
+
+	taskProcessorResizeInterval = time.Second * 10
 )
 
 type (
 	taskProcessorOptions struct {
-		queueSize   int
-		workerCount int
+		queueSize    int
+		workerCount  func() int
+		metricsScope int
+	}
+
+	taskProcessorWorker struct {
+		// used to notify the worker to retry tasks
+		notificationCh chan struct{}
+		// closed to retire the worker when the processor shrinks
+		stopCh chan struct{}
 	}
 
 	taskInfo struct {
@@ -84,11 +95,15 @@ type (
 		timeSource    clock.TimeSource
 		retryPolicy   backoff.RetryPolicy
 		workerWG      sync.WaitGroup
+		metricsScope  int
+
+		workerCount      func() int
+		safetyController *taskProcessorSafetyController
 
-		// worker coroutines notification
-		workerNotificationChans []chan struct{}
-		// duplicate numOfWorker from config.TimerTaskWorkerCount for dynamic config works correctly
-		numOfWorker int
+		workersLock sync.Mutex
+		workers     []*taskProcessorWorker
+		// retired workers still finishing their task, they keep receiving retry notifications until they exit
+		retiringWorkers map[*taskProcessorWorker]struct{}
 	}
 )
 
@@ -116,34 +131,30 @@ func newTaskProcessor(
 	logger log.Logger,
 ) *taskProcessor {
 
-	var workerNotificationChs []chan struct{}
-	for index := 0; index < options.workerCount; index++ {
-		workerNotificationChs = append(workerNotificationChs, make(chan struct{}, 1))
-	}
-
 	base := &taskProcessor{
-		shard:                   shard,
-		cache:                   historyCache,
-		shutdownCh:              make(chan struct{}),
-		tasksCh:                 make(chan *taskInfo, options.queueSize),
-		config:                  shard.GetConfig(),
-		logger:                  logger,
-		metricsClient:           shard.GetMetricsClient(),
-		timeSource:              shard.GetTimeSource(),
-		workerNotificationChans: workerNotificationChs,
-		retryPolicy:             common.CreatePersistenceRetryPolicy(),
-		numOfWorker:             options.workerCount,
+		shard:         shard,
+		cache:         historyCache,
+		shutdownCh:    make(chan struct{}),
+		tasksCh:       make(chan *taskInfo, options.queueSize),
+		config:        shard.GetConfig(),
+		logger:        logger,
+		metricsClient: shard.GetMetricsClient(),
+		timeSource:    shard.GetTimeSource(),
+		retryPolicy:   common.CreatePersistenceRetryPolicy(),
+		metricsScope:  options.metricsScope,
+
+		workerCount:      options.workerCount,
+		safetyController: newTaskProcessorSafetyController(shard.GetConfig().TaskProcessorThrottleErrorRate),
+		retiringWorkers:  make(map[*taskProcessorWorker]struct{}),
 	}
 
 	return base
 }
 
 func (t *taskProcessor) start() {
-	for i := 0; i < t.numOfWorker; i++ {
-		t.workerWG.Add(1)
-		notificationChan := t.workerNotificationChans[i]
-		go t.taskWorker(notificationChan)
-	}
+	t.resizeWorkers(t.workerCount())
+	t.workerWG.Add(1)
+	t.shard.GetDiagnostics().Go(t.resizeLoop)
 	t.logger.Info("Task processor started.")
 }
 
@@ -155,30 +166,104 @@ func (t *taskProcessor) stop() {
 	t.logger.Info("Task processor shutdown.")
 }
 
+// resizeLoop periodically applies changes of the configured worker count, lowered by the safety controller
+// while persistence errors are elevated, without restarting the processor
+func (t *taskProcessor) resizeLoop() {
+	defer t.workerWG.Done()
+
+	ticker := time.NewTicker(taskProcessorResizeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.shutdownCh:
+			return
+		case <-ticker.C:
+			configured := t.workerCount()
+			count := t.safetyController.adjust(configured)
+			if count < configured {
+				t.metricsClient.IncCounter(t.metricsScope, metrics.TaskProcessorThrottledCounter)
+			}
+			t.resizeWorkers(count)
+		}
+	}
+}
+
+// resizeWorkers starts or retires workers until count of them are running, a retired worker finishes the task
+// it is processing first
+func (t *taskProcessor) resizeWorkers(
+	count int,
+) {
+
+	if count < 1 {
+		count = 1
+	}
+
+	t.workersLock.Lock()
+	defer t.workersLock.Unlock()
+
+	if count == len(t.workers) {
+		return
+	}
+	select {
+	case <-t.shutdownCh:
+		return
+	default:
+	}
+
+	for len(t.workers) < count {
+		worker := &taskProcessorWorker{
+			notificationCh: make(chan struct{}, 1),
+			stopCh:         make(chan struct{}),
+		}
+		t.workers = append(t.workers, worker)
+		t.workerWG.Add(1)
+		t.shard.GetDiagnostics().Go(func() { t.taskWorker(worker) })
+	}
+	for len(t.workers) > count {
+		last := len(t.workers) - 1
+		close(t.workers[last].stopCh)
+		t.retiringWorkers[t.workers[last]] = struct{}{}
+		t.workers = t.workers[:last]
+	}
+	t.metricsClient.UpdateGauge(t.metricsScope, metrics.TaskProcessorWorkerCount, float64(count))
+	t.logger.Info("Task processor resized.", tag.NewInt("worker-count", count))
+}
+
 func (t *taskProcessor) taskWorker(
-	notificationChan chan struct{},
+	worker *taskProcessorWorker,
 ) {
 	defer t.workerWG.Done()
+	defer func() {
+		t.workersLock.Lock()
+		defer t.workersLock.Unlock()
+		delete(t.retiringWorkers, worker)
+	}()
 
 	for {
 		select {
 		case <-t.shutdownCh:
 			return
+		case <-worker.stopCh:
+			return
 		case task, ok := <-t.tasksCh:
 			if !ok {
 				return
 			}
-			t.processTaskAndAck(notificationChan, task)
+			t.processTaskAndAck(worker.notificationCh, task)
 		}
 	}
 }
 
 func (t *taskProcessor) retryTasks() {
-	for _, workerNotificationChan := range t.workerNotificationChans {
-		select {
-		case workerNotificationChan <- struct{}{}:
-		default:
-		}
+	t.workersLock.Lock()
+	defer t.workersLock.Unlock()
+
+	for _, worker := range t.workers {
+		worker.notify()
+	}
+	for worker := range t.retiringWorkers {
+		worker.notify()
 	}
 }
 
@@ -194,6 +279,13 @@ func (t *taskProcessor) addTask(
 	return true
 }
 
+func (w *taskProcessorWorker) notify() {
+	select {
+	case w.notificationCh <- struct{}{}:
+	default:
+	}
+}
+
 func (t *taskProcessor) processTaskAndAck(
 	notificationChan <-chan struct{},
 	task *taskInfo,
@@ -219,6 +311,7 @@ FilterLoop:
 
 	op := func() error {
 		scope, err = t.processTaskOnce(notificationChan, task)
+		t.safetyController.record(err)
 		err := t.handleTaskError(scope, task, notificationChan, err)
 		if err != nil {
 			task.attempt++
@@ -310,6 +403,20 @@ func (t *taskProcessor) handleTaskError(
 		err = nil
 	}
 
+	// shard range is stolen and the shard is closing, the new owner will process this task,
+	// so stop retrying until this processor is shut down
+	if errors.Is(err, shard.ErrRangeStolen) {
+		scope.IncCounter(metrics.TaskRangeStolenCounter)
+		<-t.shutdownCh
+		return err
+	}
+
+	// this is a transient error, the shard is being re-acquired with a new range
+	if errors.Is(err, shard.ErrRangeRenewTimeout) {
+		scope.IncCounter(metrics.TaskRangeRenewTimeoutCounter)
+		return err
+	}
+
 	// this is a transient error
 	// TODO remove this error check special case
 	//  since the new task life cycle will not give up until task processed / verified
@@ -0,0 +1,124 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"sync"
+
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/persistence"
+)
+
+const (
+	// minimum task attempts in an interval for its persistence error rate to be acted on
+	taskProcessorSafetyMinAttempts = 20
+)
+
+type (
+	// taskProcessorSafetyController lowers the worker count of a task processor while the rate of task attempts
+	// failing with persistence errors is above the configured threshold: the count is halved every resize interval
+	// the rate stays above it, and grows back by one worker per interval once it drops
+	taskProcessorSafetyController struct {
+		errorRateThreshold dynamicconfig.FloatPropertyFn
+
+		sync.Mutex
+		attempts          int
+		persistenceErrors int
+		// current ceiling of the worker count, 0 if not throttled
+		limit int
+	}
+)
+
+func newTaskProcessorSafetyController(
+	errorRateThreshold dynamicconfig.FloatPropertyFn,
+) *taskProcessorSafetyController {
+	return &taskProcessorSafetyController{
+		errorRateThreshold: errorRateThreshold,
+	}
+}
+
+// record counts the result of one task attempt
+func (c *taskProcessorSafetyController) record(
+	err error,
+) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.attempts++
+	if isPersistenceError(err) {
+		c.persistenceErrors++
+	}
+}
+
+// adjust returns the worker count to run for the configured count, based on the attempts recorded since the
+// previous call
+func (c *taskProcessorSafetyController) adjust(
+	configured int,
+) int {
+
+	c.Lock()
+	defer c.Unlock()
+
+	attempts, persistenceErrors := c.attempts, c.persistenceErrors
+	c.attempts, c.persistenceErrors = 0, 0
+
+	threshold := c.errorRateThreshold()
+	if threshold <= 0 {
+		c.limit = 0
+		return configured
+	}
+
+	if attempts >= taskProcessorSafetyMinAttempts && float64(persistenceErrors)/float64(attempts) > threshold {
+		current := configured
+		if c.limit > 0 && c.limit < configured {
+			current = c.limit
+		}
+		c.limit = current / 2
+		if c.limit < 1 {
+			c.limit = 1
+		}
+	} else if c.limit > 0 {
+		c.limit++
+		if c.limit >= configured {
+			c.limit = 0
+		}
+	}
+
+	if c.limit > 0 && c.limit < configured {
+		return c.limit
+	}
+	return configured
+}
+
+func isPersistenceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var timeoutErr *persistence.TimeoutError
+	return common.IsPersistenceTransientError(err) || errors.As(err, &timeoutErr)
+}
@@ -36,6 +36,8 @@ import (
 	"go.temporal.io/api/serviceerror"
 
 	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
@@ -88,12 +90,11 @@ func (s *taskProcessorSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          0,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          0,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		tests.NewDynamicConfig(),
 	)
 
@@ -110,8 +111,10 @@ func (s *taskProcessorSuite) SetupTest() {
 		metricsClient: s.mockShard.GetMetricsClient(),
 	}
 	options := taskProcessorOptions{
-		queueSize:   s.mockShard.GetConfig().TimerTaskBatchSize() * s.mockShard.GetConfig().TimerTaskWorkerCount(),
-		workerCount: s.mockShard.GetConfig().TimerTaskWorkerCount(),
+		queueSize: s.mockShard.GetConfig().TimerTaskBatchSize() * s.mockShard.GetConfig().TimerTaskWorkerCount(s.mockShard.GetShardID()),
+		workerCount: func() int {
+			return s.mockShard.GetConfig().TimerTaskWorkerCount(s.mockShard.GetShardID())
+		},
 	}
 	s.taskProcessor = newTaskProcessor(options, s.mockShard, h.historyCache, s.logger)
 }
@@ -255,6 +258,54 @@ func (s *taskProcessorSuite) TestHandleTaskError_RandomErr() {
 	s.Equal(err, s.taskProcessor.handleTaskError(s.scope, taskInfo, s.notificationChan, err))
 }
 
+func (s *taskProcessorSuite) TestResizeWorkers() {
+	s.taskProcessor.resizeWorkers(3)
+	s.Len(s.taskProcessor.workers, 3)
+
+	s.taskProcessor.resizeWorkers(1)
+	s.Len(s.taskProcessor.workers, 1)
+
+	s.taskProcessor.resizeWorkers(0)
+	s.Len(s.taskProcessor.workers, 1)
+
+	close(s.taskProcessor.shutdownCh)
+	s.True(common.AwaitWaitGroup(&s.taskProcessor.workerWG, time.Second))
+	s.Empty(s.taskProcessor.retiringWorkers)
+}
+
+func (s *taskProcessorSuite) TestSafetyController() {
+	controller := newTaskProcessorSafetyController(dynamicconfig.GetFloatPropertyFn(0.5))
+	recordAttempts := func(attempts int, errorAttempts int) {
+		for i := 0; i < attempts; i++ {
+			if i < errorAttempts {
+				controller.record(serviceerror.NewUnavailable("persistence unavailable"))
+			} else {
+				controller.record(nil)
+			}
+		}
+	}
+
+	recordAttempts(taskProcessorSafetyMinAttempts, taskProcessorSafetyMinAttempts/4)
+	s.Equal(10, controller.adjust(10))
+
+	// too few attempts to act on
+	recordAttempts(taskProcessorSafetyMinAttempts-1, taskProcessorSafetyMinAttempts-1)
+	s.Equal(10, controller.adjust(10))
+
+	recordAttempts(taskProcessorSafetyMinAttempts, taskProcessorSafetyMinAttempts)
+	s.Equal(5, controller.adjust(10))
+	recordAttempts(taskProcessorSafetyMinAttempts, taskProcessorSafetyMinAttempts)
+	s.Equal(2, controller.adjust(10))
+
+	// recovers one worker per interval
+	s.Equal(3, controller.adjust(10))
+	s.Equal(4, controller.adjust(10))
+
+	// a lower configured count applies right away
+	s.Equal(3, controller.adjust(3))
+	s.Equal(3, controller.adjust(3))
+}
+
 func (t *taskForTest) GetKey() tasks.Key {
 	return t.Key
 }
@@ -0,0 +1,156 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type (
+	// CategoryType determines how tasks of a category are ordered and processed
+	CategoryType int
+
+	// Category is a category of history tasks, which is processed by its own queue.
+	// Categories are identified by their ID, which is persisted and must never change.
+	Category struct {
+		id    int32
+		cType CategoryType
+		name  string
+	}
+)
+
+const (
+	CategoryTypeUnspecified CategoryType = iota
+	// CategoryTypeImmediate tasks are ordered by task ID and processed as soon as possible
+	CategoryTypeImmediate
+	// CategoryTypeScheduled tasks are ordered by fire time and processed when they fire
+	CategoryTypeScheduled
+)
+
+const (
+	CategoryIDUnspecified int32 = iota
+	CategoryIDTransfer
+	CategoryIDTimer
+	CategoryIDReplication
+	CategoryIDVisibility
+	CategoryIDTieredStorage
+)
+
+var (
+	CategoryTransfer      = NewCategory(CategoryIDTransfer, CategoryTypeImmediate, "transfer")
+	CategoryTimer         = NewCategory(CategoryIDTimer, CategoryTypeScheduled, "timer")
+	CategoryReplication   = NewCategory(CategoryIDReplication, CategoryTypeImmediate, "replication")
+	CategoryVisibility    = NewCategory(CategoryIDVisibility, CategoryTypeImmediate, "visibility")
+	CategoryTieredStorage = NewCategory(CategoryIDTieredStorage, CategoryTypeImmediate, "tiered-storage")
+)
+
+var (
+	categoriesLock sync.RWMutex
+	categories     = map[int32]Category{
+		CategoryIDTransfer:      CategoryTransfer,
+		CategoryIDTimer:         CategoryTimer,
+		CategoryIDReplication:   CategoryReplication,
+		CategoryIDVisibility:    CategoryVisibility,
+		CategoryIDTieredStorage: CategoryTieredStorage,
+	}
+)
+
+// NewCategory creates a task category, which has to be registered with RegisterCategory before use
+func NewCategory(
+	id int32,
+	cType CategoryType,
+	name string,
+) Category {
+	return Category{
+		id:    id,
+		cType: cType,
+		name:  name,
+	}
+}
+
+func (c Category) ID() int32 {
+	return c.id
+}
+
+func (c Category) Type() CategoryType {
+	return c.cType
+}
+
+func (c Category) Name() string {
+	return c.name
+}
+
+func (c Category) String() string {
+	return fmt.Sprintf("%v(%v)", c.name, c.id)
+}
+
+// RegisterCategory registers a task category, so that shard and history engine handle it
+// along with the builtin categories. It panics if ID or name of the category is already taken,
+// and is expected to be called during server initialization.
+func RegisterCategory(category Category) {
+	categoriesLock.Lock()
+	defer categoriesLock.Unlock()
+
+	if category.id == CategoryIDUnspecified || category.cType == CategoryTypeUnspecified {
+		panic(fmt.Sprintf("task category %v has unspecified ID or type", category))
+	}
+	for _, registered := range categories {
+		if registered.id == category.id || registered.name == category.name {
+			panic(fmt.Sprintf("task category %v conflicts with registered category %v", category, registered))
+		}
+	}
+	categories[category.id] = category
+}
+
+// GetCategories returns all registered task categories ordered by ID
+func GetCategories() []Category {
+	categoriesLock.RLock()
+	defer categoriesLock.RUnlock()
+
+	result := make([]Category, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, category)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+// GetCategoryByID returns the registered task category with the given ID
+func GetCategoryByID(id int32) (Category, bool) {
+	categoriesLock.RLock()
+	defer categoriesLock.RUnlock()
+
+	category, ok := categories[id]
+	return category, ok
+}
+
+// IsBuiltinCategory returns whether the category is one of the categories known to shard info persistence
+func IsBuiltinCategory(category Category) bool {
+	return category.id >= CategoryIDTransfer && category.id <= CategoryIDTieredStorage
+}
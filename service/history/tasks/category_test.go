@@ -0,0 +1,60 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCategory(t *testing.T) {
+	category := NewCategory(100, CategoryTypeImmediate, "test-category")
+	RegisterCategory(category)
+	defer func() {
+		categoriesLock.Lock()
+		defer categoriesLock.Unlock()
+		delete(categories, category.ID())
+	}()
+
+	registered, ok := GetCategoryByID(category.ID())
+	require.True(t, ok)
+	require.Equal(t, category, registered)
+	require.False(t, IsBuiltinCategory(registered))
+
+	registeredCategories := GetCategories()
+	require.Equal(t, CategoryTransfer, registeredCategories[0])
+	require.Equal(t, category, registeredCategories[len(registeredCategories)-1])
+
+	require.Panics(t, func() {
+		RegisterCategory(NewCategory(101, CategoryTypeScheduled, "test-category"))
+	})
+	require.Panics(t, func() {
+		RegisterCategory(NewCategory(CategoryIDTimer, CategoryTypeScheduled, "another-timer"))
+	})
+	require.Panics(t, func() {
+		RegisterCategory(NewCategory(102, CategoryTypeUnspecified, "unspecified"))
+	})
+}
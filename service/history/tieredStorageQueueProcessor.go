@@ -171,7 +171,7 @@ func (t *tieredStorageQueueProcessorImpl) Start() {
 		return
 	}
 	t.queueProcessorBase.Start()
-	go t.completeTaskLoop()
+	t.shard.GetDiagnostics().Go(t.completeTaskLoop)
 }
 
 func (t *tieredStorageQueueProcessorImpl) Stop() {
@@ -235,6 +235,10 @@ func (t *tieredStorageQueueProcessorImpl) completeTask() error {
 		return nil
 	}
 
+	if err := t.shard.AssertWritable(); err != nil {
+		return err
+	}
+
 	t.metricsClient.IncCounter(metrics.TieredStorageQueueProcessorScope, metrics.TaskBatchCompleteCounter)
 
 	if lowerAckLevel < upperAckLevel {
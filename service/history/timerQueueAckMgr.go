@@ -39,6 +39,7 @@ import (
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
 )
 
@@ -88,6 +89,8 @@ type (
 		sync.Mutex
 		// outstanding timer task -> finished (true)
 		outstandingTasks map[timerKey]bool
+		// outstanding timer task -> namespace of the task, see namespaceAckLevels
+		outstandingNamespaces map[timerKey]namespace.ID
 		// timer task ack level
 		ackLevel timerKey
 		// timer task read level, used by failover
@@ -154,25 +157,26 @@ func newTimerQueueAckMgr(
 	ackLevel := timerKey{VisibilityTimestamp: minLevel}
 
 	timerQueueAckMgrImpl := &timerQueueAckMgrImpl{
-		scope:               scope,
-		isFailover:          false,
-		shard:               shard,
-		executionMgr:        shard.GetExecutionManager(),
-		metricsClient:       metricsClient,
-		logger:              logger,
-		config:              shard.GetConfig(),
-		timeNow:             timeNow,
-		updateTimerAckLevel: updateTimerAckLevel,
-		timerQueueShutdown:  func() error { return nil },
-		outstandingTasks:    make(map[timerKey]bool),
-		ackLevel:            ackLevel,
-		readLevel:           ackLevel,
-		minQueryLevel:       ackLevel.VisibilityTimestamp,
-		pageToken:           nil,
-		maxQueryLevel:       ackLevel.VisibilityTimestamp,
-		isReadFinished:      false,
-		finishedChan:        nil,
-		clusterName:         clusterName,
+		scope:                 scope,
+		isFailover:            false,
+		shard:                 shard,
+		executionMgr:          shard.GetExecutionManager(),
+		metricsClient:         metricsClient,
+		logger:                logger,
+		config:                shard.GetConfig(),
+		timeNow:               timeNow,
+		updateTimerAckLevel:   updateTimerAckLevel,
+		timerQueueShutdown:    func() error { return nil },
+		outstandingTasks:      make(map[timerKey]bool),
+		outstandingNamespaces: make(map[timerKey]namespace.ID),
+		ackLevel:              ackLevel,
+		readLevel:             ackLevel,
+		minQueryLevel:         ackLevel.VisibilityTimestamp,
+		pageToken:             nil,
+		maxQueryLevel:         ackLevel.VisibilityTimestamp,
+		isReadFinished:        false,
+		finishedChan:          nil,
+		clusterName:           clusterName,
 	}
 
 	return timerQueueAckMgrImpl
@@ -192,24 +196,25 @@ func newTimerQueueFailoverAckMgr(
 	ackLevel := timerKey{VisibilityTimestamp: minLevel}
 
 	timerQueueAckMgrImpl := &timerQueueAckMgrImpl{
-		scope:               metrics.TimerActiveQueueProcessorScope,
-		isFailover:          true,
-		shard:               shard,
-		executionMgr:        shard.GetExecutionManager(),
-		metricsClient:       metricsClient,
-		logger:              logger,
-		config:              shard.GetConfig(),
-		timeNow:             timeNow,
-		updateTimerAckLevel: updateTimerAckLevel,
-		timerQueueShutdown:  timerQueueShutdown,
-		outstandingTasks:    make(map[timerKey]bool),
-		ackLevel:            ackLevel,
-		readLevel:           ackLevel,
-		minQueryLevel:       ackLevel.VisibilityTimestamp,
-		pageToken:           nil,
-		maxQueryLevel:       maxLevel,
-		isReadFinished:      false,
-		finishedChan:        make(chan struct{}, 1),
+		scope:                 metrics.TimerActiveQueueProcessorScope,
+		isFailover:            true,
+		shard:                 shard,
+		executionMgr:          shard.GetExecutionManager(),
+		metricsClient:         metricsClient,
+		logger:                logger,
+		config:                shard.GetConfig(),
+		timeNow:               timeNow,
+		updateTimerAckLevel:   updateTimerAckLevel,
+		timerQueueShutdown:    timerQueueShutdown,
+		outstandingTasks:      make(map[timerKey]bool),
+		outstandingNamespaces: make(map[timerKey]namespace.ID),
+		ackLevel:              ackLevel,
+		readLevel:             ackLevel,
+		minQueryLevel:         ackLevel.VisibilityTimestamp,
+		pageToken:             nil,
+		maxQueryLevel:         maxLevel,
+		isReadFinished:        false,
+		finishedChan:          make(chan struct{}, 1),
 	}
 
 	return timerQueueAckMgrImpl
@@ -272,6 +277,7 @@ TaskFilterLoop:
 		t.readLevel = *timerKey
 
 		t.outstandingTasks[*timerKey] = false
+		t.outstandingNamespaces[*timerKey] = namespace.ID(task.GetNamespaceID())
 		filteredTasks = append(filteredTasks, task)
 	}
 
@@ -332,6 +338,21 @@ func (t *timerQueueAckMgrImpl) completeTimerTask(
 	t.outstandingTasks[*timerKey] = true
 }
 
+// isDrained returns true if all timer tasks up to level are loaded and completed
+func (t *timerQueueAckMgrImpl) isDrained(level time.Time) bool {
+	t.Lock()
+	defer t.Unlock()
+	if t.minQueryLevel.Before(level) {
+		return false
+	}
+	for key, acked := range t.outstandingTasks {
+		if !key.VisibilityTimestamp.After(level) && !acked {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *timerQueueAckMgrImpl) getReadLevel() timerKey {
 	t.Lock()
 	defer t.Unlock()
@@ -376,6 +397,7 @@ MoveAckLevelLoop:
 		if acked {
 			ackLevel = current
 			delete(outstandingTasks, current)
+			delete(t.outstandingNamespaces, current)
 			t.logger.Debug("Moving timer ack level", tag.AckLevel(ackLevel))
 		} else {
 			break MoveAckLevelLoop
@@ -396,7 +418,19 @@ MoveAckLevelLoop:
 		return err
 	}
 
+	var namespaceAckLevels map[namespace.ID]tasks.Key
+	if !t.isFailover && t.scope == metrics.TimerActiveQueueProcessorScope {
+		namespaceAckLevels = t.namespaceAckLevels(sequenceIDs)
+	}
+
 	t.Unlock()
+	if namespaceAckLevels != nil {
+		if err := t.shard.UpdateNamespaceAckLevels(tasks.CategoryTimer, namespaceAckLevels); err != nil {
+			t.metricsClient.IncCounter(t.scope, metrics.AckLevelUpdateFailedCounter)
+			t.logger.Error("Error updating namespace timer ack levels for shard", tag.Error(err))
+			return err
+		}
+	}
 	if err := t.updateTimerAckLevel(ackLevel); err != nil {
 		t.metricsClient.IncCounter(t.scope, metrics.AckLevelUpdateFailedCounter)
 		t.logger.Error("Error updating timer ack level for shard", tag.Error(err))
@@ -432,6 +466,24 @@ func (t *timerQueueAckMgrImpl) getTimerTasks(minTimestamp time.Time, maxTimestam
 	return response.Tasks, response.NextPageToken, nil
 }
 
+// namespaceAckLevels returns the ack level of each namespace with outstanding timers, i.e. the time preceding its
+// first timer which isn't completed, given the sorted keys of all timers loaded before the ack level is moved.
+// Namespaces without outstanding timers have completed all timers up to the read level.
+func (t *timerQueueAckMgrImpl) namespaceAckLevels(sequenceIDs timerKeys) map[namespace.ID]tasks.Key {
+	namespaceAckLevels := make(map[namespace.ID]tasks.Key)
+	for _, key := range sequenceIDs {
+		acked, ok := t.outstandingTasks[key]
+		if !ok || acked {
+			continue
+		}
+		namespaceID := t.outstandingNamespaces[key]
+		if _, ok := namespaceAckLevels[namespaceID]; !ok {
+			namespaceAckLevels[namespaceID] = tasks.Key{FireTime: key.VisibilityTimestamp.Add(-time.Nanosecond)}
+		}
+	}
+	return namespaceAckLevels
+}
+
 func (t *timerQueueAckMgrImpl) isProcessNow(expiryTime time.Time) bool {
 	if expiryTime.IsZero() { // return true, but somewhere probably have bug creating empty timerTask.
 		t.logger.Warn("Timer task has timestamp zero")
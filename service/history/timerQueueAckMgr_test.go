@@ -46,6 +46,8 @@ import (
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
 )
 
@@ -116,15 +118,13 @@ func (s *timerQueueAckMgrSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId: 1,
-				RangeId: 1,
-				ClusterTimerAckLevel: map[string]*time.Time{
-					cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtcAddSeconds(-8),
-					cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
-				}},
-		},
+		&persistencespb.ShardInfo{
+			ShardId: 1,
+			RangeId: 1,
+			ClusterTimerAckLevel: map[string]*time.Time{
+				cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtcAddSeconds(-8),
+				cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
+			}},
 		config,
 	)
 
@@ -484,15 +484,12 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Nil(lookAheadTask)
 	s.False(moreTasks)
 
-	// we are not testing shard context
-	s.mockShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	timerSequenceID1 := newTimerKey(timer1.VisibilityTimestamp, timer1.TaskID)
 	s.timerQueueAckMgr.completeTimerTask(timer1.VisibilityTimestamp, timer1.TaskID)
 	s.True(s.timerQueueAckMgr.outstandingTasks[*timerSequenceID1])
 	_ = s.timerQueueAckMgr.updateAckLevel()
 	s.Equal(timer1.VisibilityTimestamp.UnixNano(), s.mockShard.GetTimerClusterAckLevel(s.clusterName).UnixNano())
 
-	s.mockShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	timerSequenceID3 := newTimerKey(timer3.VisibilityTimestamp, timer3.TaskID)
 	s.timerQueueAckMgr.completeTimerTask(timer3.VisibilityTimestamp, timer3.TaskID)
 	s.True(s.timerQueueAckMgr.outstandingTasks[*timerSequenceID3])
@@ -500,8 +497,6 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	// ack level remains unchanged
 	s.Equal(timer1.VisibilityTimestamp.UnixNano(), s.mockShard.GetTimerClusterAckLevel(s.clusterName).UnixNano())
 
-	// we are not testing shard context
-	s.mockShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	timerSequenceID2 := newTimerKey(timer2.VisibilityTimestamp, timer2.TaskID)
 	s.timerQueueAckMgr.completeTimerTask(timer2.VisibilityTimestamp, timer2.TaskID)
 	s.True(s.timerQueueAckMgr.outstandingTasks[*timerSequenceID2])
@@ -509,6 +504,53 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Equal(timer3.VisibilityTimestamp.UnixNano(), s.mockShard.GetTimerClusterAckLevel(s.clusterName).UnixNano())
 }
 
+func (s *timerQueueAckMgrSuite) TestUpdateAckLevel_NamespaceAckLevels() {
+	s.mockShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.timerQueueAckMgr.scope = metrics.TimerActiveQueueProcessorScope
+
+	otherNamespaceID := uuid.New()
+	newTimer := func(namespaceID string, visibilityTimestamp time.Time, taskID int64) *tasks.UserTimerTask {
+		return &tasks.UserTimerTask{
+			WorkflowKey: definition.NewWorkflowKey(
+				namespaceID,
+				"some random workflow ID",
+				uuid.New(),
+			),
+			VisibilityTimestamp: visibilityTimestamp,
+			TaskID:              taskID,
+			EventID:             int64(28),
+		}
+	}
+	now := time.Now().UTC()
+	timer1 := newTimer(TestNamespaceId, now.Add(-5*time.Second), 59)
+	timer2 := newTimer(otherNamespaceID, now.Add(-4*time.Second), 60)
+	timer3 := newTimer(otherNamespaceID, now.Add(-3*time.Second), 61)
+	s.mockExecutionMgr.EXPECT().GetTimerTasks(gomock.Any()).Return(&persistence.GetTimerTasksResponse{
+		Tasks: []tasks.Task{timer1, timer2, timer3},
+	}, nil)
+	s.mockExecutionMgr.EXPECT().GetTimerTasks(gomock.Any()).Return(&persistence.GetTimerTasksResponse{}, nil)
+	_, _, _, err := s.timerQueueAckMgr.readTimerTasks()
+	s.NoError(err)
+
+	s.timerQueueAckMgr.completeTimerTask(timer1.VisibilityTimestamp, timer1.TaskID)
+	s.NoError(s.timerQueueAckMgr.updateAckLevel())
+	s.Equal(map[namespace.ID]tasks.Key{
+		namespace.ID(otherNamespaceID): {FireTime: timer2.VisibilityTimestamp.Add(-time.Nanosecond)},
+	}, s.mockShard.GetNamespaceAckLevels(tasks.CategoryTimer))
+
+	s.timerQueueAckMgr.completeTimerTask(timer3.VisibilityTimestamp, timer3.TaskID)
+	s.NoError(s.timerQueueAckMgr.updateAckLevel())
+	s.Equal(map[namespace.ID]tasks.Key{
+		namespace.ID(otherNamespaceID): {FireTime: timer2.VisibilityTimestamp.Add(-time.Nanosecond)},
+	}, s.mockShard.GetNamespaceAckLevels(tasks.CategoryTimer))
+
+	s.timerQueueAckMgr.completeTimerTask(timer2.VisibilityTimestamp, timer2.TaskID)
+	s.NoError(s.timerQueueAckMgr.updateAckLevel())
+	s.Empty(s.mockShard.GetNamespaceAckLevels(tasks.CategoryTimer))
+	s.Empty(s.timerQueueAckMgr.outstandingNamespaces)
+}
+
 func (s *timerQueueAckMgrSuite) TestReadLookAheadTask() {
 	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(s.clusterName).AnyTimes()
 	level := s.mockShard.UpdateTimerMaxReadLevel(s.clusterName)
@@ -558,15 +600,14 @@ func (s *timerQueueFailoverAckMgrSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId: 1,
-				RangeId: 1,
-				ClusterTimerAckLevel: map[string]*time.Time{
-					cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtc(),
-					cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
-				}},
-			TimerFailoverLevels: make(map[string]persistence.TimerFailoverLevel),
+		&persistencespb.ShardInfo{
+			ShardId: 1,
+			RangeId: 1,
+			ClusterTimerAckLevel: map[string]*time.Time{
+				cluster.TestCurrentClusterName:     timestamp.TimeNowPtrUtc(),
+				cluster.TestAlternativeClusterName: timestamp.TimeNowPtrUtcAddSeconds(-10),
+			},
+			TimerFailoverLevels: make(map[string]*persistencespb.TimerFailoverLevel),
 		},
 		config,
 	)
@@ -794,7 +835,6 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	timerSequenceID2 := newTimerKey(timer2.VisibilityTimestamp, timer2.TaskID)
 	s.timerQueueFailoverAckMgr.completeTimerTask(timer2.VisibilityTimestamp, timer2.TaskID)
 	s.True(s.timerQueueFailoverAckMgr.outstandingTasks[*timerSequenceID2])
-	s.mockShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	_ = s.timerQueueFailoverAckMgr.updateAckLevel()
 	select {
 	case <-s.timerQueueFailoverAckMgr.getFinishedChan():
@@ -805,7 +845,6 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	timerSequenceID3 := newTimerKey(timer3.VisibilityTimestamp, timer3.TaskID)
 	s.timerQueueFailoverAckMgr.completeTimerTask(timer3.VisibilityTimestamp, timer3.TaskID)
 	s.True(s.timerQueueFailoverAckMgr.outstandingTasks[*timerSequenceID3])
-	s.mockShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	_ = s.timerQueueFailoverAckMgr.updateAckLevel()
 	select {
 	case <-s.timerQueueFailoverAckMgr.getFinishedChan():
@@ -816,7 +855,6 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	timerSequenceID1 := newTimerKey(timer1.VisibilityTimestamp, timer1.TaskID)
 	s.timerQueueFailoverAckMgr.completeTimerTask(timer1.VisibilityTimestamp, timer1.TaskID)
 	s.True(s.timerQueueFailoverAckMgr.outstandingTasks[*timerSequenceID1])
-	s.mockShardMgr.EXPECT().UpdateShard(gomock.Any()).Return(nil)
 	_ = s.timerQueueFailoverAckMgr.updateAckLevel()
 	select {
 	case <-s.timerQueueFailoverAckMgr.getFinishedChan():
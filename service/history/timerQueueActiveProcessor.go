@@ -28,8 +28,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/pborman/uuid"
-
 	"go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -122,10 +120,9 @@ func newTimerQueueActiveProcessor(
 func newTimerQueueFailoverProcessor(
 	shard shard.Context,
 	historyService *historyEngineImpl,
-	namespaceIDs map[string]struct{},
-	standbyClusterName string,
-	minLevel time.Time,
-	maxLevel time.Time,
+	failoverUUID string,
+	failoverLevel persistence.TimerFailoverLevel,
+	failoverMsg string,
 	matchingClient matchingservice.MatchingServiceClient,
 	taskAllocator taskAllocator,
 	logger log.Logger,
@@ -136,14 +133,15 @@ func newTimerQueueFailoverProcessor(
 		// should use current cluster's time when doing namespace failover
 		return shard.GetCurrentTime(currentClusterName)
 	}
-	failoverStartTime := shard.GetTimeSource().Now()
-	failoverUUID := uuid.New()
+	namespaceIDs := failoverLevel.NamespaceIDs
+	minLevel := failoverLevel.MinLevel
+	maxLevel := failoverLevel.MaxLevel
 
 	updateShardAckLevel := func(ackLevel timerKey) error {
 		return shard.UpdateTimerFailoverLevel(
 			failoverUUID,
 			persistence.TimerFailoverLevel{
-				StartTime:    failoverStartTime,
+				StartTime:    failoverLevel.StartTime,
 				MinLevel:     minLevel,
 				CurrentLevel: ackLevel.VisibilityTimestamp,
 				MaxLevel:     maxLevel,
@@ -159,7 +157,7 @@ func newTimerQueueFailoverProcessor(
 		logger,
 		tag.ClusterName(currentClusterName),
 		tag.WorkflowNamespaceIDs(namespaceIDs),
-		tag.FailoverMsg("from: "+standbyClusterName),
+		tag.FailoverMsg(failoverMsg),
 	)
 	timerTaskFilter := func(task tasks.Task) (bool, error) {
 		return taskAllocator.verifyFailoverActiveTask(namespaceIDs, namespace.ID(task.GetNamespaceID()), task)
@@ -168,7 +166,7 @@ func newTimerQueueFailoverProcessor(
 	timerQueueAckMgr := newTimerQueueFailoverAckMgr(
 		shard,
 		historyService.metricsClient,
-		minLevel,
+		failoverLevel.CurrentLevel,
 		maxLevel,
 		timeNow,
 		updateShardAckLevel,
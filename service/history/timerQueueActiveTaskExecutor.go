@@ -104,6 +104,8 @@ func (t *timerQueueActiveTaskExecutor) execute(
 		return t.executeWorkflowBackoffTimerTask(ctx, task)
 	case *tasks.DeleteHistoryEventTask:
 		return t.executeDeleteHistoryEventTask(ctx, task)
+	case *tasks.DeleteExecutionVisibilityTimerTask:
+		return t.executeDeleteVisibilityTimerTask(ctx, task)
 	default:
 		return errUnknownTimerTask
 	}
@@ -129,7 +131,7 @@ func (t *timerQueueActiveTaskExecutor) executeUserTimerTimeoutTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, weContext, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -166,7 +168,7 @@ Loop:
 		return nil
 	}
 
-	return t.updateWorkflowExecution(weContext, mutableState, timerFired)
+	return t.updateWorkflowExecution(ctx, weContext, mutableState, timerFired)
 }
 
 func (t *timerQueueActiveTaskExecutor) executeActivityTimeoutTask(
@@ -189,7 +191,7 @@ func (t *timerQueueActiveTaskExecutor) executeActivityTimeoutTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, weContext, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -199,7 +201,8 @@ func (t *timerQueueActiveTaskExecutor) executeActivityTimeoutTask(
 
 	timerSequence := t.getTimerSequence(mutableState)
 	referenceTime := t.shard.GetTimeSource().Now()
-	updateMutableState := false
+	// write the progress of coalesced heartbeats held in memory only
+	updateMutableState := mutableState.HasCoalescedActivityProgress()
 	scheduleWorkflowTask := false
 
 	// need to clear activity heartbeat timer task mask for new activity timer task creation
@@ -275,7 +278,7 @@ Loop:
 	if !updateMutableState {
 		return nil
 	}
-	return t.updateWorkflowExecution(weContext, mutableState, scheduleWorkflowTask)
+	return t.updateWorkflowExecution(ctx, weContext, mutableState, scheduleWorkflowTask)
 }
 
 func (t *timerQueueActiveTaskExecutor) executeWorkflowTaskTimeoutTask(
@@ -298,7 +301,7 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowTaskTimeoutTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, weContext, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -333,7 +336,8 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowTaskTimeoutTask(
 		); err != nil {
 			return err
 		}
-		scheduleWorkflowTask = true
+		// a workflow task which keeps timing out, e.g. because it crashes the workers, is not retried until new events arrive
+		return t.quarantineOrRetryWorkflowTask(ctx, weContext, mutableState)
 
 	case enumspb.TIMEOUT_TYPE_SCHEDULE_TO_START:
 		if workflowTask.StartedID != common.EmptyEventID {
@@ -353,7 +357,7 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowTaskTimeoutTask(
 		scheduleWorkflowTask = true
 	}
 
-	return t.updateWorkflowExecution(weContext, mutableState, scheduleWorkflowTask)
+	return t.updateWorkflowExecution(ctx, weContext, mutableState, scheduleWorkflowTask)
 }
 
 func (t *timerQueueActiveTaskExecutor) executeWorkflowBackoffTimerTask(
@@ -376,7 +380,7 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowBackoffTimerTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, weContext, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -396,7 +400,7 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowBackoffTimerTask(
 	}
 
 	// schedule first workflow task
-	return t.updateWorkflowExecution(weContext, mutableState, true)
+	return t.updateWorkflowExecution(ctx, weContext, mutableState, true)
 }
 
 func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
@@ -419,7 +423,7 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, weContext, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -493,7 +497,7 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowTimeoutTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, weContext, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -547,7 +551,7 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowTimeoutTask(
 	if initiator == enumspb.CONTINUE_AS_NEW_INITIATOR_UNSPECIFIED {
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		return t.updateWorkflowExecution(weContext, mutableState, false)
+		return t.updateWorkflowExecution(ctx, weContext, mutableState, false)
 	}
 
 	startEvent, err := mutableState.GetStartEvent()
@@ -581,6 +585,7 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowTimeoutTask(
 	newExecutionInfo := newMutableState.GetExecutionInfo()
 	newExecutionState := newMutableState.GetExecutionState()
 	return weContext.UpdateWorkflowExecutionWithNewAsActive(
+		ctx,
 		t.shard.GetTimeSource().Now(),
 		workflow.NewContext(
 			namespace.ID(newExecutionInfo.NamespaceId),
@@ -604,6 +609,7 @@ func (t *timerQueueActiveTaskExecutor) getTimerSequence(
 }
 
 func (t *timerQueueActiveTaskExecutor) updateWorkflowExecution(
+	ctx context.Context,
 	context workflow.Context,
 	mutableState workflow.MutableState,
 	scheduleNewWorkflowTask bool,
@@ -619,7 +625,7 @@ func (t *timerQueueActiveTaskExecutor) updateWorkflowExecution(
 	}
 
 	now := t.shard.GetTimeSource().Now()
-	err = context.UpdateWorkflowExecutionAsActive(now)
+	err = context.UpdateWorkflowExecutionAsActive(ctx, now)
 	if err != nil {
 		if shard.IsShardOwnershipLostError(err) {
 			// Shard is stolen.  Stop timer processing to reduce duplicates
@@ -632,6 +638,36 @@ func (t *timerQueueActiveTaskExecutor) updateWorkflowExecution(
 	return nil
 }
 
+func (t *timerQueueActiveTaskExecutor) quarantineOrRetryWorkflowTask(
+	ctx context.Context,
+	context workflow.Context,
+	mutableState workflow.MutableState,
+) error {
+
+	namespaceEntry, err := t.shard.GetNamespaceRegistry().GetNamespaceByID(namespace.ID(mutableState.GetExecutionInfo().NamespaceId))
+	if err != nil {
+		return err
+	}
+	threshold := t.config.WorkflowTaskQuarantineThreshold(namespaceEntry.Name().String())
+	if !workflow.ShouldQuarantineWorkflow(mutableState, threshold) {
+		return t.updateWorkflowExecution(ctx, context, mutableState, true)
+	}
+	if workflow.IsWorkflowQuarantined(mutableState) {
+		return t.updateWorkflowExecution(ctx, context, mutableState, false)
+	}
+
+	reason := enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT.String()
+	if err := workflow.QuarantineWorkflow(mutableState, reason, t.shard.GetTimeSource().Now()); err != nil {
+		return err
+	}
+	if err := t.updateWorkflowExecution(ctx, context, mutableState, false); err != nil {
+		return err
+	}
+	workflow.EmitWorkflowQuarantined(mutableState, namespaceEntry.Name(), reason,
+		metrics.TimerActiveTaskWorkflowTaskTimeoutScope, t.metricsClient, t.logger)
+	return nil
+}
+
 func (t *timerQueueActiveTaskExecutor) emitTimeoutMetricScopeWithNamespaceTag(
 	namespaceID namespace.ID,
 	scope int,
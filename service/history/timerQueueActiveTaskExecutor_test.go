@@ -47,6 +47,7 @@ import (
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
@@ -113,12 +114,11 @@ func (s *timerQueueActiveTaskExecutorSuite) SetupTest() {
 	config := tests.NewDynamicConfig()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          1,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          1,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		config,
 	)
 	s.mockShard.SetEventsCacheForTesting(events.NewEventsCache(
@@ -130,6 +130,7 @@ func (s *timerQueueActiveTaskExecutorSuite) SetupTest() {
 		false,
 		s.mockShard.GetLogger(),
 		s.mockShard.GetMetricsClient(),
+		nil,
 	))
 
 	s.mockShard.Resource.TimeSource = s.timeSource
@@ -901,6 +902,64 @@ func (s *timerQueueActiveTaskExecutorSuite) TestWorkflowTaskTimeout_Fire() {
 	s.Equal(int32(2), workflowTask.Attempt)
 }
 
+func (s *timerQueueActiveTaskExecutorSuite) TestWorkflowTaskTimeout_Quarantine() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskQueueName := "some random task queue"
+
+	mutableState := workflow.TestGlobalMutableState(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			Attempt:     1,
+			NamespaceId: s.namespaceID.String(),
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:        &commonpb.WorkflowType{Name: workflowType},
+				TaskQueue:           &taskqueuepb.TaskQueue{Name: taskQueueName},
+				WorkflowRunTimeout:  timestamp.DurationPtr(200 * time.Second),
+				WorkflowTaskTimeout: timestamp.DurationPtr(1 * time.Second),
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addWorkflowTaskScheduledEvent(mutableState)
+	startedEvent := addWorkflowTaskStartedEvent(mutableState, di.ScheduleID, taskQueueName, uuid.New())
+	// the previous workflow task failed before a signal reset the attempt
+	mutableState.GetExecutionInfo().WorkflowTaskConsecutiveFailures = 1
+	s.timerQueueActiveTaskExecutor.config.WorkflowTaskQuarantineThreshold = dynamicconfig.GetIntPropertyFilteredByNamespace(2)
+
+	timerTask := &tasks.WorkflowTaskTimeoutTask{
+		WorkflowKey: definition.NewWorkflowKey(
+			s.namespaceID.String(),
+			execution.GetWorkflowId(),
+			execution.GetRunId(),
+		),
+		ScheduleAttempt:     1,
+		Version:             s.version,
+		TaskID:              int64(100),
+		TimeoutType:         enumspb.TIMEOUT_TYPE_START_TO_CLOSE,
+		VisibilityTimestamp: s.now,
+		EventID:             di.ScheduleID,
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, startedEvent.GetEventId(), startedEvent.GetVersion())
+	s.mockExecutionMgr.EXPECT().GetWorkflowExecution(gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockExecutionMgr.EXPECT().UpdateWorkflowExecution(gomock.Any()).Return(tests.UpdateWorkflowExecutionResponse, nil)
+
+	err = s.timerQueueActiveTaskExecutor.execute(context.Background(), timerTask, true)
+	s.NoError(err)
+
+	quarantinedState := s.getMutableStateFromCache(s.namespaceID, execution.GetWorkflowId(), execution.GetRunId())
+	s.False(quarantinedState.HasPendingWorkflowTask())
+	s.True(workflow.IsWorkflowQuarantined(quarantinedState))
+	s.Equal(int32(2), quarantinedState.GetExecutionInfo().WorkflowTaskConsecutiveFailures)
+}
+
 func (s *timerQueueActiveTaskExecutorSuite) TestWorkflowTaskTimeout_Noop() {
 
 	execution := commonpb.WorkflowExecution{
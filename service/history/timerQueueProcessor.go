@@ -33,6 +33,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pborman/uuid"
 	"go.temporal.io/api/serviceerror"
 
 	"go.temporal.io/server/api/historyservice/v1"
@@ -59,6 +60,7 @@ type (
 		NotifyNewTimers(clusterName string, timerTask []tasks.Task)
 		LockTaskProcessing()
 		UnlockTaskProcessing()
+		Drain(level time.Time) bool
 	}
 
 	timeNow                 func() time.Time
@@ -156,10 +158,33 @@ func (t *timerQueueProcessorImpl) Start() {
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			standbyTimerProcessor.Start()
 		}
+		t.resumeFailovers()
 	}
 
 	t.shutdownWG.Add(1)
-	go t.completeTimersLoop()
+	t.shard.GetDiagnostics().Go(t.completeTimersLoop)
+}
+
+// resumeFailovers restarts the namespace failovers which were in progress when the shard was last
+// unloaded, from the failover levels persisted in shard info.
+func (t *timerQueueProcessorImpl) resumeFailovers() {
+	for failoverUUID, failoverLevel := range t.shard.GetAllTimerFailoverLevels() {
+		t.logger.Info("Timer Failover Resumed",
+			tag.WorkflowNamespaceIDs(failoverLevel.NamespaceIDs),
+			tag.MinLevel(failoverLevel.CurrentLevel.UnixNano()),
+			tag.MaxLevel(failoverLevel.MaxLevel.UnixNano()))
+		_, failoverTimerProcessor := newTimerQueueFailoverProcessor(
+			t.shard,
+			t.historyService,
+			failoverUUID,
+			failoverLevel,
+			"resumed",
+			t.matchingClient,
+			t.taskAllocator,
+			t.logger,
+		)
+		failoverTimerProcessor.Start()
+	}
 }
 
 func (t *timerQueueProcessorImpl) Stop() {
@@ -176,6 +201,21 @@ func (t *timerQueueProcessorImpl) Stop() {
 	common.AwaitWaitGroup(&t.shutdownWG, time.Minute)
 }
 
+// Drain moves the ack level of the active processor past the fired timers, and returns true once
+// all active timers up to level are fired. Standby timers are left to the next owner of the shard.
+func (t *timerQueueProcessorImpl) Drain(
+	level time.Time,
+) bool {
+
+	// fire the timer gate, so timers which became due right before the drain started are read
+	t.activeTimerProcessor.timerQueueProcessorBase.notifyNewTimer(level)
+	ackMgr := t.activeTimerProcessor.timerQueueProcessorBase.timerQueueAckMgr
+	if err := ackMgr.updateAckLevel(); err != nil {
+		return false
+	}
+	return ackMgr.isDrained(level)
+}
+
 // NotifyNewTimers - Notify the processor about the new active / standby timer arrival.
 // This should be called each time new timer arrives, otherwise timers maybe fired unexpected.
 func (t *timerQueueProcessorImpl) NotifyNewTimers(
@@ -230,10 +270,15 @@ func (t *timerQueueProcessorImpl) FailoverNamespace(
 	updateShardAckLevel, failoverTimerProcessor := newTimerQueueFailoverProcessor(
 		t.shard,
 		t.historyService,
-		namespaceIDs,
-		standbyClusterName,
-		minLevel,
-		maxLevel,
+		uuid.New(),
+		persistence.TimerFailoverLevel{
+			StartTime:    t.shard.GetTimeSource().Now(),
+			MinLevel:     minLevel,
+			CurrentLevel: minLevel,
+			MaxLevel:     maxLevel,
+			NamespaceIDs: namespaceIDs,
+		},
+		"from: "+standbyClusterName,
 		t.matchingClient,
 		t.taskAllocator,
 		t.logger,
@@ -317,6 +362,10 @@ func (t *timerQueueProcessorImpl) completeTimers() error {
 		return nil
 	}
 
+	if err := t.shard.AssertWritable(); err != nil {
+		return err
+	}
+
 	t.metricsClient.IncCounter(metrics.TimerQueueProcessorScope, metrics.TaskBatchCompleteCounter)
 
 	if lowerAckLevel.VisibilityTimestamp.Before(upperAckLevel.VisibilityTimestamp) {
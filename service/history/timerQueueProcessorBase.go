@@ -103,8 +103,11 @@ func newTimerQueueProcessorBase(
 	var taskProcessor *taskProcessor
 	if !config.TimerProcessorEnablePriorityTaskProcessor() {
 		options := taskProcessorOptions{
-			workerCount: config.TimerTaskWorkerCount(),
-			queueSize:   config.TimerTaskWorkerCount() * config.TimerTaskBatchSize(),
+			workerCount: func() int {
+				return config.TimerTaskWorkerCount(shard.GetShardID())
+			},
+			queueSize:    config.TimerTaskWorkerCount(shard.GetShardID()) * config.TimerTaskBatchSize(),
+			metricsScope: scope,
 		}
 		taskProcessor = newTaskProcessor(options, shard, historyService.historyCache, logger)
 	}
@@ -148,7 +151,7 @@ func (t *timerQueueProcessorBase) Start() {
 	t.shutdownWG.Add(1)
 	// notify a initial scan
 	t.notifyNewTimer(time.Time{})
-	go t.processorPump()
+	t.shard.GetDiagnostics().Go(t.processorPump)
 
 	t.logger.Info("Timer queue processor started.")
 }
@@ -407,6 +410,11 @@ func getTimerTaskMetricScope(
 			return metrics.TimerActiveTaskDeleteHistoryEventScope
 		}
 		return metrics.TimerStandbyTaskDeleteHistoryEventScope
+	case *tasks.DeleteExecutionVisibilityTimerTask:
+		if isActive {
+			return metrics.TimerActiveTaskDeleteVisibilityScope
+		}
+		return metrics.TimerStandbyTaskDeleteVisibilityScope
 	case *tasks.ActivityRetryTimerTask:
 		if isActive {
 			return metrics.TimerActiveTaskActivityRetryTimerScope
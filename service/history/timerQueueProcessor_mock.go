@@ -30,6 +30,7 @@ package history
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	tasks "go.temporal.io/server/service/history/tasks"
@@ -58,6 +59,20 @@ func (m *MocktimerQueueProcessor) EXPECT() *MocktimerQueueProcessorMockRecorder
 	return m.recorder
 }
 
+// Drain mocks base method.
+func (m *MocktimerQueueProcessor) Drain(level time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", level)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MocktimerQueueProcessorMockRecorder) Drain(level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MocktimerQueueProcessor)(nil).Drain), level)
+}
+
 // FailoverNamespace mocks base method.
 func (m *MocktimerQueueProcessor) FailoverNamespace(namespaceIDs map[string]struct{}) {
 	m.ctrl.T.Helper()
@@ -115,6 +115,8 @@ func (t *timerQueueStandbyTaskExecutor) execute(
 		return t.executeWorkflowTimeoutTask(ctx, task)
 	case *tasks.DeleteHistoryEventTask:
 		return t.executeDeleteHistoryEventTask(ctx, task)
+	case *tasks.DeleteExecutionVisibilityTimerTask:
+		return t.executeDeleteVisibilityTimerTask(ctx, task)
 	default:
 		return errUnknownTimerTask
 	}
@@ -253,7 +255,7 @@ func (t *timerQueueStandbyTaskExecutor) executeActivityTimeoutTask(
 			return nil, err
 		}
 
-		err = context.UpdateWorkflowExecutionAsPassive(now)
+		err = context.UpdateWorkflowExecutionAsPassive(ctx, now)
 		return nil, err
 	}
 
@@ -436,7 +438,7 @@ func (t *timerQueueStandbyTaskExecutor) processTimer(
 		}
 	}()
 
-	mutableState, err := loadMutableStateForTimerTask(executionContext, timerTask, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, executionContext, timerTask, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
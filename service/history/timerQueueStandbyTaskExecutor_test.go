@@ -126,12 +126,11 @@ func (s *timerQueueStandbyTaskExecutorSuite) SetupTest() {
 
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          1,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          1,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		config,
 	)
 	s.mockShard.SetEventsCacheForTesting(events.NewEventsCache(
@@ -143,6 +142,7 @@ func (s *timerQueueStandbyTaskExecutorSuite) SetupTest() {
 		false,
 		s.mockShard.GetLogger(),
 		s.mockShard.GetMetricsClient(),
+		nil,
 	))
 	s.mockShard.Resource.TimeSource = s.timeSource
 
@@ -747,7 +747,8 @@ func (s *timerQueueStandbyTaskExecutorSuite) TestProcessActivityTimeout_Multiple
 			mutableState.GetExecutionInfo().ExecutionStats = &persistencespb.ExecutionStats{}
 
 			s.Equal(&persistence.UpdateWorkflowExecutionRequest{
-				ShardID: s.mockShard.GetShardID(),
+				ShardID:     s.mockShard.GetShardID(),
+				NamespaceID: s.namespaceID.String(),
 				UpdateWorkflowMutation: persistence.WorkflowMutation{
 					ExecutionInfo:             mutableState.GetExecutionInfo(),
 					ExecutionState:            mutableState.GetExecutionState(),
@@ -33,8 +33,10 @@ import (
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/definition"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/service/history/configs"
 	"go.temporal.io/server/service/history/shard"
@@ -95,7 +97,7 @@ func (t *timerQueueTaskExecutorBase) executeDeleteHistoryEventTask(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	mutableState, err := loadMutableStateForTimerTask(ctx, weContext, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
 	}
@@ -119,18 +121,53 @@ func (t *timerQueueTaskExecutorBase) executeDeleteHistoryEventTask(
 	clusterConfiguredForHistoryArchival := t.shard.GetService().GetArchivalMetadata().GetHistoryConfig().ClusterConfiguredForArchival()
 	namespaceConfiguredForHistoryArchival := namespaceRegistryEntry.HistoryArchivalState().State == enumspb.ARCHIVAL_STATE_ENABLED
 	archiveHistory := clusterConfiguredForHistoryArchival && namespaceConfiguredForHistoryArchival
+	if archiveHistory && !namespaceRegistryEntry.DataResidency().AllowsArchivalURI(namespaceRegistryEntry.HistoryArchivalState().URI) {
+		// never archive outside of the locations the namespace is constrained to
+		t.logger.Warn("History archival uri is not allowed by namespace data residency constraints, skipping archival",
+			tag.WorkflowNamespaceID(task.NamespaceID),
+			tag.ArchivalURI(namespaceRegistryEntry.HistoryArchivalState().URI),
+		)
+		archiveHistory = false
+	}
 
 	// TODO: @ycyang once archival backfill is in place cluster:paused && namespace:enabled should be a nop rather than a delete
 	if archiveHistory {
 		t.metricsClient.IncCounter(metrics.HistoryProcessDeleteHistoryEventScope, metrics.WorkflowCleanupArchiveCount)
-		return t.archiveWorkflow(task, weContext, mutableState, namespaceRegistryEntry)
+		return t.archiveWorkflow(ctx, task, weContext, mutableState, namespaceRegistryEntry)
 	}
 
 	t.metricsClient.IncCounter(metrics.HistoryProcessDeleteHistoryEventScope, metrics.WorkflowCleanupDeleteCount)
-	return t.deleteWorkflow(task, weContext, mutableState)
+	return t.deleteWorkflow(ctx, task, weContext, mutableState)
+}
+
+// executeDeleteVisibilityTimerTask deletes the visibility record retained past the deletion of the workflow
+// execution, through the visibility queue like the records deleted along with the execution
+func (t *timerQueueTaskExecutorBase) executeDeleteVisibilityTimerTask(
+	ctx context.Context,
+	task *tasks.DeleteExecutionVisibilityTimerTask,
+) error {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, taskTimeout)
+
+	defer cancel()
+
+	return t.shard.AddTasks(ctx, &persistence.AddTasksRequest{
+		ShardID:     t.shard.GetShardID(),
+		NamespaceID: task.NamespaceID,
+		WorkflowID:  task.WorkflowID,
+		RunID:       task.RunID,
+
+		VisibilityTasks: []tasks.Task{&tasks.DeleteExecutionVisibilityTask{
+			// TaskID is set by shard
+			WorkflowKey:         task.WorkflowKey,
+			VisibilityTimestamp: t.shard.GetTimeSource().Now(),
+			Version:             task.Version,
+		}},
+	})
 }
 
 func (t *timerQueueTaskExecutorBase) deleteWorkflow(
+	ctx context.Context,
 	task *tasks.DeleteHistoryEventTask,
 	workflowContext workflow.Context,
 	msBuilder workflow.MutableState,
@@ -141,6 +178,7 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflow(
 	}
 
 	if err := t.shard.DeleteWorkflowExecution(
+		ctx,
 		definition.WorkflowKey{
 			NamespaceID: task.NamespaceID,
 			WorkflowID:  task.WorkflowID,
@@ -159,6 +197,7 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflow(
 }
 
 func (t *timerQueueTaskExecutorBase) archiveWorkflow(
+	ctx context.Context,
 	task *tasks.DeleteHistoryEventTask,
 	workflowContext workflow.Context,
 	msBuilder workflow.MutableState,
@@ -189,7 +228,7 @@ func (t *timerQueueTaskExecutorBase) archiveWorkflow(
 		CallerService:        common.HistoryServiceName,
 		AttemptArchiveInline: false, // archive in workflow by default
 	}
-	executionStats, err := workflowContext.LoadExecutionStats()
+	executionStats, err := workflowContext.LoadExecutionStats(ctx)
 	if err == nil && executionStats.HistorySize < int64(t.config.TimerProcessorHistoryArchivalSizeLimit()) {
 		req.AttemptArchiveInline = true
 	}
@@ -222,6 +261,7 @@ func (t *timerQueueTaskExecutorBase) archiveWorkflow(
 	}
 
 	if err := t.shard.DeleteWorkflowExecution(
+		ctx,
 		definition.WorkflowKey{
 			NamespaceID: task.NamespaceID,
 			WorkflowID:  task.WorkflowID,
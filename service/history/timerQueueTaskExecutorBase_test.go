@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -92,12 +93,11 @@ func (s *timerQueueTaskExecutorBaseSuite) SetupTest() {
 	config := tests.NewDynamicConfig()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          0,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          0,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		config,
 	)
 	s.mockEngine = shard.NewMockEngine(s.controller)
@@ -159,7 +159,7 @@ func (s *timerQueueTaskExecutorBaseSuite) TestDeleteWorkflow_NoErr() {
 	s.mockExecutionManager.EXPECT().DeleteHistoryBranch(gomock.Any()).Return(nil)
 	s.mockMutableState.EXPECT().GetCurrentBranchToken().Return([]byte{1, 2, 3}, nil)
 
-	err := s.timerQueueTaskExecutorBase.deleteWorkflow(task, s.mockWorkflowExecutionContext, s.mockMutableState)
+	err := s.timerQueueTaskExecutorBase.deleteWorkflow(context.Background(), task, s.mockWorkflowExecutionContext, s.mockMutableState)
 	s.NoError(err)
 }
 
@@ -175,7 +175,7 @@ func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_NoErr_InlineArchiva
 		VisibilityTimestamp: time.Now().UTC(),
 	}
 
-	s.mockWorkflowExecutionContext.EXPECT().LoadExecutionStats().Return(&persistencespb.ExecutionStats{
+	s.mockWorkflowExecutionContext.EXPECT().LoadExecutionStats(gomock.Any()).Return(&persistencespb.ExecutionStats{
 		HistorySize: 1024,
 	}, nil)
 	s.mockWorkflowExecutionContext.EXPECT().Clear()
@@ -202,7 +202,7 @@ func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_NoErr_InlineArchiva
 	s.mockSearchAttributesProvider.EXPECT().GetSearchAttributes(gomock.Any(), false)
 
 	namespaceRegistryEntry := namespace.NewNamespaceForTest(&persistencespb.NamespaceInfo{}, &persistencespb.NamespaceConfig{}, false, nil, 0)
-	err := s.timerQueueTaskExecutorBase.archiveWorkflow(task, s.mockWorkflowExecutionContext, s.mockMutableState, namespaceRegistryEntry)
+	err := s.timerQueueTaskExecutorBase.archiveWorkflow(context.Background(), task, s.mockWorkflowExecutionContext, s.mockMutableState, namespaceRegistryEntry)
 	s.NoError(err)
 }
 
@@ -218,7 +218,7 @@ func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_SendSignalErr() {
 		VisibilityTimestamp: time.Now().UTC(),
 	}
 
-	s.mockWorkflowExecutionContext.EXPECT().LoadExecutionStats().Return(&persistencespb.ExecutionStats{
+	s.mockWorkflowExecutionContext.EXPECT().LoadExecutionStats(gomock.Any()).Return(&persistencespb.ExecutionStats{
 		HistorySize: 1024 * 1024 * 1024,
 	}, nil)
 
@@ -231,7 +231,7 @@ func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_SendSignalErr() {
 	s.mockSearchAttributesProvider.EXPECT().GetSearchAttributes(gomock.Any(), false)
 
 	namespaceRegistryEntry := namespace.NewNamespaceForTest(&persistencespb.NamespaceInfo{}, &persistencespb.NamespaceConfig{}, false, nil, 0)
-	err := s.timerQueueTaskExecutorBase.archiveWorkflow(task, s.mockWorkflowExecutionContext, s.mockMutableState, namespaceRegistryEntry)
+	err := s.timerQueueTaskExecutorBase.archiveWorkflow(context.Background(), task, s.mockWorkflowExecutionContext, s.mockMutableState, namespaceRegistryEntry)
 	s.Error(err)
 }
 
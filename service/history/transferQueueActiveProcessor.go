@@ -27,8 +27,6 @@ package history
 import (
 	"context"
 
-	"github.com/pborman/uuid"
-
 	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/common/log"
@@ -148,10 +146,9 @@ func newTransferQueueFailoverProcessor(
 	historyEngine *historyEngineImpl,
 	matchingClient matchingservice.MatchingServiceClient,
 	historyClient historyservice.HistoryServiceClient,
-	namespaceIDs map[string]struct{},
-	standbyClusterName string,
-	minLevel int64,
-	maxLevel int64,
+	failoverUUID string,
+	failoverLevel persistence.TransferFailoverLevel,
+	failoverMsg string,
 	taskAllocator taskAllocator,
 	logger log.Logger,
 ) (func(ackLevel int64) error, *transferQueueActiveProcessorImpl) {
@@ -173,12 +170,14 @@ func newTransferQueueFailoverProcessor(
 		MetricScope:                         metrics.TransferActiveQueueProcessorScope,
 	}
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
-	failoverUUID := uuid.New()
+	namespaceIDs := failoverLevel.NamespaceIDs
+	minLevel := failoverLevel.MinLevel
+	maxLevel := failoverLevel.MaxLevel
 	logger = log.With(
 		logger,
 		tag.ClusterName(currentClusterName),
 		tag.WorkflowNamespaceIDs(namespaceIDs),
-		tag.FailoverMsg("from: "+standbyClusterName),
+		tag.FailoverMsg(failoverMsg),
 	)
 
 	transferTaskFilter := func(task tasks.Task) (bool, error) {
@@ -187,12 +186,11 @@ func newTransferQueueFailoverProcessor(
 	maxReadAckLevel := func() int64 {
 		return maxLevel // this is a const
 	}
-	failoverStartTime := shard.GetTimeSource().Now()
 	updateTransferAckLevel := func(ackLevel int64) error {
 		return shard.UpdateTransferFailoverLevel(
 			failoverUUID,
 			persistence.TransferFailoverLevel{
-				StartTime:    failoverStartTime,
+				StartTime:    failoverLevel.StartTime,
 				MinLevel:     minLevel,
 				CurrentLevel: ackLevel,
 				MaxLevel:     maxLevel,
@@ -231,7 +229,7 @@ func newTransferQueueFailoverProcessor(
 		shard,
 		options,
 		processor,
-		minLevel,
+		failoverLevel.CurrentLevel,
 		logger,
 	)
 
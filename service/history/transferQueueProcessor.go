@@ -32,6 +32,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pborman/uuid"
 	"go.temporal.io/api/serviceerror"
 
 	"go.temporal.io/server/api/historyservice/v1"
@@ -58,6 +59,7 @@ type (
 		NotifyNewTask(clusterName string, transferTasks []tasks.Task)
 		LockTaskProcessing()
 		UnlockTaskProcessing()
+		Drain(level int64, since time.Time) bool
 	}
 
 	taskFilter func(task tasks.Task) (bool, error)
@@ -157,9 +159,33 @@ func (t *transferQueueProcessorImpl) Start() {
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			standbyTaskProcessor.Start()
 		}
+		t.resumeFailovers()
 	}
 
-	go t.completeTransferLoop()
+	t.shard.GetDiagnostics().Go(t.completeTransferLoop)
+}
+
+// resumeFailovers restarts the namespace failovers which were in progress when the shard was last
+// unloaded, from the failover levels persisted in shard info.
+func (t *transferQueueProcessorImpl) resumeFailovers() {
+	for failoverUUID, failoverLevel := range t.shard.GetAllTransferFailoverLevels() {
+		t.logger.Info("Transfer Failover Resumed",
+			tag.WorkflowNamespaceIDs(failoverLevel.NamespaceIDs),
+			tag.MinLevel(failoverLevel.CurrentLevel),
+			tag.MaxLevel(failoverLevel.MaxLevel))
+		_, failoverTaskProcessor := newTransferQueueFailoverProcessor(
+			t.shard,
+			t.historyService,
+			t.matchingClient,
+			t.historyClient,
+			failoverUUID,
+			failoverLevel,
+			"resumed",
+			t.taskAllocator,
+			t.logger,
+		)
+		failoverTaskProcessor.Start()
+	}
 }
 
 func (t *transferQueueProcessorImpl) Stop() {
@@ -175,6 +201,21 @@ func (t *transferQueueProcessorImpl) Stop() {
 	close(t.shutdownChan)
 }
 
+// Drain moves the ack level of the active processor past the completed tasks, and returns true once
+// all active tasks up to level are completed. Standby tasks are left to the next owner of the shard.
+func (t *transferQueueProcessorImpl) Drain(
+	level int64,
+	since time.Time,
+) bool {
+
+	// make sure tasks created right before the drain started are read
+	t.activeTaskProcessor.notifyNewTask()
+	if err := t.activeTaskProcessor.queueAckMgr.updateQueueAckLevel(); err != nil {
+		return false
+	}
+	return t.activeTaskProcessor.queueAckMgr.isDrained(level, since)
+}
+
 // NotifyNewTask - Notify the processor about the new active / standby transfer task arrival.
 // This should be called each time new transfer task arrives, otherwise tasks maybe delayed.
 func (t *transferQueueProcessorImpl) NotifyNewTask(
@@ -228,10 +269,15 @@ func (t *transferQueueProcessorImpl) FailoverNamespace(
 		t.historyService,
 		t.matchingClient,
 		t.historyClient,
-		namespaceIDs,
-		standbyClusterName,
-		minLevel,
-		maxLevel,
+		uuid.New(),
+		persistence.TransferFailoverLevel{
+			StartTime:    t.shard.GetTimeSource().Now(),
+			MinLevel:     minLevel,
+			CurrentLevel: minLevel,
+			MaxLevel:     maxLevel,
+			NamespaceIDs: namespaceIDs,
+		},
+		"from: "+standbyClusterName,
 		t.taskAllocator,
 		t.logger,
 	)
@@ -316,6 +362,10 @@ func (t *transferQueueProcessorImpl) completeTransfer() error {
 		return nil
 	}
 
+	if err := t.shard.AssertWritable(); err != nil {
+		return err
+	}
+
 	t.metricsClient.IncCounter(metrics.TransferQueueProcessorScope, metrics.TaskBatchCompleteCounter)
 
 	if lowerAckLevel < upperAckLevel {
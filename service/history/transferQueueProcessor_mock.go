@@ -30,6 +30,7 @@ package history
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	tasks "go.temporal.io/server/service/history/tasks"
@@ -58,6 +59,20 @@ func (m *MocktransferQueueProcessor) EXPECT() *MocktransferQueueProcessorMockRec
 	return m.recorder
 }
 
+// Drain mocks base method.
+func (m *MocktransferQueueProcessor) Drain(level int64, since time.Time) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", level, since)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MocktransferQueueProcessorMockRecorder) Drain(level, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MocktransferQueueProcessor)(nil).Drain), level, since)
+}
+
 // FailoverNamespace mocks base method.
 func (m *MocktransferQueueProcessor) FailoverNamespace(namespaceIDs map[string]struct{}) {
 	m.ctrl.T.Helper()
@@ -36,6 +36,7 @@ import (
 	m "go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/searchattribute"
@@ -114,6 +115,15 @@ func (t *transferQueueTaskExecutorBase) pushActivity(
 		ScheduleId:             task.ScheduleID,
 		ScheduleToStartTimeout: activityScheduleToStartTimeout,
 	})
+	if err == nil {
+		t.recordScheduleToMatchingLatency(
+			namespace.ID(task.TargetNamespaceID),
+			task.TaskQueue,
+			enumspb.TASK_QUEUE_KIND_NORMAL,
+			"activity",
+			task.VisibilityTimestamp,
+		)
+	}
 
 	return err
 }
@@ -137,9 +147,45 @@ func (t *transferQueueTaskExecutorBase) pushWorkflowTask(
 		ScheduleId:             task.ScheduleID,
 		ScheduleToStartTimeout: workflowTaskScheduleToStartTimeout,
 	})
+	if err == nil {
+		t.recordScheduleToMatchingLatency(
+			namespace.ID(task.NamespaceID),
+			taskqueue.GetName(),
+			taskqueue.GetKind(),
+			"workflow",
+			task.VisibilityTimestamp,
+		)
+	}
 	return err
 }
 
+// recordScheduleToMatchingLatency emits the time a task spent in the history transfer
+// queue, from being scheduled until it was handed to matching. Together with the matching
+// backlog and dispatch latencies this breaks down task_schedule_to_start_latency.
+func (t *transferQueueTaskExecutorBase) recordScheduleToMatchingLatency(
+	namespaceID namespace.ID,
+	taskQueueName string,
+	taskQueueKind enumspb.TaskQueueKind,
+	taskType string,
+	scheduledTime time.Time,
+) {
+
+	if scheduledTime.IsZero() {
+		return
+	}
+	namespaceName, err := t.shard.GetNamespaceRegistry().GetNamespaceName(namespaceID)
+	if err != nil {
+		return
+	}
+	metrics.GetPerTaskQueueScope(
+		t.metricsClient.Scope(metrics.TransferQueueProcessorScope),
+		namespaceName.String(),
+		taskQueueName,
+		taskQueueKind,
+	).Tagged(metrics.TaskTypeTag(taskType)).
+		RecordTimer(metrics.TaskScheduleToMatchingLatency, t.shard.GetTimeSource().Now().Sub(scheduledTime))
+}
+
 func (t *transferQueueTaskExecutorBase) recordWorkflowClosed(
 	namespaceID namespace.ID,
 	workflowID string,
@@ -162,6 +208,14 @@ func (t *transferQueueTaskExecutorBase) recordWorkflowClosed(
 	clusterConfiguredForVisibilityArchival := t.shard.GetService().GetArchivalMetadata().GetVisibilityConfig().ClusterConfiguredForArchival()
 	namespaceConfiguredForVisibilityArchival := namespaceEntry.VisibilityArchivalState().State == enumspb.ARCHIVAL_STATE_ENABLED
 	archiveVisibility := clusterConfiguredForVisibilityArchival && namespaceConfiguredForVisibilityArchival
+	if archiveVisibility && !namespaceEntry.DataResidency().AllowsArchivalURI(namespaceEntry.VisibilityArchivalState().URI) {
+		// never archive outside of the locations the namespace is constrained to
+		t.logger.Warn("Visibility archival uri is not allowed by namespace data residency constraints, skipping archival",
+			tag.WorkflowNamespaceID(namespaceID.String()),
+			tag.ArchivalURI(namespaceEntry.VisibilityArchivalState().URI),
+		)
+		archiveVisibility = false
+	}
 
 	if !archiveVisibility {
 		return nil
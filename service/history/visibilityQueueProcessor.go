@@ -47,6 +47,7 @@ type (
 	visibilityQueueProcessor interface {
 		common.Daemon
 		NotifyNewTask(visibilityTasks []tasks.Task)
+		Drain(level int64, since time.Time) bool
 	}
 
 	updateVisibilityAckLevel func(ackLevel int64) error
@@ -175,7 +176,7 @@ func (t *visibilityQueueProcessorImpl) Start() {
 		return
 	}
 	t.queueProcessorBase.Start()
-	go t.completeTaskLoop()
+	t.shard.GetDiagnostics().Go(t.completeTaskLoop)
 }
 
 func (t *visibilityQueueProcessorImpl) Stop() {
@@ -196,6 +197,20 @@ func (t *visibilityQueueProcessorImpl) NotifyNewTask(
 	}
 }
 
+// Drain moves the ack level past the completed tasks, and returns true once all tasks up to level are completed.
+func (t *visibilityQueueProcessorImpl) Drain(
+	level int64,
+	since time.Time,
+) bool {
+
+	// make sure tasks created right before the drain started are read
+	t.notifyNewTask()
+	if err := t.queueAckMgr.updateQueueAckLevel(); err != nil {
+		return false
+	}
+	return t.queueAckMgr.isDrained(level, since)
+}
+
 func (t *visibilityQueueProcessorImpl) completeTaskLoop() {
 	timer := time.NewTimer(t.config.VisibilityProcessorCompleteTaskInterval())
 	defer timer.Stop()
@@ -239,6 +254,10 @@ func (t *visibilityQueueProcessorImpl) completeTask() error {
 		return nil
 	}
 
+	if err := t.shard.AssertWritable(); err != nil {
+		return err
+	}
+
 	t.metricsClient.IncCounter(metrics.VisibilityQueueProcessorScope, metrics.TaskBatchCompleteCounter)
 
 	if lowerAckLevel < upperAckLevel {
@@ -26,6 +26,7 @@ package history
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -36,6 +37,7 @@ import (
 	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence/visibility/manager"
@@ -139,7 +141,7 @@ func (t *visibilityQueueTaskExecutor) processStartExecution(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := weContext.LoadWorkflowExecution()
+	mutableState, err := weContext.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return err
 	}
@@ -213,7 +215,7 @@ func (t *visibilityQueueTaskExecutor) processUpsertExecution(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := weContext.LoadWorkflowExecution()
+	mutableState, err := weContext.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return err
 	}
@@ -227,7 +229,7 @@ func (t *visibilityQueueTaskExecutor) processUpsertExecution(
 
 	workflowStartTime := timestamp.TimeValue(mutableState.GetExecutionInfo().GetStartTime())
 	workflowExecutionTime := timestamp.TimeValue(mutableState.GetExecutionInfo().GetExecutionTime())
-	visibilityMemo := getWorkflowMemo(copyMemo(executionInfo.Memo))
+	visibilityMemo := getWorkflowMemo(t.addActivityProgress(mutableState, copyMemo(executionInfo.Memo)))
 	searchAttr := getSearchAttributes(copySearchAttributes(executionInfo.SearchAttributes))
 	executionStatus := executionState.GetStatus()
 	taskQueue := executionInfo.TaskQueue
@@ -273,6 +275,7 @@ func (t *visibilityQueueTaskExecutor) recordStartExecution(
 	if err != nil {
 		return err
 	}
+	visibilityMemo = t.limitMemoSize(namespaceEntry.Name(), workflowID, runID, visibilityMemo)
 
 	request := &manager.RecordWorkflowExecutionStartedRequest{
 		VisibilityRequestBase: &manager.VisibilityRequestBase{
@@ -315,6 +318,7 @@ func (t *visibilityQueueTaskExecutor) upsertExecution(
 	if err != nil {
 		return err
 	}
+	visibilityMemo = t.limitMemoSize(namespaceEntry.Name(), workflowID, runID, visibilityMemo)
 
 	request := &manager.UpsertWorkflowExecutionRequest{
 		VisibilityRequestBase: &manager.VisibilityRequestBase{
@@ -361,7 +365,7 @@ func (t *visibilityQueueTaskExecutor) processCloseExecution(
 	}
 	defer func() { release(retError) }()
 
-	mutableState, err := weContext.LoadWorkflowExecution()
+	mutableState, err := weContext.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return err
 	}
@@ -441,6 +445,7 @@ func (t *visibilityQueueTaskExecutor) recordCloseExecution(
 	if err != nil {
 		return err
 	}
+	visibilityMemo = t.limitMemoSize(namespaceEntry.Name(), workflowID, runID, visibilityMemo)
 
 	recordWorkflowClose := true
 
@@ -483,9 +488,94 @@ func (t *visibilityQueueTaskExecutor) processDeleteExecution(
 		RunID:       task.RunID,
 		TaskID:      task.TaskID,
 	}
+	// Namespace name is only used to route the request to a dedicated visibility index.
+	// Namespace might be already deleted, in this case the request goes to the default index.
+	if namespaceEntry, err := t.shard.GetNamespaceRegistry().GetNamespaceByID(request.NamespaceID); err == nil {
+		request.Namespace = namespaceEntry.Name()
+	}
 	return t.visibilityMgr.DeleteWorkflowExecution(request)
 }
 
+// limitMemoSize drops the largest fields of the memo of a visibility record until it fits in the namespace memo
+// size limit, so that oversized documents aren't rejected by the visibility store and don't stall the queue.
+// The full memo is kept in mutable state.
+func (t *visibilityQueueTaskExecutor) limitMemoSize(
+	namespaceName namespace.Name,
+	workflowID string,
+	runID string,
+	visibilityMemo *commonpb.Memo,
+) *commonpb.Memo {
+	sizeLimit := t.config.VisibilityProcessorMemoSizeLimit(namespaceName.String())
+	if sizeLimit <= 0 || visibilityMemo.Size() <= sizeLimit {
+		return visibilityMemo
+	}
+
+	droppedFields := truncateMemo(visibilityMemo, sizeLimit)
+	t.metricsClient.Scope(
+		metrics.VisibilityQueueProcessorScope,
+		metrics.NamespaceTag(namespaceName.String()),
+	).IncCounter(metrics.VisibilityMemoTruncatedCounter)
+	t.logger.Warn("Visibility memo exceeds size limit, dropped memo fields from visibility record.",
+		tag.WorkflowNamespace(namespaceName.String()),
+		tag.WorkflowID(workflowID),
+		tag.WorkflowRunID(runID),
+		tag.Value(droppedFields),
+	)
+	return visibilityMemo
+}
+
+// addActivityProgress adds the progress of the pending activities of mutableState to the visibility memo fields
+// if activity progress is enabled for the namespace.
+func (t *visibilityQueueTaskExecutor) addActivityProgress(
+	mutableState workflow.MutableState,
+	memoFields map[string]*commonpb.Payload,
+) map[string]*commonpb.Payload {
+
+	namespaceName := mutableState.GetNamespaceEntry().Name().String()
+	if t.config.VisibilityActivityProgressInterval(namespaceName) <= 0 {
+		return memoFields
+	}
+
+	memoFields, err := workflow.AddActivityProgressMemo(memoFields, workflow.GetActivityProgress(mutableState))
+	if err != nil {
+		t.logger.Warn("Failed to encode activity progress for visibility memo.",
+			tag.WorkflowNamespace(namespaceName),
+			tag.Error(err),
+		)
+	}
+	return memoFields
+}
+
+// truncateMemo removes the largest fields from memo until its size is at most sizeLimit, and returns the
+// names of the removed fields
+func truncateMemo(
+	memo *commonpb.Memo,
+	sizeLimit int,
+) []string {
+
+	fieldNames := make([]string, 0, len(memo.Fields))
+	for name := range memo.Fields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Slice(fieldNames, func(i, j int) bool {
+		sizeI, sizeJ := memo.Fields[fieldNames[i]].Size(), memo.Fields[fieldNames[j]].Size()
+		if sizeI != sizeJ {
+			return sizeI > sizeJ
+		}
+		return fieldNames[i] < fieldNames[j]
+	})
+
+	var droppedFields []string
+	for _, name := range fieldNames {
+		if memo.Size() <= sizeLimit {
+			break
+		}
+		delete(memo.Fields, name)
+		droppedFields = append(droppedFields, name)
+	}
+	return droppedFields
+}
+
 func getWorkflowMemo(
 	memoFields map[string]*commonpb.Payload,
 ) *commonpb.Memo {
@@ -108,12 +108,11 @@ func (s *visibilityQueueTaskExecutorSuite) SetupTest() {
 	config := tests.NewDynamicConfig()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          1,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          1,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		config,
 	)
 	s.mockShard.SetEventsCacheForTesting(events.NewEventsCache(
@@ -125,6 +124,7 @@ func (s *visibilityQueueTaskExecutorSuite) SetupTest() {
 		false,
 		s.mockShard.GetLogger(),
 		s.mockShard.GetMetricsClient(),
+		nil,
 	))
 	s.mockShard.Resource.TimeSource = s.timeSource
 
@@ -404,6 +404,27 @@ func (s *visibilityQueueTaskExecutorSuite) createUpsertWorkflowSearchAttributesR
 	}
 }
 
+func (s *visibilityQueueTaskExecutorSuite) TestTruncateMemo() {
+	memo := &commonpb.Memo{Fields: map[string]*commonpb.Payload{
+		"small":  {Data: make([]byte, 10)},
+		"medium": {Data: make([]byte, 100)},
+		"large":  {Data: make([]byte, 1000)},
+	}}
+
+	dropped := truncateMemo(memo, 200)
+	s.Equal([]string{"large"}, dropped)
+	s.LessOrEqual(memo.Size(), 200)
+	s.Contains(memo.Fields, "small")
+	s.Contains(memo.Fields, "medium")
+
+	dropped = truncateMemo(memo, 50)
+	s.Equal([]string{"medium"}, dropped)
+	s.Len(memo.Fields, 1)
+	s.Contains(memo.Fields, "small")
+
+	s.Empty(truncateMemo(memo, 50))
+}
+
 func (s *visibilityQueueTaskExecutorSuite) createPersistenceMutableState(
 	ms workflow.MutableState,
 	lastEventID int64,
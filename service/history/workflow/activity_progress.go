@@ -0,0 +1,144 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	"sort"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+// ActivityProgressMemoKey is the visibility memo field which holds the progress of the pending activities of a
+// workflow, if activity progress is enabled for its namespace. The field is only written to visibility records,
+// the workflow memo is not changed.
+const ActivityProgressMemoKey = "__temporal_activity_progress"
+
+type (
+	// ActivityProgress is the progress reported by the last heartbeat of a pending activity. Activities report
+	// progress by heartbeating a JSON object with a "summary" string and/or a "percentage" number as the first
+	// heartbeat details payload.
+	ActivityProgress struct {
+		ActivityID        string     `json:"activityId"`
+		Summary           string     `json:"summary,omitempty"`
+		Percentage        *float64   `json:"percentage,omitempty"`
+		LastHeartbeatTime *time.Time `json:"lastHeartbeatTime,omitempty"`
+	}
+
+	heartbeatProgress struct {
+		Summary    string   `json:"summary"`
+		Percentage *float64 `json:"percentage"`
+	}
+)
+
+// UpsertActivityProgressVisibility adds an upsert visibility task after a heartbeat of ai which reported
+// progress, at most once per interval of heartbeat time per activity. previousHeartbeatTime is the heartbeat
+// time of ai before the heartbeat was recorded.
+func UpsertActivityProgressVisibility(
+	mutableState MutableState,
+	ai *persistencespb.ActivityInfo,
+	previousHeartbeatTime *time.Time,
+	interval time.Duration,
+) bool {
+
+	if interval <= 0 || ai.LastHeartbeatUpdateTime == nil {
+		return false
+	}
+	if _, ok := decodeHeartbeatProgress(ai.LastHeartbeatDetails); !ok {
+		return false
+	}
+	heartbeatTime := timestamp.TimeValue(ai.LastHeartbeatUpdateTime)
+	if previousHeartbeatTime != nil &&
+		previousHeartbeatTime.Truncate(interval).Equal(heartbeatTime.Truncate(interval)) {
+		return false
+	}
+
+	addUpsertVisibilityTask(mutableState, heartbeatTime)
+	return true
+}
+
+// GetActivityProgress returns the progress of the pending activities of mutableState which reported progress,
+// ordered by activity ID.
+func GetActivityProgress(
+	mutableState MutableState,
+) []ActivityProgress {
+
+	var result []ActivityProgress
+	for _, ai := range mutableState.GetPendingActivityInfos() {
+		progress, ok := decodeHeartbeatProgress(ai.LastHeartbeatDetails)
+		if !ok {
+			continue
+		}
+		result = append(result, ActivityProgress{
+			ActivityID:        ai.ActivityId,
+			Summary:           progress.Summary,
+			Percentage:        progress.Percentage,
+			LastHeartbeatTime: ai.LastHeartbeatUpdateTime,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ActivityID < result[j].ActivityID
+	})
+	return result
+}
+
+// AddActivityProgressMemo sets ActivityProgressMemoKey in memoFields to the encoded progress, and returns the
+// updated memo fields. memoFields is returned unchanged if there is no progress.
+func AddActivityProgressMemo(
+	memoFields map[string]*commonpb.Payload,
+	progress []ActivityProgress,
+) (map[string]*commonpb.Payload, error) {
+
+	if len(progress) == 0 {
+		return memoFields, nil
+	}
+	progressPayload, err := payload.Encode(progress)
+	if err != nil {
+		return memoFields, err
+	}
+	if memoFields == nil {
+		memoFields = make(map[string]*commonpb.Payload, 1)
+	}
+	memoFields[ActivityProgressMemoKey] = progressPayload
+	return memoFields, nil
+}
+
+func decodeHeartbeatProgress(
+	details *commonpb.Payloads,
+) (heartbeatProgress, bool) {
+
+	var progress heartbeatProgress
+	if len(details.GetPayloads()) == 0 {
+		return progress, false
+	}
+	if err := payload.Decode(details.GetPayloads()[0], &progress); err != nil {
+		return progress, false
+	}
+	return progress, progress.Summary != "" || progress.Percentage != nil
+}
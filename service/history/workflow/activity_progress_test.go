@@ -0,0 +1,118 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	commonpb "go.temporal.io/api/common/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/payloads"
+)
+
+func Test_GetActivityProgress(t *testing.T) {
+	a := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	heartbeatTime := time.Now().UTC()
+	percentage := 42.5
+	mutableState := NewMockMutableState(ctrl)
+	mutableState.EXPECT().GetPendingActivityInfos().Return(map[int64]*persistencespb.ActivityInfo{
+		5: {
+			ActivityId:              "b",
+			LastHeartbeatDetails:    payloads.EncodeString("no progress"),
+			LastHeartbeatUpdateTime: &heartbeatTime,
+		},
+		6: {
+			ActivityId:              "a",
+			LastHeartbeatDetails:    mustEncodePayloads(t, map[string]interface{}{"summary": "copying", "percentage": percentage}),
+			LastHeartbeatUpdateTime: &heartbeatTime,
+		},
+		7: {
+			ActivityId: "c",
+		},
+	})
+
+	progress := GetActivityProgress(mutableState)
+	a.Equal([]ActivityProgress{{
+		ActivityID:        "a",
+		Summary:           "copying",
+		Percentage:        &percentage,
+		LastHeartbeatTime: &heartbeatTime,
+	}}, progress)
+
+	memoFields, err := AddActivityProgressMemo(nil, progress)
+	a.NoError(err)
+	a.Contains(memoFields, ActivityProgressMemoKey)
+
+	memoFields, err = AddActivityProgressMemo(nil, nil)
+	a.NoError(err)
+	a.Nil(memoFields)
+}
+
+func Test_UpsertActivityProgressVisibility(t *testing.T) {
+	a := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	heartbeatTime := time.Date(2021, 1, 1, 0, 0, 30, 0, time.UTC)
+	ai := &persistencespb.ActivityInfo{
+		ActivityId:              "a",
+		LastHeartbeatDetails:    mustEncodePayloads(t, map[string]interface{}{"summary": "copying"}),
+		LastHeartbeatUpdateTime: &heartbeatTime,
+	}
+	mutableState := NewMockMutableState(ctrl)
+	mutableState.EXPECT().GetWorkflowKey().Return(definition.NewWorkflowKey("namespace-id", "workflow-id", "run-id")).Times(2)
+	mutableState.EXPECT().GetCurrentVersion().Return(int64(1)).Times(2)
+	mutableState.EXPECT().AddVisibilityTasks(gomock.Any()).Times(2)
+
+	// disabled
+	a.False(UpsertActivityProgressVisibility(mutableState, ai, nil, 0))
+	// first heartbeat
+	a.True(UpsertActivityProgressVisibility(mutableState, ai, nil, time.Minute))
+	// previous heartbeat within the same interval
+	previousHeartbeatTime := heartbeatTime.Add(-10 * time.Second)
+	a.False(UpsertActivityProgressVisibility(mutableState, ai, &previousHeartbeatTime, time.Minute))
+	// previous heartbeat in the previous interval
+	previousHeartbeatTime = heartbeatTime.Add(-time.Minute)
+	a.True(UpsertActivityProgressVisibility(mutableState, ai, &previousHeartbeatTime, time.Minute))
+
+	// no progress reported
+	ai.LastHeartbeatDetails = payloads.EncodeString("no progress")
+	a.False(UpsertActivityProgressVisibility(mutableState, ai, nil, time.Minute))
+}
+
+func mustEncodePayloads(t *testing.T, value interface{}) *commonpb.Payloads {
+	p, err := payload.Encode(value)
+	assert.NoError(t, err)
+	return &commonpb.Payloads{Payloads: []*commonpb.Payload{p}}
+}
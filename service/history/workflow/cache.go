@@ -43,6 +43,7 @@ import (
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/service/history/configs"
+	"go.temporal.io/server/service/history/events"
 	"go.temporal.io/server/service/history/shard"
 )
 
@@ -71,6 +72,7 @@ type (
 		logger           log.Logger
 		metricsClient    metrics.Client
 		config           *configs.Config
+		isPinned         events.NamespacePinnedFn
 	}
 
 	NewCacheFn func(shard shard.Context) Cache
@@ -89,6 +91,10 @@ func NewCache(shard shard.Context) Cache {
 	opts.InitialCapacity = config.HistoryCacheInitialSize()
 	opts.TTL = config.HistoryCacheTTL()
 	opts.Pin = true
+	isPinned := events.NewNamespacePinnedFn(config.CachePinnedNamespace)
+	opts.Protected = func(key interface{}) bool {
+		return isPinned(namespace.ID(key.(definition.WorkflowKey).NamespaceID))
+	}
 
 	return &CacheImpl{
 		Cache:            cache.New(config.HistoryCacheMaxSize(), opts),
@@ -97,6 +103,7 @@ func NewCache(shard shard.Context) Cache {
 		logger:           log.With(shard.GetLogger(), tag.ComponentHistoryCache),
 		metricsClient:    shard.GetMetricsClient(),
 		config:           config,
+		isPinned:         isPinned,
 	}
 }
 
@@ -107,7 +114,7 @@ func (c *CacheImpl) GetOrCreateCurrentWorkflowExecution(
 ) (Context, ReleaseCacheFunc, error) {
 
 	scope := metrics.HistoryCacheGetOrCreateCurrentScope
-	c.metricsClient.IncCounter(scope, metrics.CacheRequests)
+	c.tierScope(scope, namespaceID).IncCounter(metrics.CacheRequests)
 	sw := c.metricsClient.StartTimer(scope, metrics.CacheLatency)
 	defer sw.Stop()
 
@@ -140,7 +147,7 @@ func (c *CacheImpl) GetOrCreateWorkflowExecution(
 	}
 
 	scope := metrics.HistoryCacheGetOrCreateScope
-	c.metricsClient.IncCounter(scope, metrics.CacheRequests)
+	c.tierScope(scope, namespaceID).IncCounter(metrics.CacheRequests)
 	start := time.Now()
 	sw := c.metricsClient.StartTimer(scope, metrics.CacheLatency)
 	defer sw.Stop()
@@ -171,7 +178,7 @@ func (c *CacheImpl) getOrCreateWorkflowExecutionInternal(
 	key := definition.NewWorkflowKey(namespaceID.String(), execution.GetWorkflowId(), execution.GetRunId())
 	workflowCtx, cacheHit := c.Get(key).(Context)
 	if !cacheHit {
-		c.metricsClient.IncCounter(scope, metrics.CacheMissCounter)
+		c.tierScope(scope, namespaceID).IncCounter(metrics.CacheMissCounter)
 		// Let's create the workflow execution workflowCtx
 		workflowCtx = NewContext(namespaceID, execution, c.shard, c.logger)
 		elem, err := c.PutIfNotExist(key, workflowCtx)
@@ -196,6 +203,11 @@ func (c *CacheImpl) getOrCreateWorkflowExecutionInternal(
 	return workflowCtx, releaseFunc, nil
 }
 
+// tierScope tags cache hit rate metrics with whether the namespace is pinned
+func (c *CacheImpl) tierScope(scope int, namespaceID namespace.ID) metrics.Scope {
+	return c.metricsClient.Scope(scope, metrics.CacheTierTag(c.isPinned(namespaceID)))
+}
+
 func (c *CacheImpl) makeReleaseFunc(
 	key definition.WorkflowKey,
 	context Context,
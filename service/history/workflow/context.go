@@ -70,9 +70,9 @@ type (
 		GetNamespaceID() namespace.ID
 		GetExecution() *commonpb.WorkflowExecution
 
-		LoadWorkflowExecution() (MutableState, error)
-		LoadWorkflowExecutionForReplication(incomingVersion int64) (MutableState, error)
-		LoadExecutionStats() (*persistencespb.ExecutionStats, error)
+		LoadWorkflowExecution(ctx context.Context) (MutableState, error)
+		LoadWorkflowExecutionForReplication(ctx context.Context, incomingVersion int64) (MutableState, error)
+		LoadExecutionStats(ctx context.Context) (*persistencespb.ExecutionStats, error)
 		Clear()
 
 		Lock(ctx context.Context, caller CallerType) error
@@ -86,10 +86,12 @@ type (
 		) error
 
 		PersistWorkflowEvents(
+			ctx context.Context,
 			workflowEvents *persistence.WorkflowEvents,
 		) (int64, error)
 
 		CreateWorkflowExecution(
+			ctx context.Context,
 			now time.Time,
 			createMode persistence.CreateWorkflowMode,
 			prevRunID string,
@@ -99,6 +101,7 @@ type (
 			newWorkflowEvents []*persistence.WorkflowEvents,
 		) error
 		ConflictResolveWorkflowExecution(
+			ctx context.Context,
 			now time.Time,
 			conflictResolveMode persistence.ConflictResolveWorkflowMode,
 			resetMutableState MutableState,
@@ -109,22 +112,27 @@ type (
 			currentTransactionPolicy *TransactionPolicy,
 		) error
 		UpdateWorkflowExecutionAsActive(
+			ctx context.Context,
 			now time.Time,
 		) error
 		UpdateWorkflowExecutionWithNewAsActive(
+			ctx context.Context,
 			now time.Time,
 			newContext Context,
 			newMutableState MutableState,
 		) error
 		UpdateWorkflowExecutionAsPassive(
+			ctx context.Context,
 			now time.Time,
 		) error
 		UpdateWorkflowExecutionWithNewAsPassive(
+			ctx context.Context,
 			now time.Time,
 			newContext Context,
 			newMutableState MutableState,
 		) error
 		UpdateWorkflowExecutionWithNew(
+			ctx context.Context,
 			now time.Time,
 			updateMode persistence.UpdateWorkflowMode,
 			newContext Context,
@@ -242,8 +250,8 @@ func (c *ContextImpl) SetHistorySize(size int64) {
 	c.stats.HistorySize = size
 }
 
-func (c *ContextImpl) LoadExecutionStats() (*persistencespb.ExecutionStats, error) {
-	_, err := c.LoadWorkflowExecution()
+func (c *ContextImpl) LoadExecutionStats(ctx context.Context) (*persistencespb.ExecutionStats, error) {
+	_, err := c.LoadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -251,6 +259,7 @@ func (c *ContextImpl) LoadExecutionStats() (*persistencespb.ExecutionStats, erro
 }
 
 func (c *ContextImpl) LoadWorkflowExecutionForReplication(
+	ctx context.Context,
 	incomingVersion int64,
 ) (MutableState, error) {
 
@@ -260,7 +269,7 @@ func (c *ContextImpl) LoadWorkflowExecutionForReplication(
 	}
 
 	if c.MutableState == nil {
-		response, err := getWorkflowExecutionWithRetry(c.shard, &persistence.GetWorkflowExecutionRequest{
+		response, err := getWorkflowExecutionWithRetry(ctx, c.shard, &persistence.GetWorkflowExecutionRequest{
 			ShardID:     c.shard.GetShardID(),
 			NamespaceID: c.namespaceID.String(),
 			Execution:   c.workflowExecution,
@@ -310,6 +319,7 @@ func (c *ContextImpl) LoadWorkflowExecutionForReplication(
 		}
 
 		if err = c.UpdateWorkflowExecutionAsActive(
+			ctx,
 			c.shard.GetTimeSource().Now(),
 		); err != nil {
 			return nil, err
@@ -326,7 +336,7 @@ func (c *ContextImpl) LoadWorkflowExecutionForReplication(
 	return c.MutableState, nil
 }
 
-func (c *ContextImpl) LoadWorkflowExecution() (MutableState, error) {
+func (c *ContextImpl) LoadWorkflowExecution(ctx context.Context) (MutableState, error) {
 
 	namespaceEntry, err := c.shard.GetNamespaceRegistry().GetNamespaceByID(c.namespaceID)
 	if err != nil {
@@ -334,7 +344,7 @@ func (c *ContextImpl) LoadWorkflowExecution() (MutableState, error) {
 	}
 
 	if c.MutableState == nil {
-		response, err := getWorkflowExecutionWithRetry(c.shard, &persistence.GetWorkflowExecutionRequest{
+		response, err := getWorkflowExecutionWithRetry(ctx, c.shard, &persistence.GetWorkflowExecutionRequest{
 			ShardID:     c.shard.GetShardID(),
 			NamespaceID: c.namespaceID.String(),
 			Execution:   c.workflowExecution,
@@ -373,6 +383,7 @@ func (c *ContextImpl) LoadWorkflowExecution() (MutableState, error) {
 	}
 
 	if err = c.UpdateWorkflowExecutionAsActive(
+		ctx,
 		c.shard.GetTimeSource().Now(),
 	); err != nil {
 		return nil, err
@@ -390,12 +401,14 @@ func (c *ContextImpl) LoadWorkflowExecution() (MutableState, error) {
 }
 
 func (c *ContextImpl) PersistWorkflowEvents(
+	ctx context.Context,
 	workflowEvents *persistence.WorkflowEvents,
 ) (int64, error) {
-	return PersistWorkflowEvents(c.shard, workflowEvents)
+	return PersistWorkflowEvents(ctx, c.shard, workflowEvents)
 }
 
 func (c *ContextImpl) CreateWorkflowExecution(
+	ctx context.Context,
 	_ time.Time,
 	createMode persistence.CreateWorkflowMode,
 	prevRunID string,
@@ -412,7 +425,8 @@ func (c *ContextImpl) CreateWorkflowExecution(
 	}()
 
 	createRequest := &persistence.CreateWorkflowExecutionRequest{
-		ShardID: c.shard.GetShardID(),
+		ShardID:     c.shard.GetShardID(),
+		NamespaceID: newWorkflow.ExecutionInfo.NamespaceId,
 		// workflow create mode & prev run ID & version
 		Mode:                     createMode,
 		PreviousRunID:            prevRunID,
@@ -423,6 +437,7 @@ func (c *ContextImpl) CreateWorkflowExecution(
 	}
 
 	resp, err := createWorkflowExecutionWithRetry(
+		ctx,
 		c.shard,
 		createRequest,
 	)
@@ -442,6 +457,7 @@ func (c *ContextImpl) CreateWorkflowExecution(
 }
 
 func (c *ContextImpl) ConflictResolveWorkflowExecution(
+	ctx context.Context,
 	now time.Time,
 	conflictResolveMode persistence.ConflictResolveWorkflowMode,
 	resetMutableState MutableState,
@@ -523,6 +539,7 @@ func (c *ContextImpl) ConflictResolveWorkflowExecution(
 	}
 
 	if resetWorkflowSizeDiff, newWorkflowSizeDiff, currentWorkflowSizeDiff, err := c.transaction.ConflictResolveWorkflowExecution(
+		ctx,
 		conflictResolveMode,
 		resetWorkflow,
 		resetWorkflowEventsSeq,
@@ -550,16 +567,18 @@ func (c *ContextImpl) ConflictResolveWorkflowExecution(
 }
 
 func (c *ContextImpl) UpdateWorkflowExecutionAsActive(
+	ctx context.Context,
 	now time.Time,
 ) error {
 
 	// We only perform this check on active cluster for the namespace
-	forceTerminate, err := c.enforceSizeCheck()
+	forceTerminate, err := c.enforceSizeCheck(ctx)
 	if err != nil {
 		return err
 	}
 
 	if err := c.UpdateWorkflowExecutionWithNew(
+		ctx,
 		now,
 		persistence.UpdateWorkflowModeUpdateCurrent,
 		nil,
@@ -581,12 +600,14 @@ func (c *ContextImpl) UpdateWorkflowExecutionAsActive(
 }
 
 func (c *ContextImpl) UpdateWorkflowExecutionWithNewAsActive(
+	ctx context.Context,
 	now time.Time,
 	newContext Context,
 	newMutableState MutableState,
 ) error {
 
 	return c.UpdateWorkflowExecutionWithNew(
+		ctx,
 		now,
 		persistence.UpdateWorkflowModeUpdateCurrent,
 		newContext,
@@ -597,10 +618,12 @@ func (c *ContextImpl) UpdateWorkflowExecutionWithNewAsActive(
 }
 
 func (c *ContextImpl) UpdateWorkflowExecutionAsPassive(
+	ctx context.Context,
 	now time.Time,
 ) error {
 
 	return c.UpdateWorkflowExecutionWithNew(
+		ctx,
 		now,
 		persistence.UpdateWorkflowModeUpdateCurrent,
 		nil,
@@ -611,12 +634,14 @@ func (c *ContextImpl) UpdateWorkflowExecutionAsPassive(
 }
 
 func (c *ContextImpl) UpdateWorkflowExecutionWithNewAsPassive(
+	ctx context.Context,
 	now time.Time,
 	newContext Context,
 	newMutableState MutableState,
 ) error {
 
 	return c.UpdateWorkflowExecutionWithNew(
+		ctx,
 		now,
 		persistence.UpdateWorkflowModeUpdateCurrent,
 		newContext,
@@ -627,6 +652,7 @@ func (c *ContextImpl) UpdateWorkflowExecutionWithNewAsPassive(
 }
 
 func (c *ContextImpl) UpdateWorkflowExecutionWithNew(
+	ctx context.Context,
 	now time.Time,
 	updateMode persistence.UpdateWorkflowMode,
 	newContext Context,
@@ -690,6 +716,7 @@ func (c *ContextImpl) UpdateWorkflowExecutionWithNew(
 	}
 
 	if currentWorkflowSizeDiff, newWorkflowSizeDiff, err := c.transaction.UpdateWorkflowExecution(
+		ctx,
 		updateMode,
 		currentWorkflow,
 		currentWorkflowEventsSeq,
@@ -818,8 +845,7 @@ func (c *ContextImpl) ReapplyEvents(
 
 		for _, e := range events.Events {
 			event := e
-			switch event.GetEventType() {
-			case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+			if IsReapplicableEvent(event) {
 				reapplyEvents = append(reapplyEvents, event)
 			}
 		}
@@ -888,31 +914,48 @@ func (c *ContextImpl) ReapplyEvents(
 }
 
 // Returns true if execution is forced terminated
-func (c *ContextImpl) enforceSizeCheck() (bool, error) {
+func (c *ContextImpl) enforceSizeCheck(ctx context.Context) (bool, error) {
 	namespaceName := c.GetNamespace().String()
-	historySizeLimitWarn := c.config.HistorySizeLimitWarn(namespaceName)
-	historySizeLimitError := c.config.HistorySizeLimitError(namespaceName)
-	historyCountLimitWarn := c.config.HistoryCountLimitWarn(namespaceName)
-	historyCountLimitError := c.config.HistoryCountLimitError(namespaceName)
+	workflowType := c.MutableState.GetExecutionInfo().WorkflowTypeName
+	historySizeLimitWarn := c.config.HistorySizeLimitWarn(namespaceName, workflowType)
+	historySizeLimitError := c.config.HistorySizeLimitError(namespaceName, workflowType)
+	historyCountLimitWarn := c.config.HistoryCountLimitWarn(namespaceName, workflowType)
+	historyCountLimitError := c.config.HistoryCountLimitError(namespaceName, workflowType)
+	mutableStateSizeLimitWarn := c.config.MutableStateSizeLimitWarn(namespaceName)
+	mutableStateSizeLimitError := c.config.MutableStateSizeLimitError(namespaceName)
 
 	historySize := int(c.GetHistorySize())
 	historyCount := int(c.MutableState.GetNextEventID() - 1)
+	mutableStateSize := c.MutableState.GetApproximatePersistedSize()
+
+	historyLimitExceeded := historySize > historySizeLimitError || historyCount > historyCountLimitError
+	mutableStateLimitExceeded := mutableStateSizeLimitError > 0 && mutableStateSize > mutableStateSizeLimitError
 
 	// Hard terminate workflow if still running and breached size or count limit
-	if (historySize > historySizeLimitError || historyCount > historyCountLimitError) &&
+	if (historyLimitExceeded || mutableStateLimitExceeded) &&
 		c.MutableState.IsWorkflowExecutionRunning() {
-		c.logger.Error("history size exceeds error limit.",
-			tag.WorkflowNamespaceID(c.namespaceID.String()),
-			tag.WorkflowID(c.workflowExecution.GetWorkflowId()),
-			tag.WorkflowRunID(c.workflowExecution.GetRunId()),
-			tag.WorkflowHistorySize(historySize),
-			tag.WorkflowEventCount(historyCount))
+		reason := common.FailureReasonSizeExceedsLimit
+		if historyLimitExceeded {
+			c.logger.Error("history size exceeds error limit.",
+				tag.WorkflowNamespaceID(c.namespaceID.String()),
+				tag.WorkflowID(c.workflowExecution.GetWorkflowId()),
+				tag.WorkflowRunID(c.workflowExecution.GetRunId()),
+				tag.WorkflowHistorySize(historySize),
+				tag.WorkflowEventCount(historyCount))
+		} else {
+			reason = common.FailureReasonMutableStateSizeExceedsLimit
+			c.logger.Error("mutable state size exceeds error limit.",
+				tag.WorkflowNamespaceID(c.namespaceID.String()),
+				tag.WorkflowID(c.workflowExecution.GetWorkflowId()),
+				tag.WorkflowRunID(c.workflowExecution.GetRunId()),
+				tag.WorkflowMutableStateSize(mutableStateSize))
+		}
 
 		// Discard pending changes in MutableState so we can apply terminate state transition
 		c.Clear()
 
 		// Reload mutable state
-		mutableState, err := c.LoadWorkflowExecution()
+		mutableState, err := c.LoadWorkflowExecution(ctx)
 		if err != nil {
 			return false, err
 		}
@@ -923,7 +966,7 @@ func (c *ContextImpl) enforceSizeCheck() (bool, error) {
 		if err := TerminateWorkflow(
 			mutableState,
 			eventBatchFirstEventID,
-			common.FailureReasonSizeExceedsLimit,
+			reason,
 			nil,
 			consts.IdentityHistoryService,
 		); err != nil {
@@ -942,6 +985,13 @@ func (c *ContextImpl) enforceSizeCheck() (bool, error) {
 			tag.WorkflowHistorySize(historySize),
 			tag.WorkflowEventCount(historyCount))
 	}
+	if mutableStateSizeLimitWarn > 0 && mutableStateSize > mutableStateSizeLimitWarn {
+		c.logger.Warn("mutable state size exceeds warn limit.",
+			tag.WorkflowNamespaceID(c.MutableState.GetExecutionInfo().NamespaceId),
+			tag.WorkflowID(c.MutableState.GetExecutionInfo().WorkflowId),
+			tag.WorkflowRunID(c.MutableState.GetExecutionState().RunId),
+			tag.WorkflowMutableStateSize(mutableStateSize))
+	}
 
 	return false, nil
 }
@@ -0,0 +1,110 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	"strings"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/namespace"
+)
+
+type (
+	// EventsReapplyPolicy decides which events of a workflow are reapplied onto its current run when the workflow
+	// is reset or a branch of its history loses a conflict. It is only asked about reapplicable events, i.e. signal
+	// events.
+	EventsReapplyPolicy interface {
+		ShouldReapply(namespaceName namespace.Name, event *historypb.HistoryEvent) bool
+	}
+
+	eventTypesReapplyPolicy struct {
+		eventTypes dynamicconfig.StringPropertyFnWithNamespaceFilter
+	}
+)
+
+var _ EventsReapplyPolicy = (*eventTypesReapplyPolicy)(nil)
+
+// NewEventsReapplyPolicy creates an events reapply policy reapplying the events whose types are in the
+// comma separated list of event types of the namespace, e.g. WorkflowExecutionSignaled
+func NewEventsReapplyPolicy(
+	eventTypes dynamicconfig.StringPropertyFnWithNamespaceFilter,
+) EventsReapplyPolicy {
+
+	return &eventTypesReapplyPolicy{
+		eventTypes: eventTypes,
+	}
+}
+
+func (p *eventTypesReapplyPolicy) ShouldReapply(
+	namespaceName namespace.Name,
+	event *historypb.HistoryEvent,
+) bool {
+
+	for _, eventType := range strings.Split(p.eventTypes(namespaceName.String()), ",") {
+		if strings.TrimSpace(eventType) == event.GetEventType().String() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReapplicableEvent returns whether the event can be reapplied onto another run of the workflow. Markers are not,
+// they are recorded by a workflow task and replaying the run would not reproduce one out of a workflow task.
+func IsReapplicableEvent(
+	event *historypb.HistoryEvent,
+) bool {
+
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReapplyEvent adds a copy of the reapplicable event to the mutable state
+func ReapplyEvent(
+	mutableState MutableState,
+	event *historypb.HistoryEvent,
+) error {
+
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		attr := event.GetWorkflowExecutionSignaledEventAttributes()
+		_, err := mutableState.AddWorkflowExecutionSignaled(
+			attr.GetSignalName(),
+			attr.GetInput(),
+			attr.GetIdentity(),
+			attr.GetHeader(),
+		)
+		return err
+	default:
+		// events other than signal will be ignored
+		return nil
+	}
+}
@@ -0,0 +1,134 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+// Values of the TemporalFailureSource search attribute
+const (
+	FailureSourceWorkflow      = "Workflow"
+	FailureSourceActivity      = "Activity"
+	FailureSourceChildWorkflow = "ChildWorkflow"
+)
+
+// Values of the TemporalFailureType search attribute for failures without an application error type
+const (
+	FailureTypeTimeout    = "Timeout"
+	FailureTypeCanceled   = "Canceled"
+	FailureTypeTerminated = "Terminated"
+	FailureTypeServer     = "Server"
+	FailureTypeReset      = "Reset"
+)
+
+// FailureClassification is the taxonomy of the failure an execution closed with
+type FailureClassification struct {
+	// Source is where the failure originated: the workflow itself, or the activity or child workflow
+	// whose failure the workflow returned
+	Source string
+	// Type is the application error type of the root cause, or one of the FailureType values
+	Type string
+	// TimeoutType is set if the root cause is a timeout, e.g. StartToClose
+	TimeoutType string
+}
+
+// ClassifyFailure classifies the failure a workflow failed with. Source is taken from the outermost activity or
+// child workflow wrapper, Type and TimeoutType from the innermost cause.
+func ClassifyFailure(failure *failurepb.Failure) FailureClassification {
+	classification := FailureClassification{Source: FailureSourceWorkflow}
+	for failure != nil {
+		switch info := failure.GetFailureInfo().(type) {
+		case *failurepb.Failure_ActivityFailureInfo:
+			if classification.Source == FailureSourceWorkflow {
+				classification.Source = FailureSourceActivity
+			}
+			failure = failure.GetCause()
+			continue
+		case *failurepb.Failure_ChildWorkflowExecutionFailureInfo:
+			if classification.Source == FailureSourceWorkflow {
+				classification.Source = FailureSourceChildWorkflow
+			}
+			failure = failure.GetCause()
+			continue
+		case *failurepb.Failure_ApplicationFailureInfo:
+			classification.Type = info.ApplicationFailureInfo.GetType()
+		case *failurepb.Failure_TimeoutFailureInfo:
+			classification.Type = FailureTypeTimeout
+			classification.TimeoutType = timeoutTypeName(info.TimeoutFailureInfo.GetTimeoutType())
+		case *failurepb.Failure_CanceledFailureInfo:
+			classification.Type = FailureTypeCanceled
+		case *failurepb.Failure_TerminatedFailureInfo:
+			classification.Type = FailureTypeTerminated
+		case *failurepb.Failure_ServerFailureInfo:
+			classification.Type = FailureTypeServer
+		case *failurepb.Failure_ResetWorkflowFailureInfo:
+			classification.Type = FailureTypeReset
+		}
+		break
+	}
+	return classification
+}
+
+// setFailureSearchAttributes records classification in the TemporalFailureSource, TemporalFailureType and
+// TemporalTimeoutType search attributes of a closing execution, so that the close visibility record carries them.
+// Empty values are left unset.
+func setFailureSearchAttributes(
+	executionInfo *persistencespb.WorkflowExecutionInfo,
+	classification FailureClassification,
+) error {
+
+	values := map[string]string{
+		searchattribute.TemporalFailureSource: classification.Source,
+		searchattribute.TemporalFailureType:   classification.Type,
+		searchattribute.TemporalTimeoutType:   classification.TimeoutType,
+	}
+	for name, value := range values {
+		if value == "" {
+			continue
+		}
+		payload, err := searchattribute.EncodeValue(value, enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+		if err != nil {
+			return err
+		}
+		if executionInfo.SearchAttributes == nil {
+			executionInfo.SearchAttributes = make(map[string]*commonpb.Payload, len(values))
+		}
+		executionInfo.SearchAttributes[name] = payload
+	}
+	return nil
+}
+
+func timeoutTypeName(timeoutType enumspb.TimeoutType) string {
+	if timeoutType == enumspb.TIMEOUT_TYPE_UNSPECIFIED {
+		return ""
+	}
+	return timeoutType.String()
+}
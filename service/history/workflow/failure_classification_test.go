@@ -0,0 +1,104 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+func Test_ClassifyFailure(t *testing.T) {
+	a := assert.New(t)
+
+	applicationFailure := &failurepb.Failure{
+		FailureInfo: &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: &failurepb.ApplicationFailureInfo{
+			Type: "DeadlineExceeded",
+		}},
+	}
+	timeoutFailure := &failurepb.Failure{
+		FailureInfo: &failurepb.Failure_TimeoutFailureInfo{TimeoutFailureInfo: &failurepb.TimeoutFailureInfo{
+			TimeoutType: enumspb.TIMEOUT_TYPE_HEARTBEAT,
+		}},
+	}
+	activityFailure := func(cause *failurepb.Failure) *failurepb.Failure {
+		return &failurepb.Failure{
+			FailureInfo: &failurepb.Failure_ActivityFailureInfo{ActivityFailureInfo: &failurepb.ActivityFailureInfo{}},
+			Cause:       cause,
+		}
+	}
+	childWorkflowFailure := func(cause *failurepb.Failure) *failurepb.Failure {
+		return &failurepb.Failure{
+			FailureInfo: &failurepb.Failure_ChildWorkflowExecutionFailureInfo{
+				ChildWorkflowExecutionFailureInfo: &failurepb.ChildWorkflowExecutionFailureInfo{},
+			},
+			Cause: cause,
+		}
+	}
+
+	a.Equal(FailureClassification{Source: FailureSourceWorkflow}, ClassifyFailure(nil))
+	a.Equal(FailureClassification{
+		Source: FailureSourceWorkflow,
+		Type:   "DeadlineExceeded",
+	}, ClassifyFailure(applicationFailure))
+	a.Equal(FailureClassification{
+		Source: FailureSourceActivity,
+		Type:   "DeadlineExceeded",
+	}, ClassifyFailure(activityFailure(applicationFailure)))
+	a.Equal(FailureClassification{
+		Source:      FailureSourceActivity,
+		Type:        FailureTypeTimeout,
+		TimeoutType: "Heartbeat",
+	}, ClassifyFailure(activityFailure(timeoutFailure)))
+	a.Equal(FailureClassification{
+		Source:      FailureSourceChildWorkflow,
+		Type:        FailureTypeTimeout,
+		TimeoutType: "Heartbeat",
+	}, ClassifyFailure(childWorkflowFailure(activityFailure(timeoutFailure))))
+}
+
+func Test_SetFailureSearchAttributes(t *testing.T) {
+	a := assert.New(t)
+
+	executionInfo := &persistencespb.WorkflowExecutionInfo{}
+	a.NoError(setFailureSearchAttributes(executionInfo, FailureClassification{
+		Source: FailureSourceActivity,
+		Type:   "DeadlineExceeded",
+	}))
+	a.Len(executionInfo.SearchAttributes, 2)
+	a.NotContains(executionInfo.SearchAttributes, searchattribute.TemporalTimeoutType)
+
+	value, err := searchattribute.DecodeValue(
+		executionInfo.SearchAttributes[searchattribute.TemporalFailureType],
+		enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	)
+	a.NoError(err)
+	a.Equal("DeadlineExceeded", value)
+}
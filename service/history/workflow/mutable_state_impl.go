@@ -108,6 +108,8 @@ type (
 		updateActivityInfos            map[int64]*persistencespb.ActivityInfo // Modified activities from last update.
 		deleteActivityInfos            map[int64]struct{}                     // Deleted activities from last update.
 		syncActivityTasks              map[int64]struct{}                     // Activity to be sync to remote
+		activityProgressWriteTimes     map[int64]time.Time                    // Schedule Event ID -> time the progress was last written.
+		coalescedActivityProgress      bool                                   // Progress only held in memory since last update.
 
 		pendingTimerInfoIDs     map[string]*persistencespb.TimerInfo // User Timer ID -> Timer Info.
 		pendingTimerEventIDToID map[int64]string                     // User Timer Start Event ID -> User Timer ID.
@@ -130,6 +132,8 @@ type (
 		updateSignalRequestedIDs  map[string]struct{} // Set of signaled requestIds since last update
 		deleteSignalRequestedIDs  map[string]struct{} // Deleted signaled requestId
 
+		pendingInfoSizes *pendingInfoSizes // Size of the pending infos as of the last closed transaction.
+
 		executionInfo  *persistencespb.WorkflowExecutionInfo // Workflow mutable state info.
 		executionState *persistencespb.WorkflowExecutionState
 
@@ -196,6 +200,7 @@ func NewMutableState(
 		pendingActivityIDToEventID:     make(map[string]int64),
 		deleteActivityInfos:            make(map[int64]struct{}),
 		syncActivityTasks:              make(map[int64]struct{}),
+		activityProgressWriteTimes:     make(map[int64]time.Time),
 
 		pendingTimerInfoIDs:     make(map[string]*persistencespb.TimerInfo),
 		pendingTimerEventIDToID: make(map[int64]string),
@@ -218,6 +223,8 @@ func NewMutableState(
 		pendingSignalRequestedIDs: make(map[string]struct{}),
 		deleteSignalRequestedIDs:  make(map[string]struct{}),
 
+		pendingInfoSizes: newPendingInfoSizes(),
+
 		currentVersion:   namespaceEntry.FailoverVersion(),
 		bufferEventsInDB: nil,
 		stateInDB:        enumsspb.WORKFLOW_EXECUTION_STATE_VOID,
@@ -317,6 +324,7 @@ func newMutableStateBuilderFromDB(
 	}
 
 	mutableState.pendingSignalRequestedIDs = convert.StringSliceToSet(dbRecord.SignalRequestedIds)
+	mutableState.pendingInfoSizes.load(mutableState)
 	mutableState.executionState = dbRecord.ExecutionState
 	mutableState.executionInfo = dbRecord.ExecutionInfo
 
@@ -922,6 +930,40 @@ func (e *MutableStateImpl) UpdateActivityProgress(
 	ai.LastHeartbeatUpdateTime = &now
 	e.updateActivityInfos[ai.ScheduleId] = ai
 	e.syncActivityTasks[ai.ScheduleId] = struct{}{}
+	e.activityProgressWriteTimes[ai.ScheduleId] = now
+}
+
+// CoalesceActivityProgress updates the progress of the activity in memory only, and returns true, if its details
+// are unchanged and its progress was written less than interval ago, or half its heartbeat timeout if shorter.
+// The coalesced progress lives only in the cached mutable state until the next update of the workflow writes it.
+// If the mutable state is evicted from the cache or cleared first, the progress is lost and the activity keeps
+// the last written heartbeat time, so a heartbeat timeout may fire up to interval early.
+func (e *MutableStateImpl) CoalesceActivityProgress(
+	ai *persistencespb.ActivityInfo,
+	request *workflowservice.RecordActivityTaskHeartbeatRequest,
+	interval time.Duration,
+) bool {
+
+	if heartbeatTimeout := timestamp.DurationValue(ai.HeartbeatTimeout); heartbeatTimeout > 0 && interval > heartbeatTimeout/2 {
+		interval = heartbeatTimeout / 2
+	}
+	writeTime, ok := e.activityProgressWriteTimes[ai.ScheduleId]
+	if interval <= 0 || !ok || e.timeSource.Now().Sub(writeTime) >= interval ||
+		!proto.Equal(ai.LastHeartbeatDetails, request.Details) {
+		return false
+	}
+
+	e.UpdateActivityProgress(ai, request)
+	// keep the time the progress was written at
+	e.activityProgressWriteTimes[ai.ScheduleId] = writeTime
+	e.coalescedActivityProgress = true
+	return true
+}
+
+// HasCoalescedActivityProgress returns true if the progress of activities was updated in memory only since the
+// last update of the workflow
+func (e *MutableStateImpl) HasCoalescedActivityProgress() bool {
+	return e.coalescedActivityProgress
 }
 
 // ReplicateActivityInfo replicate the necessary activity information
@@ -1022,6 +1064,7 @@ func (e *MutableStateImpl) DeleteActivity(
 	}
 
 	delete(e.updateActivityInfos, scheduleEventID)
+	delete(e.activityProgressWriteTimes, scheduleEventID)
 	e.deleteActivityInfos[scheduleEventID] = struct{}{}
 	return nil
 }
@@ -1678,6 +1721,28 @@ func (e *MutableStateImpl) addBinaryCheckSumIfNotExists(
 	return nil
 }
 
+func (e *MutableStateImpl) compactResetPoints(
+	now time.Time,
+	maxResetPoints int,
+) {
+
+	maxCompacted := e.config.MaxCompactedResetPoints(e.namespaceEntry.Name().String())
+	resetPoints, compacted := compactAutoResetPoints(
+		e.executionInfo.AutoResetPoints,
+		now,
+		maxResetPoints,
+		maxCompacted,
+	)
+	if compacted == 0 {
+		return
+	}
+	e.executionInfo.AutoResetPoints = resetPoints
+	e.metricsClient.Scope(
+		metrics.HistoryRespondWorkflowTaskCompletedScope,
+		metrics.NamespaceTag(e.namespaceEntry.Name().String()),
+	).AddCounter(metrics.CompactedResetPointsCounter, int64(compacted))
+}
+
 // TODO: we will release the restriction when reset API allow those pending
 
 // CheckResettable check if workflow can be reset
@@ -2320,6 +2385,12 @@ func (e *MutableStateImpl) ReplicateWorkflowExecutionFailedEvent(
 	}
 	e.executionInfo.CompletionEventBatchId = firstEventID // Used when completion event needs to be loaded from database
 	e.executionInfo.NewExecutionRunId = event.GetWorkflowExecutionFailedEventAttributes().GetNewExecutionRunId()
+	if err := setFailureSearchAttributes(
+		e.executionInfo,
+		ClassifyFailure(event.GetWorkflowExecutionFailedEventAttributes().GetFailure()),
+	); err != nil {
+		return err
+	}
 	e.ClearStickyness()
 	e.writeEventToCache(event)
 	return nil
@@ -2362,6 +2433,14 @@ func (e *MutableStateImpl) ReplicateWorkflowExecutionTimedoutEvent(
 	}
 	e.executionInfo.CompletionEventBatchId = firstEventID // Used when completion event needs to be loaded from database
 	e.executionInfo.NewExecutionRunId = event.GetWorkflowExecutionTimedOutEventAttributes().GetNewExecutionRunId()
+	// a workflow timeout surfaces to callers as a StartToClose timeout, like a child workflow timeout does
+	if err := setFailureSearchAttributes(e.executionInfo, FailureClassification{
+		Source:      FailureSourceWorkflow,
+		Type:        FailureTypeTimeout,
+		TimeoutType: timeoutTypeName(enumspb.TIMEOUT_TYPE_START_TO_CLOSE),
+	}); err != nil {
+		return err
+	}
 	e.ClearStickyness()
 	e.writeEventToCache(event)
 	return nil
@@ -2963,6 +3042,12 @@ func (e *MutableStateImpl) ReplicateWorkflowExecutionTerminatedEvent(
 	}
 	e.executionInfo.CompletionEventBatchId = firstEventID // Used when completion event needs to be loaded from database
 	e.executionInfo.NewExecutionRunId = ""
+	if err := setFailureSearchAttributes(e.executionInfo, FailureClassification{
+		Source: FailureSourceWorkflow,
+		Type:   FailureTypeTerminated,
+	}); err != nil {
+		return err
+	}
 	e.ClearStickyness()
 	e.writeEventToCache(event)
 	return nil
@@ -3101,6 +3186,42 @@ func rolloverAutoResetPointsWithExpiringTime(
 	}
 }
 
+// compactAutoResetPoints drops reset points which can no longer be used, either because the run they
+// point to is past retention or because the max number of reset points has been lowered since they
+// were added. At most maxCompacted points are dropped, oldest first.
+func compactAutoResetPoints(
+	resetPoints *workflowpb.ResetPoints,
+	now time.Time,
+	maxResetPoints int,
+	maxCompacted int,
+) (*workflowpb.ResetPoints, int) {
+
+	if resetPoints == nil || len(resetPoints.Points) == 0 || maxCompacted <= 0 {
+		return resetPoints, 0
+	}
+
+	compacted := 0
+	newPoints := make([]*workflowpb.ResetPointInfo, 0, len(resetPoints.Points))
+	for _, rp := range resetPoints.Points {
+		if compacted < maxCompacted && rp.ExpireTime != nil && !rp.ExpireTime.After(now) {
+			compacted++
+			continue
+		}
+		newPoints = append(newPoints, rp)
+	}
+	for maxResetPoints > 0 && len(newPoints) > maxResetPoints && compacted < maxCompacted {
+		newPoints = newPoints[1:]
+		compacted++
+	}
+
+	if compacted == 0 {
+		return resetPoints, 0
+	}
+	return &workflowpb.ResetPoints{
+		Points: newPoints,
+	}, compacted
+}
+
 func (e *MutableStateImpl) ReplicateWorkflowExecutionContinuedAsNewEvent(
 	firstEventID int64,
 	continueAsNewEvent *historypb.HistoryEvent,
@@ -3892,9 +4013,12 @@ func (e *MutableStateImpl) cleanupTransaction(
 	_ TransactionPolicy,
 ) error {
 
+	e.pendingInfoSizes.size(e, true)
+
 	e.updateActivityInfos = make(map[int64]*persistencespb.ActivityInfo)
 	e.deleteActivityInfos = make(map[int64]struct{})
 	e.syncActivityTasks = make(map[int64]struct{})
+	e.coalescedActivityProgress = false
 
 	e.updateTimerInfos = make(map[string]*persistencespb.TimerInfo)
 	e.deleteTimerInfos = make(map[string]struct{})
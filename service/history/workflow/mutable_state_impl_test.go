@@ -37,6 +37,8 @@ import (
 	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
 
 	enumsspb "go.temporal.io/server/api/enums/v1"
 	historyspb "go.temporal.io/server/api/history/v1"
@@ -49,7 +51,6 @@ import (
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/payloads"
-	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/versionhistory"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/service/history/configs"
@@ -96,12 +97,11 @@ func (s *mutableStateSuite) SetupTest() {
 	s.mockConfig = tests.NewDynamicConfig()
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          0,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          0,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		s.mockConfig,
 	)
 	// set the checksum probabilities to 100% for exercising during test
@@ -337,6 +337,86 @@ func (s *mutableStateSuite) TestMergeMapOfPayload() {
 	s.Equal(2, len(resultMap))
 }
 
+func (s *mutableStateSuite) TestQuarantineWorkflow() {
+	s.True(IsQuarantinableWorkflowTaskFailure(enumspb.WORKFLOW_TASK_FAILED_CAUSE_NON_DETERMINISTIC_ERROR))
+	s.False(IsQuarantinableWorkflowTaskFailure(enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW))
+	s.False(IsWorkflowQuarantined(s.mutableState))
+
+	now := time.Now().UTC()
+	err := QuarantineWorkflow(s.mutableState, enumspb.WORKFLOW_TASK_FAILED_CAUSE_NON_DETERMINISTIC_ERROR.String(), now)
+	s.NoError(err)
+	s.True(IsWorkflowQuarantined(s.mutableState))
+	s.Len(s.mutableState.InsertVisibilityTasks, 1)
+
+	ReleaseQuarantinedWorkflow(s.mutableState, now)
+	s.False(IsWorkflowQuarantined(s.mutableState))
+	s.Len(s.mutableState.InsertVisibilityTasks, 2)
+
+	// no-op for executions which are not quarantined
+	ReleaseQuarantinedWorkflow(s.mutableState, now)
+	s.Len(s.mutableState.InsertVisibilityTasks, 2)
+}
+
+func (s *mutableStateSuite) TestWorkflowTaskConsecutiveFailures() {
+	version := int64(12)
+	runID := uuid.New()
+	s.mutableState = TestGlobalMutableState(
+		s.mockShard,
+		s.mockEventsCache,
+		s.logger,
+		version,
+		runID,
+	)
+
+	scheduleEvent, startedEvent := s.prepareTransientWorkflowTaskCompletionFirstBatchReplicated(version, runID)
+	s.False(ShouldQuarantineWorkflow(s.mutableState, 2))
+	_, err := s.mutableState.AddWorkflowTaskTimedOutEvent(scheduleEvent.GetEventId(), startedEvent.GetEventId())
+	s.NoError(err)
+	s.Equal(int32(1), s.mutableState.GetExecutionInfo().WorkflowTaskConsecutiveFailures)
+
+	// a signal resets the workflow task attempt but not the failure count
+	_, err = s.mutableState.AddWorkflowExecutionSignaled("some random signal", nil, "some random identity", nil)
+	s.NoError(err)
+	workflowTask, err := s.mutableState.AddWorkflowTaskScheduledEvent(false)
+	s.NoError(err)
+	s.Equal(int32(1), workflowTask.Attempt)
+	_, workflowTask, err = s.mutableState.AddWorkflowTaskStartedEvent(workflowTask.ScheduleID, uuid.New(), &taskqueuepb.TaskQueue{}, "some random identity")
+	s.NoError(err)
+	_, err = s.mutableState.AddWorkflowTaskFailedEvent(
+		workflowTask.ScheduleID,
+		workflowTask.StartedID,
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_NON_DETERMINISTIC_ERROR,
+		failure.NewServerFailure("some random workflow task failure details", false),
+		"some random workflow task failure identity",
+		"", "", "", 0,
+	)
+	s.NoError(err)
+	s.Equal(int32(2), s.mutableState.GetExecutionInfo().WorkflowTaskConsecutiveFailures)
+	s.True(ShouldQuarantineWorkflow(s.mutableState, 2))
+	s.False(ShouldQuarantineWorkflow(s.mutableState, 0))
+
+	versionHistory, err := versionhistory.GetCurrentVersionHistory(s.mutableState.GetExecutionInfo().GetVersionHistories())
+	s.NoError(err)
+	err = versionhistory.AddOrUpdateVersionHistoryItem(versionHistory, &historyspb.VersionHistoryItem{
+		EventId: s.mutableState.GetNextEventID() - 1,
+		Version: version,
+	})
+	s.NoError(err)
+	workflowTask, err = s.mutableState.AddWorkflowTaskScheduledEvent(false)
+	s.NoError(err)
+	_, workflowTask, err = s.mutableState.AddWorkflowTaskStartedEvent(workflowTask.ScheduleID, uuid.New(), &taskqueuepb.TaskQueue{}, "some random identity")
+	s.NoError(err)
+	_, err = s.mutableState.AddWorkflowTaskCompletedEvent(
+		workflowTask.ScheduleID,
+		workflowTask.StartedID,
+		&workflowservice.RespondWorkflowTaskCompletedRequest{Identity: "some random identity"},
+		10,
+	)
+	s.NoError(err)
+	s.Equal(int32(0), s.mutableState.GetExecutionInfo().WorkflowTaskConsecutiveFailures)
+	s.False(ShouldQuarantineWorkflow(s.mutableState, 2))
+}
+
 func (s *mutableStateSuite) TestEventReapplied() {
 	runID := uuid.New()
 	eventID := int64(1)
@@ -349,6 +429,78 @@ func (s *mutableStateSuite) TestEventReapplied() {
 	s.True(isReapplied)
 }
 
+func (s *mutableStateSuite) TestCoalesceActivityProgress() {
+	ai := &persistencespb.ActivityInfo{ScheduleId: 5}
+	s.mutableState.pendingActivityInfoIDs[ai.ScheduleId] = ai
+	request := &workflowservice.RecordActivityTaskHeartbeatRequest{Details: payloads.EncodeString("progress")}
+
+	// the first heartbeat is written
+	s.False(s.mutableState.CoalesceActivityProgress(ai, request, time.Hour))
+	s.mutableState.UpdateActivityProgress(ai, request)
+
+	// unchanged details within the interval are held in memory until the next update
+	s.True(s.mutableState.CoalesceActivityProgress(ai, request, time.Hour))
+	s.True(s.mutableState.HasCoalescedActivityProgress())
+	s.Contains(s.mutableState.updateActivityInfos, ai.ScheduleId)
+
+	// changed details are written
+	changedRequest := &workflowservice.RecordActivityTaskHeartbeatRequest{Details: payloads.EncodeString("more progress")}
+	s.False(s.mutableState.CoalesceActivityProgress(ai, changedRequest, time.Hour))
+
+	// the interval is capped at half the heartbeat timeout
+	ai.HeartbeatTimeout = timestamp.DurationPtr(time.Nanosecond)
+	s.False(s.mutableState.CoalesceActivityProgress(ai, request, time.Hour))
+
+	s.NoError(s.mutableState.cleanupTransaction(TransactionPolicyActive))
+	s.False(s.mutableState.HasCoalescedActivityProgress())
+}
+
+func (s *mutableStateSuite) TestCompactAutoResetPoints() {
+	now := time.Now().UTC()
+	expired := now.Add(-time.Hour)
+	notExpired := now.Add(time.Hour)
+	newResetPoints := func(expireTimes ...*time.Time) *workflowpb.ResetPoints {
+		resetPoints := &workflowpb.ResetPoints{}
+		for i, expireTime := range expireTimes {
+			resetPoints.Points = append(resetPoints.Points, &workflowpb.ResetPointInfo{
+				BinaryChecksum:               uuid.New(),
+				FirstWorkflowTaskCompletedId: int64(i + 1),
+				ExpireTime:                   expireTime,
+			})
+		}
+		return resetPoints
+	}
+
+	// nothing to compact
+	resetPoints := newResetPoints(nil, &notExpired)
+	compactedPoints, compacted := compactAutoResetPoints(resetPoints, now, 5, 5)
+	s.Equal(0, compacted)
+	s.Equal(resetPoints, compactedPoints)
+
+	// expired points are dropped
+	resetPoints = newResetPoints(&expired, nil, &expired, &notExpired)
+	compactedPoints, compacted = compactAutoResetPoints(resetPoints, now, 5, 5)
+	s.Equal(2, compacted)
+	s.Equal([]*workflowpb.ResetPointInfo{resetPoints.Points[1], resetPoints.Points[3]}, compactedPoints.Points)
+
+	// excess points are dropped oldest first
+	resetPoints = newResetPoints(nil, nil, nil, nil)
+	compactedPoints, compacted = compactAutoResetPoints(resetPoints, now, 2, 5)
+	s.Equal(2, compacted)
+	s.Equal(resetPoints.Points[2:], compactedPoints.Points)
+
+	// compaction is bounded
+	resetPoints = newResetPoints(&expired, &expired, nil, nil)
+	compactedPoints, compacted = compactAutoResetPoints(resetPoints, now, 1, 1)
+	s.Equal(1, compacted)
+	s.Equal(resetPoints.Points[1:], compactedPoints.Points)
+
+	// compaction disabled
+	compactedPoints, compacted = compactAutoResetPoints(resetPoints, now, 1, 0)
+	s.Equal(0, compacted)
+	s.Equal(resetPoints, compactedPoints)
+}
+
 func (s *mutableStateSuite) TestTransientWorkflowTaskSchedule_CurrentVersionChanged() {
 	version := int64(2000)
 	runID := uuid.New()
@@ -603,6 +755,61 @@ func (s *mutableStateSuite) newNamespaceCacheEntry() *namespace.Namespace {
 	)
 }
 
+func (s *mutableStateSuite) TestGetApproximatePersistedSize() {
+	dbState := s.buildWorkflowMutableState()
+	var err error
+	s.mutableState, err = newMutableStateBuilderFromDB(s.mockShard, s.mockEventsCache, s.logger, tests.LocalNamespaceEntry, dbState, 123)
+	s.NoError(err)
+	s.Equal(s.persistedSize(), s.mutableState.GetApproximatePersistedSize())
+
+	// changes of the current transaction are included before it's closed
+	timerInfo := s.mutableState.pendingTimerInfoIDs["25"]
+	timerInfo.TaskStatus = TimerTaskStatusCreated
+	s.NoError(s.mutableState.UpdateUserTimer(timerInfo))
+	s.NoError(s.mutableState.DeletePendingChildExecution(80))
+	s.Equal(s.persistedSize(), s.mutableState.GetApproximatePersistedSize())
+
+	s.NoError(s.mutableState.cleanupTransaction(TransactionPolicyActive))
+	s.Equal(s.persistedSize(), s.mutableState.GetApproximatePersistedSize())
+
+	// a timer deleted and started again within a transaction is counted once
+	s.NoError(s.mutableState.DeleteUserTimer("25"))
+	_, err = s.mutableState.ReplicateTimerStartedEvent(&historypb.HistoryEvent{
+		EventId:   130,
+		EventTime: timestamp.TimeNowPtrUtc(),
+		EventType: enumspb.EVENT_TYPE_TIMER_STARTED,
+		Attributes: &historypb.HistoryEvent_TimerStartedEventAttributes{TimerStartedEventAttributes: &historypb.TimerStartedEventAttributes{
+			TimerId:            "25",
+			StartToFireTimeout: timestamp.DurationFromSeconds(10),
+		}},
+	})
+	s.NoError(err)
+	s.Equal(s.persistedSize(), s.mutableState.GetApproximatePersistedSize())
+	s.NoError(s.mutableState.cleanupTransaction(TransactionPolicyActive))
+	s.Equal(s.persistedSize(), s.mutableState.GetApproximatePersistedSize())
+}
+
+// persistedSize computes the size GetApproximatePersistedSize tracks from all the pending infos
+func (s *mutableStateSuite) persistedSize() int {
+	size := s.mutableState.executionInfo.Size() + s.mutableState.executionState.Size()
+	for _, activityInfo := range s.mutableState.pendingActivityInfoIDs {
+		size += activityInfo.Size()
+	}
+	for _, timerInfo := range s.mutableState.pendingTimerInfoIDs {
+		size += timerInfo.Size()
+	}
+	for _, childExecutionInfo := range s.mutableState.pendingChildExecutionInfoIDs {
+		size += childExecutionInfo.Size()
+	}
+	for _, requestCancelInfo := range s.mutableState.pendingRequestCancelInfoIDs {
+		size += requestCancelInfo.Size()
+	}
+	for _, signalInfo := range s.mutableState.pendingSignalInfoIDs {
+		size += signalInfo.Size()
+	}
+	return size
+}
+
 func (s *mutableStateSuite) buildWorkflowMutableState() *persistencespb.WorkflowMutableState {
 	namespaceID := tests.NamespaceID
 	we := commonpb.WorkflowExecution{
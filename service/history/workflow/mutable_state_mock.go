@@ -888,6 +888,20 @@ func (mr *MockMutableStateMockRecorder) CloseTransactionAsSnapshot(now, transact
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseTransactionAsSnapshot", reflect.TypeOf((*MockMutableState)(nil).CloseTransactionAsSnapshot), now, transactionPolicy)
 }
 
+// CoalesceActivityProgress mocks base method.
+func (m *MockMutableState) CoalesceActivityProgress(ai *v19.ActivityInfo, request *v16.RecordActivityTaskHeartbeatRequest, interval time.Duration) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CoalesceActivityProgress", ai, request, interval)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CoalesceActivityProgress indicates an expected call of CoalesceActivityProgress.
+func (mr *MockMutableStateMockRecorder) CoalesceActivityProgress(ai, request, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoalesceActivityProgress", reflect.TypeOf((*MockMutableState)(nil).CoalesceActivityProgress), ai, request, interval)
+}
+
 // CreateTransientWorkflowTaskEvents mocks base method.
 func (m *MockMutableState) CreateTransientWorkflowTaskEvents(di *WorkflowTaskInfo, identity string) (*v13.HistoryEvent, *v13.HistoryEvent) {
 	m.ctrl.T.Helper()
@@ -1015,6 +1029,20 @@ func (mr *MockMutableStateMockRecorder) GetActivityScheduledEvent(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivityScheduledEvent", reflect.TypeOf((*MockMutableState)(nil).GetActivityScheduledEvent), arg0)
 }
 
+// GetApproximatePersistedSize mocks base method.
+func (m *MockMutableState) GetApproximatePersistedSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApproximatePersistedSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetApproximatePersistedSize indicates an expected call of GetApproximatePersistedSize.
+func (mr *MockMutableStateMockRecorder) GetApproximatePersistedSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApproximatePersistedSize", reflect.TypeOf((*MockMutableState)(nil).GetApproximatePersistedSize))
+}
+
 // GetChildExecutionInfo mocks base method.
 func (m *MockMutableState) GetChildExecutionInfo(arg0 int64) (*v19.ChildExecutionInfo, bool) {
 	m.ctrl.T.Helper()
@@ -1524,6 +1552,20 @@ func (mr *MockMutableStateMockRecorder) HasBufferedEvents() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasBufferedEvents", reflect.TypeOf((*MockMutableState)(nil).HasBufferedEvents))
 }
 
+// HasCoalescedActivityProgress mocks base method.
+func (m *MockMutableState) HasCoalescedActivityProgress() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasCoalescedActivityProgress")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasCoalescedActivityProgress indicates an expected call of HasCoalescedActivityProgress.
+func (mr *MockMutableStateMockRecorder) HasCoalescedActivityProgress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasCoalescedActivityProgress", reflect.TypeOf((*MockMutableState)(nil).HasCoalescedActivityProgress))
+}
+
 // HasInFlightWorkflowTask mocks base method.
 func (m *MockMutableState) HasInFlightWorkflowTask() bool {
 	m.ctrl.T.Helper()
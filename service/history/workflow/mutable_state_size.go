@@ -0,0 +1,140 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+type (
+	// pendingInfoSizes tracks the size of the pending activities, timers, child workflows, cancel and signal
+	// requests of a mutable state as of its last closed transaction, so that the size of the mutable state is
+	// computed from the infos changed by the current transaction only.
+	pendingInfoSizes struct {
+		sizes map[interface{}]int
+		total int
+	}
+
+	// keys of pendingInfoSizes, distinct types keep the IDs of different kinds of infos apart
+	activitySizeKey       int64
+	timerSizeKey          string
+	childExecutionSizeKey int64
+	requestCancelSizeKey  int64
+	signalSizeKey         int64
+)
+
+func newPendingInfoSizes() *pendingInfoSizes {
+	return &pendingInfoSizes{
+		sizes: make(map[interface{}]int),
+	}
+}
+
+// GetApproximatePersistedSize returns the approximate size of the persisted mutable state of an execution: its
+// execution info and state and its pending activities, timers, child workflows, cancel and signal requests. Signal
+// request IDs and buffered events are not included, the latter are bounded by the buffered events batch limit.
+func (e *MutableStateImpl) GetApproximatePersistedSize() int {
+	return e.executionInfo.Size() + e.executionState.Size() + e.pendingInfoSizes.size(e, false)
+}
+
+// load records the sizes of the pending infos loaded from the database.
+func (s *pendingInfoSizes) load(e *MutableStateImpl) {
+	for id, activityInfo := range e.pendingActivityInfoIDs {
+		s.set(activitySizeKey(id), activityInfo.Size())
+	}
+	for id, timerInfo := range e.pendingTimerInfoIDs {
+		s.set(timerSizeKey(id), timerInfo.Size())
+	}
+	for id, childExecutionInfo := range e.pendingChildExecutionInfoIDs {
+		s.set(childExecutionSizeKey(id), childExecutionInfo.Size())
+	}
+	for id, requestCancelInfo := range e.pendingRequestCancelInfoIDs {
+		s.set(requestCancelSizeKey(id), requestCancelInfo.Size())
+	}
+	for id, signalInfo := range e.pendingSignalInfoIDs {
+		s.set(signalSizeKey(id), signalInfo.Size())
+	}
+}
+
+// size returns the size of the pending infos including the changes of the current transaction, which are
+// recorded if commit is set.
+func (s *pendingInfoSizes) size(e *MutableStateImpl, commit bool) int {
+	total := s.total
+	// deletes are applied first, an info deleted and added again by the transaction is counted once
+	removed := make(map[interface{}]struct{})
+	remove := func(key interface{}) {
+		total -= s.sizes[key]
+		removed[key] = struct{}{}
+		if commit {
+			delete(s.sizes, key)
+		}
+	}
+	update := func(key interface{}, size int) {
+		if _, ok := removed[key]; !ok {
+			total -= s.sizes[key]
+		}
+		total += size
+		if commit {
+			s.sizes[key] = size
+		}
+	}
+
+	for id := range e.deleteActivityInfos {
+		remove(activitySizeKey(id))
+	}
+	for id := range e.deleteTimerInfos {
+		remove(timerSizeKey(id))
+	}
+	for id := range e.deleteChildExecutionInfos {
+		remove(childExecutionSizeKey(id))
+	}
+	for id := range e.deleteRequestCancelInfos {
+		remove(requestCancelSizeKey(id))
+	}
+	for id := range e.deleteSignalInfos {
+		remove(signalSizeKey(id))
+	}
+
+	for id, activityInfo := range e.updateActivityInfos {
+		update(activitySizeKey(id), activityInfo.Size())
+	}
+	for id, timerInfo := range e.updateTimerInfos {
+		update(timerSizeKey(id), timerInfo.Size())
+	}
+	for id, childExecutionInfo := range e.updateChildExecutionInfos {
+		update(childExecutionSizeKey(id), childExecutionInfo.Size())
+	}
+	for id, requestCancelInfo := range e.updateRequestCancelInfos {
+		update(requestCancelSizeKey(id), requestCancelInfo.Size())
+	}
+	for id, signalInfo := range e.updateSignalInfos {
+		update(signalSizeKey(id), signalInfo.Size())
+	}
+
+	if commit {
+		s.total = total
+	}
+	return total
+}
+
+func (s *pendingInfoSizes) set(key interface{}, size int) {
+	s.total += size - s.sizes[key]
+	s.sizes[key] = size
+}
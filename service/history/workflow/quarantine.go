@@ -0,0 +1,120 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+// IsQuarantinableWorkflowTaskFailure returns true if workflow tasks failing with cause are likely to keep failing
+// on every retry, i.e. the worker crashes processing the history rather than hitting a transient problem
+func IsQuarantinableWorkflowTaskFailure(cause enumspb.WorkflowTaskFailedCause) bool {
+	switch cause {
+	case enumspb.WORKFLOW_TASK_FAILED_CAUSE_NON_DETERMINISTIC_ERROR,
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_WORKFLOW_WORKER_UNHANDLED_FAILURE:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsWorkflowQuarantined returns true if the execution was quarantined by QuarantineWorkflow
+func IsWorkflowQuarantined(mutableState MutableState) bool {
+	_, ok := mutableState.GetExecutionInfo().SearchAttributes[searchattribute.TemporalQuarantineReason]
+	return ok
+}
+
+// ShouldQuarantineWorkflow returns true if the workflow tasks of the execution failed or timed out at least
+// threshold consecutive times. A threshold of 0 disables quarantining.
+func ShouldQuarantineWorkflow(mutableState MutableState, threshold int) bool {
+	return threshold > 0 && mutableState.GetExecutionInfo().WorkflowTaskConsecutiveFailures >= int32(threshold)
+}
+
+// QuarantineWorkflow marks an execution whose workflow tasks keep failing as quarantined, by setting its
+// TemporalQuarantineReason search attribute to reason. Callers stop scheduling the next workflow task after the
+// failure; new events still schedule one, and its successful completion releases the execution.
+func QuarantineWorkflow(
+	mutableState MutableState,
+	reason string,
+	now time.Time,
+) error {
+
+	reasonPayload, err := searchattribute.EncodeValue(reason, enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+	if err != nil {
+		return err
+	}
+	executionInfo := mutableState.GetExecutionInfo()
+	if executionInfo.SearchAttributes == nil {
+		executionInfo.SearchAttributes = make(map[string]*commonpb.Payload, 1)
+	}
+	executionInfo.SearchAttributes[searchattribute.TemporalQuarantineReason] = reasonPayload
+	addUpsertVisibilityTask(mutableState, now)
+	return nil
+}
+
+// ReleaseQuarantinedWorkflow clears the quarantine of an execution after one of its workflow tasks completed
+func ReleaseQuarantinedWorkflow(
+	mutableState MutableState,
+	now time.Time,
+) {
+
+	if !IsWorkflowQuarantined(mutableState) {
+		return
+	}
+	delete(mutableState.GetExecutionInfo().SearchAttributes, searchattribute.TemporalQuarantineReason)
+	addUpsertVisibilityTask(mutableState, now)
+}
+
+// EmitWorkflowQuarantined reports that QuarantineWorkflow was persisted for the execution. The execution is logged
+// with explicit tags, as not every caller has them in its context.
+func EmitWorkflowQuarantined(
+	mutableState MutableState,
+	namespaceName namespace.Name,
+	reason string,
+	scope int,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) {
+
+	metricsClient.Scope(scope).
+		Tagged(metrics.NamespaceTag(namespaceName.String())).
+		IncCounter(metrics.WorkflowQuarantinedCounter)
+	executionInfo := mutableState.GetExecutionInfo()
+	logger.Warn("Quarantined workflow after repeated workflow task failures.",
+		tag.WorkflowNamespace(namespaceName.String()),
+		tag.WorkflowID(executionInfo.WorkflowId),
+		tag.WorkflowRunID(mutableState.GetExecutionState().RunId),
+		tag.Counter(int(executionInfo.WorkflowTaskConsecutiveFailures)),
+		tag.Value(reason))
+}
@@ -25,6 +25,8 @@
 package workflow
 
 import (
+	"context"
+
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
@@ -67,14 +69,16 @@ func NewTransaction(
 }
 
 func (t *TransactionImpl) CreateWorkflowExecution(
+	ctx context.Context,
 	createMode persistence.CreateWorkflowMode,
 	newWorkflowSnapshot *persistence.WorkflowSnapshot,
 	newWorkflowEventsSeq []*persistence.WorkflowEvents,
 ) (int64, error) {
 
-	resp, err := createWorkflowExecutionWithRetry(t.shard, &persistence.CreateWorkflowExecutionRequest{
+	resp, err := createWorkflowExecutionWithRetry(ctx, t.shard, &persistence.CreateWorkflowExecutionRequest{
 		ShardID: t.shard.GetShardID(),
 		// RangeID , this is set by shard context
+		NamespaceID:         newWorkflowSnapshot.ExecutionInfo.NamespaceId,
 		Mode:                createMode,
 		NewWorkflowSnapshot: *newWorkflowSnapshot,
 		NewWorkflowEvents:   newWorkflowEventsSeq,
@@ -96,6 +100,7 @@ func (t *TransactionImpl) CreateWorkflowExecution(
 }
 
 func (t *TransactionImpl) ConflictResolveWorkflowExecution(
+	ctx context.Context,
 	conflictResolveMode persistence.ConflictResolveWorkflowMode,
 	resetWorkflowSnapshot *persistence.WorkflowSnapshot,
 	resetWorkflowEventsSeq []*persistence.WorkflowEvents,
@@ -105,9 +110,10 @@ func (t *TransactionImpl) ConflictResolveWorkflowExecution(
 	currentWorkflowEventsSeq []*persistence.WorkflowEvents,
 ) (int64, int64, int64, error) {
 
-	resp, err := conflictResolveWorkflowExecutionWithRetry(t.shard, &persistence.ConflictResolveWorkflowExecutionRequest{
+	resp, err := conflictResolveWorkflowExecutionWithRetry(ctx, t.shard, &persistence.ConflictResolveWorkflowExecutionRequest{
 		ShardID: t.shard.GetShardID(),
 		// RangeID , this is set by shard context
+		NamespaceID:             resetWorkflowSnapshot.ExecutionInfo.NamespaceId,
 		Mode:                    conflictResolveMode,
 		ResetWorkflowSnapshot:   *resetWorkflowSnapshot,
 		ResetWorkflowEvents:     resetWorkflowEventsSeq,
@@ -149,6 +155,7 @@ func (t *TransactionImpl) ConflictResolveWorkflowExecution(
 }
 
 func (t *TransactionImpl) UpdateWorkflowExecution(
+	ctx context.Context,
 	updateMode persistence.UpdateWorkflowMode,
 	currentWorkflowMutation *persistence.WorkflowMutation,
 	currentWorkflowEventsSeq []*persistence.WorkflowEvents,
@@ -156,9 +163,10 @@ func (t *TransactionImpl) UpdateWorkflowExecution(
 	newWorkflowEventsSeq []*persistence.WorkflowEvents,
 ) (int64, int64, error) {
 
-	resp, err := updateWorkflowExecutionWithRetry(t.shard, &persistence.UpdateWorkflowExecutionRequest{
+	resp, err := updateWorkflowExecutionWithRetry(ctx, t.shard, &persistence.UpdateWorkflowExecutionRequest{
 		ShardID: t.shard.GetShardID(),
 		// RangeID , this is set by shard context
+		NamespaceID:            currentWorkflowMutation.ExecutionInfo.NamespaceId,
 		Mode:                   updateMode,
 		UpdateWorkflowMutation: *currentWorkflowMutation,
 		UpdateWorkflowEvents:   currentWorkflowEventsSeq,
@@ -190,6 +198,7 @@ func (t *TransactionImpl) UpdateWorkflowExecution(
 }
 
 func PersistWorkflowEvents(
+	ctx context.Context,
 	shard shard.Context,
 	workflowEvents *persistence.WorkflowEvents,
 ) (int64, error) {
@@ -200,12 +209,13 @@ func PersistWorkflowEvents(
 
 	firstEventID := workflowEvents.Events[0].EventId
 	if firstEventID == common.FirstEventID {
-		return persistFirstWorkflowEvents(shard, workflowEvents)
+		return persistFirstWorkflowEvents(ctx, shard, workflowEvents)
 	}
-	return persistNonFirstWorkflowEvents(shard, workflowEvents)
+	return persistNonFirstWorkflowEvents(ctx, shard, workflowEvents)
 }
 
 func persistFirstWorkflowEvents(
+	ctx context.Context,
 	shard shard.Context,
 	workflowEvents *persistence.WorkflowEvents,
 ) (int64, error) {
@@ -223,6 +233,7 @@ func persistFirstWorkflowEvents(
 	txnID := workflowEvents.TxnID
 
 	size, err := appendHistoryV2EventsWithRetry(
+		ctx,
 		shard,
 		namespaceID,
 		execution,
@@ -239,6 +250,7 @@ func persistFirstWorkflowEvents(
 }
 
 func persistNonFirstWorkflowEvents(
+	ctx context.Context,
 	shard shard.Context,
 	workflowEvents *persistence.WorkflowEvents,
 ) (int64, error) {
@@ -258,6 +270,7 @@ func persistNonFirstWorkflowEvents(
 	txnID := workflowEvents.TxnID
 
 	size, err := appendHistoryV2EventsWithRetry(
+		ctx,
 		shard,
 		namespaceID,
 		execution,
@@ -273,6 +286,7 @@ func persistNonFirstWorkflowEvents(
 }
 
 func appendHistoryV2EventsWithRetry(
+	ctx context.Context,
 	shard shard.Context,
 	namespaceID namespace.ID,
 	execution commonpb.WorkflowExecution,
@@ -280,35 +294,38 @@ func appendHistoryV2EventsWithRetry(
 ) (int64, error) {
 
 	resp := 0
-	op := func() error {
+	op := func(ctx context.Context) error {
 		var err error
-		resp, err = shard.AppendHistoryEvents(request, namespaceID, execution)
+		resp, err = shard.AppendHistoryEvents(ctx, request, namespaceID, execution)
 		return err
 	}
 
-	err := backoff.Retry(
+	err := backoff.RetryContext(
+		ctx,
 		op,
-		PersistenceOperationRetryPolicy,
+		shard.GetConfig().ShardPersistenceRetryPolicy(),
 		common.IsPersistenceTransientError,
 	)
 	return int64(resp), err
 }
 
 func createWorkflowExecutionWithRetry(
+	ctx context.Context,
 	shard shard.Context,
 	request *persistence.CreateWorkflowExecutionRequest,
 ) (*persistence.CreateWorkflowExecutionResponse, error) {
 
 	var resp *persistence.CreateWorkflowExecutionResponse
-	op := func() error {
+	op := func(ctx context.Context) error {
 		var err error
-		resp, err = shard.CreateWorkflowExecution(request)
+		resp, err = shard.CreateWorkflowExecution(ctx, request)
 		return err
 	}
 
-	err := backoff.Retry(
+	err := backoff.RetryContext(
+		ctx,
 		op,
-		PersistenceOperationRetryPolicy,
+		shard.GetConfig().ShardPersistenceRetryPolicy(),
 		common.IsPersistenceTransientError,
 	)
 	switch err.(type) {
@@ -322,6 +339,11 @@ func createWorkflowExecutionWithRetry(
 				&resp.NewMutableStateStats,
 			)
 		}
+		recordActionUnits(
+			ctx,
+			snapshotTaskCount(&request.NewWorkflowSnapshot),
+			&resp.NewMutableStateStats,
+		)
 		return resp, nil
 	case *persistence.CurrentWorkflowConditionFailedError,
 		*persistence.WorkflowConditionFailedError,
@@ -343,20 +365,22 @@ func createWorkflowExecutionWithRetry(
 }
 
 func conflictResolveWorkflowExecutionWithRetry(
+	ctx context.Context,
 	shard shard.Context,
 	request *persistence.ConflictResolveWorkflowExecutionRequest,
 ) (*persistence.ConflictResolveWorkflowExecutionResponse, error) {
 
 	var resp *persistence.ConflictResolveWorkflowExecutionResponse
-	op := func() error {
+	op := func(ctx context.Context) error {
 		var err error
-		resp, err = shard.ConflictResolveWorkflowExecution(request)
+		resp, err = shard.ConflictResolveWorkflowExecution(ctx, request)
 		return err
 	}
 
-	err := backoff.Retry(
+	err := backoff.RetryContext(
+		ctx,
 		op,
-		PersistenceOperationRetryPolicy,
+		shard.GetConfig().ShardPersistenceRetryPolicy(),
 		common.IsPersistenceTransientError,
 	)
 	switch err.(type) {
@@ -379,6 +403,15 @@ func conflictResolveWorkflowExecutionWithRetry(
 				mutationToCompletionMetric(request.CurrentWorkflowMutation),
 			)
 		}
+		recordActionUnits(
+			ctx,
+			snapshotTaskCount(&request.ResetWorkflowSnapshot)+
+				snapshotTaskCount(request.NewWorkflowSnapshot)+
+				mutationTaskCount(request.CurrentWorkflowMutation),
+			&resp.ResetMutableStateStats,
+			resp.NewMutableStateStats,
+			resp.CurrentMutableStateStats,
+		)
 		return resp, nil
 	case *persistence.CurrentWorkflowConditionFailedError,
 		*persistence.WorkflowConditionFailedError,
@@ -400,6 +433,7 @@ func conflictResolveWorkflowExecutionWithRetry(
 }
 
 func getWorkflowExecutionWithRetry(
+	ctx context.Context,
 	shard shard.Context,
 	request *persistence.GetWorkflowExecutionRequest,
 ) (*persistence.GetWorkflowExecutionResponse, error) {
@@ -407,14 +441,14 @@ func getWorkflowExecutionWithRetry(
 	var resp *persistence.GetWorkflowExecutionResponse
 	op := func() error {
 		var err error
-		resp, err = shard.GetExecutionManager().GetWorkflowExecution(request)
+		resp, err = shard.GetWorkflowExecution(ctx, request)
 
 		return err
 	}
 
 	err := backoff.Retry(
 		op,
-		PersistenceOperationRetryPolicy,
+		shard.GetConfig().ShardPersistenceRetryPolicy(),
 		common.IsPersistenceTransientError,
 	)
 	switch err.(type) {
@@ -446,19 +480,22 @@ func getWorkflowExecutionWithRetry(
 }
 
 func updateWorkflowExecutionWithRetry(
+	ctx context.Context,
 	shard shard.Context,
 	request *persistence.UpdateWorkflowExecutionRequest,
 ) (*persistence.UpdateWorkflowExecutionResponse, error) {
 
 	var resp *persistence.UpdateWorkflowExecutionResponse
 	var err error
-	op := func() error {
-		resp, err = shard.UpdateWorkflowExecution(request)
+	op := func(ctx context.Context) error {
+		resp, err = shard.UpdateWorkflowExecution(ctx, request)
 		return err
 	}
 
-	err = backoff.Retry(
-		op, PersistenceOperationRetryPolicy,
+	err = backoff.RetryContext(
+		ctx,
+		op,
+		shard.GetConfig().ShardPersistenceRetryPolicy(),
 		common.IsPersistenceTransientError,
 	)
 	switch err.(type) {
@@ -479,6 +516,12 @@ func updateWorkflowExecutionWithRetry(
 				snapshotToCompletionMetric(request.NewWorkflowSnapshot),
 			)
 		}
+		recordActionUnits(
+			ctx,
+			mutationTaskCount(&request.UpdateWorkflowMutation)+snapshotTaskCount(request.NewWorkflowSnapshot),
+			&resp.UpdateMutableStateStats,
+			resp.NewMutableStateStats,
+		)
 		return resp, nil
 	case *persistence.CurrentWorkflowConditionFailedError,
 		*persistence.WorkflowConditionFailedError,
@@ -663,6 +706,55 @@ func emitGetMetrics(
 	}
 }
 
+// recordActionUnits adds the cost of a persisted transaction to the request metrics context: one state
+// transition per workflow written, the mutable state and history bytes written, and the tasks generated.
+// The counters are returned to the caller in the gRPC trailer.
+func recordActionUnits(
+	ctx context.Context,
+	taskCount int,
+	stats ...*persistence.MutableStateStatistics,
+) {
+	var stateTransitions int64
+	var bytesWritten int64
+	for _, stat := range stats {
+		if stat == nil {
+			continue
+		}
+		stateTransitions++
+		bytesWritten += int64(stat.TotalSize)
+		if stat.HistoryStatistics != nil {
+			bytesWritten += int64(stat.HistoryStatistics.SizeDiff)
+		}
+	}
+	metrics.ContextCounterAdd(ctx, metrics.HistoryActionUnitsStateTransitions, stateTransitions)
+	metrics.ContextCounterAdd(ctx, metrics.HistoryActionUnitsBytesWritten, bytesWritten)
+	metrics.ContextCounterAdd(ctx, metrics.HistoryActionUnitsTasksGenerated, int64(taskCount))
+}
+
+func snapshotTaskCount(
+	workflowSnapshot *persistence.WorkflowSnapshot,
+) int {
+	if workflowSnapshot == nil {
+		return 0
+	}
+	return len(workflowSnapshot.TransferTasks) +
+		len(workflowSnapshot.TimerTasks) +
+		len(workflowSnapshot.ReplicationTasks) +
+		len(workflowSnapshot.VisibilityTasks)
+}
+
+func mutationTaskCount(
+	workflowMutation *persistence.WorkflowMutation,
+) int {
+	if workflowMutation == nil {
+		return 0
+	}
+	return len(workflowMutation.TransferTasks) +
+		len(workflowMutation.TimerTasks) +
+		len(workflowMutation.ReplicationTasks) +
+		len(workflowMutation.VisibilityTasks)
+}
+
 func snapshotToCompletionMetric(
 	workflowSnapshot *persistence.WorkflowSnapshot,
 ) completionMetric {
@@ -25,6 +25,8 @@
 package workflow
 
 import (
+	"time"
+
 	commandpb "go.temporal.io/api/command/v1"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
@@ -35,7 +37,9 @@ import (
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/service/history/consts"
+	"go.temporal.io/server/service/history/tasks"
 )
 
 func failWorkflowTask(
@@ -158,6 +162,65 @@ func TerminateWorkflow(
 	return err
 }
 
+// RecordOperatorIdentity adds the identity of an operator who terminated, canceled or reset the execution to its
+// TemporalOperatorIdentity search attribute, so that the executions an operator touched can be found with a
+// visibility query. Visibility picks it up with the next visibility task of the execution; callers whose update
+// doesn't generate one should set upsertVisibility.
+func RecordOperatorIdentity(
+	mutableState MutableState,
+	identity string,
+	now time.Time,
+	upsertVisibility bool,
+) error {
+
+	if identity == "" || identity == consts.IdentityHistoryService {
+		return nil
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	var identities []string
+	if value, ok := executionInfo.SearchAttributes[searchattribute.TemporalOperatorIdentity]; ok {
+		decoded, err := searchattribute.DecodeValue(value, enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+		if err != nil {
+			return err
+		}
+		switch decoded := decoded.(type) {
+		case string:
+			identities = []string{decoded}
+		case []string:
+			identities = decoded
+		}
+	}
+	for _, recorded := range identities {
+		if recorded == identity {
+			return nil
+		}
+	}
+
+	identitiesPayload, err := searchattribute.EncodeValue(append(identities, identity), enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+	if err != nil {
+		return err
+	}
+	if executionInfo.SearchAttributes == nil {
+		executionInfo.SearchAttributes = make(map[string]*commonpb.Payload, 1)
+	}
+	executionInfo.SearchAttributes[searchattribute.TemporalOperatorIdentity] = identitiesPayload
+
+	if upsertVisibility {
+		addUpsertVisibilityTask(mutableState, now)
+	}
+	return nil
+}
+
+func addUpsertVisibilityTask(mutableState MutableState, now time.Time) {
+	mutableState.AddVisibilityTasks(&tasks.UpsertExecutionVisibilityTask{
+		// TaskID is set by shard
+		WorkflowKey:         mutableState.GetWorkflowKey(),
+		VisibilityTimestamp: now,
+		Version:             mutableState.GetCurrentVersion(), // task processing does not check this version
+	})
+}
+
 // FindAutoResetPoint returns the auto reset point
 func FindAutoResetPoint(
 	timeSource clock.TimeSource,
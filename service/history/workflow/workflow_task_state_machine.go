@@ -517,6 +517,10 @@ func (m *workflowTaskStateMachine) AddWorkflowTaskFailedEvent(
 	if cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW ||
 		cause == enumspb.WORKFLOW_TASK_FAILED_CAUSE_FAILOVER_CLOSE_COMMAND {
 		m.ms.executionInfo.WorkflowTaskAttempt = 1
+		m.ms.executionInfo.WorkflowTaskConsecutiveFailures = 0
+	} else {
+		// unlike the attempt, the failure count is not reset by new events
+		m.ms.executionInfo.WorkflowTaskConsecutiveFailures++
 	}
 	return event, nil
 }
@@ -549,6 +553,7 @@ func (m *workflowTaskStateMachine) AddWorkflowTaskTimedOutEvent(
 	if err := m.ReplicateWorkflowTaskTimedOutEvent(enumspb.TIMEOUT_TYPE_START_TO_CLOSE); err != nil {
 		return nil, err
 	}
+	m.ms.executionInfo.WorkflowTaskConsecutiveFailures++
 	return event, nil
 }
 
@@ -737,5 +742,10 @@ func (m *workflowTaskStateMachine) afterAddWorkflowTaskCompletedEvent(
 	maxResetPoints int,
 ) error {
 	m.ms.executionInfo.LastWorkflowTaskStartId = event.GetWorkflowTaskCompletedEventAttributes().GetStartedEventId()
-	return m.ms.addBinaryCheckSumIfNotExists(event, maxResetPoints)
+	m.ms.executionInfo.WorkflowTaskConsecutiveFailures = 0
+	if err := m.ms.addBinaryCheckSumIfNotExists(event, maxResetPoints); err != nil {
+		return err
+	}
+	m.ms.compactResetPoints(timestamp.TimeValue(event.GetEventTime()), maxResetPoints)
+	return nil
 }
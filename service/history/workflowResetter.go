@@ -42,6 +42,7 @@ import (
 	"go.temporal.io/server/common/collection"
 	"go.temporal.io/server/common/definition"
 	"go.temporal.io/server/common/failure"
+	"go.temporal.io/server/common/headers"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/namespace"
@@ -78,14 +79,15 @@ type (
 	nDCStateRebuilderProvider func() nDCStateRebuilder
 
 	workflowResetterImpl struct {
-		shard             shard.Context
-		namespaceRegistry namespace.Registry
-		clusterMetadata   cluster.Metadata
-		executionMgr      persistence.ExecutionManager
-		historyCache      workflow.Cache
-		newStateRebuilder nDCStateRebuilderProvider
-		transaction       workflow.Transaction
-		logger            log.Logger
+		shard               shard.Context
+		namespaceRegistry   namespace.Registry
+		clusterMetadata     cluster.Metadata
+		executionMgr        persistence.ExecutionManager
+		historyCache        workflow.Cache
+		newStateRebuilder   nDCStateRebuilderProvider
+		transaction         workflow.Transaction
+		eventsReapplyPolicy workflow.EventsReapplyPolicy
+		logger              log.Logger
 	}
 )
 
@@ -94,6 +96,7 @@ var _ workflowResetter = (*workflowResetterImpl)(nil)
 func newWorkflowResetter(
 	shard shard.Context,
 	historyCache workflow.Cache,
+	eventsReapplyPolicy workflow.EventsReapplyPolicy,
 	logger log.Logger,
 ) *workflowResetterImpl {
 	return &workflowResetterImpl{
@@ -105,8 +108,9 @@ func newWorkflowResetter(
 		newStateRebuilder: func() nDCStateRebuilder {
 			return newNDCStateRebuilder(shard, logger)
 		},
-		transaction: workflow.NewTransaction(shard),
-		logger:      logger,
+		transaction:         workflow.NewTransaction(shard),
+		eventsReapplyPolicy: eventsReapplyPolicy,
+		logger:              logger,
 	}
 }
 
@@ -132,6 +136,9 @@ func (r *workflowResetterImpl) resetWorkflow(
 		return err
 	}
 	resetWorkflowVersion := namespaceEntry.FailoverVersion()
+	identity := headers.GetOperatorIdentity(ctx, consts.IdentityHistoryService)
+	// validated by the history engine before the reset
+	excludedEventIDs, _ := headers.GetResetReapplyExcludeEventIDs(ctx)
 
 	var currentWorkflowMutation *persistence.WorkflowMutation
 	var currentWorkflowEventsSeq []*persistence.WorkflowEvents
@@ -141,6 +148,15 @@ func (r *workflowResetterImpl) resetWorkflow(
 		if err := r.terminateWorkflow(
 			currentMutableState,
 			resetReason,
+			identity,
+		); err != nil {
+			return err
+		}
+		if err := workflow.RecordOperatorIdentity(
+			currentMutableState,
+			identity,
+			r.shard.GetTimeSource().Now(),
+			false,
 		); err != nil {
 			return err
 		}
@@ -167,6 +183,7 @@ func (r *workflowResetterImpl) resetWorkflow(
 				baseBranchToken,
 				baseRebuildLastEventID+1,
 				baseNextEventID,
+				excludedEventIDs,
 			)
 			if err != nil {
 				return err
@@ -174,7 +191,7 @@ func (r *workflowResetterImpl) resetWorkflow(
 
 			if lastVisitedRunID == currentMutableState.GetExecutionState().RunId {
 				for _, event := range currentWorkflowEventsSeq {
-					if err := r.reapplyEvents(resetMutableState, event.Events); err != nil {
+					if err := r.reapplyEvents(resetMutableState, event.Events, nil); err != nil {
 						return err
 					}
 				}
@@ -192,6 +209,7 @@ func (r *workflowResetterImpl) resetWorkflow(
 				baseBranchToken,
 				baseRebuildLastEventID+1,
 				baseNextEventID,
+				excludedEventIDs,
 			)
 			return err
 		}
@@ -209,6 +227,7 @@ func (r *workflowResetterImpl) resetWorkflow(
 		resetRequestID,
 		resetWorkflowVersion,
 		resetReason,
+		identity,
 	)
 	if err != nil {
 		return err
@@ -229,7 +248,17 @@ func (r *workflowResetterImpl) resetWorkflow(
 		return err
 	}
 
+	if err := workflow.RecordOperatorIdentity(
+		resetWorkflow.getMutableState(),
+		identity,
+		r.shard.GetTimeSource().Now(),
+		true,
+	); err != nil {
+		return err
+	}
+
 	return r.persistToDB(
+		ctx,
 		currentWorkflow,
 		currentWorkflowMutation,
 		currentWorkflowEventsSeq,
@@ -249,6 +278,7 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 	resetRequestID string,
 	resetWorkflowVersion int64,
 	resetReason string,
+	identity string,
 ) (nDCWorkflow, error) {
 
 	resetWorkflow, err := r.replayResetWorkflow(
@@ -296,6 +326,7 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 		baseRebuildLastEventVersion,
 		resetRunID,
 		resetReason,
+		identity,
 	); err != nil {
 		return nil, err
 	}
@@ -332,7 +363,7 @@ func (r *workflowResetterImpl) reapplyEventsToResetWorkflow(
 		panic(fmt.Sprintf("unknown reset type: %v", resetReapplyType))
 	}
 
-	if err := r.reapplyEvents(resetMutableState, additionalReapplyEvents); err != nil {
+	if err := r.reapplyEvents(resetMutableState, additionalReapplyEvents, nil); err != nil {
 		return err
 	}
 
@@ -340,6 +371,7 @@ func (r *workflowResetterImpl) reapplyEventsToResetWorkflow(
 }
 
 func (r *workflowResetterImpl) persistToDB(
+	ctx context.Context,
 	currentWorkflow nDCWorkflow,
 	currentWorkflowMutation *persistence.WorkflowMutation,
 	currentWorkflowEventsSeq []*persistence.WorkflowEvents,
@@ -360,6 +392,7 @@ func (r *workflowResetterImpl) persistToDB(
 
 	if currentWorkflowMutation != nil {
 		if currentWorkflowSizeDiff, resetWorkflowSizeDiff, err := r.transaction.UpdateWorkflowExecution(
+			ctx,
 			persistence.UpdateWorkflowModeUpdateCurrent,
 			currentWorkflowMutation,
 			currentWorkflowEventsSeq,
@@ -382,6 +415,7 @@ func (r *workflowResetterImpl) persistToDB(
 	}
 
 	return resetWorkflow.getContext().CreateWorkflowExecution(
+		ctx,
 		now,
 		persistence.CreateWorkflowModeContinueAsNew,
 		currentRunID,
@@ -465,6 +499,7 @@ func (r *workflowResetterImpl) failWorkflowTask(
 	baseRebuildLastEventVersion int64,
 	resetRunID string,
 	resetReason string,
+	identity string,
 ) error {
 
 	workflowTask, ok := resetMutableState.GetPendingWorkflowTask()
@@ -497,7 +532,7 @@ func (r *workflowResetterImpl) failWorkflowTask(
 		workflowTask.StartedID,
 		enumspb.WORKFLOW_TASK_FAILED_CAUSE_RESET_WORKFLOW,
 		failure.NewResetWorkflowFailure(resetReason, nil),
-		consts.IdentityHistoryService,
+		identity,
 		"",
 		baseRunID,
 		resetRunID,
@@ -567,6 +602,7 @@ func (r *workflowResetterImpl) forkAndGenerateBranchToken(
 func (r *workflowResetterImpl) terminateWorkflow(
 	mutableState workflow.MutableState,
 	terminateReason string,
+	identity string,
 ) error {
 
 	eventBatchFirstEventID := mutableState.GetNextEventID()
@@ -575,7 +611,7 @@ func (r *workflowResetterImpl) terminateWorkflow(
 		eventBatchFirstEventID,
 		terminateReason,
 		nil,
-		consts.IdentityHistoryService,
+		identity,
 	)
 }
 
@@ -588,6 +624,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 	baseBranchToken []byte,
 	baseRebuildNextEventID int64,
 	baseNextEventID int64,
+	baseExcludedEventIDs map[int64]struct{},
 ) (string, error) {
 
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
@@ -601,6 +638,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 		baseRebuildNextEventID,
 		baseNextEventID,
 		baseBranchToken,
+		baseExcludedEventIDs,
 	)
 	switch err.(type) {
 	case nil:
@@ -628,7 +666,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 		}
 		defer func() { release(retError) }()
 
-		mutableState, err := context.LoadWorkflowExecution()
+		mutableState, err := context.LoadWorkflowExecution(ctx)
 		if err != nil {
 			// no matter what error happen, we need to retry
 			return 0, nil, err
@@ -655,6 +693,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 			common.FirstEventID,
 			nextWorkflowNextEventID,
 			nextWorkflowBranchToken,
+			nil,
 		)
 		switch err.(type) {
 		case nil:
@@ -675,6 +714,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 	firstEventID int64,
 	nextEventID int64,
 	branchToken []byte,
+	excludedEventIDs map[int64]struct{},
 ) (string, error) {
 
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
@@ -696,7 +736,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 			return "", err
 		}
 		lastEvents = batch.(*historypb.History).Events
-		if err := r.reapplyEvents(mutableState, lastEvents); err != nil {
+		if err := r.reapplyEvents(mutableState, lastEvents, excludedEventIDs); err != nil {
 			return "", err
 		}
 	}
@@ -710,25 +750,25 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 	return nextRunID, nil
 }
 
+// reapplyEvents reapplies the events selected by the events reapply policy to the reset workflow, except those
+// whose IDs are in excludedEventIDs
 func (r *workflowResetterImpl) reapplyEvents(
 	mutableState workflow.MutableState,
 	events []*historypb.HistoryEvent,
+	excludedEventIDs map[int64]struct{},
 ) error {
 
+	namespaceName := mutableState.GetNamespaceEntry().Name()
 	for _, event := range events {
-		switch event.GetEventType() {
-		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
-			attr := event.GetWorkflowExecutionSignaledEventAttributes()
-			if _, err := mutableState.AddWorkflowExecutionSignaled(
-				attr.GetSignalName(),
-				attr.GetInput(),
-				attr.GetIdentity(),
-				attr.GetHeader(),
-			); err != nil {
-				return err
-			}
-		default:
-			// events other than signal will be ignored
+		if _, ok := excludedEventIDs[event.GetEventId()]; ok {
+			continue
+		}
+		if !workflow.IsReapplicableEvent(event) || !r.eventsReapplyPolicy.ShouldReapply(namespaceName, event) {
+			// events other than signal and marker will be ignored
+			continue
+		}
+		if err := workflow.ReapplyEvent(mutableState, event); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -42,6 +42,7 @@ import (
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/collection"
 	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/failure"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/namespace"
@@ -97,12 +98,11 @@ func (s *workflowResetterSuite) SetupTest() {
 
 	s.mockShard = shard.NewTestContext(
 		s.controller,
-		&persistence.ShardInfoWithFailover{
-			ShardInfo: &persistencespb.ShardInfo{
-				ShardId:          0,
-				RangeId:          1,
-				TransferAckLevel: 0,
-			}},
+		&persistencespb.ShardInfo{
+			ShardId:          0,
+			RangeId:          1,
+			TransferAckLevel: 0,
+		},
 		tests.NewDynamicConfig(),
 	)
 	s.mockExecutionMgr = s.mockShard.Resource.ExecutionMgr
@@ -111,6 +111,7 @@ func (s *workflowResetterSuite) SetupTest() {
 	s.workflowResetter = newWorkflowResetter(
 		s.mockShard,
 		workflow.NewCache(s.mockShard),
+		workflow.NewEventsReapplyPolicy(dynamicconfig.GetStringPropertyFnFilteredByNamespace("WorkflowExecutionSignaled")),
 		s.logger,
 	)
 	s.workflowResetter.newStateRebuilder = func() nDCStateRebuilder {
@@ -188,6 +189,7 @@ func (s *workflowResetterSuite) TestPersistToDB_CurrentTerminated() {
 	resetContext.EXPECT().SetHistorySize(resetEventsSize + resetNewEventsSize)
 
 	s.mockTransaction.EXPECT().UpdateWorkflowExecution(
+		gomock.Any(),
 		persistence.UpdateWorkflowModeUpdateCurrent,
 		currentMutation,
 		currentEventsSeq,
@@ -195,7 +197,7 @@ func (s *workflowResetterSuite) TestPersistToDB_CurrentTerminated() {
 		resetEventsSeq,
 	).Return(currentNewEventsSize, resetNewEventsSize, nil)
 
-	err := s.workflowResetter.persistToDB(currentWorkflow, currentMutation, currentEventsSeq, resetWorkflow)
+	err := s.workflowResetter.persistToDB(context.Background(), currentWorkflow, currentMutation, currentEventsSeq, resetWorkflow)
 	s.NoError(err)
 	// persistToDB function is not charged of releasing locks
 	s.False(currentReleaseCalled)
@@ -246,6 +248,7 @@ func (s *workflowResetterSuite) TestPersistToDB_CurrentNotTerminated() {
 	).Return(resetSnapshot, resetEventsSeq, nil)
 	resetContext.EXPECT().GetHistorySize().Return(int64(123)).AnyTimes()
 	resetContext.EXPECT().CreateWorkflowExecution(
+		gomock.Any(),
 		gomock.Any(),
 		persistence.CreateWorkflowModeContinueAsNew,
 		s.currentRunID,
@@ -255,7 +258,7 @@ func (s *workflowResetterSuite) TestPersistToDB_CurrentNotTerminated() {
 		resetEventsSeq,
 	).Return(nil)
 
-	err := s.workflowResetter.persistToDB(currentWorkflow, nil, nil, resetWorkflow)
+	err := s.workflowResetter.persistToDB(context.Background(), currentWorkflow, nil, nil, resetWorkflow)
 	s.NoError(err)
 	// persistToDB function is not charged of releasing locks
 	s.False(currentReleaseCalled)
@@ -335,6 +338,7 @@ func (s *workflowResetterSuite) TestFailWorkflowTask_NoWorkflowTask() {
 		baseRebuildLastEventVersion,
 		resetRunID,
 		resetReason,
+		consts.IdentityHistoryService,
 	)
 	s.Error(err)
 }
@@ -388,6 +392,7 @@ func (s *workflowResetterSuite) TestFailWorkflowTask_WorkflowTaskScheduled() {
 		baseRebuildLastEventVersion,
 		resetRunID,
 		resetReason,
+		consts.IdentityHistoryService,
 	)
 	s.NoError(err)
 }
@@ -429,6 +434,7 @@ func (s *workflowResetterSuite) TestFailWorkflowTask_WorkflowTaskStarted() {
 		baseRebuildLastEventVersion,
 		resetRunID,
 		resetReason,
+		consts.IdentityHistoryService,
 	)
 	s.NoError(err)
 }
@@ -530,7 +536,7 @@ func (s *workflowResetterSuite) TestTerminateWorkflow() {
 		consts.IdentityHistoryService,
 	).Return(&historypb.HistoryEvent{}, nil)
 
-	err := s.workflowResetter.terminateWorkflow(mutableState, terminateReason)
+	err := s.workflowResetter.terminateWorkflow(mutableState, terminateReason, consts.IdentityHistoryService)
 	s.NoError(err)
 }
 
@@ -576,6 +582,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents_WithOutCo
 	}, nil)
 
 	mutableState := workflow.NewMockMutableState(s.controller)
+	mutableState.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 
 	lastVisitedRunID, err := s.workflowResetter.reapplyContinueAsNewWorkflowEvents(
 		ctx,
@@ -586,6 +593,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents_WithOutCo
 		baseBranchToken,
 		baseFirstEventID,
 		baseNextEventID,
+		nil,
 	)
 	s.NoError(err)
 	s.Equal(s.baseRunID, lastVisitedRunID)
@@ -682,13 +690,14 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents_WithConti
 	resetContext.EXPECT().Lock(gomock.Any(), workflow.CallerTypeAPI).Return(nil)
 	resetContext.EXPECT().Unlock(workflow.CallerTypeAPI)
 	resetMutableState := workflow.NewMockMutableState(s.controller)
-	resetContext.EXPECT().LoadWorkflowExecution().Return(resetMutableState, nil)
+	resetContext.EXPECT().LoadWorkflowExecution(gomock.Any()).Return(resetMutableState, nil)
 	resetMutableState.EXPECT().GetNextEventID().Return(newNextEventID).AnyTimes()
 	resetMutableState.EXPECT().GetCurrentBranchToken().Return(newBranchToken, nil).AnyTimes()
 	resetContextCacheKey := definition.NewWorkflowKey(s.namespaceID.String(), s.workflowID, newRunID)
 	_, _ = s.workflowResetter.historyCache.(*workflow.CacheImpl).PutIfNotExist(resetContextCacheKey, resetContext)
 
 	mutableState := workflow.NewMockMutableState(s.controller)
+	mutableState.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 
 	lastVisitedRunID, err := s.workflowResetter.reapplyContinueAsNewWorkflowEvents(
 		ctx,
@@ -699,6 +708,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents_WithConti
 		baseBranchToken,
 		baseFirstEventID,
 		baseNextEventID,
+		nil,
 	)
 	s.NoError(err)
 	s.Equal(newRunID, lastVisitedRunID)
@@ -752,12 +762,14 @@ func (s *workflowResetterSuite) TestReapplyWorkflowEvents() {
 	}, nil)
 
 	mutableState := workflow.NewMockMutableState(s.controller)
+	mutableState.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 
 	nextRunID, err := s.workflowResetter.reapplyWorkflowEvents(
 		mutableState,
 		firstEventID,
 		nextEventID,
 		branchToken,
+		nil,
 	)
 	s.NoError(err)
 	s.Equal(newRunID, nextRunID)
@@ -789,9 +801,19 @@ func (s *workflowResetterSuite) TestReapplyEvents() {
 			Identity:   "another random signal identity",
 		}},
 	}
-	events := []*historypb.HistoryEvent{event1, event2, event3}
+	event4 := &historypb.HistoryEvent{
+		EventId:   104,
+		EventType: enumspb.EVENT_TYPE_MARKER_RECORDED,
+		Attributes: &historypb.HistoryEvent_MarkerRecordedEventAttributes{MarkerRecordedEventAttributes: &historypb.MarkerRecordedEventAttributes{
+			MarkerName:                   "some random marker name",
+			Details:                      map[string]*commonpb.Payloads{"some random detail": payloads.EncodeString("some random marker detail")},
+			WorkflowTaskCompletedEventId: 100,
+		}},
+	}
+	events := []*historypb.HistoryEvent{event1, event2, event3, event4}
 
 	mutableState := workflow.NewMockMutableState(s.controller)
+	mutableState.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
 
 	for _, event := range events {
 		if event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED {
@@ -801,11 +823,54 @@ func (s *workflowResetterSuite) TestReapplyEvents() {
 				attr.GetInput(),
 				attr.GetIdentity(),
 				attr.GetHeader(),
-			).Return(&historypb.HistoryEvent{}, nil)
+			).Return(&historypb.HistoryEvent{}, nil).Times(2)
 		}
 	}
 
-	err := s.workflowResetter.reapplyEvents(mutableState, events)
+	err := s.workflowResetter.reapplyEvents(mutableState, events, nil)
+	s.NoError(err)
+
+	// markers are not reapplied, even if the policy selects them
+	s.workflowResetter.eventsReapplyPolicy = workflow.NewEventsReapplyPolicy(
+		dynamicconfig.GetStringPropertyFnFilteredByNamespace("WorkflowExecutionSignaled,MarkerRecorded"),
+	)
+	err = s.workflowResetter.reapplyEvents(mutableState, events, nil)
+	s.NoError(err)
+}
+
+func (s *workflowResetterSuite) TestReapplyEvents_ExcludedEventIDs() {
+
+	event1 := &historypb.HistoryEvent{
+		EventId:   101,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "poisonous signal name",
+			Input:      payloads.EncodeString("poisonous signal input"),
+			Identity:   "some random signal identity",
+		}},
+	}
+	event2 := &historypb.HistoryEvent{
+		EventId:   102,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "another random signal name",
+			Input:      payloads.EncodeString("another random signal input"),
+			Identity:   "another random signal identity",
+		}},
+	}
+	events := []*historypb.HistoryEvent{event1, event2}
+
+	mutableState := workflow.NewMockMutableState(s.controller)
+	mutableState.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
+	attr := event2.GetWorkflowExecutionSignaledEventAttributes()
+	mutableState.EXPECT().AddWorkflowExecutionSignaled(
+		attr.GetSignalName(),
+		attr.GetInput(),
+		attr.GetIdentity(),
+		attr.GetHeader(),
+	).Return(&historypb.HistoryEvent{}, nil)
+
+	err := s.workflowResetter.reapplyEvents(mutableState, events, map[int64]struct{}{event1.GetEventId(): {}})
 	s.NoError(err)
 }
 
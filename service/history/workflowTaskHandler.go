@@ -39,6 +39,7 @@ import (
 
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/backoff"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/enums"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -51,6 +52,11 @@ import (
 	"go.temporal.io/server/service/history/workflow"
 )
 
+const (
+	// minActivityTimeoutCeiling is the ceiling of activity timeouts when the workflow run is about to time out
+	minActivityTimeoutCeiling = time.Second
+)
+
 type (
 	commandAttrValidationFn func() error
 
@@ -214,7 +220,21 @@ func (handler *workflowTaskHandlerImpl) handleCommandScheduleActivity(
 				namespaceID,
 				targetNamespaceID,
 				attr,
-				timestamp.DurationValue(executionInfo.WorkflowRunTimeout),
+				handler.activityTimeoutCeiling(),
+			)
+		},
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_BAD_SCHEDULE_ACTIVITY_ATTRIBUTES,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
+	if err := handler.validateCommandAttr(
+		func() error {
+			return handler.validatePendingLimit(
+				"activities",
+				len(handler.mutableState.GetPendingActivityInfos()),
+				handler.config.NumPendingActivitiesLimitWarn,
+				handler.config.NumPendingActivitiesLimitError,
 			)
 		},
 		enumspb.WORKFLOW_TASK_FAILED_CAUSE_BAD_SCHEDULE_ACTIVITY_ATTRIBUTES,
@@ -244,6 +264,32 @@ func (handler *workflowTaskHandlerImpl) handleCommandScheduleActivity(
 	return nil
 }
 
+// activityTimeoutCeiling returns the ceiling of the timeouts of an activity scheduled now: the workflow run timeout,
+// or the time left until the run times out if the namespace caps activity timeouts by the run deadline
+func (handler *workflowTaskHandlerImpl) activityTimeoutCeiling() time.Duration {
+	executionInfo := handler.mutableState.GetExecutionInfo()
+	runTimeout := timestamp.DurationValue(executionInfo.WorkflowRunTimeout)
+
+	namespaceName := handler.mutableState.GetNamespaceEntry().Name().String()
+	if !handler.config.CapActivityTimeoutsByRunDeadline(namespaceName) {
+		return runTimeout
+	}
+	runExpirationTime := timestamp.TimeValue(executionInfo.WorkflowRunExpirationTime)
+	if runExpirationTime.IsZero() {
+		return runTimeout
+	}
+
+	remaining := runExpirationTime.Sub(handler.shard.GetTimeSource().Now())
+	if remaining < minActivityTimeoutCeiling {
+		// the run is about to time out, its timeout timer closes it shortly
+		remaining = minActivityTimeoutCeiling
+	}
+	if runTimeout > 0 && runTimeout < remaining {
+		return runTimeout
+	}
+	return remaining
+}
+
 func (handler *workflowTaskHandlerImpl) handleCommandRequestCancelActivity(
 	attr *commandpb.RequestCancelActivityTaskCommandAttributes,
 ) error {
@@ -767,6 +813,20 @@ func (handler *workflowTaskHandlerImpl) handleCommandStartChildWorkflow(
 		return err
 	}
 
+	if err := handler.validateCommandAttr(
+		func() error {
+			return handler.validatePendingLimit(
+				"child workflows",
+				len(handler.mutableState.GetPendingChildExecutionInfos()),
+				handler.config.NumPendingChildExecutionsLimitWarn,
+				handler.config.NumPendingChildExecutionsLimitError,
+			)
+		},
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_BAD_START_CHILD_EXECUTION_ATTRIBUTES,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
 		metrics.CommandTypeTag(enumspb.COMMAND_TYPE_START_CHILD_WORKFLOW_EXECUTION.String()),
 		attr.GetInput().Size(),
@@ -855,6 +915,20 @@ func (handler *workflowTaskHandlerImpl) handleCommandSignalExternalWorkflow(
 		return err
 	}
 
+	if err := handler.validateCommandAttr(
+		func() error {
+			return handler.validatePendingLimit(
+				"signals",
+				len(handler.mutableState.GetPendingSignalExternalInfos()),
+				handler.config.NumPendingSignalsLimitWarn,
+				handler.config.NumPendingSignalsLimitError,
+			)
+		},
+		enumspb.WORKFLOW_TASK_FAILED_CAUSE_BAD_SIGNAL_WORKFLOW_EXECUTION_ATTRIBUTES,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
 		metrics.CommandTypeTag(enumspb.COMMAND_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION.String()),
 		attr.GetInput().Size(),
@@ -1041,6 +1115,30 @@ func (handler *workflowTaskHandlerImpl) validateCommandAttr(
 	return nil
 }
 
+// validatePendingLimit rejects a command adding to the pending activities, child workflows or signals of the
+// execution once pendingCount of them reached the error limit, and logs a warning once it reached the warn limit
+func (handler *workflowTaskHandlerImpl) validatePendingLimit(
+	pendingKind string,
+	pendingCount int,
+	limitWarn dynamicconfig.IntPropertyFnWithNamespaceFilter,
+	limitError dynamicconfig.IntPropertyFnWithNamespaceFilter,
+) error {
+
+	namespaceName := handler.mutableState.GetNamespaceEntry().Name().String()
+	if limit := limitError(namespaceName); limit > 0 && pendingCount >= limit {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("The number of pending %v reached the limit of %v.", pendingKind, limit))
+	}
+	if limit := limitWarn(namespaceName); limit > 0 && pendingCount >= limit {
+		executionInfo := handler.mutableState.GetExecutionInfo()
+		handler.logger.Warn(fmt.Sprintf("The number of pending %v exceeds warn limit.", pendingKind),
+			tag.WorkflowNamespaceID(executionInfo.NamespaceId),
+			tag.WorkflowID(executionInfo.WorkflowId),
+			tag.WorkflowRunID(handler.mutableState.GetExecutionState().RunId),
+			tag.WorkflowPendingCount(pendingCount))
+	}
+	return nil
+}
+
 func (handler *workflowTaskHandlerImpl) failCommand(
 	failedCause enumspb.WorkflowTaskFailedCause,
 	causeErr error,
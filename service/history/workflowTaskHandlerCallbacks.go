@@ -240,6 +240,10 @@ func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskStarted(
 			metrics.GetPerTaskQueueScope(metricsScope, namespaceName.String(), taskQueue.GetName(), taskQueue.GetKind()).
 				Tagged(metrics.TaskTypeTag("workflow")).
 				RecordTimer(metrics.TaskScheduleToStartLatency, workflowScheduleToStartLatency)
+			if executionTime := timestamp.TimeValue(mutableState.GetExecutionInfo().ExecutionTime); isFirstWorkflowTask(workflowTask) && !executionTime.IsZero() {
+				metricsScope.Tagged(metrics.StartStageTag(metrics.FirstWorkflowTaskStartedStartStageTagValue)).
+					RecordTimer(metrics.StartWorkflowStageLatency, workflowTask.StartedTime.Sub(executionTime))
+			}
 
 			resp, err = handler.createRecordWorkflowTaskStartedResponse(mutableState, workflowTask, req.PollRequest.GetIdentity())
 			if err != nil {
@@ -254,6 +258,12 @@ func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskStarted(
 	return resp, nil
 }
 
+// isFirstWorkflowTask returns whether the workflow task is the first attempt of the first workflow task of a
+// run, which completes the start of the workflow for StartWorkflowStageLatency.
+func isFirstWorkflowTask(workflowTask *workflow.WorkflowTaskInfo) bool {
+	return workflowTask.ScheduleID == common.FirstEventID+1 && workflowTask.Attempt == 1
+}
+
 func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskFailed(
 	ctx context.Context,
 	req *historyservice.RespondWorkflowTaskFailedRequest,
@@ -276,11 +286,13 @@ func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskFailed(
 		RunId:      token.GetRunId(),
 	}
 
-	return handler.historyEngine.updateWorkflowExecution(
+	var quarantinedState workflow.MutableState
+	err = handler.historyEngine.updateWorkflowExecution(
 		ctx,
 		namespaceID,
 		workflowExecution,
 		func(context workflow.Context, mutableState workflow.MutableState) (*updateWorkflowAction, error) {
+			quarantinedState = nil
 			if !mutableState.IsWorkflowExecutionRunning() {
 				return nil, consts.ErrWorkflowCompleted
 			}
@@ -296,11 +308,29 @@ func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskFailed(
 			if err != nil {
 				return nil, err
 			}
+
+			// a workflow task which keeps crashing the workers is not retried until new events arrive
+			threshold := handler.config.WorkflowTaskQuarantineThreshold(namespaceEntry.Name().String())
+			if workflow.ShouldQuarantineWorkflow(mutableState, threshold) && workflow.IsQuarantinableWorkflowTaskFailure(request.GetCause()) {
+				if !workflow.IsWorkflowQuarantined(mutableState) {
+					if err := workflow.QuarantineWorkflow(mutableState, request.GetCause().String(), handler.shard.GetTimeSource().Now()); err != nil {
+						return nil, err
+					}
+					quarantinedState = mutableState
+				}
+				return updateWorkflowWithoutWorkflowTask, nil
+			}
 			return &updateWorkflowAction{
 				noop:               false,
 				createWorkflowTask: true,
 			}, nil
 		})
+
+	if err == nil && quarantinedState != nil {
+		workflow.EmitWorkflowQuarantined(quarantinedState, namespaceEntry.Name(), request.GetCause().String(),
+			metrics.HistoryRespondWorkflowTaskFailedScope, handler.metricsClient, handler.logger)
+	}
+	return err
 }
 
 func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskCompleted(
@@ -338,14 +368,14 @@ func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskCompleted(
 
 Update_History_Loop:
 	for attempt := 1; attempt <= conditionalRetryCount; attempt++ {
-		msBuilder, err := weContext.LoadWorkflowExecution()
+		msBuilder, err := weContext.LoadWorkflowExecution(ctx)
 		if err != nil {
 			return nil, err
 		}
 		if !msBuilder.IsWorkflowExecutionRunning() {
 			return nil, consts.ErrWorkflowCompleted
 		}
-		executionStats, err := weContext.LoadExecutionStats()
+		executionStats, err := weContext.LoadExecutionStats(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -433,10 +463,10 @@ Update_History_Loop:
 				handler.config.BlobSizeLimitError(namespace.String()),
 				handler.config.MemoSizeLimitWarn(namespace.String()),
 				handler.config.MemoSizeLimitError(namespace.String()),
-				handler.config.HistorySizeLimitWarn(namespace.String()),
-				handler.config.HistorySizeLimitError(namespace.String()),
-				handler.config.HistoryCountLimitWarn(namespace.String()),
-				handler.config.HistoryCountLimitError(namespace.String()),
+				handler.config.HistorySizeLimitWarn(namespace.String(), executionInfo.WorkflowTypeName),
+				handler.config.HistorySizeLimitError(namespace.String(), executionInfo.WorkflowTypeName),
+				handler.config.HistoryCountLimitWarn(namespace.String(), executionInfo.WorkflowTypeName),
+				handler.config.HistoryCountLimitError(namespace.String(), executionInfo.WorkflowTypeName),
 				completedEvent.GetEventId(),
 				msBuilder,
 				handler.historyEngine.searchAttributesValidator,
@@ -480,17 +510,16 @@ Update_History_Loop:
 
 		if wtFailedCause != nil {
 			handler.metricsClient.IncCounter(metrics.HistoryRespondWorkflowTaskCompletedScope, metrics.FailedWorkflowTasksCounter)
-			handler.logger.Info("Failing the workflow task.",
-				tag.Value(wtFailedCause.Message()),
-				tag.WorkflowID(token.GetWorkflowId()),
-				tag.WorkflowRunID(token.GetRunId()),
-				tag.WorkflowNamespaceID(namespaceID.String()))
-			msBuilder, err = handler.historyEngine.failWorkflowTask(weContext, scheduleID, startedID, wtFailedCause, request)
+			log.WithContext(ctx, handler.logger).Info("Failing the workflow task.",
+				tag.Value(wtFailedCause.Message()))
+			msBuilder, err = handler.historyEngine.failWorkflowTask(ctx, weContext, scheduleID, startedID, wtFailedCause, request)
 			if err != nil {
 				return nil, err
 			}
 			hasUnhandledEvents = true
 			newStateBuilder = nil
+		} else {
+			workflow.ReleaseQuarantinedWorkflow(msBuilder, handler.shard.GetTimeSource().Now())
 		}
 
 		createNewWorkflowTask := msBuilder.IsWorkflowExecutionRunning() && (hasUnhandledEvents || request.GetForceCreateNewWorkflowTask() || activityNotStartedCancelled)
@@ -536,6 +565,7 @@ Update_History_Loop:
 			newWorkflowExecutionInfo := newStateBuilder.GetExecutionInfo()
 			newWorkflowExecutionState := newStateBuilder.GetExecutionState()
 			updateErr = weContext.UpdateWorkflowExecutionWithNewAsActive(
+				ctx,
 				handler.shard.GetTimeSource().Now(),
 				workflow.NewContext(
 					namespace.ID(newWorkflowExecutionInfo.NamespaceId),
@@ -549,7 +579,7 @@ Update_History_Loop:
 				newStateBuilder,
 			)
 		} else {
-			updateErr = weContext.UpdateWorkflowExecutionAsActive(handler.shard.GetTimeSource().Now())
+			updateErr = weContext.UpdateWorkflowExecutionAsActive(ctx, handler.shard.GetTimeSource().Now())
 		}
 
 		if updateErr != nil {
@@ -563,7 +593,7 @@ Update_History_Loop:
 			case *persistence.TransactionSizeLimitError:
 				// must reload mutable state because the first call to updateWorkflowExecutionWithContext or continueAsNewWorkflowExecution
 				// clears mutable state if error is returned
-				msBuilder, err = weContext.LoadWorkflowExecution()
+				msBuilder, err = weContext.LoadWorkflowExecution(ctx)
 				if err != nil {
 					return nil, err
 				}
@@ -579,6 +609,7 @@ Update_History_Loop:
 					return nil, err
 				}
 				if err := weContext.UpdateWorkflowExecutionAsActive(
+					ctx,
 					handler.shard.GetTimeSource().Now(),
 				); err != nil {
 					return nil, err
@@ -588,6 +619,7 @@ Update_History_Loop:
 			return nil, updateErr
 		}
 
+		setLimitWarnings(ctx, handler.config, namespaceEntry.Name().String(), weContext, msBuilder)
 		handler.handleBufferedQueries(msBuilder, req.GetCompleteRequest().GetQueryResults(), createNewWorkflowTask, namespaceEntry, workflowTaskHeartbeating)
 
 		if workflowTaskHeartbeatTimeout {
@@ -0,0 +1,104 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/tests"
+	"go.temporal.io/server/service/history/workflow"
+)
+
+func TestActivityTimeoutCeiling(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	now := time.Now().UTC()
+	executionInfo := &persistencespb.WorkflowExecutionInfo{
+		WorkflowRunTimeout:        timestamp.DurationPtr(time.Hour),
+		WorkflowRunExpirationTime: timestamp.TimePtr(now.Add(10 * time.Minute)),
+	}
+	mockMutableState := workflow.NewMockMutableState(controller)
+	mockMutableState.EXPECT().GetExecutionInfo().Return(executionInfo).AnyTimes()
+	mockMutableState.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
+	mockShard := shard.NewMockContext(controller)
+	mockShard.EXPECT().GetTimeSource().Return(clock.NewEventTimeSource().Update(now)).AnyTimes()
+
+	config := tests.NewDynamicConfig()
+	handler := &workflowTaskHandlerImpl{
+		mutableState: mockMutableState,
+		config:       config,
+		shard:        mockShard,
+	}
+
+	// disabled, capped by the whole run timeout
+	require.Equal(t, time.Hour, handler.activityTimeoutCeiling())
+
+	config.CapActivityTimeoutsByRunDeadline = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	require.Equal(t, 10*time.Minute, handler.activityTimeoutCeiling())
+
+	executionInfo.WorkflowRunExpirationTime = timestamp.TimePtr(now.Add(-time.Minute))
+	require.Equal(t, minActivityTimeoutCeiling, handler.activityTimeoutCeiling())
+
+	executionInfo.WorkflowRunExpirationTime = nil
+	require.Equal(t, time.Hour, handler.activityTimeoutCeiling())
+}
+
+func TestValidatePendingLimit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockMutableState := workflow.NewMockMutableState(controller)
+	mockMutableState.EXPECT().GetNamespaceEntry().Return(tests.GlobalNamespaceEntry).AnyTimes()
+	mockMutableState.EXPECT().GetExecutionInfo().Return(&persistencespb.WorkflowExecutionInfo{}).AnyTimes()
+	mockMutableState.EXPECT().GetExecutionState().Return(&persistencespb.WorkflowExecutionState{}).AnyTimes()
+
+	handler := &workflowTaskHandlerImpl{
+		mutableState: mockMutableState,
+		logger:       log.NewNoopLogger(),
+	}
+
+	disabled := dynamicconfig.GetIntPropertyFilteredByNamespace(0)
+	require.NoError(t, handler.validatePendingLimit("activities", 1000, disabled, disabled))
+
+	limitWarn := dynamicconfig.GetIntPropertyFilteredByNamespace(5)
+	limitError := dynamicconfig.GetIntPropertyFilteredByNamespace(10)
+	require.NoError(t, handler.validatePendingLimit("activities", 9, limitWarn, limitError))
+
+	err := handler.validatePendingLimit("activities", 10, limitWarn, limitError)
+	var invalidArgument *serviceerror.InvalidArgument
+	require.ErrorAs(t, err, &invalidArgument)
+}
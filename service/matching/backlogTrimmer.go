@@ -0,0 +1,243 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/internal/goro"
+	"go.temporal.io/server/service/worker/scanner/taskqueue"
+)
+
+const (
+	backlogTrimValidationTimeout = 5 * time.Second
+)
+
+type (
+	// backlogTrimmer periodically inspects the oldest tasks of a task queue backlog which
+	// have not yet been loaded by the taskReader, and deletes the ones which can never be
+	// dispatched, i.e. tasks beyond their schedule-to-start timeout and tasks whose
+	// workflow has since been closed or deleted.
+	backlogTrimmer struct {
+		status         int32
+		tlMgr          *taskQueueManagerImpl
+		historyService historyservice.HistoryServiceClient
+		gorogrp        goro.Group
+	}
+
+	// taskValidator returns true if the given task can still be dispatched
+	taskValidator func(ctx context.Context, task *persistencespb.AllocatedTaskInfo) (bool, error)
+)
+
+func newBacklogTrimmer(
+	tlMgr *taskQueueManagerImpl,
+	historyService historyservice.HistoryServiceClient,
+) *backlogTrimmer {
+	return &backlogTrimmer{
+		status:         common.DaemonStatusInitialized,
+		tlMgr:          tlMgr,
+		historyService: historyService,
+	}
+}
+
+func (bt *backlogTrimmer) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&bt.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+
+	bt.gorogrp.Go(bt.trimPump)
+}
+
+func (bt *backlogTrimmer) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&bt.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+
+	bt.gorogrp.Cancel()
+}
+
+func (bt *backlogTrimmer) trimPump(ctx context.Context) error {
+	trimTimer := time.NewTimer(bt.tlMgr.config.BacklogTrimInterval())
+	defer trimTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-trimTimer.C:
+			if bt.tlMgr.config.EnableBacklogTrimming() {
+				if _, err := bt.trimBacklog(ctx, bt.validateTask); err != nil {
+					bt.tlMgr.signalIfFatal(err)
+					bt.tlMgr.logger.Warn("taskQueue: failed to trim task backlog", tag.Error(err))
+				}
+			}
+			trimTimer.Reset(bt.tlMgr.config.BacklogTrimInterval())
+		}
+	}
+}
+
+// trimBacklog reads a batch of backlog tasks beyond the current read level and deletes
+// the stale ones. Only tasks older than BacklogTrimMinTaskAge are considered, and at most
+// BacklogTrimSampleSize of them are validated against history per pass. Returns the
+// number of tasks deleted.
+func (bt *backlogTrimmer) trimBacklog(
+	ctx context.Context,
+	validate taskValidator,
+) (int, error) {
+	readLevel := bt.tlMgr.taskAckManager.getReadLevel()
+	maxReadLevel := bt.tlMgr.taskWriter.GetMaxReadLevel()
+	if readLevel >= maxReadLevel {
+		return 0, nil
+	}
+
+	response, err := bt.tlMgr.executeWithRetry(func() (interface{}, error) {
+		return bt.tlMgr.db.GetTasks(readLevel, maxReadLevel, bt.tlMgr.config.BacklogTrimBatchSize())
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var expired []*persistencespb.AllocatedTaskInfo
+	var sampled []*persistencespb.AllocatedTaskInfo
+	sampleSize := bt.tlMgr.config.BacklogTrimSampleSize()
+	minCreateTime := time.Now().UTC().Add(-bt.tlMgr.config.BacklogTrimMinTaskAge())
+	for _, task := range response.(*persistence.GetTasksResponse).Tasks {
+		if taskqueue.IsTaskExpired(task) {
+			expired = append(expired, task)
+			continue
+		}
+		if len(sampled) < sampleSize && timestamp.TimeValue(task.Data.GetCreateTime()).Before(minCreateTime) {
+			sampled = append(sampled, task)
+		}
+	}
+
+	trimmed := 0
+	for _, task := range expired {
+		if err := bt.tlMgr.db.CompleteTask(task.GetTaskId()); err != nil {
+			return trimmed, err
+		}
+		trimmed++
+		bt.scope().IncCounter(metrics.ExpiredTasksPerTaskQueueCounter)
+	}
+
+	for _, task := range bt.findStaleTasks(ctx, sampled, validate) {
+		if err := bt.tlMgr.db.CompleteTask(task.GetTaskId()); err != nil {
+			return trimmed, err
+		}
+		trimmed++
+		bt.scope().IncCounter(metrics.BacklogTrimmedTasksPerTaskQueueCounter)
+	}
+
+	if trimmed > 0 {
+		bt.tlMgr.logger.Info("taskQueue: trimmed stale tasks from backlog", tag.Counter(trimmed))
+	}
+	return trimmed, nil
+}
+
+// findStaleTasks validates the given tasks concurrently and returns the ones which are no
+// longer valid. Tasks which fail validation are kept, they will be retried on the next pass
+// or dispatched as usual.
+func (bt *backlogTrimmer) findStaleTasks(
+	ctx context.Context,
+	tasks []*persistencespb.AllocatedTaskInfo,
+	validate taskValidator,
+) []*persistencespb.AllocatedTaskInfo {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, backlogTrimValidationTimeout)
+	defer cancel()
+
+	stale := make([]bool, len(tasks))
+	var wg sync.WaitGroup
+	wg.Add(len(tasks))
+	for i, task := range tasks {
+		go func(i int, task *persistencespb.AllocatedTaskInfo) {
+			defer wg.Done()
+			valid, err := validate(ctx, task)
+			stale[i] = err == nil && !valid
+		}(i, task)
+	}
+	wg.Wait()
+
+	var result []*persistencespb.AllocatedTaskInfo
+	for i, task := range tasks {
+		if stale[i] {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+// validateTask checks the workflow of the given task against history. A task is considered
+// stale if its workflow no longer exists or is already closed.
+func (bt *backlogTrimmer) validateTask(
+	ctx context.Context,
+	task *persistencespb.AllocatedTaskInfo,
+) (bool, error) {
+	resp, err := bt.historyService.GetMutableState(ctx, &historyservice.GetMutableStateRequest{
+		NamespaceId: task.Data.GetNamespaceId(),
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: task.Data.GetWorkflowId(),
+			RunId:      task.Data.GetRunId(),
+		},
+	})
+	switch err.(type) {
+	case nil:
+		return resp.GetWorkflowState() != enumsspb.WORKFLOW_EXECUTION_STATE_COMPLETED, nil
+	case *serviceerror.NotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (bt *backlogTrimmer) scope() metrics.Scope {
+	return bt.tlMgr.metricScope()
+}
@@ -41,6 +41,17 @@ type (
 		RPS                     dynamicconfig.IntPropertyFn
 		ShutdownDrainDuration   dynamicconfig.DurationPropertyFn
 
+		// QueueBacklogMetricsInterval is the interval of the per-host backlog gauges used for autoscaling
+		QueueBacklogMetricsInterval dynamicconfig.DurationPropertyFn
+		// StickyPollerUnavailableWindow is the time since the last poll of a sticky task queue after which
+		// queries dispatched to it fail right away, so that history falls back to the normal task queue
+		StickyPollerUnavailableWindow dynamicconfig.DurationPropertyFnWithNamespaceFilter
+		// EnableNamespaceFairShare shares RPS among the namespaces with recent requests by NamespaceFairShareWeight,
+		// and reports namespaces without any request allowed for FairShareStarvationThreshold as starved
+		EnableNamespaceFairShare     dynamicconfig.BoolPropertyFn
+		NamespaceFairShareWeight     dynamicconfig.FloatPropertyFnWithNamespaceFilter
+		FairShareStarvationThreshold dynamicconfig.DurationPropertyFn
+
 		// taskQueueManager configuration
 
 		RangeSize                    int64
@@ -54,12 +65,21 @@ type (
 		ForwarderMaxOutstandingTasks dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 		ForwarderMaxRatePerSecond    dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 		ForwarderMaxChildrenPerNode  dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+		ForwarderPollLocalWait       dynamicconfig.DurationPropertyFnWithTaskQueueInfoFilters
+		ForwarderPollLatencyBudget   dynamicconfig.DurationPropertyFnWithTaskQueueInfoFilters
 
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskQueueInfoFilters
 		MinTaskThrottlingBurstSize dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 		MaxTaskDeleteBatchSize     dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 
+		// backlogTrimmer configuration
+		EnableBacklogTrimming dynamicconfig.BoolPropertyFnWithTaskQueueInfoFilters
+		BacklogTrimInterval   dynamicconfig.DurationPropertyFnWithTaskQueueInfoFilters
+		BacklogTrimMinTaskAge dynamicconfig.DurationPropertyFnWithTaskQueueInfoFilters
+		BacklogTrimBatchSize  dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+		BacklogTrimSampleSize dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 		MaxTaskBatchSize                dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
@@ -89,11 +109,20 @@ type (
 		MaxTaskqueueIdleTime       func() time.Duration
 		MinTaskThrottlingBurstSize func() int
 		MaxTaskDeleteBatchSize     func() int
+		// backlogTrimmer configuration
+		EnableBacklogTrimming func() bool
+		BacklogTrimInterval   func() time.Duration
+		BacklogTrimMinTaskAge func() time.Duration
+		BacklogTrimBatchSize  func() int
+		BacklogTrimSampleSize func() int
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold func() int
 		MaxTaskBatchSize                func() int
 		NumWritePartitions              func() int
 		NumReadPartitions               func() int
+		// poll forwarding configuration
+		ForwarderPollLocalWait     func() time.Duration
+		ForwarderPollLatencyBudget func() time.Duration
 
 		// partition qps = AdminNamespaceToPartitionDispatchRate(namespace)
 		AdminNamespaceToPartitionDispatchRate func() float64
@@ -117,6 +146,11 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		LongPollExpirationInterval:      dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingLongPollExpirationInterval, time.Minute),
 		MinTaskThrottlingBurstSize:      dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMinTaskThrottlingBurstSize, 1),
 		MaxTaskDeleteBatchSize:          dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxTaskDeleteBatchSize, 100),
+		EnableBacklogTrimming:           dc.GetBoolPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingEnableBacklogTrimming, false),
+		BacklogTrimInterval:             dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingBacklogTrimInterval, 5*time.Minute),
+		BacklogTrimMinTaskAge:           dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingBacklogTrimMinTaskAge, time.Hour),
+		BacklogTrimBatchSize:            dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingBacklogTrimBatchSize, 1000),
+		BacklogTrimSampleSize:           dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingBacklogTrimSampleSize, 100),
 		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
 		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
 		ThrottledLogRPS:                 dc.GetIntProperty(dynamicconfig.MatchingThrottledLogRPS, 20),
@@ -126,7 +160,14 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		ForwarderMaxOutstandingTasks:    dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxOutstandingTasks, 1),
 		ForwarderMaxRatePerSecond:       dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxRatePerSecond, 10),
 		ForwarderMaxChildrenPerNode:     dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxChildrenPerNode, 20),
+		ForwarderPollLocalWait:          dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderPollLocalWait, 0),
+		ForwarderPollLatencyBudget:      dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderPollLatencyBudget, 0),
 		ShutdownDrainDuration:           dc.GetDurationProperty(dynamicconfig.MatchingShutdownDrainDuration, 0),
+		QueueBacklogMetricsInterval:     dc.GetDurationProperty(dynamicconfig.MatchingQueueBacklogMetricsInterval, 30*time.Second),
+		StickyPollerUnavailableWindow:   dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.MatchingStickyPollerUnavailableWindow, 10*time.Second),
+		EnableNamespaceFairShare:        dc.GetBoolProperty(dynamicconfig.MatchingEnableNamespaceFairShare, false),
+		NamespaceFairShareWeight:        dc.GetFloatPropertyFilteredByNamespace(dynamicconfig.MatchingNamespaceFairShareWeight, 1),
+		FairShareStarvationThreshold:    dc.GetDurationProperty(dynamicconfig.MatchingFairShareStarvationThreshold, 10*time.Second),
 
 		AdminNamespaceToPartitionDispatchRate:          dc.GetFloatPropertyFilteredByNamespace(dynamicconfig.AdminMatchingNamespaceToPartitionDispatchRate, 10000),
 		AdminNamespaceTaskqueueToPartitionDispatchRate: dc.GetFloatPropertyFilteredByTaskQueueInfo(dynamicconfig.AdminMatchingNamespaceTaskqueueToPartitionDispatchRate, 1000),
@@ -176,6 +217,21 @@ func newTaskQueueConfig(id *taskQueueID, config *Config, namespaceRegistry names
 		MaxTaskDeleteBatchSize: func() int {
 			return config.MaxTaskDeleteBatchSize(namespace.String(), taskQueueName, taskType)
 		},
+		EnableBacklogTrimming: func() bool {
+			return config.EnableBacklogTrimming(namespace.String(), taskQueueName, taskType)
+		},
+		BacklogTrimInterval: func() time.Duration {
+			return config.BacklogTrimInterval(namespace.String(), taskQueueName, taskType)
+		},
+		BacklogTrimMinTaskAge: func() time.Duration {
+			return config.BacklogTrimMinTaskAge(namespace.String(), taskQueueName, taskType)
+		},
+		BacklogTrimBatchSize: func() int {
+			return config.BacklogTrimBatchSize(namespace.String(), taskQueueName, taskType)
+		},
+		BacklogTrimSampleSize: func() int {
+			return config.BacklogTrimSampleSize(namespace.String(), taskQueueName, taskType)
+		},
 		OutstandingTaskAppendsThreshold: func() int {
 			return config.OutstandingTaskAppendsThreshold(namespace.String(), taskQueueName, taskType)
 		},
@@ -184,6 +240,12 @@ func newTaskQueueConfig(id *taskQueueID, config *Config, namespaceRegistry names
 		},
 		NumWritePartitions: writePartition,
 		NumReadPartitions:  readPartition,
+		ForwarderPollLocalWait: func() time.Duration {
+			return config.ForwarderPollLocalWait(namespace.String(), taskQueueName, taskType)
+		},
+		ForwarderPollLatencyBudget: func() time.Duration {
+			return config.ForwarderPollLatencyBudget(namespace.String(), taskQueueName, taskType)
+		},
 		AdminNamespaceToPartitionDispatchRate: func() float64 {
 			return config.AdminNamespaceToPartitionDispatchRate(namespace.String())
 		},
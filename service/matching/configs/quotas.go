@@ -25,9 +25,21 @@
 package configs
 
 import (
+	"context"
+	"time"
+
 	"go.temporal.io/server/common/quotas"
 )
 
+type (
+	// fairShareRateLimiter skips the host rate limiter for the APIs whose RPS the handler shares among
+	// namespaces, so that those requests are only limited once, by the namespace fair share.
+	fairShareRateLimiter struct {
+		rateLimiter      quotas.RequestRateLimiter
+		fairShareEnabled func() bool
+	}
+)
+
 var (
 	APIToPriority = map[string]int{
 		"AddActivityTask":           0,
@@ -44,14 +56,65 @@ var (
 	APIPriorities = map[int]struct{}{
 		0: {},
 	}
+
+	// FairShareAPIs are the APIs limited by the namespace fair share when it is enabled.
+	FairShareAPIs = map[string]struct{}{
+		"AddActivityTask":       {},
+		"AddWorkflowTask":       {},
+		"PollActivityTaskQueue": {},
+		"PollWorkflowTaskQueue": {},
+	}
 )
 
 func NewPriorityRateLimiter(
 	rateFn quotas.RateFn,
+	fairShareEnabled func() bool,
 ) quotas.RequestRateLimiter {
 	rateLimiters := make(map[int]quotas.RateLimiter)
 	for priority := range APIPriorities {
 		rateLimiters[priority] = quotas.NewDefaultIncomingRateLimiter(rateFn)
 	}
-	return quotas.NewPriorityRateLimiter(APIToPriority, rateLimiters)
+	return &fairShareRateLimiter{
+		rateLimiter:      quotas.NewPriorityRateLimiter(APIToPriority, rateLimiters),
+		fairShareEnabled: fairShareEnabled,
+	}
+}
+
+func (r *fairShareRateLimiter) Allow(
+	now time.Time,
+	request quotas.Request,
+) bool {
+	if r.isFairShare(request) {
+		return true
+	}
+	return r.rateLimiter.Allow(now, request)
+}
+
+func (r *fairShareRateLimiter) Reserve(
+	now time.Time,
+	request quotas.Request,
+) quotas.Reservation {
+	if r.isFairShare(request) {
+		return quotas.NewNoopReservation()
+	}
+	return r.rateLimiter.Reserve(now, request)
+}
+
+func (r *fairShareRateLimiter) Wait(
+	ctx context.Context,
+	request quotas.Request,
+) error {
+	if r.isFairShare(request) {
+		return nil
+	}
+	return r.rateLimiter.Wait(ctx, request)
+}
+
+func (r *fairShareRateLimiter) isFairShare(
+	request quotas.Request,
+) bool {
+	if _, ok := FairShareAPIs[request.API]; !ok {
+		return false
+	}
+	return r.fairShareEnabled()
 }
@@ -27,11 +27,13 @@ package configs
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"go.temporal.io/server/api/matchingservice/v1"
+	"go.temporal.io/server/common/quotas"
 )
 
 type (
@@ -77,3 +79,32 @@ func (s *quotasSuite) TestAPIs() {
 	}
 	s.Equal(apiToPriority, APIToPriority)
 }
+
+func (s *quotasSuite) TestFairShareAPIs() {
+	for api := range FairShareAPIs {
+		_, ok := APIToPriority[api]
+		s.True(ok, api)
+	}
+}
+
+func (s *quotasSuite) TestPriorityRateLimiter_FairShare() {
+	fairShareEnabled := false
+	rateLimiter := NewPriorityRateLimiter(
+		func() float64 { return 1 },
+		func() bool { return fairShareEnabled },
+	)
+	now := time.Now()
+
+	// use up the burst of the host limiter
+	s.True(rateLimiter.Allow(now, quotas.NewRequest("PollWorkflowTaskQueue", 1, "")))
+	for i := 0; i < 10 && rateLimiter.Allow(now, quotas.NewRequest("PollWorkflowTaskQueue", 1, "")); i++ {
+	}
+	s.False(rateLimiter.Allow(now, quotas.NewRequest("PollWorkflowTaskQueue", 1, "")))
+
+	fairShareEnabled = true
+	for api := range FairShareAPIs {
+		s.True(rateLimiter.Allow(now, quotas.NewRequest(api, 1, "")))
+		s.Zero(rateLimiter.Reserve(now, quotas.NewRequest(api, 1, "")).DelayFrom(now))
+	}
+	s.False(rateLimiter.Allow(now, quotas.NewRequest("DescribeTaskQueue", 1, "")))
+}
@@ -80,6 +80,8 @@ var (
 	errTaskQueueKind        = errors.New("forwarding is not supported on sticky task queue")
 	errInvalidTaskQueueType = errors.New("unrecognized task queue type")
 	errForwarderSlowDown    = errors.New("limit exceeded")
+
+	errForwardPollBudgetExceeded = errors.New("not enough time left to forward poll")
 )
 
 // newForwarder returns an instance of Forwarder object which
@@ -79,7 +79,10 @@ func RateLimitInterceptorProvider(
 	serviceConfig *Config,
 ) *interceptor.RateLimitInterceptor {
 	return interceptor.NewRateLimitInterceptor(
-		configs.NewPriorityRateLimiter(func() float64 { return float64(serviceConfig.RPS()) }),
+		configs.NewPriorityRateLimiter(
+			func() float64 { return float64(serviceConfig.RPS()) },
+			func() bool { return serviceConfig.EnableNamespaceFairShare() },
+		),
 		map[string]int{},
 	)
 }
@@ -36,8 +36,10 @@ import (
 	"go.temporal.io/server/api/matchingservice/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/quotas"
 	"go.temporal.io/server/common/resource"
 )
 
@@ -46,11 +48,12 @@ type (
 	Handler struct {
 		resource.Resource
 
-		engine        Engine
-		config        *Config
-		metricsClient metrics.Client
-		logger        log.Logger
-		startWG       sync.WaitGroup
+		engine          Engine
+		config          *Config
+		metricsClient   metrics.Client
+		logger          log.Logger
+		startWG         sync.WaitGroup
+		fairRateLimiter quotas.RequestRateLimiter
 	}
 )
 
@@ -61,7 +64,8 @@ const (
 var (
 	_ matchingservice.MatchingServiceServer = (*Handler)(nil)
 
-	errMatchingHostThrottle = serviceerror.NewResourceExhausted("Matching host RPS exceeded.")
+	errMatchingHostThrottle       = serviceerror.NewResourceExhausted("Matching host RPS exceeded.")
+	errNamespaceFairShareThrottle = serviceerror.NewResourceExhausted("Namespace fair share of matching host RPS exceeded.")
 )
 
 // NewHandler creates a gRPC handler for the matchingservice
@@ -87,6 +91,13 @@ func NewHandler(
 		),
 	}
 
+	handler.fairRateLimiter = quotas.NewFairRateLimiter(
+		func() float64 { return float64(config.RPS()) },
+		func(namespaceName string) float64 { return config.NamespaceFairShareWeight(namespaceName) },
+		func() time.Duration { return config.FairShareStarvationThreshold() },
+		handler.onNamespaceStarved,
+	)
+
 	// prevent from serving requests before matching engine is started and ready
 	handler.startWG.Add(1)
 
@@ -158,6 +169,10 @@ func (h *Handler) AddActivityTask(
 		h.reportForwardedPerTaskQueueCounter(hCtx, namespace.ID(request.GetNamespaceId()))
 	}
 
+	if err := h.allowNamespaceFairShare(hCtx, namespace.ID(request.GetNamespaceId()), "AddActivityTask"); err != nil {
+		return nil, err
+	}
+
 	syncMatch, err := h.engine.AddActivityTask(hCtx, request)
 	if syncMatch {
 		hCtx.scope.RecordTimer(metrics.SyncMatchLatencyPerTaskQueue, time.Since(startT))
@@ -184,6 +199,10 @@ func (h *Handler) AddWorkflowTask(
 		h.reportForwardedPerTaskQueueCounter(hCtx, namespace.ID(request.GetNamespaceId()))
 	}
 
+	if err := h.allowNamespaceFairShare(hCtx, namespace.ID(request.GetNamespaceId()), "AddWorkflowTask"); err != nil {
+		return nil, err
+	}
+
 	syncMatch, err := h.engine.AddWorkflowTask(hCtx, request)
 	if syncMatch {
 		hCtx.scope.RecordTimer(metrics.SyncMatchLatencyPerTaskQueue, time.Since(startT))
@@ -216,6 +235,10 @@ func (h *Handler) PollActivityTaskQueue(
 		return nil, err
 	}
 
+	if err := h.allowNamespaceFairShare(hCtx, namespace.ID(request.GetNamespaceId()), "PollActivityTaskQueue"); err != nil {
+		return nil, err
+	}
+
 	response, err := h.engine.PollActivityTaskQueue(hCtx, request)
 	return response, err
 }
@@ -245,6 +268,10 @@ func (h *Handler) PollWorkflowTaskQueue(
 		return nil, err
 	}
 
+	if err := h.allowNamespaceFairShare(hCtx, namespace.ID(request.GetNamespaceId()), "PollWorkflowTaskQueue"); err != nil {
+		return nil, err
+	}
+
 	response, err := h.engine.PollWorkflowTaskQueue(hCtx, request)
 	return response, err
 }
@@ -348,6 +375,29 @@ func (h *Handler) namespaceName(id namespace.ID) namespace.Name {
 	return entry.Name()
 }
 
+// allowNamespaceFairShare rejects the request if its namespace used up its share of the host RPS, when
+// namespace fair share is enabled. The host RPS is shared among the namespaces with recent requests by weight;
+// the host rate limit interceptor doesn't take tokens for these APIs then (see configs.FairShareAPIs).
+func (h *Handler) allowNamespaceFairShare(hCtx *handlerContext, namespaceID namespace.ID, api string) error {
+	if !h.config.EnableNamespaceFairShare() {
+		return nil
+	}
+	if h.fairRateLimiter.Allow(time.Now().UTC(), quotas.NewRequest(api, 1, h.namespaceName(namespaceID).String())) {
+		return nil
+	}
+	hCtx.scope.IncCounter(metrics.FairShareThrottlePerTaskQueueCounter)
+	return errNamespaceFairShareThrottle
+}
+
+func (h *Handler) onNamespaceStarved(namespaceName string, starvedFor time.Duration) {
+	h.metricsClient.Scope(metrics.MatchingEngineScope, metrics.NamespaceTag(namespaceName)).
+		IncCounter(metrics.NamespaceFairShareStarvedCounter)
+	h.GetThrottledLogger().Warn("Namespace had no matching requests allowed by its fair share.",
+		tag.WorkflowNamespace(namespaceName),
+		tag.NewDurationTag("starved-for", starvedFor),
+	)
+}
+
 func (h *Handler) reportForwardedPerTaskQueueCounter(hCtx *handlerContext, namespaceId namespace.ID) {
 	hCtx.scope.IncCounter(metrics.ForwardedPerTaskQueueCounter)
 	h.GetMetricsClient().
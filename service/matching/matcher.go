@@ -330,8 +330,9 @@ func (tm *TaskMatcher) poll(ctx context.Context, queryOnly bool) (*internalTask,
 	// The priority order is:
 	// 1. ctx.Done
 	// 2. taskC and queryTaskC
-	// 3. forwarding
-	// 4. block looking locally for remainder of context lifetime
+	// 3. block looking locally for the local-first wait window, if forwarding is possible
+	// 4. forwarding
+	// 5. block looking locally for remainder of context lifetime
 	// To correctly handle priorities and allow any case to succeed, all select
 	// statements except for the last one must be non-blocking, and the last one
 	// must include all the previous cases.
@@ -359,7 +360,33 @@ func (tm *TaskMatcher) poll(ctx context.Context, queryOnly bool) (*internalTask,
 	default:
 	}
 
-	// 3. forwarding (and all other clauses repeated again)
+	// 3. local-first wait, since forwarded polls add latency even when local tasks arrive shortly after
+	if localWait := tm.config.ForwarderPollLocalWait(); localWait > 0 && tm.isForwardingAllowed() {
+		timer := time.NewTimer(localWait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			tm.scope().IncCounter(metrics.PollTimeoutPerTaskQueueCounter)
+			return nil, ErrNoTasks
+		case task := <-taskC:
+			timer.Stop()
+			if task.responseC != nil {
+				tm.scope().IncCounter(metrics.PollSuccessWithSyncPerTaskQueueCounter)
+			}
+			tm.scope().IncCounter(metrics.PollSuccessLocalWaitPerTaskQueueCounter)
+			tm.scope().IncCounter(metrics.PollSuccessPerTaskQueueCounter)
+			return task, nil
+		case task := <-queryTaskC:
+			timer.Stop()
+			tm.scope().IncCounter(metrics.PollSuccessWithSyncPerTaskQueueCounter)
+			tm.scope().IncCounter(metrics.PollSuccessLocalWaitPerTaskQueueCounter)
+			tm.scope().IncCounter(metrics.PollSuccessPerTaskQueueCounter)
+			return task, nil
+		case <-timer.C:
+		}
+	}
+
+	// 4. forwarding (and all other clauses repeated again)
 	select {
 	case <-ctx.Done():
 		tm.scope().IncCounter(metrics.PollTimeoutPerTaskQueueCounter)
@@ -375,14 +402,14 @@ func (tm *TaskMatcher) poll(ctx context.Context, queryOnly bool) (*internalTask,
 		tm.scope().IncCounter(metrics.PollSuccessPerTaskQueueCounter)
 		return task, nil
 	case token := <-tm.fwdrPollReqTokenC():
-		if task, err := tm.fwdr.ForwardPoll(ctx); err == nil {
+		if task, err := tm.forwardPoll(ctx); err == nil {
 			token.release()
 			return task, nil
 		}
 		token.release()
 	}
 
-	// 4. blocking local poll
+	// 5. blocking local poll
 	select {
 	case <-ctx.Done():
 		tm.scope().IncCounter(metrics.PollTimeoutPerTaskQueueCounter)
@@ -400,6 +427,28 @@ func (tm *TaskMatcher) poll(ctx context.Context, queryOnly bool) (*internalTask,
 	}
 }
 
+// forwardPoll forwards the poll to the parent partition, reserving the forwarding latency budget
+// of the poll timeout for the response of the parent partition to make it back to the poller
+func (tm *TaskMatcher) forwardPoll(ctx context.Context) (*internalTask, error) {
+	if budget := tm.config.ForwarderPollLatencyBudget(); budget > 0 {
+		if deadline, ok := ctx.Deadline(); ok {
+			if time.Until(deadline) <= budget {
+				return nil, errForwardPollBudgetExceeded
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, deadline.Add(-budget))
+			defer cancel()
+		}
+	}
+
+	task, err := tm.fwdr.ForwardPoll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tm.scope().IncCounter(metrics.PollSuccessForwardedPerTaskQueueCounter)
+	return task, nil
+}
+
 func (tm *TaskMatcher) fwdrPollReqTokenC() <-chan *ForwarderReqToken {
 	if tm.fwdr == nil {
 		return nil
@@ -474,6 +474,46 @@ func (t *MatcherTestSuite) TestRemotePollForQuery() {
 	t.True(task.isStarted())
 }
 
+func (t *MatcherTestSuite) TestLocalWaitBeforeRemotePoll() {
+	// force disable remote forwarding of tasks, poll forwarding is left enabled
+	<-t.fwdr.AddReqTokenC()
+	t.cfg.ForwarderPollLocalWait = func() time.Duration { return time.Second }
+
+	pollStarted := make(chan struct{})
+	taskC := make(chan *internalTask, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		close(pollStarted)
+		task, err := t.matcher.Poll(ctx)
+		cancel()
+		if err == nil {
+			task.finish(nil)
+		}
+		taskC <- task
+	}()
+
+	<-pollStarted
+	time.Sleep(10 * time.Millisecond)
+	task := newInternalTask(randomTaskInfo(), nil, enumsspb.TASK_SOURCE_HISTORY, "", true)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err := t.matcher.Offer(ctx, task)
+	cancel()
+	t.NoError(err)
+	t.True(syncMatch)
+	t.False((<-taskC).isStarted())
+}
+
+func (t *MatcherTestSuite) TestRemotePollLatencyBudgetExceeded() {
+	t.cfg.ForwarderPollLatencyBudget = func() time.Duration { return time.Second }
+
+	// poll is not forwarded, as the whole poll timeout is within the latency budget
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	task, err := t.matcher.Poll(ctx)
+	cancel()
+	t.Equal(ErrNoTasks, err)
+	t.Nil(task)
+}
+
 func (t *MatcherTestSuite) newNamespaceCache() namespace.Registry {
 	entry := namespace.NewLocalNamespaceForTest(
 		&persistencespb.NamespaceInfo{Name: "test-namespace"},
@@ -87,6 +87,7 @@ type (
 		tokenSerializer      common.TaskTokenSerializer
 		logger               log.Logger
 		metricsClient        metrics.Client
+		queueBacklogReporter *queueBacklogReporter
 		taskQueuesLock       sync.RWMutex                     // locks mutation of taskQueues
 		taskQueues           map[taskQueueID]taskQueueManager // Convert to LRU cache
 		taskQueueCount       map[taskQueueCounterKey]int      // per-namespace task queue counter
@@ -95,6 +96,7 @@ type (
 		namespaceRegistry    namespace.Registry
 		keyResolver          membership.ServiceResolver
 		clusterMeta          cluster.Metadata
+		shutdown             chan struct{}
 	}
 )
 
@@ -109,6 +111,9 @@ var (
 	// ErrNoTasks is exported temporarily for integration test
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task queue pump closed its channel")
+	// errStickyPollerUnavailable is a deadline exceeded error, which history treats like a query timed out on the
+	// sticky taskqueue: it clears stickiness and retries the query on the normal taskqueue
+	errStickyPollerUnavailable = serviceerror.NewDeadlineExceeded("sticky taskqueue has no pollers")
 
 	pollerIDKey pollerIDCtxKey = "pollerID"
 	identityKey identityCtxKey = "identity"
@@ -137,12 +142,14 @@ func NewEngine(taskManager persistence.TaskManager,
 		taskQueueCount:       make(map[taskQueueCounterKey]int),
 		logger:               log.With(logger, tag.ComponentMatchingEngine),
 		metricsClient:        metricsClient,
+		queueBacklogReporter: newQueueBacklogReporter(metricsClient),
 		matchingClient:       matchingClient,
 		config:               config,
 		lockableQueryTaskMap: lockableQueryTaskMap{queryTaskMap: make(map[string]chan *queryResult)},
 		namespaceRegistry:    namespaceRegistry,
 		keyResolver:          resolver,
 		clusterMeta:          clusterMeta,
+		shutdown:             make(chan struct{}),
 	}
 }
 
@@ -154,6 +161,8 @@ func (e *matchingEngineImpl) Start() {
 	) {
 		return
 	}
+
+	go e.queueBacklogMetricsPump()
 }
 
 func (e *matchingEngineImpl) Stop() {
@@ -165,6 +174,7 @@ func (e *matchingEngineImpl) Stop() {
 		return
 	}
 
+	close(e.shutdown)
 	for _, l := range e.getTaskQueues(math.MaxInt32) {
 		l.Stop()
 	}
@@ -410,7 +420,9 @@ pollLoop:
 			return e.createPollWorkflowTaskQueueResponse(task, resp, hCtx.scope), nil
 		}
 
+		dispatchStart := time.Now()
 		resp, err := e.recordWorkflowTaskStarted(hCtx.Context, request, task)
+		hCtx.scope.RecordTimer(metrics.DispatchLatencyPerTaskQueue, time.Since(dispatchStart))
 		if err != nil {
 			switch err.(type) {
 			case *serviceerror.NotFound, *serviceerrors.TaskAlreadyStarted:
@@ -477,7 +489,9 @@ pollLoop:
 			return task.pollActivityTaskQueueResponse(), nil
 		}
 
+		dispatchStart := time.Now()
 		resp, err := e.recordActivityTaskStarted(hCtx.Context, request, task)
+		hCtx.scope.RecordTimer(metrics.DispatchLatencyPerTaskQueue, time.Since(dispatchStart))
 		if err != nil {
 			switch err.(type) {
 			case *serviceerror.NotFound, *serviceerrors.TaskAlreadyStarted:
@@ -517,6 +531,15 @@ func (e *matchingEngineImpl) QueryWorkflow(
 	if err != nil {
 		return nil, err
 	}
+	if taskQueueKind == enumspb.TASK_QUEUE_KIND_STICKY {
+		// without a worker polling the sticky taskqueue the query would only wait for the sticky timeout,
+		// fail it right away so that history falls back to the normal taskqueue
+		window := e.config.StickyPollerUnavailableWindow(queryRequest.GetQueryRequest().GetNamespace())
+		if window > 0 && !tlMgr.HasPollerAfter(time.Now().Add(-window)) {
+			hCtx.scope.IncCounter(metrics.StickyPollerUnavailablePerTaskQueueCounter)
+			return nil, errStickyPollerUnavailable
+		}
+	}
 	taskID := uuid.New()
 	resp, err := tlMgr.DispatchQueryTask(hCtx.Context, taskID, queryRequest)
 
@@ -37,6 +37,7 @@ import (
 	"github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally/v4"
 	commandpb "go.temporal.io/api/command/v1"
@@ -137,16 +138,18 @@ func newMatchingEngine(
 	logger log.Logger, mockNamespaceCache namespace.Registry,
 ) *matchingEngineImpl {
 	return &matchingEngineImpl{
-		taskManager:       taskMgr,
-		historyService:    mockHistoryClient,
-		taskQueues:        make(map[taskQueueID]taskQueueManager),
-		taskQueueCount:    make(map[taskQueueCounterKey]int),
-		logger:            logger,
-		metricsClient:     metrics.NewNoopMetricsClient(),
-		tokenSerializer:   common.NewProtoTaskTokenSerializer(),
-		config:            config,
-		namespaceRegistry: mockNamespaceCache,
-		clusterMeta:       cluster.NewMetadataFromConfig(cluster.NewTestClusterMetadataConfig(false, true)),
+		taskManager:          taskMgr,
+		historyService:       mockHistoryClient,
+		taskQueues:           make(map[taskQueueID]taskQueueManager),
+		taskQueueCount:       make(map[taskQueueCounterKey]int),
+		logger:               logger,
+		metricsClient:        metrics.NewNoopMetricsClient(),
+		queueBacklogReporter: newQueueBacklogReporter(metrics.NewNoopMetricsClient()),
+		tokenSerializer:      common.NewProtoTaskTokenSerializer(),
+		config:               config,
+		namespaceRegistry:    mockNamespaceCache,
+		clusterMeta:          cluster.NewMetadataFromConfig(cluster.NewTestClusterMetadataConfig(false, true)),
+		shutdown:             make(chan struct{}),
 	}
 }
 
@@ -1849,6 +1852,46 @@ func Int64Comparator(a, b interface{}) int {
 	}
 }
 
+func TestQueueBacklogReporter(t *testing.T) {
+	scope := tally.NewTestScope("test", nil)
+	reporter := newQueueBacklogReporter(metrics.NewClient(&metrics.ClientConfig{}, scope, metrics.Matching))
+	gauge := func(name string, taskType enumspb.TaskQueueType) (float64, bool) {
+		for _, g := range scope.Snapshot().Gauges() {
+			if g.Name() == "test."+name && g.Tags()["task_type"] == taskType.String() {
+				return g.Value(), true
+			}
+		}
+		return 0, false
+	}
+
+	workflowQueue := *newTestTaskQueueID("namespace-id", "workflow-queue", enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+	activityQueue := *newTestTaskQueueID("namespace-id", "activity-queue", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	now := time.Now().UTC()
+	reporter.report(now, map[taskQueueID]taskQueueBacklog{
+		workflowQueue: {taskType: enumspb.TASK_QUEUE_TYPE_WORKFLOW, ackLevel: 100, backlog: 5},
+	})
+	backlog, _ := gauge("queue_backlog", enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+	require.Equal(t, float64(5), backlog)
+	backlog, _ = gauge("queue_backlog", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	require.Equal(t, float64(0), backlog)
+	_, ok := gauge("queue_processing_rate", enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+	require.False(t, ok)
+
+	reporter.report(now.Add(10*time.Second), map[taskQueueID]taskQueueBacklog{
+		workflowQueue: {taskType: enumspb.TASK_QUEUE_TYPE_WORKFLOW, ackLevel: 150, backlog: 2},
+		activityQueue: {taskType: enumspb.TASK_QUEUE_TYPE_ACTIVITY, ackLevel: 500, backlog: 7},
+	})
+	backlog, _ = gauge("queue_backlog", enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+	require.Equal(t, float64(2), backlog)
+	backlog, _ = gauge("queue_backlog", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	require.Equal(t, float64(7), backlog)
+	rate, _ := gauge("queue_processing_rate", enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+	require.Equal(t, float64(5), rate)
+	// the activity queue was not owned at the previous report, so it has no progress yet
+	rate, _ = gauge("queue_processing_rate", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	require.Equal(t, float64(0), rate)
+}
+
 func newTestTaskQueueManager() *testTaskQueueManager {
 	return &testTaskQueueManager{tasks: treemap.NewWith(Int64Comparator)}
 }
@@ -72,6 +72,18 @@ func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity, ratePerSecond
 	pollers.history.Put(id, &pollerInfo{ratePerSecond: rps})
 }
 
+// hasPollerAfter returns true if any poller polled after accessTime
+func (pollers *pollerHistory) hasPollerAfter(accessTime time.Time) bool {
+	ite := pollers.history.Iterator()
+	defer ite.Close()
+	for ite.HasNext() {
+		if ite.Next().CreateTime().After(accessTime) {
+			return true
+		}
+	}
+	return false
+}
+
 func (pollers *pollerHistory) getAllPollerInfo() []*taskqueuepb.PollerInfo {
 	var result []*taskqueuepb.PollerInfo
 
@@ -0,0 +1,117 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"math"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/common/metrics"
+)
+
+type (
+	// taskQueueBacklog is the processing state of a task queue owned by this host
+	taskQueueBacklog struct {
+		taskType enumspb.TaskQueueType
+		ackLevel int64
+		backlog  int64
+	}
+
+	// queueBacklogReporter aggregates task queue backlogs into per-host gauges, which are designed
+	// to be consumed as external metrics by autoscalers such as Kubernetes HPA or KEDA
+	queueBacklogReporter struct {
+		metricsClient metrics.Client
+		lastReport    time.Time
+		lastBacklogs  map[taskQueueID]taskQueueBacklog
+	}
+)
+
+func newQueueBacklogReporter(metricsClient metrics.Client) *queueBacklogReporter {
+	return &queueBacklogReporter{
+		metricsClient: metricsClient,
+	}
+}
+
+// report emits total backlog and processing rate per task type across the given task queues.
+// Processing rate is the ack level progress per second since the previous report, summed over
+// task queues that were owned by this host at both reports.
+func (r *queueBacklogReporter) report(now time.Time, backlogs map[taskQueueID]taskQueueBacklog) {
+	totalBacklog := map[enumspb.TaskQueueType]int64{
+		enumspb.TASK_QUEUE_TYPE_WORKFLOW: 0,
+		enumspb.TASK_QUEUE_TYPE_ACTIVITY: 0,
+	}
+	totalProgress := make(map[enumspb.TaskQueueType]int64)
+	for id, backlog := range backlogs {
+		totalBacklog[backlog.taskType] += backlog.backlog
+		if last, ok := r.lastBacklogs[id]; ok && backlog.ackLevel > last.ackLevel {
+			totalProgress[backlog.taskType] += backlog.ackLevel - last.ackLevel
+		}
+	}
+
+	elapsed := now.Sub(r.lastReport).Seconds()
+	for taskType, backlog := range totalBacklog {
+		scope := r.metricsClient.Scope(metrics.MatchingEngineScope, metrics.TaskTypeTag(taskType.String()))
+		scope.UpdateGauge(metrics.QueueBacklogGauge, float64(backlog))
+		if !r.lastReport.IsZero() && elapsed > 0 {
+			scope.UpdateGauge(metrics.QueueProcessingRateGauge, float64(totalProgress[taskType])/elapsed)
+		}
+	}
+
+	r.lastReport = now
+	r.lastBacklogs = backlogs
+}
+
+func (e *matchingEngineImpl) queueBacklogMetricsPump() {
+	// interval is re-read on every tick so that dynamic config changes take effect
+	timer := time.NewTimer(e.config.QueueBacklogMetricsInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-e.shutdown:
+			return
+		case <-timer.C:
+			e.queueBacklogReporter.report(time.Now().UTC(), e.getTaskQueueBacklogs())
+			timer.Reset(e.config.QueueBacklogMetricsInterval())
+		}
+	}
+}
+
+func (e *matchingEngineImpl) getTaskQueueBacklogs() map[taskQueueID]taskQueueBacklog {
+	taskQueues := e.getTaskQueues(math.MaxInt32)
+	backlogs := make(map[taskQueueID]taskQueueBacklog, len(taskQueues))
+	for _, tqMgr := range taskQueues {
+		ackLevel, backlog := tqMgr.BacklogStatus()
+		id := tqMgr.QueueID()
+		backlogs[*id] = taskQueueBacklog{
+			taskType: id.taskType,
+			ackLevel: ackLevel,
+			backlog:  backlog,
+		}
+	}
+	return backlogs
+}
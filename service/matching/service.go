@@ -90,7 +90,10 @@ func NewService(
 		logger,
 	)
 	rateLimiterInterceptor := interceptor.NewRateLimitInterceptor(
-		configs.NewPriorityRateLimiter(func() float64 { return float64(serviceConfig.RPS()) }),
+		configs.NewPriorityRateLimiter(
+			func() float64 { return float64(serviceConfig.RPS()) },
+			func() bool { return serviceConfig.EnableNamespaceFairShare() },
+		),
 		map[string]int{},
 	)
 
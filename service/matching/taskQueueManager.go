@@ -96,8 +96,12 @@ type (
 		DispatchQueryTask(ctx context.Context, taskID string, request *matchingservice.QueryWorkflowRequest) (*matchingservice.QueryWorkflowResponse, error)
 		CancelPoller(pollerID string)
 		GetAllPollerInfo() []*taskqueuepb.PollerInfo
+		HasPollerAfter(accessTime time.Time) bool
 		// DescribeTaskQueue returns information about the target task queue
 		DescribeTaskQueue(includeTaskQueueStatus bool) *matchingservice.DescribeTaskQueueResponse
+		// BacklogStatus returns the ack level and the backlog count hint of the task queue, without
+		// collecting poller info like DescribeTaskQueue does
+		BacklogStatus() (ackLevel int64, backlogCountHint int64)
 		String() string
 		QueueID() *taskQueueID
 		TaskQueueKind() enumspb.TaskQueueKind
@@ -111,7 +115,8 @@ type (
 		config            *taskQueueConfig
 		db                *taskQueueDB
 		taskWriter        *taskWriter
-		taskReader        *taskReader // reads tasks from db and async matches it with poller
+		taskReader        *taskReader     // reads tasks from db and async matches it with poller
+		backlogTrimmer    *backlogTrimmer // deletes stale tasks from the backlog
 		liveness          *liveness
 		taskGC            *taskGC
 		taskAckManager    ackManager   // tracks ackLevel for delivered messages
@@ -197,6 +202,7 @@ func newTaskQueueManager(
 	)
 	tlMgr.taskWriter = newTaskWriter(tlMgr)
 	tlMgr.taskReader = newTaskReader(tlMgr)
+	tlMgr.backlogTrimmer = newBacklogTrimmer(tlMgr, e.historyService)
 
 	var fwdr *Forwarder
 	if tlMgr.isFowardingAllowed(taskQueue, taskQueueKind) {
@@ -240,6 +246,9 @@ func (c *taskQueueManagerImpl) Start() {
 	c.liveness.Start()
 	c.taskWriter.Start()
 	c.taskReader.Start()
+	if c.taskQueueKind != enumspb.TASK_QUEUE_KIND_STICKY {
+		c.backlogTrimmer.Start()
+	}
 	c.logger.Info("", tag.LifeCycleStarted)
 }
 
@@ -258,6 +267,7 @@ func (c *taskQueueManagerImpl) Stop() {
 	c.liveness.Stop()
 	c.taskWriter.Stop()
 	c.taskReader.Stop()
+	c.backlogTrimmer.Stop()
 	c.logger.Info("", tag.LifeCycleStopped)
 }
 
@@ -397,6 +407,17 @@ func (c *taskQueueManagerImpl) GetAllPollerInfo() []*taskqueuepb.PollerInfo {
 	return c.pollerHistory.getAllPollerInfo()
 }
 
+// HasPollerAfter returns true if a poller is currently polling this taskqueue or polled it after accessTime
+func (c *taskQueueManagerImpl) HasPollerAfter(accessTime time.Time) bool {
+	c.outstandingPollsLock.Lock()
+	outstandingPolls := len(c.outstandingPollsMap)
+	c.outstandingPollsLock.Unlock()
+	if outstandingPolls > 0 {
+		return true
+	}
+	return c.pollerHistory.hasPollerAfter(accessTime)
+}
+
 func (c *taskQueueManagerImpl) CancelPoller(pollerID string) {
 	c.outstandingPollsLock.Lock()
 	cancel, ok := c.outstandingPollsMap[pollerID]
@@ -431,6 +452,10 @@ func (c *taskQueueManagerImpl) DescribeTaskQueue(includeTaskQueueStatus bool) *m
 	return response
 }
 
+func (c *taskQueueManagerImpl) BacklogStatus() (int64, int64) {
+	return c.taskAckManager.getAckLevel(), c.taskAckManager.getBacklogCountHint()
+}
+
 func (c *taskQueueManagerImpl) String() string {
 	buf := new(bytes.Buffer)
 	if c.taskQueueID.taskType == enumspb.TASK_QUEUE_TYPE_ACTIVITY {
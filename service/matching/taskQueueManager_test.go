@@ -28,6 +28,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -429,3 +430,63 @@ func TestCheckIdleTaskQueue(t *testing.T) {
 	tlm.Stop()
 	require.Equal(t, common.DaemonStatusStopped, atomic.LoadInt32(&tlm.status))
 }
+
+func TestTrimBacklog(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := mustCreateTestTaskQueueManager(t, controller)
+	newTask := func(taskID int64, workflowID string, createTime *time.Time, expiryTime *time.Time) *persistencespb.AllocatedTaskInfo {
+		return &persistencespb.AllocatedTaskInfo{
+			Data: &persistencespb.TaskInfo{
+				NamespaceId: tlm.taskQueueID.namespaceID.String(),
+				WorkflowId:  workflowID,
+				RunId:       "run",
+				CreateTime:  createTime,
+				ExpiryTime:  expiryTime,
+			},
+			TaskId: taskID,
+		}
+	}
+	_, err := tlm.db.CreateTasks([]*persistencespb.AllocatedTaskInfo{
+		newTask(1, "running", timestamp.TimeNowPtrUtcAddSeconds(-2*60*60), nil),
+		newTask(2, "expired", timestamp.TimeNowPtrUtcAddSeconds(-2*60*60), timestamp.TimeNowPtrUtcAddSeconds(-60)),
+		newTask(3, "closed", timestamp.TimeNowPtrUtcAddSeconds(-2*60*60), nil),
+		newTask(4, "closed", timestamp.TimeNowPtrUtcAddSeconds(-60), nil),
+	})
+	require.NoError(t, err)
+	atomic.StoreInt64(&tlm.taskWriter.maxReadLevel, 4)
+
+	var lock sync.Mutex
+	var validated []int64
+	validate := func(_ context.Context, task *persistencespb.AllocatedTaskInfo) (bool, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		validated = append(validated, task.GetTaskId())
+		return task.Data.GetWorkflowId() == "running", nil
+	}
+
+	trimmed, err := tlm.backlogTrimmer.trimBacklog(context.Background(), validate)
+	require.NoError(t, err)
+	// expired task and the old task of the closed workflow are trimmed,
+	// the recent task is not validated since it is not old enough
+	require.Equal(t, 2, trimmed)
+	require.ElementsMatch(t, []int64{1, 3}, validated)
+	require.Equal(t, 2, tlm.db.store.(*testTaskManager).getTaskCount(tlm.taskQueueID))
+}
+
+func TestHasPollerAfter(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := mustCreateTestTaskQueueManagerWithConfig(t, controller, defaultTestConfig())
+	require.False(t, tlm.HasPollerAfter(time.Now().Add(-time.Minute)))
+
+	tlm.pollerHistory.updatePollerInfo(pollerIdentity("test-poll"), nil)
+	require.True(t, tlm.HasPollerAfter(time.Now().Add(-time.Minute)))
+	require.False(t, tlm.HasPollerAfter(time.Now().Add(time.Minute)))
+
+	// a poll in progress counts regardless of the poller history
+	tlm.outstandingPollsMap["poller-id"] = func() {}
+	require.True(t, tlm.HasPollerAfter(time.Now().Add(time.Minute)))
+}
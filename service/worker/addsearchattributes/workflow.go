@@ -51,6 +51,8 @@ type (
 	WorkflowParams struct {
 		// Elasticsearch index name. Can be empty string if Elasticsearch is not configured.
 		IndexName string
+		// Dedicated namespace indices which get the same search attributes as IndexName.
+		NamespaceIndexNames []string
 		// Search attributes that need to be added to the index.
 		CustomAttributesToAdd map[string]enumspb.IndexedValueType
 		// If true skip Elasticsearch schema update and only update cluster metadata.
@@ -112,9 +114,11 @@ func AddSearchAttributesWorkflow(ctx workflow.Context, params WorkflowParams) er
 		}
 
 		ctx2 := workflow.WithActivityOptions(ctx, waitForYellowStatusActivityOptions)
-		err = workflow.ExecuteActivity(ctx2, a.WaitForYellowStatusActivity, params.IndexName).Get(ctx, nil)
-		if err != nil {
-			return fmt.Errorf("%w: WaitForYellowStatusActivity: %v", ErrUnableToExecuteActivity, err)
+		for _, indexName := range params.indexNames() {
+			err = workflow.ExecuteActivity(ctx2, a.WaitForYellowStatusActivity, indexName).Get(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("%w: WaitForYellowStatusActivity: %v", ErrUnableToExecuteActivity, err)
+			}
 		}
 	}
 
@@ -134,18 +138,21 @@ func (a *activities) AddESMappingFieldActivity(ctx context.Context, params Workf
 		return nil
 	}
 
-	a.logger.Info("Creating Elasticsearch mapping.", tag.ESIndex(params.IndexName), tag.ESMapping(params.CustomAttributesToAdd))
-	_, err := a.esClient.PutMapping(ctx, params.IndexName, params.CustomAttributesToAdd)
-	if err != nil {
-		a.metricsClient.IncCounter(metrics.AddSearchAttributesWorkflowScope, metrics.AddSearchAttributesFailuresCount)
-		if esclient.IsRetryableError(err) {
-			a.logger.Error("Unable to update Elasticsearch mapping (retryable error).", tag.ESIndex(params.IndexName), tag.Error(err))
-			return fmt.Errorf("%w: %v", ErrUnableToUpdateESMapping, err)
+	// Adding existing field with the same type is no-op, therefore retries are safe.
+	for _, indexName := range params.indexNames() {
+		a.logger.Info("Creating Elasticsearch mapping.", tag.ESIndex(indexName), tag.ESMapping(params.CustomAttributesToAdd))
+		_, err := a.esClient.PutMapping(ctx, indexName, params.CustomAttributesToAdd)
+		if err != nil {
+			a.metricsClient.IncCounter(metrics.AddSearchAttributesWorkflowScope, metrics.AddSearchAttributesFailuresCount)
+			if esclient.IsRetryableError(err) {
+				a.logger.Error("Unable to update Elasticsearch mapping (retryable error).", tag.ESIndex(indexName), tag.Error(err))
+				return fmt.Errorf("%w: %v", ErrUnableToUpdateESMapping, err)
+			}
+			a.logger.Error("Unable to update Elasticsearch mapping (non-retryable error).", tag.ESIndex(indexName), tag.Error(err))
+			return temporal.NewNonRetryableApplicationError(fmt.Sprintf("%v: %v", ErrUnableToUpdateESMapping, err), "", nil)
 		}
-		a.logger.Error("Unable to update Elasticsearch mapping (non-retryable error).", tag.ESIndex(params.IndexName), tag.Error(err))
-		return temporal.NewNonRetryableApplicationError(fmt.Sprintf("%v: %v", ErrUnableToUpdateESMapping, err), "", nil)
+		a.logger.Info("Elasticsearch mapping created.", tag.ESIndex(indexName), tag.ESMapping(params.CustomAttributesToAdd))
 	}
-	a.logger.Info("Elasticsearch mapping created.", tag.ESIndex(params.IndexName), tag.ESMapping(params.CustomAttributesToAdd))
 
 	return nil
 }
@@ -167,24 +174,31 @@ func (a *activities) WaitForYellowStatusActivity(ctx context.Context, indexName
 }
 
 func (a *activities) UpdateClusterMetadataActivity(_ context.Context, params WorkflowParams) error {
-	oldSearchAttributes, err := a.saManager.GetSearchAttributes(params.IndexName, true)
-	if err != nil {
-		return fmt.Errorf("%w: %v", ErrUnableToGetSearchAttributes, err)
-	}
+	for _, indexName := range params.indexNames() {
+		oldSearchAttributes, err := a.saManager.GetSearchAttributes(indexName, true)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUnableToGetSearchAttributes, err)
+		}
 
-	newCustomSearchAttributes := map[string]enumspb.IndexedValueType{}
-	for saName, saType := range oldSearchAttributes.Custom() {
-		newCustomSearchAttributes[saName] = saType
-	}
-	for saName, saType := range params.CustomAttributesToAdd {
-		newCustomSearchAttributes[saName] = saType
-	}
-	err = a.saManager.SaveSearchAttributes(params.IndexName, newCustomSearchAttributes)
-	if err != nil {
-		a.logger.Info("Unable to save search attributes to cluster metadata.", tag.ESIndex(params.IndexName), tag.Error(err))
-		a.metricsClient.IncCounter(metrics.AddSearchAttributesWorkflowScope, metrics.AddSearchAttributesFailuresCount)
-		return fmt.Errorf("%w: %v", ErrUnableToSaveSearchAttributes, err)
+		newCustomSearchAttributes := map[string]enumspb.IndexedValueType{}
+		for saName, saType := range oldSearchAttributes.Custom() {
+			newCustomSearchAttributes[saName] = saType
+		}
+		for saName, saType := range params.CustomAttributesToAdd {
+			newCustomSearchAttributes[saName] = saType
+		}
+		err = a.saManager.SaveSearchAttributes(indexName, newCustomSearchAttributes)
+		if err != nil {
+			a.logger.Info("Unable to save search attributes to cluster metadata.", tag.ESIndex(indexName), tag.Error(err))
+			a.metricsClient.IncCounter(metrics.AddSearchAttributesWorkflowScope, metrics.AddSearchAttributesFailuresCount)
+			return fmt.Errorf("%w: %v", ErrUnableToSaveSearchAttributes, err)
+		}
+		a.logger.Info("Search attributes saved to cluster metadata.", tag.ESIndex(indexName))
 	}
-	a.logger.Info("Search attributes saved to cluster metadata.", tag.ESIndex(params.IndexName))
 	return nil
 }
+
+// indexNames returns IndexName followed by the dedicated namespace indices.
+func (p WorkflowParams) indexNames() []string {
+	return append([]string{p.IndexName}, p.NamespaceIndexNames...)
+}
@@ -26,11 +26,13 @@ package batcher
 
 import (
 	"context"
+	"sync"
 
 	"go.temporal.io/sdk/activity"
 	sdkclient "go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
+	"golang.org/x/time/rate"
 
 	"go.temporal.io/server/client"
 	"go.temporal.io/server/common/dynamicconfig"
@@ -46,6 +48,8 @@ type (
 		MaxConcurrentWorkflowTaskExecutionSize dynamicconfig.IntPropertyFn
 		MaxConcurrentActivityTaskPollers       dynamicconfig.IntPropertyFn
 		MaxConcurrentWorkflowTaskPollers       dynamicconfig.IntPropertyFn
+		BlastRadiusGuardFraction               dynamicconfig.FloatPropertyFnWithNamespaceFilter
+		NamespaceRPS                           dynamicconfig.IntPropertyFnWithNamespaceFilter
 	}
 
 	// BootstrapParams contains the set of params needed to bootstrap
@@ -70,6 +74,9 @@ type (
 		clientBean    client.Bean
 		metricsClient metrics.Client
 		logger        log.Logger
+
+		rateLimitersLock      sync.Mutex
+		namespaceRateLimiters map[string]*rate.Limiter
 	}
 )
 
@@ -82,6 +89,8 @@ func New(params *BootstrapParams) *Batcher {
 		metricsClient: params.MetricsClient,
 		logger:        log.With(params.Logger, tag.ComponentBatcher),
 		clientBean:    params.ClientBean,
+
+		namespaceRateLimiters: make(map[string]*rate.Limiter),
 	}
 }
 
@@ -103,3 +112,28 @@ func (s *Batcher) Start() error {
 
 	return batchWorker.Start()
 }
+
+// waitNamespaceRateLimit blocks until the rate limit shared by all batches of namespace processed on this host
+// allows one more operation
+func (s *Batcher) waitNamespaceRateLimit(ctx context.Context, namespace string) error {
+	rps := s.cfg.NamespaceRPS(namespace)
+	if rps <= 0 {
+		return nil
+	}
+	return s.getNamespaceRateLimiter(namespace, rps).Wait(ctx)
+}
+
+func (s *Batcher) getNamespaceRateLimiter(namespace string, rps int) *rate.Limiter {
+	s.rateLimitersLock.Lock()
+	defer s.rateLimitersLock.Unlock()
+
+	limiter, ok := s.namespaceRateLimiters[namespace]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(rps), rps)
+		s.namespaceRateLimiters[namespace] = limiter
+	} else if limiter.Burst() != rps {
+		limiter.SetLimit(rate.Limit(rps))
+		limiter.SetBurst(rps)
+	}
+	return limiter
+}
@@ -27,10 +27,12 @@ package batcher
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/activity"
@@ -42,6 +44,7 @@ import (
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/searchattribute"
 )
 
 const (
@@ -63,6 +66,9 @@ const (
 	DefaultAttemptsOnRetryableError = 50
 	// DefaultActivityHeartBeatTimeout is the default value for ActivityHeartBeatTimeout
 	DefaultActivityHeartBeatTimeout = time.Second * 10
+
+	// BlastRadiusGuardErrorType is the application error type returned when a batch is refused by the blast radius guard
+	BlastRadiusGuardErrorType = "BlastRadiusGuard"
 )
 
 const (
@@ -118,7 +124,8 @@ type (
 		CancelParams CancelParams
 		// SignalParams is params only for BatchTypeSignal
 		SignalParams SignalParams
-		// RPS of processing. Default to DefaultRPS
+		// RPS of processing. Default to DefaultRPS. All batches of a namespace are further limited by the
+		// worker.BatcherNamespaceRPS dynamic config
 		// TODO we will implement smarter way than this static rate limiter: https://go.temporal.io/server/issues/2138
 		RPS int
 		// Number of goroutines running in parallel to process
@@ -131,6 +138,12 @@ type (
 		NonRetryableErrors []string
 		// internal conversion for NonRetryableErrors
 		_nonRetryableErrors map[string]struct{}
+		// ConfirmLargeBatch has to be set to terminate or cancel more than the guarded fraction of open workflows
+		// of the namespace. Default to false.
+		ConfirmLargeBatch bool
+		// ExpectedCount is the number of workflows the caller expects the query to match, a confirmed large batch
+		// is still refused if the query matches more workflows than this
+		ExpectedCount int64
 	}
 
 	// HeartBeatDetails is the struct for heartbeat details
@@ -252,6 +265,10 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 			return HeartBeatDetails{}, err
 		}
 		hbd.TotalEstimate = resp.GetCount()
+
+		if err := checkBlastRadius(ctx, batcher, client, batchParams); err != nil {
+			return HeartBeatDetails{}, err
+		}
 	}
 	rateLimiter := rate.NewLimiter(rate.Limit(batchParams.RPS), batchParams.RPS)
 	taskCh := make(chan taskDetail, pageSize)
@@ -321,6 +338,103 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	return hbd, nil
 }
 
+// checkBlastRadius refuses terminate and cancel batches which would affect more than the configured fraction of
+// open workflows of the namespace, unless the caller confirmed the batch with an expected count covering the estimate.
+// Closed workflows are not affected by terminate or cancel, therefore only open workflows of the batch are counted.
+func checkBlastRadius(
+	ctx context.Context,
+	batcher *Batcher,
+	client workflowservice.WorkflowServiceClient,
+	batchParams BatchParams,
+) error {
+	if batchParams.BatchType != BatchTypeTerminate && batchParams.BatchType != BatchTypeCancel {
+		return nil
+	}
+	fraction := batcher.cfg.BlastRadiusGuardFraction(batchParams.Namespace)
+	if fraction <= 0 {
+		return nil
+	}
+
+	resp, err := client.CountWorkflowExecutions(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: batchParams.Namespace,
+		Query:     openWorkflowsQuery(batchParams.Query),
+	})
+	if err != nil {
+		return err
+	}
+	estimate := resp.GetCount()
+
+	resp, err = client.CountWorkflowExecutions(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: batchParams.Namespace,
+		Query:     openWorkflowsQuery(""),
+	})
+	if err != nil {
+		return err
+	}
+	openCount := resp.GetCount()
+	if !exceedsBlastRadius(estimate, openCount, fraction) {
+		return nil
+	}
+
+	refuseErr := validateLargeBatch(batchParams, estimate)
+	// audit event for every batch above the guarded fraction, whether it is allowed or not
+	getActivityLogger(ctx).Warn("Batch operation exceeds blast radius guard.",
+		tag.NewStringTag("batch-namespace", batchParams.Namespace),
+		tag.NewStringTag("batch-type", batchParams.BatchType),
+		tag.NewStringTag("batch-query", batchParams.Query),
+		tag.NewStringTag("batch-reason", batchParams.Reason),
+		tag.NewInt64("batch-estimate", estimate),
+		tag.NewInt64("batch-expected-count", batchParams.ExpectedCount),
+		tag.NewInt64("namespace-open-count", openCount),
+		tag.NewAnyTag("guard-fraction", fraction),
+		tag.NewBoolTag("batch-confirmed", batchParams.ConfirmLargeBatch),
+		tag.NewBoolTag("batch-allowed", refuseErr == nil),
+	)
+	if refuseErr != nil {
+		batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherBlastRadiusGuardRefused)
+	}
+	return refuseErr
+}
+
+// openWorkflowsQuery restricts the visibility query to running workflows
+func openWorkflowsQuery(query string) string {
+	openQuery := fmt.Sprintf("%s = '%s'", searchattribute.ExecutionStatus, enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	if strings.TrimSpace(query) == "" {
+		return openQuery
+	}
+	return fmt.Sprintf("(%s) AND %s", query, openQuery)
+}
+
+func exceedsBlastRadius(estimate int64, openCount int64, fraction float64) bool {
+	if estimate <= 0 {
+		return false
+	}
+	if openCount <= 0 {
+		return true
+	}
+	return float64(estimate) > fraction*float64(openCount)
+}
+
+func validateLargeBatch(batchParams BatchParams, estimate int64) error {
+	if !batchParams.ConfirmLargeBatch {
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("batch would affect %v workflows, a large portion of open workflows in namespace %v, it has to be explicitly confirmed",
+				estimate, batchParams.Namespace),
+			BlastRadiusGuardErrorType,
+			nil,
+		)
+	}
+	if estimate > batchParams.ExpectedCount {
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("batch would affect %v workflows, more than the confirmed count of %v",
+				estimate, batchParams.ExpectedCount),
+			BlastRadiusGuardErrorType,
+			nil,
+		)
+	}
+	return nil
+}
+
 func startTaskProcessor(
 	ctx context.Context,
 	batchParams BatchParams,
@@ -418,6 +532,7 @@ func processTask(
 	applyOnChild *bool,
 	procFn func(string, string) error,
 ) error {
+	batcher := ctx.Value(batcherContextKey).(*Batcher)
 	wfs := []commonpb.WorkflowExecution{task.execution}
 	for len(wfs) > 0 {
 		wf := wfs[0]
@@ -426,6 +541,9 @@ func processTask(
 		if err != nil {
 			return err
 		}
+		if err := batcher.waitNamespaceRateLimit(ctx, batchParams.Namespace); err != nil {
+			return err
+		}
 		activity.RecordHeartbeat(ctx, task.hbd)
 
 		err = procFn(wf.GetWorkflowId(), wf.GetRunId())
@@ -0,0 +1,87 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"golang.org/x/time/rate"
+
+	"go.temporal.io/server/common/log"
+)
+
+func TestExceedsBlastRadius(t *testing.T) {
+	require.False(t, exceedsBlastRadius(0, 100, 0.5))
+	require.False(t, exceedsBlastRadius(50, 100, 0.5))
+	require.True(t, exceedsBlastRadius(51, 100, 0.5))
+	require.True(t, exceedsBlastRadius(1, 0, 0.5))
+	require.False(t, exceedsBlastRadius(100, 100, 1))
+}
+
+func TestOpenWorkflowsQuery(t *testing.T) {
+	require.Equal(t, "ExecutionStatus = 'Running'", openWorkflowsQuery(""))
+	require.Equal(t, "(WorkflowType = 'a' OR WorkflowType = 'b') AND ExecutionStatus = 'Running'",
+		openWorkflowsQuery("WorkflowType = 'a' OR WorkflowType = 'b'"))
+}
+
+func TestValidateLargeBatch(t *testing.T) {
+	params := BatchParams{
+		Namespace: "test-namespace",
+		BatchType: BatchTypeTerminate,
+	}
+	var appErr *temporal.ApplicationError
+
+	err := validateLargeBatch(params, 100)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, BlastRadiusGuardErrorType, appErr.Type())
+	require.True(t, appErr.NonRetryable())
+
+	params.ConfirmLargeBatch = true
+	params.ExpectedCount = 80
+	err = validateLargeBatch(params, 100)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, BlastRadiusGuardErrorType, appErr.Type())
+
+	params.ExpectedCount = 100
+	require.NoError(t, validateLargeBatch(params, 100))
+}
+
+func TestGetNamespaceRateLimiter(t *testing.T) {
+	batcher := New(&BootstrapParams{
+		Logger: log.NewNoopLogger(),
+	})
+
+	limiter := batcher.getNamespaceRateLimiter("test-namespace", 10)
+	require.Equal(t, 10, limiter.Burst())
+	require.Same(t, limiter, batcher.getNamespaceRateLimiter("test-namespace", 10))
+	require.NotSame(t, limiter, batcher.getNamespaceRateLimiter("other-namespace", 10))
+
+	// config change applies to the existing limiter
+	require.Same(t, limiter, batcher.getNamespaceRateLimiter("test-namespace", 20))
+	require.Equal(t, 20, limiter.Burst())
+	require.Equal(t, rate.Limit(20), limiter.Limit())
+}
@@ -0,0 +1,112 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"fmt"
+
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/versionhistory"
+)
+
+const (
+	historyEventChecksumFailureType = "history_event_checksum_validator"
+
+	historyEventChecksumPageSize = 100
+)
+
+type (
+	// historyEventChecksumValidator is a validator that checks every history event batch
+	// of the current branch against the checksum persisted alongside it
+	historyEventChecksumValidator struct {
+		shardID          int32
+		executionManager persistence.ExecutionManager
+	}
+)
+
+var _ Validator = (*historyEventChecksumValidator)(nil)
+
+// NewHistoryEventChecksumValidator returns new instance.
+func NewHistoryEventChecksumValidator(
+	shardID int32,
+	executionManager persistence.ExecutionManager,
+) *historyEventChecksumValidator {
+	return &historyEventChecksumValidator{
+		shardID:          shardID,
+		executionManager: executionManager,
+	}
+}
+
+func (v *historyEventChecksumValidator) Validate(
+	mutableState *MutableState,
+) ([]MutableStateValidationResult, error) {
+	currentVersionHistory, err := versionhistory.GetCurrentVersionHistory(
+		mutableState.GetExecutionInfo().GetVersionHistories(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	lastItem, err := versionhistory.GetLastVersionHistoryItem(currentVersionHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextPageToken []byte
+	for {
+		resp, err := v.executionManager.ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
+			MinEventID:    common.FirstEventID,
+			MaxEventID:    lastItem.GetEventId() + 1,
+			BranchToken:   currentVersionHistory.BranchToken,
+			ShardID:       v.shardID,
+			PageSize:      historyEventChecksumPageSize,
+			NextPageToken: nextPageToken,
+		})
+		switch err := err.(type) {
+		case nil:
+		case *persistence.HistoryEventBatchChecksumError:
+			return []MutableStateValidationResult{{
+				failureType: historyEventChecksumFailureType,
+				failureDetails: fmt.Sprintf(
+					"history event batch checksum mismatch, node ID: %v, transaction ID: %v",
+					err.NodeID,
+					err.TransactionID,
+				),
+			}}, nil
+		case *serviceerror.NotFound:
+			// noop, missing history is reported by history event ID validator
+			return nil, nil
+		default:
+			return nil, err
+		}
+
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			return nil, nil
+		}
+	}
+}
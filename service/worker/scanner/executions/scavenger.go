@@ -33,6 +33,7 @@ import (
 	"go.temporal.io/server/common/quotas"
 
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/persistence"
@@ -48,8 +49,9 @@ const (
 type (
 	// Scavenger is the type that holds the state for executions scavenger daemon
 	Scavenger struct {
-		status           int32
-		numHistoryShards int32
+		status                           int32
+		numHistoryShards                 int32
+		historyChecksumValidationEnabled dynamicconfig.BoolPropertyFn
 
 		executionManager persistence.ExecutionManager
 		executor         executor.Executor
@@ -74,13 +76,15 @@ type (
 //  - Stop() method is called to stop the scavenger
 func NewScavenger(
 	numHistoryShards int32,
+	historyChecksumValidationEnabled dynamicconfig.BoolPropertyFn,
 	executionManager persistence.ExecutionManager,
 	metricsClient metrics.Client,
 	logger log.Logger,
 ) *Scavenger {
 	return &Scavenger{
-		numHistoryShards: numHistoryShards,
-		executionManager: executionManager,
+		numHistoryShards:                 numHistoryShards,
+		historyChecksumValidationEnabled: historyChecksumValidationEnabled,
+		executionManager:                 executionManager,
 		executor: executor.NewFixedSizePoolExecutor(
 			executorPoolSize,
 			executorMaxDeferredTasks,
@@ -156,6 +156,23 @@ func (t *task) validate(
 		results = append(results, validationResults...)
 	}
 
+	if t.scavenger.historyChecksumValidationEnabled() {
+		if validationResults, err := NewHistoryEventChecksumValidator(
+			t.shardID,
+			t.executionManager,
+		).Validate(mutableState); err != nil {
+			t.logger.Error("unable to validate history event batch checksums",
+				tag.ShardID(t.shardID),
+				tag.WorkflowNamespaceID(mutableState.GetExecutionInfo().GetNamespaceId()),
+				tag.WorkflowID(mutableState.GetExecutionInfo().GetWorkflowId()),
+				tag.WorkflowRunID(mutableState.GetExecutionState().GetRunId()),
+				tag.Error(err),
+			)
+		} else {
+			results = append(results, validationResults...)
+		}
+	}
+
 	return results
 }
 
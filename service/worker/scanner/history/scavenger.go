@@ -35,7 +35,9 @@ import (
 
 	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/collection"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
@@ -58,6 +60,7 @@ type (
 	// Scavenger is the type that holds the state for history scavenger daemon
 	Scavenger struct {
 		numShards   int32
+		partitions  *common.ShardPartitions
 		db          persistence.ExecutionManager
 		client      historyservice.HistoryServiceClient
 		rateLimiter quotas.RateLimiter
@@ -101,6 +104,7 @@ const (
 //  - deletion of history itself, if there are no workflow execution
 func NewScavenger(
 	numShards int32,
+	partitions dynamicconfig.MapPropertyFn,
 	db persistence.ExecutionManager,
 	rps int,
 	client historyservice.HistoryServiceClient,
@@ -110,9 +114,10 @@ func NewScavenger(
 ) *Scavenger {
 
 	return &Scavenger{
-		numShards: numShards,
-		db:        db,
-		client:    client,
+		numShards:  numShards,
+		partitions: common.NewShardPartitions(numShards, partitions, clock.NewRealTimeSource(), logger),
+		db:         db,
+		client:     client,
 		rateLimiter: quotas.NewDefaultOutgoingRateLimiter(
 			func() float64 { return float64(rps) },
 		),
@@ -231,7 +236,7 @@ func (s *Scavenger) filterTask(
 		s.hbd.ErrorCount++
 		return nil
 	}
-	shardID := common.WorkflowIDToHistoryShard(namespaceID, workflowID, s.numShards)
+	shardID := s.partitions.GetShardID(namespaceID, workflowID)
 
 	return &taskDetail{
 		shardID:     shardID,
@@ -68,6 +68,10 @@ type (
 		HistoryScannerEnabled dynamicconfig.BoolPropertyFn
 		// ExecutionsScannerEnabled indicates if executions scanner should be started as part of scanner
 		ExecutionsScannerEnabled dynamicconfig.BoolPropertyFn
+		// ExecutionsScannerHistoryChecksumValidationEnabled indicates if executions scanner should verify history event batch checksums
+		ExecutionsScannerHistoryChecksumValidationEnabled dynamicconfig.BoolPropertyFn
+		// NamespaceShardPartitions constrains namespaces to subsets of history shards
+		NamespaceShardPartitions dynamicconfig.MapPropertyFn
 	}
 
 	// scannerContext is the context object that get's
@@ -154,6 +154,7 @@ func HistoryScavengerActivity(
 
 	scavenger := history.NewScavenger(
 		numShards,
+		ctx.cfg.NamespaceShardPartitions,
 		ctx.executionManager,
 		rps,
 		ctx.historyClient,
@@ -193,6 +194,7 @@ func ExecutionsScavengerActivity(
 	metricsClient := ctx.metricsClient
 	scavenger := executions.NewScavenger(
 		ctx.cfg.Persistence.NumHistoryShards,
+		ctx.cfg.ExecutionsScannerHistoryChecksumValidationEnabled,
 		ctx.executionManager,
 		metricsClient,
 		ctx.logger,
@@ -219,6 +219,14 @@ func NewConfig(logger log.Logger, dcClient dynamicconfig.Client, params *resourc
 				dynamicconfig.WorkerBatcherMaxConcurrentWorkflowTaskPollers,
 				4,
 			),
+			BlastRadiusGuardFraction: dc.GetFloatPropertyFilteredByNamespace(
+				dynamicconfig.WorkerBatcherBlastRadiusGuardFraction,
+				0,
+			),
+			NamespaceRPS: dc.GetIntPropertyFilteredByNamespace(
+				dynamicconfig.WorkerBatcherNamespaceRPS,
+				0,
+			),
 		},
 		ParentCloseCfg: &parentclosepolicy.Config{
 			MaxConcurrentActivityExecutionSize: dc.GetIntProperty(
@@ -273,6 +281,14 @@ func NewConfig(logger log.Logger, dcClient dynamicconfig.Client, params *resourc
 				dynamicconfig.ExecutionsScannerEnabled,
 				false,
 			),
+			ExecutionsScannerHistoryChecksumValidationEnabled: dc.GetBoolProperty(
+				dynamicconfig.ExecutionsScannerHistoryChecksumValidationEnabled,
+				false,
+			),
+			NamespaceShardPartitions: dc.GetMapProperty(
+				dynamicconfig.NamespaceShardPartitions,
+				nil,
+			),
 		},
 		EnableBatcher: dc.GetBoolProperty(
 			dynamicconfig.EnableBatcher,
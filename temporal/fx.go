@@ -51,6 +51,7 @@ import (
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/cassandra"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/sql"
 	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
 	"go.temporal.io/server/common/pprof"
@@ -61,6 +62,8 @@ import (
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/service/frontend"
 	"go.temporal.io/server/service/history"
+	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/workflow"
 	"go.temporal.io/server/service/matching"
 	"go.temporal.io/server/service/worker"
 )
@@ -122,6 +125,9 @@ func NewServerFx(opts ...ServerOption) *ServerFx {
 		fx.Provide(AuthorizerProvider),
 		fx.Provide(ClaimMapperProvider),
 		fx.Provide(JWTAudienceMapperProvider),
+		fx.Provide(ShardLifecycleObserversProvider),
+		fx.Provide(EventsReapplyPolicyProvider),
+		fx.Provide(EncryptionProviderProvider),
 		fx.Invoke(ServerLifetimeHooks),
 		fx.NopLogger,
 	)
@@ -221,6 +227,27 @@ func JWTAudienceMapperProvider(so *serverOptions) authorization.JWTAudienceMappe
 	return so.audienceGetter
 }
 
+func EncryptionProviderProvider(so *serverOptions) serialization.EncryptionProvider {
+	return so.encryptionProvider
+}
+
+func ShardLifecycleObserversProvider(so *serverOptions) shard.LifecycleObservers {
+	var observers shard.LifecycleObservers
+	for _, hook := range so.afterShardAcquireHooks {
+		hook := hook
+		observers = append(observers, func(event shard.LifecycleEvent) {
+			if event.State == shard.LifecycleStateAcquired {
+				hook(event.ShardID, event.RangeID)
+			}
+		})
+	}
+	return observers
+}
+
+func EventsReapplyPolicyProvider(so *serverOptions) workflow.EventsReapplyPolicy {
+	return so.eventsReapplyPolicy
+}
+
 type (
 	ServiceProviderParamsCommon struct {
 		fx.In
@@ -245,6 +272,9 @@ type (
 		Authorizer                 authorization.Authorizer
 		ClaimMapper                authorization.ClaimMapper
 		DataStoreFactory           persistenceClient.AbstractDataStoreFactory
+		ShardLifecycleObservers    shard.LifecycleObservers
+		EventsReapplyPolicy        workflow.EventsReapplyPolicy
+		EncryptionProvider         serialization.EncryptionProvider
 	}
 )
 
@@ -274,6 +304,7 @@ func HistoryServiceProvider(
 			params.Cfg,
 		),
 		fx.Provide(func() persistenceClient.AbstractDataStoreFactory { return params.DataStoreFactory }),
+		fx.Provide(func() serialization.EncryptionProvider { return params.EncryptionProvider }),
 		fx.Provide(func() client.FactoryProvider { return params.ClientFactoryProvider }),
 		fx.Provide(func() authorization.JWTAudienceMapper { return params.AudienceGetter }),
 		fx.Provide(func() resolver.ServiceResolver { return params.PersistenceServiceResolver }),
@@ -289,6 +320,8 @@ func HistoryServiceProvider(
 		fx.Provide(func() SdkReporter { return params.SdkReporter }),
 		fx.Provide(func() NamespaceLogger { return params.NamespaceLogger }), // resolves untyped nil error
 		fx.Provide(func() esclient.Client { return params.EsClient }),
+		fx.Provide(func() shard.LifecycleObservers { return params.ShardLifecycleObservers }),
+		fx.Provide(func() workflow.EventsReapplyPolicy { return params.EventsReapplyPolicy }),
 		fx.Provide(newBootstrapParams),
 		history.Module,
 		fx.NopLogger,
@@ -330,6 +363,7 @@ func MatchingServiceProvider(
 			params.Cfg,
 		),
 		fx.Provide(func() persistenceClient.AbstractDataStoreFactory { return params.DataStoreFactory }),
+		fx.Provide(func() serialization.EncryptionProvider { return params.EncryptionProvider }),
 		fx.Provide(func() client.FactoryProvider { return params.ClientFactoryProvider }),
 		fx.Provide(func() authorization.JWTAudienceMapper { return params.AudienceGetter }),
 		fx.Provide(func() resolver.ServiceResolver { return params.PersistenceServiceResolver }),
@@ -386,6 +420,7 @@ func FrontendServiceProvider(
 			params.Cfg,
 		),
 		fx.Provide(func() persistenceClient.AbstractDataStoreFactory { return params.DataStoreFactory }),
+		fx.Provide(func() serialization.EncryptionProvider { return params.EncryptionProvider }),
 		fx.Provide(func() client.FactoryProvider { return params.ClientFactoryProvider }),
 		fx.Provide(func() authorization.JWTAudienceMapper { return params.AudienceGetter }),
 		fx.Provide(func() resolver.ServiceResolver { return params.PersistenceServiceResolver }),
@@ -442,6 +477,7 @@ func WorkerServiceProvider(
 			params.Cfg,
 		),
 		fx.Provide(func() persistenceClient.AbstractDataStoreFactory { return params.DataStoreFactory }),
+		fx.Provide(func() serialization.EncryptionProvider { return params.EncryptionProvider }),
 		fx.Provide(func() client.FactoryProvider { return params.ClientFactoryProvider }),
 		fx.Provide(func() authorization.JWTAudienceMapper { return params.AudienceGetter }),
 		fx.Provide(func() resolver.ServiceResolver { return params.PersistenceServiceResolver }),
@@ -545,6 +581,7 @@ func ApplyClusterMetadataConfigProvider(
 		persistenceServiceResolver,
 		nil,
 		customDataStoreFactory,
+		nil,
 		config.ClusterMetadata.CurrentClusterName,
 		nil,
 		logger,
@@ -41,6 +41,18 @@ type (
 		Start() error
 		Stop()
 	}
+
+	// BeforeStartHook is called by Server.Start after system namespaces are initialized and before any service
+	// is started. Returning an error aborts Start.
+	BeforeStartHook func() error
+
+	// AfterShardAcquireHook is called by the history service each time it acquires the ownership of a shard,
+	// including after range ID renewals. It is called with the shard lock held, so it must return quickly
+	// and must not call back into the server.
+	AfterShardAcquireHook func(shardID int32, rangeID int64)
+
+	// BeforeStopHook is called by Server.Stop before any service is stopped.
+	BeforeStopHook func()
 )
 
 // Services is the list of all valid temporal services
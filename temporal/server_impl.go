@@ -130,6 +130,12 @@ func (s *ServerImpl) Start() error {
 		return fmt.Errorf("unable to initialize system namespace: %w", err)
 	}
 
+	for _, hook := range s.so.beforeStartHooks {
+		if err = hook(); err != nil {
+			return fmt.Errorf("before start hook failed: %w", err)
+		}
+	}
+
 	for _, svcMeta := range s.servicesMetadata {
 		timeoutCtx, cancelFunc := context.WithTimeout(context.Background(), serviceStartTimeout)
 		svcMeta.App.Start(timeoutCtx)
@@ -148,6 +154,10 @@ func (s *ServerImpl) Start() error {
 
 // Stop stops the server.
 func (s *ServerImpl) Stop() {
+	for _, hook := range s.so.beforeStopHooks {
+		hook()
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(len(s.servicesMetadata))
 	close(s.stoppedCh)
@@ -274,6 +284,8 @@ func newBootstrapParams(
 
 	params.ArchiverProvider = provider.NewArchiverProvider(cfg.Archival.History.Provider, cfg.Archival.Visibility.Provider)
 	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicconfig.TransactionSizeLimit, common.DefaultTransactionSizeLimit)
+	params.PersistenceConfig.EnableHistoryEventBatchChecksum = dc.GetBoolProperty(dynamicconfig.EnableHistoryEventBatchChecksum, false)
+	params.PersistenceConfig.NamespaceMetricsLimit = dc.GetIntProperty(dynamicconfig.PersistenceNamespaceMetricsLimit, 100)
 
 	return params, nil
 }
@@ -301,6 +313,7 @@ func initSystemNamespaces(
 		persistenceServiceResolver,
 		nil,
 		customDataStoreFactory,
+		nil,
 		currentClusterName,
 		nil,
 		logger,
@@ -33,9 +33,11 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	persistenceclient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/resolver"
 	"go.temporal.io/server/common/rpc/encryption"
 	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/service/history/workflow"
 	"google.golang.org/grpc"
 )
 
@@ -155,6 +157,16 @@ func WithCustomDataStoreFactory(customFactory persistenceclient.AbstractDataStor
 	})
 }
 
+// WithPersistenceEncryptionProvider sets an EncryptionProvider which encrypts shard info, history task and queue
+// metadata blobs before they are persisted. Blobs persisted before it was set are still read. tctl admin commands
+// which access the database directly need the same provider, see cli.SetPersistenceEncryptionProvider.
+// NOTE: this option is experimental and may be changed or removed in future release.
+func WithPersistenceEncryptionProvider(provider serialization.EncryptionProvider) ServerOption {
+	return newApplyFuncContainer(func(s *serverOptions) {
+		s.encryptionProvider = provider
+	})
+}
+
 // WithClientFactoryProvider sets a custom ClientFactoryProvider
 // NOTE: this option is experimental and may be changed or removed in future release.
 func WithClientFactoryProvider(clientFactoryProvider client.FactoryProvider) ServerOption {
@@ -181,3 +193,36 @@ func WithChainedFrontendGrpcInterceptors(
 		s.customInterceptors = interceptors
 	})
 }
+
+// WithBeforeStartHook adds a hook which is called before the services are started.
+// Hooks are called in the order they were added, and the first error aborts the server start.
+func WithBeforeStartHook(hook BeforeStartHook) ServerOption {
+	return newApplyFuncContainer(func(s *serverOptions) {
+		s.beforeStartHooks = append(s.beforeStartHooks, hook)
+	})
+}
+
+// WithAfterShardAcquireHook adds a hook which is called each time the history service of this server acquires a shard.
+// It has no effect if the history service is not run by this server.
+func WithAfterShardAcquireHook(hook AfterShardAcquireHook) ServerOption {
+	return newApplyFuncContainer(func(s *serverOptions) {
+		s.afterShardAcquireHooks = append(s.afterShardAcquireHooks, hook)
+	})
+}
+
+// WithBeforeStopHook adds a hook which is called before the services are stopped.
+// Hooks are called in the order they were added.
+func WithBeforeStopHook(hook BeforeStopHook) ServerOption {
+	return newApplyFuncContainer(func(s *serverOptions) {
+		s.beforeStopHooks = append(s.beforeStopHooks, hook)
+	})
+}
+
+// WithEventsReapplyPolicy sets a custom policy deciding which events are reapplied onto the current run of a workflow
+// when it is reset or a branch of its history loses a conflict. It replaces the default policy reapplying the event
+// types configured with history.reapplyEventTypes dynamic config.
+func WithEventsReapplyPolicy(policy workflow.EventsReapplyPolicy) ServerOption {
+	return newApplyFuncContainer(func(s *serverOptions) {
+		s.eventsReapplyPolicy = policy
+	})
+}
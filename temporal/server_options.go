@@ -34,9 +34,11 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/resolver"
 	"go.temporal.io/server/common/rpc/encryption"
 	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/service/history/workflow"
 	"google.golang.org/grpc"
 )
 
@@ -63,9 +65,16 @@ type (
 		elasticsearchHttpClient    *http.Client
 		dynamicConfigClient        dynamicconfig.Client
 		customDataStoreFactory     persistenceClient.AbstractDataStoreFactory
+		encryptionProvider         serialization.EncryptionProvider
 		clientFactoryProvider      client.FactoryProvider
 		searchAttributesMapper     searchattribute.Mapper
 		customInterceptors         []grpc.UnaryServerInterceptor
+
+		beforeStartHooks       []BeforeStartHook
+		afterShardAcquireHooks []AfterShardAcquireHook
+		beforeStopHooks        []BeforeStopHook
+
+		eventsReapplyPolicy workflow.EventsReapplyPolicy
 	}
 )
 
@@ -126,6 +126,27 @@ func newCQLClient(cfg *CQLClientConfig, logger log.Logger) (*cqlClient, error) {
 	}, nil
 }
 
+// NewSchemaDB returns a schema.DB for the keyspace of the given datastore config
+func NewSchemaDB(cfg *config.Cassandra, logger log.Logger) (schema.DB, error) {
+	cassandraConfig := *cfg
+	if cassandraConfig.ConnectTimeout == 0 {
+		cassandraConfig.ConnectTimeout = defaultTimeout * time.Second
+	}
+
+	session, err := gocql.NewSession(cassandraConfig, resolver.NewNoopResolver(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cqlClient{
+		keyspace:   cfg.Keyspace,
+		datacenter: cfg.Datacenter,
+		timeout:    defaultTimeout * time.Second,
+		session:    session,
+		logger:     logger,
+	}, nil
+}
+
 func (cfg *CQLClientConfig) toCassandraConfig() *config.Cassandra {
 	cassandraConfig := config.Cassandra{
 		Hosts:      cfg.Hosts,
@@ -94,6 +94,23 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminDescribeWorkflow(c)
 			},
 		},
+		{
+			Name:  "size",
+			Usage: "Show size breakdown of workflow execution mutable state, history branches and visibility record",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminWorkflowSize(c)
+			},
+		},
 		{
 			Name:    "refresh_tasks",
 			Aliases: []string{"rt"},
@@ -153,6 +170,58 @@ func newAdminShardManagementCommands() []cli.Command {
 				AdminDescribeShard(c)
 			},
 		},
+		{
+			Name:  "check",
+			Usage: "Validate shard invariants and report orphaned tasks below the ack levels",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "The Id of the shard to check",
+				},
+				cli.IntFlag{
+					Name:  FlagRangeSizeBits,
+					Value: 20,
+					Usage: "Number of task ID bits allocated per shard range, must match history service config",
+				},
+				cli.BoolFlag{
+					Name:  FlagRepair,
+					Usage: "Remove orphaned tasks below the ack levels",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminCheckShard(c)
+			},
+		},
+		{
+			Name:  "rebalance_plan",
+			Usage: "List open executions of a namespace which would move to another shard under a new shard partition",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagNumberOfShards,
+					Usage: "NumberOfShards for the temporal cluster(see config for numHistoryShards)",
+				},
+				cli.StringFlag{
+					Name:  FlagShardPartition,
+					Usage: "Shards to partition the namespace to, e.g. 1,2,10-20",
+				},
+				cli.StringFlag{
+					Name:  FlagCurrentShardPartition,
+					Usage: "Shards the namespace is currently partitioned to, all shards if not set",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 1000,
+					Usage: "Number of executions listed per request",
+				},
+				cli.BoolFlag{
+					Name:  FlagWaitUntilDrained,
+					Usage: "Repeat the plan until no open execution would move, i.e. the new partition can be applied",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminShardRebalancePlan(c)
+			},
+		},
 		{
 			Name:    "describe_task",
 			Aliases: []string{"dt"},
@@ -353,6 +422,10 @@ func newAdminHistoryHostCommands() []cli.Command {
 					Name:  FlagNumberOfShards,
 					Usage: "NumberOfShards for the temporal cluster(see config for numHistoryShards)",
 				},
+				cli.StringFlag{
+					Name:  FlagShardPartition,
+					Usage: "Shards the namespace is partitioned to (see dynamic config system.namespaceShardPartitions), e.g. 1,2,10-20",
+				},
 			},
 			Action: func(c *cli.Context) {
 				AdminGetShardID(c)
@@ -392,6 +465,14 @@ func newAdminNamespaceCommands() []cli.Command {
 				newNamespaceCLI(c, true).UpdateNamespace(c)
 			},
 		},
+		{
+			Name:  "apply",
+			Usage: "Apply a configuration patch to all namespaces whose data matches a selector",
+			Flags: adminApplyNamespaceFlags,
+			Action: func(c *cli.Context) {
+				AdminApplyNamespaces(c)
+			},
+		},
 		{
 			Name:    "describe",
 			Aliases: []string{"desc"},
@@ -336,7 +336,7 @@ func AdminDeleteWorkflow(c *cli.Context) {
 		fmt.Println("Deleting history events for:")
 		prettyPrintJSONObject(branchInfo)
 		execStore := cassandra.NewExecutionStore(session, log.NewNoopLogger())
-		execMgr := persistence.NewExecutionManager(execStore, log.NewNoopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit))
+		execMgr := persistence.NewExecutionManager(execStore, newPersistenceSerializer(), log.NewNoopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit), dynamicconfig.GetBoolPropertyFn(false))
 		err = execMgr.DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
 			BranchToken: branchToken,
 			ShardID:     int32(shardIDInt),
@@ -543,7 +543,8 @@ func AdminGetShardID(c *cli.Context) {
 		ErrorAndExit("numberOfShards is required", nil)
 		return
 	}
-	shardID := common.WorkflowIDToHistoryShard(namespaceID, wid, numberOfShards)
+	partitions := map[string]interface{}{namespaceID: parseShardPartitionFlag(c.String(FlagShardPartition))}
+	shardID := common.WorkflowIDToHistoryShardInPartition(namespaceID, wid, numberOfShards, partitions)
 	fmt.Printf("ShardId for namespace, workflowId: %v, %v is %v \n", namespaceID, wid, shardID)
 }
 
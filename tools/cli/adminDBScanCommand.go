@@ -66,6 +66,8 @@ const (
 	// OpenExecutionInvalidCurrentExecution is the CorruptionType that indicates there is an orphan concrete execution
 	OpenExecutionInvalidCurrentExecution = "open_execution_invalid_current_execution"
 	CorruptActivityIdPresent             = "corrupt_activity_id_present"
+	// HistoryChecksumMismatch is the CorruptionType indicating that a history event batch does not match its checksum
+	HistoryChecksumMismatch = "history_checksum_mismatch"
 )
 
 const (
@@ -78,7 +80,8 @@ const (
 )
 
 const (
-	historyPageSize = 1
+	historyPageSize         = 1
+	historyChecksumPageSize = 100
 )
 
 type (
@@ -169,10 +172,12 @@ type (
 		TotalInvalidFirstEvent                         int64
 		TotalOpenExecutionInvalidCurrentExecution      int64
 		TotalActivityIdsCorrupted                      int64
+		TotalHistoryChecksumMismatch                   int64
 		PercentageHistoryMissing                       float64
 		PercentageInvalidStartEvent                    float64
 		PercentageOpenExecutionInvalidCurrentExecution float64
 		PercentageActivityIdsCorrupted                 float64
+		PercentageHistoryChecksumMismatch              float64
 	}
 
 	// Rates indicates the rates at which the scan is progressing
@@ -293,7 +298,7 @@ func scanShard(
 		closeFn()
 	}()
 	workflowStore := cassp.NewExecutionStore(session, log.NewNoopLogger())
-	execMan := persistence.NewExecutionManager(workflowStore, log.NewNoopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit))
+	execMan := persistence.NewExecutionManager(workflowStore, newPersistenceSerializer(), log.NewNoopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit), dynamicconfig.GetBoolPropertyFn(false))
 
 	var token []byte
 	isFirstIteration := true
@@ -418,6 +423,29 @@ func scanShard(
 				report.Scanned.ExecutionCheckFailureCount++
 				continue
 			}
+
+			historyChecksumVerificationResult := verifyHistoryChecksum(
+				s.ExecutionInfo,
+				s.ExecutionState,
+				s.NextEventId,
+				corruptedExecutionWriter,
+				checkFailureWriter,
+				shardID,
+				byteBranch,
+				execMan,
+				limiter,
+				&report.TotalDBRequests,
+			)
+			switch historyChecksumVerificationResult {
+			case VerificationResultNoCorruption:
+			case VerificationResultDetectedCorruption:
+				report.Scanned.CorruptionTypeBreakdown.TotalHistoryChecksumMismatch++
+				report.Scanned.CorruptedExecutionsCount++
+				continue
+			case VerificationResultCheckFailure:
+				report.Scanned.ExecutionCheckFailureCount++
+				continue
+			}
 		}
 	}
 	return report
@@ -626,6 +654,78 @@ func verifyActivityIds(
 	return VerificationResultNoCorruption
 }
 
+func verifyHistoryChecksum(
+	executionInfo *persistencespb.WorkflowExecutionInfo,
+	executionState *persistencespb.WorkflowExecutionState,
+	nextEventID int64,
+	corruptedExecutionWriter BufferedWriter,
+	checkFailureWriter BufferedWriter,
+	shardID int32,
+	byteBranch *historyBranchByteKey,
+	execManager persistence.ExecutionManager,
+	limiter quotas.RateLimiter,
+	totalDBRequests *int64,
+) VerificationResult {
+	currentVersionHistory, err := versionhistory.GetCurrentVersionHistory(executionInfo.VersionHistories)
+	if err != nil {
+		checkFailureWriter.Add(&ExecutionCheckFailure{
+			ShardID:     shardID,
+			NamespaceID: executionInfo.NamespaceId,
+			WorkflowID:  executionInfo.WorkflowId,
+			RunID:       executionState.GetRunId(),
+			Note:        "failed to get current version history",
+			Details:     err.Error(),
+		})
+		return VerificationResultCheckFailure
+	}
+
+	var token []byte
+	isFirstIteration := true
+	for isFirstIteration || len(token) != 0 {
+		isFirstIteration = false
+		preconditionForDBCall(totalDBRequests, limiter)
+		resp, err := execManager.ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
+			BranchToken:   currentVersionHistory.BranchToken,
+			MinEventID:    common.FirstEventID,
+			MaxEventID:    nextEventID,
+			PageSize:      historyChecksumPageSize,
+			NextPageToken: token,
+			ShardID:       shardID,
+		})
+		if checksumErr, ok := err.(*persistence.HistoryEventBatchChecksumError); ok {
+			corruptedExecutionWriter.Add(&CorruptedExecution{
+				ShardID:     shardID,
+				NamespaceID: executionInfo.NamespaceId,
+				WorkflowID:  executionInfo.WorkflowId,
+				RunID:       executionState.GetRunId(),
+				NextEventID: nextEventID,
+				TreeID:      byteBranch.GetTreeId(),
+				BranchID:    byteBranch.GetBranchId(),
+				CloseStatus: executionState.Status,
+				CorruptedExceptionMetadata: CorruptedExceptionMetadata{
+					CorruptionType: HistoryChecksumMismatch,
+					Note:           fmt.Sprintf("history node %v with transaction %v failed checksum verification", checksumErr.NodeID, checksumErr.TransactionID),
+					Details:        checksumErr.Error(),
+				},
+			})
+			return VerificationResultDetectedCorruption
+		}
+		if err != nil {
+			checkFailureWriter.Add(&ExecutionCheckFailure{
+				ShardID:     shardID,
+				NamespaceID: executionInfo.NamespaceId,
+				WorkflowID:  executionInfo.WorkflowId,
+				RunID:       executionState.GetRunId(),
+				Note:        "failed to read history branch for checksum verification",
+				Details:     err.Error(),
+			})
+			return VerificationResultCheckFailure
+		}
+		token = resp.NextPageToken
+	}
+	return VerificationResultNoCorruption
+}
+
 func verifyCurrentExecution(
 	executionInfo *persistencespb.WorkflowExecutionInfo,
 	executionState *persistencespb.WorkflowExecutionState,
@@ -867,6 +967,7 @@ func includeShardInProgressReport(report *ShardScanReport, progressReport *Progr
 		progressReport.CorruptionTypeBreakdown.TotalHistoryMissing += report.Scanned.CorruptionTypeBreakdown.TotalHistoryMissing
 		progressReport.CorruptionTypeBreakdown.TotalOpenExecutionInvalidCurrentExecution += report.Scanned.CorruptionTypeBreakdown.TotalOpenExecutionInvalidCurrentExecution
 		progressReport.CorruptionTypeBreakdown.TotalInvalidFirstEvent += report.Scanned.CorruptionTypeBreakdown.TotalInvalidFirstEvent
+		progressReport.CorruptionTypeBreakdown.TotalHistoryChecksumMismatch += report.Scanned.CorruptionTypeBreakdown.TotalHistoryChecksumMismatch
 		if progressReport.ShardExecutionCountsDistribution.MinExecutions == nil ||
 			*progressReport.ShardExecutionCountsDistribution.MinExecutions > report.Scanned.TotalExecutionsCount {
 			progressReport.ShardExecutionCountsDistribution.MinExecutions = &report.Scanned.TotalExecutionsCount
@@ -884,6 +985,7 @@ func includeShardInProgressReport(report *ShardScanReport, progressReport *Progr
 		progressReport.CorruptionTypeBreakdown.PercentageHistoryMissing = math.Round((float64(progressReport.CorruptionTypeBreakdown.TotalHistoryMissing) * 100.0) / float64(progressReport.TotalExecutionsCount))
 		progressReport.CorruptionTypeBreakdown.PercentageInvalidStartEvent = math.Round((float64(progressReport.CorruptionTypeBreakdown.TotalInvalidFirstEvent) * 100.0) / float64(progressReport.TotalExecutionsCount))
 		progressReport.CorruptionTypeBreakdown.PercentageOpenExecutionInvalidCurrentExecution = math.Round((float64(progressReport.CorruptionTypeBreakdown.TotalOpenExecutionInvalidCurrentExecution) * 100.0) / float64(progressReport.TotalExecutionsCount))
+		progressReport.CorruptionTypeBreakdown.PercentageHistoryChecksumMismatch = math.Round((float64(progressReport.CorruptionTypeBreakdown.TotalHistoryChecksumMismatch) * 100.0) / float64(progressReport.TotalExecutionsCount))
 	}
 
 	pastTime := time.Now().UTC().Sub(startTime)
@@ -0,0 +1,140 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+const (
+	namespaceApplyResultUpdated = "updated"
+	namespaceApplyResultMatched = "matched"
+	namespaceApplyResultFailed  = "failed"
+)
+
+// AdminApplyNamespaces applies the same configuration patch to every namespace whose data matches the selector
+// and prints the result for each of them
+func AdminApplyNamespaces(c *cli.Context) {
+	selector, err := parseNamespaceDataKVs(getRequiredOption(c, FlagNamespaceSelector))
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagNamespaceSelector), err)
+	}
+	patch := namespaceApplyPatch(c)
+	if patch.UpdateInfo == nil && patch.Config == nil {
+		ErrorAndExit("Nothing to apply, at least one namespace setting must be provided.", nil)
+	}
+	dryRun := c.Bool(FlagDryRun)
+
+	d := newNamespaceCLI(c, true)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Namespace", "Result", "Error"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, ns := range d.getAllNamespaces(c) {
+		if !namespaceMatchesSelector(ns, selector) {
+			continue
+		}
+		name := ns.GetNamespaceInfo().GetName()
+		if dryRun {
+			table.Append([]string{name, namespaceApplyResultMatched, ""})
+			continue
+		}
+
+		request := &workflowservice.UpdateNamespaceRequest{
+			Namespace:  name,
+			UpdateInfo: patch.UpdateInfo,
+			Config:     patch.Config,
+		}
+		// each update gets its own timeout, so that a long list of namespaces doesn't run out of time
+		ctx, cancel := newContext(c)
+		err := d.updateNamespace(ctx, request)
+		cancel()
+		if err != nil {
+			table.Append([]string{name, namespaceApplyResultFailed, err.Error()})
+			continue
+		}
+		table.Append([]string{name, namespaceApplyResultUpdated, ""})
+	}
+	table.Render()
+}
+
+// namespaceApplyPatch builds the update request shared by all matched namespaces, leaving out the parts
+// which are not set so that the existing values of each namespace are kept
+func namespaceApplyPatch(c *cli.Context) *workflowservice.UpdateNamespaceRequest {
+	patch := &workflowservice.UpdateNamespaceRequest{}
+	if c.IsSet(FlagNamespaceData) {
+		namespaceData, err := parseNamespaceDataKVs(c.String(FlagNamespaceData))
+		if err != nil {
+			ErrorAndExit("Namespace data format is invalid.", err)
+		}
+		patch.UpdateInfo = &namespacepb.UpdateNamespaceInfo{
+			Data: namespaceData,
+		}
+	}
+
+	config := &namespacepb.NamespaceConfig{
+		HistoryArchivalState:    archivalState(c, FlagHistoryArchivalState),
+		VisibilityArchivalState: archivalState(c, FlagVisibilityArchivalState),
+	}
+	if c.IsSet(FlagRetention) {
+		retention, err := timestamp.ParseDurationDefaultDays(c.String(FlagRetention))
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagRetention), err)
+		}
+		config.WorkflowExecutionRetentionTtl = &retention
+	}
+	if config.WorkflowExecutionRetentionTtl != nil ||
+		config.HistoryArchivalState != enumspb.ARCHIVAL_STATE_UNSPECIFIED ||
+		config.VisibilityArchivalState != enumspb.ARCHIVAL_STATE_UNSPECIFIED {
+		patch.Config = config
+	}
+	return patch
+}
+
+// namespaceMatchesSelector returns true if the namespace is not deleted and its data contains all selector pairs
+func namespaceMatchesSelector(ns *workflowservice.DescribeNamespaceResponse, selector map[string]string) bool {
+	info := ns.GetNamespaceInfo()
+	if info.GetState() == enumspb.NAMESPACE_STATE_DELETED {
+		return false
+	}
+	data := info.GetData()
+	for k, v := range selector {
+		if value, ok := data[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+func TestNamespaceMatchesSelector(t *testing.T) {
+	selector, err := parseNamespaceDataKVs("team:payments, tier:prod")
+	require.NoError(t, err)
+
+	newNamespace := func(state enumspb.NamespaceState, data map[string]string) *workflowservice.DescribeNamespaceResponse {
+		return &workflowservice.DescribeNamespaceResponse{
+			NamespaceInfo: &namespacepb.NamespaceInfo{
+				Name:  "namespace",
+				State: state,
+				Data:  data,
+			},
+		}
+	}
+
+	require.True(t, namespaceMatchesSelector(newNamespace(enumspb.NAMESPACE_STATE_REGISTERED, map[string]string{
+		"team":  "payments",
+		"tier":  "prod",
+		"owner": "someone",
+	}), selector))
+	require.False(t, namespaceMatchesSelector(newNamespace(enumspb.NAMESPACE_STATE_REGISTERED, map[string]string{
+		"team": "payments",
+		"tier": "staging",
+	}), selector))
+	require.False(t, namespaceMatchesSelector(newNamespace(enumspb.NAMESPACE_STATE_REGISTERED, map[string]string{
+		"team": "payments",
+	}), selector))
+	require.False(t, namespaceMatchesSelector(newNamespace(enumspb.NAMESPACE_STATE_DELETED, map[string]string{
+		"team": "payments",
+		"tier": "prod",
+	}), selector))
+}
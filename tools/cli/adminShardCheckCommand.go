@@ -0,0 +1,264 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+const (
+	shardCheckAckLevelInRange    = "ack_level_in_range"
+	shardCheckTimerAckLevel      = "timer_ack_level_not_in_future"
+	shardCheckRangeID            = "range_id_consistent"
+	shardCheckOrphanedTransfer   = "orphaned_transfer_task"
+	shardCheckOrphanedTimer      = "orphaned_timer_task"
+	shardCheckOrphanedVisibility = "orphaned_visibility_task"
+)
+
+type (
+	// shardCheckReport is the result of a shard integrity check
+	shardCheckReport struct {
+		ShardID  int32
+		RangeID  int64
+		Owner    string
+		Findings []*shardCheckFinding
+		Repaired int
+	}
+
+	// shardCheckFinding is a single violated invariant. Repairable findings are orphaned tasks
+	// below the ack level, which are never going to be processed and can be removed safely.
+	shardCheckFinding struct {
+		Check      string
+		Message    string
+		Repairable bool
+
+		category       enumsspb.TaskCategory
+		taskID         int64
+		visibilityTime *time.Time
+	}
+)
+
+// AdminCheckShard validates persisted shard invariants and optionally removes orphaned tasks
+func AdminCheckShard(c *cli.Context) {
+	sid := int32(getRequiredIntOption(c, FlagShardID))
+	rangeSizeBits := uint(c.Int(FlagRangeSizeBits))
+	repair := c.Bool(FlagRepair)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	shardInfo := getShardInfo(ctx, adminClient, sid)
+	report := &shardCheckReport{
+		ShardID:  sid,
+		RangeID:  shardInfo.GetRangeId(),
+		Owner:    shardInfo.GetOwner(),
+		Findings: checkShardInfo(shardInfo, rangeSizeBits, time.Now().UTC()),
+	}
+
+	orphaned, err := listOrphanedShardTasks(ctx, adminClient, shardInfo)
+	if err != nil {
+		ErrorAndExit("Failed to list shard tasks", err)
+	}
+	report.Findings = append(report.Findings, orphaned...)
+
+	// shard may have been acquired by another host while tasks were listed
+	current := getShardInfo(ctx, adminClient, sid)
+	if current.GetRangeId() != shardInfo.GetRangeId() {
+		report.Findings = append(report.Findings, &shardCheckFinding{
+			Check:   shardCheckRangeID,
+			Message: fmt.Sprintf("range ID changed from %v to %v during the check, report may be stale", shardInfo.GetRangeId(), current.GetRangeId()),
+		})
+		if repair {
+			prettyPrintJSONObject(report)
+			ErrorAndExit("Refusing to repair shard which changed owner during the check", nil)
+		}
+	}
+
+	if repair {
+		for _, finding := range report.Findings {
+			if !finding.Repairable {
+				continue
+			}
+			if _, err := adminClient.RemoveTask(ctx, &adminservice.RemoveTaskRequest{
+				ShardId:        sid,
+				Category:       finding.category,
+				TaskId:         finding.taskID,
+				VisibilityTime: finding.visibilityTime,
+			}); err != nil {
+				prettyPrintJSONObject(report)
+				ErrorAndExit("Failed to remove orphaned task", err)
+			}
+			report.Repaired++
+		}
+	}
+
+	prettyPrintJSONObject(report)
+}
+
+func getShardInfo(ctx context.Context, adminClient adminservice.AdminServiceClient, shardID int32) *persistencespb.ShardInfo {
+	resp, err := adminClient.GetShard(ctx, &adminservice.GetShardRequest{ShardId: shardID})
+	if err != nil {
+		ErrorAndExit("Failed to get shard", err)
+	}
+	return resp.GetShardInfo()
+}
+
+// checkShardInfo validates invariants which can be evaluated from the persisted shard info alone.
+// Task IDs of a shard are allocated from [rangeID << rangeSizeBits, (rangeID+1) << rangeSizeBits),
+// so no ack level may reach the end of the current range.
+func checkShardInfo(shardInfo *persistencespb.ShardInfo, rangeSizeBits uint, now time.Time) []*shardCheckFinding {
+	var findings []*shardCheckFinding
+
+	maxReadLevel := (shardInfo.GetRangeId() + 1) << rangeSizeBits
+	checkAckLevel := func(name string, ackLevel int64) {
+		if ackLevel >= maxReadLevel {
+			findings = append(findings, &shardCheckFinding{
+				Check:   shardCheckAckLevelInRange,
+				Message: fmt.Sprintf("%v %v is not below transfer max read level %v", name, ackLevel, maxReadLevel),
+			})
+		}
+	}
+	checkAckLevel("transfer ack level", shardInfo.GetTransferAckLevel())
+	checkAckLevel("visibility ack level", shardInfo.GetVisibilityAckLevel())
+	checkAckLevel("tiered storage ack level", shardInfo.GetTieredStorageAckLevel())
+	checkAckLevel("replication ack level", shardInfo.GetReplicationAckLevel())
+	for cluster, ackLevel := range shardInfo.GetClusterTransferAckLevel() {
+		checkAckLevel(fmt.Sprintf("transfer ack level of cluster %v", cluster), ackLevel)
+	}
+	for cluster, ackLevel := range shardInfo.GetClusterReplicationLevel() {
+		checkAckLevel(fmt.Sprintf("replication level of cluster %v", cluster), ackLevel)
+	}
+
+	checkTimerAckLevel := func(name string, ackLevel *time.Time) {
+		if ackLevel != nil && ackLevel.After(now) {
+			findings = append(findings, &shardCheckFinding{
+				Check:   shardCheckTimerAckLevel,
+				Message: fmt.Sprintf("%v %v is in the future", name, ackLevel.UTC()),
+			})
+		}
+	}
+	checkTimerAckLevel("timer ack level", shardInfo.GetTimerAckLevelTime())
+	for cluster, ackLevel := range shardInfo.GetClusterTimerAckLevel() {
+		checkTimerAckLevel(fmt.Sprintf("timer ack level of cluster %v", cluster), ackLevel)
+	}
+
+	return findings
+}
+
+// listOrphanedShardTasks returns tasks which are still persisted at or below the shard ack levels
+func listOrphanedShardTasks(
+	ctx context.Context,
+	adminClient adminservice.AdminServiceClient,
+	shardInfo *persistencespb.ShardInfo,
+) ([]*shardCheckFinding, error) {
+	var findings []*shardCheckFinding
+	sid := shardInfo.GetShardId()
+
+	transferReq := &adminservice.ListTransferTasksRequest{
+		ShardId:   sid,
+		MaxTaskId: shardInfo.GetTransferAckLevel(),
+	}
+	for {
+		resp, err := adminClient.ListTransferTasks(ctx, transferReq)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			findings = append(findings, newOrphanedTaskFinding(shardCheckOrphanedTransfer, enumsspb.TASK_CATEGORY_TRANSFER, task, nil))
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		transferReq.NextPageToken = resp.NextPageToken
+	}
+
+	visibilityReq := &adminservice.ListVisibilityTasksRequest{
+		ShardId:      sid,
+		MaxReadLevel: shardInfo.GetVisibilityAckLevel(),
+	}
+	for {
+		resp, err := adminClient.ListVisibilityTasks(ctx, visibilityReq)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			findings = append(findings, newOrphanedTaskFinding(shardCheckOrphanedVisibility, enumsspb.TASK_CATEGORY_VISIBILITY, task, nil))
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		visibilityReq.NextPageToken = resp.NextPageToken
+	}
+
+	if shardInfo.GetTimerAckLevelTime() != nil {
+		timerReq := &adminservice.ListTimerTasksRequest{
+			ShardId: sid,
+			MinTime: timestamp.TimePtr(time.Unix(0, 0).UTC()),
+			MaxTime: shardInfo.GetTimerAckLevelTime(),
+		}
+		for {
+			resp, err := adminClient.ListTimerTasks(ctx, timerReq)
+			if err != nil {
+				return nil, err
+			}
+			for _, task := range resp.Tasks {
+				findings = append(findings, newOrphanedTaskFinding(shardCheckOrphanedTimer, enumsspb.TASK_CATEGORY_TIMER, task, task.GetFireTime()))
+			}
+			if len(resp.NextPageToken) == 0 {
+				break
+			}
+			timerReq.NextPageToken = resp.NextPageToken
+		}
+	}
+
+	return findings, nil
+}
+
+func newOrphanedTaskFinding(
+	check string,
+	category enumsspb.TaskCategory,
+	task *adminservice.Task,
+	visibilityTime *time.Time,
+) *shardCheckFinding {
+	return &shardCheckFinding{
+		Check: check,
+		Message: fmt.Sprintf("task %v of type %v for workflow %v/%v is below the ack level",
+			task.GetTaskId(), task.GetTaskType(), task.GetWorkflowId(), task.GetRunId()),
+		Repairable:     true,
+		category:       category,
+		taskID:         task.GetTaskId(),
+		visibilityTime: visibilityTime,
+	}
+}
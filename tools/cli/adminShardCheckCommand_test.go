@@ -0,0 +1,70 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+func TestCheckShardInfo_Consistent(t *testing.T) {
+	now := time.Now().UTC()
+	shardInfo := &persistencespb.ShardInfo{
+		ShardId:            1,
+		RangeId:            3,
+		TransferAckLevel:   3<<20 + 100,
+		VisibilityAckLevel: 3<<20 + 50,
+		TimerAckLevelTime:  timestamp.TimePtr(now.Add(-time.Minute)),
+	}
+
+	require.Empty(t, checkShardInfo(shardInfo, 20, now))
+}
+
+func TestCheckShardInfo_Violations(t *testing.T) {
+	now := time.Now().UTC()
+	shardInfo := &persistencespb.ShardInfo{
+		ShardId:          1,
+		RangeId:          3,
+		TransferAckLevel: 4 << 20,
+		ClusterReplicationLevel: map[string]int64{
+			"standby": 5 << 20,
+		},
+		TimerAckLevelTime: timestamp.TimePtr(now.Add(time.Hour)),
+	}
+
+	findings := checkShardInfo(shardInfo, 20, now)
+	require.Len(t, findings, 3)
+	require.Equal(t, shardCheckAckLevelInRange, findings[0].Check)
+	require.Equal(t, shardCheckAckLevelInRange, findings[1].Check)
+	require.Equal(t, shardCheckTimerAckLevel, findings[2].Check)
+	for _, finding := range findings {
+		require.False(t, finding.Repairable)
+	}
+}
@@ -0,0 +1,149 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	filterpb "go.temporal.io/api/filter/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/convert"
+)
+
+const (
+	// shardRebalancePlanInterval is how often a waiting rebalance plan lists the open executions again
+	shardRebalancePlanInterval = 30 * time.Second
+)
+
+// AdminShardRebalancePlan lists the open executions of a namespace which would move to another shard if the
+// namespace was partitioned as given. Executions can't move between shards, so a partition change should only
+// be applied once the plan is empty, i.e. the listed executions have closed, followed by a restart of all
+// services; see dynamicconfig.NamespaceShardPartitions. With FlagWaitUntilDrained the plan is repeated until
+// it's empty.
+func AdminShardRebalancePlan(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	numberOfShards := int32(c.Int(FlagNumberOfShards))
+	if numberOfShards <= 0 {
+		ErrorAndExit("numberOfShards is required", nil)
+	}
+	target := parseShardPartitionFlag(getRequiredOption(c, FlagShardPartition))
+	if len(common.ParseShardPartition(target, numberOfShards)) == 0 {
+		ErrorAndExit(fmt.Sprintf("Partition %q has no shard in [1, %v]", c.String(FlagShardPartition), numberOfShards), nil)
+	}
+	current := parseShardPartitionFlag(c.String(FlagCurrentShardPartition))
+
+	frontendClient := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	namespaceResp, err := frontendClient.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		ErrorAndExit("Describe namespace failed", err)
+	}
+	namespaceID := namespaceResp.GetNamespaceInfo().GetId()
+	currentPartitions := map[string]interface{}{namespaceID: current}
+	targetPartitions := map[string]interface{}{namespaceID: target}
+
+	for {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetBorder(false)
+		table.SetColumnSeparator("|")
+		table.SetHeader([]string{"Workflow Id", "Run Id", "Shard Id", "Target Shard Id"})
+		table.SetHeaderLine(false)
+		table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+
+		earliestTime := time.Unix(0, 0).UTC()
+		latestTime := time.Now().UTC()
+		request := &workflowservice.ListOpenWorkflowExecutionsRequest{
+			Namespace:       namespace,
+			MaximumPageSize: int32(c.Int(FlagPageSize)),
+			StartTimeFilter: &filterpb.StartTimeFilter{
+				EarliestTime: &earliestTime,
+				LatestTime:   &latestTime,
+			},
+		}
+		var total, moving int
+		for {
+			ctx, cancel := newContext(c)
+			resp, err := frontendClient.ListOpenWorkflowExecutions(ctx, request)
+			cancel()
+			if err != nil {
+				ErrorAndExit("List open workflow executions failed", err)
+			}
+			for _, info := range resp.GetExecutions() {
+				workflowID := info.GetExecution().GetWorkflowId()
+				shardID := common.WorkflowIDToHistoryShardInPartition(namespaceID, workflowID, numberOfShards, currentPartitions)
+				targetShardID := common.WorkflowIDToHistoryShardInPartition(namespaceID, workflowID, numberOfShards, targetPartitions)
+				total++
+				if shardID == targetShardID {
+					continue
+				}
+				moving++
+				table.Append([]string{
+					workflowID,
+					info.GetExecution().GetRunId(),
+					convert.Int32ToString(shardID),
+					convert.Int32ToString(targetShardID),
+				})
+			}
+			if len(resp.NextPageToken) == 0 {
+				break
+			}
+			request.NextPageToken = resp.NextPageToken
+		}
+
+		table.Render()
+		fmt.Printf("%v of %v open executions of namespace %v would move to another shard\n", moving, total, namespace)
+		if moving == 0 {
+			fmt.Println("The partition can be applied, restart all services once it's changed")
+			return
+		}
+		if !c.Bool(FlagWaitUntilDrained) {
+			return
+		}
+		time.Sleep(shardRebalancePlanInterval)
+	}
+}
+
+// parseShardPartitionFlag splits a comma separated list of shard IDs and shard ranges, e.g. "1,2,10-20",
+// into the dynamic config representation of a partition
+func parseShardPartitionFlag(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	var partition []interface{}
+	for _, entry := range strings.Split(value, ",") {
+		partition = append(partition, entry)
+	}
+	return partition
+}
@@ -0,0 +1,221 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/persistence/versionhistory"
+)
+
+type (
+	// workflowSizeItem is one row of the execution size breakdown
+	workflowSizeItem struct {
+		Component string
+		Count     int64
+		Bytes     int64
+	}
+)
+
+// AdminWorkflowSize displays the size breakdown of a workflow execution: mutable state by component,
+// history by branch and the visibility record.
+func AdminWorkflowSize(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+
+	resp := describeMutableState(c)
+	mutableState := resp.GetDatabaseMutableState()
+	if mutableState == nil {
+		ErrorAndExit("Mutable state is not available in database.", nil)
+	}
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: wid,
+		RunId:      mutableState.GetExecutionState().GetRunId(),
+	}
+
+	fmt.Println(colorGreen("Mutable state:"))
+	printWorkflowSizeItems(mutableStateSizeBreakdown(mutableState))
+	fmt.Printf("\n")
+
+	fmt.Println(colorGreen("History:"))
+	printWorkflowSizeItems(historySizeBreakdown(c, namespace, execution, mutableState.GetExecutionInfo().GetVersionHistories()))
+	fmt.Printf("\n")
+
+	fmt.Println(colorGreen("Visibility:"))
+	printWorkflowSizeItems(visibilitySizeBreakdown(c, namespace, execution))
+}
+
+func mutableStateSizeBreakdown(ms *persistencespb.WorkflowMutableState) []workflowSizeItem {
+	activityInfos := workflowSizeItem{Component: "activity infos", Count: int64(len(ms.ActivityInfos))}
+	for _, info := range ms.ActivityInfos {
+		activityInfos.Bytes += int64(info.Size())
+	}
+	timerInfos := workflowSizeItem{Component: "timer infos", Count: int64(len(ms.TimerInfos))}
+	for _, info := range ms.TimerInfos {
+		timerInfos.Bytes += int64(info.Size())
+	}
+	childExecutionInfos := workflowSizeItem{Component: "child execution infos", Count: int64(len(ms.ChildExecutionInfos))}
+	for _, info := range ms.ChildExecutionInfos {
+		childExecutionInfos.Bytes += int64(info.Size())
+	}
+	requestCancelInfos := workflowSizeItem{Component: "request cancel infos", Count: int64(len(ms.RequestCancelInfos))}
+	for _, info := range ms.RequestCancelInfos {
+		requestCancelInfos.Bytes += int64(info.Size())
+	}
+	signalInfos := workflowSizeItem{Component: "signal infos", Count: int64(len(ms.SignalInfos))}
+	for _, info := range ms.SignalInfos {
+		signalInfos.Bytes += int64(info.Size())
+	}
+	signalRequestedIDs := workflowSizeItem{Component: "signal requested ids", Count: int64(len(ms.SignalRequestedIds))}
+	for _, id := range ms.SignalRequestedIds {
+		signalRequestedIDs.Bytes += int64(len(id))
+	}
+	bufferedEvents := workflowSizeItem{Component: "buffered events", Count: int64(len(ms.BufferedEvents))}
+	for _, event := range ms.BufferedEvents {
+		bufferedEvents.Bytes += int64(event.Size())
+	}
+
+	return []workflowSizeItem{
+		{Component: "execution info", Count: 1, Bytes: int64(ms.ExecutionInfo.Size())},
+		{Component: "execution state", Count: 1, Bytes: int64(ms.ExecutionState.Size())},
+		activityInfos,
+		timerInfos,
+		childExecutionInfos,
+		requestCancelInfos,
+		signalInfos,
+		signalRequestedIDs,
+		bufferedEvents,
+		// total is the encoded size of the whole record, including map keys and field tags
+		{Component: "total", Bytes: int64(ms.Size())},
+	}
+}
+
+func historySizeBreakdown(
+	c *cli.Context,
+	namespace string,
+	execution *commonpb.WorkflowExecution,
+	versionHistories *historyspb.VersionHistories,
+) []workflowSizeItem {
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	var items []workflowSizeItem
+	for index, history := range versionHistories.GetHistories() {
+		request := &adminservice.GetWorkflowExecutionRawHistoryV2Request{
+			Namespace:       namespace,
+			Execution:       execution,
+			MaximumPageSize: 100,
+		}
+		component := fmt.Sprintf("branch %v", index)
+		if int32(index) == versionHistories.GetCurrentVersionHistoryIndex() {
+			component += " (current)"
+		} else {
+			// end event is exclusive, so the last event of a non current branch is not counted
+			lastItem, err := versionhistory.GetLastVersionHistoryItem(history)
+			if err != nil {
+				ErrorAndExit("Unable to get last version history item.", err)
+			}
+			request.EndEventId = lastItem.GetEventId()
+			request.EndEventVersion = lastItem.GetVersion()
+		}
+
+		item, err := rawHistorySize(ctx, adminClient, request)
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Unable to read history of %v.", component), err)
+		}
+		item.Component = component
+		items = append(items, item)
+	}
+	return items
+}
+
+func rawHistorySize(
+	ctx context.Context,
+	adminClient adminservice.AdminServiceClient,
+	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
+) (workflowSizeItem, error) {
+	var item workflowSizeItem
+	for {
+		resp, err := adminClient.GetWorkflowExecutionRawHistoryV2(ctx, request)
+		if err != nil {
+			return item, err
+		}
+		for _, blob := range resp.GetHistoryBatches() {
+			item.Count++
+			item.Bytes += int64(len(blob.GetData()))
+		}
+		if len(resp.NextPageToken) == 0 {
+			return item, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+func visibilitySizeBreakdown(c *cli.Context, namespace string, execution *commonpb.WorkflowExecution) []workflowSizeItem {
+	frontendClient := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := frontendClient.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: execution,
+	})
+	if err != nil {
+		ErrorAndExit("Describe workflow execution failed", err)
+	}
+
+	info := resp.GetWorkflowExecutionInfo()
+	return []workflowSizeItem{
+		{Component: "memo", Count: int64(len(info.GetMemo().GetFields())), Bytes: int64(info.GetMemo().Size())},
+		{Component: "search attributes", Count: int64(len(info.GetSearchAttributes().GetIndexedFields())), Bytes: int64(info.GetSearchAttributes().Size())},
+		// the visibility document is approximated by the encoded execution info
+		{Component: "total", Bytes: int64(info.Size())},
+	}
+}
+
+func printWorkflowSizeItems(items []workflowSizeItem) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Component", "Count", "Bytes"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, item := range items {
+		table.Append([]string{item.Component, convert.Int64ToString(item.Count), convert.Int64ToString(item.Bytes)})
+	}
+	table.Render()
+}
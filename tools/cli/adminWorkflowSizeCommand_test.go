@@ -0,0 +1,75 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	historypb "go.temporal.io/api/history/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+)
+
+func TestMutableStateSizeBreakdown(t *testing.T) {
+	ms := &persistencespb.WorkflowMutableState{
+		ActivityInfos: map[int64]*persistencespb.ActivityInfo{
+			5: {ScheduleId: 5, ActivityId: "activity-1"},
+			6: {ScheduleId: 6, ActivityId: "activity-2"},
+		},
+		TimerInfos: map[string]*persistencespb.TimerInfo{
+			"timer": {TimerId: "timer", StartedId: 7},
+		},
+		SignalRequestedIds: []string{"signal-1", "signal-22"},
+		BufferedEvents: []*historypb.HistoryEvent{
+			{EventId: 10},
+		},
+		ExecutionInfo:  &persistencespb.WorkflowExecutionInfo{WorkflowId: "workflow-id"},
+		ExecutionState: &persistencespb.WorkflowExecutionState{RunId: "run-id"},
+	}
+
+	items := mutableStateSizeBreakdown(ms)
+	byComponent := make(map[string]workflowSizeItem, len(items))
+	for _, item := range items {
+		byComponent[item.Component] = item
+	}
+
+	require.Equal(t, int64(2), byComponent["activity infos"].Count)
+	require.Equal(t, int64(ms.ActivityInfos[5].Size()+ms.ActivityInfos[6].Size()), byComponent["activity infos"].Bytes)
+	require.Equal(t, int64(1), byComponent["timer infos"].Count)
+	require.Equal(t, int64(ms.TimerInfos["timer"].Size()), byComponent["timer infos"].Bytes)
+	require.Equal(t, workflowSizeItem{Component: "signal requested ids", Count: 2, Bytes: 17}, byComponent["signal requested ids"])
+	require.Equal(t, int64(ms.BufferedEvents[0].Size()), byComponent["buffered events"].Bytes)
+	require.Equal(t, workflowSizeItem{Component: "child execution infos"}, byComponent["child execution infos"])
+	require.Equal(t, int64(ms.Size()), byComponent["total"].Bytes)
+
+	var components int64
+	for _, item := range items {
+		if item.Component != "total" {
+			components += item.Bytes
+		}
+	}
+	require.LessOrEqual(t, components, byComponent["total"].Bytes)
+}
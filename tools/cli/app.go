@@ -28,6 +28,7 @@ import (
 	"github.com/urfave/cli"
 
 	"go.temporal.io/server/common/headers"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/tools/cli/dataconverter"
 	"go.temporal.io/server/tools/cli/headersprovider"
 	"go.temporal.io/server/tools/cli/plugin"
@@ -38,6 +39,12 @@ func SetFactory(factory ClientFactory) {
 	cFactory = factory
 }
 
+// SetPersistenceEncryptionProvider sets the EncryptionProvider of the server, so that admin commands which access
+// the database directly can read and write the records it encrypts
+func SetPersistenceEncryptionProvider(provider serialization.EncryptionProvider) {
+	persistenceEncryptionProvider = provider
+}
+
 // NewCliApp instantiates a new instance of the CLI application.
 func NewCliApp() *cli.App {
 	app := cli.NewApp()
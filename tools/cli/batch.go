@@ -114,6 +114,10 @@ func newBatchCommands() []cli.Command {
 					Name:  FlagYes,
 					Usage: "Optional flag to disable confirmation prompt",
 				},
+				cli.BoolFlag{
+					Name:  FlagConfirmLargeBatch,
+					Usage: "Confirm terminating or canceling a large portion of open workflows in the namespace",
+				},
 				cli.IntFlag{
 					Name:  FlagConcurrency,
 					Value: batcher.DefaultConcurrency,
@@ -31,6 +31,8 @@ import (
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/common/persistence/serialization"
 )
 
 const (
@@ -94,6 +96,9 @@ var resetReapplyTypesMap = map[string]interface{}{
 
 var (
 	cFactory ClientFactory
+	// persistenceEncryptionProvider decrypts the persistence records read by admin commands, see
+	// SetPersistenceEncryptionProvider
+	persistenceEncryptionProvider serialization.EncryptionProvider
 
 	colorRed     = color.New(color.FgRed).SprintFunc()
 	colorMagenta = color.New(color.FgMagenta).SprintFunc()
@@ -163,6 +163,7 @@ const (
 	FlagPromoteNamespaceWithAlias             = FlagPromoteNamespace + ", pn"
 	FlagNamespaceData                         = "namespace_data"
 	FlagNamespaceDataWithAlias                = FlagNamespaceData + ", dmd"
+	FlagNamespaceSelector                     = "selector"
 	FlagEventID                               = "event_id"
 	FlagEventIDWithAlias                      = FlagEventID + ", eid"
 	FlagActivityID                            = "activity_id"
@@ -184,6 +185,7 @@ const (
 	FlagRemoveBadBinary                       = "remove_bad_binary"
 	FlagResetType                             = "reset_type"
 	FlagResetReapplyType                      = "reset_reapply_type"
+	FlagResetReapplyExcludeEventIDs           = "reset_reapply_exclude_event_ids"
 	FlagResetPointsOnly                       = "reset_points_only"
 	FlagResetBadBinaryChecksum                = "reset_bad_binary_checksum"
 	FlagListQuery                             = "query"
@@ -204,6 +206,13 @@ const (
 	FlagJobID                                 = "job_id"
 	FlagJobIDWithAlias                        = FlagJobID + ", jid"
 	FlagYes                                   = "yes"
+	FlagConfirmLargeBatch                     = "confirm_large_batch"
+	FlagRepair                                = "repair"
+	FlagRangeSizeBits                         = "range_size_bits"
+	FlagShardPartition                        = "partition"
+	FlagCurrentShardPartition                 = "current_partition"
+	FlagWaitUntilDrained                      = "wait"
+	FlagTop                                   = "top"
 	FlagServiceConfigDir                      = "service_config_dir"
 	FlagServiceConfigDirWithAlias             = FlagServiceConfigDir + ", scd"
 	FlagServiceEnv                            = "service_env"
@@ -591,6 +600,19 @@ func getFlagsForDescribeID() []cli.Flag {
 	}
 }
 
+func getFlagsForTimeline() []cli.Flag {
+	return append(flagsForExecution,
+		cli.IntFlag{
+			Name:  FlagTop,
+			Usage: "Only show the N longest operations",
+		},
+		cli.BoolFlag{
+			Name:  FlagPrintDateTimeWithAlias,
+			Usage: "Print timestamp",
+		},
+	)
+}
+
 func getFlagsForObserve() []cli.Flag {
 	return append(flagsForExecution, getFlagsForObserveID()...)
 }
@@ -197,6 +197,36 @@ var (
 		adminNamespaceCommonFlags...,
 	)
 
+	adminApplyNamespaceFlags = append(
+		[]cli.Flag{
+			cli.StringFlag{
+				Name:  FlagNamespaceSelector,
+				Usage: "Required namespace data selector, in format of k1:v1,k2:v2; a namespace matches if its data contains all pairs",
+			},
+			cli.StringFlag{
+				Name:  FlagRetentionWithAlias,
+				Usage: "Workflow execution retention",
+			},
+			cli.StringFlag{
+				Name:  FlagNamespaceDataWithAlias,
+				Usage: "Namespace data of key value pairs to merge, in format of k1:v1,k2:v2,k3:v3",
+			},
+			cli.StringFlag{
+				Name:  FlagHistoryArchivalStateWithAlias,
+				Usage: "Flag to set history archival state, valid values are \"disabled\" and \"enabled\"",
+			},
+			cli.StringFlag{
+				Name:  FlagVisibilityArchivalStateWithAlias,
+				Usage: "Flag to set visibility archival state, valid values are \"disabled\" and \"enabled\"",
+			},
+			cli.BoolFlag{
+				Name:  FlagDryRun,
+				Usage: "List matching namespaces without updating them",
+			},
+		},
+		adminNamespaceCommonFlags...,
+	)
+
 	adminDescribeNamespaceFlags = append(
 		updateNamespaceFlags,
 		adminNamespaceCommonFlags...,
@@ -284,6 +314,7 @@ func initializePersistenceFactory(
 		resolver.NewNoopResolver(),
 		dynamicconfig.GetIntPropertyFn(dependencyMaxQPS),
 		nil, // TODO propagate abstract datastore factory from the CLI.
+		persistenceEncryptionProvider,
 		"",
 		metricsClient,
 		logger,
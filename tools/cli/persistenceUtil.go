@@ -35,6 +35,7 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	persistenceClient "go.temporal.io/server/common/persistence/client"
+	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/sql/sqlplugin/mysql"
 	"go.temporal.io/server/common/persistence/sql/sqlplugin/postgresql"
 	"go.temporal.io/server/common/resolver"
@@ -66,6 +67,7 @@ func CreatePersistenceFactory(c *cli.Context) persistenceClient.Factory {
 		resolver.NewNoopResolver(),
 		GetQPS,
 		nil,
+		persistenceEncryptionProvider,
 		c.String(FlagTargetCluster),
 		nil, // MetricsClient
 		log.NewNoopLogger(),
@@ -74,6 +76,15 @@ func CreatePersistenceFactory(c *cli.Context) persistenceClient.Factory {
 	return factory
 }
 
+// newPersistenceSerializer returns the serializer of persistence managers created without CreatePersistenceFactory
+func newPersistenceSerializer() serialization.Serializer {
+	serializer := serialization.NewSerializer()
+	if persistenceEncryptionProvider != nil {
+		serializer = serialization.NewEncryptingSerializer(serializer, persistenceEncryptionProvider)
+	}
+	return serializer
+}
+
 // CreateDefaultDBConfig return default DB configuration based on provided options
 func CreateDefaultDBConfig(c *cli.Context) (config.DataStore, error) {
 	engine := getRequiredOption(c, FlagDBEngine)
@@ -187,6 +187,14 @@ func newWorkflowCommands() []cli.Command {
 				QueryWorkflowUsingStackTrace(c)
 			},
 		},
+		{
+			Name:  "timeline",
+			Usage: "show workflow history grouped into activities, timers and child workflows with their durations",
+			Flags: getFlagsForTimeline(),
+			Action: func(c *cli.Context) {
+				ShowTimeline(c)
+			},
+		},
 		{
 			Name:    "describe",
 			Aliases: []string{"desc"},
@@ -255,6 +263,10 @@ func newWorkflowCommands() []cli.Command {
 					Usage: "whether to reapply events after the reset point. Support one of these: " +
 						strings.Join(mapKeysToArray(resetReapplyTypesMap), ",") + "Default to: All",
 				},
+				cli.StringFlag{
+					Name:  FlagResetReapplyExcludeEventIDs,
+					Usage: "comma separated eventIds of signals after the reset point not to reapply, e.g. a signal which caused the failure",
+				},
 				cli.StringFlag{
 					Name:  FlagResetBadBinaryChecksum,
 					Usage: "Binary checksum for resetType of BadBinary",
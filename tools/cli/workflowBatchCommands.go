@@ -216,8 +216,10 @@ func StartBatchJob(c *cli.Context) {
 			SignalName: sigName,
 			Input:      sigInput,
 		},
-		RPS:         rps,
-		Concurrency: concurrency,
+		RPS:               rps,
+		Concurrency:       concurrency,
+		ConfirmLargeBatch: c.Bool(FlagConfirmLargeBatch),
+		ExpectedCount:     resp.GetCount(),
 	}
 	wf, err := client.ExecuteWorkflow(tcCtx, options, batcher.BatchWFTypeName, params)
 	if err != nil {
@@ -34,6 +34,7 @@ import (
 	"math/rand"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -59,6 +60,7 @@ import (
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/codec"
 	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/headers"
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/payloads"
 	"go.temporal.io/server/common/primitives/timestamp"
@@ -1434,9 +1436,20 @@ func ResetWorkflow(c *cli.Context) {
 	if _, ok := resetReapplyTypesMap[resetReapplyType]; !ok {
 		ErrorAndExit(fmt.Sprintf("must specify valid reset reapply type: %v", strings.Join(mapKeysToArray(resetReapplyTypesMap), ", ")), nil)
 	}
+	var excludeEventIDs []int64
+	if excludes := c.String(FlagResetReapplyExcludeEventIDs); excludes != "" {
+		for _, exclude := range strings.Split(excludes, ",") {
+			excludeEventID, err := strconv.ParseInt(strings.TrimSpace(exclude), 10, 64)
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("invalid eventId %q in %v", exclude, FlagResetReapplyExcludeEventIDs), err)
+			}
+			excludeEventIDs = append(excludeEventIDs, excludeEventID)
+		}
+	}
 
 	ctx, cancel := newContext(c)
 	defer cancel()
+	ctx = headers.SetResetReapplyExcludeEventIDs(ctx, excludeEventIDs)
 
 	frontendClient := cFactory.FrontendClient(c)
 
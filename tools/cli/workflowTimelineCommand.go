@@ -0,0 +1,85 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+
+	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/timeline"
+)
+
+// ShowTimeline shows the history of a workflow execution grouped into logical operations
+// (workflow tasks, activities with their retries, timers and child workflows) with their durations.
+func ShowTimeline(c *cli.Context) {
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	sdkClient := getSDKClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	history, err := GetHistory(ctx, sdkClient, wid, rid)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", wid, rid), err)
+	}
+
+	operations := timeline.Build(history.GetEvents(), time.Now().UTC())
+	if c.IsSet(FlagTop) {
+		operations = timeline.Longest(operations, c.Int(FlagTop))
+	}
+	printTimeline(operations, c.Bool(FlagPrintDateTime))
+}
+
+func printTimeline(operations []*timeline.Operation, printDateTime bool) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Type", "ID", "Name", "Start", "Duration", "Attempts", "Status", "Failure"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue,
+		tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, op := range operations {
+		var attempts string
+		if op.Attempts > 0 {
+			attempts = convert.Int32ToString(op.Attempts)
+		}
+		table.Append([]string{
+			string(op.Type),
+			op.ID,
+			op.Name,
+			formatTime(op.StartTime, !printDateTime),
+			op.Duration.String(),
+			attempts,
+			string(op.Status),
+			op.Failure,
+		})
+	}
+	table.Render()
+}
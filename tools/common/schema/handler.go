@@ -48,6 +48,22 @@ func Update(cli *cli.Context, db DB, logger log.Logger) error {
 	return newUpdateSchemaTask(db, cfg, logger).Run()
 }
 
+// UpdateWithConfig updates the schema for the specified database using the given config
+func UpdateWithConfig(db DB, config *UpdateConfig, logger log.Logger) error {
+	if err := validateUpdateConfig(config); err != nil {
+		return err
+	}
+	return newUpdateSchemaTask(db, config, logger).Run()
+}
+
+// SetupWithConfig sets up schema tables using the given config
+func SetupWithConfig(db DB, config *SetupConfig, logger log.Logger) error {
+	if err := validateSetupConfig(config); err != nil {
+		return err
+	}
+	return newSetupSchemaTask(db, config, logger).Run()
+}
+
 func newUpdateConfig(cli *cli.Context) (*UpdateConfig, error) {
 	config := new(UpdateConfig)
 	config.SchemaDir = cli.String(CLIOptSchemaDir)
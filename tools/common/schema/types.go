@@ -26,6 +26,7 @@ package schema
 
 import (
 	"fmt"
+	"io/fs"
 	"regexp"
 )
 
@@ -41,7 +42,11 @@ type (
 		DBName        string
 		TargetVersion string
 		SchemaDir     string
-		IsDryRun      bool
+		// SchemaFS is the file system SchemaDir is read from, the local disk if nil
+		SchemaFS fs.FS
+		IsDryRun bool
+		// IsPlanOnly logs the pending updates without applying them
+		IsPlanOnly bool
 	}
 	// SetupConfig holds the config
 	// params need by the SetupTask
@@ -32,7 +32,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"sort"
 	"strings"
 
@@ -47,6 +49,8 @@ type (
 		db     DB
 		config *UpdateConfig
 		logger log.Logger
+		fsys   fs.FS
+		dir    string
 	}
 
 	// manifest is a value type that represents
@@ -84,10 +88,16 @@ var (
 
 // NewUpdateSchemaTask returns a new instance of UpdateTask
 func newUpdateSchemaTask(db DB, config *UpdateConfig, logger log.Logger) *UpdateTask {
+	fsys, dir := config.SchemaFS, config.SchemaDir
+	if fsys == nil {
+		fsys, dir = os.DirFS(config.SchemaDir), "."
+	}
 	return &UpdateTask{
 		db:     db,
 		config: config,
 		logger: logger,
+		fsys:   fsys,
+		dir:    dir,
 	}
 }
 
@@ -105,7 +115,11 @@ func (task *UpdateTask) Run() error {
 
 	currVer, err := task.db.ReadSchemaVersion()
 	if err != nil {
-		return fmt.Errorf("error reading current schema version:%v", err.Error())
+		if !config.IsPlanOnly {
+			return fmt.Errorf("error reading current schema version:%v", err.Error())
+		}
+		// schema version tables are not set up yet, every version is pending
+		currVer = "0.0"
 	}
 
 	updates, err := task.buildChangeSet(currVer)
@@ -113,6 +127,11 @@ func (task *UpdateTask) Run() error {
 		return err
 	}
 
+	if config.IsPlanOnly {
+		task.logPlan(currVer, updates)
+		return nil
+	}
+
 	err = task.executeUpdates(currVer, updates)
 	if err != nil {
 		return err
@@ -147,6 +166,20 @@ func (task *UpdateTask) executeUpdates(currVer string, updates []changeSet) erro
 	return nil
 }
 
+func (task *UpdateTask) logPlan(currVer string, updates []changeSet) {
+	if len(updates) == 0 {
+		task.logger.Info(fmt.Sprintf("Schema is up to date at version %v", currVer))
+		return
+	}
+	for _, cs := range updates {
+		task.logger.Info(fmt.Sprintf("Pending schema update from %v to %v: %v", currVer, cs.version, cs.manifest.Description))
+		for _, stmt := range cs.cqlStmts {
+			task.logger.Info(rmspaceRegex.ReplaceAllString(stmt, " "))
+		}
+		currVer = cs.version
+	}
+}
+
 func (task *UpdateTask) execStmts(ver string, stmts []string) error {
 	task.logger.Debug(fmt.Sprintf("---- Executing updates for version %v ----", ver))
 	for _, stmt := range stmts {
@@ -179,7 +212,7 @@ func (task *UpdateTask) buildChangeSet(currVer string) ([]changeSet, error) {
 
 	config := task.config
 
-	verDirs, err := readSchemaDir(config.SchemaDir, currVer, config.TargetVersion)
+	verDirs, err := readSchemaDir(task.fsys, task.dir, currVer, config.TargetVersion)
 	if err != nil {
 		return nil, fmt.Errorf("error listing schema dir:%v", err.Error())
 	}
@@ -188,9 +221,9 @@ func (task *UpdateTask) buildChangeSet(currVer string) ([]changeSet, error) {
 
 	for _, vd := range verDirs {
 
-		dirPath := config.SchemaDir + "/" + vd
+		dirPath := path.Join(task.dir, vd)
 
-		m, e := readManifest(dirPath)
+		m, e := readManifest(task.fsys, dirPath)
 		if e != nil {
 			return nil, fmt.Errorf("error processing manifest for version %v:%v", vd, e.Error())
 		}
@@ -225,10 +258,10 @@ func (task *UpdateTask) parseSQLStmts(dir string, manifest *manifest) ([]string,
 	result := make([]string, 0, 4)
 
 	for _, file := range manifest.SchemaUpdateCqlFiles {
-		path := dir + "/" + file
-		stmts, err := ParseFile(path)
+		filePath := path.Join(dir, file)
+		stmts, err := parseFS(task.fsys, filePath)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing file %v, err=%v", path, err)
+			return nil, fmt.Errorf("error parsing file %v, err=%v", filePath, err)
 		}
 		result = append(result, stmts...)
 	}
@@ -256,10 +289,10 @@ func validateCQLStmts(stmts []string) error {
 	return nil
 }
 
-func readManifest(dirPath string) (*manifest, error) {
+func readManifest(fsys fs.FS, dirPath string) (*manifest, error) {
 
-	filePath := dirPath + "/" + manifestFileName
-	jsonBlob, err := os.ReadFile(filePath)
+	filePath := path.Join(dirPath, manifestFileName)
+	jsonBlob, err := fs.ReadFile(fsys, filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -306,9 +339,9 @@ func readManifest(dirPath string) (*manifest, error) {
 //  - startVer < endVer
 //  - endVer is empty and no subdirs have version >= startVer
 //  - endVer is non-empty and subdir with version == endVer is not found
-func readSchemaDir(dir string, startVer string, endVer string) ([]string, error) {
+func readSchemaDir(fsys fs.FS, dir string, startVer string, endVer string) ([]string, error) {
 
-	subdirs, err := os.ReadDir(dir)
+	subdirs, err := fs.ReadDir(fsys, dir)
 	if err != nil {
 		return nil, err
 	}
@@ -27,6 +27,7 @@ package schema
 import (
 	"os"
 	"testing"
+	"testing/fstest"
 
 	"go.temporal.io/server/tests/testhelper"
 
@@ -56,36 +57,69 @@ func (s *UpdateTaskTestSuite) TestReadSchemaDir() {
 		s.NoError(os.Mkdir(tmpDir+"/"+d, os.FileMode(0444)))
 	}
 
-	_, err := readSchemaDir(tmpDir, "11.0", "11.2")
+	_, err := readSchemaDir(os.DirFS(tmpDir), ".", "11.0", "11.2")
 	s.Error(err)
-	_, err = readSchemaDir(tmpDir, "0.5", "10.3")
+	_, err = readSchemaDir(os.DirFS(tmpDir), ".", "0.5", "10.3")
 	s.Error(err)
-	_, err = readSchemaDir(tmpDir, "1.5", "0.5")
+	_, err = readSchemaDir(os.DirFS(tmpDir), ".", "1.5", "0.5")
 	s.Error(err)
-	_, err = readSchemaDir(tmpDir, "10.3", "")
+	_, err = readSchemaDir(os.DirFS(tmpDir), ".", "10.3", "")
 	s.Error(err)
-	_, err = readSchemaDir(emptyDir, "11.0", "")
+	_, err = readSchemaDir(os.DirFS(emptyDir), ".", "11.0", "")
 	s.Error(err)
-	_, err = readSchemaDir(emptyDir, "10.1", "")
+	_, err = readSchemaDir(os.DirFS(emptyDir), ".", "10.1", "")
 	s.Error(err)
 
-	ans, err := readSchemaDir(tmpDir, "1.5", "1.5")
+	ans, err := readSchemaDir(os.DirFS(tmpDir), ".", "1.5", "1.5")
 	s.NoError(err)
 	s.Equal(0, len(ans))
 
-	ans, err = readSchemaDir(tmpDir, "0.4", "10.2")
+	ans, err = readSchemaDir(os.DirFS(tmpDir), ".", "0.4", "10.2")
 	s.NoError(err)
 	s.Equal([]string{"v0.5", "v1.5", "v2.5", "v3.5", "v10.2"}, ans)
 
-	ans, err = readSchemaDir(tmpDir, "0.5", "3.5")
+	ans, err = readSchemaDir(os.DirFS(tmpDir), ".", "0.5", "3.5")
 	s.NoError(err)
 	s.Equal([]string{"v1.5", "v2.5", "v3.5"}, ans)
 
-	ans, err = readSchemaDir(tmpDir, "10.2", "")
+	ans, err = readSchemaDir(os.DirFS(tmpDir), ".", "10.2", "")
 	s.NoError(err)
 	s.Equal(0, len(ans))
 }
 
+func (s *UpdateTaskTestSuite) TestBuildChangeSetFromFS() {
+	fsys := fstest.MapFS{
+		"versioned/v1.0/manifest.json": {Data: []byte(`{
+			"CurrVersion": "1.0",
+			"MinCompatibleVersion": "1.0",
+			"Description": "base version of schema",
+			"SchemaUpdateCqlFiles": ["base.cql"]
+		}`)},
+		"versioned/v1.0/base.cql": {Data: []byte("CREATE TABLE t1 (id int);\n-- comment\nCREATE TABLE t2 (id int);\n")},
+		"versioned/v1.1/manifest.json": {Data: []byte(`{
+			"CurrVersion": "1.1",
+			"MinCompatibleVersion": "1.0",
+			"Description": "add column",
+			"SchemaUpdateCqlFiles": ["update.cql"]
+		}`)},
+		"versioned/v1.1/update.cql": {Data: []byte("ALTER TABLE t1 ADD name text;\n")},
+	}
+
+	task := newUpdateSchemaTask(nil, &UpdateConfig{SchemaFS: fsys, SchemaDir: "versioned"}, nil)
+	changes, err := task.buildChangeSet("0.0")
+	s.NoError(err)
+	s.Len(changes, 2)
+	s.Equal("1.0", changes[0].version)
+	s.Equal([]string{"CREATE TABLE t1 (id int);", "CREATE TABLE t2 (id int);"}, changes[0].cqlStmts)
+	s.Equal("1.1", changes[1].version)
+	s.Equal([]string{"ALTER TABLE t1 ADD name text;"}, changes[1].cqlStmts)
+
+	changes, err = task.buildChangeSet("1.0")
+	s.NoError(err)
+	s.Len(changes, 1)
+	s.Equal("1.1", changes[0].version)
+}
+
 func (s *UpdateTaskTestSuite) TestReadManifest() {
 	tmpDir := testhelper.MkdirTemp(s.T(), "", "update_schema_test")
 
@@ -146,7 +180,7 @@ func (s *UpdateTaskTestSuite) runReadManifestTest(dir, input, currVer, minVer, d
 	err := os.WriteFile(file, []byte(input), os.FileMode(0644))
 	s.Nil(err)
 
-	m, err := readManifest(dir)
+	m, err := readManifest(os.DirFS(dir), ".")
 	if isErr {
 		s.Error(err)
 		return
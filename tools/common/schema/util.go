@@ -27,6 +27,7 @@ package schema
 import (
 	"bufio"
 	"io"
+	"io/fs"
 	"os"
 	"strings"
 )
@@ -42,9 +43,26 @@ func ParseFile(filePath string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = f.Close() }()
 
-	reader := bufio.NewReader(f)
+	return parse(f)
+}
+
+// parseFS is ParseFile for a file in fsys.
+func parseFS(fsys fs.FS, filePath string) ([]string, error) {
+	f, err := fsys.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return parse(f)
+}
+
+func parse(r io.Reader) ([]string, error) {
+	reader := bufio.NewReader(r)
 
+	var err error
 	var line string
 	var currStmt string
 	var stmts = make([]string, 0, 4)
@@ -0,0 +1,283 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	mysqlplugin "go.temporal.io/server/common/persistence/sql/sqlplugin/mysql"
+	postgresqlplugin "go.temporal.io/server/common/persistence/sql/sqlplugin/postgresql"
+	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
+	"go.temporal.io/server/schema"
+	cassandraschema "go.temporal.io/server/schema/cassandra"
+	mysqlschema "go.temporal.io/server/schema/mysql"
+	postgresqlschema "go.temporal.io/server/schema/postgresql"
+	cassandratool "go.temporal.io/server/tools/cassandra"
+	commonschema "go.temporal.io/server/tools/common/schema"
+	sqltool "go.temporal.io/server/tools/sql"
+)
+
+const (
+	// esVisibilityTemplateName is the index template name used by the Elasticsearch setup scripts
+	esVisibilityTemplateName = "temporal_visibility_v1_template"
+	// esNamespaceTemplateNameSuffix is appended to a dedicated namespace index name to get its index template name
+	esNamespaceTemplateNameSuffix = "_template"
+	// esNamespaceTemplateOrder makes templates of dedicated namespace indices take precedence over the visibility
+	// template, whose patterns may match them too
+	esNamespaceTemplateOrder = 1
+	// initialVersion is recorded for databases without schema version tables before applying the versioned schema
+	initialVersion = "0.0"
+)
+
+type (
+	// schemaTarget is the embedded versioned schema directory of a store and the version the server expects
+	schemaTarget struct {
+		dir     string
+		version string
+	}
+)
+
+// Migrate applies the schema versions embedded in the server binary to the stores of the persistence config,
+// up to the versions this binary was built against. Every applied version is recorded in the schema_version
+// and schema_update_history tables, the latter keeps the version each update started from for rollbacks.
+// Keyspaces and databases must already exist. With dryRun the pending updates are logged but not applied.
+func Migrate(cfg *config.Persistence, dryRun bool, logger log.Logger) error {
+	if err := migrateStore(cfg, cfg.DefaultStore, false, dryRun, logger); err != nil {
+		return err
+	}
+	if cfg.VisibilityStore != "" {
+		if err := migrateStore(cfg, cfg.VisibilityStore, true, dryRun, logger); err != nil {
+			return err
+		}
+	}
+	if cfg.AdvancedVisibilityStore != "" {
+		ds, ok := cfg.DataStores[cfg.AdvancedVisibilityStore]
+		if !ok {
+			return fmt.Errorf("persistence config: missing datastore %q", cfg.AdvancedVisibilityStore)
+		}
+		if err := migrateElasticsearch(ds.Elasticsearch, dryRun, logger); err != nil {
+			return fmt.Errorf("unable to migrate datastore %q: %w", cfg.AdvancedVisibilityStore, err)
+		}
+	}
+	return nil
+}
+
+func migrateStore(
+	cfg *config.Persistence,
+	storeName string,
+	visibility bool,
+	dryRun bool,
+	logger log.Logger,
+) error {
+	ds, ok := cfg.DataStores[storeName]
+	if !ok {
+		return fmt.Errorf("persistence config: missing datastore %q", storeName)
+	}
+	logger = log.With(logger, tag.NewStringTag("datastore", storeName))
+
+	var (
+		db     commonschema.DB
+		target schemaTarget
+		err    error
+	)
+	switch {
+	case ds.Cassandra != nil:
+		target = cassandraTarget(visibility)
+		db, err = cassandratool.NewSchemaDB(ds.Cassandra, logger)
+	case ds.SQL != nil:
+		target, err = sqlTarget(ds.SQL.PluginName, visibility)
+		if err != nil {
+			return err
+		}
+		db, err = sqltool.NewConnection(ds.SQL)
+	default:
+		logger.Info("Skipping schema migration of datastore without an embedded schema.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to connect to datastore %q: %w", storeName, err)
+	}
+	defer db.Close()
+
+	if err := updateSchema(db, target, dryRun, logger); err != nil {
+		return fmt.Errorf("unable to migrate datastore %q: %w", storeName, err)
+	}
+	return nil
+}
+
+func cassandraTarget(visibility bool) schemaTarget {
+	if visibility {
+		return schemaTarget{dir: "cassandra/visibility/versioned", version: cassandraschema.VisibilityVersion}
+	}
+	return schemaTarget{dir: "cassandra/temporal/versioned", version: cassandraschema.Version}
+}
+
+func sqlTarget(pluginName string, visibility bool) (schemaTarget, error) {
+	switch pluginName {
+	case mysqlplugin.PluginName:
+		if visibility {
+			return schemaTarget{dir: "mysql/v57/visibility/versioned", version: mysqlschema.VisibilityVersion}, nil
+		}
+		return schemaTarget{dir: "mysql/v57/temporal/versioned", version: mysqlschema.Version}, nil
+	case postgresqlplugin.PluginName:
+		if visibility {
+			return schemaTarget{dir: "postgresql/v96/visibility/versioned", version: postgresqlschema.VisibilityVersion}, nil
+		}
+		return schemaTarget{dir: "postgresql/v96/temporal/versioned", version: postgresqlschema.Version}, nil
+	default:
+		return schemaTarget{}, fmt.Errorf("schema migration is not supported for SQL plugin %q", pluginName)
+	}
+}
+
+func updateSchema(
+	db commonschema.DB,
+	target schemaTarget,
+	dryRun bool,
+	logger log.Logger,
+) error {
+	if !dryRun {
+		// creates the version tables of a new database, existing versions are left untouched
+		if err := commonschema.SetupWithConfig(db, &commonschema.SetupConfig{
+			InitialVersion: initialVersion,
+		}, logger); err != nil {
+			return err
+		}
+	}
+	return commonschema.UpdateWithConfig(db, &commonschema.UpdateConfig{
+		TargetVersion: target.version,
+		SchemaDir:     target.dir,
+		SchemaFS:      schema.Assets,
+		IsPlanOnly:    dryRun,
+	}, logger)
+}
+
+// migrateElasticsearch puts the visibility index template and creates the visibility index if it doesn't exist.
+// Every dedicated namespace index gets its own copy of the template, which matches only that index, and is created too.
+// Elasticsearch has no version table, reindexing between index versions is still done by the versioned scripts.
+func migrateElasticsearch(cfg *esclient.Config, dryRun bool, logger log.Logger) error {
+	if cfg == nil {
+		return fmt.Errorf("missing Elasticsearch config")
+	}
+
+	// same client as the server, so AWS request signing applies to the migration too
+	httpClient, err := esclient.NewAwsHttpClient(cfg.AWSRequestSigning)
+	if err != nil {
+		return fmt.Errorf("unable to create AWS HTTP client for Elasticsearch: %w", err)
+	}
+	esClient, err := esclient.NewClient(cfg, httpClient, logger)
+	if err != nil {
+		return fmt.Errorf("unable to create Elasticsearch client: %w", err)
+	}
+	// the clients of all supported versions implement the index operations
+	client, ok := esClient.(esclient.IntegrationTestsClient)
+	if !ok {
+		return fmt.Errorf("unable to migrate Elasticsearch with client %T: index operations are not supported", esClient)
+	}
+	return updateElasticsearch(context.Background(), client, cfg, dryRun, logger)
+}
+
+func updateElasticsearch(
+	ctx context.Context,
+	client esclient.IntegrationTestsClient,
+	cfg *esclient.Config,
+	dryRun bool,
+	logger log.Logger,
+) error {
+	version := cfg.Version
+	if version == "" {
+		version = "v7"
+	}
+	template, err := fs.ReadFile(schema.Assets, fmt.Sprintf(schema.ElasticsearchIndexTemplatePath, version))
+	if err != nil {
+		return err
+	}
+
+	if err := updateElasticsearchIndex(ctx, client, esVisibilityTemplateName, string(template), cfg.GetVisibilityIndex(), dryRun, logger); err != nil {
+		return err
+	}
+	for _, index := range cfg.GetNamespaceVisibilityIndexNames() {
+		namespaceTemplate, err := namespaceIndexTemplate(template, index)
+		if err != nil {
+			return err
+		}
+		if err := updateElasticsearchIndex(ctx, client, index+esNamespaceTemplateNameSuffix, namespaceTemplate, index, dryRun, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateElasticsearchIndex(
+	ctx context.Context,
+	client esclient.IntegrationTestsClient,
+	templateName string,
+	template string,
+	index string,
+	dryRun bool,
+	logger log.Logger,
+) error {
+	exists, err := client.IndexExists(ctx, index)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		logger.Info(fmt.Sprintf("Pending Elasticsearch update: put index template %v, create index %v: %v",
+			templateName, index, !exists))
+		return nil
+	}
+
+	if _, err := client.IndexPutTemplate(ctx, templateName, template); err != nil {
+		return err
+	}
+	if !exists {
+		if _, err := client.CreateIndex(ctx, index); err != nil {
+			return err
+		}
+	}
+	logger.Info(fmt.Sprintf("Elasticsearch index template %v and index %v are up to date", templateName, index))
+	return nil
+}
+
+// namespaceIndexTemplate returns the visibility index template with index patterns replaced by the dedicated index name.
+func namespaceIndexTemplate(template []byte, index string) (string, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal(template, &body); err != nil {
+		return "", fmt.Errorf("unable to parse Elasticsearch index template: %w", err)
+	}
+	body["index_patterns"] = []string{index}
+	body["order"] = esNamespaceTemplateOrder
+	result, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
@@ -0,0 +1,143 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	mysqlplugin "go.temporal.io/server/common/persistence/sql/sqlplugin/mysql"
+	postgresqlplugin "go.temporal.io/server/common/persistence/sql/sqlplugin/postgresql"
+	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
+	cassandraschema "go.temporal.io/server/schema/cassandra"
+	mysqlschema "go.temporal.io/server/schema/mysql"
+	postgresqlschema "go.temporal.io/server/schema/postgresql"
+)
+
+type (
+	migrateSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller *gomock.Controller
+		esClient   *esclient.MockIntegrationTestsClient
+		esConfig   *esclient.Config
+	}
+)
+
+func TestMigrateSuite(t *testing.T) {
+	suite.Run(t, new(migrateSuite))
+}
+
+func (s *migrateSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.esClient = esclient.NewMockIntegrationTestsClient(s.controller)
+	s.esConfig = &esclient.Config{
+		Indices: map[string]string{
+			esclient.VisibilityAppName: "temporal_visibility_v1_test",
+		},
+	}
+}
+
+func (s *migrateSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *migrateSuite) TestTargets() {
+	s.Equal(schemaTarget{dir: "cassandra/temporal/versioned", version: cassandraschema.Version}, cassandraTarget(false))
+	s.Equal(schemaTarget{dir: "cassandra/visibility/versioned", version: cassandraschema.VisibilityVersion}, cassandraTarget(true))
+
+	target, err := sqlTarget(mysqlplugin.PluginName, false)
+	s.NoError(err)
+	s.Equal(schemaTarget{dir: "mysql/v57/temporal/versioned", version: mysqlschema.Version}, target)
+	target, err = sqlTarget(postgresqlplugin.PluginName, true)
+	s.NoError(err)
+	s.Equal(schemaTarget{dir: "postgresql/v96/visibility/versioned", version: postgresqlschema.VisibilityVersion}, target)
+
+	_, err = sqlTarget("sqlite", false)
+	s.Error(err)
+}
+
+func (s *migrateSuite) TestMigrate_MissingDataStore() {
+	err := Migrate(&config.Persistence{DefaultStore: "default"}, false, log.NewNoopLogger())
+	s.Error(err)
+}
+
+func (s *migrateSuite) TestUpdateElasticsearch_CreatesMissingIndex() {
+	s.esClient.EXPECT().IndexExists(gomock.Any(), "temporal_visibility_v1_test").Return(false, nil)
+	s.esClient.EXPECT().IndexPutTemplate(gomock.Any(), esVisibilityTemplateName, gomock.Any()).Return(true, nil)
+	s.esClient.EXPECT().CreateIndex(gomock.Any(), "temporal_visibility_v1_test").Return(true, nil)
+
+	s.NoError(updateElasticsearch(context.Background(), s.esClient, s.esConfig, false, log.NewNoopLogger()))
+}
+
+func (s *migrateSuite) TestUpdateElasticsearch_ExistingIndex() {
+	s.esClient.EXPECT().IndexExists(gomock.Any(), "temporal_visibility_v1_test").Return(true, nil)
+	s.esClient.EXPECT().IndexPutTemplate(gomock.Any(), esVisibilityTemplateName, gomock.Any()).Return(true, nil)
+
+	s.NoError(updateElasticsearch(context.Background(), s.esClient, s.esConfig, false, log.NewNoopLogger()))
+}
+
+func (s *migrateSuite) TestUpdateElasticsearch_NamespaceIndices() {
+	s.esConfig.NamespaceIndices = map[string]string{
+		"namespace-1": "temporal_visibility_v1_namespace",
+		"namespace-2": "temporal_visibility_v1_namespace",
+	}
+	s.esClient.EXPECT().IndexExists(gomock.Any(), "temporal_visibility_v1_test").Return(true, nil)
+	s.esClient.EXPECT().IndexPutTemplate(gomock.Any(), esVisibilityTemplateName, gomock.Any()).Return(true, nil)
+	s.esClient.EXPECT().IndexExists(gomock.Any(), "temporal_visibility_v1_namespace").Return(false, nil)
+	s.esClient.EXPECT().IndexPutTemplate(gomock.Any(), "temporal_visibility_v1_namespace_template", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, bodyString string) (bool, error) {
+			var body map[string]interface{}
+			s.NoError(json.Unmarshal([]byte(bodyString), &body))
+			s.Equal([]interface{}{"temporal_visibility_v1_namespace"}, body["index_patterns"])
+			s.EqualValues(esNamespaceTemplateOrder, body["order"])
+			s.Contains(body, "mappings")
+			return true, nil
+		})
+	s.esClient.EXPECT().CreateIndex(gomock.Any(), "temporal_visibility_v1_namespace").Return(true, nil)
+
+	s.NoError(updateElasticsearch(context.Background(), s.esClient, s.esConfig, false, log.NewNoopLogger()))
+}
+
+func (s *migrateSuite) TestUpdateElasticsearch_DryRun() {
+	s.esClient.EXPECT().IndexExists(gomock.Any(), "temporal_visibility_v1_test").Return(false, nil)
+
+	s.NoError(updateElasticsearch(context.Background(), s.esClient, s.esConfig, true, log.NewNoopLogger()))
+}
+
+func (s *migrateSuite) TestUpdateElasticsearch_UnsupportedVersion() {
+	s.esConfig.Version = "v5"
+
+	s.Error(updateElasticsearch(context.Background(), s.esClient, s.esConfig, false, log.NewNoopLogger()))
+}